@@ -8,12 +8,14 @@ import (
 	"crypto/rand"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 
 	base58 "github.com/jbenet/go-base58"
 	"github.com/minio/cli"
 	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/pkg/auth"
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -31,8 +33,9 @@ import (
 type GatewayFlags struct {
 	NonInteractive bool `help:"disable interactive mode" default:"false" setup:"true"`
 
-	Server miniogw.ServerConfig
-	Minio  miniogw.MinioConfig
+	Server      miniogw.ServerConfig
+	Minio       miniogw.MinioConfig
+	MultiTenant miniogw.MultiTenantConfig
 
 	uplink.Config
 }
@@ -57,9 +60,32 @@ var (
 		Short: "Run the S3 gateway",
 		RunE:  cmdRun,
 	}
+	credentialsCmd = &cobra.Command{
+		Use:   "credentials",
+		Short: "Manage the access grants of a multi-tenant gateway",
+	}
+	credentialsAddCmd = &cobra.Command{
+		Use:   "add",
+		Short: "Store the configured access grant under a new access key",
+		Args:  cobra.NoArgs,
+		RunE:  cmdCredentialsAdd,
+	}
+	credentialsListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the access keys of the stored access grants",
+		Args:  cobra.NoArgs,
+		RunE:  cmdCredentialsList,
+	}
+	credentialsRemoveCmd = &cobra.Command{
+		Use:   "remove [access key]",
+		Short: "Remove the access grant of an access key",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdCredentialsRemove,
+	}
 
-	setupCfg GatewayFlags
-	runCfg   GatewayFlags
+	setupCfg       GatewayFlags
+	runCfg         GatewayFlags
+	credentialsCfg GatewayFlags
 
 	confDir     string
 	identityDir string
@@ -74,8 +100,15 @@ func init() {
 
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(setupCmd)
+	rootCmd.AddCommand(credentialsCmd)
+	credentialsCmd.AddCommand(credentialsAddCmd)
+	credentialsCmd.AddCommand(credentialsListCmd)
+	credentialsCmd.AddCommand(credentialsRemoveCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
+	process.Bind(credentialsAddCmd, &credentialsCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(credentialsListCmd, &credentialsCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(credentialsRemoveCmd, &credentialsCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 }
 
 func cmdSetup(cmd *cobra.Command, args []string) (err error) {
@@ -132,8 +165,12 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 
 	fmt.Printf("Starting Storj S3-compatible gateway!\n\n")
 	fmt.Printf("Endpoint: %s\n", address)
-	fmt.Printf("Access key: %s\n", runCfg.Minio.AccessKey)
-	fmt.Printf("Secret key: %s\n", runCfg.Minio.SecretKey)
+	if runCfg.MultiTenant.Enabled {
+		fmt.Printf("Credentials: %s\n", runCfg.MultiTenant.Credentials)
+	} else {
+		fmt.Printf("Access key: %s\n", runCfg.Minio.AccessKey)
+		fmt.Printf("Secret key: %s\n", runCfg.Minio.SecretKey)
+	}
 
 	ctx := process.Ctx(cmd)
 
@@ -141,6 +178,10 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		zap.S().Error("Failed to initialize telemetry batcher: ", err)
 	}
 
+	if runCfg.MultiTenant.Enabled {
+		return runCfg.Run(ctx)
+	}
+
 	err = checkCfg(ctx)
 	if err != nil {
 		return fmt.Errorf("Failed to contact Satellite.\n"+
@@ -150,6 +191,87 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 	return runCfg.Run(ctx)
 }
 
+func cmdCredentialsAdd(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	creds, err := miniogw.OpenCredentials(credentialsCfg.MultiTenant.Credentials)
+	if err != nil {
+		return err
+	}
+
+	encKey, err := uplink.UseOrLoadEncryptionKey(credentialsCfg.Enc.EncryptionKey, credentialsCfg.Enc.KeyFilepath)
+	if err != nil {
+		return err
+	}
+
+	access := miniogw.Access{
+		SatelliteAddr: credentialsCfg.Client.SatelliteAddr,
+		APIKey:        credentialsCfg.Client.APIKey,
+		EncryptionKey: base58.Encode(encKey[:]),
+	}
+
+	// check the access grant before handing out keys for it
+	project, _, err := credentialsCfg.openAccess(ctx, access)
+	if err != nil {
+		return err
+	}
+	_, err = project.ListBuckets(ctx, &storj.BucketListOptions{Direction: storj.After})
+	err = errs.Combine(err, project.Close())
+	if err != nil {
+		return fmt.Errorf("Failed to contact Satellite.\n"+
+			"Perhaps your configuration is invalid?\n%s", err)
+	}
+
+	accessKey, err := generateKey()
+	if err != nil {
+		return err
+	}
+	access.SecretKey, err = generateKey()
+	if err != nil {
+		return err
+	}
+
+	if err := creds.Put(accessKey, access); err != nil {
+		return err
+	}
+
+	fmt.Printf("Access key: %s\n", accessKey)
+	fmt.Printf("Secret key: %s\n", access.SecretKey)
+	return nil
+}
+
+func cmdCredentialsList(cmd *cobra.Command, args []string) (err error) {
+	creds, err := miniogw.OpenCredentials(credentialsCfg.MultiTenant.Credentials)
+	if err != nil {
+		return err
+	}
+
+	accessKeys, err := creds.List()
+	if err != nil {
+		return err
+	}
+
+	for _, accessKey := range accessKeys {
+		access, ok, err := creds.Lookup(accessKey)
+		if err != nil {
+			return err
+		}
+		if ok {
+			fmt.Printf("%s\t%s\n", accessKey, access.SatelliteAddr)
+		}
+	}
+	return nil
+}
+
+func cmdCredentialsRemove(cmd *cobra.Command, args []string) (err error) {
+	creds, err := miniogw.OpenCredentials(credentialsCfg.MultiTenant.Credentials)
+	if err != nil {
+		return err
+	}
+
+	return creds.Delete(args[0])
+}
+
 func generateKey() (key string, err error) {
 	var buf [20]byte
 	_, err = rand.Read(buf[:])
@@ -172,11 +294,37 @@ func checkCfg(ctx context.Context) (err error) {
 
 // Run starts a Minio Gateway given proper config
 func (flags GatewayFlags) Run(ctx context.Context) (err error) {
+	var tenants *miniogw.Tenants
+	address, accessKey, secretKey := flags.Server.Address, flags.Minio.AccessKey, flags.Minio.SecretKey
+	if flags.MultiTenant.Enabled {
+		// minio only serves the requests forwarded by the gateway, signed
+		// with credentials which are never handed out
+		address = flags.MultiTenant.MinioAddress
+		accessKey, err = generateKey()
+		if err != nil {
+			return err
+		}
+		secretKey, err = generateKey()
+		if err != nil {
+			return err
+		}
+
+		tenants, err = flags.newTenants(auth.Credentials{AccessKey: accessKey, SecretKey: secretKey})
+		if err != nil {
+			return err
+		}
+
+		server := &http.Server{Addr: flags.Server.Address, Handler: tenants.Handler(address)}
+		go func() {
+			zap.S().Fatal(server.ListenAndServe())
+		}()
+	}
+
 	err = minio.RegisterGatewayCommand(cli.Command{
 		Name:  "storj",
 		Usage: "Storj",
 		Action: func(cliCtx *cli.Context) error {
-			return flags.action(ctx, cliCtx)
+			return flags.action(ctx, cliCtx, tenants)
 		},
 		HideHelpCommand: true,
 	})
@@ -185,24 +333,35 @@ func (flags GatewayFlags) Run(ctx context.Context) (err error) {
 	}
 
 	// TODO(jt): Surely there is a better way. This is so upsetting
-	err = os.Setenv("MINIO_ACCESS_KEY", flags.Minio.AccessKey)
+	err = os.Setenv("MINIO_ACCESS_KEY", accessKey)
 	if err != nil {
 		return err
 	}
-	err = os.Setenv("MINIO_SECRET_KEY", flags.Minio.SecretKey)
+	err = os.Setenv("MINIO_SECRET_KEY", secretKey)
 	if err != nil {
 		return err
 	}
 
 	minio.Main([]string{"storj", "gateway", "storj",
-		"--address", flags.Server.Address, "--config-dir", flags.Minio.Dir, "--quiet"})
+		"--address", address, "--config-dir", flags.Minio.Dir, "--quiet"})
 	return errs.New("unexpected minio exit")
 }
 
-func (flags GatewayFlags) action(ctx context.Context, cliCtx *cli.Context) (err error) {
-	gw, err := flags.NewGateway(ctx)
-	if err != nil {
-		return err
+func (flags GatewayFlags) action(ctx context.Context, cliCtx *cli.Context, tenants *miniogw.Tenants) (err error) {
+	var gw minio.Gateway
+	if tenants != nil {
+		gw = miniogw.NewMultiTenantGateway(
+			tenants,
+			storj.Cipher(flags.Enc.PathType).ToCipherSuite(),
+			flags.GetEncryptionScheme().ToEncryptionParameters(),
+			flags.GetRedundancyScheme(),
+			flags.Client.SegmentSize,
+		)
+	} else {
+		gw, err = flags.NewGateway(ctx)
+		if err != nil {
+			return err
+		}
 	}
 
 	minio.StartGateway(cliCtx, miniogw.Logging(gw, zap.L()))
@@ -231,7 +390,48 @@ func (flags GatewayFlags) NewGateway(ctx context.Context) (gw minio.Gateway, err
 	), nil
 }
 
-func (flags GatewayFlags) openProject(ctx context.Context) (*libuplink.Project, error) {
+// newTenants creates the tenants of a multi-tenant gateway from the
+// credentials store
+func (flags GatewayFlags) newTenants(internal auth.Credentials) (*miniogw.Tenants, error) {
+	creds, err := miniogw.OpenCredentials(flags.MultiTenant.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	return miniogw.NewTenants(zap.L(), creds, flags.openAccess, internal), nil
+}
+
+// openAccess opens the project of an access grant from the credentials store
+func (flags GatewayFlags) openAccess(ctx context.Context, access miniogw.Access) (*libuplink.Project, *storj.Key, error) {
+	apiKey, err := libuplink.ParseAPIKey(access.APIKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rawKey := base58.Decode(access.EncryptionKey)
+	if len(rawKey) != storj.KeySize {
+		return nil, nil, Error.New("invalid encryption key")
+	}
+	var encKey storj.Key
+	copy(encKey[:], rawKey)
+
+	var opts libuplink.ProjectOptions
+	opts.Volatile.EncryptionKey = &encKey
+
+	uplk, err := libuplink.NewUplink(ctx, flags.uplinkConfig())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	project, err := uplk.OpenProject(ctx, access.SatelliteAddr, apiKey, &opts)
+	if err != nil {
+		return nil, nil, errs.Combine(err, uplk.Close())
+	}
+	return project, &encKey, nil
+}
+
+// uplinkConfig returns the libuplink configuration of the gateway
+func (flags GatewayFlags) uplinkConfig() *libuplink.Config {
 	cfg := libuplink.Config{}
 	cfg.Volatile.TLS = struct {
 		SkipPeerCAWhitelist bool
@@ -242,7 +442,12 @@ func (flags GatewayFlags) openProject(ctx context.Context) (*libuplink.Project,
 	}
 	cfg.Volatile.MaxInlineSize = flags.Client.MaxInlineSize
 	cfg.Volatile.MaxMemory = flags.RS.MaxBufferMem
+	cfg.Volatile.SegmentParallelism = flags.Client.SegmentParallelism
+	cfg.Volatile.MaxSegmentMemory = flags.Client.MaxSegmentBufferMem
+	return &cfg
+}
 
+func (flags GatewayFlags) openProject(ctx context.Context) (*libuplink.Project, error) {
 	apiKey, err := libuplink.ParseAPIKey(flags.Client.APIKey)
 	if err != nil {
 		return nil, err
@@ -256,7 +461,7 @@ func (flags GatewayFlags) openProject(ctx context.Context) (*libuplink.Project,
 	var opts libuplink.ProjectOptions
 	opts.Volatile.EncryptionKey = encKey
 
-	uplk, err := libuplink.NewUplink(ctx, &cfg)
+	uplk, err := libuplink.NewUplink(ctx, flags.uplinkConfig())
 	if err != nil {
 		return nil, err
 	}
@@ -76,6 +76,11 @@ var (
 		Short: "list segments in irreparable database",
 		RunE:  getSegments,
 	}
+	repairQueueCmd = &cobra.Command{
+		Use:   "repairqueue",
+		Short: "show repair queue depth by number of healthy pieces",
+		RunE:  getQueueDepth,
+	}
 	countNodeCmd = &cobra.Command{
 		Use:   "count",
 		Short: "count nodes in kademlia and overlay",
@@ -135,6 +140,36 @@ var (
 		Args:  cobra.MinimumNArgs(1),
 		RunE:  CreateCSVStats,
 	}
+	getNodeStatusCmd = &cobra.Command{
+		Use:   "getstatus <node_id>",
+		Short: "Get node disqualification and suspension status",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  GetNodeStatus,
+	}
+	disqualifyNodeCmd = &cobra.Command{
+		Use:   "disqualify <node_id> <reason>",
+		Short: "Disqualify node",
+		Args:  cobra.MinimumNArgs(2),
+		RunE:  SetNodeStatus(pb.SetNodeStatusRequest_DISQUALIFY),
+	}
+	suspendNodeCmd = &cobra.Command{
+		Use:   "suspend <node_id> <reason>",
+		Short: "Suspend node",
+		Args:  cobra.MinimumNArgs(2),
+		RunE:  SetNodeStatus(pb.SetNodeStatusRequest_SUSPEND),
+	}
+	unsuspendNodeCmd = &cobra.Command{
+		Use:   "unsuspend <node_id>",
+		Short: "Lift node suspension",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  SetNodeStatus(pb.SetNodeStatusRequest_UNSUSPEND),
+	}
+	reinstateNodeCmd = &cobra.Command{
+		Use:   "reinstate <node_id>",
+		Short: "Clear node disqualification and suspension",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  SetNodeStatus(pb.SetNodeStatusRequest_REINSTATE),
+	}
 	objectHealthCmd = &cobra.Command{
 		Use:   "object <project-id> <bucket> <encrypted-path>",
 		Short: "Get stats about an object's health",
@@ -155,6 +190,7 @@ type Inspector struct {
 	kadclient     pb.KadInspectorClient
 	overlayclient pb.OverlayInspectorClient
 	irrdbclient   pb.IrreparableInspectorClient
+	repairclient  pb.RepairQueueInspectorClient
 	healthclient  pb.HealthInspectorClient
 }
 
@@ -181,6 +217,7 @@ func NewInspector(address, path string) (*Inspector, error) {
 		kadclient:     pb.NewKadInspectorClient(conn),
 		overlayclient: pb.NewOverlayInspectorClient(conn),
 		irrdbclient:   pb.NewIrreparableInspectorClient(conn),
+		repairclient:  pb.NewRepairQueueInspectorClient(conn),
 		healthclient:  pb.NewHealthInspectorClient(conn),
 	}, nil
 }
@@ -354,6 +391,8 @@ func GetStats(cmd *cobra.Command, args []string) (err error) {
 	fmt.Printf("Stats for ID %s:\n", nodeID)
 	fmt.Printf("AuditSuccessRatio: %f, AuditCount: %d, UptimeRatio: %f, UptimeCount: %d,\n",
 		res.AuditRatio, res.AuditCount, res.UptimeRatio, res.UptimeCount)
+	fmt.Printf("UploadSuccessRatio: %f, UploadLongTailRatio: %f, UploadCount: %d, UploadThroughput: %.0f B/s, Latency90: %d ms\n",
+		res.UploadSuccessRatio, res.UploadLongTailRatio, res.UploadCount, res.UploadThroughput, res.Latency_90)
 	return nil
 }
 
@@ -390,10 +429,71 @@ func GetCSVStats(cmd *cobra.Command, args []string) (err error) {
 		fmt.Printf("Stats for ID %s:\n", nodeID)
 		fmt.Printf("AuditSuccessRatio: %f, AuditCount: %d, UptimeRatio: %f, UptimeCount: %d,\n",
 			res.AuditRatio, res.AuditCount, res.UptimeRatio, res.UptimeCount)
+		fmt.Printf("UploadSuccessRatio: %f, UploadLongTailRatio: %f, UploadCount: %d, UploadThroughput: %.0f B/s, Latency90: %d ms\n",
+			res.UploadSuccessRatio, res.UploadLongTailRatio, res.UploadCount, res.UploadThroughput, res.Latency_90)
 	}
 	return nil
 }
 
+// GetNodeStatus gets a node's disqualification and suspension status from overlay
+func GetNodeStatus(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr, *IdentityPath)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	nodeID, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return err
+	}
+
+	res, err := i.overlayclient.GetNodeStatus(context.Background(), &pb.GetNodeStatusRequest{
+		NodeId: nodeID,
+	})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	fmt.Printf("Status for ID %s:\n", nodeID)
+	fmt.Printf("Disqualified: %t, DisqualifiedAt: %v, Reason: %q\n",
+		res.Disqualified, res.DisqualifiedAt, res.DisqualificationReason)
+	fmt.Printf("Suspended: %t, SuspendedAt: %v, Reason: %q\n",
+		res.Suspended, res.SuspendedAt, res.SuspensionReason)
+	return nil
+}
+
+// SetNodeStatus returns a command that applies action to a node's status in overlay
+func SetNodeStatus(action pb.SetNodeStatusRequest_Action) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		i, err := NewInspector(*Addr, *IdentityPath)
+		if err != nil {
+			return ErrInspectorDial.Wrap(err)
+		}
+
+		nodeID, err := storj.NodeIDFromString(args[0])
+		if err != nil {
+			return err
+		}
+
+		var reason string
+		if len(args) > 1 {
+			reason = strings.Join(args[1:], " ")
+		}
+
+		_, err = i.overlayclient.SetNodeStatus(context.Background(), &pb.SetNodeStatusRequest{
+			NodeId: nodeID,
+			Action: action,
+			Reason: reason,
+		})
+		if err != nil {
+			return ErrRequest.Wrap(err)
+		}
+
+		fmt.Printf("Applied %s to node %s\n", action, nodeID)
+		return nil
+	}
+}
+
 // CreateStats creates a node with stats in overlay
 func CreateStats(cmd *cobra.Command, args []string) (err error) {
 	i, err := NewInspector(*Addr, *IdentityPath)
@@ -733,6 +833,24 @@ func getSegments(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func getQueueDepth(cmd *cobra.Command, args []string) error {
+	i, err := NewInspector(*Addr, *IdentityPath)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	res, err := i.repairclient.QueueDepth(context.Background(), &pb.QueueDepthRequest{})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	fmt.Printf("%-16s %-10s %-10s\n", "Healthy Pieces", "Queued", "Leased")
+	for _, bucket := range res.Buckets {
+		fmt.Printf("%-16d %-10d %-10d\n", bucket.NumHealthyPieces, bucket.Count, bucket.Leased)
+	}
+	return nil
+}
+
 // sortSegments by the object they belong to
 func sortSegments(segments []*pb.IrreparableSegment) map[string][]*pb.IrreparableSegment {
 	objects := make(map[string][]*pb.IrreparableSegment)
@@ -751,6 +869,7 @@ func init() {
 	rootCmd.AddCommand(kadCmd)
 	rootCmd.AddCommand(statsCmd)
 	rootCmd.AddCommand(irreparableCmd)
+	rootCmd.AddCommand(repairQueueCmd)
 	rootCmd.AddCommand(healthCmd)
 
 	kadCmd.AddCommand(countNodeCmd)
@@ -764,6 +883,11 @@ func init() {
 	statsCmd.AddCommand(getCSVStatsCmd)
 	statsCmd.AddCommand(createStatsCmd)
 	statsCmd.AddCommand(createCSVStatsCmd)
+	statsCmd.AddCommand(getNodeStatusCmd)
+	statsCmd.AddCommand(disqualifyNodeCmd)
+	statsCmd.AddCommand(suspendNodeCmd)
+	statsCmd.AddCommand(unsuspendNodeCmd)
+	statsCmd.AddCommand(reinstateNodeCmd)
 
 	healthCmd.AddCommand(objectHealthCmd)
 	healthCmd.AddCommand(segmentHealthCmd)
@@ -0,0 +1,93 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/satellite/billing"
+	"storj.io/storj/satellite/billing/simulate"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+// generateInvoices creates and stores invoices for all projects in the given month
+func generateInvoices(ctx context.Context, period time.Time) (err error) {
+	db, err := satellitedb.New(zap.L().Named("db"), generateInvoicesCfg.Database)
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	service := billing.NewService(zap.L().Named("billing"), generateInvoicesCfg.Billing, db.Billing(), db.Console().Projects(), db.Console().UsageRollups(), nil)
+	invoices, err := service.GenerateInvoices(ctx, period)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Generated %d invoices for %s\n", len(invoices), period.Format("2006-01"))
+	return nil
+}
+
+// chargeInvoices charges the unpaid invoices of the given month through the configured payment provider
+func chargeInvoices(ctx context.Context, period time.Time) (err error) {
+	provider, err := newPaymentProvider(chargeInvoicesCfg.Provider)
+	if err != nil {
+		return err
+	}
+
+	db, err := satellitedb.New(zap.L().Named("db"), chargeInvoicesCfg.Database)
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	service := billing.NewService(zap.L().Named("billing"), billing.Config{}, db.Billing(), db.Console().Projects(), db.Console().UsageRollups(), provider)
+	if err := service.ChargeInvoices(ctx, period); err != nil {
+		return err
+	}
+
+	fmt.Printf("Charged invoices for %s\n", period.Format("2006-01"))
+	return nil
+}
+
+// newPaymentProvider returns the payment provider with the given name
+func newPaymentProvider(name string) (billing.PaymentProvider, error) {
+	switch name {
+	case "simulate":
+		return simulate.NewProvider(), nil
+	case "":
+		return nil, errs.New("no payment provider configured")
+	default:
+		return nil, errs.New("unknown payment provider %q", name)
+	}
+}
+
+// createCoupon creates a new coupon and prints its code
+func createCoupon(ctx context.Context, amount int64, durationMonths int) (err error) {
+	db, err := satellitedb.New(zap.L().Named("db"), createCouponCfg.Database)
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	service := billing.NewService(zap.L().Named("billing"), billing.Config{}, db.Billing(), db.Console().Projects(), db.Console().UsageRollups(), nil)
+	coupon, err := service.CreateCoupon(ctx, amount, durationMonths)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(coupon.Code.String())
+	return nil
+}
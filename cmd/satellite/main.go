@@ -8,9 +8,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"text/tabwriter"
 	"time"
 
+	"github.com/skyrings/skyring-common/tools/uuid"
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -18,8 +20,12 @@ import (
 	"storj.io/storj/internal/fpath"
 	"storj.io/storj/internal/version"
 	"storj.io/storj/pkg/cfgstruct"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/process"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/billing"
+	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/satellitedb"
 )
 
@@ -30,6 +36,13 @@ type Satellite struct {
 	satellite.Config
 }
 
+// Repairer defines standalone repair worker configuration
+type Repairer struct {
+	Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"sqlite3://$CONFDIR/master.db"`
+
+	satellite.RepairerConfig
+}
+
 var (
 	rootCmd = &cobra.Command{
 		Use:   "satellite",
@@ -40,6 +53,11 @@ var (
 		Short: "Run the satellite",
 		RunE:  cmdRun,
 	}
+	repairCmd = &cobra.Command{
+		Use:   "repair",
+		Short: "Run a standalone repair worker",
+		RunE:  cmdRepair,
+	}
 	setupCmd = &cobra.Command{
 		Use:         "setup",
 		Short:       "Create config files",
@@ -67,9 +85,51 @@ var (
 		Args:  cobra.MinimumNArgs(2),
 		RunE:  cmdNodeUsage,
 	}
+	generateStatementsCmd = &cobra.Command{
+		Use:   "generate-statements [month]",
+		Short: "Generate payment statements for all storage nodes for a given month",
+		Long:  "Generate payment statements for all storage nodes for a given month, replacing previously generated ones. Format the month using YYYY-MM",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdGenerateStatements,
+	}
+	exportStatementsCmd = &cobra.Command{
+		Use:   "export-statements [month]",
+		Short: "Export generated payment statements as JSON and CSV files per storage node",
+		Long:  "Export generated payment statements as JSON and CSV files per storage node. Format the month using YYYY-MM",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdExportStatements,
+	}
+	generateInvoicesCmd = &cobra.Command{
+		Use:   "generate-invoices [month]",
+		Short: "Generate invoices for all projects for a given month",
+		Long:  "Generate invoices for all projects for a given month, projects that already have an invoice are skipped. Format the month using YYYY-MM",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdGenerateInvoices,
+	}
+	chargeInvoicesCmd = &cobra.Command{
+		Use:   "charge-invoices [month]",
+		Short: "Charge the open and failed invoices of a given month",
+		Long:  "Charge the open and failed invoices of a given month through the configured payment provider. Format the month using YYYY-MM",
+		Args:  cobra.ExactArgs(1),
+		RunE:  cmdChargeInvoices,
+	}
+	createCouponCmd = &cobra.Command{
+		Use:   "create-coupon [amount] [months]",
+		Short: "Create a coupon crediting amount cents per month for a number of months",
+		Args:  cobra.ExactArgs(2),
+		RunE:  cmdCreateCoupon,
+	}
+	setBucketPlacementCmd = &cobra.Command{
+		Use:   "set-bucket-placement [project-id] [bucket] [policy]",
+		Short: "Restrict the nodes storing new and repaired segments of a bucket to a placement policy",
+		Long:  "Restrict the nodes storing new and repaired segments of a bucket to one of the placement policies configured with --overlay.placement.policies, an empty policy removes the restriction",
+		Args:  cobra.ExactArgs(3),
+		RunE:  cmdSetBucketPlacement,
+	}
 
-	runCfg   Satellite
-	setupCfg Satellite
+	runCfg    Satellite
+	repairCfg Repairer
+	setupCfg  Satellite
 
 	diagCfg struct {
 		Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"sqlite3://$CONFDIR/master.db"`
@@ -82,6 +142,29 @@ var (
 		Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"sqlite3://$CONFDIR/master.db"`
 		Output   string `help:"destination of report output" default:""`
 	}
+	generateStatementsCfg struct {
+		Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"sqlite3://$CONFDIR/master.db"`
+		Payments payments.Config
+	}
+	exportStatementsCfg struct {
+		Database  string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"sqlite3://$CONFDIR/master.db"`
+		OutputDir string `help:"directory to write the statements to" default:"$CONFDIR/statements"`
+	}
+	generateInvoicesCfg struct {
+		Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"sqlite3://$CONFDIR/master.db"`
+		Billing  billing.Config
+	}
+	chargeInvoicesCfg struct {
+		Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"sqlite3://$CONFDIR/master.db"`
+		Provider string `help:"payment provider used to charge invoices (simulate)" releaseDefault:"" devDefault:"simulate"`
+	}
+	createCouponCfg struct {
+		Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"sqlite3://$CONFDIR/master.db"`
+	}
+	setBucketPlacementCfg struct {
+		Database string `help:"satellite database connection string" releaseDefault:"postgres://" devDefault:"sqlite3://$CONFDIR/master.db"`
+		Overlay  overlay.Config
+	}
 	confDir     string
 	identityDir string
 )
@@ -93,16 +176,30 @@ func init() {
 	cfgstruct.SetupFlag(zap.L(), rootCmd, &identityDir, "identity-dir", defaultIdentityDir, "main directory for satellite identity credentials")
 	defaults := cfgstruct.DefaultsFlag(rootCmd)
 	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(repairCmd)
 	rootCmd.AddCommand(setupCmd)
 	rootCmd.AddCommand(diagCmd)
 	rootCmd.AddCommand(qdiagCmd)
 	rootCmd.AddCommand(reportsCmd)
 	reportsCmd.AddCommand(nodeUsageCmd)
+	reportsCmd.AddCommand(generateStatementsCmd)
+	reportsCmd.AddCommand(exportStatementsCmd)
+	reportsCmd.AddCommand(generateInvoicesCmd)
+	rootCmd.AddCommand(chargeInvoicesCmd)
+	rootCmd.AddCommand(createCouponCmd)
+	rootCmd.AddCommand(setBucketPlacementCmd)
 	process.Bind(runCmd, &runCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(repairCmd, &repairCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(setupCmd, &setupCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir), cfgstruct.SetupMode())
 	process.Bind(diagCmd, &diagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(qdiagCmd, &qdiagCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 	process.Bind(nodeUsageCmd, &nodeUsageCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(generateStatementsCmd, &generateStatementsCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(exportStatementsCmd, &exportStatementsCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(generateInvoicesCmd, &generateInvoicesCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(chargeInvoicesCmd, &chargeInvoicesCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(createCouponCmd, &createCouponCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
+	process.Bind(setBucketPlacementCmd, &setBucketPlacementCfg, defaults, cfgstruct.ConfDir(confDir), cfgstruct.IdentityDir(identityDir))
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
@@ -151,6 +248,54 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 	return errs.Combine(runError, closeError)
 }
 
+func cmdRepair(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+	log := zap.L()
+
+	identity, err := repairCfg.Identity.Load()
+	if err != nil {
+		zap.S().Fatal(err)
+	}
+
+	db, err := satellitedb.New(log.Named("db"), repairCfg.Database)
+	if err != nil {
+		return errs.New("Error connecting to master database on satellite: %+v", err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	pointerDB, err := metainfo.NewStore(log.Named("metainfo:store"), repairCfg.Metainfo.DatabaseURL)
+	if err != nil {
+		return errs.New("Error connecting to pointer database on satellite: %+v", err)
+	}
+
+	defer func() {
+		err = errs.Combine(err, pointerDB.Close())
+	}()
+
+	peer, err := satellite.NewRepairer(log, identity, db, pointerDB, &repairCfg.RepairerConfig, version.Build)
+	if err != nil {
+		return err
+	}
+
+	err = peer.Version.CheckVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := process.InitMetricsWithCertPath(ctx, nil, repairCfg.Identity.CertPath); err != nil {
+		zap.S().Error("Failed to initialize telemetry batcher: ", err)
+	}
+
+	// tables are created and migrated by the satellite, not by repair workers
+
+	runError := peer.Run(ctx)
+	closeError := peer.Close()
+	return errs.Combine(runError, closeError)
+}
+
 func cmdSetup(cmd *cobra.Command, args []string) (err error) {
 	setupDir, err := filepath.Abs(confDir)
 	if err != nil {
@@ -272,6 +417,76 @@ func cmdNodeUsage(cmd *cobra.Command, args []string) (err error) {
 	return generateCSV(ctx, start, end, file)
 }
 
+func cmdGenerateStatements(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	period, err := parsePeriod(args[0])
+	if err != nil {
+		return err
+	}
+
+	return generateStatements(ctx, period)
+}
+
+func cmdExportStatements(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	period, err := parsePeriod(args[0])
+	if err != nil {
+		return err
+	}
+
+	return exportStatements(ctx, period, exportStatementsCfg.OutputDir)
+}
+
+func cmdGenerateInvoices(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	period, err := parsePeriod(args[0])
+	if err != nil {
+		return err
+	}
+
+	return generateInvoices(ctx, period)
+}
+
+func cmdChargeInvoices(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	period, err := parsePeriod(args[0])
+	if err != nil {
+		return err
+	}
+
+	return chargeInvoices(ctx, period)
+}
+
+func cmdCreateCoupon(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	amount, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return errs.New("invalid amount %q", args[0])
+	}
+	months, err := strconv.Atoi(args[1])
+	if err != nil {
+		return errs.New("invalid number of months %q", args[1])
+	}
+
+	return createCoupon(ctx, amount, months)
+}
+
+func cmdSetBucketPlacement(cmd *cobra.Command, args []string) (err error) {
+	ctx := process.Ctx(cmd)
+
+	projectID, err := uuid.Parse(args[0])
+	if err != nil {
+		return errs.New("invalid project id %q", args[0])
+	}
+
+	return setBucketPlacement(ctx, *projectID, []byte(args[1]), args[2])
+}
+
 func main() {
 	process.Exec(rootCmd)
 }
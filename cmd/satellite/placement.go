@@ -0,0 +1,44 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+// setBucketPlacement sets the placement policy of a bucket after checking it is configured
+func setBucketPlacement(ctx context.Context, projectID uuid.UUID, bucketName []byte, policy string) (err error) {
+	placement, err := overlay.NewPlacement(setBucketPlacementCfg.Overlay.Placement)
+	if err != nil {
+		return err
+	}
+
+	db, err := satellitedb.New(zap.L().Named("db"), setBucketPlacementCfg.Database)
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	cache := overlay.NewCache(zap.L().Named("overlay"), db.OverlayCache(), setBucketPlacementCfg.Overlay.Node, placement)
+	if err := cache.SetBucketPlacement(ctx, projectID, bucketName, policy); err != nil {
+		return err
+	}
+
+	if policy == "" {
+		fmt.Printf("Removed the placement policy of bucket %q\n", bucketName)
+	} else {
+		fmt.Printf("Bucket %q is placed with policy %q\n", bucketName, policy)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/satellite/payments"
+	"storj.io/storj/satellite/satellitedb"
+)
+
+// parsePeriod parses a payout month formatted as YYYY-MM
+func parsePeriod(value string) (time.Time, error) {
+	period, err := time.Parse("2006-01", value)
+	if err != nil {
+		return time.Time{}, errs.New("Invalid month format. Please use YYYY-MM")
+	}
+	return period, nil
+}
+
+// generateStatements creates and stores payment statements for all nodes in the given month
+func generateStatements(ctx context.Context, period time.Time) (err error) {
+	db, err := satellitedb.New(zap.L().Named("db"), generateStatementsCfg.Database)
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	service := payments.NewService(zap.L().Named("payments"), generateStatementsCfg.Payments, db.StoragenodeAccounting(), db.Payments())
+	statements, err := service.GenerateStatements(ctx, period)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Generated %d payment statements for %s\n", len(statements), period.Format("2006-01"))
+	return nil
+}
+
+// exportStatements writes a JSON and a CSV statement for every node in the given month
+func exportStatements(ctx context.Context, period time.Time, outputDir string) (err error) {
+	db, err := satellitedb.New(zap.L().Named("db"), exportStatementsCfg.Database)
+	if err != nil {
+		return errs.New("error connecting to master database on satellite: %+v", err)
+	}
+	defer func() {
+		err = errs.Combine(err, db.Close())
+	}()
+
+	statements, err := db.Payments().GetStatements(ctx, period)
+	if err != nil {
+		return err
+	}
+	if len(statements) == 0 {
+		return errs.New("no statements for %s, generate them first", period.Format("2006-01"))
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		name := filepath.Join(outputDir, statement.NodeID.String()+"-"+period.Format("2006-01"))
+
+		err := writeStatementFile(name+".json", func(file *os.File) error {
+			return payments.WriteJSON(file, statement)
+		})
+		if err != nil {
+			return err
+		}
+
+		err = writeStatementFile(name+".csv", func(file *os.File) error {
+			return payments.WriteCSV(file, statement)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Exported %d payment statements to %s\n", len(statements), outputDir)
+	return nil
+}
+
+// writeStatementFile creates the file at path and writes a statement to it
+func writeStatementFile(path string, write func(file *os.File) error) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errs.Combine(err, file.Close())
+	}()
+
+	return write(file)
+}
@@ -0,0 +1,227 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/internal/memory"
+	libuplink "storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/backup"
+	"storj.io/storj/pkg/process"
+)
+
+var (
+	backupParallelism  *int
+	restoreParallelism *int
+	pruneDryRun        *bool
+	prunePolicy        backup.Policy
+)
+
+func init() {
+	backupCmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Stores and restores deduplicated snapshots of local directories",
+	}
+	RootCmd.AddCommand(backupCmd)
+
+	createCmd := addCmd(&cobra.Command{
+		Use:   "create",
+		Short: "Stores a snapshot of a local directory in a repository",
+		RunE:  backupCreateMain,
+	}, backupCmd)
+	backupParallelism = createCmd.Flags().Int("parallelism", 4, "number of chunks uploaded at the same time")
+
+	addCmd(&cobra.Command{
+		Use:   "list",
+		Short: "Lists the snapshots of a repository",
+		RunE:  backupListMain,
+	}, backupCmd)
+
+	restoreCmd := addCmd(&cobra.Command{
+		Use:   "restore",
+		Short: "Restores a snapshot into a local directory",
+		RunE:  backupRestoreMain,
+	}, backupCmd)
+	restoreParallelism = restoreCmd.Flags().Int("parallelism", 4, "number of files restored at the same time")
+
+	pruneCmd := addCmd(&cobra.Command{
+		Use:   "prune",
+		Short: "Removes the snapshots not kept by the retention policy and their unused chunks",
+		RunE:  backupPruneMain,
+	}, backupCmd)
+	pruneCmd.Flags().IntVar(&prunePolicy.Last, "keep-last", 0, "keep the newest snapshots")
+	pruneCmd.Flags().IntVar(&prunePolicy.Hourly, "keep-hourly", 0, "keep the newest snapshot of each of the last hours")
+	pruneCmd.Flags().IntVar(&prunePolicy.Daily, "keep-daily", 0, "keep the newest snapshot of each of the last days")
+	pruneCmd.Flags().IntVar(&prunePolicy.Weekly, "keep-weekly", 0, "keep the newest snapshot of each of the last weeks")
+	pruneCmd.Flags().IntVar(&prunePolicy.Monthly, "keep-monthly", 0, "keep the newest snapshot of each of the last months")
+	pruneCmd.Flags().IntVar(&prunePolicy.Yearly, "keep-yearly", 0, "keep the newest snapshot of each of the last years")
+	pruneCmd.Flags().DurationVar(&prunePolicy.Within, "keep-within", 0, "keep the snapshots taken within the duration")
+	pruneDryRun = pruneCmd.Flags().Bool("dry-run", false, "if true, only print what would be removed")
+}
+
+// openRepository opens the backup repository at the Storj URL arg. The
+// returned function closes the project and the bucket.
+func openRepository(ctx context.Context, arg string, parallelism int) (_ *backup.Repository, closeRepository func(), err error) {
+	remote, err := fpath.New(arg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if remote.IsLocal() {
+		return nil, nil, fmt.Errorf("Repository must be a Storj URL: %s", arg)
+	}
+	if remote.Bucket() == "" {
+		return nil, nil, fmt.Errorf("No bucket specified, use format sj://bucket/prefix")
+	}
+
+	prefix := remote.Path()
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	access, err := useOrLoadEncryptionAccess(cfg.Enc.EncryptionKey, cfg.Enc.KeyFilepath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	project, bucket, err := cfg.GetProjectAndBucket(ctx, remote.Bucket(), access)
+	if err != nil {
+		return nil, nil, convertError(err, remote)
+	}
+
+	var upload libuplink.UploadOptions
+	upload.Volatile.RedundancyScheme = cfg.GetRedundancyScheme()
+	upload.Volatile.EncryptionParameters = cfg.GetEncryptionScheme().ToEncryptionParameters()
+
+	repo := backup.NewRepository(bucket, prefix, backup.Config{
+		Parallelism: parallelism,
+		Upload:      upload,
+	})
+	return repo, func() { closeProjectAndBucket(project, bucket) }, nil
+}
+
+// backupCreateMain stores a snapshot of a local directory
+func backupCreateMain(cmd *cobra.Command, args []string) (err error) {
+	if len(args) != 2 {
+		return fmt.Errorf("Usage: uplink backup create <local-dir> sj://bucket/prefix")
+	}
+	ctx := process.Ctx(cmd)
+
+	repo, closeRepository, err := openRepository(ctx, args[1], *backupParallelism)
+	if err != nil {
+		return err
+	}
+	defer closeRepository()
+
+	snapshot, stats, err := repo.Backup(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created snapshot %s of %s: %d files (%s), %d of %d chunks new (%s)\n",
+		snapshot.ID, snapshot.Source, stats.Files, memory.Size(stats.Bytes),
+		stats.NewChunks, stats.Chunks, memory.Size(stats.NewBytes))
+	return nil
+}
+
+// backupListMain prints the snapshots of a repository
+func backupListMain(cmd *cobra.Command, args []string) (err error) {
+	if len(args) != 1 {
+		return fmt.Errorf("Usage: uplink backup list sj://bucket/prefix")
+	}
+	ctx := process.Ctx(cmd)
+
+	repo, closeRepository, err := openRepository(ctx, args[0], 1)
+	if err != nil {
+		return err
+	}
+	defer closeRepository()
+
+	snapshots, err := repo.Snapshots(ctx)
+	if err != nil {
+		return err
+	}
+	printSnapshots(snapshots)
+	return nil
+}
+
+// backupRestoreMain restores a snapshot into a local directory
+func backupRestoreMain(cmd *cobra.Command, args []string) (err error) {
+	if len(args) != 3 {
+		return fmt.Errorf("Usage: uplink backup restore sj://bucket/prefix <snapshot-id|latest> <local-dir>")
+	}
+	ctx := process.Ctx(cmd)
+
+	repo, closeRepository, err := openRepository(ctx, args[0], *restoreParallelism)
+	if err != nil {
+		return err
+	}
+	defer closeRepository()
+
+	snapshot, err := repo.Snapshot(ctx, args[1])
+	if err != nil {
+		return fmt.Errorf("Unable to load snapshot %s: %v", args[1], err)
+	}
+	if err := repo.Restore(ctx, snapshot, args[2]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Restored snapshot %s to %s: %d files (%s)\n", snapshot.ID, args[2], snapshot.SnapshotInfo.Files, memory.Size(snapshot.Size))
+	return nil
+}
+
+// backupPruneMain removes the snapshots not kept by the retention policy
+func backupPruneMain(cmd *cobra.Command, args []string) (err error) {
+	if len(args) != 1 {
+		return fmt.Errorf("Usage: uplink backup prune sj://bucket/prefix --keep-last 7")
+	}
+	if prunePolicy.Empty() {
+		return fmt.Errorf("No retention policy, use one of the --keep flags")
+	}
+	ctx := process.Ctx(cmd)
+
+	repo, closeRepository, err := openRepository(ctx, args[0], 1)
+	if err != nil {
+		return err
+	}
+	defer closeRepository()
+
+	stats, err := repo.Prune(ctx, prunePolicy, *pruneDryRun)
+	if err != nil {
+		return err
+	}
+
+	verb := "Removed"
+	if *pruneDryRun {
+		verb = "Would remove"
+	}
+	fmt.Printf("Keeping %d snapshots:\n", len(stats.Kept))
+	printSnapshots(stats.Kept)
+	if len(stats.Removed) > 0 {
+		fmt.Printf("%s %d snapshots:\n", verb, len(stats.Removed))
+		printSnapshots(stats.Removed)
+	}
+	fmt.Printf("%s %d unused chunks (%s)\n", verb, stats.Chunks, memory.Size(stats.Bytes))
+	return nil
+}
+
+// printSnapshots prints a table of snapshots
+func printSnapshots(snapshots []backup.SnapshotInfo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTIME\tHOST\tFILES\tSIZE\tSOURCE")
+	for _, snapshot := range snapshots {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n",
+			snapshot.ID, snapshot.Time.Format(time.RFC3339), snapshot.Host,
+			snapshot.Files, memory.Size(snapshot.Size), snapshot.Source)
+	}
+	_ = w.Flush()
+}
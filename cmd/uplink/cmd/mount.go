@@ -0,0 +1,117 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// +build linux
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/uplinkfs"
+)
+
+var (
+	mountCacheDir  *string
+	mountCacheSize = 512 * memory.MiB
+	mountBlockSize = 1 * memory.MiB
+	mountReadAhead *int
+	mountReadOnly  *bool
+)
+
+func init() {
+	mountCmd := addCmd(&cobra.Command{
+		Use:   "mount",
+		Short: "Mounts a bucket as a local directory",
+		RunE:  mountMain,
+	}, RootCmd)
+	mountCacheDir = mountCmd.Flags().String("cache-dir", filepath.Join(fpath.ApplicationDir("storj", "uplink"), "cache"), "directory for caching downloaded blocks and files being written")
+	mountCmd.Flags().Var(&mountCacheSize, "cache-size", "maximum size of the cached blocks")
+	mountCmd.Flags().Var(&mountBlockSize, "block-size", "size of the blocks objects are downloaded and cached in")
+	mountReadAhead = mountCmd.Flags().Int("read-ahead", 4, "number of blocks downloaded at once when a read misses the cache")
+	mountReadOnly = mountCmd.Flags().Bool("read-only", false, "if true, mount the bucket read-only")
+}
+
+// mountMain serves a bucket as a FUSE file system until it is unmounted
+// or the process is interrupted
+func mountMain(cmd *cobra.Command, args []string) (err error) {
+	if len(args) != 2 {
+		return fmt.Errorf("Usage: uplink mount sj://bucket /path/to/mountpoint")
+	}
+	ctx := process.Ctx(cmd)
+
+	src, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+	if src.IsLocal() {
+		src, err = fpath.New("sj://" + args[0])
+		if err != nil {
+			return err
+		}
+	}
+	if src.Bucket() == "" || src.Path() != "" {
+		return fmt.Errorf("Nested buckets not supported, use format sj://bucket")
+	}
+	mountpoint := args[1]
+
+	access, err := useOrLoadEncryptionAccess(cfg.Enc.EncryptionKey, cfg.Enc.KeyFilepath)
+	if err != nil {
+		return err
+	}
+
+	project, bucket, err := cfg.GetProjectAndBucket(ctx, src.Bucket(), access)
+	if err != nil {
+		return convertError(err, src)
+	}
+	defer closeProjectAndBucket(project, bucket)
+
+	cache, err := uplinkfs.NewCache(filepath.Join(*mountCacheDir, src.Bucket()), mountCacheSize)
+	if err != nil {
+		return err
+	}
+
+	options := []fuse.MountOption{
+		fuse.FSName("sj://" + src.Bucket()),
+		fuse.Subtype("uplink"),
+	}
+	if *mountReadOnly {
+		options = append(options, fuse.ReadOnly())
+	}
+
+	conn, err := fuse.Mount(mountpoint, options...)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, conn.Close()) }()
+
+	filesystem := uplinkfs.New(bucket, cache, uplinkfs.Config{
+		BlockSize: mountBlockSize,
+		ReadAhead: *mountReadAhead,
+	})
+
+	served := make(chan error, 1)
+	go func() { served <- fs.Serve(conn, filesystem) }()
+
+	fmt.Printf("Mounted %s at %s\n", src, mountpoint)
+
+	select {
+	case err = <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	if err := fuse.Unmount(mountpoint); err != nil {
+		return fmt.Errorf("unable to unmount %s: %v", mountpoint, err)
+	}
+	return <-served
+}
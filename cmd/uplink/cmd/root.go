@@ -82,6 +82,8 @@ func (c *UplinkFlags) GetProject(ctx context.Context) (*libuplink.Project, error
 
 	cfg.Volatile.MaxInlineSize = c.Client.MaxInlineSize
 	cfg.Volatile.MaxMemory = c.RS.MaxBufferMem
+	cfg.Volatile.SegmentParallelism = c.Client.SegmentParallelism
+	cfg.Volatile.MaxSegmentMemory = c.Client.MaxSegmentBufferMem
 
 	uplk, err := c.NewUplink(ctx, cfg)
 	if err != nil {
@@ -0,0 +1,567 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/internal/memory"
+	libuplink "storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storj"
+)
+
+// syncModifiedKey is the metadata key holding the modification time of the
+// local file an object was synced from
+const syncModifiedKey = "uplink:mtime"
+
+var (
+	syncParallelism *int
+	syncDelete      *bool
+	syncDryRun      *bool
+	syncChecksum    *bool
+)
+
+func init() {
+	syncCmd := addCmd(&cobra.Command{
+		Use:   "sync",
+		Short: "Synchronizes a local directory with a Storj prefix, or a Storj prefix with a local directory",
+		RunE:  syncMain,
+	}, RootCmd)
+	syncParallelism = syncCmd.Flags().Int("parallelism", 4, "number of files transferred at the same time")
+	syncDelete = syncCmd.Flags().Bool("delete", false, "if true, delete files in the destination which do not exist in the source")
+	syncDryRun = syncCmd.Flags().Bool("dry-run", false, "if true, only print what would be done")
+	syncChecksum = syncCmd.Flags().Bool("checksum", false, "if true, compare files by their SHA-256 checksum instead of their modification time")
+}
+
+// syncEntry is a file or object being synchronized
+type syncEntry struct {
+	size     int64
+	modified time.Time
+	// checksum is the SHA-256 checksum of the contents, which is computed
+	// from local when it is needed
+	checksum []byte
+	// local is the path of a local file
+	local string
+}
+
+// sum returns the SHA-256 checksum of the contents of entry
+func (entry *syncEntry) sum() ([]byte, error) {
+	if entry.checksum != nil || entry.local == "" {
+		return entry.checksum, nil
+	}
+
+	file, err := os.Open(entry.local)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, err
+	}
+	entry.checksum = hash.Sum(nil)
+	return entry.checksum, nil
+}
+
+// sameModified compares entries by their size and modification time
+func sameModified(source, destination *syncEntry) (bool, error) {
+	return source.size == destination.size && source.modified.Equal(destination.modified), nil
+}
+
+// sameChecksum compares entries by their size and checksum
+func sameChecksum(source, destination *syncEntry) (bool, error) {
+	if source.size != destination.size {
+		return false, nil
+	}
+
+	sourceSum, err := source.sum()
+	if err != nil {
+		return false, err
+	}
+	destinationSum, err := destination.sum()
+	if err != nil {
+		return false, err
+	}
+	return sourceSum != nil && bytes.Equal(sourceSum, destinationSum), nil
+}
+
+// syncPlan lists the paths which differ between the source and destination
+type syncPlan struct {
+	// transfer holds the paths to copy from the source to the destination
+	transfer []string
+	// extraneous holds the paths which only exist in the destination
+	extraneous []string
+	// unchanged is the number of paths which are the same on both sides
+	unchanged int
+}
+
+// planSync compares the entries of source and destination by their
+// relative paths
+func planSync(source, destination map[string]*syncEntry, same func(source, destination *syncEntry) (bool, error)) (plan syncPlan, err error) {
+	for relative, entry := range source {
+		existing, ok := destination[relative]
+		if ok {
+			equal, err := same(entry, existing)
+			if err != nil {
+				return plan, err
+			}
+			if equal {
+				plan.unchanged++
+				continue
+			}
+		}
+		plan.transfer = append(plan.transfer, relative)
+	}
+
+	for relative := range destination {
+		if _, ok := source[relative]; !ok {
+			plan.extraneous = append(plan.extraneous, relative)
+		}
+	}
+
+	sort.Strings(plan.transfer)
+	sort.Strings(plan.extraneous)
+	return plan, nil
+}
+
+// syncTempSuffix is added to the name of a file while it is downloaded
+const syncTempSuffix = ".sync"
+
+// isSyncTemp returns whether name is the temporary file of a download, which
+// is named "." + name + syncTempSuffix + a random number
+func isSyncTemp(name string) bool {
+	i := strings.LastIndex(name, syncTempSuffix)
+	if !strings.HasPrefix(name, ".") || i < 1 {
+		return false
+	}
+	random := name[i+len(syncTempSuffix):]
+	return random != "" && strings.Trim(random, "0123456789") == ""
+}
+
+// listLocal returns the regular files in dir by their slash separated path
+// relative to dir, without the temporary files of interrupted downloads. A
+// missing dir has no files.
+func listLocal(dir string) (map[string]*syncEntry, error) {
+	entries := map[string]*syncEntry{}
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == dir && os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.Mode().IsRegular() || isSyncTemp(info.Name()) {
+			return nil
+		}
+
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		entries[filepath.ToSlash(relative)] = &syncEntry{
+			size:     info.Size(),
+			modified: info.ModTime(),
+			local:    path,
+		}
+		return nil
+	})
+	return entries, err
+}
+
+// listRemote returns the objects under prefix by their path relative to
+// prefix
+func listRemote(ctx context.Context, bucket *libuplink.Bucket, prefix storj.Path) (map[string]*syncEntry, error) {
+	entries := map[string]*syncEntry{}
+
+	startAfter := ""
+	for {
+		list, err := bucket.ListObjects(ctx, &storj.ListOptions{
+			Direction: storj.After,
+			Cursor:    startAfter,
+			Prefix:    prefix,
+			Recursive: true,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range list.Items {
+			if object.IsPrefix {
+				continue
+			}
+			entries[object.Path] = &syncEntry{
+				size:     object.Size,
+				modified: objectModified(object.Metadata, object.Modified),
+				checksum: object.Checksum,
+			}
+		}
+
+		if !list.More || len(list.Items) == 0 {
+			break
+		}
+		startAfter = list.Items[len(list.Items)-1].Path
+	}
+
+	return entries, nil
+}
+
+// objectModified returns the modification time of the local file an object
+// was synced from, or when the object was uploaded if it was not synced
+func objectModified(metadata map[string]string, uploaded time.Time) time.Time {
+	if modified, err := time.Parse(time.RFC3339Nano, metadata[syncModifiedKey]); err == nil {
+		return modified
+	}
+	return uploaded
+}
+
+// syncMain synchronizes the destination with the source, where one of
+// them is a local directory and the other a Storj prefix
+func syncMain(cmd *cobra.Command, args []string) (err error) {
+	if len(args) != 2 {
+		return fmt.Errorf("Usage: uplink sync <local-dir> sj://bucket/prefix or uplink sync sj://bucket/prefix <local-dir>")
+	}
+	ctx := process.Ctx(cmd)
+
+	src, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+	dst, err := fpath.New(args[1])
+	if err != nil {
+		return err
+	}
+
+	if src.IsLocal() == dst.IsLocal() {
+		return fmt.Errorf("One of source and destination must be a local directory and the other a Storj URL")
+	}
+
+	upload := src.IsLocal()
+	local, remote := src, dst
+	if !upload {
+		local, remote = dst, src
+	}
+
+	if remote.Bucket() == "" {
+		return fmt.Errorf("No bucket specified, use format sj://bucket/prefix")
+	}
+	if err := checkLocalDir(local.Path(), upload); err != nil {
+		return err
+	}
+
+	prefix := remote.Path()
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	access, err := useOrLoadEncryptionAccess(cfg.Enc.EncryptionKey, cfg.Enc.KeyFilepath)
+	if err != nil {
+		return err
+	}
+
+	project, bucket, err := cfg.GetProjectAndBucket(ctx, remote.Bucket(), access)
+	if err != nil {
+		return convertError(err, remote)
+	}
+	defer closeProjectAndBucket(project, bucket)
+
+	localEntries, err := listLocal(local.Path())
+	if err != nil {
+		return err
+	}
+	remoteEntries, err := listRemote(ctx, bucket, prefix)
+	if err != nil {
+		return convertError(err, remote)
+	}
+
+	same := sameModified
+	if *syncChecksum {
+		same = sameChecksum
+	}
+
+	var plan syncPlan
+	if upload {
+		plan, err = planSync(localEntries, remoteEntries, same)
+	} else {
+		plan, err = planSync(remoteEntries, localEntries, same)
+	}
+	if err != nil {
+		return err
+	}
+
+	syncer := &syncer{
+		bucket: bucket,
+		local:  local.Path(),
+		prefix: prefix,
+		dryRun: *syncDryRun,
+	}
+
+	var jobs []func(context.Context) error
+	for _, relative := range plan.transfer {
+		relative := relative
+		if upload {
+			jobs = append(jobs, func(ctx context.Context) error { return syncer.upload(ctx, relative, localEntries[relative]) })
+		} else {
+			jobs = append(jobs, func(ctx context.Context) error { return syncer.download(ctx, relative, remoteEntries[relative]) })
+		}
+	}
+	if *syncDelete {
+		for _, relative := range plan.extraneous {
+			relative := relative
+			if upload {
+				jobs = append(jobs, func(ctx context.Context) error { return syncer.deleteObject(ctx, relative) })
+			} else {
+				jobs = append(jobs, func(ctx context.Context) error { return syncer.deleteFile(relative) })
+			}
+		}
+	}
+
+	failed := runParallel(ctx, jobs, *syncParallelism)
+
+	summary := fmt.Sprintf("%d transferred (%s), %d unchanged", syncer.transferred, memory.Size(syncer.bytes), plan.unchanged)
+	if *syncDelete {
+		summary += fmt.Sprintf(", %d deleted", syncer.deleted)
+	} else if len(plan.extraneous) > 0 {
+		summary += fmt.Sprintf(", %d only in destination", len(plan.extraneous))
+	}
+	if failed > 0 {
+		summary += fmt.Sprintf(", %d failed", failed)
+	}
+	if *syncDryRun {
+		summary += " (dry run)"
+	}
+	fmt.Println(summary)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d operations failed", failed, len(jobs))
+	}
+	return nil
+}
+
+// checkLocalDir checks that dir is a directory. Only a destination may be
+// missing, as a missing source would delete the whole destination with --delete.
+func checkLocalDir(dir string, source bool) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		if os.IsNotExist(err) && !source {
+			return nil
+		}
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("Local path must be a directory: %s", dir)
+	}
+	return nil
+}
+
+// runParallel runs jobs with at most parallelism of them at the same time
+// and returns how many of them failed or were not started because ctx was
+// canceled
+func runParallel(ctx context.Context, jobs []func(context.Context) error, parallelism int) (failed int) {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	queue := make(chan func(context.Context) error)
+
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				if err := job(ctx); err != nil {
+					fmt.Printf("%v\n", err)
+					mu.Lock()
+					failed++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	started := 0
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- job
+		started++
+	}
+	close(queue)
+	wg.Wait()
+
+	return failed + len(jobs) - started
+}
+
+// syncer transfers and deletes the files of a sync
+type syncer struct {
+	bucket *libuplink.Bucket
+	local  string
+	prefix storj.Path
+	dryRun bool
+
+	mu          sync.Mutex
+	transferred int
+	bytes       int64
+	deleted     int
+}
+
+// upload uploads the local file at relative, storing its modification time
+// in the metadata of the object
+func (syncer *syncer) upload(ctx context.Context, relative string, entry *syncEntry) (err error) {
+	object := syncer.prefix + relative
+	if syncer.dryRun {
+		fmt.Printf("Would upload %s to sj://%s/%s\n", entry.local, syncer.bucket.Name, object)
+		syncer.count(entry.size)
+		return nil
+	}
+
+	file, err := os.Open(entry.local)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	opts := &libuplink.UploadOptions{
+		Metadata: map[string]string{
+			syncModifiedKey: entry.modified.UTC().Format(time.RFC3339Nano),
+		},
+	}
+	opts.Volatile.RedundancyScheme = cfg.GetRedundancyScheme()
+	opts.Volatile.EncryptionParameters = cfg.GetEncryptionScheme().ToEncryptionParameters()
+
+	if err := syncer.bucket.UploadObject(ctx, object, file, opts); err != nil {
+		return fmt.Errorf("error uploading %s: %v", entry.local, err)
+	}
+
+	fmt.Printf("Uploaded %s to sj://%s/%s\n", entry.local, syncer.bucket.Name, object)
+	syncer.count(entry.size)
+	return nil
+}
+
+// download downloads the object at relative, setting the modification
+// time of the local file to the one the object was synced from
+func (syncer *syncer) download(ctx context.Context, relative string, entry *syncEntry) (err error) {
+	object := syncer.prefix + relative
+	target, err := syncer.localPath(relative)
+	if err != nil {
+		return err
+	}
+	if syncer.dryRun {
+		fmt.Printf("Would download sj://%s/%s to %s\n", syncer.bucket.Name, object, target)
+		syncer.count(entry.size)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	reader, err := syncer.bucket.NewReader(ctx, object)
+	if err != nil {
+		return fmt.Errorf("error downloading sj://%s/%s: %v", syncer.bucket.Name, object, err)
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	// download next to the target, so that a partial download never
+	// replaces the file
+	file, err := ioutil.TempFile(filepath.Dir(target), "."+filepath.Base(target)+syncTempSuffix)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, reader)
+	err = errs.Combine(err, file.Close())
+	if err == nil {
+		err = os.Chtimes(file.Name(), entry.modified, entry.modified)
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), target)
+	}
+	if err != nil {
+		return fmt.Errorf("error downloading sj://%s/%s: %v", syncer.bucket.Name, object, errs.Combine(err, os.Remove(file.Name())))
+	}
+
+	fmt.Printf("Downloaded sj://%s/%s to %s\n", syncer.bucket.Name, object, target)
+	syncer.count(entry.size)
+	return nil
+}
+
+// deleteObject deletes the object at relative
+func (syncer *syncer) deleteObject(ctx context.Context, relative string) error {
+	object := syncer.prefix + relative
+	if !syncer.dryRun {
+		if err := syncer.bucket.DeleteObject(ctx, object); err != nil {
+			return fmt.Errorf("error deleting sj://%s/%s: %v", syncer.bucket.Name, object, err)
+		}
+	}
+	return syncer.countDeleted(fmt.Sprintf("sj://%s/%s", syncer.bucket.Name, object))
+}
+
+// deleteFile deletes the local file at relative
+func (syncer *syncer) deleteFile(relative string) error {
+	target, err := syncer.localPath(relative)
+	if err != nil {
+		return err
+	}
+	if !syncer.dryRun {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+	return syncer.countDeleted(target)
+}
+
+// localPath returns the local file of the object at relative. Object paths
+// are chosen by whoever uploaded them, so paths which lead outside of the
+// local directory are rejected.
+func (syncer *syncer) localPath(relative string) (string, error) {
+	target := filepath.Join(syncer.local, filepath.FromSlash(relative))
+	within, err := filepath.Rel(syncer.local, target)
+	if err != nil || within == "." || within == ".." || strings.HasPrefix(within, ".."+string(filepath.Separator)) || filepath.IsAbs(within) {
+		return "", fmt.Errorf("Object path leads outside of %s: %s", syncer.local, relative)
+	}
+	return target, nil
+}
+
+// count records a transferred file of size bytes
+func (syncer *syncer) count(size int64) {
+	syncer.mu.Lock()
+	defer syncer.mu.Unlock()
+	syncer.transferred++
+	syncer.bytes += size
+}
+
+// countDeleted records a deleted file
+func (syncer *syncer) countDeleted(name string) error {
+	if syncer.dryRun {
+		fmt.Printf("Would delete %s\n", name)
+	} else {
+		fmt.Printf("Deleted %s\n", name)
+	}
+
+	syncer.mu.Lock()
+	defer syncer.mu.Unlock()
+	syncer.deleted++
+	return nil
+}
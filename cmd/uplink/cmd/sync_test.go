@@ -0,0 +1,120 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+)
+
+func TestPlanSync(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	dir := ctx.Dir("sync")
+	modified := time.Date(2019, 4, 1, 12, 30, 0, 123456789, time.UTC)
+	for name, data := range map[string]string{
+		"same":          "unchanged",
+		"newer":         "changed",
+		"resized":       "longer data",
+		"added":         "new",
+		"nested/same":   "unchanged",
+		"nested/a/file": "checksum",
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(data), 0644))
+		require.NoError(t, os.Chtimes(path, modified, modified))
+	}
+
+	local, err := listLocal(dir)
+	require.NoError(t, err)
+	assert.Len(t, local, 6)
+	assert.EqualValues(t, 9, local["nested/same"].size)
+
+	missing, err := listLocal(filepath.Join(dir, "missing"))
+	require.NoError(t, err)
+	assert.Len(t, missing, 0)
+
+	// only a missing destination may be synced
+	assert.NoError(t, checkLocalDir(dir, true))
+	assert.NoError(t, checkLocalDir(filepath.Join(dir, "missing"), false))
+	assert.Error(t, checkLocalDir(filepath.Join(dir, "missing"), true))
+	assert.Error(t, checkLocalDir(filepath.Join(dir, "same"), false))
+
+	// the temporary files of interrupted downloads are neither uploaded
+	// nor deleted
+	temp, err := ioutil.TempFile(filepath.Join(dir, "nested"), ".same"+syncTempSuffix)
+	require.NoError(t, err)
+	require.NoError(t, temp.Close())
+	local, err = listLocal(dir)
+	require.NoError(t, err)
+	assert.Len(t, local, 6)
+	assert.False(t, isSyncTemp("same.sync"))
+	assert.False(t, isSyncTemp(".same.sync"))
+
+	sum := func(data string) []byte {
+		checksum := sha256.Sum256([]byte(data))
+		return checksum[:]
+	}
+
+	metadata := map[string]string{syncModifiedKey: modified.Format(time.RFC3339Nano)}
+	remote := map[string]*syncEntry{
+		"same":          {size: 9, modified: objectModified(metadata, time.Now()), checksum: sum("unchanged")},
+		"newer":         {size: 7, modified: modified.Add(time.Second), checksum: sum("changed")},
+		"resized":       {size: 4, modified: modified, checksum: sum("data")},
+		"nested/same":   {size: 9, modified: modified, checksum: sum("unchanged")},
+		"nested/a/file": {size: 8, modified: objectModified(nil, modified.Add(time.Hour)), checksum: sum("checksum")},
+		"removed":       {size: 1, modified: modified},
+	}
+
+	plan, err := planSync(local, remote, sameModified)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"added", "nested/a/file", "newer", "resized"}, plan.transfer)
+	assert.Equal(t, []string{"removed"}, plan.extraneous)
+	assert.Equal(t, 2, plan.unchanged)
+
+	plan, err = planSync(local, remote, sameChecksum)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"added", "resized"}, plan.transfer)
+	assert.Equal(t, []string{"removed"}, plan.extraneous)
+	assert.Equal(t, 4, plan.unchanged)
+
+	// the reverse direction
+	plan, err = planSync(remote, local, sameModified)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"nested/a/file", "newer", "removed", "resized"}, plan.transfer)
+	assert.Equal(t, []string{"added"}, plan.extraneous)
+	assert.Equal(t, 2, plan.unchanged)
+}
+
+func TestSyncLocalPath(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	dir := ctx.Dir("sync")
+	syncer := &syncer{local: dir}
+
+	target, err := syncer.localPath("nested/../file")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "file"), target)
+
+	for _, relative := range []string{"../escaped", "nested/../../escaped", "..", "."} {
+		_, err := syncer.localPath(relative)
+		assert.Error(t, err, relative)
+
+		// the file outside of the local directory is not touched
+		assert.Error(t, syncer.deleteFile(relative), relative)
+	}
+	assert.Equal(t, 0, syncer.deleted)
+}
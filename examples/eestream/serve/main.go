@@ -16,10 +16,9 @@ import (
 	"strings"
 	"time"
 
-	"github.com/vivint/infectious"
-
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/ranger"
 	"storj.io/storj/pkg/storj"
 )
@@ -30,6 +29,7 @@ var (
 	key              = flag.String("key", "a key", "the secret key")
 	rsk              = flag.Int("required", 20, "rs required")
 	rsn              = flag.Int("total", 40, "rs total")
+	cauchy           = flag.Bool("cauchy", false, "use the cauchy reed-solomon scheme")
 )
 
 func main() {
@@ -48,11 +48,14 @@ func main() {
 // Main is the exported CLI executable function
 func Main() error {
 	encKey := storj.Key(sha256.Sum256([]byte(*key)))
-	fc, err := infectious.NewFEC(*rsk, *rsn)
+	schemeType := pb.RedundancyScheme_RS
+	if *cauchy {
+		schemeType = pb.RedundancyScheme_RS_CAUCHY
+	}
+	es, err := eestream.NewErasureScheme(schemeType, *rsk, *rsn, *erasureShareSize)
 	if err != nil {
 		return err
 	}
-	es := eestream.NewRSScheme(fc, *erasureShareSize)
 	var firstNonce storj.Nonce
 	decrypter, err := encryption.NewDecrypter(storj.AESGCM, &encKey, &firstNonce, es.StripeSize())
 	if err != nil {
@@ -12,10 +12,9 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/vivint/infectious"
-
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
 )
 
@@ -24,6 +23,7 @@ var (
 	key              = flag.String("key", "a key", "the secret key")
 	rsk              = flag.Int("required", 20, "rs required")
 	rsn              = flag.Int("total", 40, "rs total")
+	cauchy           = flag.Bool("cauchy", false, "use the cauchy reed-solomon scheme")
 )
 
 func main() {
@@ -45,11 +45,14 @@ func Main() error {
 	if err != nil {
 		return err
 	}
-	fc, err := infectious.NewFEC(*rsk, *rsn)
+	schemeType := pb.RedundancyScheme_RS
+	if *cauchy {
+		schemeType = pb.RedundancyScheme_RS_CAUCHY
+	}
+	es, err := eestream.NewErasureScheme(schemeType, *rsk, *rsn, *erasureShareSize)
 	if err != nil {
 		return err
 	}
-	es := eestream.NewRSScheme(fc, *erasureShareSize)
 	rs, err := eestream.NewRedundancyStrategy(es, 0, 0)
 	if err != nil {
 		return err
@@ -6,6 +6,7 @@ package testcontext
 import (
 	"os/exec"
 	"path"
+	"path/filepath"
 )
 
 // Compile compiles the specified package and returns the executable name.
@@ -29,3 +30,46 @@ func (ctx *Context) Compile(pkg string) string {
 
 	return exe
 }
+
+// CompileShared compiles the specified package as a C shared library with
+// the header generated by cgo, and returns the path of the library. The
+// library is named lib<name>.so.
+func (ctx *Context) CompileShared(name string, pkg string) string {
+	ctx.test.Helper()
+
+	lib := ctx.File("build", "lib"+name+".so")
+
+	out, err := exec.Command("go", "build", "-buildmode=c-shared", "-o", lib, pkg).CombinedOutput()
+	if err != nil {
+		ctx.test.Error(string(out))
+		ctx.test.Fatal(err)
+	}
+
+	return lib
+}
+
+// CompileC compiles the C source file src with the headers in includes and
+// links it with the shared libraries returned by CompileShared. It returns
+// the executable name.
+func (ctx *Context) CompileC(src string, includes []string, libs ...string) string {
+	ctx.test.Helper()
+
+	exe := ctx.File("build", path.Base(src)+".exe")
+
+	args := []string{"-o", exe, src}
+	for _, include := range includes {
+		args = append(args, "-I", include)
+	}
+	for _, lib := range libs {
+		dir := filepath.Dir(lib)
+		args = append(args, "-I", dir, lib, "-Wl,-rpath,"+dir)
+	}
+
+	out, err := exec.Command("gcc", args...).CombinedOutput()
+	if err != nil {
+		ctx.test.Error(string(out))
+		ctx.test.Fatal(err)
+	}
+
+	return exe
+}
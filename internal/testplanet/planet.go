@@ -50,12 +50,14 @@ import (
 	"storj.io/storj/satellite/console/consoleweb"
 	"storj.io/storj/satellite/mailservice"
 	"storj.io/storj/satellite/metainfo"
+	satelliteorders "storj.io/storj/satellite/orders"
 	"storj.io/storj/satellite/satellitedb"
 	"storj.io/storj/satellite/vouchers"
 	"storj.io/storj/storagenode"
 	"storj.io/storj/storagenode/collector"
 	"storj.io/storj/storagenode/orders"
 	"storj.io/storj/storagenode/piecestore"
+	"storj.io/storj/storagenode/scrubber"
 	"storj.io/storj/storagenode/storagenodedb"
 	"storj.io/storj/versioncontrol"
 )
@@ -456,6 +458,8 @@ func (planet *Planet) newSatellites(count int) ([]*satellite.Peer, error) {
 					NewNodePercentage: 0,
 					OnlineWindow:      time.Hour,
 					DistinctIP:        false,
+					ExplorationRatio:  0.25,
+					UploadStatsLambda: 0.95,
 				},
 			},
 			Discovery: discovery.Config{
@@ -472,6 +476,10 @@ func (planet *Planet) newSatellites(count int) ([]*satellite.Peer, error) {
 				BwExpiration:         45,
 			},
 			BwAgreement: bwagreement.Config{},
+			Orders: satelliteorders.Config{
+				SettlementBatchSize: 10,
+				FraudThreshold:      10,
+			},
 			Checker: checker.Config{
 				Interval:            30 * time.Second,
 				IrreparableInterval: 15 * time.Second,
@@ -486,6 +494,14 @@ func (planet *Planet) newSatellites(count int) ([]*satellite.Peer, error) {
 				MaxRetriesStatDB:  0,
 				Interval:          30 * time.Second,
 				MinBytesPerSecond: 1 * memory.KB,
+				Selection:         audit.SelectionRandom,
+				Weighted: audit.WeightedConfig{
+					AuditsPerNode:  1,
+					UnvettedWeight: 3,
+					FailureWeight:  2,
+					FailureWindow:  time.Hour,
+					AgeWeight:      720 * time.Hour,
+				},
 			},
 			Tally: tally.Config{
 				Interval: 30 * time.Second,
@@ -617,6 +633,12 @@ func (planet *Planet) newStorageNodes(count int, whitelistedSatelliteIDs []strin
 			Collector: collector.Config{
 				Interval: time.Minute,
 			},
+			Scrubber: scrubber.Config{
+				Interval:        time.Hour,
+				ScrubEvery:      time.Hour,
+				MaxBytesPerRun:  memory.GiB,
+				ReportCorrupted: true,
+			},
 			Storage2: piecestore.Config{
 				Sender: orders.SenderConfig{
 					Interval: time.Hour,
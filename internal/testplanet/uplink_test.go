@@ -223,6 +223,10 @@ func (mock *piecestoreMock) Delete(ctx context.Context, delete *pb.PieceDeleteRe
 	return nil, nil
 }
 
+func (mock *piecestoreMock) Repair(ctx context.Context, repair *pb.PieceRepairRequest) (_ *pb.PieceRepairResponse, err error) {
+	return nil, nil
+}
+
 func TestDownloadFromUnresponsiveNode(t *testing.T) {
 	testplanet.Run(t, testplanet.Config{
 		SatelliteCount: 1, StorageNodeCount: 5, UplinkCount: 1,
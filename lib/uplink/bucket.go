@@ -14,6 +14,7 @@ import (
 	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/stream"
+	"storj.io/storj/pkg/transfer"
 )
 
 // Bucket represents operations you can perform on a bucket
@@ -48,6 +49,7 @@ func (b *Bucket) OpenObject(ctx context.Context, path storj.Path) (o *Object, er
 			Expires:     info.Expires,
 			Size:        info.Size,
 			Checksum:    info.Checksum,
+			MD5:         info.MD5,
 			Volatile: struct {
 				EncryptionParameters storj.EncryptionParameters
 				RedundancyScheme     storj.RedundancyScheme
@@ -76,6 +78,9 @@ type UploadOptions struct {
 	// Expires is the time at which the new Object can expire (be deleted
 	// automatically from storage nodes).
 	Expires time.Time
+	// Observer, if set, is notified about the progress of the upload and
+	// about the result of the upload of every piece to a storage node.
+	Observer TransferObserver
 
 	// Volatile groups config values that are likely to change semantics
 	// or go away entirely between releases. Be careful when using them!
@@ -127,6 +132,40 @@ func (b *Bucket) ListObjects(ctx context.Context, cfg *ListOptions) (list storj.
 // NewWriter creates a writer which uploads the object.
 func (b *Bucket) NewWriter(ctx context.Context, path storj.Path, opts *UploadOptions) (_ io.WriteCloser, err error) {
 	defer mon.Task()(&ctx)(&err)
+	return b.ResumeWriter(ctx, path, opts, nil)
+}
+
+// UploadState is the progress of a paused upload, from which it can be
+// resumed with ResumeWriter.
+type UploadState = streams.UploadState
+
+// PausableWriter is a writer which uploads an object, and whose upload can
+// be paused to be resumed later.
+type PausableWriter interface {
+	io.WriteCloser
+	// Pause stops the upload without completing it. The segments which were
+	// uploaded completely are kept, and the returned state tells from which
+	// offset of the data the upload has to be resumed.
+	Pause() (*UploadState, error)
+	// Cancel stops the upload without completing it and deletes the
+	// segments which were uploaded, including the ones of the paused
+	// upload it resumes.
+	Cancel() error
+}
+
+// DeletePausedUpload deletes the segments of the paused upload of the object
+// from state, when the upload is not going to be resumed.
+func (b *Bucket) DeletePausedUpload(ctx context.Context, path storj.Path, state *UploadState) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return b.streams.DeletePaused(ctx, storj.JoinPaths(b.Name, path), b.PathCipher.ToCipher(), state)
+}
+
+// ResumeWriter creates a writer which resumes the paused upload of the
+// object from state, so that the data written to it must start at
+// state.Offset(). The options must be the same as the ones of the paused
+// upload. If state is nil, a new upload is started.
+func (b *Bucket) ResumeWriter(ctx context.Context, path storj.Path, opts *UploadOptions, state *UploadState) (_ PausableWriter, err error) {
+	defer mon.Task()(&ctx)(&err)
 
 	if opts == nil {
 		opts = &UploadOptions{}
@@ -174,7 +213,7 @@ func (b *Bucket) NewWriter(ctx context.Context, path storj.Path, opts *UploadOpt
 		return nil, err
 	}
 
-	upload := stream.NewUpload(ctx, mutableStream, b.streams)
+	upload := stream.NewResumableUpload(transfer.WithObserver(ctx, opts.Observer), mutableStream, b.streams, state)
 	return upload, nil
 }
 
@@ -185,16 +224,33 @@ type ReadSeekCloser interface {
 	io.Closer
 }
 
+// DownloadOptions controls options about downloading an Object.
+type DownloadOptions struct {
+	// Observer, if set, is notified about the progress of the download and
+	// about the result of the download of every piece from a storage node.
+	Observer TransferObserver
+}
+
 // NewReader creates a new reader that downloads the object data.
 func (b *Bucket) NewReader(ctx context.Context, path storj.Path) (_ ReadSeekCloser, err error) {
+	return b.NewReaderWithOptions(ctx, path, nil)
+}
+
+// NewReaderWithOptions creates a new reader that downloads the object data
+// with the given options.
+func (b *Bucket) NewReaderWithOptions(ctx context.Context, path storj.Path, opts *DownloadOptions) (_ ReadSeekCloser, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+
 	segmentStream, err := b.metainfo.GetObjectStream(ctx, b.Name, path)
 	if err != nil {
 		return nil, err
 	}
 
-	return stream.NewDownload(ctx, segmentStream, b.streams), nil
+	return stream.NewDownload(transfer.WithObserver(ctx, opts.Observer), segmentStream, b.streams), nil
 }
 
 // Close closes the Bucket session.
@@ -13,6 +13,7 @@ import (
 	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/stream"
+	"storj.io/storj/pkg/transfer"
 )
 
 // ObjectMeta contains metadata about a specific Object
@@ -45,8 +46,12 @@ type ObjectMeta struct {
 
 	// Size gives the size of the Object in bytes.
 	Size int64
-	// Checksum gives a checksum of the contents of the Object.
+	// Checksum gives the SHA-256 checksum of the contents of the Object.
+	// It is verified when the whole Object is downloaded.
 	Checksum []byte
+	// MD5 gives the MD5 checksum of the contents of the Object, as used by
+	// S3 compatible clients.
+	MD5 []byte
 
 	// Volatile groups config values that are likely to change semantics
 	// or go away entirely between releases. Be careful when using them!
@@ -81,12 +86,22 @@ type Object struct {
 // DownloadRange returns an Object's data. A length of -1 will mean
 // (Object.Size - offset).
 func (o *Object) DownloadRange(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	return o.DownloadRangeWithOptions(ctx, offset, length, nil)
+}
+
+// DownloadRangeWithOptions is like DownloadRange, but downloads the data with
+// the given options.
+func (o *Object) DownloadRangeWithOptions(ctx context.Context, offset, length int64, opts *DownloadOptions) (io.ReadCloser, error) {
+	if opts == nil {
+		opts = &DownloadOptions{}
+	}
+
 	readOnlyStream, err := o.metainfoDB.GetObjectStream(ctx, o.Meta.Bucket, o.Meta.Path)
 	if err != nil {
 		return nil, err
 	}
 
-	download := stream.NewDownload(ctx, readOnlyStream, o.streams)
+	download := stream.NewDownload(transfer.WithObserver(ctx, opts.Observer), readOnlyStream, o.streams)
 	_, err = download.Seek(offset, io.SeekStart)
 	if err != nil {
 		return nil, err
@@ -99,6 +114,23 @@ func (o *Object) DownloadRange(ctx context.Context, offset, length int64) (io.Re
 	return readcloser.LimitReadCloser(download, length), nil
 }
 
+// UpdateMetadata replaces the ContentType and the Metadata of the Object
+// without uploading its contents again. The Modified time of the Object is
+// updated as well.
+func (o *Object) UpdateMetadata(ctx context.Context, contentType string, metadata map[string]string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	info, err := o.metainfoDB.UpdateObjectMetadata(ctx, o.Meta.Bucket, o.Meta.Path, contentType, metadata)
+	if err != nil {
+		return err
+	}
+
+	o.Meta.ContentType = info.ContentType
+	o.Meta.Metadata = info.Metadata
+	o.Meta.Modified = info.Modified
+	return nil
+}
+
 // Close closes the Object.
 func (o *Object) Close() error {
 	return nil
@@ -6,8 +6,6 @@ package uplink
 import (
 	"context"
 
-	"github.com/vivint/infectious"
-
 	"storj.io/storj/internal/memory"
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/encryption"
@@ -166,12 +164,18 @@ func (p *Project) OpenBucket(ctx context.Context, bucketName string, access *Enc
 	encryptionScheme := cfg.EncryptionParameters.ToEncryptionScheme()
 
 	ec := ecclient.NewClient(p.tc, p.uplinkCfg.Volatile.MaxMemory.Int())
-	fc, err := infectious.NewFEC(int(cfg.Volatile.RedundancyScheme.RequiredShares), int(cfg.Volatile.RedundancyScheme.TotalShares))
+	schemeType, err := eestream.SchemeTypeForAlgorithm(cfg.Volatile.RedundancyScheme.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	es, err := eestream.NewErasureScheme(schemeType,
+		int(cfg.Volatile.RedundancyScheme.RequiredShares),
+		int(cfg.Volatile.RedundancyScheme.TotalShares),
+		int(cfg.Volatile.RedundancyScheme.ShareSize))
 	if err != nil {
 		return nil, err
 	}
-	rs, err := eestream.NewRedundancyStrategy(
-		eestream.NewRSScheme(fc, int(cfg.Volatile.RedundancyScheme.ShareSize)),
+	rs, err := eestream.NewRedundancyStrategy(es,
 		int(cfg.Volatile.RedundancyScheme.RepairShares),
 		int(cfg.Volatile.RedundancyScheme.OptimalShares))
 	if err != nil {
@@ -185,7 +189,8 @@ func (p *Project) OpenBucket(ctx context.Context, bucketName string, access *Enc
 	}
 	segmentStore := segments.NewSegmentStore(p.metainfo, ec, rs, p.maxInlineSize.Int(), maxEncryptedSegmentSize)
 
-	streamStore, err := streams.NewStreamStore(segmentStore, cfg.Volatile.SegmentsSize.Int64(), &access.Key, int(encryptionScheme.BlockSize), encryptionScheme.Cipher)
+	streamStore, err := streams.NewParallelStreamStore(segmentStore, cfg.Volatile.SegmentsSize.Int64(), &access.Key, int(encryptionScheme.BlockSize), encryptionScheme.Cipher,
+		p.uplinkCfg.Volatile.SegmentParallelism, p.uplinkCfg.Volatile.MaxSegmentMemory.Int64())
 	if err != nil {
 		return nil, err
 	}
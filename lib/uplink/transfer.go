@@ -0,0 +1,37 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplink
+
+import (
+	"storj.io/storj/pkg/transfer"
+)
+
+// TransferObserver is notified about the progress of uploads and downloads.
+// It is called concurrently for segments and pieces transferred at the same
+// time. See UploadOptions.Observer and DownloadOptions.Observer.
+type TransferObserver = transfer.Observer
+
+// PieceResult describes the transfer of a piece to or from a storage node.
+type PieceResult = transfer.PieceResult
+
+// TransferStatus is the outcome of the transfer of a piece.
+type TransferStatus = transfer.Status
+
+const (
+	// TransferSucceeded means that the piece was transferred completely.
+	TransferSucceeded = transfer.Succeeded
+	// TransferFailed means that the transfer of the piece failed.
+	TransferFailed = transfer.Failed
+	// TransferCanceled means that the transfer of the piece was canceled,
+	// because enough other pieces were transferred (long tail) or because
+	// the transfer was aborted.
+	TransferCanceled = transfer.Canceled
+)
+
+// TransferStats is a TransferObserver which sums up the transfers it
+// observes. The zero value is ready to use.
+type TransferStats = transfer.Stats
+
+// TransferSummary sums up transfers, as returned by TransferStats.Summary.
+type TransferSummary = transfer.Summary
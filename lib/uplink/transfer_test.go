@@ -0,0 +1,104 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplink
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/storj"
+)
+
+// check that the progress of uploads and downloads and the results of the
+// transfers of pieces are reported to observers.
+func TestTransferObserver(t *testing.T) {
+	var (
+		access         = simpleEncryptionAccess("exandria")
+		inBucketConfig BucketConfig
+		testConfig     testConfig
+	)
+	inBucketConfig.Volatile.RedundancyScheme = storj.RedundancyScheme{
+		Algorithm:      storj.ReedSolomon,
+		ShareSize:      memory.KiB.Int32(),
+		RequiredShares: 2,
+		RepairShares:   3,
+		OptimalShares:  4,
+		TotalShares:    5,
+	}
+	inBucketConfig.Volatile.SegmentsSize = 8 * memory.KiB
+	testConfig.uplinkCfg.Volatile.MaxInlineSize = 1
+
+	testPlanetWithLibUplink(t, testConfig, &access.Key,
+		func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet, proj *Project) {
+			_, err := proj.CreateBucket(ctx, "bucket", &inBucketConfig)
+			require.NoError(t, err)
+
+			bucket, err := proj.OpenBucket(ctx, "bucket", &access)
+			require.NoError(t, err)
+			defer ctx.Check(bucket.Close)
+
+			data := make([]byte, 20*memory.KiB)
+			for i := range data {
+				data[i] = byte(i)
+			}
+			segmentBytes := map[int64]int64{
+				0: 8 * memory.KiB.Int64(),
+				1: 8 * memory.KiB.Int64(),
+				2: 4 * memory.KiB.Int64(),
+			}
+
+			{ // every piece of every segment is reported once
+				var stats TransferStats
+				err := bucket.UploadObject(ctx, "object", bytes.NewReader(data), &UploadOptions{Observer: &stats})
+				require.NoError(t, err)
+
+				summary := stats.Summary()
+				assert.EqualValues(t, len(data), summary.Bytes)
+				assert.Equal(t, segmentBytes, summary.SegmentBytes)
+				assert.Equal(t, 3*5, summary.Succeeded+summary.Failed+summary.Canceled)
+				assert.True(t, summary.Succeeded >= 3*3, "fewer successful uploads than the repair threshold")
+				assert.Equal(t, 5, summary.Nodes)
+			}
+
+			{ // downloads report the pieces they needed
+				var stats TransferStats
+				reader, err := bucket.NewReaderWithOptions(ctx, "object", &DownloadOptions{Observer: &stats})
+				require.NoError(t, err)
+
+				downloaded, err := ioutil.ReadAll(reader)
+				require.NoError(t, err)
+				require.NoError(t, reader.Close())
+				assert.Equal(t, data, downloaded)
+
+				summary := stats.Summary()
+				assert.EqualValues(t, len(data), summary.Bytes)
+				assert.Equal(t, segmentBytes, summary.SegmentBytes)
+				assert.Equal(t, 0, summary.Failed)
+				assert.True(t, summary.Succeeded+summary.Canceled >= 3*2, "fewer downloaded pieces than required")
+			}
+
+			{ // ranges report the segments they contain
+				var stats TransferStats
+				object, err := bucket.OpenObject(ctx, "object")
+				require.NoError(t, err)
+				defer ctx.Check(object.Close)
+
+				reader, err := object.DownloadRangeWithOptions(ctx, 10*memory.KiB.Int64(), memory.KiB.Int64(), &DownloadOptions{Observer: &stats})
+				require.NoError(t, err)
+				downloaded, err := ioutil.ReadAll(reader)
+				require.NoError(t, err)
+				require.NoError(t, reader.Close())
+				assert.Equal(t, data[10*memory.KiB:11*memory.KiB], downloaded)
+
+				assert.Equal(t, map[int64]int64{1: memory.KiB.Int64()}, stats.Summary().SegmentBytes)
+			}
+		})
+}
@@ -6,12 +6,11 @@ package uplink
 import (
 	"context"
 
-	"github.com/vivint/infectious"
-
 	"storj.io/storj/internal/memory"
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/metainfo/kvmetainfo"
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/peertls/tlsopts"
 	"storj.io/storj/pkg/storage/buckets"
 	"storj.io/storj/pkg/storage/segments"
@@ -64,6 +63,18 @@ type Config struct {
 		// be used. If set to a negative value, the system will use the
 		// smallest amount of memory it can.
 		MaxMemory memory.Size
+
+		// SegmentParallelism is the number of segments of an Object
+		// which are uploaded or downloaded at the same time. If set to
+		// zero, segments are transferred one after the other.
+		SegmentParallelism int
+
+		// MaxSegmentMemory is the maximum amount of memory to be
+		// allocated for buffering the segments which are transferred at
+		// the same time. SegmentParallelism is reduced until the
+		// segments fit. If set to zero, the library default (256 MiB)
+		// will be used.
+		MaxSegmentMemory memory.Size
 	}
 }
 
@@ -81,6 +92,12 @@ func (cfg *Config) setDefaults(ctx context.Context) error {
 	} else if cfg.Volatile.MaxMemory.Int() < 0 {
 		cfg.Volatile.MaxMemory = 0
 	}
+	if cfg.Volatile.SegmentParallelism <= 0 {
+		cfg.Volatile.SegmentParallelism = 1
+	}
+	if cfg.Volatile.MaxSegmentMemory.Int() == 0 {
+		cfg.Volatile.MaxSegmentMemory = 256 * memory.MiB
+	}
 	return nil
 }
 
@@ -147,11 +164,11 @@ func (u *Uplink) OpenProject(ctx context.Context, satelliteAddr string, apiKey A
 
 	// TODO: we shouldn't really need encoding parameters to manage buckets.
 	whoCares := 1
-	fc, err := infectious.NewFEC(whoCares, whoCares)
+	es, err := eestream.NewErasureScheme(pb.RedundancyScheme_RS, whoCares, whoCares, whoCares)
 	if err != nil {
 		return nil, Error.New("failed to create erasure coding client: %v", err)
 	}
-	rs, err := eestream.NewRedundancyStrategy(eestream.NewRSScheme(fc, whoCares), whoCares, whoCares)
+	rs, err := eestream.NewRedundancyStrategy(es, whoCares, whoCares)
 	if err != nil {
 		return nil, Error.New("failed to create redundancy strategy: %v", err)
 	}
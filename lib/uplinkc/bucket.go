@@ -0,0 +1,206 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+
+import (
+	"io"
+	"time"
+	"unsafe"
+
+	libuplink "storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/storj"
+)
+
+// Upload is a scoped upload of an object
+type Upload struct {
+	scope
+	writer io.WriteCloser
+}
+
+// list_objects lists the objects of the bucket, all of them when opts is
+// NULL
+//export list_objects
+func list_objects(bucketRef C.BucketRef, opts *C.ListOptions, cerr *C.UplinkError) C.ObjectList {
+	bucket, ok := universe.get(int64(bucketRef._handle)).(*Bucket)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("bucket"))
+		return C.ObjectList{}
+	}
+
+	libopts := &libuplink.ListOptions{Direction: storj.After}
+	if opts != nil {
+		libopts.Delimiter = rune(opts.delimiter)
+		libopts.Recursive = bool(opts.recursive)
+		libopts.Direction = storj.ListDirection(opts.direction)
+		libopts.Limit = int(opts.limit)
+		if opts.prefix != nil {
+			libopts.Prefix = C.GoString(opts.prefix)
+		}
+		if opts.cursor != nil {
+			libopts.Cursor = C.GoString(opts.cursor)
+		}
+	}
+
+	list, err := bucket.lib.ListObjects(bucket.ctx, libopts)
+	if setError(cerr, err) {
+		return C.ObjectList{}
+	}
+
+	items := C.calloc(C.size_t(len(list.Items)), C.size_t(unsafe.Sizeof(C.ObjectInfo{})))
+	for i, object := range list.Items {
+		*objectInfoAt(items, i) = C.ObjectInfo{
+			path:         C.CString(object.Path),
+			is_prefix:    C.bool(object.IsPrefix),
+			content_type: C.CString(object.ContentType),
+			created:      unixTime(object.Created),
+			modified:     unixTime(object.Modified),
+			expires:      unixTime(object.Expires),
+			size:         C.int64_t(object.Size),
+		}
+	}
+	return C.ObjectList{
+		bucket: C.CString(list.Bucket),
+		prefix: C.CString(list.Prefix),
+		more:   C.bool(list.More),
+		items:  (*C.ObjectInfo)(items),
+		length: C.int32_t(len(list.Items)),
+	}
+}
+
+// free_list_objects frees the strings and items of list
+//export free_list_objects
+func free_list_objects(list *C.ObjectList) {
+	if list == nil {
+		return
+	}
+	for i := 0; i < int(list.length); i++ {
+		item := objectInfoAt(unsafe.Pointer(list.items), i)
+		C.free(unsafe.Pointer(item.path))
+		C.free(unsafe.Pointer(item.content_type))
+	}
+	C.free(unsafe.Pointer(list.items))
+	C.free(unsafe.Pointer(list.bucket))
+	C.free(unsafe.Pointer(list.prefix))
+	*list = C.ObjectList{}
+}
+
+// delete_object deletes the object at path
+//export delete_object
+func delete_object(bucketRef C.BucketRef, path *C.char, cerr *C.UplinkError) {
+	bucket, ok := universe.get(int64(bucketRef._handle)).(*Bucket)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("bucket"))
+		return
+	}
+	if path == nil {
+		setError(cerr, ErrInvalidArgument.New("path"))
+		return
+	}
+
+	setError(cerr, bucket.lib.DeleteObject(bucket.ctx, C.GoString(path)))
+}
+
+// upload starts uploading an object to path. The data is written with
+// upload_write, and the upload is finished with upload_commit or
+// upload_cancel, which also free the handle.
+//export upload
+func upload(bucketRef C.BucketRef, path *C.char, opts *C.UploadOptions, cerr *C.UplinkError) C.UploaderRef {
+	bucket, ok := universe.get(int64(bucketRef._handle)).(*Bucket)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("bucket"))
+		return C.UploaderRef{}
+	}
+	if path == nil {
+		setError(cerr, ErrInvalidArgument.New("path"))
+		return C.UploaderRef{}
+	}
+
+	libopts := &libuplink.UploadOptions{}
+	if opts != nil {
+		if opts.content_type != nil {
+			libopts.ContentType = C.GoString(opts.content_type)
+		}
+		if opts.expires > 0 {
+			libopts.Expires = time.Unix(int64(opts.expires), 0)
+		}
+	}
+
+	scope := bucket.child()
+	writer, err := bucket.lib.NewWriter(scope.ctx, C.GoString(path), libopts)
+	if setError(cerr, err) {
+		scope.cancel()
+		return C.UploaderRef{}
+	}
+	return C.UploaderRef{_handle: C.int64_t(universe.add(&Upload{scope, writer}))}
+}
+
+// maxWriteLength is the largest number of bytes written by upload_write at once
+const maxWriteLength = 1 << 30
+
+// upload_write writes up to length bytes to the upload and returns the number
+// of bytes written
+//export upload_write
+func upload_write(uploader C.UploaderRef, bytes *C.uint8_t, length C.size_t, cerr *C.UplinkError) C.size_t {
+	upload, ok := universe.get(int64(uploader._handle)).(*Upload)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("uploader"))
+		return 0
+	}
+	if bytes == nil && length > 0 {
+		setError(cerr, ErrInvalidArgument.New("bytes"))
+		return 0
+	}
+
+	if length > maxWriteLength {
+		length = maxWriteLength
+	}
+	n, err := upload.writer.Write(C.GoBytes(unsafe.Pointer(bytes), C.int(length)))
+	setError(cerr, err)
+	return C.size_t(n)
+}
+
+// upload_commit finishes the upload, which makes the object visible, and
+// frees its handle
+//export upload_commit
+func upload_commit(uploader C.UploaderRef, cerr *C.UplinkError) {
+	upload, ok := universe.del(int64(uploader._handle)).(*Upload)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("uploader"))
+		return
+	}
+	defer upload.cancel()
+
+	setError(cerr, upload.writer.Close())
+}
+
+// upload_cancel aborts the upload and frees its handle
+//export upload_cancel
+func upload_cancel(uploader C.UploaderRef, cerr *C.UplinkError) {
+	upload, ok := universe.del(int64(uploader._handle)).(*Upload)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("uploader"))
+		return
+	}
+
+	upload.cancel()
+	// closing a canceled upload only reports the cancellation
+	_ = upload.writer.Close()
+}
+
+// objectInfoAt returns the i-th element of the C array items
+func objectInfoAt(items unsafe.Pointer, i int) *C.ObjectInfo {
+	return (*C.ObjectInfo)(unsafe.Pointer(uintptr(items) + uintptr(i)*unsafe.Sizeof(C.ObjectInfo{})))
+}
+
+// unixTime converts t to seconds since the Unix epoch, where the zero time
+// is 0
+func unixTime(t time.Time) C.int64_t {
+	if t.IsZero() {
+		return 0
+	}
+	return C.int64_t(t.Unix())
+}
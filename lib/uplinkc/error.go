@@ -0,0 +1,66 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	// ErrInvalidHandle is returned for a handle which was already freed or
+	// refers to a value of a different kind
+	ErrInvalidHandle = errs.Class("invalid handle")
+	// ErrInvalidArgument is returned for a malformed argument
+	ErrInvalidArgument = errs.Class("invalid argument")
+)
+
+// setError stores err in cerr and returns whether err is not nil
+func setError(cerr *C.UplinkError, err error) bool {
+	if err == nil {
+		return false
+	}
+	if cerr != nil {
+		C.free(unsafe.Pointer(cerr.message))
+		cerr.code = errorCode(err)
+		cerr.message = C.CString(err.Error())
+	}
+	return true
+}
+
+// errorCode returns the code of err in uplink_definitions.h
+func errorCode(err error) C.int32_t {
+	switch {
+	case ErrInvalidHandle.Has(err):
+		return C.UPLINK_ERROR_INVALID_HANDLE
+	case ErrInvalidArgument.Has(err):
+		return C.UPLINK_ERROR_INVALID_ARGUMENT
+	case storj.ErrBucketNotFound.Has(err):
+		return C.UPLINK_ERROR_BUCKET_NOT_FOUND
+	case storj.ErrObjectNotFound.Has(err):
+		return C.UPLINK_ERROR_OBJECT_NOT_FOUND
+	case errs.Unwrap(err) == context.Canceled:
+		return C.UPLINK_ERROR_CANCELED
+	default:
+		return C.UPLINK_ERROR_INTERNAL
+	}
+}
+
+// free_error frees the message of cerr and resets it
+//export free_error
+func free_error(cerr *C.UplinkError) {
+	if cerr == nil {
+		return
+	}
+	C.free(unsafe.Pointer(cerr.message))
+	cerr.message = nil
+	cerr.code = C.UPLINK_OK
+}
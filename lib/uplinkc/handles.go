@@ -0,0 +1,70 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// handles keeps the values referred to by the handles passed to C, because C
+// must not keep pointers to Go memory. The zero handle is never used.
+type handles struct {
+	mu     sync.Mutex
+	last   int64
+	values map[int64]interface{}
+}
+
+// universe contains all handles of the library
+var universe = newHandles()
+
+func newHandles() *handles {
+	return &handles{values: map[int64]interface{}{}}
+}
+
+// add stores value and returns its new handle
+func (h *handles) add(value interface{}) int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.last++
+	h.values[h.last] = value
+	return h.last
+}
+
+// get returns the value of handle, which is nil for an unknown handle
+func (h *handles) get(handle int64) interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.values[handle]
+}
+
+// del removes handle and returns its value, which is nil for an unknown
+// handle
+func (h *handles) del(handle int64) interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	value := h.values[handle]
+	delete(h.values, handle)
+	return value
+}
+
+// scope cancels the operations of a handle and of the handles derived from
+// it when the handle is closed
+type scope struct {
+	ctx    context.Context
+	cancel func()
+}
+
+func rootScope() scope {
+	ctx, cancel := context.WithCancel(context.Background())
+	return scope{ctx, cancel}
+}
+
+func (parent *scope) child() scope {
+	ctx, cancel := context.WithCancel(parent.ctx)
+	return scope{ctx, cancel}
+}
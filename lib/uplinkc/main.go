@@ -0,0 +1,21 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package main implements the C bindings of lib/uplink. It is built as a C
+// shared library, which also generates the header libuplinkc.h:
+//
+//    go build -buildmode=c-shared -o libuplinkc.so storj.io/storj/lib/uplinkc
+//
+// Go values are never passed to C. Instead every uplink, project, bucket,
+// object, upload and download is referred to by an opaque handle, which must
+// be released with the close or free function of its kind. Strings, lists
+// and metadata returned to C are allocated with malloc and must be freed with
+// the matching free function.
+//
+// Every function takes an UplinkError as its last argument, which is set
+// when the call fails. See uplink_definitions.h for the error codes.
+package main
+
+import "C"
+
+func main() {}
@@ -0,0 +1,185 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+
+import (
+	"io"
+	"unsafe"
+
+	libuplink "storj.io/storj/lib/uplink"
+)
+
+// Object is a scoped libuplink.Object
+type Object struct {
+	scope
+	lib *libuplink.Object
+}
+
+// Download is a scoped download of an object
+type Download struct {
+	scope
+	reader io.ReadCloser
+}
+
+// open_object opens the object at path
+//export open_object
+func open_object(bucketRef C.BucketRef, path *C.char, cerr *C.UplinkError) C.ObjectRef {
+	bucket, ok := universe.get(int64(bucketRef._handle)).(*Bucket)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("bucket"))
+		return C.ObjectRef{}
+	}
+	if path == nil {
+		setError(cerr, ErrInvalidArgument.New("path"))
+		return C.ObjectRef{}
+	}
+
+	scope := bucket.child()
+	object, err := bucket.lib.OpenObject(scope.ctx, C.GoString(path))
+	if setError(cerr, err) {
+		scope.cancel()
+		return C.ObjectRef{}
+	}
+	return C.ObjectRef{_handle: C.int64_t(universe.add(&Object{scope, object}))}
+}
+
+// close_object closes the object and frees its handle
+//export close_object
+func close_object(objectRef C.ObjectRef, cerr *C.UplinkError) {
+	object, ok := universe.del(int64(objectRef._handle)).(*Object)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("object"))
+		return
+	}
+	defer object.cancel()
+
+	setError(cerr, object.lib.Close())
+}
+
+// get_object_meta returns the metadata of the object
+//export get_object_meta
+func get_object_meta(objectRef C.ObjectRef, cerr *C.UplinkError) C.ObjectMeta {
+	object, ok := universe.get(int64(objectRef._handle)).(*Object)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("object"))
+		return C.ObjectMeta{}
+	}
+
+	meta := object.lib.Meta
+	return C.ObjectMeta{
+		bucket:          C.CString(meta.Bucket),
+		path:            C.CString(meta.Path),
+		is_prefix:       C.bool(meta.IsPrefix),
+		content_type:    C.CString(meta.ContentType),
+		created:         unixTime(meta.Created),
+		modified:        unixTime(meta.Modified),
+		expires:         unixTime(meta.Expires),
+		size:            C.int64_t(meta.Size),
+		checksum_bytes:  (*C.uint8_t)(C.CBytes(meta.Checksum)),
+		checksum_length: C.int64_t(len(meta.Checksum)),
+	}
+}
+
+// free_object_meta frees the strings and the checksum of meta
+//export free_object_meta
+func free_object_meta(meta *C.ObjectMeta) {
+	if meta == nil {
+		return
+	}
+	C.free(unsafe.Pointer(meta.bucket))
+	C.free(unsafe.Pointer(meta.path))
+	C.free(unsafe.Pointer(meta.content_type))
+	C.free(unsafe.Pointer(meta.checksum_bytes))
+	*meta = C.ObjectMeta{}
+}
+
+// download starts downloading the object at path. The data is read with
+// download_read, and the handle is freed with download_close.
+//export download
+func download(bucketRef C.BucketRef, path *C.char, cerr *C.UplinkError) C.DownloaderRef {
+	bucket, ok := universe.get(int64(bucketRef._handle)).(*Bucket)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("bucket"))
+		return C.DownloaderRef{}
+	}
+	if path == nil {
+		setError(cerr, ErrInvalidArgument.New("path"))
+		return C.DownloaderRef{}
+	}
+
+	scope := bucket.child()
+	reader, err := bucket.lib.NewReader(scope.ctx, C.GoString(path))
+	if setError(cerr, err) {
+		scope.cancel()
+		return C.DownloaderRef{}
+	}
+	return C.DownloaderRef{_handle: C.int64_t(universe.add(&Download{scope, reader}))}
+}
+
+// download_range starts downloading length bytes of the object from offset,
+// up to the end of the object when length is -1
+//export download_range
+func download_range(objectRef C.ObjectRef, offset, length C.int64_t, cerr *C.UplinkError) C.DownloaderRef {
+	object, ok := universe.get(int64(objectRef._handle)).(*Object)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("object"))
+		return C.DownloaderRef{}
+	}
+
+	scope := object.child()
+	reader, err := object.lib.DownloadRange(scope.ctx, int64(offset), int64(length))
+	if setError(cerr, err) {
+		scope.cancel()
+		return C.DownloaderRef{}
+	}
+	return C.DownloaderRef{_handle: C.int64_t(universe.add(&Download{scope, reader}))}
+}
+
+// maxReadLength is the largest number of bytes read by download_read at once
+const maxReadLength = 1 << 30
+
+// download_read reads up to length bytes into bytes and returns the number
+// of bytes read, which is 0 at the end of the data
+//export download_read
+func download_read(downloader C.DownloaderRef, bytes *C.uint8_t, length C.size_t, cerr *C.UplinkError) C.size_t {
+	download, ok := universe.get(int64(downloader._handle)).(*Download)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("downloader"))
+		return 0
+	}
+	if bytes == nil || length == 0 {
+		setError(cerr, ErrInvalidArgument.New("bytes"))
+		return 0
+	}
+
+	if length > maxReadLength {
+		length = maxReadLength
+	}
+	buf := (*[maxReadLength]byte)(unsafe.Pointer(bytes))[:length:length]
+	for {
+		n, err := download.reader.Read(buf)
+		if n > 0 || err == io.EOF {
+			return C.size_t(n)
+		}
+		if setError(cerr, err) {
+			return 0
+		}
+	}
+}
+
+// download_close stops the download and frees its handle
+//export download_close
+func download_close(downloader C.DownloaderRef, cerr *C.UplinkError) {
+	download, ok := universe.del(int64(downloader._handle)).(*Download)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("downloader"))
+		return
+	}
+	defer download.cancel()
+
+	setError(cerr, download.reader.Close())
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"storj.io/storj/internal/memory"
+	libuplink "storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/storj"
+)
+
+// Bucket is a scoped libuplink.Bucket
+type Bucket struct {
+	scope
+	lib *libuplink.Bucket
+}
+
+// create_bucket creates a new bucket, using the defaults of the library for
+// the zero fields of cfg
+//export create_bucket
+func create_bucket(projectRef C.ProjectRef, name *C.char, cfg *C.BucketConfig, cerr *C.UplinkError) C.BucketInfo {
+	project, ok := universe.get(int64(projectRef._handle)).(*Project)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("project"))
+		return C.BucketInfo{}
+	}
+	if name == nil {
+		setError(cerr, ErrInvalidArgument.New("bucket name"))
+		return C.BucketInfo{}
+	}
+
+	var libcfg *libuplink.BucketConfig
+	if cfg != nil {
+		libcfg = &libuplink.BucketConfig{
+			PathCipher:           storj.CipherSuite(cfg.path_cipher),
+			EncryptionParameters: fromEncryptionParameters(cfg.encryption_parameters),
+		}
+		libcfg.Volatile.RedundancyScheme = fromRedundancyScheme(cfg.redundancy_scheme)
+		libcfg.Volatile.SegmentsSize = memory.Size(cfg.segment_size)
+	}
+
+	bucket, err := project.lib.CreateBucket(project.ctx, C.GoString(name), libcfg)
+	if setError(cerr, err) {
+		return C.BucketInfo{}
+	}
+	return newBucketInfo(bucket)
+}
+
+// get_bucket_info returns the bucket with name
+//export get_bucket_info
+func get_bucket_info(projectRef C.ProjectRef, name *C.char, cerr *C.UplinkError) C.BucketInfo {
+	project, ok := universe.get(int64(projectRef._handle)).(*Project)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("project"))
+		return C.BucketInfo{}
+	}
+	if name == nil {
+		setError(cerr, ErrInvalidArgument.New("bucket name"))
+		return C.BucketInfo{}
+	}
+
+	bucket, _, err := project.lib.GetBucketInfo(project.ctx, C.GoString(name))
+	if setError(cerr, err) {
+		return C.BucketInfo{}
+	}
+	return newBucketInfo(bucket)
+}
+
+// delete_bucket deletes the bucket with name
+//export delete_bucket
+func delete_bucket(projectRef C.ProjectRef, name *C.char, cerr *C.UplinkError) {
+	project, ok := universe.get(int64(projectRef._handle)).(*Project)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("project"))
+		return
+	}
+	if name == nil {
+		setError(cerr, ErrInvalidArgument.New("bucket name"))
+		return
+	}
+
+	setError(cerr, project.lib.DeleteBucket(project.ctx, C.GoString(name)))
+}
+
+// list_buckets lists the buckets of the project, all of them when opts is
+// NULL
+//export list_buckets
+func list_buckets(projectRef C.ProjectRef, opts *C.BucketListOptions, cerr *C.UplinkError) C.BucketList {
+	project, ok := universe.get(int64(projectRef._handle)).(*Project)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("project"))
+		return C.BucketList{}
+	}
+
+	libopts := &libuplink.BucketListOptions{Direction: storj.After}
+	if opts != nil {
+		libopts.Direction = storj.ListDirection(opts.direction)
+		libopts.Limit = int(opts.limit)
+		if opts.cursor != nil {
+			libopts.Cursor = C.GoString(opts.cursor)
+		}
+	}
+
+	list, err := project.lib.ListBuckets(project.ctx, libopts)
+	if setError(cerr, err) {
+		return C.BucketList{}
+	}
+
+	items := C.calloc(C.size_t(len(list.Items)), C.size_t(unsafe.Sizeof(C.BucketInfo{})))
+	for i, bucket := range list.Items {
+		*bucketInfoAt(items, i) = newBucketInfo(bucket)
+	}
+	return C.BucketList{
+		more:   C.bool(list.More),
+		items:  (*C.BucketInfo)(items),
+		length: C.int32_t(len(list.Items)),
+	}
+}
+
+// open_bucket opens the bucket with name for accessing its objects with
+// access
+//export open_bucket
+func open_bucket(projectRef C.ProjectRef, name *C.char, access *C.EncryptionAccess, cerr *C.UplinkError) C.BucketRef {
+	project, ok := universe.get(int64(projectRef._handle)).(*Project)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("project"))
+		return C.BucketRef{}
+	}
+	if name == nil {
+		setError(cerr, ErrInvalidArgument.New("bucket name"))
+		return C.BucketRef{}
+	}
+	if access == nil {
+		setError(cerr, ErrInvalidArgument.New("encryption access"))
+		return C.BucketRef{}
+	}
+
+	libaccess := &libuplink.EncryptionAccess{}
+	copy(libaccess.Key[:], C.GoBytes(unsafe.Pointer(&access.key[0]), C.int(len(libaccess.Key))))
+
+	scope := project.child()
+	bucket, err := project.lib.OpenBucket(scope.ctx, C.GoString(name), libaccess)
+	if setError(cerr, err) {
+		scope.cancel()
+		return C.BucketRef{}
+	}
+	return C.BucketRef{_handle: C.int64_t(universe.add(&Bucket{scope, bucket}))}
+}
+
+// close_bucket closes the bucket and frees its handle
+//export close_bucket
+func close_bucket(bucketRef C.BucketRef, cerr *C.UplinkError) {
+	bucket, ok := universe.del(int64(bucketRef._handle)).(*Bucket)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("bucket"))
+		return
+	}
+	defer bucket.cancel()
+
+	setError(cerr, bucket.lib.Close())
+}
+
+// free_bucket_info frees the strings of info
+//export free_bucket_info
+func free_bucket_info(info *C.BucketInfo) {
+	if info == nil {
+		return
+	}
+	C.free(unsafe.Pointer(info.name))
+	info.name = nil
+}
+
+// free_bucket_list frees the items of list
+//export free_bucket_list
+func free_bucket_list(list *C.BucketList) {
+	if list == nil {
+		return
+	}
+	for i := 0; i < int(list.length); i++ {
+		free_bucket_info(bucketInfoAt(unsafe.Pointer(list.items), i))
+	}
+	C.free(unsafe.Pointer(list.items))
+	list.items = nil
+	list.length = 0
+}
+
+// bucketInfoAt returns the i-th element of the C array items
+func bucketInfoAt(items unsafe.Pointer, i int) *C.BucketInfo {
+	return (*C.BucketInfo)(unsafe.Pointer(uintptr(items) + uintptr(i)*unsafe.Sizeof(C.BucketInfo{})))
+}
+
+func newBucketInfo(bucket storj.Bucket) C.BucketInfo {
+	return C.BucketInfo{
+		name:                  C.CString(bucket.Name),
+		created:               C.int64_t(bucket.Created.Unix()),
+		path_cipher:           C.uint8_t(bucket.PathCipher.ToCipherSuite()),
+		segment_size:          C.int64_t(bucket.SegmentsSize),
+		encryption_parameters: newEncryptionParameters(bucket.EncryptionParameters),
+		redundancy_scheme:     newRedundancyScheme(bucket.RedundancyScheme),
+	}
+}
+
+func newEncryptionParameters(params storj.EncryptionParameters) C.EncryptionParameters {
+	return C.EncryptionParameters{
+		cipher_suite: C.uint8_t(params.CipherSuite),
+		block_size:   C.int32_t(params.BlockSize),
+	}
+}
+
+func fromEncryptionParameters(params C.EncryptionParameters) storj.EncryptionParameters {
+	return storj.EncryptionParameters{
+		CipherSuite: storj.CipherSuite(params.cipher_suite),
+		BlockSize:   int32(params.block_size),
+	}
+}
+
+func newRedundancyScheme(scheme storj.RedundancyScheme) C.RedundancyScheme {
+	return C.RedundancyScheme{
+		algorithm:       C.uint8_t(scheme.Algorithm),
+		share_size:      C.int32_t(scheme.ShareSize),
+		required_shares: C.int16_t(scheme.RequiredShares),
+		repair_shares:   C.int16_t(scheme.RepairShares),
+		optimal_shares:  C.int16_t(scheme.OptimalShares),
+		total_shares:    C.int16_t(scheme.TotalShares),
+	}
+}
+
+func fromRedundancyScheme(scheme C.RedundancyScheme) storj.RedundancyScheme {
+	return storj.RedundancyScheme{
+		Algorithm:      storj.RedundancyAlgorithm(scheme.algorithm),
+		ShareSize:      int32(scheme.share_size),
+		RequiredShares: int16(scheme.required_shares),
+		RepairShares:   int16(scheme.repair_shares),
+		OptimalShares:  int16(scheme.optimal_shares),
+		TotalShares:    int16(scheme.total_shares),
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <string.h>
+
+#include "libuplinkc.h"
+#include "require.h"
+
+// upload_data uploads data to path in bucket in chunks of 1000 bytes.
+static void upload_data(BucketRef bucket, char *path, uint8_t *data, size_t length) {
+    UplinkError err = {0};
+
+    UploadOptions opts = {0};
+    opts.content_type = "application/octet-stream";
+
+    UploaderRef uploader = upload(bucket, path, &opts, &err);
+    require_noerror(err);
+
+    for (size_t written = 0; written < length;) {
+        size_t chunk = length - written;
+        if (chunk > 1000) {
+            chunk = 1000;
+        }
+        size_t n = upload_write(uploader, data + written, chunk, &err);
+        require_noerror(err);
+        written += n;
+    }
+
+    upload_commit(uploader, &err);
+    require_noerror(err);
+}
+
+// find_object returns the item of list with path. The items are sorted by
+// their encrypted paths, so their order is unknown.
+static ObjectInfo *find_object(ObjectList *list, char *path) {
+    for (int i = 0; i < list->length; i++) {
+        if (strcmp(list->items[i].path, path) == 0) {
+            return &list->items[i];
+        }
+    }
+    fprintf(stderr, "missing object %s\n", path);
+    exit(1);
+}
+
+// read_all reads the data of downloader into buf and returns its length.
+static size_t read_all(DownloaderRef downloader, uint8_t *buf, size_t length) {
+    UplinkError err = {0};
+
+    size_t total = 0;
+    for (;;) {
+        // read in small pieces to span several reads per segment
+        size_t n = download_read(downloader, buf + total, 777, &err);
+        require_noerror(err);
+        if (n == 0) {
+            break;
+        }
+        total += n;
+        require(total <= length);
+    }
+
+    download_close(downloader, &err);
+    require_noerror(err);
+    return total;
+}
+
+int main(void) {
+    UplinkError err = {0};
+
+    UplinkRef uplink = new_uplink(test_config(), &err);
+    require_noerror(err);
+
+    ProjectRef project = open_test_project(uplink);
+
+    BucketConfig cfg = test_bucket_config();
+    BucketInfo info = create_bucket(project, "objects", &cfg, &err);
+    require_noerror(err);
+    free_bucket_info(&info);
+
+    EncryptionAccess access = {0};
+    memcpy(access.key, "uplinkc", 7);
+    BucketRef bucket = open_bucket(project, "objects", &access, &err);
+    require_noerror(err);
+
+    // the data spans three segments
+    size_t length = 20 * 1024;
+    uint8_t *data = malloc(length);
+    for (size_t i = 0; i < length; i++) {
+        data[i] = (uint8_t)(i * 31);
+    }
+    uint8_t *buf = malloc(length + 777);
+
+    upload_data(bucket, "dir/first", data, length);
+    upload_data(bucket, "dir/second", data, 100);
+    upload_data(bucket, "third", data, length);
+
+    { // objects are listed
+        ObjectList list = list_objects(bucket, NULL, &err);
+        require_noerror(err);
+        require(list.length == 2);
+        require(strcmp(list.bucket, "objects") == 0);
+        require(find_object(&list, "dir/")->is_prefix);
+        require(find_object(&list, "third")->size == (int64_t)length);
+        free_list_objects(&list);
+
+        ListOptions opts = {0};
+        opts.prefix = "dir/";
+        opts.direction = STORJ_AFTER;
+        list = list_objects(bucket, &opts, &err);
+        require_noerror(err);
+        require(list.length == 2);
+        require(find_object(&list, "first")->size == (int64_t)length);
+        ObjectInfo *second = find_object(&list, "second");
+        require(strcmp(second->content_type, "application/octet-stream") == 0);
+        require(second->size == 100);
+        free_list_objects(&list);
+    }
+
+    { // objects are downloaded
+        DownloaderRef downloader = download(bucket, "dir/first", &err);
+        require_noerror(err);
+        require(read_all(downloader, buf, length + 777) == length);
+        require(memcmp(buf, data, length) == 0);
+    }
+
+    { // ranges of objects are downloaded
+        ObjectRef object = open_object(bucket, "third", &err);
+        require_noerror(err);
+
+        ObjectMeta meta = get_object_meta(object, &err);
+        require_noerror(err);
+        require(strcmp(meta.bucket, "objects") == 0);
+        require(strcmp(meta.path, "third") == 0);
+        require(meta.size == (int64_t)length);
+        require(meta.created > 0);
+        require(meta.expires == 0);
+        free_object_meta(&meta);
+
+        DownloaderRef downloader = download_range(object, 8000, 5000, &err);
+        require_noerror(err);
+        require(read_all(downloader, buf, length + 777) == 5000);
+        require(memcmp(buf, data + 8000, 5000) == 0);
+
+        downloader = download_range(object, 10000, -1, &err);
+        require_noerror(err);
+        require(read_all(downloader, buf, length + 777) == length - 10000);
+        require(memcmp(buf, data + 10000, length - 10000) == 0);
+
+        close_object(object, &err);
+        require_noerror(err);
+    }
+
+    { // canceled uploads don't create objects
+        UploaderRef uploader = upload(bucket, "canceled", NULL, &err);
+        require_noerror(err);
+        upload_write(uploader, data, 1000, &err);
+        require_noerror(err);
+        upload_cancel(uploader, &err);
+        require_noerror(err);
+
+        open_object(bucket, "canceled", &err);
+        require_error(err, UPLINK_ERROR_OBJECT_NOT_FOUND);
+    }
+
+    { // objects are deleted
+        delete_object(bucket, "third", &err);
+        require_noerror(err);
+
+        download(bucket, "third", &err);
+        require_error(err, UPLINK_ERROR_OBJECT_NOT_FOUND);
+
+        delete_object(bucket, "third", &err);
+        require_error(err, UPLINK_ERROR_OBJECT_NOT_FOUND);
+    }
+
+    free(data);
+    free(buf);
+
+    close_bucket(bucket, &err);
+    require_noerror(err);
+
+    close_project(project, &err);
+    require_noerror(err);
+
+    close_uplink(uplink, &err);
+    require_noerror(err);
+
+    return 0;
+}
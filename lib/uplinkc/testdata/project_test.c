@@ -0,0 +1,92 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <string.h>
+
+#include "libuplinkc.h"
+#include "require.h"
+
+int main(void) {
+    UplinkError err = {0};
+
+    { // api keys are parsed and serialized
+        APIKeyRef apikey = parse_api_key(require_env("APIKEY"), &err);
+        require_noerror(err);
+
+        char *serialized = serialize_api_key(apikey, &err);
+        require_noerror(err);
+        require(strcmp(serialized, require_env("APIKEY")) == 0);
+        free(serialized);
+
+        free_api_key(apikey);
+
+        // freed handles are invalid
+        serialize_api_key(apikey, &err);
+        require_error(err, UPLINK_ERROR_INVALID_HANDLE);
+    }
+
+    UplinkRef uplink = new_uplink(test_config(), &err);
+    require_noerror(err);
+
+    ProjectRef project = open_test_project(uplink);
+
+    { // buckets are created, listed and deleted
+        BucketConfig cfg = test_bucket_config();
+        char *names[] = {"alpha", "beta", "gamma"};
+        for (int i = 0; i < 3; i++) {
+            BucketInfo info = create_bucket(project, names[i], &cfg, &err);
+            require_noerror(err);
+            require(strcmp(info.name, names[i]) == 0);
+            require(info.created > 0);
+            require(info.path_cipher == STORJ_ENC_AESGCM);
+            require(info.segment_size == cfg.segment_size);
+            require(info.redundancy_scheme.total_shares == 5);
+            free_bucket_info(&info);
+        }
+
+        BucketInfo info = get_bucket_info(project, "beta", &err);
+        require_noerror(err);
+        require(strcmp(info.name, "beta") == 0);
+        require(info.encryption_parameters.cipher_suite == STORJ_ENC_AESGCM);
+        require(info.redundancy_scheme.required_shares == 2);
+        free_bucket_info(&info);
+
+        BucketList list = list_buckets(project, NULL, &err);
+        require_noerror(err);
+        require(list.length == 3);
+        require(!list.more);
+        for (int i = 0; i < list.length; i++) {
+            require(strcmp(list.items[i].name, names[i]) == 0);
+        }
+        free_bucket_list(&list);
+
+        BucketListOptions opts = {0};
+        opts.cursor = "alpha";
+        opts.direction = STORJ_AFTER;
+        opts.limit = 1;
+        list = list_buckets(project, &opts, &err);
+        require_noerror(err);
+        require(list.length == 1);
+        require(list.more);
+        require(strcmp(list.items[0].name, "beta") == 0);
+        free_bucket_list(&list);
+
+        delete_bucket(project, "gamma", &err);
+        require_noerror(err);
+
+        get_bucket_info(project, "gamma", &err);
+        require_error(err, UPLINK_ERROR_BUCKET_NOT_FOUND);
+    }
+
+    close_project(project, &err);
+    require_noerror(err);
+
+    // a closed project can not be used
+    list_buckets(project, NULL, &err);
+    require_error(err, UPLINK_ERROR_INVALID_HANDLE);
+
+    close_uplink(uplink, &err);
+    require_noerror(err);
+
+    return 0;
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+// require fails the test program when test is false.
+#define require(test) \
+    do { \
+        if (!(test)) { \
+            fprintf(stderr, "%s:%d: failed: %s\n", __FILE__, __LINE__, #test); \
+            exit(1); \
+        } \
+    } while (0)
+
+// require_noerror fails the test program when err is set.
+#define require_noerror(err) \
+    do { \
+        if ((err).code != UPLINK_OK) { \
+            fprintf(stderr, "%s:%d: error %d: %s\n", __FILE__, __LINE__, (err).code, (err).message); \
+            exit(1); \
+        } \
+    } while (0)
+
+// require_error fails the test program unless err is set with code, and
+// resets err for the next call.
+#define require_error(err, expected) \
+    do { \
+        if ((err).code != (expected)) { \
+            fprintf(stderr, "%s:%d: expected error %d, got %d: %s\n", __FILE__, __LINE__, (expected), (err).code, (err).message); \
+            exit(1); \
+        } \
+        free_error(&(err)); \
+    } while (0)
+
+// require_env returns the value of the environment variable name.
+static char *require_env(const char *name) {
+    char *value = getenv(name);
+    if (value == NULL) {
+        fprintf(stderr, "missing environment variable %s\n", name);
+        exit(1);
+    }
+    return value;
+}
+
+// test_config returns the uplink config for testplanet.
+static UplinkConfig test_config(void) {
+    UplinkConfig cfg = {0};
+    cfg.skip_peer_ca_whitelist = true;
+    // avoid inline segments, so that the data is stored on storage nodes
+    cfg.max_inline_size = 1;
+    return cfg;
+}
+
+// test_bucket_config returns a bucket config for the storage nodes of
+// testplanet.
+static BucketConfig test_bucket_config(void) {
+    BucketConfig cfg = {0};
+    cfg.path_cipher = STORJ_ENC_AESGCM;
+    cfg.encryption_parameters.cipher_suite = STORJ_ENC_AESGCM;
+    cfg.encryption_parameters.block_size = 1024;
+    cfg.redundancy_scheme.algorithm = STORJ_REED_SOLOMON;
+    cfg.redundancy_scheme.share_size = 1024;
+    cfg.redundancy_scheme.required_shares = 2;
+    cfg.redundancy_scheme.repair_shares = 3;
+    cfg.redundancy_scheme.optimal_shares = 4;
+    cfg.redundancy_scheme.total_shares = 5;
+    cfg.segment_size = 8 * 1024;
+    return cfg;
+}
+
+// open_test_project opens the project of the test API key.
+static ProjectRef open_test_project(UplinkRef uplink) {
+    UplinkError err = {0};
+
+    APIKeyRef apikey = parse_api_key(require_env("APIKEY"), &err);
+    require_noerror(err);
+
+    ProjectOptions opts = {0};
+    memcpy(opts.encryption_key, "uplinkc", 7);
+
+    ProjectRef project = open_project(uplink, require_env("SATELLITE_ADDR"), apikey, &opts, &err);
+    require_noerror(err);
+
+    free_api_key(apikey);
+    return project;
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+
+import (
+	"unsafe"
+
+	"storj.io/storj/internal/memory"
+	libuplink "storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/storj"
+)
+
+// Uplink is a scoped libuplink.Uplink
+type Uplink struct {
+	scope
+	lib *libuplink.Uplink
+}
+
+// new_uplink creates a new uplink with cfg
+//export new_uplink
+func new_uplink(cfg C.UplinkConfig, cerr *C.UplinkError) C.UplinkRef {
+	scope := rootScope()
+
+	libcfg := &libuplink.Config{}
+	libcfg.Volatile.TLS.SkipPeerCAWhitelist = bool(cfg.skip_peer_ca_whitelist)
+	libcfg.Volatile.MaxInlineSize = memory.Size(cfg.max_inline_size)
+	libcfg.Volatile.MaxMemory = memory.Size(cfg.max_memory)
+
+	lib, err := libuplink.NewUplink(scope.ctx, libcfg)
+	if setError(cerr, err) {
+		scope.cancel()
+		return C.UplinkRef{}
+	}
+	return C.UplinkRef{_handle: C.int64_t(universe.add(&Uplink{scope, lib}))}
+}
+
+// close_uplink closes the uplink and frees its handle
+//export close_uplink
+func close_uplink(uplinkRef C.UplinkRef, cerr *C.UplinkError) {
+	uplink, ok := universe.del(int64(uplinkRef._handle)).(*Uplink)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("uplink"))
+		return
+	}
+	defer uplink.cancel()
+
+	setError(cerr, uplink.lib.Close())
+}
+
+// parse_api_key parses a serialized API key
+//export parse_api_key
+func parse_api_key(val *C.char, cerr *C.UplinkError) C.APIKeyRef {
+	if val == nil {
+		setError(cerr, ErrInvalidArgument.New("api key"))
+		return C.APIKeyRef{}
+	}
+
+	apikey, err := libuplink.ParseAPIKey(C.GoString(val))
+	if setError(cerr, err) {
+		return C.APIKeyRef{}
+	}
+	return C.APIKeyRef{_handle: C.int64_t(universe.add(apikey))}
+}
+
+// serialize_api_key returns the serialized API key, which must be freed by
+// the caller
+//export serialize_api_key
+func serialize_api_key(apikeyRef C.APIKeyRef, cerr *C.UplinkError) *C.char {
+	apikey, ok := universe.get(int64(apikeyRef._handle)).(libuplink.APIKey)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("api key"))
+		return nil
+	}
+	return C.CString(apikey.Serialize())
+}
+
+// free_api_key frees the handle of the API key
+//export free_api_key
+func free_api_key(apikeyRef C.APIKeyRef) {
+	universe.del(int64(apikeyRef._handle))
+}
+
+// Project is a scoped libuplink.Project
+type Project struct {
+	scope
+	lib *libuplink.Project
+}
+
+// open_project opens the project of apikey on the satellite at satelliteAddr
+//export open_project
+func open_project(uplinkRef C.UplinkRef, satelliteAddr *C.char, apikeyRef C.APIKeyRef, opts *C.ProjectOptions, cerr *C.UplinkError) C.ProjectRef {
+	uplink, ok := universe.get(int64(uplinkRef._handle)).(*Uplink)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("uplink"))
+		return C.ProjectRef{}
+	}
+	apikey, ok := universe.get(int64(apikeyRef._handle)).(libuplink.APIKey)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("api key"))
+		return C.ProjectRef{}
+	}
+	if satelliteAddr == nil {
+		setError(cerr, ErrInvalidArgument.New("satellite address"))
+		return C.ProjectRef{}
+	}
+
+	var libopts *libuplink.ProjectOptions
+	if opts != nil {
+		var key storj.Key
+		copy(key[:], C.GoBytes(unsafe.Pointer(&opts.encryption_key[0]), C.int(len(key))))
+		libopts = &libuplink.ProjectOptions{}
+		libopts.Volatile.EncryptionKey = &key
+	}
+
+	scope := uplink.child()
+	project, err := uplink.lib.OpenProject(scope.ctx, C.GoString(satelliteAddr), apikey, libopts)
+	if setError(cerr, err) {
+		scope.cancel()
+		return C.ProjectRef{}
+	}
+	return C.ProjectRef{_handle: C.int64_t(universe.add(&Project{scope, project}))}
+}
+
+// close_project closes the project and frees its handle
+//export close_project
+func close_project(projectRef C.ProjectRef, cerr *C.UplinkError) {
+	project, ok := universe.del(int64(projectRef._handle)).(*Project)
+	if !ok {
+		setError(cerr, ErrInvalidHandle.New("project"))
+		return
+	}
+	defer project.cancel()
+
+	setError(cerr, project.lib.Close())
+}
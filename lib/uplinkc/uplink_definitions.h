@@ -0,0 +1,174 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#ifndef UPLINK_DEFINITIONS_H
+#define UPLINK_DEFINITIONS_H
+
+#include <stdbool.h>
+#include <stdint.h>
+#include <stdlib.h>
+
+// Error codes are stored in UplinkError.code.
+enum {
+    // UPLINK_OK means that the call succeeded.
+    UPLINK_OK = 0,
+    // UPLINK_ERROR_INTERNAL is any error which has no other code.
+    UPLINK_ERROR_INTERNAL = 1,
+    // UPLINK_ERROR_INVALID_HANDLE means that a handle was already freed or
+    // is of the wrong kind.
+    UPLINK_ERROR_INVALID_HANDLE = 2,
+    // UPLINK_ERROR_INVALID_ARGUMENT means that an argument was malformed.
+    UPLINK_ERROR_INVALID_ARGUMENT = 3,
+    // UPLINK_ERROR_BUCKET_NOT_FOUND means that the bucket does not exist.
+    UPLINK_ERROR_BUCKET_NOT_FOUND = 4,
+    // UPLINK_ERROR_OBJECT_NOT_FOUND means that the object does not exist.
+    UPLINK_ERROR_OBJECT_NOT_FOUND = 5,
+    // UPLINK_ERROR_CANCELED means that the operation was canceled.
+    UPLINK_ERROR_CANCELED = 6,
+};
+
+// UplinkError describes why a call failed. It must be zeroed before it is
+// passed to a call, and freed with free_error when a call set it.
+typedef struct UplinkError {
+    int32_t code;
+    char *message;
+} UplinkError;
+
+// Handles refer to objects of the library. They must be freed with the
+// close or free function of their kind.
+typedef struct APIKeyRef     { int64_t _handle; } APIKeyRef;
+typedef struct UplinkRef     { int64_t _handle; } UplinkRef;
+typedef struct ProjectRef    { int64_t _handle; } ProjectRef;
+typedef struct BucketRef     { int64_t _handle; } BucketRef;
+typedef struct ObjectRef     { int64_t _handle; } ObjectRef;
+typedef struct UploaderRef   { int64_t _handle; } UploaderRef;
+typedef struct DownloaderRef { int64_t _handle; } DownloaderRef;
+
+// UplinkConfig configures an uplink. All of its fields are volatile, which
+// means that they may change semantics or go away between releases.
+typedef struct UplinkConfig {
+    bool skip_peer_ca_whitelist;
+    int64_t max_inline_size;
+    int64_t max_memory;
+} UplinkConfig;
+
+typedef struct ProjectOptions {
+    uint8_t encryption_key[32];
+} ProjectOptions;
+
+typedef struct EncryptionAccess {
+    uint8_t key[32];
+} EncryptionAccess;
+
+typedef struct RedundancyScheme {
+    uint8_t algorithm;
+    int32_t share_size;
+    int16_t required_shares;
+    int16_t repair_shares;
+    int16_t optimal_shares;
+    int16_t total_shares;
+} RedundancyScheme;
+
+typedef struct EncryptionParameters {
+    uint8_t cipher_suite;
+    int32_t block_size;
+} EncryptionParameters;
+
+typedef struct BucketConfig {
+    uint8_t path_cipher;
+    EncryptionParameters encryption_parameters;
+    RedundancyScheme redundancy_scheme;
+    int64_t segment_size;
+} BucketConfig;
+
+// BucketInfo must be freed with free_bucket_info. Times are in seconds
+// since the Unix epoch.
+typedef struct BucketInfo {
+    char *name;
+    int64_t created;
+    uint8_t path_cipher;
+    int64_t segment_size;
+    EncryptionParameters encryption_parameters;
+    RedundancyScheme redundancy_scheme;
+} BucketInfo;
+
+typedef struct BucketListOptions {
+    char *cursor;
+    int8_t direction;
+    int64_t limit;
+} BucketListOptions;
+
+// BucketList must be freed with free_bucket_list.
+typedef struct BucketList {
+    bool more;
+    BucketInfo *items;
+    int32_t length;
+} BucketList;
+
+typedef struct ListOptions {
+    char *prefix;
+    char *cursor;
+    char delimiter;
+    bool recursive;
+    int8_t direction;
+    int64_t limit;
+} ListOptions;
+
+// ObjectInfo describes an object in an ObjectList. Times are in seconds
+// since the Unix epoch.
+typedef struct ObjectInfo {
+    char *path;
+    bool is_prefix;
+    char *content_type;
+    int64_t created;
+    int64_t modified;
+    int64_t expires;
+    int64_t size;
+} ObjectInfo;
+
+// ObjectList must be freed with free_list_objects.
+typedef struct ObjectList {
+    char *bucket;
+    char *prefix;
+    bool more;
+    ObjectInfo *items;
+    int32_t length;
+} ObjectList;
+
+// ObjectMeta must be freed with free_object_meta. Times are in seconds
+// since the Unix epoch.
+typedef struct ObjectMeta {
+    char *bucket;
+    char *path;
+    bool is_prefix;
+    char *content_type;
+    int64_t created;
+    int64_t modified;
+    int64_t expires;
+    int64_t size;
+    uint8_t *checksum_bytes;
+    int64_t checksum_length;
+} ObjectMeta;
+
+typedef struct UploadOptions {
+    char *content_type;
+    // expires is in seconds since the Unix epoch, 0 means never
+    int64_t expires;
+} UploadOptions;
+
+// Redundancy algorithms, cipher suites and list directions.
+enum {
+    STORJ_REED_SOLOMON = 1,
+
+    STORJ_ENC_UNSPECIFIED = 0,
+    STORJ_ENC_NULL = 1,
+    STORJ_ENC_AESGCM = 2,
+    STORJ_ENC_SECRETBOX = 3,
+
+    STORJ_BEFORE = -2,
+    STORJ_BACKWARD = -1,
+    STORJ_FORWARD = 1,
+    STORJ_AFTER = 2,
+};
+
+#endif
@@ -0,0 +1,62 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+)
+
+// TestC runs the C test programs in testdata against the library
+func TestC(t *testing.T) {
+	if _, err := exec.LookPath("gcc"); err != nil {
+		t.Skip("gcc is not available:", err)
+	}
+
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	lib := ctx.CompileShared("uplinkc", "storj.io/storj/lib/uplinkc")
+
+	definitions, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests, err := filepath.Glob("testdata/*_test.c")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, src := range tests {
+		src := src
+		t.Run(filepath.Base(src), func(t *testing.T) {
+			exe := ctx.CompileC(src, []string{definitions, "testdata"}, lib)
+
+			testplanet.Run(t, testplanet.Config{
+				SatelliteCount: 1, StorageNodeCount: 5, UplinkCount: 1,
+			}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+				satellite := planet.Satellites[0]
+
+				cmd := exec.Command(exe)
+				cmd.Dir = filepath.Dir(exe)
+				cmd.Env = append(os.Environ(),
+					"SATELLITE_ADDR="+satellite.Addr(),
+					"APIKEY="+planet.Uplinks[0].APIKey[satellite.ID()],
+				)
+
+				out, err := cmd.CombinedOutput()
+				if err != nil {
+					t.Error(string(out))
+					t.Fatal(err)
+				}
+			})
+		})
+	}
+}
@@ -6,8 +6,11 @@ package mobile
 import (
 	"fmt"
 	"io"
+	"os"
 	"time"
 
+	"github.com/zeebo/errs"
+
 	libuplink "storj.io/storj/lib/uplink"
 	"storj.io/storj/pkg/storj"
 )
@@ -271,14 +274,64 @@ func NewWriterOptions() *WriterOptions {
 // Writer writes data into object
 type Writer struct {
 	scope
-	writer io.WriteCloser
+	writer   libuplink.PausableWriter
+	progress *progressObserver
+
+	bucket  string
+	path    storj.Path
+	options *WriterOptions
+	offset  int64
 }
 
-// NewWriter creates instance of Writer
+// NewWriter creates instance of Writer. A paused upload of the object at
+// path is discarded.
 func (bucket *Bucket) NewWriter(path storj.Path, options *WriterOptions) (*Writer, error) {
+	if err := bucket.discardPaused(path); err != nil {
+		return nil, safeError(err)
+	}
+	return bucket.newWriter(path, options, nil)
+}
+
+// discardPaused deletes the segments and the state file of a paused upload
+// of the object at path, if there is one
+func (bucket *Bucket) discardPaused(path storj.Path) error {
+	if bucket.tempDir == "" {
+		return nil
+	}
+	if _, err := os.Stat(writerStatePath(bucket.tempDir, bucket.Name, path)); os.IsNotExist(err) {
+		return nil
+	}
+
+	// a state file which cannot be loaded is removed as well
+	state, err := loadWriterState(bucket.tempDir, bucket.Name, path)
+	if err == nil {
+		err = bucket.lib.DeletePausedUpload(bucket.ctx, path, state.Upload)
+	}
+	return errs.Combine(err, removeWriterState(bucket.tempDir, bucket.Name, path))
+}
+
+// ResumeWriter continues the paused upload of the object at path, whose
+// progress was saved by Writer.Pause. The data written to the returned
+// Writer must start at its Offset.
+func (bucket *Bucket) ResumeWriter(path storj.Path) (*Writer, error) {
+	if bucket.tempDir == "" {
+		return nil, fmt.Errorf("resuming uploads requires a temporary directory")
+	}
+
+	state, err := loadWriterState(bucket.tempDir, bucket.Name, path)
+	if err != nil {
+		return nil, safeError(err)
+	}
+	return bucket.newWriter(path, state.Options, state.Upload)
+}
+
+// newWriter creates a Writer, which resumes the upload of state unless it
+// is nil
+func (bucket *Bucket) newWriter(path storj.Path, options *WriterOptions, state *libuplink.UploadState) (*Writer, error) {
 	scope := bucket.scope.child()
 
-	opts := &libuplink.UploadOptions{}
+	progress := &progressObserver{}
+	opts := &libuplink.UploadOptions{Observer: progress}
 	if options != nil {
 		opts.ContentType = options.ContentType
 		opts.Metadata = options.Metadata
@@ -289,11 +342,37 @@ func (bucket *Bucket) NewWriter(path storj.Path, options *WriterOptions) (*Write
 		opts.Volatile.RedundancyScheme = newStorjRedundancyScheme(options.RedundancyScheme)
 	}
 
-	writer, err := bucket.lib.NewWriter(scope.ctx, path, opts)
+	var offset int64
+	if state != nil {
+		offset = state.Offset()
+		progress.transferred = offset
+	}
+
+	writer, err := bucket.lib.ResumeWriter(scope.ctx, path, opts, state)
 	if err != nil {
 		return nil, safeError(err)
 	}
-	return &Writer{scope, writer}, nil
+	return &Writer{
+		scope:    scope,
+		writer:   writer,
+		progress: progress,
+		bucket:   bucket.Name,
+		path:     path,
+		options:  options,
+		offset:   offset,
+	}, nil
+}
+
+// Offset returns the offset of the object data from which the Writer
+// continues the upload. It is 0 unless the Writer resumes a paused upload.
+func (w *Writer) Offset() int64 {
+	return w.offset
+}
+
+// SetProgress sets the callback which is notified about the progress of
+// the upload. It may be nil.
+func (w *Writer) SetProgress(callback ProgressCallback) {
+	w.progress.setCallback(callback)
 }
 
 // Write writes data.length bytes from data to the underlying data stream.
@@ -303,15 +382,51 @@ func (w *Writer) Write(data []byte, offset, length int32) (int32, error) {
 	return int32(n), safeError(err)
 }
 
-// Cancel cancels writing operation
+// Pause stops the upload without completing it, e.g. before the app is
+// suspended. The segments which were uploaded completely are kept, and the
+// progress is saved to a state file in the temporary directory, so that the
+// upload can be continued with Bucket.ResumeWriter, even after the app was
+// restarted. Pause returns the offset of the object data from which the
+// upload has to be continued.
+func (w *Writer) Pause() (int64, error) {
+	if w.tempDir == "" {
+		return 0, fmt.Errorf("pausing uploads requires a temporary directory")
+	}
+	defer w.cancel()
+
+	state, err := w.writer.Pause()
+	if err != nil {
+		return 0, safeError(err)
+	}
+
+	err = saveWriterState(w.tempDir, &writerState{
+		Bucket:  w.bucket,
+		Path:    w.path,
+		Options: w.options,
+		Upload:  state,
+	})
+	if err != nil {
+		return 0, safeError(err)
+	}
+	return state.Offset(), nil
+}
+
+// Cancel cancels writing operation and deletes the data which was uploaded,
+// including the data and the state file of the paused upload the Writer
+// resumes.
 func (w *Writer) Cancel() {
-	w.cancel()
+	defer w.cancel()
+	_ = w.writer.Cancel()
+	_ = removeWriterState(w.tempDir, w.bucket, w.path)
 }
 
 // Close closes writer
 func (w *Writer) Close() error {
 	defer w.cancel()
-	return safeError(w.writer.Close())
+	if err := w.writer.Close(); err != nil {
+		return safeError(err)
+	}
+	return safeError(removeWriterState(w.tempDir, w.bucket, w.path))
 }
 
 // ReaderOptions options for reading
@@ -321,6 +436,7 @@ type ReaderOptions struct {
 // Reader reader for downloading object
 type Reader struct {
 	scope
+	progress  *progressObserver
 	readError error
 	reader    interface {
 		io.Reader
@@ -333,16 +449,24 @@ type Reader struct {
 func (bucket *Bucket) NewReader(path storj.Path, options *ReaderOptions) (*Reader, error) {
 	scope := bucket.scope.child()
 
-	reader, err := bucket.lib.NewReader(scope.ctx, path)
+	progress := &progressObserver{}
+	reader, err := bucket.lib.NewReaderWithOptions(scope.ctx, path, &libuplink.DownloadOptions{Observer: progress})
 	if err != nil {
 		return nil, safeError(err)
 	}
 	return &Reader{
-		scope:  scope,
-		reader: reader,
+		scope:    scope,
+		progress: progress,
+		reader:   reader,
 	}, nil
 }
 
+// SetProgress sets the callback which is notified about the progress of
+// the download. It may be nil.
+func (r *Reader) SetProgress(callback ProgressCallback) {
+	r.progress.setCallback(callback)
+}
+
 // Read reads data into byte array
 func (r *Reader) Read(data []byte) (n int32, err error) {
 	if r.readError != nil {
@@ -0,0 +1,100 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package mobile
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/storage"
+)
+
+func TestWriterCancel(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 0, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+
+		uplink, err := NewUplink(&Config{MaxInlineSize: 4 * memory.KiB.Int64()}, ctx.Dir("temp"))
+		require.NoError(t, err)
+		defer ctx.Check(uplink.Close)
+
+		project, err := uplink.OpenProject(satellite.Addr(), planet.Uplinks[0].APIKey[satellite.ID()], &ProjectOptions{EncryptionKey: []byte("key")})
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		_, err = project.CreateBucket("bucket", &BucketConfig{SegmentsSize: memory.KiB.Int64()})
+		require.NoError(t, err)
+		bucket, err := project.OpenBucket("bucket", &BucketAccess{PathEncryptionKey: []byte("key")})
+		require.NoError(t, err)
+		defer ctx.Check(bucket.Close)
+
+		data := make([]byte, 5000)
+		_, err = rand.Read(data)
+		require.NoError(t, err)
+
+		pointers := func() (count int) {
+			err := satellite.Metainfo.Database.Iterate(storage.IterateOptions{Recurse: true}, func(it storage.Iterator) error {
+				var item storage.ListItem
+				for it.Next(&item) {
+					count++
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			return count
+		}
+		initial := pointers()
+
+		// pause writes 3 segments of 1 KiB and returns the offset after them
+		pause := func(writer *Writer) int64 {
+			_, err := writer.Write(data, int32(writer.Offset()), int32(writer.Offset())+3500)
+			require.NoError(t, err)
+			offset, err := writer.Pause()
+			require.NoError(t, err)
+			return offset
+		}
+
+		{ // canceling a resumed upload deletes the segments of the paused upload
+			writer, err := bucket.NewWriter("object", nil)
+			require.NoError(t, err)
+			offset := pause(writer)
+			assert.EqualValues(t, 3*memory.KiB, offset)
+			assert.Equal(t, initial+3, pointers())
+
+			writer, err = bucket.ResumeWriter("object")
+			require.NoError(t, err)
+			_, err = writer.Write(data, int32(offset), int32(offset)+1500)
+			require.NoError(t, err)
+			writer.Cancel()
+			assert.Equal(t, initial, pointers())
+
+			// the state of the paused upload is removed as well
+			_, err = bucket.ResumeWriter("object")
+			assert.Error(t, err)
+		}
+
+		{ // a new upload discards the paused upload
+			writer, err := bucket.NewWriter("object", nil)
+			require.NoError(t, err)
+			pause(writer)
+			assert.Equal(t, initial+3, pointers())
+
+			writer, err = bucket.NewWriter("object", nil)
+			require.NoError(t, err)
+			assert.Equal(t, initial, pointers())
+
+			_, err = writer.Write(data, 0, int32(len(data)))
+			require.NoError(t, err)
+			require.NoError(t, writer.Close())
+			assert.Equal(t, initial+5, pointers())
+		}
+	})
+}
@@ -22,4 +22,9 @@
 //   implementation fileTree(dir: 'libs', include: ['*.jar', '*.aar'])
 //
 // See example Java file
+//
+// Uploads can be paused with Writer.Pause, e.g. before the app is suspended,
+// and continued with Bucket.ResumeWriter, even after the app was restarted.
+// The progress of paused uploads is kept in the temporary directory passed
+// to NewUplink, so it is not available with "inmemory".
 package mobile
@@ -9,6 +9,7 @@ import org.junit.Test;
 import org.junit.runner.RunWith;
 
 import java.io.ByteArrayOutputStream;
+import java.util.Arrays;
 import java.util.HashSet;
 import java.util.Random;
 import java.util.Set;
@@ -274,6 +275,106 @@ public class LibuplinkInstrumentedTest {
         }
     }
 
+    @Test
+    public void testPauseResumeUpload() throws Exception {
+        Config config = new Config();
+
+        Uplink uplink = new Uplink(config, filesDir);
+        try {
+            ProjectOptions options = new ProjectOptions();
+            options.setEncryptionKey("TestEncryptionKey".getBytes());
+
+            Project project = uplink.openProject(VALID_SATELLITE_ADDRESS, VALID_API_KEY, options);
+            try {
+                BucketAccess access = new BucketAccess();
+                access.setPathEncryptionKey("TestEncryptionKey".getBytes());
+
+                RedundancyScheme scheme = new RedundancyScheme();
+                scheme.setRequiredShares((short) 2);
+                scheme.setRepairShares((short) 4);
+                scheme.setOptimalShares((short) 6);
+                scheme.setTotalShares((short) 8);
+
+                BucketConfig bucketConfig = new BucketConfig();
+                bucketConfig.setRedundancyScheme(scheme);
+                bucketConfig.setSegmentsSize(64 * 1024);
+
+                project.createBucket("test", bucketConfig);
+
+                Bucket bucket = project.openBucket("test", access);
+
+                byte[] expectedData = new byte[1024 * 200];
+                Random random = new Random();
+                random.nextBytes(expectedData);
+
+                final long[] transferred = new long[1];
+                ProgressCallback progress = new ProgressCallback() {
+                    @Override
+                    public void onProgress(long bytes) {
+                        transferred[0] = bytes;
+                    }
+
+                    @Override
+                    public void onPieceFinished(long segment, String nodeID, long status, long retries) {
+                    }
+                };
+
+                long offset;
+                {
+                    Writer writer = bucket.newWriter("object/path", new WriterOptions());
+                    writer.setProgress(progress);
+                    writer.write(expectedData, 0, 1024 * 100);
+                    offset = writer.pause();
+                    assertEquals(64 * 1024, offset);
+                }
+
+                {
+                    Writer writer = bucket.resumeWriter("object/path");
+                    try {
+                        writer.setProgress(progress);
+                        assertEquals(offset, writer.offset());
+                        byte[] rest = Arrays.copyOfRange(expectedData, (int) offset, expectedData.length);
+                        writer.write(rest, 0, rest.length);
+                    } finally {
+                        writer.close();
+                    }
+                    assertEquals(expectedData.length, transferred[0]);
+                }
+
+                {
+                    Reader reader = bucket.newReader("object/path", new ReaderOptions());
+                    try {
+                        ByteArrayOutputStream writer = new ByteArrayOutputStream();
+                        byte[] buf = new byte[4096];
+                        int read = 0;
+                        while ((read = reader.read(buf)) != -1) {
+                            writer.write(buf, 0, read);
+                        }
+                        assertArrayEquals(expectedData, writer.toByteArray());
+                    } finally {
+                        reader.close();
+                    }
+                }
+
+                try {
+                    bucket.resumeWriter("object/path");
+                    fail("exception expected");
+                } catch (Exception e) {
+                    assertTrue(e.getMessage().startsWith("no paused upload"));
+                }
+
+                bucket.deleteObject("object/path");
+                bucket.close();
+
+                project.deleteBucket("test");
+            } finally {
+                project.close();
+            }
+        } finally {
+            uplink.close();
+        }
+    }
+
     @Test
     public void testListObjects() throws Exception {
         Config config = new Config();
@@ -0,0 +1,69 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package mobile
+
+import (
+	"sync"
+
+	libuplink "storj.io/storj/lib/uplink"
+)
+
+const (
+	// TransferSucceeded means that a piece was transferred completely
+	TransferSucceeded = int(libuplink.TransferSucceeded)
+	// TransferFailed means that the transfer of a piece failed
+	TransferFailed = int(libuplink.TransferFailed)
+	// TransferCanceled means that the transfer of a piece was canceled,
+	// because enough other pieces were transferred
+	TransferCanceled = int(libuplink.TransferCanceled)
+)
+
+// ProgressCallback is notified about the progress of an upload or a
+// download. It is implemented by the app and called from background threads.
+type ProgressCallback interface {
+	// OnProgress is called when data was transferred, with the number of
+	// bytes of the object transferred so far. For resumed uploads it
+	// includes the data uploaded before the upload was paused.
+	OnProgress(transferred int64)
+	// OnPieceFinished is called when the transfer of a piece of segment to
+	// or from the storage node nodeID ended with status, which is one of
+	// TransferSucceeded, TransferFailed and TransferCanceled.
+	OnPieceFinished(segment int64, nodeID string, status int)
+}
+
+// progressObserver passes the progress of a transfer to a callback, which
+// can be set while the transfer is running
+type progressObserver struct {
+	mu          sync.Mutex
+	callback    ProgressCallback
+	transferred int64
+}
+
+// setCallback sets the callback, which may be nil
+func (observer *progressObserver) setCallback(callback ProgressCallback) {
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	observer.callback = callback
+}
+
+// Progress implements libuplink.TransferObserver
+func (observer *progressObserver) Progress(segment int64, bytes int64) {
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	observer.transferred += bytes
+	if observer.callback != nil {
+		observer.callback.OnProgress(observer.transferred)
+	}
+}
+
+// PieceFinished implements libuplink.TransferObserver
+func (observer *progressObserver) PieceFinished(result libuplink.PieceResult) {
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+
+	if observer.callback != nil {
+		observer.callback.OnPieceFinished(result.Segment, result.NodeID.String(), int(result.Status))
+	}
+}
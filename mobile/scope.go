@@ -12,20 +12,24 @@ import (
 type scope struct {
 	ctx    context.Context
 	cancel func()
+	// tempDir is the directory for temporary data, which is empty when
+	// temporary data is kept in memory
+	tempDir string
 }
 
 func rootScope(tempDir string) scope {
 	ctx := context.Background()
 	if tempDir == "inmemory" {
 		ctx = fpath.WithTempData(ctx, "", true)
+		tempDir = ""
 	} else {
 		ctx = fpath.WithTempData(ctx, tempDir, false)
 	}
 	ctx, cancel := context.WithCancel(ctx)
-	return scope{ctx, cancel}
+	return scope{ctx, cancel, tempDir}
 }
 
 func (parent *scope) child() scope {
 	ctx, cancel := context.WithCancel(parent.ctx)
-	return scope{ctx, cancel}
+	return scope{ctx, cancel, parent.tempDir}
 }
@@ -0,0 +1,86 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package mobile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	libuplink "storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/storj"
+)
+
+// writerState is the progress of a paused upload, which is saved to a state
+// file in the temporary directory
+type writerState struct {
+	Bucket  string
+	Path    storj.Path
+	Options *WriterOptions
+	Upload  *libuplink.UploadState
+}
+
+// writerStatePath returns the path of the state file of the upload of path
+// in bucket
+func writerStatePath(tempDir, bucket string, path storj.Path) string {
+	hash := sha256.Sum256([]byte(storj.JoinPaths(bucket, path)))
+	return filepath.Join(tempDir, "uploads", hex.EncodeToString(hash[:])+".json")
+}
+
+// saveWriterState saves state to its state file
+func saveWriterState(tempDir string, state *writerState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	path := writerStatePath(tempDir, state.Bucket, state.Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	// the state file is replaced at once, so that it is never incomplete
+	temp := path + ".tmp"
+	if err := ioutil.WriteFile(temp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(temp, path)
+}
+
+// loadWriterState loads the state of the paused upload of path in bucket
+func loadWriterState(tempDir, bucket string, path storj.Path) (*writerState, error) {
+	data, err := ioutil.ReadFile(writerStatePath(tempDir, bucket, path))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no paused upload of %q", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	state := &writerState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Bucket != bucket || state.Path != path || state.Upload == nil {
+		return nil, fmt.Errorf("invalid state of paused upload of %q", path)
+	}
+	return state, nil
+}
+
+// removeWriterState removes the state file of the upload of path in bucket,
+// if there is one
+func removeWriterState(tempDir, bucket string, path storj.Path) error {
+	if tempDir == "" {
+		return nil
+	}
+	err := os.Remove(writerStatePath(tempDir, bucket, path))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
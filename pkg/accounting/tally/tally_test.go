@@ -82,7 +82,7 @@ func TestOnlyInline(t *testing.T) {
 			InlineFiles:    1,
 			Bytes:          expectedTotalBytes,
 			InlineBytes:    expectedTotalBytes,
-			MetadataSize:   111, // brittle, this is hardcoded since its too difficult to get this value progamatically
+			MetadataSize:   163, // brittle, this is hardcoded since its too difficult to get this value progamatically
 		}
 
 		// Execute test: upload a file, then calculate at rest data
@@ -134,7 +134,7 @@ func TestCalculateAtRestData(t *testing.T) {
 			RemoteFiles:    1,
 			Bytes:          expectedTotalBytes,
 			RemoteBytes:    expectedTotalBytes,
-			MetadataSize:   112, // brittle, this is hardcoded since its too difficult to get this value progamatically
+			MetadataSize:   164, // brittle, this is hardcoded since its too difficult to get this value progamatically
 		}
 
 		// Execute test: upload a file, then calculate at rest data
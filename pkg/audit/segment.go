@@ -0,0 +1,54 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/satellite/orders"
+)
+
+// SegmentAuditor audits single segments outside of the audit loop, e.g. the
+// segments whose pieces were just placed on new nodes by a repair
+type SegmentAuditor struct {
+	verifier *Verifier
+	reporter reporter
+}
+
+// NewSegmentAuditor creates a segment auditor
+func NewSegmentAuditor(log *zap.Logger, config Config, transport transport.Client, overlay *overlay.Cache, containment Containment, orders *orders.Service, identity *identity.FullIdentity) *SegmentAuditor {
+	reporter := NewReporter(overlay, containment, config.MaxRetriesStatDB)
+	return &SegmentAuditor{
+		verifier: NewVerifier(log, reporter, transport, overlay, containment, orders, identity, config.MinBytesPerSecond),
+		reporter: reporter,
+	}
+}
+
+// AuditSegment verifies a random stripe of the segment at path and records
+// the results like the audit loop does
+func (auditor *SegmentAuditor) AuditSegment(ctx context.Context, path storj.Path, pointer *pb.Pointer) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	index, err := getRandomStripe(pointer)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	report, err := auditor.verifier.Verify(ctx, &Stripe{
+		Index:       index,
+		Segment:     pointer,
+		SegmentPath: path,
+	}, nil)
+
+	_, recordErr := auditor.reporter.RecordAudits(ctx, report)
+	return errs.Combine(err, recordErr)
+}
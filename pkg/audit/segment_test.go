@@ -0,0 +1,65 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/audit"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/uplink"
+)
+
+func TestSegmentAuditor(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 6, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Audit.Service.Loop.Pause()
+
+		testData := make([]byte, 1*memory.MiB)
+		_, err := rand.Read(testData)
+		require.NoError(t, err)
+
+		err = planet.Uplinks[0].UploadWithConfig(ctx, satellite, &uplink.RSConfig{
+			MinThreshold:     4,
+			RepairThreshold:  5,
+			SuccessThreshold: 6,
+			MaxThreshold:     6,
+		}, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		stripe, _, err := audit.NewCursor(satellite.Metainfo.Service).NextStripe(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, stripe)
+
+		auditCounts := func() map[storj.NodeID]int64 {
+			counts := map[storj.NodeID]int64{}
+			for _, piece := range stripe.Segment.GetRemote().GetRemotePieces() {
+				node, err := satellite.Overlay.Service.Get(ctx, piece.NodeId)
+				require.NoError(t, err)
+				counts[piece.NodeId] = node.Reputation.AuditSuccessCount
+			}
+			return counts
+		}
+		before := auditCounts()
+
+		auditor := audit.NewSegmentAuditor(zaptest.NewLogger(t), audit.Config{MaxRetriesStatDB: 1, MinBytesPerSecond: 128 * memory.B},
+			satellite.Transport, satellite.Overlay.Service, satellite.DB.Containment(), satellite.Orders.Service, satellite.Identity)
+		require.NoError(t, auditor.AuditSegment(ctx, stripe.SegmentPath, stripe.Segment))
+
+		// every node of the segment passed an audit
+		for nodeID, count := range auditCounts() {
+			assert.Equal(t, before[nodeID]+1, count, nodeID)
+		}
+	})
+}
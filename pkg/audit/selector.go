@@ -0,0 +1,329 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/storage"
+)
+
+// Selector picks the stripes that are audited
+type Selector interface {
+	// NextStripe returns a stripe to be audited. "more" is false once the selector
+	// has completed a round. The stripe can be nil when nothing was found.
+	NextStripe(ctx context.Context) (stripe *Stripe, more bool, err error)
+}
+
+// List of supported audit selection strategies
+const (
+	SelectionRandom   = "random"
+	SelectionWeighted = "weighted"
+)
+
+// WeightedConfig contains configurable values for the weighted audit selection
+type WeightedConfig struct {
+	AuditsPerNode  int           `help:"number of segments audited per node in each weighted round" default:"1"`
+	UnvettedWeight int           `help:"audit rate multiplier for nodes that haven't been vetted yet" default:"3"`
+	FailureWeight  int           `help:"audit rate multiplier for nodes that recently failed an audit" default:"2"`
+	FailureWindow  time.Duration `help:"how long a failed audit increases the audit rate of a node" default:"24h0m0s"`
+	AgeWeight      time.Duration `help:"segment age at which it is twice as likely to be audited as a new segment, 0 disables" default:"720h0m0s"`
+}
+
+// WeightedSelector selects segments to audit per node, so that each node is
+// audited at a configurable rate regardless of how much data it holds.
+//
+// Each round it iterates over metainfo and keeps a weighted reservoir sample of
+// segments for every node. Older segments are weighted higher. Unvetted nodes and
+// nodes that recently failed an audit get more segments audited.
+type WeightedSelector struct {
+	log      *zap.Logger
+	config   WeightedConfig
+	metainfo *metainfo.Service
+	overlay  *overlay.Cache
+
+	mu       sync.Mutex
+	queue    []storj.Path
+	planned  map[storj.NodeID]int
+	failures map[storj.NodeID]time.Time
+}
+
+// NewWeightedSelector creates a new weighted audit selector
+func NewWeightedSelector(log *zap.Logger, config WeightedConfig, metainfo *metainfo.Service, overlay *overlay.Cache) *WeightedSelector {
+	if config.AuditsPerNode < 1 {
+		config.AuditsPerNode = 1
+	}
+	if config.UnvettedWeight < 1 {
+		config.UnvettedWeight = 1
+	}
+	if config.FailureWeight < 1 {
+		config.FailureWeight = 1
+	}
+
+	return &WeightedSelector{
+		log:      log,
+		config:   config,
+		metainfo: metainfo,
+		overlay:  overlay,
+		planned:  map[storj.NodeID]int{},
+		failures: map[storj.NodeID]time.Time{},
+	}
+}
+
+// NextStripe returns a stripe from the current round, planning a new round when
+// the previous one has been completed.
+func (selector *WeightedSelector) NextStripe(ctx context.Context) (stripe *Stripe, more bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	selector.mu.Lock()
+	defer selector.mu.Unlock()
+
+	if len(selector.queue) == 0 {
+		if err := selector.plan(ctx); err != nil {
+			return nil, false, err
+		}
+	}
+
+	for len(selector.queue) > 0 {
+		path := selector.queue[0]
+		selector.queue = selector.queue[1:]
+
+		pointer, err := selector.metainfo.Get(path)
+		if err != nil {
+			if storage.ErrKeyNotFound.Has(err) {
+				continue
+			}
+			return nil, len(selector.queue) > 0, err
+		}
+		if !isAuditable(pointer, time.Now()) {
+			continue
+		}
+
+		index, err := getRandomStripe(pointer)
+		if err != nil {
+			return nil, len(selector.queue) > 0, err
+		}
+
+		return &Stripe{
+			Index:       index,
+			Segment:     pointer,
+			SegmentPath: path,
+		}, len(selector.queue) > 0, nil
+	}
+
+	return nil, false, nil
+}
+
+// RecordReport remembers the nodes that failed an audit, so they are audited more often.
+func (selector *WeightedSelector) RecordReport(report *Report) {
+	if report == nil {
+		return
+	}
+
+	selector.mu.Lock()
+	defer selector.mu.Unlock()
+
+	now := time.Now()
+	for _, nodeID := range report.Fails {
+		selector.failures[nodeID] = now
+	}
+}
+
+// AuditCounts returns the number of audits planned per node in the current round.
+func (selector *WeightedSelector) AuditCounts() map[storj.NodeID]int {
+	selector.mu.Lock()
+	defer selector.mu.Unlock()
+
+	counts := make(map[storj.NodeID]int, len(selector.planned))
+	for nodeID, count := range selector.planned {
+		counts[nodeID] = count
+	}
+	return counts
+}
+
+// plan iterates over metainfo and fills the queue with the segments for the next round.
+func (selector *WeightedSelector) plan(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var src cryptoSource
+	rnd := rand.New(src)
+	now := time.Now()
+
+	maxPerNode := selector.config.AuditsPerNode * selector.config.UnvettedWeight * selector.config.FailureWeight
+	reservoirs := map[storj.NodeID]*reservoir{}
+
+	err = selector.metainfo.Iterate("", "", true, false,
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+
+				pointer := &pb.Pointer{}
+				err := proto.Unmarshal(item.Value, pointer)
+				if err != nil {
+					return Error.New("error unmarshalling pointer %s", err)
+				}
+				if !isAuditable(pointer, now) {
+					continue
+				}
+
+				path := item.Key.String()
+				weight := selector.ageWeight(pointer, now)
+				for _, piece := range pointer.GetRemote().GetRemotePieces() {
+					r, ok := reservoirs[piece.NodeId]
+					if !ok {
+						r = &reservoir{size: maxPerNode}
+						reservoirs[piece.NodeId] = r
+					}
+					r.sample(path, weight, rnd)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for nodeID, failedAt := range selector.failures {
+		if now.Sub(failedAt) > selector.config.FailureWindow {
+			delete(selector.failures, nodeID)
+		}
+	}
+
+	selector.planned = make(map[storj.NodeID]int, len(reservoirs))
+	selected := map[storj.Path]struct{}{}
+	for nodeID, r := range reservoirs {
+		count := selector.auditCount(ctx, nodeID)
+		paths := r.top(count)
+
+		selector.planned[nodeID] = len(paths)
+		mon.IntVal("audits_per_node").Observe(int64(len(paths)))
+
+		for _, path := range paths {
+			selected[path] = struct{}{}
+		}
+	}
+	mon.IntVal("audited_nodes").Observe(int64(len(reservoirs)))
+
+	selector.queue = make([]storj.Path, 0, len(selected))
+	for path := range selected {
+		selector.queue = append(selector.queue, path)
+	}
+	rnd.Shuffle(len(selector.queue), func(i, k int) {
+		selector.queue[i], selector.queue[k] = selector.queue[k], selector.queue[i]
+	})
+
+	return nil
+}
+
+// auditCount returns how many segments of the node should be audited in a round.
+func (selector *WeightedSelector) auditCount(ctx context.Context, nodeID storj.NodeID) int {
+	count := selector.config.AuditsPerNode
+
+	if _, failed := selector.failures[nodeID]; failed {
+		count *= selector.config.FailureWeight
+	}
+
+	vetted, err := selector.overlay.VetNode(ctx, nodeID)
+	if err != nil {
+		selector.log.Debug("unable to check whether node is vetted", zap.Stringer("Node ID", nodeID), zap.Error(err))
+		return count
+	}
+	if !vetted {
+		count *= selector.config.UnvettedWeight
+	}
+
+	return count
+}
+
+// ageWeight returns the sampling weight of a segment based on its age.
+func (selector *WeightedSelector) ageWeight(pointer *pb.Pointer, now time.Time) float64 {
+	if selector.config.AgeWeight <= 0 || pointer.GetCreationDate() == nil {
+		return 1
+	}
+	created, err := ptypes.Timestamp(pointer.GetCreationDate())
+	if err != nil || created.After(now) {
+		return 1
+	}
+	return 1 + float64(now.Sub(created))/float64(selector.config.AgeWeight)
+}
+
+// isAuditable returns whether the pointer references an unexpired remote segment.
+func isAuditable(pointer *pb.Pointer, now time.Time) bool {
+	if pointer.GetType() != pb.Pointer_REMOTE || pointer.GetSegmentSize() == 0 {
+		return false
+	}
+	if expiration := pointer.GetExpirationDate(); expiration != nil {
+		t, err := ptypes.Timestamp(expiration)
+		if err != nil || t.Before(now) {
+			return false
+		}
+	}
+	return true
+}
+
+// reservoir implements weighted reservoir sampling (Efraimidis-Spirakis) of segment paths.
+type reservoir struct {
+	size  int
+	paths []storj.Path
+	keys  []float64
+}
+
+// sample offers path with the given weight to the reservoir.
+func (r *reservoir) sample(path storj.Path, weight float64, rnd *rand.Rand) {
+	key := math.Pow(rnd.Float64(), 1/weight)
+	if len(r.paths) < r.size {
+		r.paths = append(r.paths, path)
+		r.keys = append(r.keys, key)
+		return
+	}
+
+	min := 0
+	for i := range r.keys {
+		if r.keys[i] < r.keys[min] {
+			min = i
+		}
+	}
+	if key > r.keys[min] {
+		r.paths[min] = path
+		r.keys[min] = key
+	}
+}
+
+// top returns up to n sampled paths with the highest keys.
+func (r *reservoir) top(n int) []storj.Path {
+	if n >= len(r.paths) {
+		return r.paths
+	}
+
+	order := make([]int, len(r.paths))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, k int) bool {
+		return r.keys[order[i]] > r.keys[order[k]]
+	})
+
+	paths := make([]storj.Path, n)
+	for i := range paths {
+		paths[i] = r.paths[order[i]]
+	}
+	return paths
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit_test
+
+import (
+	"crypto/rand"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/audit"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+func TestWeightedSelector(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Audit.Service.Loop.Pause()
+
+		for i := 0; i < 5; i++ {
+			testData := make([]byte, 8*memory.KiB)
+			_, err := rand.Read(testData)
+			require.NoError(t, err)
+
+			err = planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path"+strconv.Itoa(i), testData)
+			require.NoError(t, err)
+		}
+
+		// count how many segments each node holds
+		held := map[storj.NodeID]int{}
+		err := satellite.Metainfo.Service.Iterate("", "", true, false, func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				pointer := &pb.Pointer{}
+				if err := proto.Unmarshal(item.Value, pointer); err != nil {
+					return err
+				}
+				for _, piece := range pointer.GetRemote().GetRemotePieces() {
+					held[piece.NodeId]++
+				}
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, held)
+
+		selector := audit.NewWeightedSelector(zaptest.NewLogger(t), audit.WeightedConfig{
+			AuditsPerNode:  1,
+			UnvettedWeight: 3,
+			FailureWeight:  2,
+			FailureWindow:  time.Hour,
+			AgeWeight:      time.Hour,
+		}, satellite.Metainfo.Service, satellite.Overlay.Service)
+
+		// runs a full round and returns the nodes covered by the selected stripes
+		round := func() map[storj.NodeID]int {
+			covered := map[storj.NodeID]int{}
+			for {
+				stripe, more, err := selector.NextStripe(ctx)
+				require.NoError(t, err)
+				if stripe != nil {
+					for _, piece := range stripe.Segment.GetRemote().GetRemotePieces() {
+						covered[piece.NodeId]++
+					}
+				}
+				if !more {
+					return covered
+				}
+			}
+		}
+
+		covered := round()
+		counts := selector.AuditCounts()
+		for nodeID := range held {
+			// every node holding data must be audited in each round
+			require.NotZero(t, covered[nodeID], nodeID.String())
+			require.Equal(t, 1, counts[nodeID], nodeID.String())
+		}
+
+		// nodes that failed an audit are audited more often
+		var failed storj.NodeID
+		for nodeID, count := range held {
+			if count >= 2 {
+				failed = nodeID
+				break
+			}
+		}
+		require.False(t, failed.IsZero())
+
+		selector.RecordReport(&audit.Report{Fails: storj.NodeIDList{failed}})
+
+		covered = round()
+		counts = selector.AuditCounts()
+		require.Equal(t, 2, counts[failed])
+		require.True(t, covered[failed] >= 2)
+	})
+}
@@ -28,13 +28,22 @@ type Config struct {
 	MaxRetriesStatDB  int           `help:"max number of times to attempt updating a statdb batch" default:"3"`
 	Interval          time.Duration `help:"how frequently segments are audited" default:"30s"`
 	MinBytesPerSecond memory.Size   `help:"the minimum acceptable bytes that storage nodes can transfer per second to the satellite" default:"128B"`
+
+	Selection string `help:"strategy for selecting segments to audit, either random or weighted" default:"random"`
+	Weighted  WeightedConfig
+}
+
+// reportRecorder is implemented by selectors that adjust the selection based on audit results
+type reportRecorder interface {
+	RecordReport(report *Report)
 }
 
-// Service helps coordinate Cursor and Verifier to run the audit process continuously
+// Service helps coordinate Selector and Verifier to run the audit process continuously
 type Service struct {
 	log *zap.Logger
 
 	Cursor   *Cursor
+	Selector Selector
 	Verifier *Verifier
 	Reporter reporter
 
@@ -45,10 +54,23 @@ type Service struct {
 func NewService(log *zap.Logger, config Config, metainfo *metainfo.Service,
 	orders *orders.Service, transport transport.Client, overlay *overlay.Cache,
 	containment Containment, identity *identity.FullIdentity) (service *Service, err error) {
+	cursor := NewCursor(metainfo)
+
+	var selector Selector
+	switch config.Selection {
+	case SelectionRandom:
+		selector = cursor
+	case SelectionWeighted:
+		selector = NewWeightedSelector(log.Named("audit:selector"), config.Weighted, metainfo, overlay)
+	default:
+		return nil, Error.New("unknown audit selection strategy %q", config.Selection)
+	}
+
 	return &Service{
 		log: log,
 
-		Cursor:   NewCursor(metainfo),
+		Cursor:   cursor,
+		Selector: selector,
 		Verifier: NewVerifier(log.Named("audit:verifier"), NewReporter(overlay, containment, config.MaxRetriesStatDB), transport, overlay, containment, orders, identity, config.MinBytesPerSecond),
 		Reporter: NewReporter(overlay, containment, config.MaxRetriesStatDB),
 
@@ -80,7 +102,7 @@ func (service *Service) Close() error {
 func (service *Service) process(ctx context.Context) error {
 	var stripe *Stripe
 	for {
-		s, more, err := service.Cursor.NextStripe(ctx)
+		s, more, err := service.Selector.NextStripe(ctx)
 		if err != nil {
 			return err
 		}
@@ -128,6 +150,10 @@ func (service *Service) process(ctx context.Context) error {
 		errlist.Add(err)
 	}
 
+	if recorder, ok := service.Selector.(reportRecorder); ok {
+		recorder.RecordReport(report)
+	}
+
 	// TODO(moby) we need to decide if we want to do something with nodes that the reporter failed to update
 	_, err = service.Reporter.RecordAudits(ctx, report)
 	if err != nil {
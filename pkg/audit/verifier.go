@@ -9,13 +9,13 @@ import (
 	"io"
 	"time"
 
-	"github.com/vivint/infectious"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/internal/memory"
 	"storj.io/storj/pkg/auth/signing"
+	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
@@ -104,8 +104,8 @@ func (verifier *Verifier) Verify(ctx context.Context, stripe *Stripe, skip map[s
 		}
 	}
 
-	required := int(pointer.Remote.Redundancy.GetMinReq())
-	total := int(pointer.Remote.Redundancy.GetTotal())
+	redundancy := pointer.Remote.Redundancy
+	required := int(redundancy.GetMinReq())
 
 	if len(sharesToAudit) < required {
 		return &Report{
@@ -113,7 +113,23 @@ func (verifier *Verifier) Verify(ctx context.Context, stripe *Stripe, skip map[s
 		}, ErrNotEnoughShares.New("got %d, required %d", len(sharesToAudit), required)
 	}
 
-	pieceNums, correctedShares, err := auditShares(ctx, required, total, sharesToAudit)
+	es, err := eestream.NewErasureScheme(redundancy.GetType(), required, int(redundancy.GetTotal()), int(shareSize))
+	if err != nil {
+		return &Report{
+			Offlines: offlineNodes,
+		}, Error.Wrap(err)
+	}
+
+	pieceNums, stripeData, err := auditShares(ctx, es, sharesToAudit)
+	if eestream.ErrUnableToCorrect.Contains(err) {
+		// the shares are corrupted, but it is unknown which ones, so no node
+		// passes or fails this audit
+		mon.Meter("audit_unable_to_correct").Mark(1)
+		verifier.log.Warn("unable to correct the audited stripe", zap.String("segment", stripe.SegmentPath), zap.Error(err))
+		return &Report{
+			Offlines: offlineNodes,
+		}, nil
+	}
 	if err != nil {
 		return &Report{
 			Offlines: offlineNodes,
@@ -126,7 +142,7 @@ func (verifier *Verifier) Verify(ctx context.Context, stripe *Stripe, skip map[s
 
 	successNodes := getSuccessNodes(ctx, nodes, failedNodes, offlineNodes, containedNodes)
 
-	pendingAudits, err := createPendingAudits(containedNodes, correctedShares, stripe)
+	pendingAudits, err := createPendingAudits(containedNodes, es, stripeData, stripe)
 	if err != nil {
 		return &Report{
 			Successes: successNodes,
@@ -319,42 +335,39 @@ func (verifier *Verifier) GetShare(ctx context.Context, limit *pb.AddressedOrder
 	}, nil
 }
 
-// auditShares takes the downloaded shares and uses infectious's Correct function to check that they
-// haven't been altered. auditShares returns a slice containing the piece numbers of altered shares,
-// and a slice of the corrected shares.
-func auditShares(ctx context.Context, required, total int, originals map[int]Share) (pieceNums []int, corrected []infectious.Share, err error) {
+// auditShares takes the downloaded shares and uses the erasure scheme of the segment to decode
+// and re-encode the stripe, to check that they haven't been altered. auditShares returns a slice
+// containing the piece numbers of altered shares, and the corrected stripe.
+func auditShares(ctx context.Context, es eestream.ErasureScheme, originals map[int]Share) (pieceNums []int, stripe []byte, err error) {
 	defer mon.Task()(&ctx)(&err)
-	f, err := infectious.NewFEC(required, total)
-	if err != nil {
-		return nil, nil, err
-	}
 
 	copies, err := makeCopies(ctx, originals)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	err = f.Correct(copies)
+	stripe, err = es.Decode(nil, copies)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	for _, share := range copies {
-		if !bytes.Equal(originals[share.Number].Data, share.Data) {
-			pieceNums = append(pieceNums, share.Number)
+	err = es.Encode(stripe, func(num int, data []byte) {
+		if original, ok := originals[num]; ok && !bytes.Equal(original.Data, data) {
+			pieceNums = append(pieceNums, num)
 		}
+	})
+	if err != nil {
+		return nil, nil, err
 	}
-	return pieceNums, copies, nil
+	return pieceNums, stripe, nil
 }
 
-// makeCopies takes in a map of audit Shares and deep copies their data to a slice of infectious Shares
-func makeCopies(ctx context.Context, originals map[int]Share) (copies []infectious.Share, err error) {
+// makeCopies takes in a map of audit Shares and deep copies their data to a map of erasure shares
+func makeCopies(ctx context.Context, originals map[int]Share) (copies map[int][]byte, err error) {
 	defer mon.Task()(&ctx)(&err)
-	copies = make([]infectious.Share, 0, len(originals))
+	copies = make(map[int][]byte, len(originals))
 	for _, original := range originals {
-		copies = append(copies, infectious.Share{
-			Data:   append([]byte{}, original.Data...),
-			Number: original.PieceNum})
+		copies[original.PieceNum] = append([]byte{}, original.Data...)
 	}
 	return copies, nil
 }
@@ -390,30 +403,17 @@ func createBucketID(path storj.Path) []byte {
 	return []byte(storj.JoinPaths(comps[0], comps[2]))
 }
 
-func createPendingAudits(containedNodes map[int]storj.NodeID, correctedShares []infectious.Share, stripe *Stripe) ([]*PendingAudit, error) {
+func createPendingAudits(containedNodes map[int]storj.NodeID, es eestream.ErasureScheme, stripeData []byte, stripe *Stripe) ([]*PendingAudit, error) {
 	if len(containedNodes) > 0 {
 		return nil, nil
 	}
 
-	redundancy := stripe.Segment.GetRemote().GetRedundancy()
-	required := int(redundancy.GetMinReq())
-	total := int(redundancy.GetTotal())
-	shareSize := redundancy.GetErasureShareSize()
-
-	fec, err := infectious.NewFEC(required, total)
-	if err != nil {
-		return nil, Error.Wrap(err)
-	}
-
-	stripeData, err := rebuildStripe(fec, correctedShares, int(shareSize))
-	if err != nil {
-		return nil, Error.Wrap(err)
-	}
+	shareSize := stripe.Segment.GetRemote().GetRedundancy().GetErasureShareSize()
 
 	var pendingAudits []*PendingAudit
 	for pieceNum, nodeID := range containedNodes {
 		share := make([]byte, shareSize)
-		err = fec.EncodeSingle(stripeData, share, pieceNum)
+		err := es.EncodeSingle(stripeData, share, pieceNum)
 		if err != nil {
 			return nil, Error.Wrap(err)
 		}
@@ -428,14 +428,3 @@ func createPendingAudits(containedNodes map[int]storj.NodeID, correctedShares []
 
 	return pendingAudits, nil
 }
-
-func rebuildStripe(fec *infectious.FEC, corrected []infectious.Share, shareSize int) ([]byte, error) {
-	stripe := make([]byte, fec.Required()*shareSize)
-	err := fec.Rebuild(corrected, func(share infectious.Share) {
-		copy(stripe[share.Number*shareSize:], share.Data)
-	})
-	if err != nil {
-		return nil, err
-	}
-	return stripe, nil
-}
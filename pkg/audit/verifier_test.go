@@ -9,6 +9,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"github.com/vivint/infectious"
+
+	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/pb"
 )
 
 func TestFailingAudit(t *testing.T) {
@@ -55,18 +58,74 @@ func TestFailingAudit(t *testing.T) {
 		}
 	}
 
-	pieceNums, correctedShares, err := auditShares(ctx, 8, 14, auditPkgShares)
+	es, err := eestream.NewErasureScheme(pb.RedundancyScheme_RS, required, total, 2)
+	require.NoError(t, err)
+
+	pieceNums, stripe, err := auditShares(ctx, es, auditPkgShares)
 	if err != nil {
 		panic(err)
 	}
 
-	for i, num := range pieceNums {
-		if num != badPieceNums[i] {
-			t.Fatal("expected nums in pieceNums to be same as in badPieceNums")
+	require.Equal(t, badPieceNums, pieceNums)
+	require.Equal(t, []byte("hello, world! __"), stripe)
+}
+
+func TestFailingAuditCauchy(t *testing.T) {
+	const (
+		required = 8
+		total    = 14
+	)
+
+	es, err := eestream.NewErasureScheme(pb.RedundancyScheme_RS_CAUCHY, required, total, 2)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	auditPkgShares := make(map[int]Share, total)
+	err = es.Encode([]byte("hello, world! __"), func(num int, data []byte) {
+		auditPkgShares[num] = Share{
+			PieceNum: num,
+			Data:     append([]byte(nil), data...),
 		}
-	}
+	})
+	require.NoError(t, err)
+
+	auditPkgShares[3].Data[0]++
+	auditPkgShares[10].Data[1]++
 
-	require.Equal(t, shares, correctedShares)
+	pieceNums, stripe, err := auditShares(ctx, es, auditPkgShares)
+	require.NoError(t, err)
+
+	require.Equal(t, []int{3, 10}, pieceNums)
+	require.Equal(t, []byte("hello, world! __"), stripe)
+}
+
+func TestUnableToCorrectAuditCauchy(t *testing.T) {
+	const (
+		required = 4
+		total    = 40
+	)
+
+	es, err := eestream.NewErasureScheme(pb.RedundancyScheme_RS_CAUCHY, required, total, 1)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	auditPkgShares := make(map[int]Share, total)
+	err = es.Encode([]byte("data"), func(num int, data []byte) {
+		auditPkgShares[num] = Share{
+			PieceNum: num,
+			Data:     append([]byte(nil), data...),
+		}
+	})
+	require.NoError(t, err)
+
+	// more corrupted shares than the scheme searches for, Verify reports
+	// this as unable to correct without failing any node
+	auditPkgShares[35].Data[0]++
+	auditPkgShares[37].Data[0]++
+	auditPkgShares[39].Data[0]++
+
+	_, _, err = auditShares(ctx, es, auditPkgShares)
+	require.True(t, eestream.ErrUnableToCorrect.Contains(err), err)
 }
 
 func TestNotEnoughShares(t *testing.T) {
@@ -100,6 +159,9 @@ func TestNotEnoughShares(t *testing.T) {
 			Data:     append([]byte(nil), shares[i].Data...),
 		}
 	}
-	_, _, err = auditShares(ctx, 20, 40, auditPkgShares)
+	es, err := eestream.NewErasureScheme(pb.RedundancyScheme_RS, 20, 40, 2)
+	require.NoError(t, err)
+
+	_, _, err = auditShares(ctx, es, auditPkgShares)
 	require.Contains(t, err.Error(), "infectious: must specify at least the number of required shares")
 }
@@ -0,0 +1,122 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package backup
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"math/bits"
+
+	"storj.io/storj/internal/memory"
+)
+
+// ChunkerConfig configures the sizes of chunks. Changing it splits the same
+// data into different chunks, which are not deduplicated with the chunks
+// stored before.
+type ChunkerConfig struct {
+	// MinSize is the size below which chunks are never split
+	MinSize memory.Size
+	// AverageSize is the approximate size chunks are split at beyond
+	// MinSize, rounded down to a power of two
+	AverageSize memory.Size
+	// MaxSize is the size at which chunks are always split
+	MaxSize memory.Size
+}
+
+// DefaultChunkerConfig is the chunker config used by the uplink
+var DefaultChunkerConfig = ChunkerConfig{
+	MinSize:     512 * memory.KiB,
+	AverageSize: 1 * memory.MiB,
+	MaxSize:     8 * memory.MiB,
+}
+
+// gear maps bytes to random values for the rolling hash. It must never
+// change, because the chunk boundaries depend on it.
+var gear [256]uint64
+
+func init() {
+	for i := range gear {
+		sum := sha256.Sum256([]byte{byte(i)})
+		gear[i] = binary.BigEndian.Uint64(sum[:])
+	}
+}
+
+// Chunker splits data into chunks at the positions where a rolling hash of
+// the preceding bytes matches a mask, which only depends on the content
+// close to the boundary.
+type Chunker struct {
+	reader io.Reader
+	config ChunkerConfig
+	mask   uint64
+
+	// buf holds the data read ahead, starting with the previous chunk of
+	// length next
+	buf  []byte
+	next int
+	err  error
+}
+
+// NewChunker returns a chunker which splits the data of reader
+func NewChunker(reader io.Reader, config ChunkerConfig) *Chunker {
+	if config.MinSize < 0 {
+		config.MinSize = 0
+	}
+	if config.AverageSize <= 0 {
+		config.AverageSize = 1
+	}
+	if config.MaxSize < config.MinSize+1 {
+		config.MaxSize = config.MinSize + 1
+	}
+
+	// the highest bits of the hash depend on the most bytes
+	maskBits := uint(bits.Len64(uint64(config.AverageSize)) - 1)
+	return &Chunker{
+		reader: reader,
+		config: config,
+		mask:   ^uint64(0) << (64 - maskBits),
+		buf:    make([]byte, 0, config.MaxSize),
+	}
+}
+
+// Next returns the next chunk, which is only valid until the following
+// call. It returns io.EOF after the last chunk.
+func (chunker *Chunker) Next() ([]byte, error) {
+	if chunker.next > 0 {
+		chunker.buf = chunker.buf[:copy(chunker.buf, chunker.buf[chunker.next:])]
+		chunker.next = 0
+	}
+
+	for len(chunker.buf) < cap(chunker.buf) && chunker.err == nil {
+		n, err := chunker.reader.Read(chunker.buf[len(chunker.buf):cap(chunker.buf)])
+		chunker.buf = chunker.buf[:len(chunker.buf)+n]
+		chunker.err = err
+	}
+	if chunker.err != nil && chunker.err != io.EOF {
+		return nil, chunker.err
+	}
+	if len(chunker.buf) == 0 {
+		return nil, io.EOF
+	}
+
+	chunker.next = chunker.boundary(chunker.buf)
+	return chunker.buf[:chunker.next], nil
+}
+
+// boundary returns the length of the chunk at the start of data
+func (chunker *Chunker) boundary(data []byte) int {
+	min := chunker.config.MinSize.Int()
+	if len(data) <= min {
+		return len(data)
+	}
+
+	var hash uint64
+	for i := min; i < len(data); i++ {
+		hash = hash<<1 + gear[data[i]]
+		if hash&chunker.mask == 0 {
+			return i + 1
+		}
+	}
+	return len(data)
+}
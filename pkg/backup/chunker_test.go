@@ -0,0 +1,90 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package backup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/memory"
+)
+
+// chunks splits data and returns the SHA-256 hashes of its chunks
+func chunks(t *testing.T, reader io.Reader, config ChunkerConfig) (hashes [][32]byte, joined []byte) {
+	chunker := NewChunker(reader, config)
+	for {
+		chunk, err := chunker.Next()
+		if err == io.EOF {
+			return hashes, joined
+		}
+		require.NoError(t, err)
+		require.True(t, len(chunk) <= config.MaxSize.Int(), "chunk larger than MaxSize")
+
+		hashes = append(hashes, sha256.Sum256(chunk))
+		joined = append(joined, chunk...)
+	}
+}
+
+func TestChunker(t *testing.T) {
+	config := ChunkerConfig{
+		MinSize:     1 * memory.KiB,
+		AverageSize: 4 * memory.KiB,
+		MaxSize:     16 * memory.KiB,
+	}
+
+	data := make([]byte, 1*memory.MiB)
+	_, err := rand.New(rand.NewSource(1)).Read(data)
+	require.NoError(t, err)
+
+	hashes, joined := chunks(t, bytes.NewReader(data), config)
+	assert.Equal(t, data, joined)
+	assert.InDelta(t, len(data)/(5*memory.KiB.Int()), len(hashes), float64(len(hashes))/2)
+
+	// the boundaries don't depend on how the data is read
+	oneByte, _ := chunks(t, iotest.OneByteReader(bytes.NewReader(data)), config)
+	assert.Equal(t, hashes, oneByte)
+
+	// inserting data only changes the chunks around the insertion
+	inserted := append(append(append([]byte{}, data[:500*memory.KiB]...), "inserted"...), data[500*memory.KiB:]...)
+	changed, joined := chunks(t, bytes.NewReader(inserted), config)
+	assert.Equal(t, inserted, joined)
+
+	known := map[[32]byte]bool{}
+	for _, hash := range hashes {
+		known[hash] = true
+	}
+	unknown := 0
+	for _, hash := range changed {
+		if !known[hash] {
+			unknown++
+		}
+	}
+	assert.True(t, unknown <= 2, "%d of %d chunks changed", unknown, len(changed))
+
+	{ // small data is a single chunk
+		hashes, joined := chunks(t, bytes.NewReader([]byte("small")), config)
+		assert.Len(t, hashes, 1)
+		assert.Equal(t, "small", string(joined))
+	}
+
+	{ // empty data has no chunks
+		hashes, _ := chunks(t, bytes.NewReader(nil), config)
+		assert.Len(t, hashes, 0)
+	}
+
+	{ // read errors are returned
+		chunker := NewChunker(iotest.TimeoutReader(bytes.NewReader(data)), config)
+		for err == nil {
+			_, err = chunker.Next()
+		}
+		assert.Equal(t, iotest.ErrTimeout, err)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*Package backup stores deduplicated snapshots of local directories in a
+bucket. Files are split into chunks at content-defined boundaries, so that
+an insertion only changes the chunks around it, and every unique chunk is
+stored once as an object named by its SHA-256 hash. Each backup writes a
+snapshot manifest, which lists the files with their chunks.
+
+Chunks and manifests are ordinary objects, so their contents and paths are
+encrypted with the encryption key of the uplink like all other data.
+
+A repository under prefix has the layout
+
+    prefix/chunks/<sha256 of the chunk>
+    prefix/snapshots/<snapshot id>
+    prefix/locks/<backup or prune>-<lock id>
+
+Snapshots are removed with retention policies. Pruning deletes the chunks
+which are not used by the remaining snapshots, so it must not run at the
+same time as a backup to the same repository. Backups and prunes write a
+lock object while they run, and a prune refuses to start while any other
+lock exists, as does a backup while a prune is running. The lock of an
+operation which crashed stays behind and has to be deleted by hand.
+*/
+package backup
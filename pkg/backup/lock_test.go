@@ -0,0 +1,92 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package backup
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestUnlockFailure(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 5, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+
+		apiKey, err := uplink.ParseAPIKey(planet.Uplinks[0].APIKey[satellite.ID()])
+		require.NoError(t, err)
+
+		config := &uplink.Config{}
+		config.Volatile.TLS.SkipPeerCAWhitelist = true
+		upl, err := uplink.NewUplink(ctx, config)
+		require.NoError(t, err)
+		defer ctx.Check(upl.Close)
+
+		var key storj.Key
+		copy(key[:], "backup")
+		var options uplink.ProjectOptions
+		options.Volatile.EncryptionKey = &key
+		project, err := upl.OpenProject(ctx, satellite.Addr(), apiKey, &options)
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		var bucketConfig uplink.BucketConfig
+		bucketConfig.Volatile.RedundancyScheme = storj.RedundancyScheme{
+			Algorithm:      storj.ReedSolomon,
+			ShareSize:      memory.KiB.Int32(),
+			RequiredShares: 2,
+			RepairShares:   3,
+			OptimalShares:  4,
+			TotalShares:    5,
+		}
+		_, err = project.CreateBucket(ctx, "bucket", &bucketConfig)
+		require.NoError(t, err)
+		bucket, err := project.OpenBucket(ctx, "bucket", &uplink.EncryptionAccess{Key: key})
+		require.NoError(t, err)
+		defer ctx.Check(bucket.Close)
+
+		source := ctx.Dir("source")
+		require.NoError(t, ioutil.WriteFile(filepath.Join(source, "file"), []byte("data"), 0644))
+
+		repo := NewRepository(bucket, "backups/", Config{})
+		_, _, err = repo.Backup(ctx, source)
+		require.NoError(t, err)
+
+		unlockFailed := errs.New("unlock failed")
+		repo.removeLock = func(ctx context.Context, path storj.Path) error { return unlockFailed }
+
+		{ // the failed unlock is returned by prune
+			_, err := repo.Prune(ctx, Policy{Last: 1}, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), unlockFailed.Error())
+		}
+
+		{ // and by backup
+			_, _, err := repo.Backup(ctx, source)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), unlockFailed.Error())
+		}
+
+		// the locks are left behind and block the next prune
+		locks, err := bucket.ListObjects(ctx, &storj.ListOptions{Prefix: "backups/" + locksDir, Direction: storj.After, Recursive: true})
+		require.NoError(t, err)
+		assert.Len(t, locks.Items, 2)
+
+		repo.removeLock = bucket.DeleteObject
+		_, err = repo.Prune(ctx, Policy{Last: 1}, false)
+		assert.True(t, ErrLocked.Has(err), err)
+	})
+}
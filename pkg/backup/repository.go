@@ -0,0 +1,566 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	"golang.org/x/sync/errgroup"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/storj"
+)
+
+var mon = monkit.Package()
+
+// Error is the default error class for backups
+var Error = errs.Class("backup error")
+
+// ErrLocked is returned when a backup or prune conflicts with one which is
+// running on the same repository
+var ErrLocked = errs.Class("repository locked")
+
+const (
+	chunksDir    = "chunks/"
+	snapshotsDir = "snapshots/"
+	locksDir     = "locks/"
+)
+
+// kinds of locks, backups only conflict with prunes while prunes conflict
+// with all other locks
+const (
+	lockBackup = "backup"
+	lockPrune  = "prune"
+)
+
+// Config configures a repository
+type Config struct {
+	Chunker ChunkerConfig
+	// Parallelism is the number of chunks uploaded or files restored at
+	// the same time
+	Parallelism int
+	// Upload holds the options for uploading chunks and manifests
+	Upload uplink.UploadOptions
+}
+
+// Repository stores backups under a prefix of a bucket
+type Repository struct {
+	bucket *uplink.Bucket
+	prefix storj.Path
+	config Config
+
+	// removeLock deletes a lock object, tests replace it to make unlocking
+	// fail
+	removeLock func(ctx context.Context, path storj.Path) error
+}
+
+// NewRepository returns the repository under prefix in bucket. An empty
+// repository needs no setup.
+func NewRepository(bucket *uplink.Bucket, prefix storj.Path, config Config) *Repository {
+	if config.Chunker == (ChunkerConfig{}) {
+		config.Chunker = DefaultChunkerConfig
+	}
+	if config.Parallelism <= 0 {
+		config.Parallelism = 1
+	}
+	return &Repository{bucket: bucket, prefix: prefix, config: config, removeLock: bucket.DeleteObject}
+}
+
+// BackupStats counts what a backup stored
+type BackupStats struct {
+	Files int
+	Bytes int64
+	// Chunks is the number of chunks of the files
+	Chunks int
+	// NewChunks is the number of chunks which were uploaded, because they
+	// were not stored yet
+	NewChunks int
+	NewBytes  int64
+}
+
+// Backup stores the files, directories and symbolic links in source and
+// writes a snapshot of them
+func (repo *Repository) Backup(ctx context.Context, source string) (_ *Snapshot, stats BackupStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	source, err = filepath.Abs(source)
+	if err != nil {
+		return nil, stats, err
+	}
+	if info, err := os.Stat(source); err != nil {
+		return nil, stats, err
+	} else if !info.IsDir() {
+		return nil, stats, Error.New("%s is not a directory", source)
+	}
+
+	unlock, err := repo.lock(ctx, lockBackup)
+	if err != nil {
+		return nil, stats, err
+	}
+	defer func() { err = errs.Combine(err, unlock()) }()
+
+	known, err := repo.listChunks(ctx)
+	if err != nil {
+		return nil, stats, err
+	}
+
+	now := time.Now()
+	id, err := newSnapshotID(now)
+	if err != nil {
+		return nil, stats, err
+	}
+	host, _ := os.Hostname()
+	snapshot := &Snapshot{SnapshotInfo: SnapshotInfo{ID: id, Time: now, Host: host, Source: source}}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	slots := make(chan struct{}, repo.config.Parallelism)
+
+	// upload uploads a chunk in the background unless it is stored already
+	upload := func(chunk []byte) (string, error) {
+		sum := sha256.Sum256(chunk)
+		hash := hex.EncodeToString(sum[:])
+
+		stats.Chunks++
+		if _, ok := known[hash]; ok {
+			return hash, nil
+		}
+		known[hash] = int64(len(chunk))
+		stats.NewChunks++
+		stats.NewBytes += int64(len(chunk))
+
+		select {
+		case slots <- struct{}{}:
+		case <-groupCtx.Done():
+			return "", groupCtx.Err()
+		}
+		data := append([]byte{}, chunk...)
+		group.Go(func() error {
+			defer func() { <-slots }()
+			opts := repo.config.Upload
+			return repo.bucket.UploadObject(groupCtx, repo.prefix+chunksDir+hash, bytes.NewReader(data), &opts)
+		})
+		return hash, nil
+	}
+
+	err = filepath.Walk(source, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == source {
+			return nil
+		}
+
+		relative, err := filepath.Rel(source, name)
+		if err != nil {
+			return err
+		}
+		file := File{
+			Path:    filepath.ToSlash(relative),
+			Mode:    info.Mode(),
+			ModTime: info.ModTime(),
+		}
+
+		switch {
+		case info.IsDir():
+		case info.Mode()&os.ModeSymlink != 0:
+			file.Link, err = os.Readlink(name)
+			if err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			file.Size, file.Chunks, err = repo.chunkFile(name, upload)
+			if err != nil {
+				return err
+			}
+			stats.Bytes += file.Size
+		default:
+			// devices, sockets and pipes are not backed up
+			return nil
+		}
+
+		snapshot.Files = append(snapshot.Files, file)
+		stats.Files++
+		return nil
+	})
+	err = errs.Combine(err, group.Wait())
+	if err != nil {
+		return nil, stats, Error.Wrap(err)
+	}
+
+	snapshot.SnapshotInfo.Files = len(snapshot.Files)
+	snapshot.SnapshotInfo.Size = stats.Bytes
+
+	var manifest bytes.Buffer
+	if err := encodeSnapshot(&manifest, snapshot); err != nil {
+		return nil, stats, Error.Wrap(err)
+	}
+	opts := repo.config.Upload
+	opts.Metadata = snapshot.SnapshotInfo.metadata()
+	err = repo.bucket.UploadObject(ctx, repo.prefix+snapshotsDir+snapshot.ID, &manifest, &opts)
+	if err != nil {
+		return nil, stats, Error.Wrap(err)
+	}
+	return snapshot, stats, nil
+}
+
+// chunkFile splits the file at name into chunks and passes them to upload.
+// It returns the size of the file and the hashes of its chunks.
+func (repo *Repository) chunkFile(name string, upload func([]byte) (string, error)) (size int64, hashes []string, err error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { err = errs.Combine(err, file.Close()) }()
+
+	chunker := NewChunker(file, repo.config.Chunker)
+	for {
+		chunk, err := chunker.Next()
+		if err == io.EOF {
+			return size, hashes, nil
+		}
+		if err != nil {
+			return size, hashes, err
+		}
+
+		hash, err := upload(chunk)
+		if err != nil {
+			return size, hashes, err
+		}
+		size += int64(len(chunk))
+		hashes = append(hashes, hash)
+	}
+}
+
+// Snapshots returns the snapshots of the repository, oldest first
+func (repo *Repository) Snapshots(ctx context.Context) (_ []SnapshotInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var snapshots []SnapshotInfo
+	err = repo.list(ctx, repo.prefix+snapshotsDir, func(object storj.Object) error {
+		info, err := snapshotInfo(object.Path, object.Metadata)
+		if err != nil {
+			return err
+		}
+		snapshots = append(snapshots, info)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(snapshots, func(i, k int) bool { return snapshots[i].Time.Before(snapshots[k].Time) })
+	return snapshots, nil
+}
+
+// Snapshot downloads the manifest of the snapshot with id. The id "latest"
+// refers to the newest snapshot.
+func (repo *Repository) Snapshot(ctx context.Context, id string) (_ *Snapshot, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if id == "latest" {
+		snapshots, err := repo.Snapshots(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(snapshots) == 0 {
+			return nil, Error.New("no snapshots")
+		}
+		id = snapshots[len(snapshots)-1].ID
+	}
+
+	reader, err := repo.bucket.NewReader(ctx, repo.prefix+snapshotsDir+id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	return decodeSnapshot(reader)
+}
+
+// Restore restores the files of snapshot into target, replacing existing
+// files with the same paths
+func (repo *Repository) Restore(ctx context.Context, snapshot *Snapshot, target string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if err := os.MkdirAll(target, 0755); err != nil {
+		return err
+	}
+
+	var dirs []File
+	group, groupCtx := errgroup.WithContext(ctx)
+	slots := make(chan struct{}, repo.config.Parallelism)
+
+	for _, file := range snapshot.Files {
+		file := file
+		name := filepath.Join(target, filepath.FromSlash(file.Path))
+
+		switch {
+		case file.Mode.IsDir():
+			// directories stay writable until their files are restored
+			if err := os.MkdirAll(name, 0700); err != nil {
+				return errs.Combine(err, group.Wait())
+			}
+			dirs = append(dirs, file)
+		case file.Mode&os.ModeSymlink != 0:
+			if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+				return errs.Combine(err, group.Wait())
+			}
+			_ = os.Remove(name)
+			if err := os.Symlink(file.Link, name); err != nil {
+				return errs.Combine(err, group.Wait())
+			}
+		default:
+			select {
+			case slots <- struct{}{}:
+			case <-groupCtx.Done():
+				return group.Wait()
+			}
+			group.Go(func() error {
+				defer func() { <-slots }()
+				return repo.restoreFile(groupCtx, file, name)
+			})
+		}
+	}
+	if err := group.Wait(); err != nil {
+		return err
+	}
+
+	// restore the deepest directories first, because changing their
+	// contents updates the modification time of their parents
+	for i := len(dirs) - 1; i >= 0; i-- {
+		name := filepath.Join(target, filepath.FromSlash(dirs[i].Path))
+		if err := os.Chmod(name, dirs[i].Mode.Perm()); err != nil {
+			return err
+		}
+		if err := os.Chtimes(name, dirs[i].ModTime, dirs[i].ModTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreFile downloads the chunks of file into name
+func (repo *Repository) restoreFile(ctx context.Context, file File, name string) (err error) {
+	if err := os.MkdirAll(filepath.Dir(name), 0700); err != nil {
+		return err
+	}
+
+	out, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode.Perm()|0200)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errs.Combine(err, out.Close())
+		if err == nil {
+			err = os.Chmod(name, file.Mode.Perm())
+		}
+		if err == nil {
+			err = os.Chtimes(name, file.ModTime, file.ModTime)
+		}
+	}()
+
+	for _, hash := range file.Chunks {
+		chunk, err := repo.downloadChunk(ctx, hash)
+		if err != nil {
+			return Error.New("unable to restore %s: %v", file.Path, err)
+		}
+		if _, err := out.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// downloadChunk downloads the chunk with hash and verifies its contents
+func (repo *Repository) downloadChunk(ctx context.Context, hash string) (_ []byte, err error) {
+	reader, err := repo.bucket.NewReader(ctx, repo.prefix+chunksDir+hash)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	chunk, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if sum := sha256.Sum256(chunk); hex.EncodeToString(sum[:]) != hash {
+		return nil, Error.New("chunk %s is corrupted", hash)
+	}
+	return chunk, nil
+}
+
+// PruneStats counts what pruning removed
+type PruneStats struct {
+	Kept    []SnapshotInfo
+	Removed []SnapshotInfo
+	// Chunks is the number of chunks which are not used by the kept
+	// snapshots
+	Chunks int
+	Bytes  int64
+}
+
+// Prune removes the snapshots which policy does not keep and the chunks
+// which are not used by the remaining snapshots. With dryRun nothing is
+// deleted. Prune fails with ErrLocked while a backup is running, because
+// the backup may use chunks which no snapshot refers to yet.
+func (repo *Repository) Prune(ctx context.Context, policy Policy, dryRun bool) (stats PruneStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if policy.Empty() {
+		return stats, Error.New("no retention policy, which would remove all snapshots")
+	}
+
+	if !dryRun {
+		var unlock func() error
+		unlock, err = repo.lock(ctx, lockPrune)
+		if err != nil {
+			return stats, err
+		}
+		defer func() { err = errs.Combine(err, unlock()) }()
+	}
+
+	snapshots, err := repo.Snapshots(ctx)
+	if err != nil {
+		return stats, err
+	}
+	stats.Kept, stats.Removed = policy.Apply(snapshots, time.Now())
+
+	used := map[string]bool{}
+	for _, info := range stats.Kept {
+		snapshot, err := repo.Snapshot(ctx, info.ID)
+		if err != nil {
+			return stats, err
+		}
+		for _, file := range snapshot.Files {
+			for _, hash := range file.Chunks {
+				used[hash] = true
+			}
+		}
+	}
+
+	chunks, err := repo.listChunks(ctx)
+	if err != nil {
+		return stats, err
+	}
+	var unused []string
+	for hash, size := range chunks {
+		if !used[hash] {
+			unused = append(unused, hash)
+			stats.Chunks++
+			stats.Bytes += size
+		}
+	}
+	if dryRun {
+		return stats, nil
+	}
+
+	// the snapshots are removed first, so that an interrupted prune never
+	// leaves snapshots with missing chunks
+	for _, info := range stats.Removed {
+		if err := repo.bucket.DeleteObject(ctx, repo.prefix+snapshotsDir+info.ID); err != nil {
+			return stats, Error.Wrap(err)
+		}
+	}
+	for _, hash := range unused {
+		if err := repo.bucket.DeleteObject(ctx, repo.prefix+chunksDir+hash); err != nil {
+			return stats, Error.Wrap(err)
+		}
+	}
+	return stats, nil
+}
+
+// lock writes a lock object of kind under the prefix and returns a function
+// removing it. It fails with ErrLocked when a conflicting lock exists. The
+// lock is written before looking for other locks, so that of two concurrent
+// operations at least one sees the lock of the other.
+func (repo *Repository) lock(ctx context.Context, kind string) (unlock func() error, err error) {
+	id, err := newSnapshotID(time.Now())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	name := kind + "-" + id
+	path := repo.prefix + locksDir + name
+
+	host, _ := os.Hostname()
+	opts := repo.config.Upload
+	opts.Metadata = map[string]string{metaHost: host}
+	if err := repo.bucket.UploadObject(ctx, path, bytes.NewReader(nil), &opts); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	unlock = func() error {
+		return Error.Wrap(repo.removeLock(ctx, path))
+	}
+
+	var conflict storj.Object
+	err = repo.list(ctx, repo.prefix+locksDir, func(object storj.Object) error {
+		if object.Path == name || conflict.Path != "" {
+			return nil
+		}
+		if kind == lockPrune || strings.HasPrefix(object.Path, lockPrune+"-") {
+			conflict = object
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errs.Combine(err, unlock())
+	}
+	if conflict.Path != "" {
+		return nil, errs.Combine(ErrLocked.New("%s from %s, delete it if that operation is not running anymore",
+			repo.prefix+locksDir+conflict.Path, conflict.Metadata[metaHost]), unlock())
+	}
+	return unlock, nil
+}
+
+// listChunks returns the sizes of the stored chunks by their hashes
+func (repo *Repository) listChunks(ctx context.Context) (map[string]int64, error) {
+	chunks := map[string]int64{}
+	err := repo.list(ctx, repo.prefix+chunksDir, func(object storj.Object) error {
+		chunks[object.Path] = object.Size
+		return nil
+	})
+	return chunks, err
+}
+
+// list calls fn with the objects under prefix, whose paths are relative to
+// prefix
+func (repo *Repository) list(ctx context.Context, prefix storj.Path, fn func(storj.Object) error) error {
+	cursor := ""
+	for {
+		list, err := repo.bucket.ListObjects(ctx, &storj.ListOptions{
+			Direction: storj.After,
+			Cursor:    cursor,
+			Prefix:    prefix,
+			Recursive: true,
+		})
+		if err != nil {
+			return Error.Wrap(err)
+		}
+
+		for _, object := range list.Items {
+			if object.IsPrefix {
+				continue
+			}
+			if err := fn(object); err != nil {
+				return err
+			}
+		}
+
+		if !list.More || len(list.Items) == 0 {
+			return nil
+		}
+		cursor = list.Items[len(list.Items)-1].Path
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package backup_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/backup"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestRepository(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 5, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+
+		apiKey, err := uplink.ParseAPIKey(planet.Uplinks[0].APIKey[satellite.ID()])
+		require.NoError(t, err)
+
+		config := &uplink.Config{}
+		config.Volatile.TLS.SkipPeerCAWhitelist = true
+		upl, err := uplink.NewUplink(ctx, config)
+		require.NoError(t, err)
+		defer ctx.Check(upl.Close)
+
+		var key storj.Key
+		copy(key[:], "backup")
+		var options uplink.ProjectOptions
+		options.Volatile.EncryptionKey = &key
+		project, err := upl.OpenProject(ctx, satellite.Addr(), apiKey, &options)
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		var bucketConfig uplink.BucketConfig
+		bucketConfig.Volatile.RedundancyScheme = storj.RedundancyScheme{
+			Algorithm:      storj.ReedSolomon,
+			ShareSize:      memory.KiB.Int32(),
+			RequiredShares: 2,
+			RepairShares:   3,
+			OptimalShares:  4,
+			TotalShares:    5,
+		}
+		_, err = project.CreateBucket(ctx, "bucket", &bucketConfig)
+		require.NoError(t, err)
+		bucket, err := project.OpenBucket(ctx, "bucket", &uplink.EncryptionAccess{Key: key})
+		require.NoError(t, err)
+		defer ctx.Check(bucket.Close)
+
+		repo := backup.NewRepository(bucket, "backups/", backup.Config{
+			Chunker: backup.ChunkerConfig{
+				MinSize:     1 * memory.KiB,
+				AverageSize: 4 * memory.KiB,
+				MaxSize:     16 * memory.KiB,
+			},
+			Parallelism: 4,
+		})
+
+		source := ctx.Dir("source")
+		big := make([]byte, 64*memory.KiB)
+		_, err = rand.New(rand.NewSource(1)).Read(big)
+		require.NoError(t, err)
+
+		modified := time.Date(2019, 4, 1, 12, 0, 0, 0, time.UTC)
+		write := func(name string, data []byte) {
+			path := filepath.Join(source, filepath.FromSlash(name))
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, ioutil.WriteFile(path, data, 0640))
+			require.NoError(t, os.Chtimes(path, modified, modified))
+		}
+		write("big", big)
+		write("copy/of/big", big)
+		write("small", []byte("small"))
+		write("empty", nil)
+		require.NoError(t, os.Mkdir(filepath.Join(source, "dir"), 0755))
+		require.NoError(t, os.Symlink("small", filepath.Join(source, "link")))
+
+		first, stats, err := repo.Backup(ctx, source)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2*len(big)+5, stats.Bytes)
+		// the copy of big is deduplicated
+		assert.Equal(t, stats.Chunks-stats.NewChunks, stats.NewChunks-1)
+		assert.EqualValues(t, len(big)+5, stats.NewBytes)
+
+		// changing the start of big only uploads its first chunks
+		big = append([]byte("prepended"), big...)
+		write("big", big)
+		second, stats, err := repo.Backup(ctx, source)
+		require.NoError(t, err)
+		assert.True(t, stats.NewChunks <= 2, "%d new chunks", stats.NewChunks)
+
+		snapshots, err := repo.Snapshots(ctx)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 2)
+		assert.Equal(t, first.ID, snapshots[0].ID)
+		assert.Equal(t, second.SnapshotInfo.ID, snapshots[1].ID)
+		assert.Equal(t, 8, snapshots[1].Files)
+		assert.EqualValues(t, 2*len(big)-9+5, snapshots[1].Size)
+
+		restore := func(id string) string {
+			snapshot, err := repo.Snapshot(ctx, id)
+			require.NoError(t, err)
+
+			target := ctx.Dir("restore", snapshot.ID)
+			require.NoError(t, repo.Restore(ctx, snapshot, target))
+			return target
+		}
+		check := func(target, name string, data []byte) {
+			path := filepath.Join(target, filepath.FromSlash(name))
+			restored, err := ioutil.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, data, restored, name)
+
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0640), info.Mode(), name)
+			assert.True(t, modified.Equal(info.ModTime()), name)
+		}
+
+		target := restore(first.ID)
+		check(target, "big", big[9:])
+		check(target, "copy/of/big", big[9:])
+		check(target, "small", []byte("small"))
+		check(target, "empty", []byte{})
+		link, err := os.Readlink(filepath.Join(target, "link"))
+		require.NoError(t, err)
+		assert.Equal(t, "small", link)
+		info, err := os.Stat(filepath.Join(target, "dir"))
+		require.NoError(t, err)
+		assert.True(t, info.IsDir())
+
+		target = restore("latest")
+		check(target, "big", big)
+		check(target, "copy/of/big", big[9:])
+
+		{ // pruning without a policy is refused
+			_, err := repo.Prune(ctx, backup.Policy{}, false)
+			require.Error(t, err)
+		}
+
+		{ // a dry run deletes nothing
+			stats, err := repo.Prune(ctx, backup.Policy{Last: 1}, true)
+			require.NoError(t, err)
+			require.Len(t, stats.Removed, 1)
+			assert.Equal(t, first.ID, stats.Removed[0].ID)
+
+			snapshots, err := repo.Snapshots(ctx)
+			require.NoError(t, err)
+			assert.Len(t, snapshots, 2)
+		}
+
+		{ // a running backup blocks pruning, but not a dry run
+			require.NoError(t, bucket.UploadObject(ctx, "backups/locks/backup-running", bytes.NewReader(nil), nil))
+
+			_, err := repo.Prune(ctx, backup.Policy{Last: 1}, false)
+			require.True(t, backup.ErrLocked.Has(err), err)
+			_, err = repo.Prune(ctx, backup.Policy{Last: 1}, true)
+			require.NoError(t, err)
+
+			// backups may run at the same time
+			_, _, err = repo.Backup(ctx, source)
+			require.NoError(t, err)
+
+			require.NoError(t, bucket.DeleteObject(ctx, "backups/locks/backup-running"))
+		}
+
+		{ // a running prune blocks backups
+			require.NoError(t, bucket.UploadObject(ctx, "backups/locks/prune-running", bytes.NewReader(nil), nil))
+
+			_, _, err := repo.Backup(ctx, source)
+			require.True(t, backup.ErrLocked.Has(err), err)
+			_, err = repo.Prune(ctx, backup.Policy{Last: 1}, false)
+			require.True(t, backup.ErrLocked.Has(err), err)
+
+			require.NoError(t, bucket.DeleteObject(ctx, "backups/locks/prune-running"))
+		}
+
+		// the refused operations removed their own locks
+		locks, err := bucket.ListObjects(ctx, &storj.ListOptions{Prefix: "backups/locks/", Direction: storj.After, Recursive: true})
+		require.NoError(t, err)
+		assert.Empty(t, locks.Items)
+
+		// removing big from the source and pruning the first snapshot
+		// leaves no chunks for the restored files
+		require.NoError(t, os.Remove(filepath.Join(source, "big")))
+		require.NoError(t, os.Remove(filepath.Join(source, "copy/of/big")))
+		third, _, err := repo.Backup(ctx, source)
+		require.NoError(t, err)
+
+		pruned, err := repo.Prune(ctx, backup.Policy{Last: 1}, false)
+		require.NoError(t, err)
+		assert.Len(t, pruned.Removed, 3)
+		assert.True(t, pruned.Chunks > 0)
+
+		snapshots, err = repo.Snapshots(ctx)
+		require.NoError(t, err)
+		require.Len(t, snapshots, 1)
+		assert.Equal(t, third.ID, snapshots[0].ID)
+
+		list, err := bucket.ListObjects(ctx, &storj.ListOptions{Prefix: "backups/chunks/", Direction: storj.After, Recursive: true})
+		require.NoError(t, err)
+		assert.Len(t, list.Items, 1)
+
+		target = restore("latest")
+		check(target, "small", []byte("small"))
+		_, err = os.Stat(filepath.Join(target, "big"))
+		assert.True(t, os.IsNotExist(err))
+
+		_, err = repo.Snapshot(ctx, first.ID)
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+	})
+}
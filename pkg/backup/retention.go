@@ -0,0 +1,96 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package backup
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Policy selects the snapshots to keep when pruning. A snapshot is kept
+// when any of the rules keeps it. The hourly, daily, weekly, monthly and
+// yearly rules keep the newest snapshot of each of the last periods that
+// have snapshots, in the time zone the snapshot was taken in.
+type Policy struct {
+	// Last keeps the newest snapshots
+	Last int
+	// Hourly keeps the newest snapshot of each of the last hours
+	Hourly int
+	// Daily keeps the newest snapshot of each of the last days
+	Daily int
+	// Weekly keeps the newest snapshot of each of the last ISO weeks
+	Weekly int
+	// Monthly keeps the newest snapshot of each of the last months
+	Monthly int
+	// Yearly keeps the newest snapshot of each of the last years
+	Yearly int
+	// Within keeps the snapshots taken within the duration before now
+	Within time.Duration
+}
+
+// Empty returns whether the policy has no rules, which would remove all
+// snapshots
+func (policy Policy) Empty() bool {
+	return policy == Policy{}
+}
+
+// periodRule keeps the newest snapshot of count periods
+type periodRule struct {
+	count  int
+	period func(time.Time) string
+	last   string
+}
+
+// Apply splits snapshots into the ones to keep and the ones to remove. Both
+// are sorted by time, oldest first.
+func (policy Policy) Apply(snapshots []SnapshotInfo, now time.Time) (keep, remove []SnapshotInfo) {
+	newest := append([]SnapshotInfo{}, snapshots...)
+	sort.SliceStable(newest, func(i, k int) bool { return newest[i].Time.After(newest[k].Time) })
+
+	rules := []*periodRule{
+		{count: policy.Hourly, period: func(t time.Time) string { return t.Format("2006-01-02 15") }},
+		{count: policy.Daily, period: func(t time.Time) string { return t.Format("2006-01-02") }},
+		{count: policy.Weekly, period: func(t time.Time) string {
+			year, week := t.ISOWeek()
+			return fmt.Sprintf("%d-%d", year, week)
+		}},
+		{count: policy.Monthly, period: func(t time.Time) string { return t.Format("2006-01") }},
+		{count: policy.Yearly, period: func(t time.Time) string { return t.Format("2006") }},
+	}
+
+	for i, snapshot := range newest {
+		kept := i < policy.Last
+		if policy.Within > 0 && now.Sub(snapshot.Time) <= policy.Within {
+			kept = true
+		}
+		for _, rule := range rules {
+			if rule.count <= 0 {
+				continue
+			}
+			if period := rule.period(snapshot.Time); period != rule.last {
+				rule.last = period
+				rule.count--
+				kept = true
+			}
+		}
+
+		if kept {
+			keep = append(keep, snapshot)
+		} else {
+			remove = append(remove, snapshot)
+		}
+	}
+
+	reverse(keep)
+	reverse(remove)
+	return keep, remove
+}
+
+// reverse reverses the order of snapshots
+func reverse(snapshots []SnapshotInfo) {
+	for i, k := 0, len(snapshots)-1; i < k; i, k = i+1, k-1 {
+		snapshots[i], snapshots[k] = snapshots[k], snapshots[i]
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package backup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy(t *testing.T) {
+	now := time.Date(2019, 4, 30, 12, 0, 0, 0, time.UTC)
+
+	// two snapshots a day for the last 60 days
+	var snapshots []SnapshotInfo
+	for day := 59; day >= 0; day-- {
+		for _, hour := range []int{2, 10} {
+			at := time.Date(2019, 4, 30-day, hour, 0, 0, 0, time.UTC)
+			snapshots = append(snapshots, SnapshotInfo{ID: at.Format("0102-15"), Time: at})
+		}
+	}
+
+	ids := func(snapshots []SnapshotInfo) (ids []string) {
+		for _, snapshot := range snapshots {
+			ids = append(ids, snapshot.ID)
+		}
+		return ids
+	}
+
+	for _, test := range []struct {
+		policy Policy
+		keep   []string
+	}{
+		{Policy{Last: 3}, []string{"0429-10", "0430-02", "0430-10"}},
+		{Policy{Daily: 3}, []string{"0428-10", "0429-10", "0430-10"}},
+		{Policy{Weekly: 3}, []string{"0421-10", "0428-10", "0430-10"}},
+		{Policy{Monthly: 2}, []string{"0331-10", "0430-10"}},
+		{Policy{Yearly: 5}, []string{"0430-10"}},
+		{Policy{Within: 26 * time.Hour}, []string{"0429-10", "0430-02", "0430-10"}},
+		{Policy{Last: 2, Monthly: 3}, []string{"0331-10", "0430-02", "0430-10"}},
+		{Policy{Hourly: 2, Daily: 2}, []string{"0429-10", "0430-02", "0430-10"}},
+	} {
+		keep, remove := test.policy.Apply(snapshots, now)
+		assert.Equal(t, test.keep, ids(keep), "%+v", test.policy)
+		assert.Equal(t, len(snapshots), len(keep)+len(remove))
+	}
+
+	assert.True(t, Policy{}.Empty())
+	assert.False(t, Policy{Within: time.Hour}.Empty())
+}
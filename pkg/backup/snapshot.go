@@ -0,0 +1,132 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package backup
+
+import (
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// metadata keys of the snapshot objects, which allow listing snapshots
+// without downloading their manifests
+const (
+	metaTime   = "backup:time"
+	metaHost   = "backup:host"
+	metaSource = "backup:source"
+	metaFiles  = "backup:files"
+	metaSize   = "backup:size"
+)
+
+// SnapshotInfo describes a snapshot
+type SnapshotInfo struct {
+	ID     string    `json:"id"`
+	Time   time.Time `json:"time"`
+	Host   string    `json:"host"`
+	Source string    `json:"source"`
+	// Files is the number of files, directories and links
+	Files int `json:"files"`
+	// Size is the total size of the files
+	Size int64 `json:"size"`
+}
+
+// Snapshot is the manifest of a backup
+type Snapshot struct {
+	SnapshotInfo
+	Files []File `json:"entries"`
+}
+
+// File is a file, directory or symbolic link of a snapshot
+type File struct {
+	// Path is the slash separated path relative to the source directory
+	Path    string      `json:"path"`
+	Mode    os.FileMode `json:"mode"`
+	ModTime time.Time   `json:"mtime"`
+	Size    int64       `json:"size,omitempty"`
+	// Link is the target of a symbolic link
+	Link string `json:"link,omitempty"`
+	// Chunks are the SHA-256 hashes of the chunks of a regular file
+	Chunks []string `json:"chunks,omitempty"`
+}
+
+// newSnapshotID returns a new snapshot ID, which sorts by time
+func newSnapshotID(now time.Time) (string, error) {
+	var random [4]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return "", err
+	}
+	return now.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(random[:]), nil
+}
+
+// metadata returns the object metadata of info
+func (info *SnapshotInfo) metadata() map[string]string {
+	return map[string]string{
+		metaTime:   info.Time.Format(time.RFC3339Nano),
+		metaHost:   info.Host,
+		metaSource: info.Source,
+		metaFiles:  strconv.Itoa(info.Files),
+		metaSize:   strconv.FormatInt(info.Size, 10),
+	}
+}
+
+// snapshotInfo parses the object metadata of the snapshot with id
+func snapshotInfo(id string, metadata map[string]string) (info SnapshotInfo, err error) {
+	info.ID = id
+	info.Host = metadata[metaHost]
+	info.Source = metadata[metaSource]
+	info.Time, err = time.Parse(time.RFC3339Nano, metadata[metaTime])
+	if err != nil {
+		return info, Error.New("invalid time of snapshot %s: %v", id, err)
+	}
+	info.Files, err = strconv.Atoi(metadata[metaFiles])
+	if err != nil {
+		return info, Error.New("invalid file count of snapshot %s: %v", id, err)
+	}
+	info.Size, err = strconv.ParseInt(metadata[metaSize], 10, 64)
+	if err != nil {
+		return info, Error.New("invalid size of snapshot %s: %v", id, err)
+	}
+	return info, nil
+}
+
+// encodeSnapshot writes the compressed manifest of snapshot to w
+func encodeSnapshot(w io.Writer, snapshot *Snapshot) error {
+	compressed := gzip.NewWriter(w)
+	if err := json.NewEncoder(compressed).Encode(snapshot); err != nil {
+		return err
+	}
+	return compressed.Close()
+}
+
+// decodeSnapshot reads a compressed manifest and checks its paths
+func decodeSnapshot(r io.Reader) (*Snapshot, error) {
+	compressed, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	snapshot := &Snapshot{}
+	if err := json.NewDecoder(compressed).Decode(snapshot); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	for _, file := range snapshot.Files {
+		if !validPath(file.Path) {
+			return nil, Error.New("invalid path %q in snapshot %s", file.Path, snapshot.ID)
+		}
+	}
+	return snapshot, nil
+}
+
+// validPath returns whether p is a clean relative path, which stays within
+// the directory it is restored to
+func validPath(p string) bool {
+	return p != "" && p == path.Clean(p) && !path.IsAbs(p) && p != ".." && !strings.HasPrefix(p, "../")
+}
@@ -50,13 +50,14 @@ type Checker struct {
 	repairQueue     queue.RepairQueue
 	overlay         *overlay.Cache
 	irrdb           irreparable.DB
+	corrupted       *CorruptedPieces
 	logger          *zap.Logger
 	Loop            sync2.Cycle
 	IrreparableLoop sync2.Cycle
 }
 
 // NewChecker creates a new instance of checker
-func NewChecker(metainfo *metainfo.Service, repairQueue queue.RepairQueue, overlay *overlay.Cache, irrdb irreparable.DB, limit int, logger *zap.Logger, repairInterval, irreparableInterval time.Duration) *Checker {
+func NewChecker(metainfo *metainfo.Service, repairQueue queue.RepairQueue, overlay *overlay.Cache, irrdb irreparable.DB, corrupted *CorruptedPieces, limit int, logger *zap.Logger, repairInterval, irreparableInterval time.Duration) *Checker {
 	// TODO: reorder arguments
 	checker := &Checker{
 		metainfo:        metainfo,
@@ -64,6 +65,7 @@ func NewChecker(metainfo *metainfo.Service, repairQueue queue.RepairQueue, overl
 		repairQueue:     repairQueue,
 		overlay:         overlay,
 		irrdb:           irrdb,
+		corrupted:       corrupted,
 		logger:          logger,
 		Loop:            *sync2.NewCycle(repairInterval),
 		IrreparableLoop: *sync2.NewCycle(irreparableInterval),
@@ -117,6 +119,19 @@ func (checker *Checker) IdentifyInjuredSegments(ctx context.Context) (err error)
 
 	var monStats durabilityStats
 
+	// reported pieces that aren't found in any segment during this pass are
+	// dropped, unless the pass didn't finish. Pieces are only forgotten once
+	// they were removed from their segment.
+	corrupted := checker.corrupted.take()
+	defer func() {
+		if err != nil {
+			checker.corrupted.restore(corrupted)
+		}
+	}()
+	// pointers without the reported pieces are stored after iterating,
+	// since the store may not allow writes during iteration.
+	updated := make(map[string]*corruptedUpdate)
+
 	err = checker.metainfo.Iterate("", checker.lastChecked, true, false,
 		func(it storage.Iterator) error {
 			var item storage.ListItem
@@ -145,6 +160,14 @@ func (checker *Checker) IdentifyInjuredSegments(ctx context.Context) (err error)
 					return Error.New("error unmarshalling pointer %s", err)
 				}
 
+				if removed := removeCorrupted(pointer, corrupted); len(removed) > 0 {
+					updated[item.Key.String()] = &corruptedUpdate{
+						old:     append([]byte(nil), item.Value...),
+						pointer: pointer,
+						removed: removed,
+					}
+				}
+
 				err = checker.updateSegmentStatus(ctx, pointer, item.Key.String(), &monStats)
 				if err != nil {
 					return err
@@ -157,6 +180,110 @@ func (checker *Checker) IdentifyInjuredSegments(ctx context.Context) (err error)
 		return err
 	}
 
+	for path, update := range updated {
+		// the pointer may have been uploaded, repaired or deleted since it
+		// was read, then its pieces are checked again in the next pass
+		err = checker.metainfo.CompareAndSwap(path, update.old, update.pointer)
+		if storage.ErrValueChanged.Has(err) || storage.ErrKeyNotFound.Has(err) {
+			checker.corrupted.restore(update.removed)
+			forget(corrupted, update.removed)
+			continue
+		}
+		if err != nil {
+			return Error.New("error removing corrupted pieces %s", err)
+		}
+		forget(corrupted, update.removed)
+		mon.Meter("corrupted_pieces_removed").Mark(update.count())
+
+		err = checker.penalize(ctx, update.removed)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// corruptedUpdate is a pointer without the reported pieces which were
+// removed from it, and the pointer as it was read
+type corruptedUpdate struct {
+	old     []byte
+	pointer *pb.Pointer
+	removed map[storj.NodeID]map[storj.PieceID]struct{}
+}
+
+// count returns the number of removed pieces
+func (update *corruptedUpdate) count() int {
+	count := 0
+	for _, pieceIDs := range update.removed {
+		count += len(pieceIDs)
+	}
+	return count
+}
+
+// removeCorrupted removes the pieces that storage nodes reported as corrupted
+// from the pointer, so that they are repaired instead of failing audits. It
+// returns the removed pieces, corrupted is left unchanged.
+func removeCorrupted(pointer *pb.Pointer, corrupted map[storj.NodeID]map[storj.PieceID]struct{}) map[storj.NodeID]map[storj.PieceID]struct{} {
+	remote := pointer.GetRemote()
+	if remote == nil || len(corrupted) == 0 {
+		return nil
+	}
+
+	var remaining []*pb.RemotePiece
+	removed := make(map[storj.NodeID]map[storj.PieceID]struct{})
+	for _, piece := range remote.GetRemotePieces() {
+		if reported, ok := corrupted[piece.NodeId]; ok {
+			pieceID := remote.RootPieceId.Derive(piece.NodeId)
+			if _, ok := reported[pieceID]; ok {
+				if removed[piece.NodeId] == nil {
+					removed[piece.NodeId] = make(map[storj.PieceID]struct{})
+				}
+				removed[piece.NodeId][pieceID] = struct{}{}
+				continue
+			}
+		}
+		remaining = append(remaining, piece)
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+
+	remote.RemotePieces = remaining
+	return removed
+}
+
+// forget drops the pieces of removed from corrupted
+func forget(corrupted, removed map[storj.NodeID]map[storj.PieceID]struct{}) {
+	for nodeID, pieceIDs := range removed {
+		for pieceID := range pieceIDs {
+			delete(corrupted[nodeID], pieceID)
+		}
+		if len(corrupted[nodeID]) == 0 {
+			delete(corrupted, nodeID)
+		}
+	}
+}
+
+// penalize counts every removed piece as a failed audit of its node. A
+// node reporting its own pieces gets them repaired early, but it doesn't
+// escape the reputation cost of losing them.
+func (checker *Checker) penalize(ctx context.Context, removed map[storj.NodeID]map[storj.PieceID]struct{}) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for nodeID, pieceIDs := range removed {
+		for range pieceIDs {
+			_, err = checker.overlay.UpdateStats(ctx, &overlay.UpdateRequest{
+				NodeID:       nodeID,
+				IsUp:         true,
+				AuditSuccess: false,
+			})
+			if err != nil {
+				return Error.New("error updating stats of node with corrupted pieces %s", err)
+			}
+		}
+	}
 	return nil
 }
 
@@ -204,8 +331,9 @@ func (checker *Checker) updateSegmentStatus(ctx context.Context, pointer *pb.Poi
 		}
 		monStats.remoteSegmentsNeedingRepair++
 		err = checker.repairQueue.Insert(ctx, &pb.InjuredSegment{
-			Path:       path,
-			LostPieces: missingPieces,
+			Path:             path,
+			LostPieces:       missingPieces,
+			NumHealthyPieces: numHealthy,
 		})
 		if err != nil {
 			return Error.New("error adding injured segment to queue %s", err)
@@ -9,8 +9,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
 
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testplanet"
@@ -18,6 +20,7 @@ import (
 	"storj.io/storj/pkg/datarepair/checker"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/storage"
 )
 
@@ -52,6 +55,7 @@ func TestIdentifyInjuredSegments(t *testing.T) {
 		numValidNode := int32(len(planet.StorageNodes))
 		require.Equal(t, "b", injuredSegment.Path)
 		require.Equal(t, len(planet.StorageNodes), len(injuredSegment.LostPieces))
+		require.Equal(t, numValidNode, injuredSegment.NumHealthyPieces)
 		for _, lostPiece := range injuredSegment.LostPieces {
 			// makePointer() starts with numValidNode good pieces
 			require.True(t, lostPiece >= numValidNode, fmt.Sprintf("%d >= %d \n", lostPiece, numValidNode))
@@ -157,6 +161,83 @@ func TestIdentifyIrreparableSegments(t *testing.T) {
 	})
 }
 
+func TestRemoveCorruptedPieces(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 0,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Repair.Checker.Loop.Pause()
+
+		makePointer(t, planet, "a", false)
+		pointer, err := satellite.Metainfo.Service.Get("a")
+		require.NoError(t, err)
+		piece := pointer.GetRemote().GetRemotePieces()[0]
+
+		store := &changingStore{KeyValueStore: satellite.Metainfo.Service.DB}
+		corrupted := checker.NewCorruptedPieces()
+		c := checker.NewChecker(metainfo.NewService(zaptest.NewLogger(t), store), &mockRepairQueue{}, satellite.Overlay.Service, satellite.DB.Irreparable(), corrupted, 0, zaptest.NewLogger(t), 30*time.Second, 15*time.Second)
+
+		corrupted.Add(piece.NodeId, []storj.PieceID{pointer.GetRemote().RootPieceId.Derive(piece.NodeId)})
+		before, err := satellite.Overlay.Service.Get(ctx, piece.NodeId)
+		require.NoError(t, err)
+
+		{ // a pointer changed during the pass is left alone
+			store.change = func() {
+				changed := *pointer
+				changed.Metadata = []byte("changed")
+				require.NoError(t, satellite.Metainfo.Service.Put("a", &changed))
+			}
+			require.NoError(t, c.IdentifyInjuredSegments(ctx))
+
+			current, err := satellite.Metainfo.Service.Get("a")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("changed"), current.Metadata)
+			assert.Len(t, current.GetRemote().GetRemotePieces(), len(pointer.GetRemote().GetRemotePieces()))
+		}
+
+		{ // the piece is removed in the next pass and costs the node an audit
+			require.NoError(t, c.IdentifyInjuredSegments(ctx))
+
+			current, err := satellite.Metainfo.Service.Get("a")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("changed"), current.Metadata)
+			require.Len(t, current.GetRemote().GetRemotePieces(), len(pointer.GetRemote().GetRemotePieces())-1)
+			for _, remaining := range current.GetRemote().GetRemotePieces() {
+				assert.NotEqual(t, piece.NodeId, remaining.NodeId)
+			}
+
+			after, err := satellite.Overlay.Service.Get(ctx, piece.NodeId)
+			require.NoError(t, err)
+			assert.Equal(t, before.Reputation.AuditCount+1, after.Reputation.AuditCount)
+			assert.Equal(t, before.Reputation.AuditSuccessCount, after.Reputation.AuditSuccessCount)
+		}
+
+		{ // the piece was forgotten after it was removed
+			makePointer(t, planet, "a", false)
+			require.NoError(t, c.IdentifyInjuredSegments(ctx))
+
+			current, err := satellite.Metainfo.Service.Get("a")
+			require.NoError(t, err)
+			assert.Len(t, current.GetRemote().GetRemotePieces(), len(pointer.GetRemote().GetRemotePieces()))
+		}
+	})
+}
+
+// changingStore calls change once after the next iteration
+type changingStore struct {
+	storage.KeyValueStore
+	change func()
+}
+
+func (store *changingStore) Iterate(opts storage.IterateOptions, fn func(storage.Iterator) error) error {
+	err := store.KeyValueStore.Iterate(opts, fn)
+	if store.change != nil {
+		store.change()
+		store.change = nil
+	}
+	return err
+}
+
 func makePointer(t *testing.T, planet *testplanet.Planet, pieceID string, createLost bool) {
 	numOfStorageNodes := len(planet.StorageNodes)
 	pieces := make([]*pb.RemotePiece, 0, numOfStorageNodes)
@@ -202,7 +283,7 @@ func TestCheckerResume(t *testing.T) {
 	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
 		repairQueue := &mockRepairQueue{}
 		irrepairQueue := planet.Satellites[0].DB.Irreparable()
-		c := checker.NewChecker(planet.Satellites[0].Metainfo.Service, repairQueue, planet.Satellites[0].Overlay.Service, irrepairQueue, 0, nil, 30*time.Second, 15*time.Second)
+		c := checker.NewChecker(planet.Satellites[0].Metainfo.Service, repairQueue, planet.Satellites[0].Overlay.Service, irrepairQueue, checker.NewCorruptedPieces(), 0, nil, 30*time.Second, 15*time.Second)
 
 		// create pointer that needs repair
 		makePointer(t, planet, "a", true)
@@ -293,3 +374,7 @@ func (mockRepairQueue *mockRepairQueue) Delete(ctx context.Context, s *pb.Injure
 func (mockRepairQueue *mockRepairQueue) SelectN(ctx context.Context, limit int) ([]pb.InjuredSegment, error) {
 	return []pb.InjuredSegment{}, errs.New("mock SelectN error")
 }
+
+func (mockRepairQueue *mockRepairQueue) Depth(ctx context.Context) ([]*pb.QueueDepthBucket, error) {
+	return nil, errs.New("mock Depth error")
+}
@@ -0,0 +1,66 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package checker
+
+import (
+	"sync"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// maxCorruptedPerNode limits how many reported pieces are kept for a single storage node
+const maxCorruptedPerNode = 10000
+
+// CorruptedPieces collects pieces that storage nodes reported as corrupted,
+// until the checker removes them from the segments they belong to.
+type CorruptedPieces struct {
+	mu     sync.Mutex
+	pieces map[storj.NodeID]map[storj.PieceID]struct{}
+}
+
+// NewCorruptedPieces creates an empty set of reported pieces.
+func NewCorruptedPieces() *CorruptedPieces {
+	return &CorruptedPieces{
+		pieces: make(map[storj.NodeID]map[storj.PieceID]struct{}),
+	}
+}
+
+// Add records pieces that failed an integrity check on the storage node.
+func (corrupted *CorruptedPieces) Add(nodeID storj.NodeID, pieceIDs []storj.PieceID) {
+	corrupted.mu.Lock()
+	defer corrupted.mu.Unlock()
+
+	reported, ok := corrupted.pieces[nodeID]
+	if !ok {
+		reported = make(map[storj.PieceID]struct{}, len(pieceIDs))
+		corrupted.pieces[nodeID] = reported
+	}
+	for _, pieceID := range pieceIDs {
+		if len(reported) >= maxCorruptedPerNode {
+			break
+		}
+		reported[pieceID] = struct{}{}
+	}
+}
+
+// take removes and returns all reported pieces.
+func (corrupted *CorruptedPieces) take() map[storj.NodeID]map[storj.PieceID]struct{} {
+	corrupted.mu.Lock()
+	defer corrupted.mu.Unlock()
+
+	pieces := corrupted.pieces
+	corrupted.pieces = make(map[storj.NodeID]map[storj.PieceID]struct{})
+	return pieces
+}
+
+// restore adds back pieces that were taken but not handled.
+func (corrupted *CorruptedPieces) restore(pieces map[storj.NodeID]map[storj.PieceID]struct{}) {
+	for nodeID, reported := range pieces {
+		pieceIDs := make([]storj.PieceID, 0, len(reported))
+		for pieceID := range reported {
+			pieceIDs = append(pieceIDs, pieceID)
+		}
+		corrupted.Add(nodeID, pieceIDs)
+	}
+}
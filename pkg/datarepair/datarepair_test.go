@@ -4,17 +4,22 @@
 package datarepair_test
 
 import (
+	"context"
 	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
 
 	"storj.io/storj/internal/memory"
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testplanet"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/storagenode/orders"
 	"storj.io/storj/uplink"
 )
 
@@ -131,3 +136,204 @@ func TestDataRepair(t *testing.T) {
 		}
 	})
 }
+
+func TestStandaloneRepairer(t *testing.T) {
+	var satelliteConfig satellite.Config
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 10,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Repairer.Standalone = true
+				satelliteConfig = *config
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		ul := planet.Uplinks[0]
+		sat := planet.Satellites[0]
+		// the segment is queued by hand, discovery and the checker must not touch the overlay or the queue
+		sat.Discovery.Service.Discovery.Stop()
+		sat.Discovery.Service.Refresh.Stop()
+		sat.Discovery.Service.Graveyard.Stop()
+		sat.Repair.Checker.Loop.Pause()
+
+		// the satellite leaves repairs to the standalone workers
+		require.Nil(t, sat.Repair.Repairer)
+
+		testData := make([]byte, 1*memory.MiB)
+		_, err := rand.Read(testData)
+		require.NoError(t, err)
+
+		err = ul.UploadWithConfig(ctx, sat, &uplink.RSConfig{
+			MinThreshold:     3,
+			RepairThreshold:  4,
+			SuccessThreshold: 6,
+			MaxThreshold:     6,
+		}, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		path, pointer := getRemoteSegment(t, sat)
+
+		// kill two nodes holding pieces of the segment
+		killed := make(map[storj.NodeID]bool)
+		for _, piece := range pointer.GetRemote().GetRemotePieces()[:2] {
+			killed[piece.NodeId] = true
+		}
+		for _, node := range planet.StorageNodes {
+			if killed[node.ID()] {
+				require.NoError(t, planet.StopPeer(node))
+				_, err = sat.Overlay.Service.UpdateUptime(ctx, node.ID(), false)
+				require.NoError(t, err)
+			}
+		}
+
+		err = sat.DB.RepairQueue().Insert(ctx, &pb.InjuredSegment{
+			Path:             path,
+			NumHealthyPieces: int32(len(pointer.GetRemote().GetRemotePieces()) - len(killed)),
+		})
+		require.NoError(t, err)
+
+		worker, err := satellite.NewRepairer(zaptest.NewLogger(t).Named("repairer"), sat.Identity, sat.DB, sat.Metainfo.Database, &satellite.RepairerConfig{
+			Server:   satelliteConfig.Server.Config,
+			Overlay:  satelliteConfig.Overlay,
+			Metainfo: satelliteConfig.Metainfo,
+			Repairer: satelliteConfig.Repairer,
+			Version:  satelliteConfig.Version,
+		}, planet.NewVersionInfo())
+		require.NoError(t, err)
+		defer ctx.Check(worker.Close)
+
+		workerCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		ctx.Go(func() error {
+			return worker.Run(workerCtx)
+		})
+
+		worker.Repairer.Service.Loop.TriggerWait()
+		worker.Repairer.Service.Limiter.Wait()
+
+		// the worker dequeued the segment and replaced the pieces of the killed nodes
+		queued, err := sat.DB.RepairQueue().SelectN(ctx, 10)
+		require.NoError(t, err)
+		assert.Empty(t, queued)
+
+		pointer, err = sat.Metainfo.Service.Get(path)
+		require.NoError(t, err)
+
+		remotePieces := pointer.GetRemote().GetRemotePieces()
+		assert.Len(t, remotePieces, int(pointer.GetRemote().GetRedundancy().GetSuccessThreshold()))
+		for _, piece := range remotePieces {
+			assert.False(t, killed[piece.NodeId])
+		}
+
+		newData, err := ul.Download(ctx, sat, "testbucket", "test/path")
+		require.NoError(t, err)
+		assert.Equal(t, testData, newData)
+	})
+}
+
+// getRemoteSegment returns the path and pointer of the first remote segment.
+func getRemoteSegment(t *testing.T, sat *satellite.Peer) (string, *pb.Pointer) {
+	listResponse, _, err := sat.Metainfo.Service.List("", "", "", true, 0, 0)
+	require.NoError(t, err)
+
+	for _, v := range listResponse {
+		path := v.GetPath()
+		pointer, err := sat.Metainfo.Service.Get(path)
+		require.NoError(t, err)
+		if pointer.GetType() == pb.Pointer_REMOTE {
+			return path, pointer
+		}
+	}
+
+	t.Fatal("no remote segment found")
+	return "", nil
+}
+
+func TestNodeRepairSettlement(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount:   1,
+		StorageNodeCount: 10,
+		UplinkCount:      1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Repairer.NodeRepair = true
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		ul := planet.Uplinks[0]
+		sat := planet.Satellites[0]
+		sat.Discovery.Service.Discovery.Stop()
+		sat.Discovery.Service.Refresh.Stop()
+		sat.Discovery.Service.Graveyard.Stop()
+		sat.Audit.Service.Loop.Stop()
+		sat.Repair.Checker.Loop.Pause()
+		sat.Repair.Repairer.Loop.Pause()
+		for _, node := range planet.StorageNodes {
+			node.Storage2.Sender.Loop.Pause()
+		}
+
+		testData := make([]byte, 1*memory.MiB)
+		_, err := rand.Read(testData)
+		require.NoError(t, err)
+
+		err = ul.UploadWithConfig(ctx, sat, &uplink.RSConfig{
+			MinThreshold:     3,
+			RepairThreshold:  4,
+			SuccessThreshold: 6,
+			MaxThreshold:     6,
+		}, "testbucket", "test/path", testData)
+		require.NoError(t, err)
+
+		path, pointer := getRemoteSegment(t, sat)
+
+		killed := make(map[storj.NodeID]bool)
+		for _, piece := range pointer.GetRemote().GetRemotePieces()[:2] {
+			killed[piece.NodeId] = true
+		}
+		for _, node := range planet.StorageNodes {
+			if killed[node.ID()] {
+				require.NoError(t, planet.StopPeer(node))
+				_, err = sat.Overlay.Service.UpdateUptime(ctx, node.ID(), false)
+				require.NoError(t, err)
+			}
+		}
+
+		err = sat.DB.RepairQueue().Insert(ctx, &pb.InjuredSegment{
+			Path:             path,
+			NumHealthyPieces: int32(len(pointer.GetRemote().GetRemotePieces()) - len(killed)),
+		})
+		require.NoError(t, err)
+
+		sat.Repair.Repairer.Loop.TriggerWait()
+		sat.Repair.Repairer.Limiter.Wait()
+
+		pointer, err = sat.Metainfo.Service.Get(path)
+		require.NoError(t, err)
+		require.Len(t, pointer.GetRemote().GetRemotePieces(), int(pointer.GetRemote().GetRedundancy().GetSuccessThreshold()))
+
+		// orders for the repair are signed by the repairing storage node, the satellite
+		// must know its public key to accept them
+		repairOrders := 0
+		for _, node := range planet.StorageNodes {
+			if killed[node.ID()] {
+				continue
+			}
+			node.Storage2.Sender.Loop.TriggerWait()
+
+			archived, err := node.DB.Orders().ListArchived(ctx, 100)
+			require.NoError(t, err)
+			for _, info := range archived {
+				action := info.Limit.GetAction()
+				if action != pb.PieceAction_GET_REPAIR && action != pb.PieceAction_PUT_REPAIR {
+					continue
+				}
+				repairOrders++
+				assert.NotEqual(t, sat.ID(), info.Limit.UplinkId)
+				assert.Equal(t, orders.StatusAccepted, info.Status, "%v order from %v", action, info.Limit.UplinkId)
+			}
+		}
+		assert.NotZero(t, repairOrders)
+	})
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package queue
+
+import (
+	"context"
+
+	"storj.io/storj/pkg/pb"
+)
+
+// Inspector is a gRPC service for inspecting the repair queue
+type Inspector struct {
+	queue RepairQueue
+}
+
+// NewInspector creates an Inspector
+func NewInspector(queue RepairQueue) *Inspector {
+	return &Inspector{queue: queue}
+}
+
+// QueueDepth returns the number of queued segments grouped by their number of healthy pieces
+func (srv *Inspector) QueueDepth(ctx context.Context, req *pb.QueueDepthRequest) (*pb.QueueDepthResponse, error) {
+	buckets, err := srv.queue.Depth(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	return &pb.QueueDepthResponse{Buckets: buckets}, nil
+}
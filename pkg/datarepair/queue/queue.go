@@ -12,12 +12,15 @@ import (
 // RepairQueue implements queueing for segments that need repairing.
 // Implementation can be found at satellite/satellitedb/repairqueue.go.
 type RepairQueue interface {
-	// Insert adds an injured segment.
+	// Insert adds an injured segment, or updates the health of an already queued one.
 	Insert(ctx context.Context, s *pb.InjuredSegment) error
-	// Select gets an injured segment.
+	// Select leases the injured segment with the fewest healthy pieces, oldest first.
+	// A leased segment isn't returned to other callers until the lease expires.
 	Select(ctx context.Context) (*pb.InjuredSegment, error)
 	// Delete removes an injured segment.
 	Delete(ctx context.Context, s *pb.InjuredSegment) error
 	// SelectN lists limit amount of injured segments.
 	SelectN(ctx context.Context, limit int) ([]pb.InjuredSegment, error)
+	// Depth returns the number of queued and leased segments for each number of healthy pieces.
+	Depth(ctx context.Context) ([]*pb.QueueDepthBucket, error)
 }
@@ -167,3 +167,70 @@ func TestParallel(t *testing.T) {
 		}
 	})
 }
+
+func TestSelectByHealth(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		q := db.RepairQueue()
+
+		for _, healthy := range []int32{8, 5, 7, 6} {
+			err := q.Insert(ctx, &pb.InjuredSegment{
+				Path:             strconv.Itoa(int(healthy)),
+				LostPieces:       []int32{0},
+				NumHealthyPieces: healthy,
+			})
+			require.NoError(t, err)
+		}
+
+		// reinserting a queued segment updates its health
+		err := q.Insert(ctx, &pb.InjuredSegment{
+			Path:             "8",
+			LostPieces:       []int32{0, 1, 2, 3},
+			NumHealthyPieces: 4,
+		})
+		require.NoError(t, err)
+
+		for _, expected := range []string{"8", "5", "6", "7"} {
+			seg, err := q.Select(ctx)
+			require.NoError(t, err)
+			require.Equal(t, expected, seg.Path)
+		}
+
+		// all segments are leased
+		_, err = q.Select(ctx)
+		require.True(t, storage.ErrEmptyQueue.Has(err), "error should of class EmptyQueue")
+	})
+}
+
+func TestDepth(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		q := db.RepairQueue()
+
+		buckets, err := q.Depth(ctx)
+		require.NoError(t, err)
+		require.Empty(t, buckets)
+
+		for i, healthy := range []int32{5, 6, 5, 7, 5} {
+			err := q.Insert(ctx, &pb.InjuredSegment{
+				Path:             strconv.Itoa(i),
+				NumHealthyPieces: healthy,
+			})
+			require.NoError(t, err)
+		}
+
+		_, err = q.Select(ctx)
+		require.NoError(t, err)
+
+		buckets, err = q.Depth(ctx)
+		require.NoError(t, err)
+		require.Len(t, buckets, 3)
+		require.True(t, pb.Equal(&pb.QueueDepthBucket{NumHealthyPieces: 5, Count: 3, Leased: 1}, buckets[0]))
+		require.True(t, pb.Equal(&pb.QueueDepthBucket{NumHealthyPieces: 6, Count: 1, Leased: 0}, buckets[1]))
+		require.True(t, pb.Equal(&pb.QueueDepthBucket{NumHealthyPieces: 7, Count: 1, Leased: 0}, buckets[2]))
+	})
+}
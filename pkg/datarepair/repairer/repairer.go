@@ -37,15 +37,17 @@ type Config struct {
 	Interval     time.Duration `help:"how frequently checker should audit segments" releaseDefault:"1h" devDefault:"0h5m0s"`
 	Timeout      time.Duration `help:"time limit for uploading repaired pieces to new storage nodes" default:"10m0s"`
 	MaxBufferMem memory.Size   `help:"maximum buffer memory (in bytes) to be allocated for read buffers" default:"4M"`
+	NodeRepair   bool          `help:"if true, a healthy storage node reconstructs the segment and uploads the repaired pieces instead of the satellite" default:"false"`
+	Standalone   bool          `help:"if true, the satellite doesn't repair segments itself and leaves the repair queue to standalone repair workers" default:"false"`
 }
 
 // GetSegmentRepairer creates a new segment repairer from storeConfig values
-func (c Config) GetSegmentRepairer(ctx context.Context, tc transport.Client, metainfo *metainfo.Service, orders *orders.Service, cache *overlay.Cache, identity *identity.FullIdentity) (ss SegmentRepairer, err error) {
+func (c Config) GetSegmentRepairer(ctx context.Context, tc transport.Client, metainfo *metainfo.Service, orders *orders.Service, cache *overlay.Cache, identity *identity.FullIdentity, auditor segments.Auditor) (ss SegmentRepairer, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	ec := ecclient.NewClient(tc, c.MaxBufferMem.Int())
 
-	return segments.NewSegmentRepairer(metainfo, orders, cache, ec, identity, c.Timeout), nil
+	return segments.NewSegmentRepairer(metainfo, orders, cache, ec, tc, identity, c.Timeout, c.NodeRepair, auditor), nil
 }
 
 // SegmentRepairer is a repairer for segments
@@ -63,11 +65,12 @@ type Service struct {
 	metainfo  *metainfo.Service
 	orders    *orders.Service
 	cache     *overlay.Cache
+	auditor   segments.Auditor
 	repairer  SegmentRepairer
 }
 
 // NewService creates repairing service
-func NewService(queue queue.RepairQueue, config *Config, interval time.Duration, concurrency int, transport transport.Client, metainfo *metainfo.Service, orders *orders.Service, cache *overlay.Cache) *Service {
+func NewService(queue queue.RepairQueue, config *Config, interval time.Duration, concurrency int, transport transport.Client, metainfo *metainfo.Service, orders *orders.Service, cache *overlay.Cache, auditor segments.Auditor) *Service {
 	return &Service{
 		queue:     queue,
 		config:    config,
@@ -77,6 +80,7 @@ func NewService(queue queue.RepairQueue, config *Config, interval time.Duration,
 		metainfo:  metainfo,
 		orders:    orders,
 		cache:     cache,
+		auditor:   auditor,
 	}
 }
 
@@ -95,6 +99,7 @@ func (service *Service) Run(ctx context.Context) (err error) {
 		service.orders,
 		service.cache,
 		service.transport.Identity(),
+		service.auditor,
 	)
 	if err != nil {
 		return err
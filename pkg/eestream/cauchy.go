@@ -0,0 +1,246 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eestream
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/klauspost/reedsolomon"
+	"github.com/vivint/infectious"
+
+	"storj.io/storj/pkg/pb"
+)
+
+type cauchyScheme struct {
+	enc              reedsolomon.Encoder
+	required         int
+	total            int
+	erasureShareSize int
+}
+
+// NewCauchyRSScheme returns a Reed-Solomon-based ErasureScheme that uses a
+// Cauchy encoding matrix and SIMD accelerated Galois field arithmetic.
+//
+// Decode corrects up to (n-k)/2 corrupted erasure shares, where n is the
+// number of shares given and k the required count, by searching for the
+// shares that have to be left out for the rest to be consistent. The search
+// is bounded by maxCorrectionAttempts, which covers all combinations only for
+// few corrupted shares or small n, e.g. up to 2 corrupted shares of 80. When
+// the bound is reached, Decode only detects the corruption and returns
+// ErrUnableToCorrect.
+func NewCauchyRSScheme(required, total, erasureShareSize int) (ErasureScheme, error) {
+	if required <= 0 || total <= required {
+		return nil, Error.New("invalid cauchy scheme: required %d, total %d", required, total)
+	}
+	enc, err := reedsolomon.New(required, total-required, reedsolomon.WithCauchyMatrix())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &cauchyScheme{
+		enc:              enc,
+		required:         required,
+		total:            total,
+		erasureShareSize: erasureShareSize,
+	}, nil
+}
+
+// shards splits input into the data shards and allocates the parity shards.
+func (s *cauchyScheme) shards(input []byte) ([][]byte, error) {
+	if len(input)%s.required != 0 {
+		return nil, Error.New("input length must be a multiple of %d", s.required)
+	}
+	shareSize := len(input) / s.required
+	shards := make([][]byte, s.total)
+	for i := 0; i < s.required; i++ {
+		shards[i] = input[i*shareSize : (i+1)*shareSize : (i+1)*shareSize]
+	}
+	for i := s.required; i < s.total; i++ {
+		shards[i] = make([]byte, shareSize)
+	}
+	return shards, nil
+}
+
+func (s *cauchyScheme) EncodeSingle(input, output []byte, num int) (err error) {
+	if num < 0 || num >= s.total {
+		return Error.New("invalid erasure share number %d", num)
+	}
+	shards, err := s.shards(input)
+	if err != nil {
+		return err
+	}
+	if num >= s.required {
+		if err := s.enc.Encode(shards); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+	copy(output, shards[num])
+	return nil
+}
+
+func (s *cauchyScheme) Encode(input []byte, output func(num int, data []byte)) (err error) {
+	shards, err := s.shards(input)
+	if err != nil {
+		return err
+	}
+	if err := s.enc.Encode(shards); err != nil {
+		return Error.Wrap(err)
+	}
+	for num, data := range shards {
+		output(num, data)
+	}
+	return nil
+}
+
+// maxCorrectionAttempts bounds the number of combinations of erasure shares
+// that Decode leaves out when looking for corrupted ones.
+const maxCorrectionAttempts = 4096
+
+// ErrUnableToCorrect is returned by the Cauchy scheme when it detects
+// corrupted erasure shares, but gives up locating them before trying every
+// combination the shares could be corrected with. It is a kind of
+// infectious.TooManyErrors.
+var ErrUnableToCorrect = infectious.TooManyErrors.NewClass("unable to correct")
+
+func (s *cauchyScheme) Decode(out []byte, in map[int][]byte) ([]byte, error) {
+	if len(in) < s.required {
+		return nil, infectious.NotEnoughShares.New("must specify at least the number of required shares")
+	}
+
+	shareSize := -1
+	shards := make([][]byte, s.total)
+	nums := make([]int, 0, len(in))
+	for num, data := range in {
+		if num < 0 || num >= s.total {
+			return nil, Error.New("invalid erasure share number %d", num)
+		}
+		if shareSize >= 0 && len(data) != shareSize {
+			return nil, Error.New("erasure shares have different sizes")
+		}
+		shareSize = len(data)
+		shards[num] = data
+		nums = append(nums, num)
+	}
+	sort.Ints(nums)
+
+	// with e corrupted shares left out, the remaining len(in)-e shares
+	// determine the stripe unambiguously as long as e <= (len(in)-k)/2.
+	var data [][]byte
+	attempts, exhausted := 0, false
+	maxErrors := (len(in) - s.required) / 2
+	for errors := 0; errors <= maxErrors && data == nil && !exhausted; errors++ {
+		var err error
+		combinations(nums, errors, func(skip []int) bool {
+			if attempts >= maxCorrectionAttempts {
+				exhausted = true
+				return false
+			}
+			attempts++
+			data, err = s.reconstruct(shards, skip, shareSize)
+			return data == nil && err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if data == nil && exhausted {
+		return nil, ErrUnableToCorrect.New("corrupted erasure shares not located in %d attempts", maxCorrectionAttempts)
+	}
+	if data == nil {
+		return nil, infectious.TooManyErrors.New("too many corrupted erasure shares")
+	}
+
+	resultLen := shareSize * s.required
+	if cap(out) < resultLen {
+		out = make([]byte, resultLen)
+	} else {
+		out = out[:resultLen]
+	}
+	for i := 0; i < s.required; i++ {
+		copy(out[i*shareSize:], data[i])
+	}
+	return out, nil
+}
+
+// reconstruct rebuilds the data shards from shards, leaving out the shares
+// listed in skip. It returns nil if the remaining shares are not consistent
+// with the result.
+func (s *cauchyScheme) reconstruct(shards [][]byte, skip []int, shareSize int) ([][]byte, error) {
+	// reconstruct into a separate slice, so that the received shares can
+	// be compared against the re-encoded result afterwards.
+	work := make([][]byte, s.total)
+	copy(work, shards)
+	for _, num := range skip {
+		work[num] = nil
+	}
+	if err := s.enc.ReconstructData(work); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	check := make([][]byte, s.total)
+	copy(check, work[:s.required])
+	for i := s.required; i < s.total; i++ {
+		check[i] = make([]byte, shareSize)
+	}
+	if err := s.enc.Encode(check); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	for num, data := range work {
+		if data != nil && !bytes.Equal(data, check[num]) {
+			return nil, nil
+		}
+	}
+	return check[:s.required], nil
+}
+
+// combinations calls fn with every k element subset of items, in
+// lexicographic order, until fn returns false.
+func combinations(items []int, k int, fn func(subset []int) bool) {
+	indexes := make([]int, k)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	subset := make([]int, k)
+	for {
+		for i, index := range indexes {
+			subset[i] = items[index]
+		}
+		if !fn(subset) {
+			return
+		}
+
+		// advance to the next combination
+		i := k - 1
+		for i >= 0 && indexes[i] == len(items)-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		indexes[i]++
+		for j := i + 1; j < k; j++ {
+			indexes[j] = indexes[j-1] + 1
+		}
+	}
+}
+
+func (s *cauchyScheme) ErasureShareSize() int {
+	return s.erasureShareSize
+}
+
+func (s *cauchyScheme) StripeSize() int {
+	return s.erasureShareSize * s.required
+}
+
+func (s *cauchyScheme) TotalCount() int {
+	return s.total
+}
+
+func (s *cauchyScheme) RequiredCount() int {
+	return s.required
+}
+
+func (s *cauchyScheme) SchemeType() pb.RedundancyScheme_SchemeType {
+	return pb.RedundancyScheme_RS_CAUCHY
+}
@@ -9,7 +9,6 @@ import (
 	"io/ioutil"
 	"os"
 
-	"github.com/vivint/infectious"
 	"go.uber.org/zap"
 
 	"storj.io/storj/internal/fpath"
@@ -48,6 +47,10 @@ type ErasureScheme interface {
 
 	// Decode requires at least this many pieces
 	RequiredCount() int
+
+	// SchemeType is the type stored in pointers for segments encoded with
+	// this scheme
+	SchemeType() pb.RedundancyScheme_SchemeType
 }
 
 // RedundancyStrategy is an ErasureScheme with a repair and optimal thresholds
@@ -98,11 +101,10 @@ func NewRedundancyStrategy(es ErasureScheme, repairThreshold, optimalThreshold i
 // NewRedundancyStrategyFromProto creates new RedundancyStrategy from the given
 // RedundancyScheme protobuf.
 func NewRedundancyStrategyFromProto(scheme *pb.RedundancyScheme) (RedundancyStrategy, error) {
-	fc, err := infectious.NewFEC(int(scheme.GetMinReq()), int(scheme.GetTotal()))
+	es, err := NewErasureScheme(scheme.GetType(), int(scheme.GetMinReq()), int(scheme.GetTotal()), int(scheme.GetErasureShareSize()))
 	if err != nil {
-		return RedundancyStrategy{}, Error.Wrap(err)
+		return RedundancyStrategy{}, err
 	}
-	es := NewRSScheme(fc, int(scheme.GetErasureShareSize()))
 	return NewRedundancyStrategy(es, int(scheme.GetRepairThreshold()), int(scheme.GetSuccessThreshold()))
 }
 
@@ -5,6 +5,8 @@ package eestream
 
 import (
 	"github.com/vivint/infectious"
+
+	"storj.io/storj/pkg/pb"
 )
 
 type rsScheme struct {
@@ -51,3 +53,7 @@ func (s *rsScheme) TotalCount() int {
 func (s *rsScheme) RequiredCount() int {
 	return s.fc.Required()
 }
+
+func (s *rsScheme) SchemeType() pb.RedundancyScheme_SchemeType {
+	return pb.RedundancyScheme_RS
+}
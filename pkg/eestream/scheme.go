@@ -0,0 +1,41 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eestream
+
+import (
+	"github.com/vivint/infectious"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// NewErasureScheme returns the ErasureScheme implementation for the given
+// scheme type, as stored in pointers.
+func NewErasureScheme(schemeType pb.RedundancyScheme_SchemeType, required, total, erasureShareSize int) (ErasureScheme, error) {
+	switch schemeType {
+	case pb.RedundancyScheme_RS:
+		fc, err := infectious.NewFEC(required, total)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		return NewRSScheme(fc, erasureShareSize), nil
+	case pb.RedundancyScheme_RS_CAUCHY:
+		return NewCauchyRSScheme(required, total, erasureShareSize)
+	default:
+		return nil, Error.New("unsupported redundancy scheme type: %v", schemeType)
+	}
+}
+
+// SchemeTypeForAlgorithm returns the scheme type to store in pointers for the
+// given redundancy algorithm.
+func SchemeTypeForAlgorithm(algorithm storj.RedundancyAlgorithm) (pb.RedundancyScheme_SchemeType, error) {
+	switch algorithm {
+	case storj.ReedSolomon:
+		return pb.RedundancyScheme_RS, nil
+	case storj.CauchyReedSolomon:
+		return pb.RedundancyScheme_RS_CAUCHY, nil
+	default:
+		return 0, Error.New("unsupported redundancy algorithm: %d", algorithm)
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package eestream
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vivint/infectious"
+	"github.com/zeebo/errs"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+var schemeTypes = []pb.RedundancyScheme_SchemeType{
+	pb.RedundancyScheme_RS,
+	pb.RedundancyScheme_RS_CAUCHY,
+}
+
+func TestErasureSchemeConformance(t *testing.T) {
+	for _, schemeType := range schemeTypes {
+		for _, conf := range []struct{ required, total int }{
+			{1, 2},
+			{2, 4},
+			{4, 10},
+			{29, 80},
+		} {
+			schemeType, conf := schemeType, conf
+			name := fmt.Sprintf("%v/r%dt%d", schemeType, conf.required, conf.total)
+			t.Run(name, func(t *testing.T) {
+				es, err := NewErasureScheme(schemeType, conf.required, conf.total, 256)
+				require.NoError(t, err)
+				assert.Equal(t, schemeType, es.SchemeType())
+				assert.Equal(t, conf.required, es.RequiredCount())
+				assert.Equal(t, conf.total, es.TotalCount())
+				assert.Equal(t, 256*conf.required, es.StripeSize())
+
+				stripe := randData(es.StripeSize())
+
+				shares := make(map[int][]byte, conf.total)
+				err = es.Encode(stripe, func(num int, data []byte) {
+					shares[num] = append([]byte{}, data...)
+				})
+				require.NoError(t, err)
+				require.Len(t, shares, conf.total)
+
+				for num := 0; num < conf.total; num++ {
+					single := make([]byte, es.ErasureShareSize())
+					require.NoError(t, es.EncodeSingle(stripe, single, num))
+					assert.Equal(t, shares[num], single, "share %d", num)
+				}
+
+				for i := 0; i < 10; i++ {
+					n := conf.required + rand.Intn(conf.total-conf.required+1)
+					subset := make(map[int][]byte, n)
+					for _, num := range rand.Perm(conf.total)[:n] {
+						subset[num] = append([]byte{}, shares[num]...)
+					}
+					decoded, err := es.Decode(nil, subset)
+					require.NoError(t, err)
+					assert.Equal(t, stripe, decoded)
+				}
+
+				subset := make(map[int][]byte, conf.required-1)
+				for num := 0; num < conf.required-1; num++ {
+					subset[num] = shares[num]
+				}
+				_, err = es.Decode(nil, subset)
+				assert.Error(t, err)
+			})
+		}
+	}
+}
+
+func TestErasureSchemeCorrectsCorruption(t *testing.T) {
+	for _, schemeType := range schemeTypes {
+		for _, tt := range []struct {
+			shares    int
+			corrupted []int
+			correct   bool
+		}{
+			{10, nil, true},
+			{10, []int{1}, true},
+			{10, []int{0, 9}, true},
+			{10, []int{2, 5, 7}, true},
+			{6, []int{4}, true},
+			{5, []int{1}, false},
+			{9, []int{0, 1, 2}, false},
+		} {
+			name := fmt.Sprintf("%v/n%d/%v", schemeType, tt.shares, tt.corrupted)
+			es, err := NewErasureScheme(schemeType, 4, 10, 256)
+			require.NoError(t, err, name)
+
+			stripe := randData(es.StripeSize())
+			shares := make(map[int][]byte, es.TotalCount())
+			err = es.Encode(stripe, func(num int, data []byte) {
+				if num < tt.shares {
+					shares[num] = append([]byte{}, data...)
+				}
+			})
+			require.NoError(t, err, name)
+
+			for _, num := range tt.corrupted {
+				shares[num][0]++
+			}
+
+			decoded, err := es.Decode(nil, shares)
+			if tt.correct {
+				require.NoError(t, err, name)
+				assert.Equal(t, stripe, decoded, name)
+			} else {
+				// either error lets the StripeReader wait for more shares
+				require.Error(t, err, name)
+				assert.True(t, infectious.TooManyErrors.Contains(err) || infectious.NotEnoughShares.Contains(err), name)
+			}
+		}
+	}
+}
+
+func TestCauchyUnableToCorrect(t *testing.T) {
+	es, err := NewCauchyRSScheme(4, 40, 256)
+	require.NoError(t, err)
+
+	stripe := randData(es.StripeSize())
+	shares := make(map[int][]byte, es.TotalCount())
+	err = es.Encode(stripe, func(num int, data []byte) {
+		shares[num] = append([]byte{}, data...)
+	})
+	require.NoError(t, err)
+
+	// two corrupted shares are located within maxCorrectionAttempts
+	shares[35][0]++
+	shares[37][0]++
+	decoded, err := es.Decode(nil, shares)
+	require.NoError(t, err)
+	assert.Equal(t, stripe, decoded)
+
+	// three are not, although 40 shares could correct them
+	shares[39][0]++
+	_, err = es.Decode(nil, shares)
+	require.Error(t, err)
+	assert.True(t, ErrUnableToCorrect.Contains(err), err)
+	assert.True(t, infectious.TooManyErrors.Contains(err), err)
+}
+
+func TestErasureSchemeStreams(t *testing.T) {
+	ctx := context.Background()
+	for _, schemeType := range schemeTypes {
+		es, err := NewErasureScheme(schemeType, 2, 4, 8*1024)
+		require.NoError(t, err)
+		rs, err := NewRedundancyStrategy(es, 0, 0)
+		require.NoError(t, err)
+
+		data := randData(32 * 1024)
+		readers, err := EncodeReader(ctx, bytes.NewReader(data), rs)
+		require.NoError(t, err)
+
+		readerMap := make(map[int]io.ReadCloser, len(readers))
+		for i, reader := range readers {
+			readerMap[i] = reader
+		}
+		// drop all but the required amount of pieces
+		delete(readerMap, 0)
+		assert.NoError(t, readers[0].Close())
+		delete(readerMap, 3)
+		assert.NoError(t, readers[3].Close())
+
+		decoder := DecodeReaders(ctx, readerMap, rs, int64(len(data)), 0)
+		data2, err := ioutil.ReadAll(decoder)
+		require.NoError(t, err)
+		assert.Equal(t, data, data2)
+		assert.NoError(t, decoder.Close())
+	}
+}
+
+func TestErasureSchemeStreamsCorrupted(t *testing.T) {
+	ctx := context.Background()
+	for _, schemeType := range schemeTypes {
+		es, err := NewErasureScheme(schemeType, 2, 4, 1024)
+		require.NoError(t, err)
+		rs, err := NewRedundancyStrategy(es, 0, 0)
+		require.NoError(t, err)
+
+		data := randData(32 * 1024)
+		readers, err := EncodeReader(ctx, bytes.NewReader(data), rs)
+		require.NoError(t, err)
+
+		pieces := make([][]byte, len(readers))
+		var group errgroup.Group
+		for i, reader := range readers {
+			i, reader := i, reader
+			group.Go(func() (err error) {
+				pieces[i], err = ioutil.ReadAll(reader)
+				return errs.Combine(err, reader.Close())
+			})
+		}
+		require.NoError(t, group.Wait())
+
+		// corrupt every stripe of a single piece
+		for i := 0; i < len(pieces[1]); i += es.ErasureShareSize() {
+			pieces[1][i]++
+		}
+
+		readerMap := make(map[int]io.ReadCloser, len(pieces))
+		for i, piece := range pieces {
+			readerMap[i] = ioutil.NopCloser(bytes.NewReader(piece))
+		}
+
+		decoder := DecodeReaders(ctx, readerMap, rs, int64(len(data)), 0)
+		data2, err := ioutil.ReadAll(decoder)
+		require.NoError(t, err, schemeType.String())
+		assert.Equal(t, data, data2, schemeType.String())
+		assert.NoError(t, decoder.Close())
+	}
+}
+
+func TestNewRedundancyStrategyFromProto(t *testing.T) {
+	for _, tt := range []struct {
+		algorithm  storj.RedundancyAlgorithm
+		schemeType pb.RedundancyScheme_SchemeType
+	}{
+		{storj.ReedSolomon, pb.RedundancyScheme_RS},
+		{storj.CauchyReedSolomon, pb.RedundancyScheme_RS_CAUCHY},
+	} {
+		schemeType, err := SchemeTypeForAlgorithm(tt.algorithm)
+		require.NoError(t, err)
+		assert.Equal(t, tt.schemeType, schemeType)
+
+		rs, err := NewRedundancyStrategyFromProto(&pb.RedundancyScheme{
+			Type:             schemeType,
+			MinReq:           4,
+			Total:            10,
+			RepairThreshold:  6,
+			SuccessThreshold: 8,
+			ErasureShareSize: 1024,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, tt.schemeType, rs.SchemeType())
+		assert.Equal(t, 6, rs.RepairThreshold())
+		assert.Equal(t, 8, rs.OptimalThreshold())
+	}
+
+	_, err := SchemeTypeForAlgorithm(storj.InvalidRedundancyAlgorithm)
+	assert.Error(t, err)
+
+	_, err = NewRedundancyStrategyFromProto(&pb.RedundancyScheme{
+		Type:   pb.RedundancyScheme_SchemeType(100),
+		MinReq: 4,
+		Total:  10,
+	})
+	assert.Error(t, err)
+}
+
+func BenchmarkErasureSchemes(b *testing.B) {
+	confs := []struct{ required, total int }{
+		{4, 10},
+		{29, 80},
+		{29, 130},
+	}
+
+	for _, schemeType := range schemeTypes {
+		for _, conf := range confs {
+			es, err := NewErasureScheme(schemeType, conf.required, conf.total, 1024)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			stripe := randData(es.StripeSize())
+			shares := make(map[int][]byte, conf.total)
+			err = es.Encode(stripe, func(num int, data []byte) {
+				shares[num] = append([]byte{}, data...)
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			name := fmt.Sprintf("%v/r%dt%d", schemeType, conf.required, conf.total)
+
+			b.Run("Encode/"+name, func(b *testing.B) {
+				b.SetBytes(int64(len(stripe)))
+				for i := 0; i < b.N; i++ {
+					err := es.Encode(stripe, func(num int, data []byte) {})
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+
+			// decoding from the parity shares only is the worst case for
+			// repair, where the data shares are usually the ones missing
+			parity := make(map[int][]byte, conf.required)
+			for num := conf.total - conf.required; num < conf.total; num++ {
+				parity[num] = shares[num]
+			}
+			output := make([]byte, len(stripe))
+
+			b.Run("Decode/"+name, func(b *testing.B) {
+				b.SetBytes(int64(len(stripe)))
+				for i := 0; i < b.N; i++ {
+					_, err := es.Decode(output, parity)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package geoip locates IP addresses with a local database file.
+//
+// The database is a csv file mapping networks in CIDR notation to ISO 3166
+// country codes, one network per line:
+//
+//	2.16.0.0/13,DE
+//	2a02:2e0::/29,DE
+//
+// Empty lines and lines starting with '#' are ignored. When networks
+// overlap, the most specific one is used.
+package geoip
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// Error is the default geoip errs class
+var Error = errs.Class("geoip error")
+
+// DB locates IP addresses
+type DB struct {
+	// prefixes are ordered from the most to the least specific mask
+	prefixes []*prefix
+}
+
+// prefix contains the networks sharing a mask
+type prefix struct {
+	mask     net.IPMask
+	networks map[string]string
+}
+
+// Open loads the database from the csv file at path
+func Open(path string) (_ *DB, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, Error.Wrap(file.Close())) }()
+
+	return Parse(file)
+}
+
+// Parse loads the database from csv data
+func Parse(r io.Reader) (*DB, error) {
+	byMask := make(map[string]*prefix)
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) != 2 {
+			return nil, Error.New("line %d: expected network and country code", lineNumber)
+		}
+
+		_, network, err := net.ParseCIDR(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, Error.New("line %d: %v", lineNumber, err)
+		}
+		country := strings.ToUpper(strings.TrimSpace(fields[1]))
+		if country == "" {
+			return nil, Error.New("line %d: missing country code", lineNumber)
+		}
+
+		ip, mask := normalize(network.IP), network.Mask
+		p, ok := byMask[string(mask)]
+		if !ok {
+			p = &prefix{mask: mask, networks: make(map[string]string)}
+			byMask[string(mask)] = p
+		}
+		p.networks[string(ip.Mask(mask))] = country
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	db := &DB{}
+	for _, p := range byMask {
+		db.prefixes = append(db.prefixes, p)
+	}
+	sort.Slice(db.prefixes, func(i, k int) bool {
+		ones1, _ := db.prefixes[i].mask.Size()
+		ones2, _ := db.prefixes[k].mask.Size()
+		return ones1 > ones2
+	})
+	return db, nil
+}
+
+// Country returns the country code of ip, or an empty string when it is unknown
+func (db *DB) Country(ip net.IP) string {
+	ip = normalize(ip)
+	for _, p := range db.prefixes {
+		if len(p.mask) != len(ip) {
+			continue
+		}
+		if country, ok := p.networks[string(ip.Mask(p.mask))]; ok {
+			return country
+		}
+	}
+	return ""
+}
+
+// normalize returns the 4 byte representation of IPv4 addresses
+func normalize(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	return ip
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package geoip_test
+
+import (
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/geoip"
+)
+
+const database = `# network,country
+2.16.0.0/13,de
+2.16.8.0/24,FR
+81.0.0.0/8,NL
+
+2a02:2e0::/29,DE
+`
+
+func TestCountry(t *testing.T) {
+	db, err := geoip.Parse(strings.NewReader(database))
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		ip      string
+		country string
+	}{
+		{"2.16.1.1", "DE"},
+		{"2.23.255.255", "DE"},
+		{"2.16.8.20", "FR"},
+		{"81.12.0.1", "NL"},
+		{"::ffff:81.12.0.1", "NL"},
+		{"2a02:2e0::1", "DE"},
+		{"2.24.0.1", ""},
+		{"2a03::1", ""},
+	} {
+		assert.Equal(t, tt.country, db.Country(net.ParseIP(tt.ip)), tt.ip)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	path := ctx.File("geoip.csv")
+	require.NoError(t, ioutil.WriteFile(path, []byte(database), 0644))
+
+	db, err := geoip.Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, "FR", db.Country(net.ParseIP("2.16.8.1")))
+
+	_, err = geoip.Open(ctx.File("missing.csv"))
+	assert.Error(t, err)
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, data := range []string{
+		"2.16.0.0/13",
+		"2.16.0.0/13,DE,extra",
+		"2.16.0.0,DE",
+		"2.16.0.0/13,",
+	} {
+		_, err := geoip.Parse(strings.NewReader(data))
+		assert.Error(t, err, data)
+	}
+}
@@ -28,6 +28,7 @@ type Config struct {
 	BootstrapBackoffBase time.Duration `help:"the base interval to wait when retrying bootstrap" default:"1s"`
 	DBPath               string        `help:"the path for storage node db services to be created on" default:"$CONFDIR/kademlia"`
 	ExternalAddress      string        `user:"true" help:"the public address of the Kademlia node, useful for nodes behind NAT" default:""`
+	RelayAddress         string        `user:"true" help:"address of a relay to accept connections through, advertised instead of the external address for storage nodes that are not publicly reachable" default:""`
 	Operator             OperatorConfig
 
 	// TODO: reduce the number of flags here
@@ -121,6 +121,13 @@ func (rt *RoutingTable) UpdateSelf(capacity *pb.NodeCapacity) {
 	}
 }
 
+// UpdateLastIP updates the ip address the local node reports next to its dial address
+func (rt *RoutingTable) UpdateLastIP(lastIP string) {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+	rt.self.LastIp = lastIP
+}
+
 // K returns the currently configured maximum of nodes to store in a bucket
 func (rt *RoutingTable) K() int {
 	return rt.bucketSize
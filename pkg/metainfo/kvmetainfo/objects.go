@@ -140,6 +140,32 @@ func (db *DB) DeleteObject(ctx context.Context, bucket string, path storj.Path)
 	return store.Delete(ctx, path)
 }
 
+// UpdateObjectMetadata replaces the content type and the user defined metadata
+// of a committed object without uploading its content again
+func (db *DB) UpdateObjectMetadata(ctx context.Context, bucket string, path storj.Path, contentType string, metadata map[string]string) (info storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	store, err := db.buckets.GetObjectStore(ctx, bucket)
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	meta, err := store.UpdateMeta(ctx, path, pb.SerializableMeta{
+		ContentType: contentType,
+		UserDefined: metadata,
+	})
+	if err != nil {
+		return storj.Object{}, err
+	}
+
+	return objectFromMeta(bucketInfo, path, false, meta), nil
+}
+
 // ModifyPendingObject creates an interface for updating a partially uploaded object
 func (db *DB) ModifyPendingObject(ctx context.Context, bucket string, path storj.Path) (object storj.MutableObject, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -306,7 +332,8 @@ func objectFromMeta(bucket storj.Bucket, path storj.Path, isPrefix bool, meta ob
 
 		Stream: storj.Stream{
 			Size:     meta.Size,
-			Checksum: []byte(meta.Checksum),
+			Checksum: meta.Checksum,
+			MD5:      meta.MD5,
 		},
 	}
 }
@@ -335,14 +362,15 @@ func objectStreamFromMeta(bucket storj.Bucket, path storj.Path, lastSegment segm
 		Expires:     lastSegment.Expiration, // TODO: use correct field
 
 		Stream: storj.Stream{
-			Size: stream.SegmentsSize*(stream.NumberOfSegments-1) + stream.LastSegmentSize,
-			// Checksum: []byte(object.Checksum),
+			Size:     stream.SegmentsSize*(stream.NumberOfSegments-1) + stream.LastSegmentSize,
+			Checksum: stream.Sha256,
+			MD5:      stream.Md5,
 
 			SegmentCount:     stream.NumberOfSegments,
 			FixedSegmentSize: stream.SegmentsSize,
 
 			RedundancyScheme: storj.RedundancyScheme{
-				Algorithm:      storj.ReedSolomon,
+				Algorithm:      redundancyAlgorithmFromType(redundancyScheme.GetType()),
 				ShareSize:      redundancyScheme.GetErasureShareSize(),
 				RequiredShares: int16(redundancyScheme.GetMinReq()),
 				RepairShares:   int16(redundancyScheme.GetRepairThreshold()),
@@ -362,6 +390,17 @@ func objectStreamFromMeta(bucket storj.Bucket, path storj.Path, lastSegment segm
 	}, nil
 }
 
+func redundancyAlgorithmFromType(schemeType pb.RedundancyScheme_SchemeType) storj.RedundancyAlgorithm {
+	switch schemeType {
+	case pb.RedundancyScheme_RS:
+		return storj.ReedSolomon
+	case pb.RedundancyScheme_RS_CAUCHY:
+		return storj.CauchyReedSolomon
+	default:
+		return storj.InvalidRedundancyAlgorithm
+	}
+}
+
 // convertTime converts gRPC timestamp to Go time
 func convertTime(ts *timestamp.Timestamp) time.Time {
 	if ts == nil {
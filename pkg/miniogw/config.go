@@ -15,3 +15,10 @@ type MinioConfig struct {
 type ServerConfig struct {
 	Address string `help:"address to serve S3 api over" default:"127.0.0.1:7777"`
 }
+
+// MultiTenantConfig determines how a gateway serves multiple tenants
+type MultiTenantConfig struct {
+	Enabled      bool   `help:"serve every access key with its own access grant from the credentials store" default:"false"`
+	Credentials  string `help:"path to the credentials store of the tenants" default:"$CONFDIR/credentials.json"`
+	MinioAddress string `help:"internal address minio listens on when serving multiple tenants" default:"127.0.0.1:7778"`
+}
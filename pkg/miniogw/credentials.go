@@ -0,0 +1,199 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+// ErrCredentials is the errs class of the credentials store
+var ErrCredentials = errs.Class("credentials error")
+
+// Access is the access grant a multi-tenant gateway serves an S3 access key
+// with. EncryptionKey is the base58 encoded root encryption key.
+type Access struct {
+	SecretKey     string `json:"secret-key"`
+	SatelliteAddr string `json:"satellite-addr"`
+	APIKey        string `json:"api-key"`
+	EncryptionKey string `json:"encryption-key"`
+}
+
+// Credentials is a local file mapping S3 access keys to access grants.
+//
+// The file is only readable by its owner and is reloaded when it is changed,
+// so access grants can be managed while the gateway is running.
+type Credentials struct {
+	path string
+
+	mu       sync.Mutex
+	modTime  time.Time
+	size     int64
+	accesses map[string]Access
+}
+
+// OpenCredentials opens the credentials store at path. A missing file is
+// treated as an empty store.
+func OpenCredentials(path string) (*Credentials, error) {
+	creds := &Credentials{path: path}
+
+	creds.mu.Lock()
+	defer creds.mu.Unlock()
+
+	if err := creds.reload(); err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+// Lookup returns the access grant of accessKey
+func (creds *Credentials) Lookup(accessKey string) (access Access, ok bool, err error) {
+	creds.mu.Lock()
+	defer creds.mu.Unlock()
+
+	if err := creds.reload(); err != nil {
+		return Access{}, false, err
+	}
+
+	access, ok = creds.accesses[accessKey]
+	return access, ok, nil
+}
+
+// List returns the sorted access keys in the store
+func (creds *Credentials) List() ([]string, error) {
+	creds.mu.Lock()
+	defer creds.mu.Unlock()
+
+	if err := creds.reload(); err != nil {
+		return nil, err
+	}
+
+	accessKeys := make([]string, 0, len(creds.accesses))
+	for accessKey := range creds.accesses {
+		accessKeys = append(accessKeys, accessKey)
+	}
+	sort.Strings(accessKeys)
+	return accessKeys, nil
+}
+
+// Put stores the access grant of accessKey, replacing any previous one
+func (creds *Credentials) Put(accessKey string, access Access) error {
+	if accessKey == "" || access.SecretKey == "" {
+		return ErrCredentials.New("access key and secret key are required")
+	}
+
+	creds.mu.Lock()
+	defer creds.mu.Unlock()
+
+	if err := creds.reload(); err != nil {
+		return err
+	}
+
+	accesses := make(map[string]Access, len(creds.accesses)+1)
+	for key, value := range creds.accesses {
+		accesses[key] = value
+	}
+	accesses[accessKey] = access
+
+	return creds.save(accesses)
+}
+
+// Delete removes the access grant of accessKey
+func (creds *Credentials) Delete(accessKey string) error {
+	creds.mu.Lock()
+	defer creds.mu.Unlock()
+
+	if err := creds.reload(); err != nil {
+		return err
+	}
+
+	if _, ok := creds.accesses[accessKey]; !ok {
+		return ErrCredentials.New("access key %q not found", accessKey)
+	}
+
+	accesses := make(map[string]Access, len(creds.accesses))
+	for key, value := range creds.accesses {
+		if key != accessKey {
+			accesses[key] = value
+		}
+	}
+
+	return creds.save(accesses)
+}
+
+// reload reads the file when it has changed since it was last read
+func (creds *Credentials) reload() error {
+	info, err := os.Stat(creds.path)
+	if os.IsNotExist(err) {
+		creds.modTime, creds.size, creds.accesses = time.Time{}, 0, map[string]Access{}
+		return nil
+	}
+	if err != nil {
+		return ErrCredentials.Wrap(err)
+	}
+	if creds.accesses != nil && info.ModTime().Equal(creds.modTime) && info.Size() == creds.size {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(creds.path)
+	if err != nil {
+		return ErrCredentials.Wrap(err)
+	}
+
+	accesses := map[string]Access{}
+	if err := json.Unmarshal(data, &accesses); err != nil {
+		return ErrCredentials.New("invalid credentials file %q: %v", creds.path, err)
+	}
+
+	creds.modTime, creds.size, creds.accesses = info.ModTime(), info.Size(), accesses
+	return nil
+}
+
+// save atomically replaces the file with accesses
+func (creds *Credentials) save(accesses map[string]Access) (err error) {
+	data, err := json.MarshalIndent(accesses, "", "\t")
+	if err != nil {
+		return ErrCredentials.Wrap(err)
+	}
+
+	dir := filepath.Dir(creds.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return ErrCredentials.Wrap(err)
+	}
+
+	tmp, err := ioutil.TempFile(dir, filepath.Base(creds.path)+".tmp")
+	if err != nil {
+		return ErrCredentials.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	_, err = tmp.Write(data)
+	err = errs.Combine(err, tmp.Chmod(0600), tmp.Close())
+	if err != nil {
+		return ErrCredentials.Wrap(err)
+	}
+
+	if err := os.Rename(tmp.Name(), creds.path); err != nil {
+		return ErrCredentials.Wrap(err)
+	}
+
+	info, err := os.Stat(creds.path)
+	if err != nil {
+		return ErrCredentials.Wrap(err)
+	}
+
+	creds.modTime, creds.size, creds.accesses = info.ModTime(), info.Size(), accesses
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw_test
+
+import (
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/miniogw"
+)
+
+func TestCredentials(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	path := ctx.File("gateway", "credentials.json")
+
+	creds, err := miniogw.OpenCredentials(path)
+	require.NoError(t, err)
+
+	{ // a missing store is empty
+		accessKeys, err := creds.List()
+		require.NoError(t, err)
+		assert.Empty(t, accessKeys)
+
+		_, ok, err := creds.Lookup("alpha")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+
+	alpha := miniogw.Access{SecretKey: "alpha-secret", SatelliteAddr: "127.0.0.1:10000", APIKey: "alpha-api-key", EncryptionKey: "alpha-key"}
+	beta := miniogw.Access{SecretKey: "beta-secret", SatelliteAddr: "127.0.0.1:10000", APIKey: "beta-api-key", EncryptionKey: "beta-key"}
+
+	require.NoError(t, creds.Put("alpha", alpha))
+	require.NoError(t, creds.Put("beta", beta))
+	require.Error(t, creds.Put("gamma", miniogw.Access{}))
+
+	{ // the access grants are stored in a file only the owner can read
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		if runtime.GOOS != "windows" {
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+		}
+	}
+
+	{ // another store sees the changes
+		other, err := miniogw.OpenCredentials(path)
+		require.NoError(t, err)
+
+		accessKeys, err := other.List()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alpha", "beta"}, accessKeys)
+
+		access, ok, err := other.Lookup("beta")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, beta, access)
+
+		require.NoError(t, other.Delete("alpha"))
+		require.Error(t, other.Delete("alpha"))
+	}
+
+	{ // the store is reloaded after it was changed
+		_, ok, err := creds.Lookup("alpha")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		accessKeys, err := creds.List()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"beta"}, accessKeys)
+	}
+}
@@ -31,26 +31,55 @@ var (
 
 // NewStorjGateway creates a *Storj object from an existing ObjectStore
 func NewStorjGateway(project *uplink.Project, rootEncKey *storj.Key, pathCipher storj.CipherSuite, encryption storj.EncryptionParameters, redundancy storj.RedundancyScheme, segmentSize memory.Size) *Gateway {
+	return newGateway(&tenant{
+		project:   project,
+		access:    &uplink.EncryptionAccess{Key: *rootEncKey},
+		multipart: NewMultipartUploads(),
+	}, pathCipher, encryption, redundancy, segmentSize)
+}
+
+// NewMultiTenantGateway creates a gateway which serves every request with the
+// access grant of its access key. Requests must reach the gateway through the
+// handler of tenants.
+func NewMultiTenantGateway(tenants *Tenants, pathCipher storj.CipherSuite, encryption storj.EncryptionParameters, redundancy storj.RedundancyScheme, segmentSize memory.Size) *Gateway {
+	return newGateway(tenants, pathCipher, encryption, redundancy, segmentSize)
+}
+
+func newGateway(tenants tenantResolver, pathCipher storj.CipherSuite, encryption storj.EncryptionParameters, redundancy storj.RedundancyScheme, segmentSize memory.Size) *Gateway {
 	return &Gateway{
-		project:     project,
-		rootEncKey:  rootEncKey,
+		tenants:     tenants,
 		pathCipher:  pathCipher,
 		encryption:  encryption,
 		redundancy:  redundancy,
 		segmentSize: segmentSize,
-		multipart:   NewMultipartUploads(),
 	}
 }
 
 // Gateway is the implementation of a minio cmd.Gateway
 type Gateway struct {
-	project     *uplink.Project
-	rootEncKey  *storj.Key
+	tenants     tenantResolver
 	pathCipher  storj.CipherSuite
 	encryption  storj.EncryptionParameters
 	redundancy  storj.RedundancyScheme
 	segmentSize memory.Size
-	multipart   *MultipartUploads
+}
+
+// tenant is the project, encryption access and pending multipart uploads
+// requests are served with
+type tenant struct {
+	project   *uplink.Project
+	access    *uplink.EncryptionAccess
+	multipart *MultipartUploads
+}
+
+// tenantResolver returns the tenant of a request
+type tenantResolver interface {
+	tenant(ctx context.Context) (*tenant, error)
+}
+
+// tenant implements tenantResolver for a gateway with a single tenant
+func (tenant *tenant) tenant(ctx context.Context) (*tenant, error) {
+	return tenant, nil
 }
 
 // Name implements cmd.Gateway
@@ -76,7 +105,12 @@ type gatewayLayer struct {
 func (layer *gatewayLayer) DeleteBucket(ctx context.Context, bucketName string) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	empty, err := layer.bucketEmpty(ctx, bucketName)
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	empty, err := layer.bucketEmpty(ctx, tenant, bucketName)
 	if err != nil {
 		return convertError(err, bucketName, "")
 	}
@@ -85,13 +119,13 @@ func (layer *gatewayLayer) DeleteBucket(ctx context.Context, bucketName string)
 		return minio.BucketNotEmpty{Bucket: bucketName}
 	}
 
-	err = layer.gateway.project.DeleteBucket(ctx, bucketName)
+	err = tenant.project.DeleteBucket(ctx, bucketName)
 
 	return convertError(err, bucketName, "")
 }
 
-func (layer *gatewayLayer) bucketEmpty(ctx context.Context, bucketName string) (empty bool, err error) {
-	bucket, err := layer.gateway.project.OpenBucket(ctx, bucketName, &uplink.EncryptionAccess{Key: *layer.gateway.rootEncKey})
+func (layer *gatewayLayer) bucketEmpty(ctx context.Context, tenant *tenant, bucketName string) (empty bool, err error) {
+	bucket, err := tenant.project.OpenBucket(ctx, bucketName, tenant.access)
 	if err != nil {
 		return false, convertError(err, bucketName, "")
 	}
@@ -108,7 +142,12 @@ func (layer *gatewayLayer) bucketEmpty(ctx context.Context, bucketName string) (
 func (layer *gatewayLayer) DeleteObject(ctx context.Context, bucketName, objectPath string) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	bucket, err := layer.gateway.project.OpenBucket(ctx, bucketName, &uplink.EncryptionAccess{Key: *layer.gateway.rootEncKey})
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := tenant.project.OpenBucket(ctx, bucketName, tenant.access)
 	if err != nil {
 		return convertError(err, bucketName, "")
 	}
@@ -122,7 +161,12 @@ func (layer *gatewayLayer) DeleteObject(ctx context.Context, bucketName, objectP
 func (layer *gatewayLayer) GetBucketInfo(ctx context.Context, bucketName string) (bucketInfo minio.BucketInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	bucket, _, err := layer.gateway.project.GetBucketInfo(ctx, bucketName)
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return minio.BucketInfo{}, err
+	}
+
+	bucket, _, err := tenant.project.GetBucketInfo(ctx, bucketName)
 
 	if err != nil {
 		return minio.BucketInfo{}, convertError(err, bucketName, "")
@@ -134,7 +178,12 @@ func (layer *gatewayLayer) GetBucketInfo(ctx context.Context, bucketName string)
 func (layer *gatewayLayer) GetObject(ctx context.Context, bucketName, objectPath string, startOffset int64, length int64, writer io.Writer, etag string) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	bucket, err := layer.gateway.project.OpenBucket(ctx, bucketName, &uplink.EncryptionAccess{Key: *layer.gateway.rootEncKey})
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := tenant.project.OpenBucket(ctx, bucketName, tenant.access)
 	if err != nil {
 		return convertError(err, bucketName, "")
 	}
@@ -146,6 +195,11 @@ func (layer *gatewayLayer) GetObject(ctx context.Context, bucketName, objectPath
 	}
 	defer func() { err = errs.Combine(err, object.Close()) }()
 
+	// the object may have been replaced since minio checked its preconditions
+	if etag != "" && etag != objectETag(object.Meta.MD5, object.Meta.Metadata) {
+		return minio.InvalidETag{}
+	}
+
 	if startOffset < 0 || length < -1 || startOffset+length > object.Meta.Size {
 		return minio.InvalidRange{
 			OffsetBegin:  startOffset,
@@ -168,7 +222,12 @@ func (layer *gatewayLayer) GetObject(ctx context.Context, bucketName, objectPath
 func (layer *gatewayLayer) GetObjectInfo(ctx context.Context, bucketName, objectPath string) (objInfo minio.ObjectInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	bucket, err := layer.gateway.project.OpenBucket(ctx, bucketName, &uplink.EncryptionAccess{Key: *layer.gateway.rootEncKey})
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
+
+	bucket, err := tenant.project.OpenBucket(ctx, bucketName, tenant.access)
 	if err != nil {
 		return minio.ObjectInfo{}, convertError(err, bucketName, "")
 	}
@@ -185,19 +244,24 @@ func (layer *gatewayLayer) GetObjectInfo(ctx context.Context, bucketName, object
 		Bucket:      object.Meta.Bucket,
 		ModTime:     object.Meta.Modified,
 		Size:        object.Meta.Size,
-		ETag:        hex.EncodeToString(object.Meta.Checksum),
+		ETag:        objectETag(object.Meta.MD5, object.Meta.Metadata),
 		ContentType: object.Meta.ContentType,
-		UserDefined: object.Meta.Metadata,
+		UserDefined: userDefined(object.Meta.Metadata),
 	}, err
 }
 
 func (layer *gatewayLayer) ListBuckets(ctx context.Context) (bucketItems []minio.BucketInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	startAfter := ""
 
 	for {
-		list, err := layer.gateway.project.ListBuckets(ctx, &storj.BucketListOptions{Direction: storj.After, Cursor: startAfter})
+		list, err := tenant.project.ListBuckets(ctx, &storj.BucketListOptions{Direction: storj.After, Cursor: startAfter})
 		if err != nil {
 			return nil, err
 		}
@@ -219,11 +283,16 @@ func (layer *gatewayLayer) ListBuckets(ctx context.Context) (bucketItems []minio
 func (layer *gatewayLayer) ListObjects(ctx context.Context, bucketName, prefix, marker, delimiter string, maxKeys int) (result minio.ListObjectsInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return minio.ListObjectsInfo{}, err
+	}
+
 	if delimiter != "" && delimiter != "/" {
 		return minio.ListObjectsInfo{}, minio.UnsupportedDelimiter{Delimiter: delimiter}
 	}
 
-	bucket, err := layer.gateway.project.OpenBucket(ctx, bucketName, &uplink.EncryptionAccess{Key: *layer.gateway.rootEncKey})
+	bucket, err := tenant.project.OpenBucket(ctx, bucketName, tenant.access)
 	if err != nil {
 		return minio.ListObjectsInfo{}, convertError(err, bucketName, "")
 	}
@@ -261,9 +330,9 @@ func (layer *gatewayLayer) ListObjects(ctx context.Context, bucketName, prefix,
 				Bucket:      item.Bucket.Name,
 				ModTime:     item.Modified,
 				Size:        item.Size,
-				ETag:        hex.EncodeToString(item.Checksum),
+				ETag:        objectETag(item.MD5, item.Metadata),
 				ContentType: item.ContentType,
-				UserDefined: item.Metadata,
+				UserDefined: userDefined(item.Metadata),
 			})
 		}
 		startAfter = list.Items[len(list.Items)-1].Path
@@ -285,11 +354,16 @@ func (layer *gatewayLayer) ListObjects(ctx context.Context, bucketName, prefix,
 func (layer *gatewayLayer) ListObjectsV2(ctx context.Context, bucketName, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result minio.ListObjectsV2Info, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return minio.ListObjectsV2Info{ContinuationToken: continuationToken}, err
+	}
+
 	if delimiter != "" && delimiter != "/" {
 		return minio.ListObjectsV2Info{ContinuationToken: continuationToken}, minio.UnsupportedDelimiter{Delimiter: delimiter}
 	}
 
-	bucket, err := layer.gateway.project.OpenBucket(ctx, bucketName, &uplink.EncryptionAccess{Key: *layer.gateway.rootEncKey})
+	bucket, err := tenant.project.OpenBucket(ctx, bucketName, tenant.access)
 	if err != nil {
 		return minio.ListObjectsV2Info{}, convertError(err, bucketName, "")
 	}
@@ -335,9 +409,9 @@ func (layer *gatewayLayer) ListObjectsV2(ctx context.Context, bucketName, prefix
 				Bucket:      item.Bucket.Name,
 				ModTime:     item.Modified,
 				Size:        item.Size,
-				ETag:        hex.EncodeToString(item.Checksum),
+				ETag:        objectETag(item.MD5, item.Metadata),
 				ContentType: item.ContentType,
-				UserDefined: item.Metadata,
+				UserDefined: userDefined(item.Metadata),
 			})
 		}
 
@@ -359,6 +433,11 @@ func (layer *gatewayLayer) ListObjectsV2(ctx context.Context, bucketName, prefix
 
 func (layer *gatewayLayer) MakeBucketWithLocation(ctx context.Context, bucketName string, location string) (err error) {
 	defer mon.Task()(&ctx)(&err)
+
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return err
+	}
 	// TODO: This current strategy of calling bs.Get
 	// to check if a bucket exists, then calling bs.Put
 	// if not, can create a race condition if two people
@@ -366,7 +445,7 @@ func (layer *gatewayLayer) MakeBucketWithLocation(ctx context.Context, bucketNam
 	// therefore try to Put a bucket at the same time.
 	// The reason for the Get call to check if the
 	// bucket already exists is to match S3 CLI behavior.
-	_, _, err = layer.gateway.project.GetBucketInfo(ctx, bucketName)
+	_, _, err = tenant.project.GetBucketInfo(ctx, bucketName)
 	if err == nil {
 		return minio.BucketAlreadyExists{Bucket: bucketName}
 	}
@@ -382,7 +461,7 @@ func (layer *gatewayLayer) MakeBucketWithLocation(ctx context.Context, bucketNam
 	cfg.Volatile.RedundancyScheme = layer.gateway.redundancy
 	cfg.Volatile.SegmentsSize = layer.gateway.segmentSize
 
-	_, err = layer.gateway.project.CreateBucket(ctx, bucketName, &cfg)
+	_, err = tenant.project.CreateBucket(ctx, bucketName, &cfg)
 
 	return err
 }
@@ -390,7 +469,12 @@ func (layer *gatewayLayer) MakeBucketWithLocation(ctx context.Context, bucketNam
 func (layer *gatewayLayer) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo minio.ObjectInfo) (objInfo minio.ObjectInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	bucket, err := layer.gateway.project.OpenBucket(ctx, srcBucket, &uplink.EncryptionAccess{Key: *layer.gateway.rootEncKey})
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
+
+	bucket, err := tenant.project.OpenBucket(ctx, srcBucket, tenant.access)
 	if err != nil {
 		return minio.ObjectInfo{}, convertError(err, srcBucket, "")
 	}
@@ -402,6 +486,38 @@ func (layer *gatewayLayer) CopyObject(ctx context.Context, srcBucket, srcObject,
 	}
 	defer func() { err = errs.Combine(err, object.Close()) }()
 
+	// minio passes the metadata of the copy, which is either the metadata of
+	// the source object or the metadata of the request
+	contentType, metadata := object.Meta.ContentType, map[string]string{}
+	for key, value := range srcInfo.UserDefined {
+		metadata[key] = value
+	}
+	if value, ok := metadata["content-type"]; ok {
+		contentType = value
+		delete(metadata, "content-type")
+	}
+	if tags, ok := object.Meta.Metadata[objectTaggingKey]; ok {
+		metadata[objectTaggingKey] = tags
+	}
+
+	if srcBucket == destBucket && srcObject == destObject {
+		// copying an object to itself only replaces its metadata
+		err = object.UpdateMetadata(ctx, contentType, metadata)
+		if err != nil {
+			return minio.ObjectInfo{}, convertError(err, srcBucket, srcObject)
+		}
+
+		return minio.ObjectInfo{
+			Name:        object.Meta.Path,
+			Bucket:      object.Meta.Bucket,
+			ModTime:     object.Meta.Modified,
+			Size:        object.Meta.Size,
+			ETag:        objectETag(object.Meta.MD5, object.Meta.Metadata),
+			ContentType: object.Meta.ContentType,
+			UserDefined: userDefined(object.Meta.Metadata),
+		}, nil
+	}
+
 	reader, err := object.DownloadRange(ctx, 0, -1)
 	if err != nil {
 		return minio.ObjectInfo{}, convertError(err, srcBucket, srcObject)
@@ -409,20 +525,20 @@ func (layer *gatewayLayer) CopyObject(ctx context.Context, srcBucket, srcObject,
 	defer func() { err = errs.Combine(err, reader.Close()) }()
 
 	opts := uplink.UploadOptions{
-		ContentType: object.Meta.ContentType,
-		Metadata:    object.Meta.Metadata,
+		ContentType: contentType,
+		Metadata:    metadata,
 		Expires:     object.Meta.Expires,
 	}
 	opts.Volatile.EncryptionParameters = object.Meta.Volatile.EncryptionParameters
 	opts.Volatile.RedundancyScheme = object.Meta.Volatile.RedundancyScheme
 
-	return layer.putObject(ctx, destBucket, destObject, reader, &opts)
+	return layer.putObject(ctx, tenant, destBucket, destObject, reader, &opts)
 }
 
-func (layer *gatewayLayer) putObject(ctx context.Context, bucketName, objectPath string, reader io.Reader, opts *uplink.UploadOptions) (objInfo minio.ObjectInfo, err error) {
+func (layer *gatewayLayer) putObject(ctx context.Context, tenant *tenant, bucketName, objectPath string, reader io.Reader, opts *uplink.UploadOptions) (objInfo minio.ObjectInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	bucket, err := layer.gateway.project.OpenBucket(ctx, bucketName, &uplink.EncryptionAccess{Key: *layer.gateway.rootEncKey})
+	bucket, err := tenant.project.OpenBucket(ctx, bucketName, tenant.access)
 	if err != nil {
 		return minio.ObjectInfo{}, convertError(err, bucketName, "")
 	}
@@ -444,9 +560,9 @@ func (layer *gatewayLayer) putObject(ctx context.Context, bucketName, objectPath
 		Bucket:      object.Meta.Bucket,
 		ModTime:     object.Meta.Modified,
 		Size:        object.Meta.Size,
-		ETag:        hex.EncodeToString(object.Meta.Checksum),
+		ETag:        objectETag(object.Meta.MD5, object.Meta.Metadata),
 		ContentType: object.Meta.ContentType,
-		UserDefined: object.Meta.Metadata,
+		UserDefined: userDefined(object.Meta.Metadata),
 	}, nil
 }
 
@@ -466,6 +582,11 @@ func upload(ctx context.Context, streams streams.Store, mutableObject storj.Muta
 func (layer *gatewayLayer) PutObject(ctx context.Context, bucketName, objectPath string, data *hash.Reader, metadata map[string]string) (objInfo minio.ObjectInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
+
 	contentType := metadata["content-type"]
 	delete(metadata, "content-type")
 
@@ -474,7 +595,7 @@ func (layer *gatewayLayer) PutObject(ctx context.Context, bucketName, objectPath
 		Metadata:    metadata,
 	}
 
-	return layer.putObject(ctx, bucketName, objectPath, data, &opts)
+	return layer.putObject(ctx, tenant, bucketName, objectPath, data, &opts)
 }
 
 func (layer *gatewayLayer) Shutdown(ctx context.Context) (err error) {
@@ -486,6 +607,43 @@ func (layer *gatewayLayer) StorageInfo(context.Context) minio.StorageInfo {
 	return minio.StorageInfo{}
 }
 
+const (
+	// multipartETagKey is the metadata key the ETag of an object uploaded
+	// with a multipart upload is stored in. S3 clients expect the ETag of
+	// such objects to be derived from the MD5 checksums of the parts instead
+	// of the content.
+	multipartETagKey = "s3:multipart-etag"
+
+	// objectTaggingKey is the metadata key the tags of an object are stored
+	// in, encoded as URL query parameters
+	objectTaggingKey = "s3:tagging"
+)
+
+// objectETag returns the S3 ETag of an object with the MD5 checksum and metadata
+func objectETag(md5 []byte, metadata map[string]string) string {
+	if etag, ok := metadata[multipartETagKey]; ok {
+		return etag
+	}
+	return hex.EncodeToString(md5)
+}
+
+// userDefined returns metadata without the keys reserved by the gateway
+func userDefined(metadata map[string]string) map[string]string {
+	_, hasETag := metadata[multipartETagKey]
+	_, hasTags := metadata[objectTaggingKey]
+	if !hasETag && !hasTags {
+		return metadata
+	}
+
+	result := make(map[string]string, len(metadata))
+	for key, value := range metadata {
+		if key != multipartETagKey && key != objectTaggingKey {
+			result[key] = value
+		}
+	}
+	return result
+}
+
 func convertError(err error, bucket, object string) error {
 	if storj.ErrNoBucket.Has(err) {
 		return minio.BucketNameInvalid{Bucket: bucket}
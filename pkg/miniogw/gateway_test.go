@@ -6,6 +6,8 @@ package miniogw
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
 	"strings"
@@ -201,7 +203,7 @@ func TestPutObject(t *testing.T) {
 			assert.False(t, info.IsDir)
 			assert.True(t, time.Since(info.ModTime) < 1*time.Minute)
 			assert.Equal(t, data.Size(), info.Size)
-			// assert.Equal(t, data.SHA256HexString(), info.ETag) TODO: when we start calculating checksums
+			assert.Equal(t, data.MD5HexString(), info.ETag)
 			assert.Equal(t, serMetaInfo.ContentType, info.ContentType)
 			assert.Equal(t, serMetaInfo.UserDefined, info.UserDefined)
 		}
@@ -214,7 +216,7 @@ func TestPutObject(t *testing.T) {
 			assert.False(t, obj.IsPrefix)
 			assert.Equal(t, info.ModTime, obj.Modified)
 			assert.Equal(t, info.Size, obj.Size)
-			assert.Equal(t, info.ETag, hex.EncodeToString(obj.Checksum))
+			assert.Equal(t, info.ETag, hex.EncodeToString(obj.MD5))
 			assert.Equal(t, info.ContentType, obj.ContentType)
 			assert.Equal(t, info.UserDefined, obj.Metadata)
 		}
@@ -259,7 +261,7 @@ func TestGetObjectInfo(t *testing.T) {
 			assert.False(t, info.IsDir)
 			assert.Equal(t, obj.Modified, info.ModTime)
 			assert.Equal(t, obj.Size, info.Size)
-			assert.Equal(t, hex.EncodeToString(obj.Checksum), info.ETag)
+			assert.Equal(t, hex.EncodeToString(obj.MD5), info.ETag)
 			assert.Equal(t, createInfo.ContentType, info.ContentType)
 			assert.Equal(t, createInfo.Metadata, info.UserDefined)
 		}
@@ -326,6 +328,17 @@ func TestGetObject(t *testing.T) {
 				assert.Equal(t, tt.substr, buf.String(), errTag)
 			}
 		}
+
+		// Check that the object is only returned if it still has the expected ETag
+		checksum := md5.Sum([]byte("abcdef"))
+		var buf bytes.Buffer
+		err = layer.GetObject(ctx, TestBucket, TestFile, 0, 6, &buf, hex.EncodeToString(checksum[:]))
+		if assert.NoError(t, err) {
+			assert.Equal(t, "abcdef", buf.String())
+		}
+
+		err = layer.GetObject(ctx, TestBucket, TestFile, 0, 6, &buf, "replaced")
+		assert.Equal(t, minio.InvalidETag{}, err)
 	})
 }
 
@@ -379,7 +392,7 @@ func TestCopyObject(t *testing.T) {
 			assert.False(t, info.IsDir)
 			assert.True(t, info.ModTime.Sub(obj.Modified) < 1*time.Minute)
 			assert.Equal(t, obj.Size, info.Size)
-			assert.Equal(t, hex.EncodeToString(obj.Checksum), info.ETag)
+			assert.Equal(t, hex.EncodeToString(obj.MD5), info.ETag)
 			assert.Equal(t, createInfo.ContentType, info.ContentType)
 			assert.Equal(t, createInfo.Metadata, info.UserDefined)
 		}
@@ -392,10 +405,35 @@ func TestCopyObject(t *testing.T) {
 			assert.False(t, obj.IsPrefix)
 			assert.Equal(t, info.ModTime, obj.Modified)
 			assert.Equal(t, info.Size, obj.Size)
-			assert.Equal(t, info.ETag, hex.EncodeToString(obj.Checksum))
+			assert.Equal(t, info.ETag, hex.EncodeToString(obj.MD5))
 			assert.Equal(t, info.ContentType, obj.ContentType)
 			assert.Equal(t, info.UserDefined, obj.Metadata)
 		}
+
+		// Replace the metadata of the object by copying it to itself using the Minio API
+		srcInfo.UserDefined = map[string]string{"content-type": "text/html", "key3": "value3"}
+		info, err = layer.CopyObject(ctx, TestBucket, TestFile, TestBucket, TestFile, srcInfo)
+		if assert.NoError(t, err) {
+			assert.Equal(t, TestFile, info.Name)
+			assert.Equal(t, srcInfo.Size, info.Size)
+			assert.Equal(t, srcInfo.ETag, info.ETag)
+			assert.Equal(t, "text/html", info.ContentType)
+			assert.Equal(t, map[string]string{"key3": "value3"}, info.UserDefined)
+		}
+
+		// Check that only the metadata of the object was replaced using the Metainfo API
+		obj, err = metainfo.GetObject(ctx, TestBucket, TestFile)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "text/html", obj.ContentType)
+			assert.Equal(t, map[string]string{"key3": "value3"}, obj.Metadata)
+			assert.Equal(t, srcInfo.ETag, hex.EncodeToString(obj.MD5))
+		}
+
+		var buf bytes.Buffer
+		err = layer.GetObject(ctx, TestBucket, TestFile, 0, -1, &buf, "")
+		if assert.NoError(t, err) {
+			assert.Equal(t, "test", buf.String())
+		}
 	})
 }
 
@@ -435,6 +473,66 @@ func TestDeleteObject(t *testing.T) {
 	})
 }
 
+func TestCompleteMultipartUpload(t *testing.T) {
+	runTest(t, func(ctx context.Context, layer minio.ObjectLayer, metainfo storj.Metainfo, streams streams.Store) {
+		// Create the bucket using the Metainfo API
+		_, err := metainfo.CreateBucket(ctx, TestBucket, nil)
+		assert.NoError(t, err)
+
+		uploadID, err := layer.NewMultipartUpload(ctx, TestBucket, TestFile, map[string]string{"key1": "value1"})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		// Upload the parts using the Minio API
+		var completed []minio.CompletePart
+		var checksums []byte
+		for i, content := range []string{"first part", "second part"} {
+			checksum := md5.Sum([]byte(content))
+			checksums = append(checksums, checksum[:]...)
+
+			data, err := hash.NewReader(bytes.NewReader([]byte(content)), int64(len(content)), hex.EncodeToString(checksum[:]), "")
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			part, err := layer.PutObjectPart(ctx, TestBucket, TestFile, uploadID, i+1, data)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, hex.EncodeToString(checksum[:]), part.ETag)
+
+			completed = append(completed, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+		}
+
+		// Check that the ETag of the object is derived from the ETags of the parts
+		checksum := md5.Sum(checksums)
+		etag := hex.EncodeToString(checksum[:]) + "-2"
+
+		info, err := layer.CompleteMultipartUpload(ctx, TestBucket, TestFile, uploadID, completed)
+		if assert.NoError(t, err) {
+			assert.Equal(t, etag, info.ETag)
+			assert.Equal(t, map[string]string{"key1": "value1"}, info.UserDefined)
+		}
+
+		info, err = layer.GetObjectInfo(ctx, TestBucket, TestFile)
+		if assert.NoError(t, err) {
+			assert.Equal(t, etag, info.ETag)
+			assert.Equal(t, int64(len("first partsecond part")), info.Size)
+			assert.Equal(t, map[string]string{"key1": "value1"}, info.UserDefined)
+		}
+
+		// Check that the object content can still be verified with its checksums
+		obj, err := metainfo.GetObject(ctx, TestBucket, TestFile)
+		if assert.NoError(t, err) {
+			checksum := md5.Sum([]byte("first partsecond part"))
+			assert.Equal(t, checksum[:], obj.MD5)
+			digest := sha256.Sum256([]byte("first partsecond part"))
+			assert.Equal(t, digest[:], obj.Checksum)
+		}
+	})
+}
+
 func TestListObjects(t *testing.T) {
 	testListObjects(t, func(ctx context.Context, layer minio.ObjectLayer, bucket, prefix, marker, delimiter string, maxKeys int) ([]string, []minio.ObjectInfo, bool, error) {
 		list, err := layer.ListObjects(ctx, TestBucket, prefix, marker, delimiter, maxKeys)
@@ -627,7 +725,7 @@ func testListObjects(t *testing.T, listObjects func(context.Context, minio.Objec
 					assert.False(t, objectInfo.IsDir, errTag)
 					assert.Equal(t, obj.Modified, objectInfo.ModTime, errTag)
 					assert.Equal(t, obj.Size, objectInfo.Size, errTag)
-					assert.Equal(t, hex.EncodeToString(obj.Checksum), objectInfo.ETag, errTag)
+					assert.Equal(t, hex.EncodeToString(obj.MD5), objectInfo.ETag, errTag)
 					assert.Equal(t, obj.ContentType, objectInfo.ContentType, errTag)
 					assert.Equal(t, obj.Metadata, objectInfo.UserDefined, errTag)
 				}
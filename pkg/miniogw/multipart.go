@@ -5,6 +5,8 @@ package miniogw
 
 import (
 	"context"
+	"crypto/md5"
+	"encoding/hex"
 	"io"
 	"sort"
 	"strconv"
@@ -21,13 +23,22 @@ import (
 func (layer *gatewayLayer) NewMultipartUpload(ctx context.Context, bucket, object string, metadata map[string]string) (uploadID string, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return "", err
+	}
+
 	// Check that the bucket exists
-	_, _, err = layer.gateway.project.GetBucketInfo(ctx, bucket)
+	_, _, err = tenant.project.GetBucketInfo(ctx, bucket)
 	if err != nil {
 		return "", convertError(err, bucket, "")
 	}
 
-	uploads := layer.gateway.multipart
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	uploads := tenant.multipart
 
 	upload, err := uploads.Create(bucket, object, metadata)
 	if err != nil {
@@ -42,7 +53,7 @@ func (layer *gatewayLayer) NewMultipartUpload(ctx context.Context, bucket, objec
 			ContentType: contentType,
 			Metadata:    metadata,
 		}
-		objInfo, err := layer.putObject(ctx, bucket, object, upload.Stream, &opts)
+		objInfo, err := layer.putObject(ctx, tenant, bucket, object, upload.Stream, &opts)
 
 		uploads.RemoveByID(upload.ID)
 
@@ -59,7 +70,12 @@ func (layer *gatewayLayer) NewMultipartUpload(ctx context.Context, bucket, objec
 func (layer *gatewayLayer) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *hash.Reader) (info minio.PartInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	uploads := layer.gateway.multipart
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return minio.PartInfo{}, err
+	}
+
+	uploads := tenant.multipart
 
 	upload, err := uploads.Get(bucket, object, uploadID)
 	if err != nil {
@@ -79,7 +95,7 @@ func (layer *gatewayLayer) PutObjectPart(ctx context.Context, bucket, object, up
 	partInfo := minio.PartInfo{
 		PartNumber:   part.Number,
 		LastModified: time.Now(),
-		ETag:         data.SHA256HexString(),
+		ETag:         hex.EncodeToString(data.MD5Current()),
 		Size:         atomic.LoadInt64(&part.Size),
 	}
 
@@ -91,7 +107,12 @@ func (layer *gatewayLayer) PutObjectPart(ctx context.Context, bucket, object, up
 func (layer *gatewayLayer) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	uploads := layer.gateway.multipart
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return err
+	}
+
+	uploads := tenant.multipart
 
 	upload, err := uploads.Remove(bucket, object, uploadID)
 	if err != nil {
@@ -110,12 +131,26 @@ func (layer *gatewayLayer) AbortMultipartUpload(ctx context.Context, bucket, obj
 func (layer *gatewayLayer) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []minio.CompletePart) (objInfo minio.ObjectInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	uploads := layer.gateway.multipart
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return minio.ObjectInfo{}, err
+	}
+
+	uploads := tenant.multipart
 	upload, err := uploads.Remove(bucket, object, uploadID)
 	if err != nil {
 		return minio.ObjectInfo{}, err
 	}
 
+	etag, err := multipartETag(upload.getCompletedParts())
+	if err != nil {
+		upload.Stream.Abort(err)
+		<-upload.Done
+		return minio.ObjectInfo{}, err
+	}
+	// the metadata is stored when the stream is committed
+	upload.Metadata[multipartETagKey] = etag
+
 	// notify stream that there aren't more parts coming
 	upload.Stream.Close()
 	// wait for completion
@@ -127,7 +162,12 @@ func (layer *gatewayLayer) CompleteMultipartUpload(ctx context.Context, bucket,
 func (layer *gatewayLayer) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int) (result minio.ListPartsInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	uploads := layer.gateway.multipart
+	tenant, err := layer.gateway.tenants.tenant(ctx)
+	if err != nil {
+		return minio.ListPartsInfo{}, err
+	}
+
+	uploads := tenant.multipart
 	upload, err := uploads.Get(bucket, object, uploadID)
 	if err != nil {
 		return minio.ListPartsInfo{}, err
@@ -165,6 +205,26 @@ func (layer *gatewayLayer) ListObjectParts(ctx context.Context, bucket, object,
 	return list, nil
 }
 
+// multipartETag returns the ETag S3 assigns to an object uploaded with parts:
+// the MD5 of the concatenated MD5 checksums of the parts followed by the
+// number of parts.
+func multipartETag(parts []minio.PartInfo) (string, error) {
+	sort.Slice(parts, func(i, k int) bool {
+		return parts[i].PartNumber < parts[k].PartNumber
+	})
+
+	hash := md5.New()
+	for _, part := range parts {
+		checksum, err := hex.DecodeString(part.ETag)
+		if err != nil {
+			return "", Error.New("invalid ETag of part %d: %v", part.PartNumber, err)
+		}
+		_, _ = hash.Write(checksum)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)) + "-" + strconv.Itoa(len(parts)), nil
+}
+
 // TODO: implement
 // func (layer *gatewayLayer) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result minio.ListMultipartsInfo, err error) {
 // func (layer *gatewayLayer) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int, startOffset int64, length int64, srcInfo minio.ObjectInfo) (info minio.PartInfo, err error) {
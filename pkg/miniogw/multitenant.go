@@ -0,0 +1,513 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/pkg/s3signer"
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/storj"
+)
+
+// defaultIdleTimeout is how long the project of an access key is kept open
+// without requests
+const defaultIdleTimeout = 10 * time.Minute
+
+// OpenProjectFunc opens the project of an access grant and returns it along
+// with the root encryption key of the access grant.
+type OpenProjectFunc func(ctx context.Context, access Access) (*uplink.Project, *storj.Key, error)
+
+// Tenants serves the requests of a multi-tenant gateway with the access grant
+// stored for their access key.
+//
+// Requests are authenticated by the handler of Tenants with the secret key of
+// the access grant and forwarded to minio signed with internal credentials,
+// which are only known to the gateway. The tenant of a forwarded request is
+// carried in its context to the connection it is sent over, which the object
+// layer finds by the remote address minio reports for the request. Minio
+// creates the context of the object layer without the request context, so
+// TestMinioRemoteHost runs minio to catch changes of that address.
+//
+// Projects are opened on the first request of an access key and are closed
+// when they have not been used for the idle timeout, or when the access grant
+// of the access key changes and no request uses them anymore.
+type Tenants struct {
+	log         *zap.Logger
+	credentials *Credentials
+	open        OpenProjectFunc
+	internal    auth.Credentials
+	idleTimeout time.Duration
+
+	mu        sync.Mutex
+	sessions  map[string]*tenant
+	projects  map[string]*cachedTenant
+	retired   map[*cachedTenant]struct{}
+	lastSweep time.Time
+}
+
+// cachedTenant is the tenant of an access key while or after it is opened.
+// refs, lastUsed and retired are guarded by the mutex of Tenants.
+type cachedTenant struct {
+	access Access
+	ready  chan struct{}
+	tenant *tenant
+	err    error
+
+	refs     int
+	lastUsed time.Time
+	retired  bool
+}
+
+// failed returns whether opening the project of entry has failed
+func (entry *cachedTenant) failed() bool {
+	select {
+	case <-entry.ready:
+		return entry.err != nil
+	default:
+		return false
+	}
+}
+
+// NewTenants creates the tenants of a multi-tenant gateway. Minio must be
+// started with the internal credentials.
+func NewTenants(log *zap.Logger, credentials *Credentials, open OpenProjectFunc, internal auth.Credentials) *Tenants {
+	return &Tenants{
+		log:         log,
+		credentials: credentials,
+		open:        open,
+		internal:    internal,
+		idleTimeout: defaultIdleTimeout,
+		sessions:    map[string]*tenant{},
+		projects:    map[string]*cachedTenant{},
+		retired:     map[*cachedTenant]struct{}{},
+	}
+}
+
+// Handler returns the S3 endpoint of the multi-tenant gateway, which forwards
+// authenticated requests to minio listening at address.
+func (tenants *Tenants) Handler(address string) http.Handler {
+	return &tenantHandler{
+		tenants: tenants,
+		address: address,
+		proxy: &httputil.ReverseProxy{
+			Director: func(*http.Request) {},
+			// every forwarded request gets its own connection, which minio
+			// reports as the remote address of the request
+			Transport: &http.Transport{
+				DialContext:       tenants.dialSession,
+				DisableKeepAlives: true,
+			},
+		},
+	}
+}
+
+// Close closes the projects of all tenants
+func (tenants *Tenants) Close() (err error) {
+	tenants.mu.Lock()
+	var cached []*cachedTenant
+	for entry := range tenants.retired {
+		cached = append(cached, entry)
+	}
+	for _, entry := range tenants.projects {
+		cached = append(cached, entry)
+	}
+	tenants.projects = map[string]*cachedTenant{}
+	tenants.retired = map[*cachedTenant]struct{}{}
+	tenants.mu.Unlock()
+
+	var group errs.Group
+	for _, entry := range cached {
+		group.Add(entry.close())
+	}
+	return group.Err()
+}
+
+// sessionKey is the context key of the tenant a request is forwarded for
+type sessionKey struct{}
+
+// withSession returns ctx carrying the tenant to the connection the request
+// is forwarded over
+func withSession(ctx context.Context, tenant *tenant) context.Context {
+	return context.WithValue(ctx, sessionKey{}, tenant)
+}
+
+// dialSession dials minio for a forwarded request and makes the tenant of the
+// request available to the object layer while the connection is open
+func (tenants *Tenants) dialSession(ctx context.Context, network, address string) (net.Conn, error) {
+	tenant, ok := ctx.Value(sessionKey{}).(*tenant)
+	if !ok {
+		return nil, Error.New("request has no session")
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	session := conn.LocalAddr().String()
+	tenants.mu.Lock()
+	tenants.sessions[session] = tenant
+	tenants.mu.Unlock()
+
+	return &sessionConn{Conn: conn, end: func() {
+		tenants.mu.Lock()
+		delete(tenants.sessions, session)
+		tenants.mu.Unlock()
+	}}, nil
+}
+
+// sessionConn ends its session when it is closed
+type sessionConn struct {
+	net.Conn
+	once sync.Once
+	end  func()
+}
+
+// Close implements net.Conn. The session ends before the local address can be
+// reused by another connection.
+func (conn *sessionConn) Close() error {
+	conn.once.Do(conn.end)
+	return conn.Conn.Close()
+}
+
+// tenant implements tenantResolver with the session of the connection the
+// request was forwarded over
+func (tenants *Tenants) tenant(ctx context.Context) (*tenant, error) {
+	info := logger.GetReqInfo(ctx)
+	if info == nil || info.RemoteHost == "" {
+		return nil, Error.New("request was not authenticated by the gateway")
+	}
+
+	tenants.mu.Lock()
+	tenant, ok := tenants.sessions[info.RemoteHost]
+	tenants.mu.Unlock()
+	if !ok {
+		return nil, Error.New("request was not authenticated by the gateway")
+	}
+	return tenant, nil
+}
+
+// openTenant returns the tenant of accessKey, opening its project if it is
+// not already open or when its access grant has changed. The project is kept
+// open until release is called.
+func (tenants *Tenants) openTenant(ctx context.Context, accessKey string, access Access) (_ *tenant, release func(), err error) {
+	now := time.Now()
+
+	tenants.mu.Lock()
+	idle := tenants.sweepIdle(now)
+
+	entry, ok := tenants.projects[accessKey]
+	if ok && (entry.access != access || entry.failed()) {
+		delete(tenants.projects, accessKey)
+		if entry.refs == 0 {
+			idle = append(idle, entry)
+		} else {
+			// requests in flight still use the project of the old access grant
+			entry.retired = true
+			tenants.retired[entry] = struct{}{}
+		}
+		ok = false
+	}
+	if !ok {
+		entry = &cachedTenant{access: access, ready: make(chan struct{})}
+		tenants.projects[accessKey] = entry
+
+		go tenants.openCached(entry)
+	}
+	entry.refs++
+	entry.lastUsed = now
+	tenants.mu.Unlock()
+
+	tenants.closeAsync(idle)
+
+	release = func() { tenants.release(entry) }
+
+	select {
+	case <-entry.ready:
+		if entry.err != nil {
+			release()
+			return nil, nil, entry.err
+		}
+		return entry.tenant, release, nil
+	case <-ctx.Done():
+		release()
+		return nil, nil, ctx.Err()
+	}
+}
+
+// release ends a use of entry, closing it when it was retired and this was
+// the last use
+func (tenants *Tenants) release(entry *cachedTenant) {
+	tenants.mu.Lock()
+	entry.refs--
+	entry.lastUsed = time.Now()
+	closing := entry.retired && entry.refs == 0
+	if closing {
+		delete(tenants.retired, entry)
+	}
+	tenants.mu.Unlock()
+
+	if closing {
+		tenants.closeAsync([]*cachedTenant{entry})
+	}
+}
+
+// sweepIdle removes the projects which have not been used for the idle
+// timeout. It checks at most once per idle timeout and must be called with
+// the mutex held.
+func (tenants *Tenants) sweepIdle(now time.Time) (idle []*cachedTenant) {
+	if now.Sub(tenants.lastSweep) < tenants.idleTimeout {
+		return nil
+	}
+	tenants.lastSweep = now
+
+	for accessKey, entry := range tenants.projects {
+		if entry.refs == 0 && now.Sub(entry.lastUsed) >= tenants.idleTimeout {
+			delete(tenants.projects, accessKey)
+			idle = append(idle, entry)
+		}
+	}
+	return idle
+}
+
+// closeAsync closes the projects of entries without blocking the request
+func (tenants *Tenants) closeAsync(entries []*cachedTenant) {
+	for _, entry := range entries {
+		go func(entry *cachedTenant) {
+			if err := entry.close(); err != nil {
+				tenants.log.Error("failed to close project", zap.Error(err))
+			}
+		}(entry)
+	}
+}
+
+// close closes the project of entry once it is opened
+func (entry *cachedTenant) close() error {
+	<-entry.ready
+	if entry.tenant == nil {
+		return nil
+	}
+	return entry.tenant.project.Close()
+}
+
+// openCached opens the project of entry independent of the request that
+// needed it first
+func (tenants *Tenants) openCached(entry *cachedTenant) {
+	defer close(entry.ready)
+
+	project, key, err := tenants.open(context.Background(), entry.access)
+	if err != nil {
+		entry.err = Error.Wrap(err)
+		return
+	}
+
+	entry.tenant = &tenant{
+		project:   project,
+		access:    &uplink.EncryptionAccess{Key: *key},
+		multipart: NewMultipartUploads(),
+	}
+}
+
+// tenantHandler authenticates requests with the credentials of their tenant
+// and forwards them to minio
+type tenantHandler struct {
+	tenants *Tenants
+	address string
+	proxy   *httputil.ReverseProxy
+}
+
+// ServeHTTP implements http.Handler
+func (handler *tenantHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tenants := handler.tenants
+
+	sig, err := parseSignatureV4(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	access, ok, err := tenants.credentials.Lookup(sig.accessKey)
+	if err != nil {
+		tenants.log.Error("failed to look up credentials", zap.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	if !ok {
+		writeError(w, r, errUnknownAccessKey.New("%q", sig.accessKey))
+		return
+	}
+
+	if err := sig.verify(r, access.SecretKey, time.Now()); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	tenant, release, err := tenants.openTenant(r.Context(), sig.accessKey, access)
+	if err != nil {
+		tenants.log.Error("failed to open project", zap.String("access key", sig.accessKey), zap.Error(err))
+		writeError(w, r, err)
+		return
+	}
+	defer release()
+
+	if bucket, object, ok := isObjectTagging(r); ok {
+		handler.serveTagging(w, r, sig, access, tenant, bucket, object)
+		return
+	}
+
+	out, err := handler.forward(r, sig, access)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	out = out.WithContext(withSession(out.Context(), tenant))
+	handler.proxy.ServeHTTP(w, s3signer.SignV4(*out, tenants.internal.AccessKey, tenants.internal.SecretKey, "", "us-east-1"))
+}
+
+// forward returns the request to send to minio for r, without the
+// authentication of the tenant
+func (handler *tenantHandler) forward(r *http.Request, sig *signatureV4, access Access) (*http.Request, error) {
+	out := r.WithContext(r.Context())
+	out.RequestURI = ""
+	out.Host = handler.address
+
+	query := r.URL.Query()
+	if sig.presigned {
+		for _, name := range []string{"X-Amz-Algorithm", "X-Amz-Credential", "X-Amz-Date", "X-Amz-Expires", "X-Amz-SignedHeaders", "X-Amz-Signature", "X-Amz-Content-Sha256"} {
+			query.Del(name)
+		}
+	}
+	out.URL = &url.URL{
+		Scheme:   "http",
+		Host:     handler.address,
+		Path:     r.URL.Path,
+		RawPath:  r.URL.RawPath,
+		RawQuery: query.Encode(),
+	}
+
+	// headers which are changed by the proxy must not be signed for minio
+	out.Header = http.Header{}
+	for name, values := range r.Header {
+		switch name {
+		case "Authorization", "Connection", "Expect", "Keep-Alive", "Proxy-Authenticate", "Proxy-Authorization",
+			"Proxy-Connection", "Te", "Trailer", "Transfer-Encoding", "Upgrade", "X-Forwarded-For",
+			"X-Amz-Decoded-Content-Length":
+			continue
+		}
+		out.Header[name] = append([]string(nil), values...)
+	}
+
+	switch hash := sig.payloadHash(r); hash {
+	case streamingPayload:
+		decodedLength, err := strconv.ParseInt(r.Header.Get("X-Amz-Decoded-Content-Length"), 10, 64)
+		if err != nil || decodedLength < 0 {
+			return nil, errMalformedSignature.New("invalid decoded content length")
+		}
+		out.ContentLength = decodedLength
+		out.Body = struct {
+			io.Reader
+			io.Closer
+		}{newChunkReader(r.Body, sig, access.SecretKey), r.Body}
+
+		var encodings []string
+		for _, encoding := range strings.Split(r.Header.Get("Content-Encoding"), ",") {
+			if encoding = strings.TrimSpace(encoding); encoding != "" && encoding != "aws-chunked" {
+				encodings = append(encodings, encoding)
+			}
+		}
+		out.Header.Del("Content-Encoding")
+		if len(encodings) > 0 {
+			out.Header.Set("Content-Encoding", strings.Join(encodings, ","))
+		}
+		out.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	default:
+		// minio verifies the payload against the hash signed by the tenant
+		out.Header.Set("X-Amz-Content-Sha256", hash)
+	}
+
+	return out, nil
+}
+
+// errUnknownAccessKey is returned when there is no access grant for an access key
+var errUnknownAccessKey = errs.Class("unknown access key")
+
+// errorCode returns the HTTP status and the S3 error code of err
+func errorCode(err error) (status int, code string) {
+	switch err.(type) {
+	case minio.BucketNotFound:
+		return http.StatusNotFound, "NoSuchBucket"
+	case minio.ObjectNotFound:
+		return http.StatusNotFound, "NoSuchKey"
+	case minio.BucketNameInvalid:
+		return http.StatusBadRequest, "InvalidBucketName"
+	case minio.ObjectNameInvalid:
+		return http.StatusBadRequest, "InvalidObjectName"
+	}
+
+	switch {
+	case errUnknownAccessKey.Has(err):
+		return http.StatusForbidden, "InvalidAccessKeyId"
+	case errSignatureMismatch.Has(err):
+		return http.StatusForbidden, "SignatureDoesNotMatch"
+	case errRequestExpired.Has(err):
+		return http.StatusForbidden, "RequestTimeTooSkewed"
+	case errMalformedSignature.Has(err):
+		return http.StatusBadRequest, "AuthorizationHeaderMalformed"
+	case errUnsupportedSignature.Has(err):
+		return http.StatusForbidden, "AccessDenied"
+	case errInvalidTag.Has(err):
+		return http.StatusBadRequest, "InvalidTag"
+	case errMalformedXML.Has(err):
+		return http.StatusBadRequest, "MalformedXML"
+	case errContentMismatch.Has(err):
+		return http.StatusBadRequest, "XAmzContentSHA256Mismatch"
+	case err == context.Canceled:
+		return http.StatusServiceUnavailable, "SlowDown"
+	default:
+		return http.StatusInternalServerError, "InternalError"
+	}
+}
+
+// writeError writes err as an S3 error response
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status, code := errorCode(err)
+	if status == http.StatusInternalServerError {
+		err = errs.New("internal error")
+	}
+
+	response := struct {
+		XMLName  xml.Name `xml:"Error"`
+		Code     string   `xml:"Code"`
+		Message  string   `xml:"Message"`
+		Resource string   `xml:"Resource"`
+	}{
+		Code:     code,
+		Message:  err.Error(),
+		Resource: r.URL.Path,
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, xml.Header)
+	_ = xml.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,347 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	minioclient "github.com/minio/minio-go"
+	"github.com/minio/minio-go/pkg/s3signer"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	libuplink "storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestMultiTenant(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 2,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+
+		creds, err := OpenCredentials(ctx.File("credentials.json"))
+		require.NoError(t, err)
+
+		for i, name := range []string{"alpha", "beta"} {
+			require.NoError(t, creds.Put(name, Access{
+				SecretKey:     name + "-secret",
+				SatelliteAddr: satellite.Addr(),
+				APIKey:        planet.Uplinks[i].APIKey[satellite.ID()],
+				EncryptionKey: name + "-encryption-key",
+			}))
+		}
+
+		openProject := func(ctx context.Context, access Access) (*libuplink.Project, *storj.Key, error) {
+			cfg := libuplink.Config{}
+			cfg.Volatile.TLS.SkipPeerCAWhitelist = true
+
+			uplink, err := libuplink.NewUplink(ctx, &cfg)
+			if err != nil {
+				return nil, nil, err
+			}
+			apiKey, err := libuplink.ParseAPIKey(access.APIKey)
+			if err != nil {
+				return nil, nil, err
+			}
+			key, err := storj.NewKey([]byte(access.EncryptionKey))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			var opts libuplink.ProjectOptions
+			opts.Volatile.EncryptionKey = key
+			project, err := uplink.OpenProject(ctx, access.SatelliteAddr, apiKey, &opts)
+			return project, key, err
+		}
+
+		internal := auth.Credentials{AccessKey: "internal-access-key", SecretKey: "internal-secret-key"}
+		tenants := NewTenants(zaptest.NewLogger(t), creds, openProject, internal)
+		defer ctx.Check(tenants.Close)
+
+		gateway := NewMultiTenantGateway(tenants,
+			storj.EncAESGCM,
+			storj.EncryptionParameters{CipherSuite: storj.EncAESGCM, BlockSize: 1 * memory.KiB.Int32()},
+			storj.RedundancyScheme{
+				Algorithm:      storj.ReedSolomon,
+				RequiredShares: 2,
+				RepairShares:   3,
+				OptimalShares:  4,
+				TotalShares:    4,
+				ShareSize:      1 * memory.KiB.Int32(),
+			},
+			8*memory.MiB,
+		)
+		layer, err := gateway.NewGatewayLayer(auth.Credentials{})
+		require.NoError(t, err)
+
+		// minioServer stands in for minio: it only accepts requests signed
+		// with the internal credentials and serves them with the object layer
+		minioServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sig, err := parseSignatureV4(r)
+			if err == nil && sig.accessKey != internal.AccessKey {
+				err = errUnknownAccessKey.New("%q", sig.accessKey)
+			}
+			if err == nil {
+				err = sig.verify(r, internal.SecretKey, time.Now())
+			}
+			if !assert.NoError(t, err) {
+				writeError(w, r, err)
+				return
+			}
+
+			ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{RemoteHost: r.RemoteAddr})
+			path := strings.SplitN(strings.Trim(r.URL.Path, "/"), "/", 2)
+			switch {
+			case r.Method == http.MethodPut && len(path) == 1:
+				err = layer.MakeBucketWithLocation(ctx, path[0], "")
+			case r.Method == http.MethodPut:
+				var data *hash.Reader
+				data, err = hash.NewReader(r.Body, r.ContentLength, "", "")
+				if err == nil {
+					_, err = layer.PutObject(ctx, path[0], path[1], data, map[string]string{})
+				}
+			default:
+				http.Error(w, "unexpected request", http.StatusNotImplemented)
+				return
+			}
+			if !assert.NoError(t, err) {
+				writeError(w, r, err)
+			}
+		}))
+		defer minioServer.Close()
+
+		gatewayServer := httptest.NewServer(tenants.Handler(minioServer.Listener.Addr().String()))
+		defer gatewayServer.Close()
+
+		gatewayURL, err := url.Parse(gatewayServer.URL)
+		require.NoError(t, err)
+
+		newClient := func(accessKey, secretKey string) *minioclient.Client {
+			client, err := minioclient.NewWithRegion(gatewayURL.Host, accessKey, secretKey, false, "us-east-1")
+			require.NoError(t, err)
+			return client
+		}
+
+		data := make([]byte, 5*memory.KiB)
+		_, err = rand.Read(data)
+		require.NoError(t, err)
+
+		{ // every access key is served with its own project
+			alpha := newClient("alpha", "alpha-secret")
+			require.NoError(t, alpha.MakeBucket("shared", ""))
+			_, err = alpha.PutObject("shared", "object", bytes.NewReader(data), int64(len(data)), minioclient.PutObjectOptions{})
+			require.NoError(t, err)
+
+			beta := newClient("beta", "beta-secret")
+			require.NoError(t, beta.MakeBucket("shared", ""))
+		}
+
+		{ // the objects are encrypted with the encryption key of the tenant
+			access, _, err := creds.Lookup("alpha")
+			require.NoError(t, err)
+			project, key, err := openProject(ctx, access)
+			require.NoError(t, err)
+			defer ctx.Check(project.Close)
+
+			bucket, err := project.OpenBucket(ctx, "shared", &libuplink.EncryptionAccess{Key: *key})
+			require.NoError(t, err)
+			defer ctx.Check(bucket.Close)
+
+			reader, err := bucket.NewReader(ctx, "object")
+			require.NoError(t, err)
+			downloaded, err := ioutil.ReadAll(reader)
+			require.NoError(t, err)
+			require.NoError(t, reader.Close())
+			assert.Equal(t, data, downloaded)
+
+			access, _, err = creds.Lookup("beta")
+			require.NoError(t, err)
+			other, key, err := openProject(ctx, access)
+			require.NoError(t, err)
+			defer ctx.Check(other.Close)
+
+			bucket, err = other.OpenBucket(ctx, "shared", &libuplink.EncryptionAccess{Key: *key})
+			require.NoError(t, err)
+			defer ctx.Check(bucket.Close)
+
+			list, err := bucket.ListObjects(ctx, &storj.ListOptions{Direction: storj.After, Recursive: true})
+			require.NoError(t, err)
+			assert.Empty(t, list.Items)
+		}
+
+		{ // requests must be signed with a stored secret key
+			err := newClient("gamma", "gamma-secret").MakeBucket("gamma", "")
+			assert.Equal(t, "InvalidAccessKeyId", minioclient.ToErrorResponse(err).Code)
+
+			err = newClient("alpha", "beta-secret").MakeBucket("alpha", "")
+			assert.Equal(t, "SignatureDoesNotMatch", minioclient.ToErrorResponse(err).Code)
+
+			_, err = newClient("alpha", "beta-secret").PutObject("shared", "forged", bytes.NewReader(data), int64(len(data)), minioclient.PutObjectOptions{})
+			assert.Equal(t, "SignatureDoesNotMatch", minioclient.ToErrorResponse(err).Code)
+
+			err = newClient(internal.AccessKey, internal.SecretKey).MakeBucket("internal", "")
+			assert.Equal(t, "InvalidAccessKeyId", minioclient.ToErrorResponse(err).Code)
+		}
+
+		{ // object tags are served by the gateway
+			request := func(accessKey, method, path string, body []byte) (*http.Response, []byte) {
+				request, err := http.NewRequest(method, gatewayServer.URL+path, bytes.NewReader(body))
+				require.NoError(t, err)
+				sum := sha256.Sum256(body)
+				request.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+
+				response, err := http.DefaultClient.Do(s3signer.SignV4(*request, accessKey, accessKey+"-secret", "", "us-east-1"))
+				require.NoError(t, err)
+				data, err := ioutil.ReadAll(response.Body)
+				require.NoError(t, err)
+				require.NoError(t, response.Body.Close())
+				return response, data
+			}
+			encode := func(tags ...tag) []byte {
+				data, err := xml.Marshal(tagging{TagSet: tags})
+				require.NoError(t, err)
+				return data
+			}
+
+			tags := []tag{{Key: "project", Value: "storj"}, {Key: "team", Value: "gateway"}}
+			response, _ := request("alpha", http.MethodPut, "/shared/object?tagging", encode(tags[1], tags[0]))
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+
+			response, data := request("alpha", http.MethodGet, "/shared/object?tagging", nil)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			var result tagging
+			require.NoError(t, xml.Unmarshal(data, &result))
+			assert.Equal(t, tags, result.TagSet)
+
+			var tooMany []tag
+			for i := 0; i <= maxTags; i++ {
+				tooMany = append(tooMany, tag{Key: strconv.Itoa(i)})
+			}
+			response, data = request("alpha", http.MethodPut, "/shared/object?tagging", encode(tooMany...))
+			assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+			assert.Contains(t, string(data), "InvalidTag")
+
+			response, data = request("alpha", http.MethodGet, "/shared/missing?tagging", nil)
+			assert.Equal(t, http.StatusNotFound, response.StatusCode)
+			assert.Contains(t, string(data), "NoSuchKey")
+
+			// the object of alpha is not visible to beta
+			response, _ = request("beta", http.MethodGet, "/shared/object?tagging", nil)
+			assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+			response, _ = request("alpha", http.MethodDelete, "/shared/object?tagging", nil)
+			assert.Equal(t, http.StatusNoContent, response.StatusCode)
+
+			response, data = request("alpha", http.MethodGet, "/shared/object?tagging", nil)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			result = tagging{}
+			require.NoError(t, xml.Unmarshal(data, &result))
+			assert.Empty(t, result.TagSet)
+		}
+
+		{ // presigned requests are accepted until they expire
+			alpha := newClient("alpha", "alpha-secret")
+			presigned, err := alpha.Presign(http.MethodPut, "shared", "presigned", time.Minute, nil)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, presigned.String(), bytes.NewReader(data))
+			require.NoError(t, err)
+			response, err := http.DefaultClient.Do(request)
+			require.NoError(t, err)
+			require.NoError(t, response.Body.Close())
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+		}
+	})
+}
+
+func TestTenantsEviction(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	opened := map[string]int{}
+	openProject := func(ctx context.Context, access Access) (*libuplink.Project, *storj.Key, error) {
+		opened[access.APIKey]++
+		return &libuplink.Project{}, &storj.Key{}, nil
+	}
+
+	tenants := NewTenants(zaptest.NewLogger(t), nil, openProject, auth.Credentials{})
+	defer ctx.Check(tenants.Close)
+	tenants.idleTimeout = time.Hour
+
+	open := func(accessKey string, access Access) func() {
+		_, release, err := tenants.openTenant(ctx, accessKey, access)
+		require.NoError(t, err)
+		return release
+	}
+	state := func() (projects, retired int) {
+		tenants.mu.Lock()
+		defer tenants.mu.Unlock()
+		return len(tenants.projects), len(tenants.retired)
+	}
+
+	keys := func() (accessKeys []string) {
+		tenants.mu.Lock()
+		defer tenants.mu.Unlock()
+		for accessKey := range tenants.projects {
+			accessKeys = append(accessKeys, accessKey)
+		}
+		return accessKeys
+	}
+
+	first := Access{APIKey: "first"}
+	second := Access{APIKey: "second"}
+
+	{ // projects are reused while their access grant stays the same
+		open("alpha", first)()
+		open("alpha", first)()
+		assert.Equal(t, 1, opened["first"])
+	}
+
+	{ // a replaced access grant is retired until its last request is done
+		release := open("alpha", first)
+		open("alpha", second)()
+		projects, retired := state()
+		assert.Equal(t, 1, projects)
+		assert.Equal(t, 1, retired)
+
+		release()
+		projects, retired = state()
+		assert.Equal(t, 1, projects)
+		assert.Equal(t, 0, retired)
+	}
+
+	{ // projects without requests are closed after the idle timeout
+		release := open("beta", first)
+		tenants.idleTimeout = 0
+
+		// alpha is closed, beta is still in use
+		open("gamma", first)()
+		assert.ElementsMatch(t, []string{"beta", "gamma"}, keys())
+
+		release()
+		open("delta", first)()
+		assert.ElementsMatch(t, []string{"delta"}, keys())
+	}
+}
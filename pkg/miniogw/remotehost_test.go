@@ -0,0 +1,126 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"context"
+	"flag"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/minio-go/pkg/s3signer"
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	libuplink "storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/storj"
+)
+
+// sessionGateway serves minio with a layer which only checks that the
+// tenant of a request is found
+type sessionGateway struct {
+	tenants *Tenants
+	found   chan error
+}
+
+func (gateway *sessionGateway) Name() string     { return "storj" }
+func (gateway *sessionGateway) Production() bool { return false }
+
+func (gateway *sessionGateway) NewGatewayLayer(creds auth.Credentials) (minio.ObjectLayer, error) {
+	return &sessionLayer{gateway: gateway}, nil
+}
+
+type sessionLayer struct {
+	minio.ObjectLayer
+	gateway *sessionGateway
+}
+
+func (layer *sessionLayer) ListBuckets(ctx context.Context) ([]minio.BucketInfo, error) {
+	return nil, nil
+}
+
+func (layer *sessionLayer) MakeBucketWithLocation(ctx context.Context, bucket string, location string) error {
+	_, err := layer.gateway.tenants.tenant(ctx)
+	select {
+	case layer.gateway.found <- err:
+	default:
+	}
+	return err
+}
+
+// TestMinioRemoteHost runs the tenant handler in front of minio, the object
+// layer finds the tenant of a request only as long as minio reports the local
+// address of the forwarding connection as the remote host of the request.
+func TestMinioRemoteHost(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	creds, err := OpenCredentials(ctx.File("credentials.json"))
+	require.NoError(t, err)
+	require.NoError(t, creds.Put("alpha", Access{SecretKey: "alpha-secret"}))
+
+	openProject := func(ctx context.Context, access Access) (*libuplink.Project, *storj.Key, error) {
+		return &libuplink.Project{}, &storj.Key{}, nil
+	}
+
+	internal := auth.Credentials{AccessKey: "internal-access-key", SecretKey: "internal-secret-key"}
+	tenants := NewTenants(zaptest.NewLogger(t), creds, openProject, internal)
+	defer ctx.Check(tenants.Close)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	minioAddr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	require.NoError(t, os.Setenv("MINIO_ACCESS_KEY", internal.AccessKey))
+	require.NoError(t, os.Setenv("MINIO_SECRET_KEY", internal.SecretKey))
+
+	flags := flag.NewFlagSet("gateway", flag.ContinueOnError)
+	flags.String("address", minioAddr, "")
+	flags.String("config-dir", ctx.Dir("minio"), "")
+	flags.Bool("quiet", true, "")
+	cliCtx := cli.NewContext(cli.NewApp(), flags, nil)
+	require.NoError(t, cliCtx.Set("quiet", "true"))
+
+	gateway := &sessionGateway{tenants: tenants, found: make(chan error, 1)}
+	// minio serves until the process exits
+	go minio.StartGateway(cliCtx, gateway)
+
+	gatewayServer := httptest.NewServer(tenants.Handler(minioAddr))
+	defer gatewayServer.Close()
+
+	// wait for minio to accept requests
+	for start := time.Now(); ; time.Sleep(50 * time.Millisecond) {
+		response, err := http.Get("http://" + minioAddr + "/minio/health/live")
+		if err == nil {
+			require.NoError(t, response.Body.Close())
+			break
+		}
+		require.True(t, time.Since(start) < 10*time.Second, "minio did not start: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPut, gatewayServer.URL+"/bucket", nil)
+	require.NoError(t, err)
+	request.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+	response, err := http.DefaultClient.Do(s3signer.SignV4(*request, "alpha", "alpha-secret", "", "us-east-1"))
+	require.NoError(t, err)
+	require.NoError(t, response.Body.Close())
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	select {
+	case err := <-gateway.found:
+		assert.NoError(t, err)
+	default:
+		t.Fatal("request did not reach the object layer")
+	}
+}
@@ -0,0 +1,981 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+//
+// Minio Cloud Storage, (C) 2015, 2016, 2017, 2018 Minio, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package miniogw
+
+// The verification of AWS signature version 4 below is minio's, from
+// cmd/signature-v4.go, cmd/signature-v4-parser.go, cmd/signature-v4-utils.go
+// and cmd/streaming-signature-v4.go of the minio version the gateway runs.
+// This product includes software developed at Minio, Inc. (https://minio.io/).
+//
+// Minio keeps the verification unexported and verifies against its single
+// server credential. Storj Labs modified the copy to take the secret key of
+// the tenant and to return the errors of this package instead of minio's API
+// error codes. This file stays under the Apache License above, keep it in
+// sync when updating minio.
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zeebo/errs"
+)
+
+const (
+	signV4Algorithm        = "AWS4-HMAC-SHA256"
+	signV4ChunkedAlgorithm = "AWS4-HMAC-SHA256-PAYLOAD"
+	streamingPayload       = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	unsignedPayload        = "UNSIGNED-PAYLOAD"
+	emptySHA256            = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	iso8601Format = "20060102T150405Z"
+	yyyymmdd      = "20060102"
+
+	// maxClockSkew is how far the date of a request may be off
+	maxClockSkew = 15 * time.Minute
+	// maxPresignExpiry is the longest a presigned request may be valid for
+	maxPresignExpiry = 7 * 24 * time.Hour
+	// maxChunkLineLength limits the header line of a streaming chunk
+	maxChunkLineLength = 4096
+)
+
+var (
+	// errMalformedSignature is returned when the signature cannot be parsed
+	errMalformedSignature = errs.Class("malformed signature")
+	// errUnsupportedSignature is returned for anything but signature version 4
+	errUnsupportedSignature = errs.Class("unsupported signature")
+	// errSignatureMismatch is returned when the request is not signed with the secret key
+	errSignatureMismatch = errs.Class("signature mismatch")
+	// errRequestExpired is returned when the request date is out of range
+	errRequestExpired = errs.Class("request expired")
+)
+
+// signatureV4 is an AWS signature version 4 of a request, sent either in the
+// Authorization header or in the query of a presigned URL.
+type signatureV4 struct {
+	signValues
+	accessKey string
+	date      time.Time
+	presigned bool
+	expires   time.Duration
+}
+
+// parseSignatureV4 parses the signature of r without verifying it
+func parseSignatureV4(r *http.Request) (*signatureV4, error) {
+	if isRequestPresignedSignatureV4(r) {
+		values, err := parsePreSignV4(r.URL.Query())
+		if err != nil {
+			return nil, err
+		}
+		return &signatureV4{
+			signValues: values.signValues,
+			accessKey:  values.Credential.accessKey,
+			date:       values.Date,
+			presigned:  true,
+			expires:    values.Expires,
+		}, nil
+	}
+
+	values, err := parseSignV4(r.Header.Get("Authorization"))
+	if err != nil {
+		return nil, err
+	}
+
+	date := r.Header.Get("X-Amz-Date")
+	if date == "" {
+		if date = r.Header.Get("Date"); date == "" {
+			return nil, errMalformedSignature.New("missing date header")
+		}
+	}
+	t, err := time.Parse(iso8601Format, date)
+	if err != nil {
+		return nil, errMalformedSignature.New("invalid date %q", date)
+	}
+
+	return &signatureV4{
+		signValues: values,
+		accessKey:  values.Credential.accessKey,
+		date:       t,
+	}, nil
+}
+
+// verify checks that r is signed with secretKey at about now
+func (sig *signatureV4) verify(r *http.Request, secretKey string, now time.Time) error {
+	if sig.presigned {
+		return doesPresignedSignatureMatch(sig.payloadHash(r), r, secretKey, now)
+	}
+	if now.Sub(sig.date) > maxClockSkew || sig.date.Sub(now) > maxClockSkew {
+		return errRequestExpired.New("request time %s is too skewed", sig.date.UTC().Format(iso8601Format))
+	}
+	return doesSignatureMatch(sig.payloadHash(r), r, secretKey)
+}
+
+// payloadHash returns the hashed payload the request was signed with
+func (sig *signatureV4) payloadHash(r *http.Request) string {
+	return getContentSha256Cksum(r)
+}
+
+// credentialHeader data type represents structured form of Credential
+// string from authorization header.
+type credentialHeader struct {
+	accessKey string
+	scope     struct {
+		date    time.Time
+		region  string
+		service string
+		request string
+	}
+}
+
+// Return scope string.
+func (c credentialHeader) getScope() string {
+	return strings.Join([]string{
+		c.scope.date.Format(yyyymmdd),
+		c.scope.region,
+		c.scope.service,
+		c.scope.request,
+	}, "/")
+}
+
+// parse credentialHeader string into its structured form. The access key
+// is looked up by the caller.
+func parseCredentialHeader(credElement string) (ch credentialHeader, err error) {
+	creds := strings.Split(strings.TrimSpace(credElement), "=")
+	if len(creds) != 2 {
+		return ch, errMalformedSignature.New("missing fields")
+	}
+	if creds[0] != "Credential" {
+		return ch, errMalformedSignature.New("missing credential tag")
+	}
+	credElements := strings.Split(strings.TrimSpace(creds[1]), "/")
+	if len(credElements) != 5 || credElements[0] == "" {
+		return ch, errMalformedSignature.New("invalid credential %q", creds[1])
+	}
+	// Save access key id.
+	cred := credentialHeader{
+		accessKey: credElements[0],
+	}
+	cred.scope.date, err = time.Parse(yyyymmdd, credElements[1])
+	if err != nil {
+		return ch, errMalformedSignature.New("invalid credential date %q", credElements[1])
+	}
+	cred.scope.region = credElements[2]
+	if credElements[3] != "s3" {
+		return ch, errMalformedSignature.New("invalid service %q", credElements[3])
+	}
+	cred.scope.service = credElements[3]
+	if credElements[4] != "aws4_request" {
+		return ch, errMalformedSignature.New("invalid request version %q", credElements[4])
+	}
+	cred.scope.request = credElements[4]
+	return cred, nil
+}
+
+// Parse signature from signature tag.
+func parseSignature(signElement string) (string, error) {
+	signFields := strings.Split(strings.TrimSpace(signElement), "=")
+	if len(signFields) != 2 || signFields[1] == "" {
+		return "", errMalformedSignature.New("missing fields")
+	}
+	if signFields[0] != "Signature" {
+		return "", errMalformedSignature.New("missing signature tag")
+	}
+	return signFields[1], nil
+}
+
+// Parse slice of signed headers from signed headers tag.
+func parseSignedHeader(signedHdrElement string) ([]string, error) {
+	signedHdrFields := strings.Split(strings.TrimSpace(signedHdrElement), "=")
+	if len(signedHdrFields) != 2 || signedHdrFields[1] == "" {
+		return nil, errMalformedSignature.New("missing fields")
+	}
+	if signedHdrFields[0] != "SignedHeaders" {
+		return nil, errMalformedSignature.New("missing signed headers tag")
+	}
+	return strings.Split(signedHdrFields[1], ";"), nil
+}
+
+// signValues data type represents structured form of AWS Signature V4 header.
+type signValues struct {
+	Credential    credentialHeader
+	SignedHeaders []string
+	Signature     string
+}
+
+// preSignValues data type represents structued form of AWS Signature V4 query string.
+type preSignValues struct {
+	signValues
+	Date    time.Time
+	Expires time.Duration
+}
+
+// isRequestPresignedSignatureV4 returns whether the request is signed in its query
+func isRequestPresignedSignatureV4(r *http.Request) bool {
+	_, ok := r.URL.Query()["X-Amz-Credential"]
+	return ok
+}
+
+// verifies if any of the necessary query params are missing in the presigned request.
+func doesV4PresignParamsExist(query url.Values) error {
+	v4PresignQueryParams := []string{"X-Amz-Algorithm", "X-Amz-Credential", "X-Amz-Signature", "X-Amz-Date", "X-Amz-SignedHeaders", "X-Amz-Expires"}
+	for _, v4PresignQueryParam := range v4PresignQueryParams {
+		if _, ok := query[v4PresignQueryParam]; !ok {
+			return errMalformedSignature.New("missing query parameter %s", v4PresignQueryParam)
+		}
+	}
+	return nil
+}
+
+// Parses all the presigned signature values into separate elements.
+func parsePreSignV4(query url.Values) (psv preSignValues, err error) {
+	// verify whether the required query params exist.
+	if err := doesV4PresignParamsExist(query); err != nil {
+		return psv, err
+	}
+
+	// Verify if the query algorithm is supported or not.
+	if query.Get("X-Amz-Algorithm") != signV4Algorithm {
+		return psv, errUnsupportedSignature.New("only %s is supported", signV4Algorithm)
+	}
+
+	// Initialize signature version '4' structured header.
+	preSignV4Values := preSignValues{}
+
+	// Save credential.
+	preSignV4Values.Credential, err = parseCredentialHeader("Credential=" + query.Get("X-Amz-Credential"))
+	if err != nil {
+		return psv, err
+	}
+
+	// Save date in native time.Time.
+	preSignV4Values.Date, err = time.Parse(iso8601Format, query.Get("X-Amz-Date"))
+	if err != nil {
+		return psv, errMalformedSignature.New("invalid date %q", query.Get("X-Amz-Date"))
+	}
+
+	// Save expires in native time.Duration.
+	preSignV4Values.Expires, err = time.ParseDuration(query.Get("X-Amz-Expires") + "s")
+	if err != nil || preSignV4Values.Expires < 0 || preSignV4Values.Expires > maxPresignExpiry {
+		return psv, errMalformedSignature.New("invalid expiry %q", query.Get("X-Amz-Expires"))
+	}
+
+	// Save signed headers.
+	preSignV4Values.SignedHeaders, err = parseSignedHeader("SignedHeaders=" + query.Get("X-Amz-SignedHeaders"))
+	if err != nil {
+		return psv, err
+	}
+
+	// Save signature.
+	preSignV4Values.Signature, err = parseSignature("Signature=" + query.Get("X-Amz-Signature"))
+	if err != nil {
+		return psv, err
+	}
+
+	// Return structed form of signature query string.
+	return preSignV4Values, nil
+}
+
+// Parses signature version '4' header of the following form.
+//
+//	Authorization: algorithm Credential=accessKeyID/credScope, \
+//	        SignedHeaders=signedHeaders, Signature=signature
+func parseSignV4(v4Auth string) (sv signValues, err error) {
+	// Replace all spaced strings, some clients can send spaced
+	// parameters and some won't. So we pro-actively remove any spaces
+	// to make parsing easier.
+	v4Auth = strings.Replace(v4Auth, " ", "", -1)
+	if v4Auth == "" {
+		return sv, errUnsupportedSignature.New("anonymous requests are not allowed")
+	}
+
+	// Verify if the header algorithm is supported or not.
+	if !strings.HasPrefix(v4Auth, signV4Algorithm) {
+		return sv, errUnsupportedSignature.New("only %s is supported", signV4Algorithm)
+	}
+
+	// Strip off the Algorithm prefix.
+	v4Auth = strings.TrimPrefix(v4Auth, signV4Algorithm)
+	authFields := strings.Split(strings.TrimSpace(v4Auth), ",")
+	if len(authFields) != 3 {
+		return sv, errMalformedSignature.New("missing fields")
+	}
+
+	// Initialize signature version '4' structured header.
+	signV4Values := signValues{}
+
+	// Save credentail values.
+	signV4Values.Credential, err = parseCredentialHeader(authFields[0])
+	if err != nil {
+		return sv, err
+	}
+
+	// Save signed headers.
+	signV4Values.SignedHeaders, err = parseSignedHeader(authFields[1])
+	if err != nil {
+		return sv, err
+	}
+
+	// Save signature.
+	signV4Values.Signature, err = parseSignature(authFields[2])
+	if err != nil {
+		return sv, err
+	}
+
+	// Return the structure here.
+	return signV4Values, nil
+}
+
+// Returns SHA256 for calculating canonical-request.
+func getContentSha256Cksum(r *http.Request) string {
+	var (
+		defaultSha256Cksum string
+		v                  []string
+		ok                 bool
+	)
+
+	// For a presigned request we look at the query param for sha256.
+	if isRequestPresignedSignatureV4(r) {
+		// X-Amz-Content-Sha256, if not set in presigned requests, checksum
+		// will default to 'UNSIGNED-PAYLOAD'.
+		defaultSha256Cksum = unsignedPayload
+		v, ok = r.URL.Query()["X-Amz-Content-Sha256"]
+	} else {
+		// X-Amz-Content-Sha256, if not set in signed requests, checksum
+		// will default to sha256([]byte("")).
+		defaultSha256Cksum = emptySHA256
+		v, ok = r.Header["X-Amz-Content-Sha256"]
+	}
+
+	// We found 'X-Amz-Content-Sha256' return the captured value.
+	if ok {
+		return v[0]
+	}
+
+	// We couldn't find 'X-Amz-Content-Sha256'.
+	return defaultSha256Cksum
+}
+
+// sumHMAC calculate hmac between two input byte array.
+func sumHMAC(key []byte, data []byte) []byte {
+	hash := hmac.New(sha256.New, key)
+	_, _ = hash.Write(data)
+	return hash.Sum(nil)
+}
+
+// Reserved string regexp.
+var reservedNames = regexp.MustCompile("^[a-zA-Z0-9-_.~/]+$")
+
+// getURLEncodedName encode the strings from UTF-8 byte representations to HTML hex escape sequences
+func getURLEncodedName(name string) string {
+	// if object matches reserved string, no need to encode them
+	if reservedNames.MatchString(name) {
+		return name
+	}
+	var encodedName strings.Builder
+	for _, s := range name {
+		if 'A' <= s && s <= 'Z' || 'a' <= s && s <= 'z' || '0' <= s && s <= '9' { // §2.3 Unreserved characters (mark)
+			encodedName.WriteRune(s)
+			continue
+		}
+		switch s {
+		case '-', '_', '.', '~', '/': // §2.3 Unreserved characters (mark)
+			encodedName.WriteRune(s)
+			continue
+		default:
+			u := make([]byte, utf8.RuneLen(s))
+			utf8.EncodeRune(u, s)
+			for _, r := range u {
+				encodedName.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{r})))
+			}
+		}
+	}
+	return encodedName.String()
+}
+
+// extractSignedHeaders extract signed headers from Authorization header
+func extractSignedHeaders(signedHeaders []string, r *http.Request) (http.Header, error) {
+	reqHeaders := r.Header
+	// find whether "host" is part of list of signed headers.
+	// if not return an error. "host" is mandatory.
+	if !containsString(signedHeaders, "host") {
+		return nil, errMalformedSignature.New("host header is not signed")
+	}
+	extractedSignedHeaders := make(http.Header)
+	for _, header := range signedHeaders {
+		// `host` will not be found in the headers, can be found in r.Host.
+		// but its alway necessary that the list of signed headers containing host in it.
+		val, ok := reqHeaders[http.CanonicalHeaderKey(header)]
+		if ok {
+			for _, enc := range val {
+				extractedSignedHeaders.Add(header, enc)
+			}
+			continue
+		}
+		switch header {
+		case "expect":
+			// Golang http server strips off 'Expect' header, if the
+			// client sent this as part of signed headers we need to
+			// handle otherwise we would see a signature mismatch.
+			extractedSignedHeaders.Set(header, "100-continue")
+		case "host":
+			// Go http server removes "host" from Request.Header
+			extractedSignedHeaders.Set(header, r.Host)
+		case "transfer-encoding":
+			// Go http server removes "transfer-encoding" from Request.Header
+			for _, enc := range r.TransferEncoding {
+				extractedSignedHeaders.Add(header, enc)
+			}
+		case "content-length":
+			// Signature-V4 spec excludes Content-Length from signed headers list for signature calculation.
+			// But some clients deviate from this rule. Hence we consider Content-Length for signature
+			// calculation to be compatible with such clients.
+			extractedSignedHeaders.Set(header, strconv.FormatInt(r.ContentLength, 10))
+		default:
+			return nil, errMalformedSignature.New("signed header %q is missing", header)
+		}
+	}
+	return extractedSignedHeaders, nil
+}
+
+// containsString returns whether list contains s
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Trim leading and trailing spaces and replace sequential spaces with one space, following Trimall()
+// in http://docs.aws.amazon.com/general/latest/gr/sigv4-create-canonical-request.html
+func signV4TrimAll(input string) string {
+	// Compress adjacent spaces (a space is determined by
+	// unicode.IsSpace() internally here) to one space and return
+	return strings.Join(strings.Fields(input), " ")
+}
+
+// getCanonicalHeaders generate a list of request headers with their values
+func getCanonicalHeaders(signedHeaders http.Header) string {
+	var headers []string
+	vals := make(http.Header)
+	for k, vv := range signedHeaders {
+		headers = append(headers, strings.ToLower(k))
+		vals[strings.ToLower(k)] = vv
+	}
+	sort.Strings(headers)
+
+	var buf bytes.Buffer
+	for _, k := range headers {
+		buf.WriteString(k)
+		buf.WriteByte(':')
+		for idx, v := range vals[k] {
+			if idx > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(signV4TrimAll(v))
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.String()
+}
+
+// getSignedHeaders generate a string i.e alphabetically sorted, semicolon-separated list of lowercase request header names
+func getSignedHeaders(signedHeaders http.Header) string {
+	var headers []string
+	for k := range signedHeaders {
+		headers = append(headers, strings.ToLower(k))
+	}
+	sort.Strings(headers)
+	return strings.Join(headers, ";")
+}
+
+// getCanonicalRequest generate a canonical request of style
+//
+// canonicalRequest =
+//
+//	<HTTPMethod>\n
+//	<CanonicalURI>\n
+//	<CanonicalQueryString>\n
+//	<CanonicalHeaders>\n
+//	<SignedHeaders>\n
+//	<HashedPayload>
+func getCanonicalRequest(extractedSignedHeaders http.Header, payload, queryStr, urlPath, method string) string {
+	rawQuery := strings.Replace(queryStr, "+", "%20", -1)
+	encodedPath := getURLEncodedName(urlPath)
+	canonicalRequest := strings.Join([]string{
+		method,
+		encodedPath,
+		rawQuery,
+		getCanonicalHeaders(extractedSignedHeaders),
+		getSignedHeaders(extractedSignedHeaders),
+		payload,
+	}, "\n")
+	return canonicalRequest
+}
+
+// getScope generate a string of a specific date, an AWS region, and a service.
+func getScope(t time.Time, region string) string {
+	scope := strings.Join([]string{
+		t.Format(yyyymmdd),
+		region,
+		"s3",
+		"aws4_request",
+	}, "/")
+	return scope
+}
+
+// getStringToSign a string based on selected query values.
+func getStringToSign(canonicalRequest string, t time.Time, scope string) string {
+	stringToSign := signV4Algorithm + "\n" + t.Format(iso8601Format) + "\n"
+	stringToSign = stringToSign + scope + "\n"
+	canonicalRequestBytes := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign = stringToSign + hex.EncodeToString(canonicalRequestBytes[:])
+	return stringToSign
+}
+
+// getSigningKey hmac seed to calculate final signature.
+func getSigningKey(secretKey string, t time.Time, region string) []byte {
+	date := sumHMAC([]byte("AWS4"+secretKey), []byte(t.Format(yyyymmdd)))
+	regionBytes := sumHMAC(date, []byte(region))
+	service := sumHMAC(regionBytes, []byte("s3"))
+	signingKey := sumHMAC(service, []byte("aws4_request"))
+	return signingKey
+}
+
+// getSignature final signature in hexadecimal form.
+func getSignature(signingKey []byte, stringToSign string) string {
+	return hex.EncodeToString(sumHMAC(signingKey, []byte(stringToSign)))
+}
+
+// compareSignatureV4 returns true if and only if both signatures
+// are equal. The signatures are expected to be HEX encoded strings
+// according to the AWS S3 signature V4 spec.
+func compareSignatureV4(sig1, sig2 string) bool {
+	// The CTC using []byte(str) works because the hex encoding
+	// is unique for a sequence of bytes. See also compareSignatureV2.
+	return subtle.ConstantTimeCompare([]byte(sig1), []byte(sig2)) == 1
+}
+
+// doesPresignedSignatureMatch - Verify query headers with presigned signature
+//   - http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html
+func doesPresignedSignatureMatch(hashedPayload string, r *http.Request, secretKey string, now time.Time) error {
+	// Copy request
+	req := *r
+
+	// Parse request query string.
+	pSignValues, err := parsePreSignV4(req.URL.Query())
+	if err != nil {
+		return err
+	}
+
+	// Extract all the signed headers along with its values.
+	extractedSignedHeaders, err := extractSignedHeaders(pSignValues.SignedHeaders, r)
+	if err != nil {
+		return err
+	}
+	// Construct new query.
+	query := make(url.Values)
+	if req.URL.Query().Get("X-Amz-Content-Sha256") != "" {
+		query.Set("X-Amz-Content-Sha256", hashedPayload)
+	}
+
+	query.Set("X-Amz-Algorithm", signV4Algorithm)
+
+	// If the host which signed the request is slightly ahead in time (by less than maxClockSkew) the
+	// request should still be allowed.
+	if pSignValues.Date.After(now.Add(maxClockSkew)) {
+		return errRequestExpired.New("presigned request is not valid yet")
+	}
+
+	if now.Sub(pSignValues.Date) > pSignValues.Expires {
+		return errRequestExpired.New("presigned request has expired")
+	}
+
+	// Save the date and expires.
+	t := pSignValues.Date
+	expireSeconds := int(pSignValues.Expires / time.Second)
+
+	// Construct the query.
+	query.Set("X-Amz-Date", t.Format(iso8601Format))
+	query.Set("X-Amz-Expires", strconv.Itoa(expireSeconds))
+	query.Set("X-Amz-SignedHeaders", getSignedHeaders(extractedSignedHeaders))
+	query.Set("X-Amz-Credential", pSignValues.Credential.accessKey+"/"+getScope(t, pSignValues.Credential.scope.region))
+
+	// Save other headers available in the request parameters.
+	for k, v := range req.URL.Query() {
+		if strings.HasPrefix(strings.ToLower(k), "x-amz") {
+			continue
+		}
+		query[k] = v
+	}
+
+	// Get the encoded query.
+	encodedQuery := query.Encode()
+
+	// Verify if date, expires, signed headers and credential query are the same.
+	for _, name := range []string{"X-Amz-Date", "X-Amz-Expires", "X-Amz-SignedHeaders", "X-Amz-Credential"} {
+		if req.URL.Query().Get(name) != query.Get(name) {
+			return errSignatureMismatch.New("%s of presigned request does not match", name)
+		}
+	}
+
+	/// Verify finally if signature is same.
+
+	// Get canonical request.
+	presignedCanonicalReq := getCanonicalRequest(extractedSignedHeaders, hashedPayload, encodedQuery, req.URL.Path, req.Method)
+
+	// Get string to sign from canonical request.
+	presignedStringToSign := getStringToSign(presignedCanonicalReq, t, pSignValues.Credential.getScope())
+
+	// Get hmac presigned signing key.
+	presignedSigningKey := getSigningKey(secretKey, pSignValues.Credential.scope.date, pSignValues.Credential.scope.region)
+
+	// Get new signature.
+	newSignature := getSignature(presignedSigningKey, presignedStringToSign)
+
+	// Verify signature.
+	if !compareSignatureV4(req.URL.Query().Get("X-Amz-Signature"), newSignature) {
+		return errSignatureMismatch.New("request is not signed with the secret key of %q", pSignValues.Credential.accessKey)
+	}
+	return nil
+}
+
+// doesSignatureMatch - Verify authorization header with calculated header in accordance with
+//   - http://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+func doesSignatureMatch(hashedPayload string, r *http.Request, secretKey string) error {
+	// Copy request.
+	req := *r
+
+	// Parse signature version '4' header.
+	signV4Values, err := parseSignV4(req.Header.Get("Authorization"))
+	if err != nil {
+		return err
+	}
+
+	// Extract all the signed headers along with its values.
+	extractedSignedHeaders, err := extractSignedHeaders(signV4Values.SignedHeaders, r)
+	if err != nil {
+		return err
+	}
+
+	// Extract date, if not present throw error.
+	var date string
+	if date = req.Header.Get(http.CanonicalHeaderKey("x-amz-date")); date == "" {
+		if date = r.Header.Get("Date"); date == "" {
+			return errMalformedSignature.New("missing date header")
+		}
+	}
+	// Parse date header.
+	t, err := time.Parse(iso8601Format, date)
+	if err != nil {
+		return errMalformedSignature.New("invalid date %q", date)
+	}
+
+	// Query string.
+	queryStr := req.URL.Query().Encode()
+
+	// Get canonical request.
+	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, hashedPayload, queryStr, req.URL.Path, req.Method)
+
+	// Get string to sign from canonical request.
+	stringToSign := getStringToSign(canonicalRequest, t, signV4Values.Credential.getScope())
+
+	// Get hmac signing key.
+	signingKey := getSigningKey(secretKey, signV4Values.Credential.scope.date, signV4Values.Credential.scope.region)
+
+	// Calculate signature.
+	newSignature := getSignature(signingKey, stringToSign)
+
+	// Verify if signature match.
+	if !compareSignatureV4(newSignature, signV4Values.Signature) {
+		return errSignatureMismatch.New("request is not signed with the secret key of %q", signV4Values.Credential.accessKey)
+	}
+	return nil
+}
+
+// getChunkSignature - get chunk signature.
+func getChunkSignature(secretKey string, seedSignature string, region string, date time.Time, hashedChunk string) string {
+	// Calculate string to sign.
+	stringToSign := signV4ChunkedAlgorithm + "\n" +
+		date.Format(iso8601Format) + "\n" +
+		getScope(date, region) + "\n" +
+		seedSignature + "\n" +
+		emptySHA256 + "\n" +
+		hashedChunk
+
+	// Get hmac signing key.
+	signingKey := getSigningKey(secretKey, date, region)
+
+	// Calculate signature.
+	return getSignature(signingKey, stringToSign)
+}
+
+// errMalformedEncoding is returned when a chunk header is wrongly formed
+var errMalformedEncoding = errMalformedSignature.New("malformed chunked encoding")
+
+// newChunkReader returns a reader of the decoded payload of a streaming
+// request, which verifies the signature of every chunk. The signature of the
+// request must have been verified with verify.
+func newChunkReader(body io.Reader, sig *signatureV4, secretKey string) io.Reader {
+	return &s3ChunkedReader{
+		reader:            bufio.NewReader(body),
+		secretKey:         secretKey,
+		seedSignature:     sig.Signature,
+		seedDate:          sig.date,
+		region:            sig.Credential.scope.region,
+		chunkSHA256Writer: sha256.New(),
+		state:             readChunkHeader,
+	}
+}
+
+// Represents the overall state that is required for decoding a
+// AWS Signature V4 chunked reader.
+type s3ChunkedReader struct {
+	reader            *bufio.Reader
+	secretKey         string
+	seedSignature     string
+	seedDate          time.Time
+	region            string
+	state             chunkState
+	lastChunk         bool
+	chunkSignature    string
+	chunkSHA256Writer hash.Hash // Calculates sha256 of chunk data.
+	n                 uint64    // Unread bytes in chunk
+	err               error
+}
+
+// Read chunk reads the chunk token signature portion.
+func (cr *s3ChunkedReader) readS3ChunkHeader() {
+	// Read the first chunk line until CRLF.
+	var hexChunkSize, hexChunkSignature []byte
+	hexChunkSize, hexChunkSignature, cr.err = readChunkLine(cr.reader)
+	if cr.err != nil {
+		return
+	}
+	// <hex>;token=value - converts the hex into its uint64 form.
+	cr.n, cr.err = parseHexUint(hexChunkSize)
+	if cr.err != nil {
+		return
+	}
+	if cr.n == 0 {
+		cr.err = io.EOF
+	}
+	// Save the incoming chunk signature.
+	cr.chunkSignature = string(hexChunkSignature)
+}
+
+type chunkState int
+
+const (
+	readChunkHeader chunkState = iota
+	readChunkTrailer
+	readChunk
+	verifyChunk
+	eofChunk
+)
+
+// Read - implements `io.Reader`, which transparently decodes
+// the incoming AWS Signature V4 streaming signature.
+func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
+	for {
+		switch cr.state {
+		case readChunkHeader:
+			cr.readS3ChunkHeader()
+			// If we're at the end of a chunk.
+			if cr.n == 0 && cr.err == io.EOF {
+				cr.state = readChunkTrailer
+				cr.lastChunk = true
+				continue
+			}
+			if cr.err != nil {
+				return 0, cr.err
+			}
+			cr.state = readChunk
+		case readChunkTrailer:
+			cr.err = readCRLF(cr.reader)
+			if cr.err != nil {
+				return 0, errMalformedEncoding
+			}
+			cr.state = verifyChunk
+		case readChunk:
+			// There is no more space left in the request buffer.
+			if len(buf) == 0 {
+				return n, nil
+			}
+			rbuf := buf
+			// The request buffer is larger than the current chunk size.
+			// Read only the current chunk from the underlying reader.
+			if uint64(len(rbuf)) > cr.n {
+				rbuf = rbuf[:cr.n]
+			}
+			var n0 int
+			n0, cr.err = cr.reader.Read(rbuf)
+			if cr.err != nil {
+				// We have lesser than chunk size advertised in chunkHeader, this is 'unexpected'.
+				if cr.err == io.EOF {
+					cr.err = io.ErrUnexpectedEOF
+				}
+				return 0, cr.err
+			}
+
+			// Calculate sha256.
+			_, _ = cr.chunkSHA256Writer.Write(rbuf[:n0])
+			// Update the bytes read into request buffer so far.
+			n += n0
+			buf = buf[n0:]
+			// Update bytes to be read of the current chunk before verifying chunk's signature.
+			cr.n -= uint64(n0)
+
+			// If we're at the end of a chunk.
+			if cr.n == 0 {
+				cr.state = readChunkTrailer
+				continue
+			}
+		case verifyChunk:
+			// Calculate the hashed chunk.
+			hashedChunk := hex.EncodeToString(cr.chunkSHA256Writer.Sum(nil))
+			// Calculate the chunk signature.
+			newSignature := getChunkSignature(cr.secretKey, cr.seedSignature, cr.region, cr.seedDate, hashedChunk)
+			if !compareSignatureV4(cr.chunkSignature, newSignature) {
+				// Chunk signature doesn't match we return signature does not match.
+				cr.err = errSignatureMismatch.New("chunk is not signed with the secret key")
+				return 0, cr.err
+			}
+			// Newly calculated signature becomes the seed for the next chunk
+			// this follows the chaining.
+			cr.seedSignature = newSignature
+			cr.chunkSHA256Writer.Reset()
+			if cr.lastChunk {
+				cr.state = eofChunk
+			} else {
+				cr.state = readChunkHeader
+			}
+		case eofChunk:
+			return n, io.EOF
+		}
+	}
+}
+
+// readCRLF - check if reader only has '\r\n' CRLF character.
+// returns malformed encoding if it doesn't.
+func readCRLF(reader io.Reader) error {
+	buf := make([]byte, 2)
+	_, err := io.ReadFull(reader, buf[:2])
+	if err != nil {
+		return err
+	}
+	if buf[0] != '\r' || buf[1] != '\n' {
+		return errMalformedEncoding
+	}
+	return nil
+}
+
+// Read a line of bytes (up to \n) from b.
+// Give up if the line exceeds maxChunkLineLength.
+// The returned bytes are owned by the bufio.Reader
+// so they are only valid until the next bufio read.
+func readChunkLine(b *bufio.Reader) ([]byte, []byte, error) {
+	buf, err := b.ReadSlice('\n')
+	if err != nil {
+		// We always know when EOF is coming.
+		// If the caller asked for a line, there should be a line.
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		} else if err == bufio.ErrBufferFull {
+			err = errMalformedSignature.New("chunk header line too long")
+		}
+		return nil, nil, err
+	}
+	if len(buf) >= maxChunkLineLength {
+		return nil, nil, errMalformedSignature.New("chunk header line too long")
+	}
+	// Parse s3 specific chunk extension and fetch the values.
+	hexChunkSize, hexChunkSignature := parseS3ChunkExtension(buf)
+	return hexChunkSize, hexChunkSignature, nil
+}
+
+// trimTrailingWhitespace - trim trailing white space.
+func trimTrailingWhitespace(b []byte) []byte {
+	for len(b) > 0 && isASCIISpace(b[len(b)-1]) {
+		b = b[:len(b)-1]
+	}
+	return b
+}
+
+// isASCIISpace - is ascii space?
+func isASCIISpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// Constant s3 chunk encoding signature.
+const s3ChunkSignatureStr = ";chunk-signature="
+
+// parses3ChunkExtension removes any s3 specific chunk-extension from buf.
+// For example,
+//
+//	"10000;chunk-signature=..." => "10000", "chunk-signature=..."
+func parseS3ChunkExtension(buf []byte) ([]byte, []byte) {
+	buf = trimTrailingWhitespace(buf)
+	semi := bytes.Index(buf, []byte(s3ChunkSignatureStr))
+	// Chunk signature not found, return the whole buffer.
+	if semi == -1 {
+		return buf, nil
+	}
+	return buf[:semi], parseChunkSignature(buf[semi:])
+}
+
+// parseChunkSignature - parse chunk signature.
+func parseChunkSignature(chunk []byte) []byte {
+	chunkSplits := bytes.SplitN(chunk, []byte(s3ChunkSignatureStr), 2)
+	return chunkSplits[1]
+}
+
+// parse hex to uint64.
+func parseHexUint(v []byte) (n uint64, err error) {
+	for i, b := range v {
+		switch {
+		case '0' <= b && b <= '9':
+			b = b - '0'
+		case 'a' <= b && b <= 'f':
+			b = b - 'a' + 10
+		case 'A' <= b && b <= 'F':
+			b = b - 'A' + 10
+		default:
+			return 0, errMalformedSignature.New("invalid byte in chunk length")
+		}
+		if i == 16 {
+			return 0, errMalformedSignature.New("chunk length too large")
+		}
+		n <<= 4
+		n |= uint64(b)
+	}
+	return
+}
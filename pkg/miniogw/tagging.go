@@ -0,0 +1,227 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxTags is the most tags an object can have
+	maxTags = 10
+	// maxTagKeyLength and maxTagValueLength limit the number of characters
+	// of the key and value of a tag
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+	// maxTaggingSize limits the size of a tagging request body
+	maxTaggingSize = 64 * 1024
+)
+
+var (
+	// errInvalidTag is returned for tags which S3 does not accept
+	errInvalidTag = errs.Class("invalid tag")
+	// errMalformedXML is returned when a request body cannot be decoded
+	errMalformedXML = errs.Class("malformed XML")
+	// errContentMismatch is returned when a request body does not match its signed hash
+	errContentMismatch = errs.Class("content SHA-256 mismatch")
+)
+
+// tagging is the S3 representation of the tags of an object
+type tagging struct {
+	XMLName xml.Name `xml:"Tagging"`
+	Xmlns   string   `xml:"xmlns,attr,omitempty"`
+	TagSet  []tag    `xml:"TagSet>Tag"`
+}
+
+// tag is a single tag of an object
+type tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// validateTags checks that tags are accepted by S3
+func validateTags(tags []tag) error {
+	if len(tags) > maxTags {
+		return errInvalidTag.New("object tags cannot be greater than %d", maxTags)
+	}
+
+	keys := map[string]bool{}
+	for _, tag := range tags {
+		switch {
+		case tag.Key == "" || utf8.RuneCountInString(tag.Key) > maxTagKeyLength:
+			return errInvalidTag.New("the tag key must be between 1 and %d characters", maxTagKeyLength)
+		case utf8.RuneCountInString(tag.Value) > maxTagValueLength:
+			return errInvalidTag.New("the tag value must be at most %d characters", maxTagValueLength)
+		case keys[tag.Key]:
+			return errInvalidTag.New("cannot provide multiple tags with the same key %q", tag.Key)
+		}
+		keys[tag.Key] = true
+	}
+	return nil
+}
+
+// objectTags returns the tags of an object sorted by their keys
+func (tenant *tenant) objectTags(ctx context.Context, bucketName, objectPath string) (tags []tag, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	bucket, err := tenant.project.OpenBucket(ctx, bucketName, tenant.access)
+	if err != nil {
+		return nil, convertError(err, bucketName, "")
+	}
+	defer func() { err = errs.Combine(err, bucket.Close()) }()
+
+	object, err := bucket.OpenObject(ctx, objectPath)
+	if err != nil {
+		return nil, convertError(err, bucketName, objectPath)
+	}
+	defer func() { err = errs.Combine(err, object.Close()) }()
+
+	values, err := url.ParseQuery(object.Meta.Metadata[objectTaggingKey])
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	tags = []tag{}
+	for key := range values {
+		tags = append(tags, tag{Key: key, Value: values.Get(key)})
+	}
+	sort.Slice(tags, func(i, k int) bool { return tags[i].Key < tags[k].Key })
+	return tags, nil
+}
+
+// setObjectTags replaces the tags of an object. The object is not uploaded
+// again, only its metadata is updated.
+func (tenant *tenant) setObjectTags(ctx context.Context, bucketName, objectPath string, tags []tag) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	bucket, err := tenant.project.OpenBucket(ctx, bucketName, tenant.access)
+	if err != nil {
+		return convertError(err, bucketName, "")
+	}
+	defer func() { err = errs.Combine(err, bucket.Close()) }()
+
+	object, err := bucket.OpenObject(ctx, objectPath)
+	if err != nil {
+		return convertError(err, bucketName, objectPath)
+	}
+	defer func() { err = errs.Combine(err, object.Close()) }()
+
+	metadata := make(map[string]string, len(object.Meta.Metadata)+1)
+	for key, value := range object.Meta.Metadata {
+		metadata[key] = value
+	}
+
+	delete(metadata, objectTaggingKey)
+	if len(tags) > 0 {
+		values := url.Values{}
+		for _, tag := range tags {
+			values.Set(tag.Key, tag.Value)
+		}
+		metadata[objectTaggingKey] = values.Encode()
+	}
+
+	err = object.UpdateMetadata(ctx, object.Meta.ContentType, metadata)
+	return convertError(err, bucketName, objectPath)
+}
+
+// isObjectTagging returns whether r is a tagging request of an object
+func isObjectTagging(r *http.Request) (bucket, object string, ok bool) {
+	if _, ok := r.URL.Query()["tagging"]; !ok {
+		return "", "", false
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// serveTagging serves the tagging request of an object, which minio does
+// not support
+func (handler *tenantHandler) serveTagging(w http.ResponseWriter, r *http.Request, sig *signatureV4, access Access, tenant *tenant, bucket, object string) {
+	ctx := r.Context()
+
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		var tags []tag
+		tags, err = tenant.objectTags(ctx, bucket, object)
+		if err == nil {
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = io.WriteString(w, xml.Header)
+			_ = xml.NewEncoder(w).Encode(tagging{Xmlns: "http://s3.amazonaws.com/doc/2006-03-01/", TagSet: tags})
+			return
+		}
+	case http.MethodPut:
+		var body []byte
+		body, err = readSignedBody(r, sig, access.SecretKey)
+		if err == nil {
+			var request tagging
+			if xmlErr := xml.Unmarshal(body, &request); xmlErr != nil {
+				err = errMalformedXML.Wrap(xmlErr)
+			} else if err = validateTags(request.TagSet); err == nil {
+				err = tenant.setObjectTags(ctx, bucket, object, request.TagSet)
+			}
+		}
+		if err == nil {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	case http.MethodDelete:
+		err = tenant.setObjectTags(ctx, bucket, object, nil)
+		if err == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if status, _ := errorCode(err); status == http.StatusInternalServerError {
+		handler.tenants.log.Error("failed to serve object tagging", zap.String("bucket", bucket), zap.Error(err))
+	}
+	writeError(w, r, err)
+}
+
+// readSignedBody reads the body of a request, which the gateway would
+// otherwise leave to minio to verify against its signed hash
+func readSignedBody(r *http.Request, sig *signatureV4, secretKey string) ([]byte, error) {
+	hash := sig.payloadHash(r)
+
+	var body io.Reader = r.Body
+	if hash == streamingPayload {
+		body = newChunkReader(r.Body, sig, secretKey)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(body, maxTaggingSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxTaggingSize {
+		return nil, errMalformedXML.New("request body is too large")
+	}
+
+	if hash != streamingPayload && hash != unsignedPayload {
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != hash {
+			return nil, errContentMismatch.New("request body does not match its signed hash")
+		}
+	}
+	return data, nil
+}
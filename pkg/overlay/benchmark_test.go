@@ -116,7 +116,7 @@ func BenchmarkOverlay(b *testing.B) {
 		b.Run("UpdateUptime", func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
 				id := all[i%len(all)]
-				_, err := overlaydb.UpdateUptime(ctx, id, i&1 == 0)
+				_, err := overlaydb.UpdateUptime(ctx, id, i&1 == 0, 1, 1)
 				require.NoError(b, err)
 			}
 		})
@@ -9,6 +9,7 @@ import (
 	"net"
 	"time"
 
+	"github.com/skyrings/skyring-common/tools/uuid"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
@@ -54,12 +55,32 @@ type DB interface {
 	CreateStats(ctx context.Context, nodeID storj.NodeID, initial *NodeStats) (stats *NodeStats, err error)
 	// Update updates node address
 	UpdateAddress(ctx context.Context, value *pb.Node) error
+	// UpdateLocation updates the network and country of the node
+	UpdateLocation(ctx context.Context, nodeID storj.NodeID, location NodeLocation) error
 	// UpdateStats all parts of single storagenode's stats.
 	UpdateStats(ctx context.Context, request *UpdateRequest) (stats *NodeStats, err error)
 	// UpdateNodeInfo updates node dossier with info requested from the node itself like node type, email, wallet, capacity, and version.
 	UpdateNodeInfo(ctx context.Context, node storj.NodeID, nodeInfo *pb.InfoResponse) (stats *NodeDossier, err error)
 	// UpdateUptime updates a single storagenode's uptime stats.
-	UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool) (stats *NodeStats, err error)
+	UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool, lambda, weight float64) (stats *NodeStats, err error)
+	// GetUploadStats returns how uploads to the node went.
+	GetUploadStats(ctx context.Context, nodeID storj.NodeID) (*UploadStats, error)
+	// UpdateUploadStats records how uploads to storage nodes went, lambda is the forgetting factor.
+	UpdateUploadStats(ctx context.Context, stats []*pb.PieceUploadStats, lambda float64) error
+
+	// DisqualifyNode disqualifies the node, unless it is already disqualified.
+	DisqualifyNode(ctx context.Context, nodeID storj.NodeID, reason string) error
+	// SuspendNode suspends the node, unless it is already suspended or disqualified.
+	SuspendNode(ctx context.Context, nodeID storj.NodeID, reason string) error
+	// UnsuspendNode lifts the suspension of the node when it was suspended for the given reason.
+	UnsuspendNode(ctx context.Context, nodeID storj.NodeID, reason string) error
+	// ReinstateNode clears both disqualification and suspension of the node.
+	ReinstateNode(ctx context.Context, nodeID storj.NodeID) error
+
+	// GetBucketPlacement returns the placement policy of the bucket, empty when it has none.
+	GetBucketPlacement(ctx context.Context, projectID uuid.UUID, bucketName []byte) (string, error)
+	// SetBucketPlacement sets the placement policy of the bucket, an empty policy removes it.
+	SetBucketPlacement(ctx context.Context, projectID uuid.UUID, bucketName []byte, placement string) error
 }
 
 // FindStorageNodesRequest defines easy request parameters.
@@ -70,6 +91,11 @@ type FindStorageNodesRequest struct {
 	FreeDisk             int64
 	ExcludedNodes        []storj.NodeID
 	MinimumVersion       string // semver or empty
+
+	// Placement is the placement policy of the bucket, empty allows all countries
+	Placement string
+	// ExistingNodes already store pieces of the segment and count towards the placement constraints
+	ExistingNodes []storj.NodeID
 }
 
 // NodeCriteria are the requirements for selecting nodes
@@ -82,16 +108,29 @@ type NodeCriteria struct {
 	UptimeSuccessRatio float64
 	ExcludedNodes      []storj.NodeID
 	ExcludedIPs        []string
+	ExcludedNetworks   []string
+	Countries          []string // allowed countries, empty allows all
+	ExcludedCountries  []string
 	MinimumVersion     string // semver or empty
 	OnlineWindow       time.Duration
 	DistinctIP         bool
+	DistinctSubnet     bool
+	PreferFast         bool // prefer nodes with a high upload throughput and success ratio
 }
 
 // UpdateRequest is used to update a node status.
+//
+// The lambda and weight fields configure the reputation model, see NodeStats.
+// A zero lambda or weight is treated as 1.
 type UpdateRequest struct {
 	NodeID       storj.NodeID
 	AuditSuccess bool
 	IsUp         bool
+
+	AuditLambda  float64
+	AuditWeight  float64
+	UptimeLambda float64
+	UptimeWeight float64
 }
 
 // NodeDossier is the complete info that the satellite tracks for a storage node
@@ -104,19 +143,77 @@ type NodeDossier struct {
 	Version      pb.NodeVersion
 	Contained    bool
 	Disqualified bool
+	Status       NodeStatus
+}
+
+// NodeStatus contains the disqualification and suspension details of a node.
+//
+// A disqualified node is permanently excluded from node selection and the
+// pieces it stores are considered lost. A suspended node is excluded from
+// node selection until the suspension is lifted, its pieces are still
+// considered healthy.
+type NodeStatus struct {
+	DisqualifiedAt         *time.Time
+	DisqualificationReason string
+	SuspendedAt            *time.Time
+	SuspensionReason       string
+}
+
+// Reasons used when the node status is changed automatically.
+const (
+	DisqualifiedAuditRatio = "audit success ratio below threshold"
+	SuspendedUptimeRatio   = "uptime ratio below threshold"
+)
+
+// IsSuspended returns whether the node is currently suspended.
+func (node *NodeDossier) IsSuspended() bool {
+	return node.Status.SuspendedAt != nil
 }
 
 // NodeStats contains statistics about a node.
+//
+// AuditSuccessRatio and UptimeRatio are reputation scores of a beta
+// reputation model rather than lifetime ratios. On every update
+//
+//	alpha = lambda*alpha + weight*(1+v)/2
+//	beta  = lambda*beta  + weight*(1-v)/2
+//
+// where v is 1 for a success and -1 for a failure, and the score is
+// alpha / (alpha + beta). With lambda < 1 old results are gradually
+// forgotten, with lambda = 1 and weight = 1 the score equals the lifetime
+// success ratio.
 type NodeStats struct {
-	Latency90          int64
-	AuditSuccessRatio  float64
-	AuditSuccessCount  int64
-	AuditCount         int64
-	UptimeRatio        float64
-	UptimeSuccessCount int64
-	UptimeCount        int64
-	LastContactSuccess time.Time
-	LastContactFailure time.Time
+	Latency90             int64
+	AuditSuccessRatio     float64
+	AuditSuccessCount     int64
+	AuditCount            int64
+	AuditReputationAlpha  float64
+	AuditReputationBeta   float64
+	UptimeRatio           float64
+	UptimeSuccessCount    int64
+	UptimeCount           int64
+	UptimeReputationAlpha float64
+	UptimeReputationBeta  float64
+	LastContactSuccess    time.Time
+	LastContactFailure    time.Time
+	// SuspendedAt is nil unless the node is suspended for SuspensionReason
+	SuspendedAt      *time.Time
+	SuspensionReason string
+}
+
+// UploadStats describes how uploads to a node went, as reported by uplinks.
+//
+// The ratios and the throughput are exponentially weighted moving averages,
+// on every upload
+//
+//	value = lambda*value + (1-lambda)*sample
+//
+// so recent uploads count more than old ones.
+type UploadStats struct {
+	Count         int64
+	SuccessRatio  float64
+	LongTailRatio float64
+	Throughput    float64 // bytes per second of successful uploads
 }
 
 // Cache is used to store and handle node information
@@ -124,14 +221,16 @@ type Cache struct {
 	log         *zap.Logger
 	db          DB
 	preferences NodeSelectionConfig
+	placement   *Placement
 }
 
-// NewCache returns a new Cache
-func NewCache(log *zap.Logger, db DB, preferences NodeSelectionConfig) *Cache {
+// NewCache returns a new Cache, a nil placement disables locating nodes and placement policies
+func NewCache(log *zap.Logger, db DB, preferences NodeSelectionConfig, placement *Placement) *Cache {
 	return &Cache{
 		log:         log,
 		db:          db,
 		preferences: preferences,
+		placement:   placement,
 	}
 }
 
@@ -181,37 +280,41 @@ func (cache *Cache) FindStorageNodesWithPreferences(ctx context.Context, req Fin
 		reputableNodeCount = req.RequestedCount
 	}
 
-	excludedNodes := req.ExcludedNodes
-
-	newNodeCount := 0
-	if preferences.NewNodePercentage > 0 {
-		newNodeCount = int(float64(reputableNodeCount) * preferences.NewNodePercentage)
+	countries, err := cache.placement.Countries(req.Placement)
+	if err != nil {
+		return nil, err
 	}
 
-	var newNodes []*pb.Node
-	if newNodeCount > 0 {
-		newNodes, err = cache.db.SelectNewStorageNodes(ctx, newNodeCount, &NodeCriteria{
-			FreeBandwidth:     req.FreeBandwidth,
-			FreeDisk:          req.FreeDisk,
-			AuditCount:        preferences.AuditCount,
-			AuditSuccessRatio: preferences.AuditSuccessRatio,
-			ExcludedNodes:     excludedNodes,
-			MinimumVersion:    preferences.MinimumVersion,
-			OnlineWindow:      preferences.OnlineWindow,
-			DistinctIP:        preferences.DistinctIP,
-		})
+	selection := newSelection(preferences, req.ExcludedNodes)
+	for _, id := range req.ExistingNodes {
+		node, err := cache.db.Get(ctx, id)
+		if ErrNodeNotFound.Has(err) {
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
+		selection.include(&node.Node, cache.placement.Locate(node.LastIp))
 	}
 
-	var excludedIPs []string
-	// add selected new nodes and their IPs to the excluded lists for reputable node selection
-	for _, newNode := range newNodes {
-		excludedNodes = append(excludedNodes, newNode.Id)
-		if preferences.DistinctIP {
-			excludedIPs = append(excludedIPs, newNode.LastIp)
-		}
+	newNodeCount := 0
+	if preferences.NewNodePercentage > 0 {
+		newNodeCount = int(float64(reputableNodeCount) * preferences.NewNodePercentage)
+	}
+
+	newNodes, err := cache.selectNodes(ctx, newNodeCount, selection, cache.db.SelectNewStorageNodes, NodeCriteria{
+		FreeBandwidth:     req.FreeBandwidth,
+		FreeDisk:          req.FreeDisk,
+		AuditCount:        preferences.AuditCount,
+		AuditSuccessRatio: preferences.AuditSuccessRatio,
+		Countries:         countries,
+		MinimumVersion:    preferences.MinimumVersion,
+		OnlineWindow:      preferences.OnlineWindow,
+		DistinctIP:        preferences.DistinctIP,
+		DistinctSubnet:    preferences.DistinctSubnet,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	criteria := NodeCriteria{
@@ -221,18 +324,35 @@ func (cache *Cache) FindStorageNodesWithPreferences(ctx context.Context, req Fin
 		AuditSuccessRatio:  preferences.AuditSuccessRatio,
 		UptimeCount:        preferences.UptimeCount,
 		UptimeSuccessRatio: preferences.UptimeRatio,
-		ExcludedNodes:      excludedNodes,
-		ExcludedIPs:        excludedIPs,
+		Countries:          countries,
 		MinimumVersion:     preferences.MinimumVersion,
 		OnlineWindow:       preferences.OnlineWindow,
 		DistinctIP:         preferences.DistinctIP,
+		DistinctSubnet:     preferences.DistinctSubnet,
+	}
+
+	// prefer fast nodes, but select some nodes at random so slow nodes and
+	// nodes without upload stats get a chance to prove themselves
+	fastNodeCount := 0
+	if preferences.ExplorationRatio < 1 {
+		explorationCount := int(float64(reputableNodeCount-len(newNodes)) * preferences.ExplorationRatio)
+		fastNodeCount = reputableNodeCount - len(newNodes) - explorationCount
 	}
-	reputableNodes, err := cache.db.SelectStorageNodes(ctx, reputableNodeCount-len(newNodes), &criteria)
+
+	fastCriteria := criteria
+	fastCriteria.PreferFast = true
+	fastNodes, err := cache.selectNodes(ctx, fastNodeCount, selection, cache.db.SelectStorageNodes, fastCriteria)
+	if err != nil {
+		return nil, err
+	}
+
+	reputableNodes, err := cache.selectNodes(ctx, reputableNodeCount-len(newNodes)-len(fastNodes), selection, cache.db.SelectStorageNodes, criteria)
 	if err != nil {
 		return nil, err
 	}
 
 	nodes = append(nodes, newNodes...)
+	nodes = append(nodes, fastNodes...)
 	nodes = append(nodes, reputableNodes...)
 
 	if len(nodes) < reputableNodeCount {
@@ -242,6 +362,34 @@ func (cache *Cache) FindStorageNodesWithPreferences(ctx context.Context, req Fin
 	return nodes, nil
 }
 
+// selectNodes selects up to count nodes matching criteria with query.
+// Nodes exceeding the maximum pieces per country are skipped and replaced
+// by another query excluding the full countries.
+func (cache *Cache) selectNodes(ctx context.Context, count int, selection *selection, query func(context.Context, int, *NodeCriteria) ([]*pb.Node, error), criteria NodeCriteria) (nodes []*pb.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for i := 0; i < 3 && len(nodes) < count; i++ {
+		selection.apply(&criteria)
+
+		needed := count - len(nodes)
+		selected, err := query(ctx, needed, &criteria)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range selected {
+			if selection.add(node, cache.placement.Locate(node.LastIp)) {
+				nodes = append(nodes, node)
+			}
+		}
+		if len(selected) < needed {
+			// there are no more matching nodes
+			break
+		}
+	}
+
+	return nodes, nil
+}
+
 // KnownUnreliableOrOffline filters a set of nodes to unhealth or offlines node, independent of new.
 func (cache *Cache) KnownUnreliableOrOffline(ctx context.Context, nodeIds storj.NodeIDList) (badNodes storj.NodeIDList, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -270,12 +418,48 @@ func (cache *Cache) Put(ctx context.Context, nodeID storj.NodeID, value pb.Node)
 	if value.Address == nil {
 		return errors.New("node has no address")
 	}
-	//Resolve IP Address to ensure it is set
-	value.LastIp, err = getIP(value.Address.Address)
+	if value.Address.Transport == pb.NodeTransport_TCP_TLS_GRPC_RELAY {
+		// relayed nodes are dialed at the relay address, their own ip is reported
+		// by the node once it connected to the relay
+		ip := net.ParseIP(value.LastIp)
+		if ip == nil {
+			cache.log.Debug("relayed node has not reported its ip address", zap.Stringer("Node ID", nodeID))
+			return nil
+		}
+		value.LastIp = ip.String()
+	} else {
+		//Resolve IP Address to ensure it is set
+		value.LastIp, err = getIP(value.Address.Address)
+		if err != nil {
+			return OverlayError.Wrap(err)
+		}
+	}
+	err = cache.db.UpdateAddress(ctx, &value)
 	if err != nil {
-		return OverlayError.Wrap(err)
+		return err
 	}
-	return cache.db.UpdateAddress(ctx, &value)
+	return cache.db.UpdateLocation(ctx, nodeID, cache.placement.Locate(value.LastIp))
+}
+
+// BucketPlacement returns the placement policy of the bucket, empty when it has none
+func (cache *Cache) BucketPlacement(ctx context.Context, projectID uuid.UUID, bucketName []byte) (_ string, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return cache.db.GetBucketPlacement(ctx, projectID, bucketName)
+}
+
+// SetBucketPlacement sets the placement policy of the bucket, an empty policy removes it
+func (cache *Cache) SetBucketPlacement(ctx context.Context, projectID uuid.UUID, bucketName []byte, placement string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if _, err := cache.placement.Countries(placement); err != nil {
+		return err
+	}
+	return cache.db.SetBucketPlacement(ctx, projectID, bucketName, placement)
+}
+
+// DeleteBucketPlacement removes the placement policy of a deleted bucket
+func (cache *Cache) DeleteBucketPlacement(ctx context.Context, projectID uuid.UUID, bucketName []byte) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return cache.db.SetBucketPlacement(ctx, projectID, bucketName, "")
 }
 
 // Create adds a new stats entry for node.
@@ -303,7 +487,18 @@ func (cache *Cache) VetNode(ctx context.Context, nodeID storj.NodeID) (reputable
 // UpdateStats all parts of single storagenode's stats.
 func (cache *Cache) UpdateStats(ctx context.Context, request *UpdateRequest) (stats *NodeStats, err error) {
 	defer mon.Task()(&ctx)(&err)
-	return cache.db.UpdateStats(ctx, request)
+
+	update := *request
+	update.AuditLambda = cache.preferences.AuditReputationLambda
+	update.AuditWeight = cache.preferences.AuditReputationWeight
+	update.UptimeLambda = cache.preferences.UptimeReputationLambda
+	update.UptimeWeight = cache.preferences.UptimeReputationWeight
+
+	stats, err = cache.db.UpdateStats(ctx, &update)
+	if err != nil {
+		return stats, err
+	}
+	return stats, cache.updateStatus(ctx, request.NodeID, stats)
 }
 
 // UpdateNodeInfo updates node dossier with info requested from the node itself like node type, email, wallet, capacity, and version.
@@ -315,7 +510,78 @@ func (cache *Cache) UpdateNodeInfo(ctx context.Context, node storj.NodeID, nodeI
 // UpdateUptime updates a single storagenode's uptime stats.
 func (cache *Cache) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool) (stats *NodeStats, err error) {
 	defer mon.Task()(&ctx)(&err)
-	return cache.db.UpdateUptime(ctx, nodeID, isUp)
+	stats, err = cache.db.UpdateUptime(ctx, nodeID, isUp,
+		cache.preferences.UptimeReputationLambda, cache.preferences.UptimeReputationWeight)
+	if err != nil {
+		return stats, err
+	}
+	return stats, cache.updateStatus(ctx, nodeID, stats)
+}
+
+// UploadStats returns how uploads to the node went.
+func (cache *Cache) UploadStats(ctx context.Context, nodeID storj.NodeID) (stats *UploadStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+	return cache.db.GetUploadStats(ctx, nodeID)
+}
+
+// UpdateUploadStats records how uploads to storage nodes went.
+func (cache *Cache) UpdateUploadStats(ctx context.Context, stats []*pb.PieceUploadStats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	if len(stats) == 0 {
+		return nil
+	}
+	return cache.db.UpdateUploadStats(ctx, stats, cache.preferences.UploadStatsLambda)
+}
+
+// updateStatus disqualifies, suspends or unsuspends the node based on its latest stats.
+func (cache *Cache) updateStatus(ctx context.Context, nodeID storj.NodeID, stats *NodeStats) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if threshold := cache.preferences.DisqualifyAuditRatio; threshold > 0 {
+		if stats.AuditCount >= cache.preferences.AuditCount && stats.AuditSuccessRatio < threshold {
+			mon.Meter("node_disqualified").Mark(1)
+			return cache.db.DisqualifyNode(ctx, nodeID, DisqualifiedAuditRatio)
+		}
+	}
+
+	if threshold := cache.preferences.SuspendUptimeRatio; threshold > 0 {
+		if stats.UptimeCount >= cache.preferences.UptimeCount && stats.UptimeRatio < threshold {
+			if stats.SuspendedAt != nil {
+				return nil
+			}
+			return cache.db.SuspendNode(ctx, nodeID, SuspendedUptimeRatio)
+		}
+		if stats.SuspendedAt == nil || stats.SuspensionReason != SuspendedUptimeRatio {
+			return nil
+		}
+		return cache.db.UnsuspendNode(ctx, nodeID, SuspendedUptimeRatio)
+	}
+
+	return nil
+}
+
+// Disqualify disqualifies the node.
+func (cache *Cache) Disqualify(ctx context.Context, nodeID storj.NodeID, reason string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return cache.db.DisqualifyNode(ctx, nodeID, reason)
+}
+
+// Suspend suspends the node.
+func (cache *Cache) Suspend(ctx context.Context, nodeID storj.NodeID, reason string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return cache.db.SuspendNode(ctx, nodeID, reason)
+}
+
+// Unsuspend lifts the suspension of the node, regardless of its reason.
+func (cache *Cache) Unsuspend(ctx context.Context, nodeID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return cache.db.UnsuspendNode(ctx, nodeID, "")
+}
+
+// Reinstate clears disqualification and suspension of the node.
+func (cache *Cache) Reinstate(ctx context.Context, nodeID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	return cache.db.ReinstateNode(ctx, nodeID)
 }
 
 // ConnFailure implements the Transport Observer `ConnFailure` function
@@ -326,7 +592,8 @@ func (cache *Cache) ConnFailure(ctx context.Context, node *pb.Node, failureError
 	// TODO: Kademlia paper specifies 5 unsuccessful PINGs before removing the node
 	// from our routing table, but this is the cache so maybe we want to treat
 	// it differently.
-	_, err = cache.db.UpdateUptime(ctx, node.Id, false)
+	_, err = cache.db.UpdateUptime(ctx, node.Id, false,
+		cache.preferences.UptimeReputationLambda, cache.preferences.UptimeReputationWeight)
 	if err != nil {
 		zap.L().Debug("error updating uptime for node", zap.Error(err))
 	}
@@ -341,13 +608,14 @@ func (cache *Cache) ConnSuccess(ctx context.Context, node *pb.Node) {
 	if err != nil {
 		zap.L().Debug("error updating uptime for node", zap.Error(err))
 	}
-	_, err = cache.db.UpdateUptime(ctx, node.Id, true)
+	_, err = cache.db.UpdateUptime(ctx, node.Id, true,
+		cache.preferences.UptimeReputationLambda, cache.preferences.UptimeReputationWeight)
 	if err != nil {
 		zap.L().Debug("error updating node connection info", zap.Error(err))
 	}
 }
 
-// GetMissingPieces returns the list of offline nodes
+// GetMissingPieces returns the list of pieces stored on offline, unreliable or disqualified nodes
 func (cache *Cache) GetMissingPieces(ctx context.Context, pieces []*pb.RemotePiece) (missingPieces []int32, err error) {
 	var nodeIDs storj.NodeIDList
 	for _, p := range pieces {
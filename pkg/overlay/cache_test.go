@@ -44,7 +44,7 @@ func testCache(ctx context.Context, t *testing.T, store overlay.DB) {
 	_, _ = rand.Read(valid2ID[:])
 	_, _ = rand.Read(missingID[:])
 
-	cache := overlay.NewCache(zaptest.NewLogger(t), store, overlay.NodeSelectionConfig{OnlineWindow: time.Hour})
+	cache := overlay.NewCache(zaptest.NewLogger(t), store, overlay.NodeSelectionConfig{OnlineWindow: time.Hour}, nil)
 
 	{ // Put
 		err := cache.Put(ctx, valid1ID, pb.Node{Id: valid1ID, Address: address})
@@ -124,7 +124,7 @@ func TestRandomizedSelection(t *testing.T) {
 				Capacity: &pb.NodeCapacity{},
 			})
 			require.NoError(t, err)
-			_, err = cache.UpdateUptime(ctx, newID, true)
+			_, err = cache.UpdateUptime(ctx, newID, true, 1, 1)
 			require.NoError(t, err)
 			allIDs[i] = newID
 			nodeCounts[newID] = 0
@@ -209,3 +209,117 @@ func TestVetNode(t *testing.T) {
 		assert.False(t, reputable)
 	})
 }
+
+// unsuspendCounter counts the calls to UnsuspendNode
+type unsuspendCounter struct {
+	overlay.DB
+	calls int
+}
+
+func (db *unsuspendCounter) UnsuspendNode(ctx context.Context, nodeID storj.NodeID, reason string) error {
+	db.calls++
+	return db.DB.UnsuspendNode(ctx, nodeID, reason)
+}
+
+func TestNodeStatus(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		counter := &unsuspendCounter{DB: db.OverlayCache()}
+		cache := overlay.NewCache(zaptest.NewLogger(t), counter, overlay.NodeSelectionConfig{
+			OnlineWindow:         time.Hour,
+			AuditCount:           2,
+			UptimeCount:          2,
+			DisqualifyAuditRatio: 0.5,
+			SuspendUptimeRatio:   0.5,
+		}, nil)
+
+		nodeID := storj.NodeID{1}
+		err := cache.Put(ctx, nodeID, pb.Node{Id: nodeID, Address: &pb.NodeAddress{Address: "127.0.0.1:0"}})
+		require.NoError(t, err)
+
+		{ // suspended after going offline, unsuspended after recovering
+			for i := 0; i < 3; i++ {
+				_, err = cache.UpdateUptime(ctx, nodeID, false)
+				require.NoError(t, err)
+			}
+
+			node, err := cache.Get(ctx, nodeID)
+			require.NoError(t, err)
+			assert.True(t, node.IsSuspended())
+			assert.Equal(t, overlay.SuspendedUptimeRatio, node.Status.SuspensionReason)
+			assert.False(t, node.Disqualified)
+
+			for i := 0; i < 4; i++ {
+				_, err = cache.UpdateUptime(ctx, nodeID, true)
+				require.NoError(t, err)
+			}
+
+			node, err = cache.Get(ctx, nodeID)
+			require.NoError(t, err)
+			assert.False(t, node.IsSuspended())
+
+			// only the uptime check which recovered the node lifted the suspension
+			assert.Equal(t, 1, counter.calls)
+		}
+
+		{ // manual suspension isn't lifted by uptime checks
+			require.NoError(t, cache.Suspend(ctx, nodeID, "manual"))
+
+			_, err = cache.UpdateUptime(ctx, nodeID, true)
+			require.NoError(t, err)
+
+			node, err := cache.Get(ctx, nodeID)
+			require.NoError(t, err)
+			assert.True(t, node.IsSuspended())
+			assert.Equal(t, "manual", node.Status.SuspensionReason)
+
+			require.NoError(t, cache.Unsuspend(ctx, nodeID))
+		}
+
+		{ // disqualified after failing audits and not recovering
+			missing, err := cache.GetMissingPieces(ctx, []*pb.RemotePiece{{PieceNum: 0, NodeId: nodeID}})
+			require.NoError(t, err)
+			assert.Empty(t, missing)
+
+			for i := 0; i < 3; i++ {
+				_, err = cache.UpdateStats(ctx, &overlay.UpdateRequest{NodeID: nodeID, IsUp: true, AuditSuccess: false})
+				require.NoError(t, err)
+			}
+
+			node, err := cache.Get(ctx, nodeID)
+			require.NoError(t, err)
+			assert.True(t, node.Disqualified)
+			require.NotNil(t, node.Status.DisqualifiedAt)
+			assert.Equal(t, overlay.DisqualifiedAuditRatio, node.Status.DisqualificationReason)
+
+			for i := 0; i < 10; i++ {
+				_, err = cache.UpdateStats(ctx, &overlay.UpdateRequest{NodeID: nodeID, IsUp: true, AuditSuccess: true})
+				require.NoError(t, err)
+			}
+
+			node, err = cache.Get(ctx, nodeID)
+			require.NoError(t, err)
+			assert.True(t, node.Disqualified)
+
+			missing, err = cache.GetMissingPieces(ctx, []*pb.RemotePiece{{PieceNum: 0, NodeId: nodeID}})
+			require.NoError(t, err)
+			assert.Equal(t, []int32{0}, missing)
+		}
+
+		{ // reinstate
+			require.NoError(t, cache.Reinstate(ctx, nodeID))
+
+			node, err := cache.Get(ctx, nodeID)
+			require.NoError(t, err)
+			assert.False(t, node.Disqualified)
+			assert.Nil(t, node.Status.DisqualifiedAt)
+		}
+
+		{ // unknown node
+			err := cache.Disqualify(ctx, storj.NodeID{2}, "manual")
+			assert.True(t, overlay.ErrNodeNotFound.Has(err))
+		}
+	})
+}
@@ -22,7 +22,8 @@ var (
 // Config is a configuration struct for everything you need to start the
 // Overlay cache responsibility.
 type Config struct {
-	Node NodeSelectionConfig
+	Node      NodeSelectionConfig
+	Placement PlacementConfig
 }
 
 // LookupConfig is a configuration struct for querying the overlay cache with one or more node IDs
@@ -42,6 +43,20 @@ type NodeSelectionConfig struct {
 	MinimumVersion    string        `help:"the minimum node software version for node selection queries" default:""`
 	OnlineWindow      time.Duration `help:"the amount of time without seeing a node before its considered offline" default:"1h"`
 	DistinctIP        bool          `help:"require distinct IPs when choosing nodes for upload" releaseDefault:"true" devDefault:"false"`
+	DistinctSubnet    bool          `help:"require distinct /24 (ipv4) or /64 (ipv6) subnets when choosing nodes for upload" releaseDefault:"true" devDefault:"false"`
+
+	MaxPiecesPerCountry int `help:"the maximum number of pieces of a segment stored in one country, 0 disables" default:"0"`
+
+	ExplorationRatio  float64 `help:"the ratio of reputable nodes per request selected at random rather than preferring nodes with fast uploads" default:"0.25"`
+	UploadStatsLambda float64 `help:"the forgetting factor used to average the upload success ratio, long tail ratio and throughput of a node" default:"0.95"`
+
+	DisqualifyAuditRatio float64 `help:"audit success ratio below which a vetted node is disqualified, 0 disables" releaseDefault:"0.2" devDefault:"0"`
+	SuspendUptimeRatio   float64 `help:"uptime ratio below which a node is suspended until it recovers, 0 disables" releaseDefault:"0.6" devDefault:"0"`
+
+	AuditReputationLambda  float64 `help:"the forgetting factor used to calculate the audit reputation of a node" default:"0.95"`
+	AuditReputationWeight  float64 `help:"the normalization weight used to calculate the audit reputation of a node" default:"1.0"`
+	UptimeReputationLambda float64 `help:"the forgetting factor used to calculate the uptime reputation of a node" default:"0.99"`
+	UptimeReputationWeight float64 `help:"the normalization weight used to calculate the uptime reputation of a node" default:"1.0"`
 }
 
 // ParseIDs converts the base58check encoded node ID strings from the config into node IDs
@@ -6,6 +6,7 @@ package overlay
 import (
 	"context"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/zeebo/errs"
 
 	"storj.io/storj/pkg/pb"
@@ -45,11 +46,21 @@ func (srv *Inspector) GetStats(ctx context.Context, req *pb.GetStatsRequest) (*p
 		return nil, err
 	}
 
+	uploads, err := srv.cache.UploadStats(ctx, req.NodeId)
+	if err != nil {
+		return nil, err
+	}
+
 	return &pb.GetStatsResponse{
-		AuditCount:  node.Reputation.AuditCount,
-		AuditRatio:  node.Reputation.AuditSuccessRatio,
-		UptimeCount: node.Reputation.UptimeCount,
-		UptimeRatio: node.Reputation.UptimeRatio,
+		AuditCount:          node.Reputation.AuditCount,
+		AuditRatio:          node.Reputation.AuditSuccessRatio,
+		UptimeCount:         node.Reputation.UptimeCount,
+		UptimeRatio:         node.Reputation.UptimeRatio,
+		Latency_90:          node.Reputation.Latency90,
+		UploadCount:         uploads.Count,
+		UploadSuccessRatio:  uploads.SuccessRatio,
+		UploadLongTailRatio: uploads.LongTailRatio,
+		UploadThroughput:    uploads.Throughput,
 	}, nil
 }
 
@@ -69,3 +80,53 @@ func (srv *Inspector) CreateStats(ctx context.Context, req *pb.CreateStatsReques
 
 	return &pb.CreateStatsResponse{}, nil
 }
+
+// GetNodeStatus returns the disqualification and suspension status of a node
+func (srv *Inspector) GetNodeStatus(ctx context.Context, req *pb.GetNodeStatusRequest) (_ *pb.GetNodeStatusResponse, err error) {
+	node, err := srv.cache.Get(ctx, req.NodeId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetNodeStatusResponse{
+		Disqualified:           node.Disqualified,
+		DisqualificationReason: node.Status.DisqualificationReason,
+		Suspended:              node.IsSuspended(),
+		SuspensionReason:       node.Status.SuspensionReason,
+	}
+	if node.Status.DisqualifiedAt != nil {
+		resp.DisqualifiedAt, err = ptypes.TimestampProto(*node.Status.DisqualifiedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if node.Status.SuspendedAt != nil {
+		resp.SuspendedAt, err = ptypes.TimestampProto(*node.Status.SuspendedAt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// SetNodeStatus overrides the disqualification and suspension status of a node
+func (srv *Inspector) SetNodeStatus(ctx context.Context, req *pb.SetNodeStatusRequest) (_ *pb.SetNodeStatusResponse, err error) {
+	switch req.Action {
+	case pb.SetNodeStatusRequest_DISQUALIFY:
+		err = srv.cache.Disqualify(ctx, req.NodeId, req.Reason)
+	case pb.SetNodeStatusRequest_SUSPEND:
+		err = srv.cache.Suspend(ctx, req.NodeId, req.Reason)
+	case pb.SetNodeStatusRequest_UNSUSPEND:
+		err = srv.cache.Unsuspend(ctx, req.NodeId)
+	case pb.SetNodeStatusRequest_REINSTATE:
+		err = srv.cache.Reinstate(ctx, req.NodeId)
+	default:
+		err = errs.New("invalid node status action %v", req.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SetNodeStatusResponse{}, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/geoip"
+)
+
+// ErrUnknownPlacement is returned when a placement policy is not configured
+var ErrUnknownPlacement = errs.Class("unknown placement policy")
+
+// PlacementConfig configures where the pieces of a segment may be stored
+type PlacementConfig struct {
+	GeoIPDatabase string `help:"path to a csv file mapping ip networks to country codes, used to locate nodes" default:""`
+	Policies      string `help:"placement policies buckets can use, as name:country,country;name:country (e.g. eu:DE,FR,NL;us:US)" default:""`
+}
+
+// NodeLocation is where a node is located on the network
+type NodeLocation struct {
+	// Network is the /24 (ipv4) or /64 (ipv6) subnet of the node
+	Network string
+	// Country is the country code of the node, empty when unknown
+	Country string
+}
+
+// Placement locates nodes and contains the placement policies buckets can use
+type Placement struct {
+	geoip    *geoip.DB
+	policies map[string][]string
+}
+
+// NewPlacement loads the geoip database and parses the placement policies of config
+func NewPlacement(config PlacementConfig) (*Placement, error) {
+	placement := &Placement{}
+
+	if config.GeoIPDatabase != "" {
+		db, err := geoip.Open(config.GeoIPDatabase)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		placement.geoip = db
+	}
+
+	policies, err := ParsePolicies(config.Policies)
+	if err != nil {
+		return nil, err
+	}
+	placement.policies = policies
+
+	return placement, nil
+}
+
+// ParsePolicies parses placement policies in the form name:country,country;name:country
+func ParsePolicies(s string) (map[string][]string, error) {
+	policies := make(map[string][]string)
+	for _, policy := range strings.Split(s, ";") {
+		policy = strings.TrimSpace(policy)
+		if policy == "" {
+			continue
+		}
+
+		parts := strings.SplitN(policy, ":", 2)
+		name := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || name == "" {
+			return nil, Error.New("invalid placement policy %q", policy)
+		}
+		if _, exists := policies[name]; exists {
+			return nil, Error.New("placement policy %q defined twice", name)
+		}
+
+		var countries []string
+		for _, country := range strings.Split(parts[1], ",") {
+			country = strings.ToUpper(strings.TrimSpace(country))
+			if country != "" {
+				countries = append(countries, country)
+			}
+		}
+		if len(countries) == 0 {
+			return nil, Error.New("placement policy %q has no countries", name)
+		}
+		sort.Strings(countries)
+		policies[name] = countries
+	}
+	return policies, nil
+}
+
+// Countries returns the countries nodes must be located in for policy.
+// No policy allows all countries.
+func (placement *Placement) Countries(policy string) ([]string, error) {
+	if policy == "" {
+		return nil, nil
+	}
+	if placement != nil {
+		if countries, ok := placement.policies[policy]; ok {
+			return countries, nil
+		}
+	}
+	return nil, ErrUnknownPlacement.New("%q", policy)
+}
+
+// Locate returns the location of a node with the last ip
+func (placement *Placement) Locate(lastIP string) NodeLocation {
+	ip := net.ParseIP(lastIP)
+	if ip == nil {
+		return NodeLocation{}
+	}
+
+	location := NodeLocation{Network: Network(ip)}
+	if placement != nil && placement.geoip != nil {
+		location.Country = placement.geoip.Country(ip)
+	}
+	return location
+}
+
+// Network returns the /24 subnet of an ipv4 address or the /64 subnet of an
+// ipv6 address, which usually belong to the same operator
+func Network(ip net.IP) string {
+	mask := net.CIDRMask(64, 128)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, mask = ip4, net.CIDRMask(24, 32)
+	}
+	return (&net.IPNet{IP: ip.Mask(mask), Mask: mask}).String()
+}
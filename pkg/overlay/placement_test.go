@@ -0,0 +1,154 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestParsePolicies(t *testing.T) {
+	policies, err := overlay.ParsePolicies(" eu: de, FR ,nl ; us:US;")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"eu": {"DE", "FR", "NL"},
+		"us": {"US"},
+	}, policies)
+
+	for _, invalid := range []string{"eu", ":DE", "eu:", "eu:DE;eu:FR"} {
+		_, err := overlay.ParsePolicies(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestNetwork(t *testing.T) {
+	assert.Equal(t, "10.1.2.0/24", overlay.Network(net.ParseIP("10.1.2.3")))
+	assert.Equal(t, "10.1.2.0/24", overlay.Network(net.ParseIP("::ffff:10.1.2.3")))
+	assert.Equal(t, "2a02:2e0:1:2::/64", overlay.Network(net.ParseIP("2a02:2e0:1:2:3:4:5:6")))
+}
+
+func TestPlacement(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		geoip := ctx.File("geoip.csv")
+		require.NoError(t, ioutil.WriteFile(geoip, []byte("10.1.0.0/16,DE\n10.2.0.0/16,FR\n10.3.0.0/16,US\n"), 0644))
+
+		placement, err := overlay.NewPlacement(overlay.PlacementConfig{
+			GeoIPDatabase: geoip,
+			Policies:      "eu:DE,FR",
+		})
+		require.NoError(t, err)
+
+		cache := overlay.NewCache(zaptest.NewLogger(t), db.OverlayCache(), overlay.NodeSelectionConfig{
+			OnlineWindow:        time.Hour,
+			DistinctSubnet:      true,
+			MaxPiecesPerCountry: 2,
+		}, placement)
+
+		// two nodes in each of three subnets in each country
+		countries := map[storj.NodeID]string{}
+		for i, country := range []string{"DE", "FR", "US"} {
+			for subnet := 0; subnet < 3; subnet++ {
+				for host := 1; host <= 2; host++ {
+					id := storj.NodeID{byte(i + 1), byte(subnet), byte(host)}
+					address := fmt.Sprintf("10.%d.%d.%d:7777", i+1, subnet, host)
+					require.NoError(t, cache.Put(ctx, id, pb.Node{Id: id, Address: &pb.NodeAddress{Address: address}}))
+
+					_, err := cache.UpdateNodeInfo(ctx, id, &pb.InfoResponse{
+						Type:     pb.NodeType_STORAGE,
+						Capacity: &pb.NodeCapacity{FreeBandwidth: 1000, FreeDisk: 1000},
+					})
+					require.NoError(t, err)
+					countries[id] = country
+				}
+			}
+		}
+
+		checkPlacement := func(nodes []*pb.Node, existing ...storj.NodeID) map[string]int {
+			perCountry := map[string]int{}
+			networks := map[string]bool{}
+			for _, id := range existing {
+				perCountry[countries[id]]++
+			}
+			for _, node := range nodes {
+				perCountry[countries[node.Id]]++
+
+				network := overlay.Network(net.ParseIP(node.LastIp))
+				assert.False(t, networks[network], "subnet %s selected twice", network)
+				networks[network] = true
+			}
+			for country, count := range perCountry {
+				assert.True(t, count <= 2, "%d pieces in %s", count, country)
+			}
+			return perCountry
+		}
+
+		{ // without a policy pieces are spread over all countries
+			nodes, err := cache.FindStorageNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 6})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]int{"DE": 2, "FR": 2, "US": 2}, checkPlacement(nodes))
+		}
+
+		{ // the policy restricts the countries
+			nodes, err := cache.FindStorageNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 4, Placement: "eu"})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]int{"DE": 2, "FR": 2}, checkPlacement(nodes))
+
+			_, err = cache.FindStorageNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 5, Placement: "eu"})
+			assert.True(t, overlay.ErrNotEnoughNodes.Has(err))
+		}
+
+		{ // existing pieces count towards the maximum per country
+			existing := []storj.NodeID{{1, 0, 1}, {1, 1, 1}}
+			nodes, err := cache.FindStorageNodes(ctx, overlay.FindStorageNodesRequest{
+				RequestedCount: 2,
+				Placement:      "eu",
+				ExcludedNodes:  existing,
+				ExistingNodes:  existing,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]int{"DE": 2, "FR": 2}, checkPlacement(nodes, existing...))
+		}
+
+		{ // unknown policies are refused
+			_, err := cache.FindStorageNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 1, Placement: "mars"})
+			assert.True(t, overlay.ErrUnknownPlacement.Has(err))
+		}
+
+		{ // bucket placement
+			projectID, err := uuid.New()
+			require.NoError(t, err)
+			bucket := []byte("bucket")
+
+			assert.Error(t, cache.SetBucketPlacement(ctx, *projectID, bucket, "mars"))
+
+			require.NoError(t, cache.SetBucketPlacement(ctx, *projectID, bucket, "eu"))
+			policy, err := cache.BucketPlacement(ctx, *projectID, bucket)
+			require.NoError(t, err)
+			assert.Equal(t, "eu", policy)
+
+			require.NoError(t, cache.SetBucketPlacement(ctx, *projectID, bucket, ""))
+			policy, err = cache.BucketPlacement(ctx, *projectID, bucket)
+			require.NoError(t, err)
+			assert.Equal(t, "", policy)
+		}
+	})
+}
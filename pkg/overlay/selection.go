@@ -0,0 +1,80 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// selection tracks the nodes chosen for a segment so far, to exclude nodes
+// that would violate the placement constraints from further queries
+type selection struct {
+	preferences *NodeSelectionConfig
+
+	excludedNodes     []storj.NodeID
+	excludedIPs       []string
+	excludedNetworks  []string
+	excludedCountries []string
+
+	networks         map[string]bool
+	piecesPerCountry map[string]int
+}
+
+func newSelection(preferences *NodeSelectionConfig, excludedNodes []storj.NodeID) *selection {
+	return &selection{
+		preferences:      preferences,
+		excludedNodes:    append([]storj.NodeID(nil), excludedNodes...),
+		networks:         make(map[string]bool),
+		piecesPerCountry: make(map[string]int),
+	}
+}
+
+// apply sets the exclusions of the selection on criteria
+func (selection *selection) apply(criteria *NodeCriteria) {
+	criteria.ExcludedNodes = selection.excludedNodes
+	criteria.ExcludedIPs = selection.excludedIPs
+	criteria.ExcludedNetworks = selection.excludedNetworks
+	criteria.ExcludedCountries = selection.excludedCountries
+}
+
+// add includes node unless its subnet was already selected or its country
+// already stores the maximum number of pieces
+func (selection *selection) add(node *pb.Node, location NodeLocation) bool {
+	if selection.preferences.DistinctSubnet && selection.networks[location.Network] {
+		return false
+	}
+	if selection.countryFull(location.Country) {
+		return false
+	}
+	selection.include(node, location)
+	return true
+}
+
+// include excludes node, its ip and its network from further queries and
+// counts it towards the pieces stored in its country
+func (selection *selection) include(node *pb.Node, location NodeLocation) {
+	selection.excludedNodes = append(selection.excludedNodes, node.Id)
+	if selection.preferences.DistinctIP {
+		selection.excludedIPs = append(selection.excludedIPs, node.LastIp)
+	}
+	if selection.preferences.DistinctSubnet && location.Network != "" && !selection.networks[location.Network] {
+		selection.networks[location.Network] = true
+		selection.excludedNetworks = append(selection.excludedNetworks, location.Network)
+	}
+
+	if location.Country == "" || selection.preferences.MaxPiecesPerCountry <= 0 {
+		return
+	}
+	selection.piecesPerCountry[location.Country]++
+	if selection.piecesPerCountry[location.Country] == selection.preferences.MaxPiecesPerCountry {
+		selection.excludedCountries = append(selection.excludedCountries, location.Country)
+	}
+}
+
+// countryFull returns whether country stores the maximum number of pieces
+func (selection *selection) countryFull(country string) bool {
+	max := selection.preferences.MaxPiecesPerCountry
+	return country != "" && max > 0 && selection.piecesPerCountry[country] >= max
+}
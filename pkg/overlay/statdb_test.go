@@ -5,6 +5,7 @@ package overlay_test
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 
@@ -228,7 +229,7 @@ func testDatabase(ctx context.Context, t *testing.T, cache overlay.DB) {
 		assert.EqualValues(t, currUptimeSuccess, node.Reputation.UptimeSuccessCount)
 		assert.EqualValues(t, uptimeRatio, node.Reputation.UptimeRatio)
 
-		stats, err := cache.UpdateUptime(ctx, nodeID, false)
+		stats, err := cache.UpdateUptime(ctx, nodeID, false, 1, 1)
 		require.NoError(t, err)
 
 		currUptimeCount++
@@ -267,3 +268,46 @@ func testDatabase(ctx context.Context, t *testing.T, cache overlay.DB) {
 		assert.EqualValues(t, newUptimeRatio, stats.UptimeRatio)
 	}
 }
+
+func TestReputationForgetting(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		cache := db.OverlayCache()
+		nodeID := storj.NodeID{1}
+
+		err := cache.UpdateAddress(ctx, &pb.Node{Id: nodeID})
+		require.NoError(t, err)
+
+		_, err = cache.CreateStats(ctx, nodeID, &overlay.NodeStats{
+			AuditSuccessCount: 100,
+			AuditCount:        100,
+		})
+		require.NoError(t, err)
+
+		var stats *overlay.NodeStats
+		for i := 0; i < 10; i++ {
+			stats, err = cache.UpdateStats(ctx, &overlay.UpdateRequest{
+				NodeID:       nodeID,
+				AuditSuccess: false,
+				IsUp:         true,
+				AuditLambda:  0.9,
+				AuditWeight:  1,
+			})
+			require.NoError(t, err)
+		}
+
+		// the reputation score falls below the lifetime ratio since
+		// most of the good history is forgotten
+		assert.EqualValues(t, 100, stats.AuditSuccessCount)
+		assert.EqualValues(t, 110, stats.AuditCount)
+		assert.InDelta(t, 100*math.Pow(0.9, 10), stats.AuditReputationAlpha, 1e-9)
+		assert.InDelta(t, (1-math.Pow(0.9, 10))/(1-0.9), stats.AuditReputationBeta, 1e-9)
+		assert.True(t, stats.AuditSuccessRatio < 100.0/110.0)
+
+		node, err := cache.Get(ctx, nodeID)
+		require.NoError(t, err)
+		assert.Equal(t, stats.AuditSuccessRatio, node.Reputation.AuditSuccessRatio)
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestUploadStats(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		preferences := overlay.NodeSelectionConfig{
+			OnlineWindow:      time.Hour,
+			UploadStatsLambda: 0.5,
+		}
+		cache := overlay.NewCache(zaptest.NewLogger(t), db.OverlayCache(), preferences, nil)
+
+		var ids []storj.NodeID
+		for i := 0; i < 10; i++ {
+			id := storj.NodeID{byte(i + 1)}
+			address := fmt.Sprintf("127.0.0.%d:7777", i+1)
+			require.NoError(t, cache.Put(ctx, id, pb.Node{Id: id, Address: &pb.NodeAddress{Address: address}}))
+
+			_, err := cache.UpdateNodeInfo(ctx, id, &pb.InfoResponse{
+				Type:     pb.NodeType_STORAGE,
+				Capacity: &pb.NodeCapacity{FreeBandwidth: 1000, FreeDisk: 1000},
+			})
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		fast, slow := ids[0], ids[1]
+
+		{ // nodes without uploads have no stats
+			stats, err := cache.UploadStats(ctx, fast)
+			require.NoError(t, err)
+			assert.Equal(t, &overlay.UploadStats{}, stats)
+		}
+
+		require.NoError(t, cache.UpdateUploadStats(ctx, []*pb.PieceUploadStats{
+			{NodeId: fast, Status: pb.PieceUploadStats_SUCCEEDED, PieceSize: 1000, DurationMs: 100},
+			{NodeId: slow, Status: pb.PieceUploadStats_SUCCEEDED, PieceSize: 1000, DurationMs: 1000},
+		}))
+		require.NoError(t, cache.UpdateUploadStats(ctx, []*pb.PieceUploadStats{
+			{NodeId: fast, Status: pb.PieceUploadStats_SUCCEEDED, PieceSize: 1000, DurationMs: 50},
+			{NodeId: slow, Status: pb.PieceUploadStats_LONG_TAIL_CANCELED, PieceSize: 500, DurationMs: 1000},
+		}))
+
+		{ // the ratios and the throughput are averaged
+			stats, err := cache.UploadStats(ctx, fast)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), stats.Count)
+			assert.Equal(t, 1.0, stats.SuccessRatio)
+			assert.Equal(t, 0.0, stats.LongTailRatio)
+			assert.InDelta(t, 15000, stats.Throughput, 0.001)
+
+			stats, err = cache.UploadStats(ctx, slow)
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), stats.Count)
+			assert.Equal(t, 0.5, stats.SuccessRatio)
+			assert.Equal(t, 0.5, stats.LongTailRatio)
+			assert.InDelta(t, 1000, stats.Throughput, 0.001)
+		}
+
+		{ // the latency follows the duration of successful uploads
+			node, err := cache.Get(ctx, fast)
+			require.NoError(t, err)
+			assert.True(t, node.Reputation.Latency90 > 50 && node.Reputation.Latency90 < 100, node.Reputation.Latency90)
+
+			node, err = cache.Get(ctx, slow)
+			require.NoError(t, err)
+			assert.Equal(t, int64(1000), node.Reputation.Latency90)
+		}
+
+		{ // without exploration the fast nodes are preferred
+			for i := 0; i < 10; i++ {
+				nodes, err := cache.FindStorageNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 2})
+				require.NoError(t, err)
+				require.Len(t, nodes, 2)
+
+				selected := map[storj.NodeID]bool{nodes[0].Id: true, nodes[1].Id: true}
+				assert.True(t, selected[fast] && selected[slow], "selected %v", nodes)
+			}
+		}
+
+		{ // with full exploration nodes are selected at random
+			preferences.ExplorationRatio = 1
+			cache := overlay.NewCache(zaptest.NewLogger(t), db.OverlayCache(), preferences, nil)
+
+			selected := map[storj.NodeID]bool{}
+			for i := 0; i < 20; i++ {
+				nodes, err := cache.FindStorageNodes(ctx, overlay.FindStorageNodesRequest{RequestedCount: 2})
+				require.NoError(t, err)
+				for _, node := range nodes {
+					selected[node.Id] = true
+				}
+			}
+			assert.True(t, len(selected) > 2, "selected %v", selected)
+		}
+	})
+}
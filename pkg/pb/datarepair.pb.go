@@ -24,6 +24,7 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type InjuredSegment struct {
 	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
 	LostPieces           []int32  `protobuf:"varint,2,rep,packed,name=lost_pieces,json=lostPieces,proto3" json:"lost_pieces,omitempty"`
+	NumHealthyPieces     int32    `protobuf:"varint,3,opt,name=num_healthy_pieces,json=numHealthyPieces,proto3" json:"num_healthy_pieces,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -67,6 +68,13 @@ func (m *InjuredSegment) GetLostPieces() []int32 {
 	return nil
 }
 
+func (m *InjuredSegment) GetNumHealthyPieces() int32 {
+	if m != nil {
+		return m.NumHealthyPieces
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*InjuredSegment)(nil), "repair.InjuredSegment")
 }
@@ -74,13 +82,15 @@ func init() {
 func init() { proto.RegisterFile("datarepair.proto", fileDescriptor_b1b08e6fe9398aa6) }
 
 var fileDescriptor_b1b08e6fe9398aa6 = []byte{
-	// 119 bytes of a gzipped FileDescriptorProto
+	// 148 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x48, 0x49, 0x2c, 0x49,
 	0x2c, 0x4a, 0x2d, 0x48, 0xcc, 0x2c, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x83, 0xf0,
-	0x94, 0x5c, 0xb9, 0xf8, 0x3c, 0xf3, 0xb2, 0x4a, 0x8b, 0x52, 0x53, 0x82, 0x53, 0xd3, 0x73, 0x53,
+	0x94, 0x8a, 0xb9, 0xf8, 0x3c, 0xf3, 0xb2, 0x4a, 0x8b, 0x52, 0x53, 0x82, 0x53, 0xd3, 0x73, 0x53,
 	0xf3, 0x4a, 0x84, 0x84, 0xb8, 0x58, 0x0a, 0x12, 0x4b, 0x32, 0x24, 0x18, 0x15, 0x18, 0x35, 0x38,
 	0x83, 0xc0, 0x6c, 0x21, 0x79, 0x2e, 0xee, 0x9c, 0xfc, 0xe2, 0x92, 0xf8, 0x82, 0xcc, 0xd4, 0xe4,
-	0xd4, 0x62, 0x09, 0x26, 0x05, 0x66, 0x0d, 0xd6, 0x20, 0x2e, 0x90, 0x50, 0x00, 0x58, 0xc4, 0x89,
-	0x25, 0x8a, 0xa9, 0x20, 0x29, 0x89, 0x0d, 0x6c, 0xb6, 0x31, 0x20, 0x00, 0x00, 0xff, 0xff, 0x13,
-	0xff, 0xff, 0x1e, 0x6f, 0x00, 0x00, 0x00,
+	0xd4, 0x62, 0x09, 0x26, 0x05, 0x66, 0x0d, 0xd6, 0x20, 0x2e, 0x90, 0x50, 0x00, 0x58, 0x44, 0x48,
+	0x87, 0x4b, 0x28, 0xaf, 0x34, 0x37, 0x3e, 0x23, 0x35, 0x31, 0xa7, 0x24, 0xa3, 0x12, 0xa6, 0x8e,
+	0x59, 0x81, 0x51, 0x83, 0x35, 0x48, 0x20, 0xaf, 0x34, 0xd7, 0x03, 0x22, 0x01, 0x51, 0xed, 0xc4,
+	0x12, 0xc5, 0x54, 0x90, 0x94, 0xc4, 0x06, 0x76, 0x89, 0x31, 0x60, 0x00, 0xd5, 0x8c, 0xf9, 0x0c,
+	0x9d, 0x00, 0x00, 0x00,
 }
@@ -25,6 +25,40 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type SetNodeStatusRequest_Action int32
+
+const (
+	SetNodeStatusRequest_INVALID    SetNodeStatusRequest_Action = 0
+	SetNodeStatusRequest_DISQUALIFY SetNodeStatusRequest_Action = 1
+	SetNodeStatusRequest_SUSPEND    SetNodeStatusRequest_Action = 2
+	SetNodeStatusRequest_UNSUSPEND  SetNodeStatusRequest_Action = 3
+	SetNodeStatusRequest_REINSTATE  SetNodeStatusRequest_Action = 4
+)
+
+var SetNodeStatusRequest_Action_name = map[int32]string{
+	0: "INVALID",
+	1: "DISQUALIFY",
+	2: "SUSPEND",
+	3: "UNSUSPEND",
+	4: "REINSTATE",
+}
+
+var SetNodeStatusRequest_Action_value = map[string]int32{
+	"INVALID":    0,
+	"DISQUALIFY": 1,
+	"SUSPEND":    2,
+	"UNSUSPEND":  3,
+	"REINSTATE":  4,
+}
+
+func (x SetNodeStatusRequest_Action) String() string {
+	return proto.EnumName(SetNodeStatusRequest_Action_name, int32(x))
+}
+
+func (SetNodeStatusRequest_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{12, 0}
+}
+
 // ListSegments
 type ListIrreparableSegmentsRequest struct {
 	Limit                int32    `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
@@ -180,6 +214,129 @@ func (m *ListIrreparableSegmentsResponse) GetSegments() []*IrreparableSegment {
 	return nil
 }
 
+// QueueDepth
+type QueueDepthRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueueDepthRequest) Reset()         { *m = QueueDepthRequest{} }
+func (m *QueueDepthRequest) String() string { return proto.CompactTextString(m) }
+func (*QueueDepthRequest) ProtoMessage()    {}
+func (*QueueDepthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{3}
+}
+func (m *QueueDepthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueueDepthRequest.Unmarshal(m, b)
+}
+func (m *QueueDepthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueueDepthRequest.Marshal(b, m, deterministic)
+}
+func (m *QueueDepthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueueDepthRequest.Merge(m, src)
+}
+func (m *QueueDepthRequest) XXX_Size() int {
+	return xxx_messageInfo_QueueDepthRequest.Size(m)
+}
+func (m *QueueDepthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueueDepthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueueDepthRequest proto.InternalMessageInfo
+
+type QueueDepthBucket struct {
+	NumHealthyPieces     int32    `protobuf:"varint,1,opt,name=num_healthy_pieces,json=numHealthyPieces,proto3" json:"num_healthy_pieces,omitempty"`
+	Count                int64    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Leased               int64    `protobuf:"varint,3,opt,name=leased,proto3" json:"leased,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QueueDepthBucket) Reset()         { *m = QueueDepthBucket{} }
+func (m *QueueDepthBucket) String() string { return proto.CompactTextString(m) }
+func (*QueueDepthBucket) ProtoMessage()    {}
+func (*QueueDepthBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{4}
+}
+func (m *QueueDepthBucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueueDepthBucket.Unmarshal(m, b)
+}
+func (m *QueueDepthBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueueDepthBucket.Marshal(b, m, deterministic)
+}
+func (m *QueueDepthBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueueDepthBucket.Merge(m, src)
+}
+func (m *QueueDepthBucket) XXX_Size() int {
+	return xxx_messageInfo_QueueDepthBucket.Size(m)
+}
+func (m *QueueDepthBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueueDepthBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueueDepthBucket proto.InternalMessageInfo
+
+func (m *QueueDepthBucket) GetNumHealthyPieces() int32 {
+	if m != nil {
+		return m.NumHealthyPieces
+	}
+	return 0
+}
+
+func (m *QueueDepthBucket) GetCount() int64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *QueueDepthBucket) GetLeased() int64 {
+	if m != nil {
+		return m.Leased
+	}
+	return 0
+}
+
+type QueueDepthResponse struct {
+	Buckets              []*QueueDepthBucket `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *QueueDepthResponse) Reset()         { *m = QueueDepthResponse{} }
+func (m *QueueDepthResponse) String() string { return proto.CompactTextString(m) }
+func (*QueueDepthResponse) ProtoMessage()    {}
+func (*QueueDepthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{5}
+}
+func (m *QueueDepthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QueueDepthResponse.Unmarshal(m, b)
+}
+func (m *QueueDepthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QueueDepthResponse.Marshal(b, m, deterministic)
+}
+func (m *QueueDepthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueueDepthResponse.Merge(m, src)
+}
+func (m *QueueDepthResponse) XXX_Size() int {
+	return xxx_messageInfo_QueueDepthResponse.Size(m)
+}
+func (m *QueueDepthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueueDepthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueueDepthResponse proto.InternalMessageInfo
+
+func (m *QueueDepthResponse) GetBuckets() []*QueueDepthBucket {
+	if m != nil {
+		return m.Buckets
+	}
+	return nil
+}
+
 // GetStats
 type GetStatsRequest struct {
 	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
@@ -192,7 +349,7 @@ func (m *GetStatsRequest) Reset()         { *m = GetStatsRequest{} }
 func (m *GetStatsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetStatsRequest) ProtoMessage()    {}
 func (*GetStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{3}
+	return fileDescriptor_a07d9034b2dd9d26, []int{6}
 }
 func (m *GetStatsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStatsRequest.Unmarshal(m, b)
@@ -217,6 +374,11 @@ type GetStatsResponse struct {
 	AuditRatio           float64  `protobuf:"fixed64,2,opt,name=audit_ratio,json=auditRatio,proto3" json:"audit_ratio,omitempty"`
 	UptimeCount          int64    `protobuf:"varint,3,opt,name=uptime_count,json=uptimeCount,proto3" json:"uptime_count,omitempty"`
 	UptimeRatio          float64  `protobuf:"fixed64,4,opt,name=uptime_ratio,json=uptimeRatio,proto3" json:"uptime_ratio,omitempty"`
+	Latency_90           int64    `protobuf:"varint,5,opt,name=latency_90,json=latency90,proto3" json:"latency_90,omitempty"`
+	UploadCount          int64    `protobuf:"varint,6,opt,name=upload_count,json=uploadCount,proto3" json:"upload_count,omitempty"`
+	UploadSuccessRatio   float64  `protobuf:"fixed64,7,opt,name=upload_success_ratio,json=uploadSuccessRatio,proto3" json:"upload_success_ratio,omitempty"`
+	UploadLongTailRatio  float64  `protobuf:"fixed64,8,opt,name=upload_long_tail_ratio,json=uploadLongTailRatio,proto3" json:"upload_long_tail_ratio,omitempty"`
+	UploadThroughput     float64  `protobuf:"fixed64,9,opt,name=upload_throughput,json=uploadThroughput,proto3" json:"upload_throughput,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -226,7 +388,7 @@ func (m *GetStatsResponse) Reset()         { *m = GetStatsResponse{} }
 func (m *GetStatsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetStatsResponse) ProtoMessage()    {}
 func (*GetStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{4}
+	return fileDescriptor_a07d9034b2dd9d26, []int{7}
 }
 func (m *GetStatsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStatsResponse.Unmarshal(m, b)
@@ -274,6 +436,41 @@ func (m *GetStatsResponse) GetUptimeRatio() float64 {
 	return 0
 }
 
+func (m *GetStatsResponse) GetLatency_90() int64 {
+	if m != nil {
+		return m.Latency_90
+	}
+	return 0
+}
+
+func (m *GetStatsResponse) GetUploadCount() int64 {
+	if m != nil {
+		return m.UploadCount
+	}
+	return 0
+}
+
+func (m *GetStatsResponse) GetUploadSuccessRatio() float64 {
+	if m != nil {
+		return m.UploadSuccessRatio
+	}
+	return 0
+}
+
+func (m *GetStatsResponse) GetUploadLongTailRatio() float64 {
+	if m != nil {
+		return m.UploadLongTailRatio
+	}
+	return 0
+}
+
+func (m *GetStatsResponse) GetUploadThroughput() float64 {
+	if m != nil {
+		return m.UploadThroughput
+	}
+	return 0
+}
+
 // CreateStats
 type CreateStatsRequest struct {
 	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
@@ -290,7 +487,7 @@ func (m *CreateStatsRequest) Reset()         { *m = CreateStatsRequest{} }
 func (m *CreateStatsRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateStatsRequest) ProtoMessage()    {}
 func (*CreateStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{5}
+	return fileDescriptor_a07d9034b2dd9d26, []int{8}
 }
 func (m *CreateStatsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateStatsRequest.Unmarshal(m, b)
@@ -348,7 +545,7 @@ func (m *CreateStatsResponse) Reset()         { *m = CreateStatsResponse{} }
 func (m *CreateStatsResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateStatsResponse) ProtoMessage()    {}
 func (*CreateStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{6}
+	return fileDescriptor_a07d9034b2dd9d26, []int{9}
 }
 func (m *CreateStatsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateStatsResponse.Unmarshal(m, b)
@@ -368,6 +565,194 @@ func (m *CreateStatsResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CreateStatsResponse proto.InternalMessageInfo
 
+// GetNodeStatus
+type GetNodeStatusRequest struct {
+	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetNodeStatusRequest) Reset()         { *m = GetNodeStatusRequest{} }
+func (m *GetNodeStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetNodeStatusRequest) ProtoMessage()    {}
+func (*GetNodeStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{10}
+}
+func (m *GetNodeStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeStatusRequest.Unmarshal(m, b)
+}
+func (m *GetNodeStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetNodeStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeStatusRequest.Merge(m, src)
+}
+func (m *GetNodeStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetNodeStatusRequest.Size(m)
+}
+func (m *GetNodeStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeStatusRequest proto.InternalMessageInfo
+
+type GetNodeStatusResponse struct {
+	Disqualified           bool                 `protobuf:"varint,1,opt,name=disqualified,proto3" json:"disqualified,omitempty"`
+	DisqualifiedAt         *timestamp.Timestamp `protobuf:"bytes,2,opt,name=disqualified_at,json=disqualifiedAt,proto3" json:"disqualified_at,omitempty"`
+	DisqualificationReason string               `protobuf:"bytes,3,opt,name=disqualification_reason,json=disqualificationReason,proto3" json:"disqualification_reason,omitempty"`
+	Suspended              bool                 `protobuf:"varint,4,opt,name=suspended,proto3" json:"suspended,omitempty"`
+	SuspendedAt            *timestamp.Timestamp `protobuf:"bytes,5,opt,name=suspended_at,json=suspendedAt,proto3" json:"suspended_at,omitempty"`
+	SuspensionReason       string               `protobuf:"bytes,6,opt,name=suspension_reason,json=suspensionReason,proto3" json:"suspension_reason,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{}             `json:"-"`
+	XXX_unrecognized       []byte               `json:"-"`
+	XXX_sizecache          int32                `json:"-"`
+}
+
+func (m *GetNodeStatusResponse) Reset()         { *m = GetNodeStatusResponse{} }
+func (m *GetNodeStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*GetNodeStatusResponse) ProtoMessage()    {}
+func (*GetNodeStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{11}
+}
+func (m *GetNodeStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetNodeStatusResponse.Unmarshal(m, b)
+}
+func (m *GetNodeStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetNodeStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *GetNodeStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetNodeStatusResponse.Merge(m, src)
+}
+func (m *GetNodeStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_GetNodeStatusResponse.Size(m)
+}
+func (m *GetNodeStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetNodeStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetNodeStatusResponse proto.InternalMessageInfo
+
+func (m *GetNodeStatusResponse) GetDisqualified() bool {
+	if m != nil {
+		return m.Disqualified
+	}
+	return false
+}
+
+func (m *GetNodeStatusResponse) GetDisqualifiedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DisqualifiedAt
+	}
+	return nil
+}
+
+func (m *GetNodeStatusResponse) GetDisqualificationReason() string {
+	if m != nil {
+		return m.DisqualificationReason
+	}
+	return ""
+}
+
+func (m *GetNodeStatusResponse) GetSuspended() bool {
+	if m != nil {
+		return m.Suspended
+	}
+	return false
+}
+
+func (m *GetNodeStatusResponse) GetSuspendedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.SuspendedAt
+	}
+	return nil
+}
+
+func (m *GetNodeStatusResponse) GetSuspensionReason() string {
+	if m != nil {
+		return m.SuspensionReason
+	}
+	return ""
+}
+
+// SetNodeStatus
+type SetNodeStatusRequest struct {
+	NodeId               NodeID                      `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Action               SetNodeStatusRequest_Action `protobuf:"varint,2,opt,name=action,proto3,enum=inspector.SetNodeStatusRequest_Action" json:"action,omitempty"`
+	Reason               string                      `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *SetNodeStatusRequest) Reset()         { *m = SetNodeStatusRequest{} }
+func (m *SetNodeStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*SetNodeStatusRequest) ProtoMessage()    {}
+func (*SetNodeStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{12}
+}
+func (m *SetNodeStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNodeStatusRequest.Unmarshal(m, b)
+}
+func (m *SetNodeStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNodeStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *SetNodeStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNodeStatusRequest.Merge(m, src)
+}
+func (m *SetNodeStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_SetNodeStatusRequest.Size(m)
+}
+func (m *SetNodeStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNodeStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNodeStatusRequest proto.InternalMessageInfo
+
+func (m *SetNodeStatusRequest) GetAction() SetNodeStatusRequest_Action {
+	if m != nil {
+		return m.Action
+	}
+	return SetNodeStatusRequest_INVALID
+}
+
+func (m *SetNodeStatusRequest) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+type SetNodeStatusResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetNodeStatusResponse) Reset()         { *m = SetNodeStatusResponse{} }
+func (m *SetNodeStatusResponse) String() string { return proto.CompactTextString(m) }
+func (*SetNodeStatusResponse) ProtoMessage()    {}
+func (*SetNodeStatusResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{13}
+}
+func (m *SetNodeStatusResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetNodeStatusResponse.Unmarshal(m, b)
+}
+func (m *SetNodeStatusResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetNodeStatusResponse.Marshal(b, m, deterministic)
+}
+func (m *SetNodeStatusResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetNodeStatusResponse.Merge(m, src)
+}
+func (m *SetNodeStatusResponse) XXX_Size() int {
+	return xxx_messageInfo_SetNodeStatusResponse.Size(m)
+}
+func (m *SetNodeStatusResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetNodeStatusResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetNodeStatusResponse proto.InternalMessageInfo
+
 // CountNodes
 type CountNodesResponse struct {
 	Count                int64    `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
@@ -380,7 +765,7 @@ func (m *CountNodesResponse) Reset()         { *m = CountNodesResponse{} }
 func (m *CountNodesResponse) String() string { return proto.CompactTextString(m) }
 func (*CountNodesResponse) ProtoMessage()    {}
 func (*CountNodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{7}
+	return fileDescriptor_a07d9034b2dd9d26, []int{14}
 }
 func (m *CountNodesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CountNodesResponse.Unmarshal(m, b)
@@ -417,7 +802,7 @@ func (m *CountNodesRequest) Reset()         { *m = CountNodesRequest{} }
 func (m *CountNodesRequest) String() string { return proto.CompactTextString(m) }
 func (*CountNodesRequest) ProtoMessage()    {}
 func (*CountNodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{8}
+	return fileDescriptor_a07d9034b2dd9d26, []int{15}
 }
 func (m *CountNodesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CountNodesRequest.Unmarshal(m, b)
@@ -447,7 +832,7 @@ func (m *GetBucketListRequest) Reset()         { *m = GetBucketListRequest{} }
 func (m *GetBucketListRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBucketListRequest) ProtoMessage()    {}
 func (*GetBucketListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{9}
+	return fileDescriptor_a07d9034b2dd9d26, []int{16}
 }
 func (m *GetBucketListRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBucketListRequest.Unmarshal(m, b)
@@ -478,7 +863,7 @@ func (m *GetBucketListResponse) Reset()         { *m = GetBucketListResponse{} }
 func (m *GetBucketListResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBucketListResponse) ProtoMessage()    {}
 func (*GetBucketListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{10}
+	return fileDescriptor_a07d9034b2dd9d26, []int{17}
 }
 func (m *GetBucketListResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBucketListResponse.Unmarshal(m, b)
@@ -518,7 +903,7 @@ func (m *GetBucketListResponse_Bucket) Reset()         { *m = GetBucketListRespo
 func (m *GetBucketListResponse_Bucket) String() string { return proto.CompactTextString(m) }
 func (*GetBucketListResponse_Bucket) ProtoMessage()    {}
 func (*GetBucketListResponse_Bucket) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{10, 0}
+	return fileDescriptor_a07d9034b2dd9d26, []int{17, 0}
 }
 func (m *GetBucketListResponse_Bucket) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBucketListResponse_Bucket.Unmarshal(m, b)
@@ -563,7 +948,7 @@ func (m *GetBucketsRequest) Reset()         { *m = GetBucketsRequest{} }
 func (m *GetBucketsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBucketsRequest) ProtoMessage()    {}
 func (*GetBucketsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{11}
+	return fileDescriptor_a07d9034b2dd9d26, []int{18}
 }
 func (m *GetBucketsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBucketsRequest.Unmarshal(m, b)
@@ -595,7 +980,7 @@ func (m *GetBucketsResponse) Reset()         { *m = GetBucketsResponse{} }
 func (m *GetBucketsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBucketsResponse) ProtoMessage()    {}
 func (*GetBucketsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{12}
+	return fileDescriptor_a07d9034b2dd9d26, []int{19}
 }
 func (m *GetBucketsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBucketsResponse.Unmarshal(m, b)
@@ -634,7 +1019,7 @@ func (m *GetBucketRequest) Reset()         { *m = GetBucketRequest{} }
 func (m *GetBucketRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBucketRequest) ProtoMessage()    {}
 func (*GetBucketRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{13}
+	return fileDescriptor_a07d9034b2dd9d26, []int{20}
 }
 func (m *GetBucketRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBucketRequest.Unmarshal(m, b)
@@ -666,7 +1051,7 @@ func (m *GetBucketResponse) Reset()         { *m = GetBucketResponse{} }
 func (m *GetBucketResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBucketResponse) ProtoMessage()    {}
 func (*GetBucketResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{14}
+	return fileDescriptor_a07d9034b2dd9d26, []int{21}
 }
 func (m *GetBucketResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBucketResponse.Unmarshal(m, b)
@@ -704,7 +1089,7 @@ func (m *Bucket) Reset()         { *m = Bucket{} }
 func (m *Bucket) String() string { return proto.CompactTextString(m) }
 func (*Bucket) ProtoMessage()    {}
 func (*Bucket) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{15}
+	return fileDescriptor_a07d9034b2dd9d26, []int{22}
 }
 func (m *Bucket) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Bucket.Unmarshal(m, b)
@@ -742,7 +1127,7 @@ func (m *BucketList) Reset()         { *m = BucketList{} }
 func (m *BucketList) String() string { return proto.CompactTextString(m) }
 func (*BucketList) ProtoMessage()    {}
 func (*BucketList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{16}
+	return fileDescriptor_a07d9034b2dd9d26, []int{23}
 }
 func (m *BucketList) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BucketList.Unmarshal(m, b)
@@ -782,7 +1167,7 @@ func (m *PingNodeRequest) Reset()         { *m = PingNodeRequest{} }
 func (m *PingNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*PingNodeRequest) ProtoMessage()    {}
 func (*PingNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{17}
+	return fileDescriptor_a07d9034b2dd9d26, []int{24}
 }
 func (m *PingNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingNodeRequest.Unmarshal(m, b)
@@ -820,7 +1205,7 @@ func (m *PingNodeResponse) Reset()         { *m = PingNodeResponse{} }
 func (m *PingNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*PingNodeResponse) ProtoMessage()    {}
 func (*PingNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{18}
+	return fileDescriptor_a07d9034b2dd9d26, []int{25}
 }
 func (m *PingNodeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingNodeResponse.Unmarshal(m, b)
@@ -859,7 +1244,7 @@ func (m *LookupNodeRequest) Reset()         { *m = LookupNodeRequest{} }
 func (m *LookupNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*LookupNodeRequest) ProtoMessage()    {}
 func (*LookupNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{19}
+	return fileDescriptor_a07d9034b2dd9d26, []int{26}
 }
 func (m *LookupNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupNodeRequest.Unmarshal(m, b)
@@ -905,7 +1290,7 @@ func (m *LookupNodeResponse) Reset()         { *m = LookupNodeResponse{} }
 func (m *LookupNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*LookupNodeResponse) ProtoMessage()    {}
 func (*LookupNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{20}
+	return fileDescriptor_a07d9034b2dd9d26, []int{27}
 }
 func (m *LookupNodeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupNodeResponse.Unmarshal(m, b)
@@ -951,7 +1336,7 @@ func (m *NodeInfoRequest) Reset()         { *m = NodeInfoRequest{} }
 func (m *NodeInfoRequest) String() string { return proto.CompactTextString(m) }
 func (*NodeInfoRequest) ProtoMessage()    {}
 func (*NodeInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{21}
+	return fileDescriptor_a07d9034b2dd9d26, []int{28}
 }
 func (m *NodeInfoRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeInfoRequest.Unmarshal(m, b)
@@ -992,7 +1377,7 @@ func (m *NodeInfoResponse) Reset()         { *m = NodeInfoResponse{} }
 func (m *NodeInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*NodeInfoResponse) ProtoMessage()    {}
 func (*NodeInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{22}
+	return fileDescriptor_a07d9034b2dd9d26, []int{29}
 }
 func (m *NodeInfoResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeInfoResponse.Unmarshal(m, b)
@@ -1053,7 +1438,7 @@ func (m *FindNearRequest) Reset()         { *m = FindNearRequest{} }
 func (m *FindNearRequest) String() string { return proto.CompactTextString(m) }
 func (*FindNearRequest) ProtoMessage()    {}
 func (*FindNearRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{23}
+	return fileDescriptor_a07d9034b2dd9d26, []int{30}
 }
 func (m *FindNearRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindNearRequest.Unmarshal(m, b)
@@ -1091,7 +1476,7 @@ func (m *FindNearResponse) Reset()         { *m = FindNearResponse{} }
 func (m *FindNearResponse) String() string { return proto.CompactTextString(m) }
 func (*FindNearResponse) ProtoMessage()    {}
 func (*FindNearResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{24}
+	return fileDescriptor_a07d9034b2dd9d26, []int{31}
 }
 func (m *FindNearResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindNearResponse.Unmarshal(m, b)
@@ -1128,7 +1513,7 @@ func (m *DumpNodesRequest) Reset()         { *m = DumpNodesRequest{} }
 func (m *DumpNodesRequest) String() string { return proto.CompactTextString(m) }
 func (*DumpNodesRequest) ProtoMessage()    {}
 func (*DumpNodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{25}
+	return fileDescriptor_a07d9034b2dd9d26, []int{32}
 }
 func (m *DumpNodesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DumpNodesRequest.Unmarshal(m, b)
@@ -1159,7 +1544,7 @@ func (m *DumpNodesResponse) Reset()         { *m = DumpNodesResponse{} }
 func (m *DumpNodesResponse) String() string { return proto.CompactTextString(m) }
 func (*DumpNodesResponse) ProtoMessage()    {}
 func (*DumpNodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{26}
+	return fileDescriptor_a07d9034b2dd9d26, []int{33}
 }
 func (m *DumpNodesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DumpNodesResponse.Unmarshal(m, b)
@@ -1196,7 +1581,7 @@ func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
 func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
 func (*StatsRequest) ProtoMessage()    {}
 func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{27}
+	return fileDescriptor_a07d9034b2dd9d26, []int{34}
 }
 func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatsRequest.Unmarshal(m, b)
@@ -1232,7 +1617,7 @@ func (m *StatSummaryResponse) Reset()         { *m = StatSummaryResponse{} }
 func (m *StatSummaryResponse) String() string { return proto.CompactTextString(m) }
 func (*StatSummaryResponse) ProtoMessage()    {}
 func (*StatSummaryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{28}
+	return fileDescriptor_a07d9034b2dd9d26, []int{35}
 }
 func (m *StatSummaryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatSummaryResponse.Unmarshal(m, b)
@@ -1304,7 +1689,7 @@ func (m *DashboardRequest) Reset()         { *m = DashboardRequest{} }
 func (m *DashboardRequest) String() string { return proto.CompactTextString(m) }
 func (*DashboardRequest) ProtoMessage()    {}
 func (*DashboardRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{29}
+	return fileDescriptor_a07d9034b2dd9d26, []int{36}
 }
 func (m *DashboardRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DashboardRequest.Unmarshal(m, b)
@@ -1343,7 +1728,7 @@ func (m *DashboardResponse) Reset()         { *m = DashboardResponse{} }
 func (m *DashboardResponse) String() string { return proto.CompactTextString(m) }
 func (*DashboardResponse) ProtoMessage()    {}
 func (*DashboardResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{30}
+	return fileDescriptor_a07d9034b2dd9d26, []int{37}
 }
 func (m *DashboardResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DashboardResponse.Unmarshal(m, b)
@@ -1419,6 +1804,130 @@ func (m *DashboardResponse) GetLastQueried() *timestamp.Timestamp {
 	return nil
 }
 
+type CorruptedPiecesRequest struct {
+	Limit                int32    `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CorruptedPiecesRequest) Reset()         { *m = CorruptedPiecesRequest{} }
+func (m *CorruptedPiecesRequest) String() string { return proto.CompactTextString(m) }
+func (*CorruptedPiecesRequest) ProtoMessage()    {}
+func (*CorruptedPiecesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{38}
+}
+func (m *CorruptedPiecesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptedPiecesRequest.Unmarshal(m, b)
+}
+func (m *CorruptedPiecesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptedPiecesRequest.Marshal(b, m, deterministic)
+}
+func (m *CorruptedPiecesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptedPiecesRequest.Merge(m, src)
+}
+func (m *CorruptedPiecesRequest) XXX_Size() int {
+	return xxx_messageInfo_CorruptedPiecesRequest.Size(m)
+}
+func (m *CorruptedPiecesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptedPiecesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptedPiecesRequest proto.InternalMessageInfo
+
+func (m *CorruptedPiecesRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type CorruptedPiece struct {
+	SatelliteId          NodeID               `protobuf:"bytes,1,opt,name=satellite_id,json=satelliteId,proto3,customtype=NodeID" json:"satellite_id"`
+	PieceId              PieceID              `protobuf:"bytes,2,opt,name=piece_id,json=pieceId,proto3,customtype=PieceID" json:"piece_id"`
+	PieceSize            int64                `protobuf:"varint,3,opt,name=piece_size,json=pieceSize,proto3" json:"piece_size,omitempty"`
+	DetectedAt           *timestamp.Timestamp `protobuf:"bytes,4,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CorruptedPiece) Reset()         { *m = CorruptedPiece{} }
+func (m *CorruptedPiece) String() string { return proto.CompactTextString(m) }
+func (*CorruptedPiece) ProtoMessage()    {}
+func (*CorruptedPiece) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{39}
+}
+func (m *CorruptedPiece) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptedPiece.Unmarshal(m, b)
+}
+func (m *CorruptedPiece) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptedPiece.Marshal(b, m, deterministic)
+}
+func (m *CorruptedPiece) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptedPiece.Merge(m, src)
+}
+func (m *CorruptedPiece) XXX_Size() int {
+	return xxx_messageInfo_CorruptedPiece.Size(m)
+}
+func (m *CorruptedPiece) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptedPiece.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptedPiece proto.InternalMessageInfo
+
+func (m *CorruptedPiece) GetPieceSize() int64 {
+	if m != nil {
+		return m.PieceSize
+	}
+	return 0
+}
+
+func (m *CorruptedPiece) GetDetectedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.DetectedAt
+	}
+	return nil
+}
+
+type CorruptedPiecesResponse struct {
+	Pieces               []*CorruptedPiece `protobuf:"bytes,1,rep,name=pieces,proto3" json:"pieces,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CorruptedPiecesResponse) Reset()         { *m = CorruptedPiecesResponse{} }
+func (m *CorruptedPiecesResponse) String() string { return proto.CompactTextString(m) }
+func (*CorruptedPiecesResponse) ProtoMessage()    {}
+func (*CorruptedPiecesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_a07d9034b2dd9d26, []int{40}
+}
+func (m *CorruptedPiecesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptedPiecesResponse.Unmarshal(m, b)
+}
+func (m *CorruptedPiecesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptedPiecesResponse.Marshal(b, m, deterministic)
+}
+func (m *CorruptedPiecesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptedPiecesResponse.Merge(m, src)
+}
+func (m *CorruptedPiecesResponse) XXX_Size() int {
+	return xxx_messageInfo_CorruptedPiecesResponse.Size(m)
+}
+func (m *CorruptedPiecesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptedPiecesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptedPiecesResponse proto.InternalMessageInfo
+
+func (m *CorruptedPiecesResponse) GetPieces() []*CorruptedPiece {
+	if m != nil {
+		return m.Pieces
+	}
+	return nil
+}
+
 type SegmentHealthRequest struct {
 	Bucket               []byte   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
 	EncryptedPath        []byte   `protobuf:"bytes,2,opt,name=encrypted_path,json=encryptedPath,proto3" json:"encrypted_path,omitempty"`
@@ -1433,7 +1942,7 @@ func (m *SegmentHealthRequest) Reset()         { *m = SegmentHealthRequest{} }
 func (m *SegmentHealthRequest) String() string { return proto.CompactTextString(m) }
 func (*SegmentHealthRequest) ProtoMessage()    {}
 func (*SegmentHealthRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{31}
+	return fileDescriptor_a07d9034b2dd9d26, []int{41}
 }
 func (m *SegmentHealthRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentHealthRequest.Unmarshal(m, b)
@@ -1493,7 +2002,7 @@ func (m *SegmentHealth) Reset()         { *m = SegmentHealth{} }
 func (m *SegmentHealth) String() string { return proto.CompactTextString(m) }
 func (*SegmentHealth) ProtoMessage()    {}
 func (*SegmentHealth) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{32}
+	return fileDescriptor_a07d9034b2dd9d26, []int{42}
 }
 func (m *SegmentHealth) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentHealth.Unmarshal(m, b)
@@ -1539,7 +2048,7 @@ func (m *SegmentHealthResponse) Reset()         { *m = SegmentHealthResponse{} }
 func (m *SegmentHealthResponse) String() string { return proto.CompactTextString(m) }
 func (*SegmentHealthResponse) ProtoMessage()    {}
 func (*SegmentHealthResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{33}
+	return fileDescriptor_a07d9034b2dd9d26, []int{43}
 }
 func (m *SegmentHealthResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentHealthResponse.Unmarshal(m, b)
@@ -1589,7 +2098,7 @@ func (m *ObjectHealthRequest) Reset()         { *m = ObjectHealthRequest{} }
 func (m *ObjectHealthRequest) String() string { return proto.CompactTextString(m) }
 func (*ObjectHealthRequest) ProtoMessage()    {}
 func (*ObjectHealthRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{34}
+	return fileDescriptor_a07d9034b2dd9d26, []int{44}
 }
 func (m *ObjectHealthRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ObjectHealthRequest.Unmarshal(m, b)
@@ -1663,7 +2172,7 @@ func (m *ObjectHealthResponse) Reset()         { *m = ObjectHealthResponse{} }
 func (m *ObjectHealthResponse) String() string { return proto.CompactTextString(m) }
 func (*ObjectHealthResponse) ProtoMessage()    {}
 func (*ObjectHealthResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_a07d9034b2dd9d26, []int{35}
+	return fileDescriptor_a07d9034b2dd9d26, []int{45}
 }
 func (m *ObjectHealthResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ObjectHealthResponse.Unmarshal(m, b)
@@ -1698,13 +2207,21 @@ func (m *ObjectHealthResponse) GetRedundancy() *RedundancyScheme {
 }
 
 func init() {
+	proto.RegisterEnum("inspector.SetNodeStatusRequest_Action", SetNodeStatusRequest_Action_name, SetNodeStatusRequest_Action_value)
 	proto.RegisterType((*ListIrreparableSegmentsRequest)(nil), "inspector.ListIrreparableSegmentsRequest")
 	proto.RegisterType((*IrreparableSegment)(nil), "inspector.IrreparableSegment")
 	proto.RegisterType((*ListIrreparableSegmentsResponse)(nil), "inspector.ListIrreparableSegmentsResponse")
+	proto.RegisterType((*QueueDepthRequest)(nil), "inspector.QueueDepthRequest")
+	proto.RegisterType((*QueueDepthBucket)(nil), "inspector.QueueDepthBucket")
+	proto.RegisterType((*QueueDepthResponse)(nil), "inspector.QueueDepthResponse")
 	proto.RegisterType((*GetStatsRequest)(nil), "inspector.GetStatsRequest")
 	proto.RegisterType((*GetStatsResponse)(nil), "inspector.GetStatsResponse")
 	proto.RegisterType((*CreateStatsRequest)(nil), "inspector.CreateStatsRequest")
 	proto.RegisterType((*CreateStatsResponse)(nil), "inspector.CreateStatsResponse")
+	proto.RegisterType((*GetNodeStatusRequest)(nil), "inspector.GetNodeStatusRequest")
+	proto.RegisterType((*GetNodeStatusResponse)(nil), "inspector.GetNodeStatusResponse")
+	proto.RegisterType((*SetNodeStatusRequest)(nil), "inspector.SetNodeStatusRequest")
+	proto.RegisterType((*SetNodeStatusResponse)(nil), "inspector.SetNodeStatusResponse")
 	proto.RegisterType((*CountNodesResponse)(nil), "inspector.CountNodesResponse")
 	proto.RegisterType((*CountNodesRequest)(nil), "inspector.CountNodesRequest")
 	proto.RegisterType((*GetBucketListRequest)(nil), "inspector.GetBucketListRequest")
@@ -1730,6 +2247,9 @@ func init() {
 	proto.RegisterType((*StatSummaryResponse)(nil), "inspector.StatSummaryResponse")
 	proto.RegisterType((*DashboardRequest)(nil), "inspector.DashboardRequest")
 	proto.RegisterType((*DashboardResponse)(nil), "inspector.DashboardResponse")
+	proto.RegisterType((*CorruptedPiecesRequest)(nil), "inspector.CorruptedPiecesRequest")
+	proto.RegisterType((*CorruptedPiece)(nil), "inspector.CorruptedPiece")
+	proto.RegisterType((*CorruptedPiecesResponse)(nil), "inspector.CorruptedPiecesResponse")
 	proto.RegisterType((*SegmentHealthRequest)(nil), "inspector.SegmentHealthRequest")
 	proto.RegisterType((*SegmentHealth)(nil), "inspector.SegmentHealth")
 	proto.RegisterType((*SegmentHealthResponse)(nil), "inspector.SegmentHealthResponse")
@@ -1740,119 +2260,154 @@ func init() {
 func init() { proto.RegisterFile("inspector.proto", fileDescriptor_a07d9034b2dd9d26) }
 
 var fileDescriptor_a07d9034b2dd9d26 = []byte{
-	// 1782 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x4b, 0x93, 0x1b, 0x49,
-	0x11, 0x76, 0xeb, 0x35, 0x52, 0x4a, 0xa3, 0x47, 0x49, 0xf6, 0x0a, 0xad, 0x3d, 0x1a, 0x9a, 0x87,
-	0xbd, 0x36, 0xc8, 0x46, 0x98, 0xc3, 0xb2, 0xb1, 0x07, 0x8f, 0xcc, 0xae, 0x15, 0x6b, 0xec, 0xd9,
-	0x1e, 0xc3, 0x81, 0xd8, 0x40, 0x51, 0xea, 0xae, 0xd1, 0x34, 0x96, 0xba, 0x7a, 0xbb, 0x4b, 0x66,
-	0xe7, 0x0f, 0x10, 0x70, 0xe2, 0xc4, 0x01, 0xae, 0xfc, 0x09, 0x82, 0x2b, 0x17, 0x6e, 0xdc, 0x39,
-	0xf8, 0x42, 0x04, 0xdc, 0xb9, 0x71, 0x23, 0x2a, 0xab, 0xfa, 0x2d, 0x31, 0x13, 0xc0, 0xde, 0xd4,
-	0x5f, 0x7e, 0x95, 0x95, 0xf9, 0xd5, 0x23, 0xb3, 0x04, 0x1d, 0xd7, 0x0b, 0x7d, 0x66, 0x0b, 0x1e,
-	0x4c, 0xfc, 0x80, 0x0b, 0x4e, 0x1a, 0x31, 0x30, 0x82, 0x15, 0x5f, 0x71, 0x05, 0x8f, 0xc0, 0xe3,
-	0x0e, 0xd3, 0xbf, 0x3b, 0x3e, 0x77, 0x3d, 0xc1, 0x02, 0x67, 0xa9, 0x81, 0xa3, 0x15, 0xe7, 0xab,
-	0x35, 0x7b, 0x88, 0x5f, 0xcb, 0xed, 0xf9, 0x43, 0x67, 0x1b, 0x50, 0xe1, 0x72, 0x4f, 0xdb, 0xc7,
-	0x79, 0xbb, 0x70, 0x37, 0x2c, 0x14, 0x74, 0xe3, 0x2b, 0x82, 0xf9, 0x02, 0x8e, 0x9e, 0xbb, 0xa1,
-	0x98, 0x07, 0x01, 0xf3, 0x69, 0x40, 0x97, 0x6b, 0x76, 0xc6, 0x56, 0x1b, 0xe6, 0x89, 0xd0, 0x62,
-	0x9f, 0x6f, 0x59, 0x28, 0xc8, 0x00, 0xaa, 0x6b, 0x77, 0xe3, 0x8a, 0xa1, 0x71, 0x6c, 0xdc, 0xab,
-	0x5a, 0xea, 0x83, 0xdc, 0x82, 0x1a, 0x3f, 0x3f, 0x0f, 0x99, 0x18, 0x96, 0x10, 0xd6, 0x5f, 0xe6,
-	0xdf, 0x0d, 0x20, 0x45, 0x67, 0x84, 0x40, 0xc5, 0xa7, 0xe2, 0x02, 0x7d, 0xb4, 0x2c, 0xfc, 0x4d,
-	0xde, 0x87, 0x76, 0xa8, 0xcc, 0x0b, 0x87, 0x09, 0xea, 0xae, 0xd1, 0x55, 0x73, 0x4a, 0x26, 0x49,
-	0x96, 0xa7, 0xea, 0x97, 0x75, 0xa8, 0x99, 0x4f, 0x91, 0x48, 0xc6, 0xd0, 0x5c, 0xf3, 0x50, 0x2c,
-	0x7c, 0x97, 0xd9, 0x2c, 0x1c, 0x96, 0x31, 0x04, 0x90, 0xd0, 0x29, 0x22, 0x64, 0x02, 0xfd, 0x35,
-	0x0d, 0xc5, 0x42, 0x06, 0xe2, 0x06, 0x0b, 0x2a, 0x04, 0xdb, 0xf8, 0x62, 0x58, 0x39, 0x36, 0xee,
-	0x95, 0xad, 0x9e, 0x34, 0x59, 0x68, 0x79, 0xa2, 0x0c, 0xe4, 0x11, 0x0c, 0xb2, 0xd4, 0x85, 0xcd,
-	0xb7, 0x9e, 0x18, 0x56, 0x71, 0x00, 0x09, 0xd2, 0xe4, 0x99, 0xb4, 0x98, 0x9f, 0xc1, 0x78, 0xaf,
-	0x70, 0xa1, 0xcf, 0xbd, 0x90, 0x91, 0xf7, 0xa1, 0xae, 0xc3, 0x0e, 0x87, 0xc6, 0x71, 0xf9, 0x5e,
-	0x73, 0x7a, 0x67, 0x92, 0x2c, 0x7a, 0x71, 0xa4, 0x15, 0xd3, 0xcd, 0xef, 0x43, 0xe7, 0x63, 0x26,
-	0xce, 0x04, 0x4d, 0xd6, 0xe1, 0x2e, 0x1c, 0xc8, 0x9d, 0xb0, 0x70, 0x1d, 0xa5, 0xe2, 0x49, 0xfb,
-	0xcf, 0x6f, 0xc7, 0x37, 0xfe, 0xfa, 0x76, 0x5c, 0x7b, 0xc1, 0x1d, 0x36, 0x7f, 0x6a, 0xd5, 0xa4,
-	0x79, 0xee, 0x98, 0xbf, 0x33, 0xa0, 0x9b, 0x0c, 0xd6, 0xb1, 0x8c, 0xa1, 0x49, 0xb7, 0x8e, 0x1b,
-	0xe5, 0x65, 0x60, 0x5e, 0x80, 0x10, 0xe6, 0x93, 0x10, 0x70, 0xff, 0xe0, 0x52, 0x18, 0x9a, 0x60,
-	0x49, 0x84, 0x7c, 0x15, 0x5a, 0x5b, 0x5f, 0x6e, 0x1f, 0xed, 0xa2, 0x8c, 0x2e, 0x9a, 0x0a, 0x53,
-	0x3e, 0x12, 0x8a, 0x72, 0x52, 0x41, 0x27, 0x9a, 0x82, 0x5e, 0xcc, 0xbf, 0x19, 0x40, 0x66, 0x01,
-	0xa3, 0x82, 0xfd, 0x57, 0xc9, 0xe5, 0xf3, 0x28, 0x15, 0xf2, 0x98, 0x40, 0x5f, 0x11, 0xc2, 0xad,
-	0x6d, 0xb3, 0x30, 0xcc, 0x44, 0xdb, 0x43, 0xd3, 0x99, 0xb2, 0xe4, 0x63, 0x56, 0xc4, 0x4a, 0x31,
-	0xad, 0x47, 0x30, 0xd0, 0x94, 0xac, 0x4f, 0xbd, 0x39, 0x94, 0x2d, 0xed, 0xd4, 0xbc, 0x09, 0xfd,
-	0x4c, 0x92, 0x6a, 0x11, 0xcc, 0xfb, 0x40, 0xd0, 0x2e, 0x73, 0x4a, 0x96, 0x66, 0x00, 0xd5, 0xf4,
-	0xa2, 0xa8, 0x0f, 0xb3, 0x0f, 0xbd, 0x34, 0x17, 0x65, 0x32, 0x6f, 0xc1, 0xe0, 0x63, 0x26, 0x4e,
-	0xb6, 0xf6, 0x6b, 0x26, 0xe4, 0xee, 0x8b, 0xf0, 0x7f, 0x1a, 0x70, 0x33, 0x67, 0xd0, 0xce, 0x9f,
-	0xc0, 0xc1, 0x12, 0xd1, 0x68, 0x0b, 0xde, 0x4d, 0x6d, 0xc1, 0x9d, 0x43, 0x26, 0x0a, 0xb2, 0xa2,
-	0x71, 0xa3, 0xdf, 0x18, 0x50, 0x53, 0x18, 0x79, 0x00, 0x0d, 0x85, 0xee, 0x5f, 0xa8, 0xba, 0x22,
-	0xcc, 0x1d, 0xf2, 0x10, 0x0e, 0x03, 0xbe, 0x15, 0xae, 0xb7, 0x5a, 0xc8, 0xc5, 0x0b, 0x87, 0x25,
-	0x0c, 0x00, 0x26, 0x78, 0xa1, 0x49, 0xba, 0xd5, 0xd2, 0x04, 0x4c, 0x92, 0x7c, 0x1b, 0x5a, 0x36,
-	0xb5, 0x2f, 0x98, 0xa3, 0xf9, 0xe5, 0x02, 0xbf, 0xa9, 0xec, 0x48, 0x97, 0x0a, 0xc5, 0x09, 0xc4,
-	0x0a, 0x3d, 0x03, 0x92, 0x06, 0x13, 0x89, 0x05, 0x17, 0x74, 0x1d, 0x49, 0x8c, 0x1f, 0xe4, 0x36,
-	0x94, 0x5d, 0x47, 0x85, 0xd5, 0x3a, 0x81, 0x54, 0x0e, 0x12, 0x36, 0xa7, 0x78, 0x8a, 0xb4, 0x18,
-	0x7a, 0x9b, 0x1e, 0x41, 0x69, 0x6f, 0xe2, 0x25, 0xd7, 0x31, 0x7f, 0x94, 0x0a, 0x29, 0x9e, 0xfc,
-	0x8a, 0x41, 0xe4, 0x18, 0xaa, 0xfb, 0xf4, 0x51, 0x06, 0xf3, 0x7e, 0xbc, 0x00, 0x57, 0x73, 0x27,
-	0x00, 0xc9, 0x9a, 0x26, 0x7c, 0x63, 0x1f, 0xff, 0x13, 0xe8, 0x9c, 0xea, 0x15, 0xb8, 0x66, 0x96,
-	0x64, 0x08, 0x07, 0xd4, 0x71, 0x02, 0x16, 0x86, 0x78, 0xfe, 0x1a, 0x56, 0xf4, 0x69, 0x9a, 0xd0,
-	0x4d, 0x9c, 0xe9, 0xf4, 0xdb, 0x50, 0xe2, 0xaf, 0xd1, 0x5b, 0xdd, 0x2a, 0xf1, 0xd7, 0xe6, 0x87,
-	0xd0, 0x7b, 0xce, 0xf9, 0xeb, 0xad, 0x9f, 0x9e, 0xb2, 0x1d, 0x4f, 0xd9, 0xb8, 0x62, 0x8a, 0xcf,
-	0x80, 0xa4, 0x87, 0xc7, 0x1a, 0x57, 0x64, 0x3a, 0xe8, 0x21, 0x9b, 0x26, 0xe2, 0xe4, 0x9b, 0x50,
-	0xd9, 0x30, 0x41, 0xe3, 0x0a, 0x13, 0xdb, 0x7f, 0xc8, 0x04, 0x75, 0xa8, 0xa0, 0x16, 0xda, 0xcd,
-	0x9f, 0x42, 0x07, 0x13, 0xf5, 0xce, 0xf9, 0x75, 0xd5, 0x78, 0x90, 0x0d, 0xb5, 0x39, 0xed, 0x25,
-	0xde, 0x9f, 0x28, 0x43, 0x12, 0xfd, 0x9f, 0x0c, 0xe8, 0x26, 0x13, 0xe8, 0xe0, 0x4d, 0xa8, 0x88,
-	0x4b, 0x5f, 0x05, 0xdf, 0x9e, 0xb6, 0x93, 0xe1, 0xaf, 0x2e, 0x7d, 0x66, 0xa1, 0x8d, 0x4c, 0xa0,
-	0xce, 0x7d, 0x16, 0x50, 0xc1, 0x83, 0x62, 0x12, 0x2f, 0xb5, 0xc5, 0x8a, 0x39, 0x92, 0x6f, 0x53,
-	0x9f, 0xda, 0xae, 0xb8, 0xc4, 0xbb, 0x2f, 0xc3, 0x9f, 0x69, 0x8b, 0x15, 0x73, 0x64, 0x16, 0x6f,
-	0x58, 0x10, 0xba, 0xdc, 0xc3, 0x1b, 0x30, 0x93, 0xc5, 0x8f, 0x95, 0xc1, 0x8a, 0x18, 0xe6, 0x06,
-	0x3a, 0x1f, 0xb9, 0x9e, 0xf3, 0x82, 0xd1, 0xe0, 0xba, 0x2a, 0x7d, 0x1d, 0xaa, 0xa1, 0xa0, 0x81,
-	0xba, 0xb1, 0x8b, 0x14, 0x65, 0x4c, 0x7a, 0x0d, 0x75, 0x5d, 0xab, 0x0f, 0xf3, 0x31, 0x74, 0x93,
-	0xe9, 0xb4, 0x66, 0x57, 0x1f, 0x04, 0x02, 0xdd, 0xa7, 0xdb, 0x8d, 0x9f, 0xb9, 0x3f, 0xbf, 0x07,
-	0xbd, 0x14, 0x96, 0x77, 0xb5, 0xf7, 0x8c, 0xb4, 0xa1, 0x95, 0xae, 0x56, 0xe6, 0xbf, 0x0c, 0xe8,
-	0x4b, 0xe0, 0x6c, 0xbb, 0xd9, 0xd0, 0xe0, 0x32, 0xf6, 0x74, 0x07, 0x60, 0x1b, 0x32, 0x67, 0x11,
-	0xfa, 0xd4, 0x66, 0xfa, 0xae, 0x69, 0x48, 0xe4, 0x4c, 0x02, 0xe4, 0x2e, 0x74, 0xe8, 0x1b, 0xea,
-	0xae, 0x65, 0xc9, 0xd7, 0x1c, 0x55, 0xbf, 0xda, 0x31, 0xac, 0x88, 0xb2, 0x26, 0x49, 0x3f, 0xae,
-	0xb7, 0xc2, 0x7d, 0x15, 0x95, 0xda, 0x90, 0x39, 0x73, 0x05, 0xc9, 0x3a, 0x88, 0x14, 0xa6, 0x18,
-	0xaa, 0x6a, 0xe1, 0xec, 0x3f, 0x50, 0x84, 0x6f, 0x40, 0x1b, 0x09, 0x4b, 0xea, 0x39, 0x3f, 0x77,
-	0x1d, 0x71, 0xa1, 0xcb, 0xd5, 0xa1, 0x44, 0x4f, 0x22, 0x90, 0x3c, 0x84, 0x7e, 0x12, 0x53, 0xc2,
-	0xad, 0xa9, 0xd2, 0x16, 0x9b, 0xe2, 0x01, 0x28, 0x2b, 0x0d, 0x2f, 0x96, 0x9c, 0x06, 0x4e, 0xa4,
-	0xc7, 0x5f, 0xca, 0xd0, 0x4b, 0x81, 0x5a, 0x8d, 0x6b, 0xd7, 0xf4, 0xf7, 0xa0, 0x8b, 0x44, 0x9b,
-	0x7b, 0x1e, 0xb3, 0x65, 0xf7, 0x1a, 0x6a, 0x61, 0x3a, 0x12, 0x9f, 0x25, 0x30, 0x79, 0x00, 0xbd,
-	0x25, 0xe7, 0x22, 0x14, 0x01, 0xf5, 0x17, 0xd1, 0xb1, 0x2b, 0xe3, 0x0d, 0xd1, 0x8d, 0x0d, 0xfa,
-	0xd4, 0x49, 0xbf, 0xd8, 0x3d, 0x7a, 0x74, 0x1d, 0x73, 0x2b, 0xc8, 0xed, 0x44, 0x78, 0x8a, 0xca,
-	0xbe, 0xc8, 0x51, 0xab, 0x8a, 0x1a, 0xe1, 0x11, 0xf5, 0x31, 0xee, 0x64, 0x11, 0xa2, 0x46, 0xcd,
-	0xe9, 0x51, 0xaa, 0x9e, 0xee, 0xd8, 0x13, 0x96, 0x22, 0x93, 0xef, 0x40, 0x4d, 0xf5, 0x09, 0xc3,
-	0x03, 0x1c, 0xf6, 0x95, 0x89, 0xea, 0xcc, 0x27, 0x51, 0x67, 0x3e, 0x79, 0xaa, 0x3b, 0x77, 0x4b,
-	0x13, 0xc9, 0x07, 0xd0, 0xc4, 0x1e, 0xd6, 0x77, 0xbd, 0x15, 0x73, 0x86, 0x75, 0x1c, 0x37, 0x2a,
-	0x8c, 0x7b, 0x15, 0x75, 0xf4, 0x16, 0x48, 0xfa, 0x29, 0xb2, 0xc9, 0x87, 0xd0, 0xc2, 0xc1, 0x9f,
-	0x6f, 0x59, 0xe0, 0x32, 0x67, 0xd8, 0xb8, 0x72, 0x34, 0x4e, 0xf6, 0xa9, 0xa2, 0x9b, 0xbf, 0x35,
-	0x60, 0xa0, 0xbb, 0xd2, 0x67, 0x8c, 0xae, 0xc5, 0x45, 0x74, 0xce, 0x6f, 0x41, 0x4d, 0x15, 0x78,
-	0xdd, 0xca, 0xeb, 0x2f, 0xb9, 0xdd, 0x98, 0x67, 0x07, 0x97, 0xbe, 0x60, 0xce, 0x02, 0x5b, 0x7d,
-	0x3c, 0xe8, 0xd6, 0x61, 0x8c, 0x9e, 0xca, 0x9e, 0xff, 0x6b, 0x10, 0x75, 0xf2, 0x0b, 0xd7, 0x73,
-	0xd8, 0x17, 0x7a, 0x6b, 0xb7, 0x34, 0x38, 0x97, 0x98, 0x3c, 0x46, 0x7e, 0xc0, 0x7f, 0xc6, 0x6c,
-	0x6c, 0x33, 0x2a, 0xe8, 0xa7, 0xa1, 0x91, 0xb9, 0x63, 0x3e, 0x87, 0xc3, 0x4c, 0x68, 0xf2, 0xb8,
-	0x70, 0x6f, 0xed, 0x7a, 0x6c, 0x11, 0x9d, 0x63, 0xf9, 0x1c, 0x68, 0x2a, 0x4c, 0xb5, 0x16, 0x43,
-	0x38, 0xd0, 0x53, 0xe8, 0xb8, 0xa2, 0x4f, 0xf3, 0x17, 0x06, 0xdc, 0xcc, 0x65, 0xaa, 0xf7, 0xef,
-	0x23, 0xa8, 0x5d, 0x20, 0xa2, 0xab, 0xca, 0x30, 0xbd, 0xd2, 0x99, 0x11, 0x9a, 0x47, 0x3e, 0x00,
-	0x08, 0x98, 0xb3, 0xf5, 0x1c, 0xea, 0xd9, 0x97, 0xfa, 0x9a, 0x7e, 0x37, 0xf5, 0x9a, 0xb1, 0x62,
-	0xe3, 0x99, 0x7d, 0xc1, 0x36, 0xcc, 0x4a, 0xd1, 0xcd, 0x7f, 0x18, 0xd0, 0x7f, 0xb9, 0x94, 0x39,
-	0x66, 0x15, 0x2f, 0x2a, 0x6b, 0xec, 0x52, 0x36, 0x59, 0x98, 0x52, 0x66, 0x61, 0xb2, 0x62, 0x96,
-	0x73, 0x62, 0xca, 0x76, 0x19, 0xaf, 0xde, 0x05, 0x3d, 0x17, 0x2c, 0x58, 0x44, 0x22, 0xe9, 0x87,
-	0x12, 0x9a, 0x9e, 0x48, 0x4b, 0xf4, 0x90, 0xfb, 0x16, 0x10, 0xe6, 0x39, 0x8b, 0x25, 0x3b, 0xe7,
-	0x01, 0x8b, 0xe9, 0xea, 0x6a, 0xe9, 0x32, 0xcf, 0x39, 0x41, 0x43, 0xc4, 0x8e, 0xef, 0xf3, 0x5a,
-	0xea, 0xed, 0x68, 0xfe, 0xca, 0x80, 0x41, 0x36, 0x53, 0xad, 0xf8, 0xe3, 0xc2, 0x83, 0x69, 0xbf,
-	0xe6, 0x31, 0xf3, 0x7f, 0x52, 0x7d, 0xfa, 0xeb, 0x0a, 0xb4, 0x3e, 0xa1, 0xce, 0x3c, 0x9a, 0x85,
-	0xcc, 0x01, 0x92, 0xbe, 0x9b, 0xdc, 0x4e, 0xcd, 0x5f, 0x68, 0xc7, 0x47, 0x77, 0xf6, 0x58, 0x75,
-	0x3a, 0x33, 0xa8, 0x47, 0xdd, 0x10, 0x19, 0xa5, 0xa8, 0xb9, 0x7e, 0x6b, 0xf4, 0xee, 0x4e, 0x9b,
-	0x76, 0x32, 0x07, 0x48, 0xfa, 0x9d, 0x4c, 0x3c, 0x85, 0x2e, 0x2a, 0x13, 0xcf, 0x8e, 0x26, 0x69,
-	0x06, 0xf5, 0xa8, 0xf7, 0xc8, 0xc4, 0x93, 0xeb, 0x78, 0x32, 0xf1, 0x14, 0x9a, 0x95, 0x19, 0xd4,
-	0xa3, 0x62, 0x9c, 0x71, 0x92, 0x6b, 0x08, 0x32, 0x4e, 0x0a, 0xd5, 0xfb, 0x23, 0x68, 0xc4, 0x75,
-	0x98, 0xa4, 0x99, 0xf9, 0x8a, 0x3d, 0xba, 0xbd, 0xdb, 0xa8, 0xfd, 0x58, 0x70, 0x98, 0x79, 0xc3,
-	0x90, 0xf1, 0xfe, 0xd7, 0x8d, 0xf2, 0x77, 0x7c, 0xd5, 0xf3, 0x67, 0xfa, 0x87, 0x12, 0x74, 0x5f,
-	0xbe, 0x61, 0xc1, 0x9a, 0x5e, 0x7e, 0x29, 0xbb, 0xe2, 0xff, 0x95, 0xfb, 0x0c, 0xea, 0xd1, 0x2b,
-	0x3f, 0xb3, 0x10, 0xb9, 0xff, 0x0d, 0x32, 0x0b, 0x51, 0xf8, 0x5b, 0xe0, 0x39, 0x34, 0x53, 0x0f,
-	0x55, 0x92, 0x09, 0xbd, 0xf0, 0x4a, 0x1f, 0x1d, 0xed, 0x33, 0x6b, 0xe9, 0x7e, 0x6f, 0x40, 0x1f,
-	0xff, 0x80, 0x39, 0x13, 0x3c, 0x60, 0x89, 0x7a, 0x27, 0x50, 0x55, 0xfe, 0xdf, 0xc9, 0x15, 0xcb,
-	0x9d, 0x9e, 0x77, 0x54, 0x51, 0xf3, 0x06, 0x79, 0x06, 0x8d, 0xb8, 0xc5, 0xc8, 0xca, 0x96, 0xeb,
-	0x46, 0xb2, 0xb2, 0xe5, 0xbb, 0x12, 0xf3, 0xc6, 0xf4, 0x97, 0x06, 0x0c, 0x52, 0x7f, 0xbe, 0x24,
-	0x61, 0xfa, 0xf0, 0xce, 0x9e, 0xbf, 0x74, 0xc8, 0x7b, 0xe9, 0x93, 0xf5, 0x1f, 0xff, 0x2f, 0x1b,
-	0xdd, 0xbf, 0x0e, 0x55, 0x0b, 0xf6, 0x47, 0x03, 0x3a, 0xea, 0x3e, 0x4b, 0xa2, 0xf8, 0x14, 0x5a,
-	0xe9, 0xcb, 0x91, 0xa4, 0xa5, 0xd9, 0x51, 0x1f, 0x46, 0xe3, 0xbd, 0xf6, 0x58, 0xbb, 0x57, 0xf9,
-	0x8a, 0x39, 0xde, 0x7b, 0xad, 0xee, 0x38, 0x26, 0x3b, 0xab, 0xa3, 0x79, 0xe3, 0xa4, 0xf2, 0x93,
-	0x92, 0xbf, 0x5c, 0xd6, 0xb0, 0x95, 0xf8, 0xee, 0xbf, 0x03, 0x00, 0x00, 0xff, 0xff, 0x8f, 0x28,
-	0x82, 0xe5, 0xcf, 0x14, 0x00, 0x00,
+	// 2345 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xcb, 0x6f, 0x23, 0x49,
+	0x19, 0x9f, 0xb6, 0x1d, 0xc7, 0xfe, 0xec, 0xd8, 0x4e, 0x25, 0x93, 0x18, 0xe7, 0xb9, 0x0d, 0xec,
+	0xce, 0xce, 0x80, 0x27, 0xe3, 0x9d, 0x15, 0x1a, 0x56, 0x0b, 0x72, 0x92, 0x99, 0x1d, 0x6b, 0x42,
+	0x26, 0xd3, 0xce, 0xac, 0xc4, 0x6a, 0x45, 0xab, 0xdc, 0x5d, 0xb1, 0x9b, 0x69, 0x77, 0xf7, 0x74,
+	0x57, 0x0f, 0x93, 0xfd, 0x03, 0x10, 0x9c, 0x38, 0x71, 0xe0, 0x5f, 0xe1, 0xba, 0x17, 0x24, 0x0e,
+	0xdc, 0x39, 0xac, 0x90, 0x90, 0x40, 0xdc, 0x10, 0x37, 0x4e, 0xa0, 0x7a, 0xf4, 0xd3, 0x76, 0x12,
+	0x58, 0xb8, 0x75, 0x7d, 0xbf, 0x5f, 0x7d, 0xf5, 0x3d, 0xea, 0xf1, 0x55, 0x35, 0x34, 0x2d, 0x27,
+	0xf0, 0x88, 0x41, 0x5d, 0xbf, 0xeb, 0xf9, 0x2e, 0x75, 0x51, 0x35, 0x16, 0x74, 0x60, 0xec, 0x8e,
+	0x5d, 0x21, 0xee, 0x80, 0xe3, 0x9a, 0x44, 0x7e, 0x37, 0x3d, 0xd7, 0x72, 0x28, 0xf1, 0xcd, 0x91,
+	0x14, 0xec, 0x8e, 0x5d, 0x77, 0x6c, 0x93, 0xfb, 0xbc, 0x35, 0x0a, 0x2f, 0xee, 0x9b, 0xa1, 0x8f,
+	0xa9, 0xe5, 0x3a, 0x12, 0xdf, 0xcb, 0xe3, 0xd4, 0x9a, 0x92, 0x80, 0xe2, 0xa9, 0x27, 0x08, 0xea,
+	0x29, 0xec, 0x9e, 0x58, 0x01, 0x1d, 0xf8, 0x3e, 0xf1, 0xb0, 0x8f, 0x47, 0x36, 0x19, 0x92, 0xf1,
+	0x94, 0x38, 0x34, 0xd0, 0xc8, 0xeb, 0x90, 0x04, 0x14, 0xad, 0xc3, 0x92, 0x6d, 0x4d, 0x2d, 0xda,
+	0x56, 0xf6, 0x95, 0x3b, 0x4b, 0x9a, 0x68, 0xa0, 0x0d, 0x28, 0xbb, 0x17, 0x17, 0x01, 0xa1, 0xed,
+	0x02, 0x17, 0xcb, 0x96, 0xfa, 0x17, 0x05, 0xd0, 0xac, 0x32, 0x84, 0xa0, 0xe4, 0x61, 0x3a, 0xe1,
+	0x3a, 0xea, 0x1a, 0xff, 0x46, 0x8f, 0xa0, 0x11, 0x08, 0x58, 0x37, 0x09, 0xc5, 0x96, 0xcd, 0x55,
+	0xd5, 0x7a, 0xa8, 0x9b, 0x78, 0x79, 0x26, 0xbe, 0xb4, 0x15, 0xc9, 0x3c, 0xe6, 0x44, 0xb4, 0x07,
+	0x35, 0xdb, 0x0d, 0xa8, 0xee, 0x59, 0xc4, 0x20, 0x41, 0xbb, 0xc8, 0x4d, 0x00, 0x26, 0x3a, 0xe3,
+	0x12, 0xd4, 0x85, 0x35, 0x1b, 0x07, 0x54, 0x67, 0x86, 0x58, 0xbe, 0x8e, 0x29, 0x25, 0x53, 0x8f,
+	0xb6, 0x4b, 0xfb, 0xca, 0x9d, 0xa2, 0xb6, 0xca, 0x20, 0x8d, 0x23, 0x7d, 0x01, 0xa0, 0x03, 0x58,
+	0xcf, 0x52, 0x75, 0xc3, 0x0d, 0x1d, 0xda, 0x5e, 0xe2, 0x1d, 0x90, 0x9f, 0x26, 0x1f, 0x31, 0x44,
+	0xfd, 0x1c, 0xf6, 0x16, 0x06, 0x2e, 0xf0, 0x5c, 0x27, 0x20, 0xe8, 0x11, 0x54, 0xa4, 0xd9, 0x41,
+	0x5b, 0xd9, 0x2f, 0xde, 0xa9, 0xf5, 0x76, 0xba, 0x49, 0xd2, 0x67, 0x7b, 0x6a, 0x31, 0x5d, 0x5d,
+	0x83, 0xd5, 0x17, 0x21, 0x09, 0xc9, 0x31, 0xf1, 0xe8, 0x44, 0x66, 0x42, 0x75, 0xa0, 0x95, 0x08,
+	0x0f, 0x43, 0xe3, 0x15, 0xa1, 0xe8, 0x3b, 0x80, 0x9c, 0x70, 0xaa, 0x4f, 0x08, 0xb6, 0xe9, 0xe4,
+	0x32, 0x0a, 0x88, 0x48, 0x55, 0xcb, 0x09, 0xa7, 0x4f, 0x05, 0x20, 0xc3, 0xb2, 0x0e, 0x4b, 0xc2,
+	0xaf, 0x02, 0xf7, 0x4b, 0x34, 0x58, 0x2e, 0x6d, 0x82, 0x03, 0x62, 0xf2, 0x40, 0x16, 0x35, 0xd9,
+	0x52, 0x9f, 0x01, 0x4a, 0x1b, 0x21, 0xbd, 0xfa, 0x10, 0x96, 0x47, 0x7c, 0xec, 0xc8, 0xa9, 0xad,
+	0x94, 0x53, 0x79, 0xfb, 0xb4, 0x88, 0xab, 0x7e, 0x1f, 0x9a, 0x9f, 0x10, 0x3a, 0xa4, 0x38, 0x99,
+	0x59, 0xef, 0xc1, 0x32, 0x9b, 0xdb, 0xba, 0x65, 0x8a, 0x79, 0x71, 0xd8, 0xf8, 0xdd, 0x57, 0x7b,
+	0xb7, 0xfe, 0xf8, 0xd5, 0x5e, 0xf9, 0xd4, 0x35, 0xc9, 0xe0, 0x58, 0x2b, 0x33, 0x78, 0x60, 0xaa,
+	0x7f, 0x2f, 0x40, 0x2b, 0xe9, 0x2c, 0xed, 0xd8, 0x83, 0x1a, 0x0e, 0x4d, 0x2b, 0xca, 0x94, 0xc2,
+	0x4d, 0x07, 0x2e, 0xe2, 0x19, 0x4a, 0x08, 0x7c, 0x45, 0x70, 0x97, 0x15, 0x49, 0xd0, 0x98, 0x04,
+	0xbd, 0x03, 0xf5, 0xd0, 0x63, 0x0b, 0x42, 0xaa, 0x10, 0xde, 0xd7, 0x84, 0x4c, 0xe8, 0x48, 0x28,
+	0x42, 0x49, 0x89, 0x2b, 0x91, 0x14, 0xa1, 0x65, 0x07, 0xc0, 0xc6, 0x94, 0x38, 0xc6, 0xa5, 0xfe,
+	0xe8, 0x40, 0x4e, 0x98, 0xaa, 0x94, 0x3c, 0x3a, 0x10, 0x1a, 0x6c, 0x17, 0x9b, 0x72, 0x90, 0x72,
+	0x34, 0x08, 0x93, 0x89, 0x41, 0x0e, 0x60, 0x5d, 0x52, 0x82, 0xd0, 0x30, 0x48, 0x10, 0xc8, 0xc1,
+	0x96, 0xf9, 0x60, 0x48, 0x60, 0x43, 0x01, 0x89, 0x31, 0x3f, 0x80, 0x0d, 0xd9, 0xc3, 0x76, 0x9d,
+	0xb1, 0xce, 0xd6, 0x84, 0xec, 0x53, 0xe1, 0x7d, 0xd6, 0x04, 0x7a, 0xe2, 0x3a, 0xe3, 0x73, 0x6c,
+	0xd9, 0xa2, 0xd3, 0x3d, 0x58, 0x95, 0x9d, 0xe8, 0xc4, 0x77, 0xc3, 0xf1, 0xc4, 0x0b, 0x69, 0xbb,
+	0xca, 0xf9, 0x2d, 0x01, 0x9c, 0xc7, 0x72, 0xf5, 0xcf, 0x0a, 0xa0, 0x23, 0x9f, 0x60, 0x4a, 0xfe,
+	0xab, 0x94, 0xe5, 0xb3, 0x53, 0x98, 0xc9, 0x4e, 0x17, 0xd6, 0x04, 0x21, 0xf2, 0x39, 0x9d, 0x83,
+	0x55, 0x0e, 0x49, 0x97, 0xf3, 0x99, 0x10, 0xc4, 0xd2, 0x6c, 0xb2, 0x78, 0x1c, 0x39, 0x25, 0xab,
+	0x53, 0x2e, 0x62, 0x81, 0xa5, 0x95, 0xaa, 0xb7, 0x61, 0x2d, 0xe3, 0xa4, 0x98, 0x5a, 0xea, 0x0f,
+	0x61, 0xfd, 0x13, 0x42, 0x99, 0x47, 0x4c, 0x1e, 0xfe, 0xe7, 0x13, 0xf6, 0xcb, 0x02, 0xdc, 0xce,
+	0x69, 0x90, 0xb3, 0x56, 0x85, 0xba, 0x69, 0x05, 0xaf, 0x43, 0x6c, 0x5b, 0x17, 0x16, 0x11, 0x7a,
+	0x2a, 0x5a, 0x46, 0x86, 0x8e, 0xa0, 0x99, 0x6e, 0xeb, 0x98, 0xca, 0x9d, 0xb1, 0xd3, 0x15, 0xdb,
+	0x79, 0x37, 0xda, 0xce, 0xbb, 0xe7, 0xd1, 0x76, 0xae, 0x35, 0xd2, 0x5d, 0xfa, 0x14, 0x7d, 0x0f,
+	0x36, 0x13, 0x89, 0xc1, 0xcf, 0x04, 0xdd, 0x27, 0x38, 0x70, 0x1d, 0x1e, 0xe3, 0xaa, 0xb6, 0x91,
+	0x87, 0x35, 0x8e, 0xa2, 0x6d, 0xa8, 0x06, 0x61, 0xe0, 0x11, 0xc7, 0x24, 0x26, 0x8f, 0x72, 0x45,
+	0x4b, 0x04, 0xe8, 0x63, 0xa8, 0xc7, 0x0d, 0x1d, 0x8b, 0xd8, 0x5e, 0x6d, 0x58, 0x2d, 0xe6, 0xf7,
+	0x29, 0x9b, 0x83, 0xa2, 0x19, 0xa4, 0xec, 0x29, 0x73, 0x7b, 0x5a, 0x09, 0x20, 0x2c, 0x51, 0xff,
+	0xa6, 0xc0, 0xfa, 0xf0, 0xeb, 0xe4, 0x01, 0xfd, 0x00, 0xca, 0xd8, 0x60, 0xbe, 0xf1, 0x00, 0x36,
+	0x7a, 0xef, 0xa6, 0xb6, 0xaa, 0x79, 0x9a, 0xbb, 0x7d, 0xce, 0xd6, 0x64, 0x2f, 0xb6, 0x33, 0x66,
+	0x62, 0x26, 0x5b, 0xea, 0x19, 0x94, 0x05, 0x13, 0xd5, 0x60, 0x79, 0x70, 0xfa, 0x69, 0xff, 0x64,
+	0x70, 0xdc, 0xba, 0x85, 0x1a, 0x00, 0xc7, 0x83, 0xe1, 0x8b, 0x97, 0xfd, 0x93, 0xc1, 0x93, 0x1f,
+	0xb7, 0x14, 0x06, 0x0e, 0x5f, 0x0e, 0xcf, 0x1e, 0x9f, 0x1e, 0xb7, 0x0a, 0x68, 0x05, 0xaa, 0x2f,
+	0x4f, 0xa3, 0x66, 0x91, 0x35, 0xb5, 0xc7, 0x83, 0xd3, 0xe1, 0x79, 0xff, 0xfc, 0x71, 0xab, 0xa4,
+	0x6e, 0xc2, 0xed, 0xe1, 0xbc, 0x09, 0xa3, 0xde, 0x05, 0xc4, 0xe7, 0x2a, 0x83, 0x62, 0x69, 0xb2,
+	0x91, 0x2b, 0xa9, 0x8d, 0x9c, 0x9d, 0x1a, 0x69, 0xae, 0x38, 0x35, 0x36, 0xf8, 0x64, 0x16, 0xdb,
+	0x31, 0x3b, 0xb1, 0x22, 0xf9, 0x3f, 0x14, 0xb8, 0x9d, 0x03, 0xa4, 0xf2, 0x7e, 0x7e, 0x87, 0x7f,
+	0x2f, 0x15, 0xb6, 0xb9, 0x5d, 0xba, 0xb9, 0xdd, 0xbe, 0xf3, 0x6b, 0x05, 0xca, 0x42, 0x86, 0xee,
+	0x41, 0x55, 0x48, 0x17, 0xa7, 0xab, 0x22, 0x08, 0x03, 0x13, 0xdd, 0x87, 0x15, 0xdf, 0x0d, 0xa9,
+	0xe5, 0x8c, 0x75, 0x96, 0xc2, 0xa0, 0x5d, 0xe0, 0x06, 0x40, 0x97, 0xb5, 0xba, 0x8c, 0xae, 0xd5,
+	0x25, 0x81, 0x35, 0x02, 0xf4, 0x5d, 0xa8, 0x1b, 0xd8, 0x98, 0x10, 0x53, 0xf2, 0x8b, 0x33, 0xfc,
+	0x9a, 0xc0, 0x39, 0x9d, 0x45, 0x28, 0x76, 0x20, 0x8e, 0xd0, 0x53, 0x40, 0x69, 0x61, 0x12, 0x62,
+	0xea, 0x52, 0x6c, 0x47, 0x21, 0xe6, 0x0d, 0xb4, 0x0d, 0x45, 0xcb, 0x14, 0x66, 0xd5, 0x0f, 0x21,
+	0xe5, 0x03, 0x13, 0xab, 0x3d, 0x68, 0xc5, 0x9a, 0xa2, 0xc9, 0xba, 0x0b, 0x85, 0x85, 0x8e, 0x17,
+	0x2c, 0x53, 0x7d, 0x99, 0x32, 0x29, 0x1e, 0xfc, 0x9a, 0x4e, 0x68, 0x1f, 0x96, 0x16, 0xc5, 0x47,
+	0x00, 0xea, 0xdd, 0x38, 0x01, 0xd7, 0x73, 0xbb, 0x00, 0x49, 0x4e, 0x13, 0xbe, 0xb2, 0x88, 0xff,
+	0x0c, 0x9a, 0x67, 0x32, 0x03, 0x37, 0xf4, 0x12, 0xb5, 0x61, 0x19, 0x9b, 0xa6, 0x4f, 0x82, 0x80,
+	0x2f, 0xc5, 0xaa, 0x16, 0x35, 0x55, 0x15, 0x5a, 0x89, 0x32, 0xe9, 0x7e, 0x03, 0x0a, 0xee, 0x2b,
+	0xb9, 0x37, 0x16, 0xdc, 0x57, 0xea, 0xc7, 0xb0, 0x7a, 0xe2, 0xba, 0xaf, 0x42, 0x2f, 0x3d, 0x64,
+	0x23, 0x1e, 0xb2, 0x7a, 0xcd, 0x10, 0x9f, 0x03, 0x4a, 0x77, 0x8f, 0x63, 0x5c, 0x62, 0xee, 0x70,
+	0x0d, 0x59, 0x37, 0xb9, 0x1c, 0xbd, 0x0b, 0xa5, 0x29, 0xa1, 0x38, 0xae, 0x4a, 0x63, 0xfc, 0x47,
+	0x84, 0x62, 0x13, 0x53, 0xac, 0x71, 0x5c, 0xfd, 0x09, 0x34, 0xb9, 0xa3, 0xce, 0x85, 0x7b, 0xd3,
+	0x68, 0xdc, 0xcb, 0x9a, 0x5a, 0xeb, 0xad, 0x26, 0xda, 0xfb, 0x02, 0x48, 0xac, 0xff, 0x52, 0x81,
+	0x56, 0x32, 0x40, 0x7c, 0x8e, 0x94, 0xe8, 0xa5, 0x27, 0x8c, 0x6f, 0xf4, 0x1a, 0x49, 0xf7, 0xf3,
+	0x4b, 0x8f, 0x68, 0x1c, 0x43, 0x5d, 0xa8, 0xb8, 0x1e, 0xf1, 0x31, 0x75, 0xfd, 0x59, 0x27, 0x9e,
+	0x4b, 0x44, 0x8b, 0x39, 0x8c, 0x6f, 0x60, 0x0f, 0x1b, 0x16, 0xbd, 0x6c, 0x17, 0xf3, 0xfc, 0x23,
+	0x89, 0x68, 0x31, 0x87, 0x79, 0xf1, 0x86, 0xf8, 0x6c, 0xc3, 0x6e, 0x97, 0xf2, 0x5e, 0x7c, 0x2a,
+	0x00, 0x2d, 0x62, 0xa8, 0x53, 0x68, 0x3e, 0xb1, 0x1c, 0xf3, 0x94, 0x60, 0xff, 0xa6, 0x51, 0xfa,
+	0x16, 0x2c, 0x05, 0x14, 0xfb, 0xe2, 0xf4, 0x9b, 0xa5, 0x08, 0x30, 0xb9, 0x9f, 0x88, 0xd2, 0x41,
+	0x34, 0xd4, 0x87, 0xd0, 0x4a, 0x86, 0x93, 0x31, 0xbb, 0x7e, 0x21, 0x20, 0x68, 0x1d, 0x87, 0x53,
+	0x2f, 0xb3, 0x7f, 0x7e, 0x08, 0xab, 0x29, 0x59, 0x5e, 0xd5, 0xc2, 0x35, 0xd2, 0x80, 0x7a, 0xba,
+	0x72, 0x52, 0xff, 0xa9, 0xc0, 0x1a, 0x13, 0x0c, 0xc3, 0xe9, 0x14, 0xfb, 0x97, 0xb1, 0xa6, 0x1d,
+	0x80, 0x30, 0x20, 0xa6, 0x1e, 0x78, 0xd8, 0x20, 0x72, 0xaf, 0xa9, 0x32, 0xc9, 0x90, 0x09, 0xd0,
+	0x7b, 0xd0, 0xc4, 0x6f, 0xb0, 0x65, 0xb3, 0x6b, 0x82, 0xe4, 0x88, 0x5a, 0xaa, 0x11, 0x8b, 0x05,
+	0x91, 0xd5, 0x47, 0x4c, 0x8f, 0xe5, 0x8c, 0xf9, 0xbc, 0x8a, 0x8a, 0xd9, 0x80, 0x98, 0x03, 0x21,
+	0x62, 0x35, 0x19, 0xa7, 0x10, 0xc1, 0x10, 0x15, 0x14, 0x1f, 0xfd, 0xb1, 0x20, 0x7c, 0x1b, 0x1a,
+	0x9c, 0x30, 0xc2, 0x8e, 0xf9, 0x33, 0xcb, 0xa4, 0x13, 0x59, 0x3a, 0xad, 0x30, 0xe9, 0x61, 0x24,
+	0x44, 0xf7, 0x61, 0x2d, 0xb1, 0x29, 0xe1, 0x8a, 0xca, 0x16, 0xc5, 0x50, 0xdc, 0x81, 0x87, 0x15,
+	0x07, 0x93, 0x91, 0x8b, 0x7d, 0x33, 0x8a, 0xc7, 0x1f, 0x8a, 0xb0, 0x9a, 0x12, 0xca, 0x68, 0xdc,
+	0xf8, 0x64, 0x7f, 0x1f, 0x5a, 0x9c, 0x68, 0xb8, 0x8e, 0x43, 0xf8, 0x51, 0x1c, 0xc8, 0xc0, 0x34,
+	0x99, 0xfc, 0x28, 0x11, 0xb3, 0x9a, 0x63, 0xe4, 0xba, 0x34, 0xa0, 0x3e, 0xf6, 0xf4, 0x68, 0xd9,
+	0x89, 0xf3, 0xbc, 0x15, 0x03, 0x72, 0xd5, 0x31, 0xbd, 0xfc, 0xc6, 0xe9, 0x60, 0x3b, 0xe6, 0x96,
+	0x38, 0xb7, 0x19, 0xc9, 0x53, 0x54, 0xf2, 0x36, 0x47, 0x5d, 0x12, 0x54, 0xf2, 0x36, 0x4b, 0x7d,
+	0xc8, 0x67, 0x32, 0x0d, 0x78, 0x8c, 0x6a, 0xbd, 0xdd, 0x74, 0x19, 0x32, 0x3b, 0x27, 0x34, 0x41,
+	0x46, 0x0f, 0xa0, 0x2c, 0x6a, 0x56, 0x7e, 0x13, 0xa8, 0xf5, 0xbe, 0x31, 0x53, 0x65, 0x1d, 0xcb,
+	0xdb, 0xbe, 0x26, 0x89, 0xe8, 0x23, 0xa8, 0xf1, 0x7b, 0xaf, 0x67, 0x39, 0x63, 0x62, 0xb6, 0x2b,
+	0xd7, 0x56, 0x67, 0xc0, 0xe8, 0x67, 0x9c, 0xcd, 0x6a, 0x3b, 0xde, 0xf9, 0x75, 0x48, 0x7c, 0x56,
+	0x9b, 0x56, 0xaf, 0xaf, 0xed, 0x18, 0xff, 0x85, 0xa0, 0xab, 0x5d, 0xd8, 0x38, 0x72, 0x7d, 0x3f,
+	0xf4, 0x28, 0x31, 0xc5, 0x7d, 0xf3, 0xca, 0x27, 0x04, 0xf5, 0xf7, 0x0a, 0x34, 0xb2, 0x1d, 0xd0,
+	0x03, 0xa8, 0x07, 0x98, 0x12, 0xdb, 0xb6, 0xe8, 0x15, 0x73, 0xa0, 0x16, 0x73, 0x06, 0x26, 0xba,
+	0x0b, 0x15, 0x7e, 0xe9, 0x65, 0x74, 0xb1, 0x4f, 0x34, 0x25, 0x7d, 0x99, 0xeb, 0x1c, 0x1c, 0x6b,
+	0xcb, 0x9c, 0x30, 0x30, 0xd9, 0x5a, 0x13, 0xdc, 0xc0, 0xfa, 0x82, 0xc8, 0x15, 0x52, 0xe5, 0x92,
+	0xa1, 0xf5, 0x05, 0x0f, 0x9e, 0x49, 0x28, 0x31, 0xa8, 0x28, 0x6d, 0x4b, 0xd7, 0x07, 0x2f, 0xa2,
+	0xf7, 0xa9, 0x7a, 0x02, 0x9b, 0x33, 0xde, 0xcb, 0x49, 0xfd, 0x00, 0xca, 0xf1, 0xbd, 0xbc, 0xc8,
+	0xf3, 0x98, 0xa4, 0x3f, 0xdb, 0x47, 0x93, 0x44, 0xf5, 0x37, 0xbc, 0xf4, 0xe5, 0x8f, 0x01, 0xe2,
+	0x06, 0x1f, 0x85, 0x72, 0x03, 0xca, 0xa2, 0x58, 0x92, 0x4f, 0x29, 0xb2, 0xc5, 0x96, 0x2e, 0x71,
+	0x0c, 0xff, 0x92, 0xa9, 0xd2, 0xf9, 0x53, 0x0b, 0x0f, 0x86, 0xb6, 0x12, 0x4b, 0xcf, 0xd8, 0x9b,
+	0xcb, 0x37, 0x21, 0x7a, 0x49, 0xd1, 0x2d, 0xc7, 0x24, 0x6f, 0x65, 0x10, 0xea, 0x52, 0x38, 0x60,
+	0x32, 0x1e, 0x26, 0xdf, 0xfd, 0x29, 0x31, 0x78, 0xc9, 0x56, 0xe2, 0x7a, 0xaa, 0x52, 0x32, 0x30,
+	0xd5, 0x13, 0x58, 0xc9, 0x98, 0xc6, 0xb6, 0x1e, 0xd7, 0xb1, 0x2d, 0x87, 0xe8, 0xd1, 0x9e, 0xc8,
+	0xb2, 0x5c, 0x13, 0x32, 0x51, 0xa6, 0xb5, 0x61, 0x59, 0x0e, 0x21, 0xed, 0x8a, 0x9a, 0xea, 0xcf,
+	0x15, 0x56, 0xf9, 0x66, 0x3c, 0x95, 0x61, 0x3b, 0x80, 0xb2, 0x78, 0xd6, 0x90, 0x27, 0x74, 0x3b,
+	0x53, 0xbc, 0xa7, 0x7b, 0x48, 0x1e, 0xfa, 0x08, 0xc0, 0x27, 0x66, 0xe8, 0x98, 0xd8, 0x31, 0x2e,
+	0xe5, 0x91, 0xb7, 0x95, 0x7a, 0x4d, 0xd2, 0x62, 0x70, 0x68, 0x4c, 0xc8, 0x94, 0x68, 0x29, 0xba,
+	0xfa, 0x57, 0x05, 0xd6, 0x9e, 0x8f, 0x98, 0x8f, 0xd9, 0x88, 0xcf, 0x46, 0x56, 0x99, 0x17, 0xd9,
+	0x24, 0x31, 0x85, 0x4c, 0x62, 0xb2, 0xc1, 0x2c, 0xe6, 0x82, 0xc9, 0xae, 0xc1, 0xfc, 0x18, 0xd3,
+	0xf1, 0x05, 0x25, 0xbe, 0x1e, 0x05, 0x49, 0x3e, 0x54, 0x71, 0xa8, 0xcf, 0x10, 0xe9, 0x30, 0x7b,
+	0xef, 0x21, 0x8e, 0xa9, 0x8f, 0xc8, 0x85, 0xeb, 0x93, 0x98, 0x2e, 0xb6, 0xe9, 0x16, 0x71, 0xcc,
+	0x43, 0x0e, 0x44, 0xec, 0x78, 0xe1, 0x95, 0xd3, 0x0b, 0xef, 0x97, 0x0a, 0xac, 0x67, 0x3d, 0x95,
+	0x11, 0x7f, 0x38, 0xf3, 0x60, 0xb5, 0x38, 0xe6, 0x31, 0xf3, 0x6b, 0x45, 0xbd, 0xf7, 0xab, 0x12,
+	0xd4, 0x9f, 0x61, 0x73, 0x10, 0x8d, 0x82, 0x06, 0x00, 0xc9, 0x1d, 0x06, 0x6d, 0x67, 0x96, 0x4a,
+	0xee, 0x6a, 0xd3, 0xd9, 0x59, 0x80, 0x4a, 0x77, 0x8e, 0xa0, 0x12, 0x55, 0x96, 0xa8, 0x93, 0xa2,
+	0xe6, 0x6a, 0xd7, 0xce, 0xd6, 0x5c, 0x4c, 0x2a, 0x19, 0x00, 0x24, 0xb5, 0x63, 0xc6, 0x9e, 0x99,
+	0x8a, 0xb4, 0xb3, 0xb3, 0x00, 0x4d, 0xec, 0x89, 0xea, 0xb8, 0x8c, 0x3d, 0xb9, 0xea, 0xb1, 0xb3,
+	0x35, 0x17, 0x4b, 0x94, 0x44, 0x85, 0x4d, 0x46, 0x49, 0xae, 0xb8, 0xea, 0x6c, 0xcd, 0xc5, 0xa4,
+	0x92, 0x27, 0x50, 0x8d, 0x6b, 0x1a, 0x94, 0x66, 0xe6, 0xab, 0x9f, 0xce, 0xf6, 0x7c, 0x50, 0xea,
+	0xd1, 0x60, 0x25, 0x73, 0x1f, 0x44, 0x7b, 0x8b, 0x6f, 0x8a, 0x42, 0xdf, 0xfe, 0x75, 0x57, 0xc9,
+	0xde, 0x9f, 0x8a, 0xd0, 0x7a, 0xfe, 0x86, 0xf8, 0x36, 0xbe, 0xfc, 0xbf, 0xcc, 0x8a, 0xff, 0x95,
+	0xef, 0x47, 0x50, 0x89, 0xde, 0x24, 0x33, 0x89, 0xc8, 0xbd, 0x72, 0x76, 0xb6, 0xe6, 0x62, 0x52,
+	0xc9, 0x09, 0xd4, 0x52, 0x0f, 0x50, 0x28, 0x63, 0xfa, 0xcc, 0xeb, 0x5b, 0x67, 0x77, 0x11, 0x9c,
+	0x49, 0x47, 0xf2, 0x88, 0x90, 0x4f, 0xc7, 0xcc, 0x7b, 0x47, 0x67, 0x7f, 0x31, 0x21, 0xd1, 0x39,
+	0x5c, 0xa8, 0x73, 0x78, 0x9d, 0xce, 0xb9, 0x6f, 0x1a, 0xbd, 0x7f, 0x29, 0xb0, 0xc6, 0xcf, 0xbb,
+	0x21, 0x75, 0x7d, 0x92, 0x64, 0xf9, 0x10, 0x96, 0x44, 0x1c, 0x36, 0x73, 0x05, 0xd2, 0xdc, 0x08,
+	0xcc, 0xa9, 0x9c, 0xd4, 0x5b, 0xe8, 0x29, 0x54, 0xe3, 0xb2, 0x32, 0x9b, 0xde, 0x5c, 0x05, 0xda,
+	0xd9, 0x9e, 0x0f, 0xc6, 0x9a, 0x3e, 0x83, 0x66, 0xee, 0x44, 0x47, 0xef, 0x2c, 0x3c, 0xb9, 0x63,
+	0x0b, 0xd5, 0xab, 0x28, 0x91, 0xee, 0xde, 0x2f, 0x14, 0x58, 0x4f, 0xfd, 0x00, 0x48, 0x42, 0xe0,
+	0xc1, 0xe6, 0x82, 0xdf, 0x0a, 0xe8, 0xfd, 0xf4, 0xee, 0x72, 0xe5, 0x3f, 0x9b, 0xce, 0xdd, 0x9b,
+	0x50, 0x65, 0x32, 0x30, 0xac, 0x8b, 0x7f, 0x21, 0xfc, 0xed, 0x3e, 0xb3, 0xe4, 0x92, 0xd7, 0xfc,
+	0xcc, 0x92, 0x9b, 0xf9, 0x33, 0xd1, 0xd9, 0x59, 0x80, 0xca, 0x21, 0x7e, 0xab, 0x40, 0x53, 0x1c,
+	0x1b, 0x89, 0xfa, 0x17, 0x50, 0x4f, 0x9f, 0x41, 0x28, 0x9d, 0xd9, 0x39, 0xc7, 0x70, 0x67, 0x6f,
+	0x21, 0x1e, 0x27, 0xec, 0x3c, 0x5f, 0x98, 0xec, 0x2d, 0x3c, 0xbd, 0xe6, 0x4e, 0xd5, 0x39, 0x45,
+	0x88, 0x7a, 0xeb, 0xb0, 0xf4, 0x59, 0xc1, 0x1b, 0x8d, 0xca, 0xbc, 0xfc, 0xfb, 0xe0, 0xdf, 0x03,
+	0x00, 0xd3, 0x21, 0x98, 0xa2, 0xb6, 0x1b, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2151,6 +2706,10 @@ type OverlayInspectorClient interface {
 	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
 	// CreateStats creates a node with specified stats
 	CreateStats(ctx context.Context, in *CreateStatsRequest, opts ...grpc.CallOption) (*CreateStatsResponse, error)
+	// GetNodeStatus returns the disqualification and suspension status of a node
+	GetNodeStatus(ctx context.Context, in *GetNodeStatusRequest, opts ...grpc.CallOption) (*GetNodeStatusResponse, error)
+	// SetNodeStatus overrides the disqualification and suspension status of a node
+	SetNodeStatus(ctx context.Context, in *SetNodeStatusRequest, opts ...grpc.CallOption) (*SetNodeStatusResponse, error)
 }
 
 type overlayInspectorClient struct {
@@ -2197,6 +2756,24 @@ func (c *overlayInspectorClient) CreateStats(ctx context.Context, in *CreateStat
 	return out, nil
 }
 
+func (c *overlayInspectorClient) GetNodeStatus(ctx context.Context, in *GetNodeStatusRequest, opts ...grpc.CallOption) (*GetNodeStatusResponse, error) {
+	out := new(GetNodeStatusResponse)
+	err := c.cc.Invoke(ctx, "/inspector.OverlayInspector/GetNodeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *overlayInspectorClient) SetNodeStatus(ctx context.Context, in *SetNodeStatusRequest, opts ...grpc.CallOption) (*SetNodeStatusResponse, error) {
+	out := new(SetNodeStatusResponse)
+	err := c.cc.Invoke(ctx, "/inspector.OverlayInspector/SetNodeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OverlayInspectorServer is the server API for OverlayInspector service.
 type OverlayInspectorServer interface {
 	// CountNodes returns the number of nodes in the cache
@@ -2207,6 +2784,10 @@ type OverlayInspectorServer interface {
 	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
 	// CreateStats creates a node with specified stats
 	CreateStats(context.Context, *CreateStatsRequest) (*CreateStatsResponse, error)
+	// GetNodeStatus returns the disqualification and suspension status of a node
+	GetNodeStatus(context.Context, *GetNodeStatusRequest) (*GetNodeStatusResponse, error)
+	// SetNodeStatus overrides the disqualification and suspension status of a node
+	SetNodeStatus(context.Context, *SetNodeStatusRequest) (*SetNodeStatusResponse, error)
 }
 
 func RegisterOverlayInspectorServer(s *grpc.Server, srv OverlayInspectorServer) {
@@ -2285,6 +2866,42 @@ func _OverlayInspector_CreateStats_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OverlayInspector_GetNodeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OverlayInspectorServer).GetNodeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.OverlayInspector/GetNodeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OverlayInspectorServer).GetNodeStatus(ctx, req.(*GetNodeStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OverlayInspector_SetNodeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetNodeStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OverlayInspectorServer).SetNodeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.OverlayInspector/SetNodeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OverlayInspectorServer).SetNodeStatus(ctx, req.(*SetNodeStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _OverlayInspector_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "inspector.OverlayInspector",
 	HandlerType: (*OverlayInspectorServer)(nil),
@@ -2305,6 +2922,14 @@ var _OverlayInspector_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateStats",
 			Handler:    _OverlayInspector_CreateStats_Handler,
 		},
+		{
+			MethodName: "GetNodeStatus",
+			Handler:    _OverlayInspector_GetNodeStatus_Handler,
+		},
+		{
+			MethodName: "SetNodeStatus",
+			Handler:    _OverlayInspector_SetNodeStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "inspector.proto",
@@ -2318,6 +2943,8 @@ type PieceStoreInspectorClient interface {
 	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatSummaryResponse, error)
 	// Dashboard returns stats for a specific storagenode
 	Dashboard(ctx context.Context, in *DashboardRequest, opts ...grpc.CallOption) (*DashboardResponse, error)
+	// CorruptedPieces returns pieces that failed integrity verification
+	CorruptedPieces(ctx context.Context, in *CorruptedPiecesRequest, opts ...grpc.CallOption) (*CorruptedPiecesResponse, error)
 }
 
 type pieceStoreInspectorClient struct {
@@ -2346,12 +2973,23 @@ func (c *pieceStoreInspectorClient) Dashboard(ctx context.Context, in *Dashboard
 	return out, nil
 }
 
+func (c *pieceStoreInspectorClient) CorruptedPieces(ctx context.Context, in *CorruptedPiecesRequest, opts ...grpc.CallOption) (*CorruptedPiecesResponse, error) {
+	out := new(CorruptedPiecesResponse)
+	err := c.cc.Invoke(ctx, "/inspector.PieceStoreInspector/CorruptedPieces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PieceStoreInspectorServer is the server API for PieceStoreInspector service.
 type PieceStoreInspectorServer interface {
 	// Stats return space and bandwidth stats for a storagenode
 	Stats(context.Context, *StatsRequest) (*StatSummaryResponse, error)
 	// Dashboard returns stats for a specific storagenode
 	Dashboard(context.Context, *DashboardRequest) (*DashboardResponse, error)
+	// CorruptedPieces returns pieces that failed integrity verification
+	CorruptedPieces(context.Context, *CorruptedPiecesRequest) (*CorruptedPiecesResponse, error)
 }
 
 func RegisterPieceStoreInspectorServer(s *grpc.Server, srv PieceStoreInspectorServer) {
@@ -2394,6 +3032,24 @@ func _PieceStoreInspector_Dashboard_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PieceStoreInspector_CorruptedPieces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CorruptedPiecesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PieceStoreInspectorServer).CorruptedPieces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.PieceStoreInspector/CorruptedPieces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PieceStoreInspectorServer).CorruptedPieces(ctx, req.(*CorruptedPiecesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _PieceStoreInspector_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "inspector.PieceStoreInspector",
 	HandlerType: (*PieceStoreInspectorServer)(nil),
@@ -2406,6 +3062,10 @@ var _PieceStoreInspector_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Dashboard",
 			Handler:    _PieceStoreInspector_Dashboard_Handler,
 		},
+		{
+			MethodName: "CorruptedPieces",
+			Handler:    _PieceStoreInspector_CorruptedPieces_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "inspector.proto",
@@ -2477,6 +3137,72 @@ var _IrreparableInspector_serviceDesc = grpc.ServiceDesc{
 	Metadata: "inspector.proto",
 }
 
+// RepairQueueInspectorClient is the client API for RepairQueueInspector service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RepairQueueInspectorClient interface {
+	// QueueDepth returns the number of queued segments by health
+	QueueDepth(ctx context.Context, in *QueueDepthRequest, opts ...grpc.CallOption) (*QueueDepthResponse, error)
+}
+
+type repairQueueInspectorClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRepairQueueInspectorClient(cc *grpc.ClientConn) RepairQueueInspectorClient {
+	return &repairQueueInspectorClient{cc}
+}
+
+func (c *repairQueueInspectorClient) QueueDepth(ctx context.Context, in *QueueDepthRequest, opts ...grpc.CallOption) (*QueueDepthResponse, error) {
+	out := new(QueueDepthResponse)
+	err := c.cc.Invoke(ctx, "/inspector.RepairQueueInspector/QueueDepth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RepairQueueInspectorServer is the server API for RepairQueueInspector service.
+type RepairQueueInspectorServer interface {
+	// QueueDepth returns the number of queued segments by health
+	QueueDepth(context.Context, *QueueDepthRequest) (*QueueDepthResponse, error)
+}
+
+func RegisterRepairQueueInspectorServer(s *grpc.Server, srv RepairQueueInspectorServer) {
+	s.RegisterService(&_RepairQueueInspector_serviceDesc, srv)
+}
+
+func _RepairQueueInspector_QueueDepth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueueDepthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepairQueueInspectorServer).QueueDepth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.RepairQueueInspector/QueueDepth",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepairQueueInspectorServer).QueueDepth(ctx, req.(*QueueDepthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _RepairQueueInspector_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "inspector.RepairQueueInspector",
+	HandlerType: (*RepairQueueInspectorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "QueueDepth",
+			Handler:    _RepairQueueInspector_QueueDepth_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "inspector.proto",
+}
+
 // HealthInspectorClient is the client API for HealthInspector service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
@@ -24,6 +24,37 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+type PieceUploadStats_Status int32
+
+const (
+	PieceUploadStats_INVALID            PieceUploadStats_Status = 0
+	PieceUploadStats_SUCCEEDED          PieceUploadStats_Status = 1
+	PieceUploadStats_FAILED             PieceUploadStats_Status = 2
+	PieceUploadStats_LONG_TAIL_CANCELED PieceUploadStats_Status = 3
+)
+
+var PieceUploadStats_Status_name = map[int32]string{
+	0: "INVALID",
+	1: "SUCCEEDED",
+	2: "FAILED",
+	3: "LONG_TAIL_CANCELED",
+}
+
+var PieceUploadStats_Status_value = map[string]int32{
+	"INVALID":            0,
+	"SUCCEEDED":          1,
+	"FAILED":             2,
+	"LONG_TAIL_CANCELED": 3,
+}
+
+func (x PieceUploadStats_Status) String() string {
+	return proto.EnumName(PieceUploadStats_Status_name, int32(x))
+}
+
+func (PieceUploadStats_Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_631e2f30a93cd64e, []int{4, 0}
+}
+
 type AddressedOrderLimit struct {
 	Limit                *OrderLimit2 `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
 	StorageNodeAddress   *NodeAddress `protobuf:"bytes,2,opt,name=storage_node_address,json=storageNodeAddress,proto3" json:"storage_node_address,omitempty"`
@@ -188,14 +219,15 @@ func (m *SegmentWriteResponse) GetAddressedLimits() []*AddressedOrderLimit {
 }
 
 type SegmentCommitRequest struct {
-	Bucket               []byte         `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
-	Path                 []byte         `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
-	Segment              int64          `protobuf:"varint,3,opt,name=segment,proto3" json:"segment,omitempty"`
-	Pointer              *Pointer       `protobuf:"bytes,4,opt,name=pointer,proto3" json:"pointer,omitempty"`
-	OriginalLimits       []*OrderLimit2 `protobuf:"bytes,5,rep,name=original_limits,json=originalLimits,proto3" json:"original_limits,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Bucket               []byte              `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Path                 []byte              `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Segment              int64               `protobuf:"varint,3,opt,name=segment,proto3" json:"segment,omitempty"`
+	Pointer              *Pointer            `protobuf:"bytes,4,opt,name=pointer,proto3" json:"pointer,omitempty"`
+	OriginalLimits       []*OrderLimit2      `protobuf:"bytes,5,rep,name=original_limits,json=originalLimits,proto3" json:"original_limits,omitempty"`
+	UploadStats          []*PieceUploadStats `protobuf:"bytes,6,rep,name=upload_stats,json=uploadStats,proto3" json:"upload_stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *SegmentCommitRequest) Reset()         { *m = SegmentCommitRequest{} }
@@ -257,6 +289,69 @@ func (m *SegmentCommitRequest) GetOriginalLimits() []*OrderLimit2 {
 	return nil
 }
 
+func (m *SegmentCommitRequest) GetUploadStats() []*PieceUploadStats {
+	if m != nil {
+		return m.UploadStats
+	}
+	return nil
+}
+
+// PieceUploadStats describes how the upload of a piece to a storage node went
+type PieceUploadStats struct {
+	NodeId               NodeID                  `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Status               PieceUploadStats_Status `protobuf:"varint,2,opt,name=status,proto3,enum=metainfo.PieceUploadStats_Status" json:"status,omitempty"`
+	PieceSize            int64                   `protobuf:"varint,3,opt,name=piece_size,json=pieceSize,proto3" json:"piece_size,omitempty"`
+	DurationMs           int64                   `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *PieceUploadStats) Reset()         { *m = PieceUploadStats{} }
+func (m *PieceUploadStats) String() string { return proto.CompactTextString(m) }
+func (*PieceUploadStats) ProtoMessage()    {}
+func (*PieceUploadStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_631e2f30a93cd64e, []int{4}
+}
+func (m *PieceUploadStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceUploadStats.Unmarshal(m, b)
+}
+func (m *PieceUploadStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceUploadStats.Marshal(b, m, deterministic)
+}
+func (m *PieceUploadStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceUploadStats.Merge(m, src)
+}
+func (m *PieceUploadStats) XXX_Size() int {
+	return xxx_messageInfo_PieceUploadStats.Size(m)
+}
+func (m *PieceUploadStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceUploadStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceUploadStats proto.InternalMessageInfo
+
+func (m *PieceUploadStats) GetStatus() PieceUploadStats_Status {
+	if m != nil {
+		return m.Status
+	}
+	return PieceUploadStats_INVALID
+}
+
+func (m *PieceUploadStats) GetPieceSize() int64 {
+	if m != nil {
+		return m.PieceSize
+	}
+	return 0
+}
+
+func (m *PieceUploadStats) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
+	}
+	return 0
+}
+
 type SegmentCommitResponse struct {
 	Pointer              *Pointer `protobuf:"bytes,1,opt,name=pointer,proto3" json:"pointer,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -268,7 +363,7 @@ func (m *SegmentCommitResponse) Reset()         { *m = SegmentCommitResponse{} }
 func (m *SegmentCommitResponse) String() string { return proto.CompactTextString(m) }
 func (*SegmentCommitResponse) ProtoMessage()    {}
 func (*SegmentCommitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e2f30a93cd64e, []int{4}
+	return fileDescriptor_631e2f30a93cd64e, []int{5}
 }
 func (m *SegmentCommitResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentCommitResponse.Unmarshal(m, b)
@@ -308,7 +403,7 @@ func (m *SegmentDownloadRequest) Reset()         { *m = SegmentDownloadRequest{}
 func (m *SegmentDownloadRequest) String() string { return proto.CompactTextString(m) }
 func (*SegmentDownloadRequest) ProtoMessage()    {}
 func (*SegmentDownloadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e2f30a93cd64e, []int{5}
+	return fileDescriptor_631e2f30a93cd64e, []int{6}
 }
 func (m *SegmentDownloadRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentDownloadRequest.Unmarshal(m, b)
@@ -361,7 +456,7 @@ func (m *SegmentDownloadResponse) Reset()         { *m = SegmentDownloadResponse
 func (m *SegmentDownloadResponse) String() string { return proto.CompactTextString(m) }
 func (*SegmentDownloadResponse) ProtoMessage()    {}
 func (*SegmentDownloadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e2f30a93cd64e, []int{6}
+	return fileDescriptor_631e2f30a93cd64e, []int{7}
 }
 func (m *SegmentDownloadResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentDownloadResponse.Unmarshal(m, b)
@@ -408,7 +503,7 @@ func (m *SegmentInfoRequest) Reset()         { *m = SegmentInfoRequest{} }
 func (m *SegmentInfoRequest) String() string { return proto.CompactTextString(m) }
 func (*SegmentInfoRequest) ProtoMessage()    {}
 func (*SegmentInfoRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e2f30a93cd64e, []int{7}
+	return fileDescriptor_631e2f30a93cd64e, []int{8}
 }
 func (m *SegmentInfoRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentInfoRequest.Unmarshal(m, b)
@@ -460,7 +555,7 @@ func (m *SegmentInfoResponse) Reset()         { *m = SegmentInfoResponse{} }
 func (m *SegmentInfoResponse) String() string { return proto.CompactTextString(m) }
 func (*SegmentInfoResponse) ProtoMessage()    {}
 func (*SegmentInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e2f30a93cd64e, []int{8}
+	return fileDescriptor_631e2f30a93cd64e, []int{9}
 }
 func (m *SegmentInfoResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentInfoResponse.Unmarshal(m, b)
@@ -500,7 +595,7 @@ func (m *SegmentDeleteRequest) Reset()         { *m = SegmentDeleteRequest{} }
 func (m *SegmentDeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*SegmentDeleteRequest) ProtoMessage()    {}
 func (*SegmentDeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e2f30a93cd64e, []int{9}
+	return fileDescriptor_631e2f30a93cd64e, []int{10}
 }
 func (m *SegmentDeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentDeleteRequest.Unmarshal(m, b)
@@ -552,7 +647,7 @@ func (m *SegmentDeleteResponse) Reset()         { *m = SegmentDeleteResponse{} }
 func (m *SegmentDeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*SegmentDeleteResponse) ProtoMessage()    {}
 func (*SegmentDeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e2f30a93cd64e, []int{10}
+	return fileDescriptor_631e2f30a93cd64e, []int{11}
 }
 func (m *SegmentDeleteResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SegmentDeleteResponse.Unmarshal(m, b)
@@ -579,6 +674,116 @@ func (m *SegmentDeleteResponse) GetAddressedLimits() []*AddressedOrderLimit {
 	return nil
 }
 
+// SegmentMetadataUpdateRequest replaces the metadata of a segment, as long
+// as the segment was not replaced since it was created at creation_date
+type SegmentMetadataUpdateRequest struct {
+	Bucket               []byte               `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Path                 []byte               `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Segment              int64                `protobuf:"varint,3,opt,name=segment,proto3" json:"segment,omitempty"`
+	Metadata             []byte               `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	CreationDate         *timestamp.Timestamp `protobuf:"bytes,5,opt,name=creation_date,json=creationDate,proto3" json:"creation_date,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SegmentMetadataUpdateRequest) Reset()         { *m = SegmentMetadataUpdateRequest{} }
+func (m *SegmentMetadataUpdateRequest) String() string { return proto.CompactTextString(m) }
+func (*SegmentMetadataUpdateRequest) ProtoMessage()    {}
+func (*SegmentMetadataUpdateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_631e2f30a93cd64e, []int{12}
+}
+func (m *SegmentMetadataUpdateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentMetadataUpdateRequest.Unmarshal(m, b)
+}
+func (m *SegmentMetadataUpdateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentMetadataUpdateRequest.Marshal(b, m, deterministic)
+}
+func (m *SegmentMetadataUpdateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentMetadataUpdateRequest.Merge(m, src)
+}
+func (m *SegmentMetadataUpdateRequest) XXX_Size() int {
+	return xxx_messageInfo_SegmentMetadataUpdateRequest.Size(m)
+}
+func (m *SegmentMetadataUpdateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentMetadataUpdateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentMetadataUpdateRequest proto.InternalMessageInfo
+
+func (m *SegmentMetadataUpdateRequest) GetBucket() []byte {
+	if m != nil {
+		return m.Bucket
+	}
+	return nil
+}
+
+func (m *SegmentMetadataUpdateRequest) GetPath() []byte {
+	if m != nil {
+		return m.Path
+	}
+	return nil
+}
+
+func (m *SegmentMetadataUpdateRequest) GetSegment() int64 {
+	if m != nil {
+		return m.Segment
+	}
+	return 0
+}
+
+func (m *SegmentMetadataUpdateRequest) GetMetadata() []byte {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *SegmentMetadataUpdateRequest) GetCreationDate() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreationDate
+	}
+	return nil
+}
+
+type SegmentMetadataUpdateResponse struct {
+	Pointer              *Pointer `protobuf:"bytes,1,opt,name=pointer,proto3" json:"pointer,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SegmentMetadataUpdateResponse) Reset()         { *m = SegmentMetadataUpdateResponse{} }
+func (m *SegmentMetadataUpdateResponse) String() string { return proto.CompactTextString(m) }
+func (*SegmentMetadataUpdateResponse) ProtoMessage()    {}
+func (*SegmentMetadataUpdateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_631e2f30a93cd64e, []int{13}
+}
+func (m *SegmentMetadataUpdateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentMetadataUpdateResponse.Unmarshal(m, b)
+}
+func (m *SegmentMetadataUpdateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentMetadataUpdateResponse.Marshal(b, m, deterministic)
+}
+func (m *SegmentMetadataUpdateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentMetadataUpdateResponse.Merge(m, src)
+}
+func (m *SegmentMetadataUpdateResponse) XXX_Size() int {
+	return xxx_messageInfo_SegmentMetadataUpdateResponse.Size(m)
+}
+func (m *SegmentMetadataUpdateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentMetadataUpdateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentMetadataUpdateResponse proto.InternalMessageInfo
+
+func (m *SegmentMetadataUpdateResponse) GetPointer() *Pointer {
+	if m != nil {
+		return m.Pointer
+	}
+	return nil
+}
+
 type ListSegmentsRequest struct {
 	Bucket               []byte   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
 	Prefix               []byte   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
@@ -596,7 +801,7 @@ func (m *ListSegmentsRequest) Reset()         { *m = ListSegmentsRequest{} }
 func (m *ListSegmentsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListSegmentsRequest) ProtoMessage()    {}
 func (*ListSegmentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e2f30a93cd64e, []int{11}
+	return fileDescriptor_631e2f30a93cd64e, []int{14}
 }
 func (m *ListSegmentsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListSegmentsRequest.Unmarshal(m, b)
@@ -677,7 +882,7 @@ func (m *ListSegmentsResponse) Reset()         { *m = ListSegmentsResponse{} }
 func (m *ListSegmentsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListSegmentsResponse) ProtoMessage()    {}
 func (*ListSegmentsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e2f30a93cd64e, []int{12}
+	return fileDescriptor_631e2f30a93cd64e, []int{15}
 }
 func (m *ListSegmentsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListSegmentsResponse.Unmarshal(m, b)
@@ -724,7 +929,7 @@ func (m *ListSegmentsResponse_Item) Reset()         { *m = ListSegmentsResponse_
 func (m *ListSegmentsResponse_Item) String() string { return proto.CompactTextString(m) }
 func (*ListSegmentsResponse_Item) ProtoMessage()    {}
 func (*ListSegmentsResponse_Item) Descriptor() ([]byte, []int) {
-	return fileDescriptor_631e2f30a93cd64e, []int{12, 0}
+	return fileDescriptor_631e2f30a93cd64e, []int{15, 0}
 }
 func (m *ListSegmentsResponse_Item) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ListSegmentsResponse_Item.Unmarshal(m, b)
@@ -766,10 +971,12 @@ func (m *ListSegmentsResponse_Item) GetIsPrefix() bool {
 }
 
 func init() {
+	proto.RegisterEnum("metainfo.PieceUploadStats_Status", PieceUploadStats_Status_name, PieceUploadStats_Status_value)
 	proto.RegisterType((*AddressedOrderLimit)(nil), "metainfo.AddressedOrderLimit")
 	proto.RegisterType((*SegmentWriteRequest)(nil), "metainfo.SegmentWriteRequest")
 	proto.RegisterType((*SegmentWriteResponse)(nil), "metainfo.SegmentWriteResponse")
 	proto.RegisterType((*SegmentCommitRequest)(nil), "metainfo.SegmentCommitRequest")
+	proto.RegisterType((*PieceUploadStats)(nil), "metainfo.PieceUploadStats")
 	proto.RegisterType((*SegmentCommitResponse)(nil), "metainfo.SegmentCommitResponse")
 	proto.RegisterType((*SegmentDownloadRequest)(nil), "metainfo.SegmentDownloadRequest")
 	proto.RegisterType((*SegmentDownloadResponse)(nil), "metainfo.SegmentDownloadResponse")
@@ -777,6 +984,8 @@ func init() {
 	proto.RegisterType((*SegmentInfoResponse)(nil), "metainfo.SegmentInfoResponse")
 	proto.RegisterType((*SegmentDeleteRequest)(nil), "metainfo.SegmentDeleteRequest")
 	proto.RegisterType((*SegmentDeleteResponse)(nil), "metainfo.SegmentDeleteResponse")
+	proto.RegisterType((*SegmentMetadataUpdateRequest)(nil), "metainfo.SegmentMetadataUpdateRequest")
+	proto.RegisterType((*SegmentMetadataUpdateResponse)(nil), "metainfo.SegmentMetadataUpdateResponse")
 	proto.RegisterType((*ListSegmentsRequest)(nil), "metainfo.ListSegmentsRequest")
 	proto.RegisterType((*ListSegmentsResponse)(nil), "metainfo.ListSegmentsResponse")
 	proto.RegisterType((*ListSegmentsResponse_Item)(nil), "metainfo.ListSegmentsResponse.Item")
@@ -785,61 +994,76 @@ func init() {
 func init() { proto.RegisterFile("metainfo.proto", fileDescriptor_631e2f30a93cd64e) }
 
 var fileDescriptor_631e2f30a93cd64e = []byte{
-	// 856 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0x67, 0xed, 0x38, 0x4e, 0x9e, 0x9d, 0x1a, 0x26, 0x21, 0x5d, 0x6d, 0x1b, 0x6c, 0x96, 0x4b,
-	0x90, 0xd0, 0x56, 0x4a, 0x4f, 0x50, 0x2e, 0x4d, 0x52, 0x44, 0x50, 0x5b, 0xa2, 0x09, 0x02, 0xa9,
-	0x42, 0xac, 0xc6, 0xde, 0xe7, 0xed, 0x08, 0xef, 0xce, 0x32, 0x33, 0x86, 0xb4, 0x77, 0x3e, 0x40,
-	0x0f, 0x7c, 0xa7, 0x1e, 0xf8, 0x00, 0x88, 0x43, 0xbe, 0x03, 0xdf, 0x00, 0xed, 0xec, 0x8c, 0xbd,
-	0x89, 0x6d, 0x42, 0x25, 0xdf, 0xe6, 0xbd, 0xf9, 0xcd, 0xfb, 0xf7, 0x7b, 0xef, 0x0d, 0xdc, 0xc9,
-	0x50, 0x33, 0x9e, 0x8f, 0x45, 0x54, 0x48, 0xa1, 0x05, 0xd9, 0x72, 0x72, 0x00, 0xa9, 0x48, 0xad,
-	0x36, 0xe8, 0xa7, 0x42, 0xa4, 0x13, 0x7c, 0x60, 0xa4, 0xe1, 0x74, 0xfc, 0x40, 0xf3, 0x0c, 0x95,
-	0x66, 0x59, 0x61, 0x01, 0x90, 0x8b, 0x04, 0xed, 0xb9, 0x57, 0x08, 0x9e, 0x6b, 0x94, 0xc9, 0xd0,
-	0x2a, 0xba, 0x42, 0x26, 0x28, 0x55, 0x25, 0x85, 0xbf, 0x7b, 0xb0, 0xfb, 0x38, 0x49, 0x24, 0x2a,
-	0x85, 0xc9, 0xb7, 0xe5, 0xcd, 0x53, 0x9e, 0x71, 0x4d, 0x3e, 0x85, 0xd6, 0xa4, 0x3c, 0xf8, 0xde,
-	0xc0, 0x3b, 0xec, 0x1c, 0xed, 0x46, 0xf6, 0xd5, 0x1c, 0x72, 0x44, 0x2b, 0x04, 0x39, 0x81, 0x3d,
-	0xa5, 0x85, 0x64, 0x29, 0xc6, 0xa5, 0xdf, 0x98, 0x55, 0xe6, 0xfc, 0x86, 0x79, 0xf9, 0x41, 0x64,
-	0x82, 0x79, 0x2e, 0x12, 0xb4, 0x7e, 0x28, 0xb1, 0xf0, 0x9a, 0x2e, 0x7c, 0xd3, 0x80, 0xdd, 0x0b,
-	0x4c, 0x33, 0xcc, 0xf5, 0x0f, 0x92, 0x6b, 0xa4, 0xf8, 0xcb, 0x14, 0x95, 0x26, 0xfb, 0xb0, 0x39,
-	0x9c, 0x8e, 0x7e, 0xc6, 0x2a, 0x90, 0x2e, 0xb5, 0x12, 0x21, 0xb0, 0x51, 0x30, 0xfd, 0xd2, 0x38,
-	0xe9, 0x52, 0x73, 0x26, 0x3e, 0xb4, 0x55, 0x65, 0xc2, 0x6f, 0x0e, 0xbc, 0xc3, 0x26, 0x75, 0x22,
-	0x79, 0x04, 0x20, 0x31, 0x99, 0xe6, 0x09, 0xcb, 0x47, 0xaf, 0xfc, 0x0d, 0x13, 0xd8, 0xbd, 0x68,
-	0x5e, 0x19, 0x3a, 0xbb, 0xbc, 0x18, 0xbd, 0xc4, 0x0c, 0x69, 0x0d, 0x4e, 0x1e, 0x41, 0x90, 0xb1,
-	0xcb, 0x18, 0xf3, 0x91, 0x7c, 0x55, 0x68, 0x4c, 0x62, 0x6b, 0x35, 0x56, 0xfc, 0x35, 0xfa, 0x2d,
-	0xe3, 0xe9, 0x6e, 0xc6, 0x2e, 0x9f, 0x38, 0x80, 0xcd, 0xe3, 0x82, 0xbf, 0x46, 0xf2, 0x05, 0x00,
-	0x5e, 0x16, 0x5c, 0x32, 0xcd, 0x45, 0xee, 0x6f, 0x1a, 0xcf, 0x41, 0x54, 0x11, 0x18, 0x39, 0x02,
-	0xa3, 0xef, 0x1c, 0x81, 0xb4, 0x86, 0x0e, 0xff, 0xf0, 0x60, 0xef, 0x7a, 0x4d, 0x54, 0x21, 0x72,
-	0x85, 0xe4, 0x6b, 0x78, 0x9f, 0x39, 0xce, 0x62, 0x43, 0x82, 0xf2, 0xbd, 0x41, 0xf3, 0xb0, 0x73,
-	0x74, 0x10, 0xcd, 0x3a, 0x68, 0x09, 0xab, 0xb4, 0x37, 0x7b, 0x66, 0x64, 0x45, 0x1e, 0xc2, 0x8e,
-	0x14, 0x42, 0xc7, 0x05, 0xc7, 0x11, 0xc6, 0x3c, 0xa9, 0xea, 0x79, 0xdc, 0x7b, 0x7b, 0xd5, 0x7f,
-	0xef, 0xef, 0xab, 0x7e, 0xfb, 0xbc, 0xd4, 0x9f, 0x9d, 0xd2, 0x4e, 0x89, 0xaa, 0x84, 0x24, 0x7c,
-	0x3b, 0x8f, 0xeb, 0x44, 0x64, 0xa5, 0xdd, 0xb5, 0x92, 0xf5, 0x19, 0xb4, 0x2d, 0x33, 0x96, 0x29,
-	0x52, 0x63, 0xea, 0xbc, 0x3a, 0x51, 0x07, 0x21, 0x5f, 0x42, 0x4f, 0x48, 0x9e, 0xf2, 0x9c, 0x4d,
-	0x5c, 0x29, 0x5a, 0xa6, 0x14, 0x4b, 0x5b, 0xf6, 0x8e, 0xc3, 0x56, 0xf9, 0x87, 0x4f, 0xe0, 0xc3,
-	0x1b, 0x99, 0xd8, 0x12, 0xd7, 0x82, 0xf0, 0x6e, 0x0d, 0x22, 0xfc, 0x09, 0xf6, 0xad, 0x99, 0x53,
-	0xf1, 0x5b, 0x3e, 0x11, 0x2c, 0x59, 0x6b, 0x49, 0xc2, 0x37, 0x1e, 0xdc, 0x5d, 0x70, 0xb0, 0xf6,
-	0x66, 0xa8, 0xe5, 0xdc, 0xb8, 0x3d, 0xe7, 0x17, 0x40, 0x6c, 0x48, 0x67, 0xf9, 0x58, 0xac, 0x37,
-	0xdf, 0x93, 0xd9, 0x32, 0xa8, 0x6c, 0x2f, 0x92, 0xf2, 0x3f, 0x02, 0xfc, 0x71, 0xd6, 0xa5, 0xa7,
-	0x38, 0xc1, 0x35, 0xaf, 0x94, 0x90, 0xcd, 0x3a, 0xc7, 0x59, 0x5f, 0x37, 0x1f, 0xe1, 0x5f, 0x1e,
-	0xec, 0x3e, 0xe5, 0x4a, 0x5b, 0x3f, 0xea, 0xb6, 0x04, 0xf6, 0x61, 0xb3, 0x90, 0x38, 0xe6, 0x97,
-	0x36, 0x05, 0x2b, 0x91, 0x3e, 0x74, 0x94, 0x66, 0x52, 0xc7, 0x6c, 0x5c, 0x96, 0xae, 0x69, 0x2e,
-	0xc1, 0xa8, 0x1e, 0x97, 0x1a, 0x72, 0x00, 0x80, 0x79, 0x12, 0x0f, 0x71, 0x2c, 0x24, 0x9a, 0xa1,
-	0xeb, 0xd2, 0x6d, 0xcc, 0x93, 0x63, 0xa3, 0x20, 0xf7, 0x61, 0x5b, 0xe2, 0x68, 0x2a, 0x15, 0xff,
-	0xb5, 0xda, 0x77, 0x5b, 0x74, 0xae, 0x20, 0x7b, 0xee, 0xa7, 0x28, 0x97, 0x5b, 0xcb, 0x7d, 0x0a,
-	0x07, 0x00, 0x65, 0xb2, 0xf1, 0x78, 0xc2, 0x52, 0xe5, 0xb7, 0x07, 0xde, 0x61, 0x9b, 0x6e, 0x97,
-	0x9a, 0xaf, 0x4a, 0x45, 0xf8, 0xa7, 0x07, 0x7b, 0xd7, 0x53, 0xb3, 0xd5, 0xfb, 0x1c, 0x5a, 0x5c,
-	0x63, 0xe6, 0x4a, 0xf6, 0xc9, 0xbc, 0x64, 0xcb, 0xe0, 0xd1, 0x99, 0xc6, 0x8c, 0x56, 0x2f, 0x4a,
-	0xfe, 0xb2, 0x32, 0xfe, 0x86, 0x89, 0xd0, 0x9c, 0x03, 0x84, 0x8d, 0x12, 0x32, 0xe3, 0xd6, 0xab,
-	0x71, 0xfb, 0x4e, 0xdd, 0x44, 0xee, 0xc1, 0x36, 0x57, 0xb1, 0xad, 0x6f, 0xd3, 0xb8, 0xd8, 0xe2,
-	0xea, 0xdc, 0xc8, 0x47, 0xff, 0x34, 0x61, 0xeb, 0x99, 0x0d, 0x94, 0x3c, 0x87, 0x9d, 0x13, 0x89,
-	0x4c, 0xa3, 0x8d, 0x96, 0xd4, 0x78, 0x5f, 0xf2, 0xc5, 0x05, 0x1f, 0xad, 0xba, 0xb6, 0x25, 0x39,
-	0x87, 0x9d, 0x6a, 0x39, 0x39, 0x7b, 0x8b, 0x0f, 0xae, 0xad, 0xe1, 0xa0, 0xbf, 0xf2, 0xde, 0x5a,
-	0xfc, 0x06, 0x3a, 0xb5, 0xf1, 0x22, 0xf7, 0x17, 0xf0, 0xb5, 0x89, 0x0e, 0x0e, 0x56, 0xdc, 0x5a,
-	0x5b, 0xdf, 0x43, 0xcf, 0xad, 0x24, 0x17, 0xdf, 0x60, 0xe1, 0xc5, 0x8d, 0xad, 0x18, 0x7c, 0xfc,
-	0x1f, 0x88, 0x79, 0xd6, 0xd5, 0x60, 0xad, 0xce, 0xfa, 0xda, 0x58, 0x2f, 0xc9, 0xfa, 0xc6, 0x60,
-	0x3e, 0x83, 0x6e, 0xbd, 0x87, 0xea, 0xb4, 0x2c, 0x99, 0xb2, 0x3a, 0x2d, 0xcb, 0x5a, 0xef, 0x78,
-	0xe3, 0x45, 0xa3, 0x18, 0x0e, 0x37, 0xcd, 0x1f, 0xfe, 0xf0, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0xbd, 0xa9, 0x5c, 0xa6, 0xba, 0x09, 0x00, 0x00,
+	// 1096 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0x4b, 0x6f, 0x1b, 0x55,
+	0x14, 0xee, 0xd8, 0x89, 0x1d, 0x1f, 0x3b, 0x0f, 0x6e, 0xd2, 0xd4, 0x9a, 0x26, 0xb5, 0x3b, 0x48,
+	0x34, 0x48, 0x68, 0x2a, 0xa5, 0xab, 0x52, 0x10, 0x72, 0x6c, 0x97, 0x1a, 0x39, 0x69, 0x34, 0x6e,
+	0x8a, 0x54, 0x21, 0x46, 0xd7, 0x9e, 0x63, 0x67, 0x84, 0xe7, 0xc1, 0xdc, 0x6b, 0x48, 0xbb, 0x62,
+	0x83, 0x58, 0x77, 0xc1, 0x0f, 0x62, 0xc7, 0x82, 0x1f, 0x80, 0x40, 0xea, 0x6f, 0x41, 0xf7, 0x31,
+	0xf6, 0x24, 0x8e, 0x1b, 0x40, 0xde, 0xcd, 0x39, 0xe7, 0x3b, 0xef, 0xc7, 0x1d, 0xd8, 0x08, 0x90,
+	0x53, 0x3f, 0x1c, 0x46, 0x76, 0x9c, 0x44, 0x3c, 0x22, 0x6b, 0x29, 0x6d, 0xc2, 0x28, 0x1a, 0x69,
+	0xae, 0x59, 0x1b, 0x45, 0xd1, 0x68, 0x8c, 0x0f, 0x25, 0xd5, 0x9f, 0x0c, 0x1f, 0x72, 0x3f, 0x40,
+	0xc6, 0x69, 0x10, 0x6b, 0x00, 0x84, 0x91, 0x87, 0xfa, 0x7b, 0x33, 0x8e, 0xfc, 0x90, 0x63, 0xe2,
+	0xf5, 0x35, 0xa3, 0x12, 0x25, 0x1e, 0x26, 0x4c, 0x51, 0xd6, 0xcf, 0x06, 0x6c, 0x37, 0x3c, 0x2f,
+	0x41, 0xc6, 0xd0, 0x7b, 0x2e, 0x24, 0x5d, 0x3f, 0xf0, 0x39, 0xf9, 0x18, 0x56, 0xc7, 0xe2, 0xa3,
+	0x6a, 0xd4, 0x8d, 0x83, 0xf2, 0xe1, 0xb6, 0xad, 0xb5, 0x66, 0x90, 0x43, 0x47, 0x21, 0x48, 0x13,
+	0x76, 0x18, 0x8f, 0x12, 0x3a, 0x42, 0x57, 0xf8, 0x75, 0xa9, 0x32, 0x57, 0xcd, 0x49, 0xcd, 0x0f,
+	0x6c, 0x19, 0xcc, 0x49, 0xe4, 0xa1, 0xf6, 0xe3, 0x10, 0x0d, 0xcf, 0xf0, 0xac, 0xb7, 0x39, 0xd8,
+	0xee, 0xe1, 0x28, 0xc0, 0x90, 0x7f, 0x9d, 0xf8, 0x1c, 0x1d, 0xfc, 0x7e, 0x82, 0x8c, 0x93, 0x5d,
+	0x28, 0xf4, 0x27, 0x83, 0xef, 0x50, 0x05, 0x52, 0x71, 0x34, 0x45, 0x08, 0xac, 0xc4, 0x94, 0x9f,
+	0x4b, 0x27, 0x15, 0x47, 0x7e, 0x93, 0x2a, 0x14, 0x99, 0x32, 0x51, 0xcd, 0xd7, 0x8d, 0x83, 0xbc,
+	0x93, 0x92, 0xe4, 0x09, 0x40, 0x82, 0xde, 0x24, 0xf4, 0x68, 0x38, 0x78, 0x5d, 0x5d, 0x91, 0x81,
+	0xdd, 0xb5, 0x67, 0x95, 0x71, 0xa6, 0xc2, 0xde, 0xe0, 0x1c, 0x03, 0x74, 0x32, 0x70, 0xf2, 0x04,
+	0xcc, 0x80, 0x5e, 0xb8, 0x18, 0x0e, 0x92, 0xd7, 0x31, 0x47, 0xcf, 0xd5, 0x56, 0x5d, 0xe6, 0xbf,
+	0xc1, 0xea, 0xaa, 0xf4, 0x74, 0x27, 0xa0, 0x17, 0xed, 0x14, 0xa0, 0xf3, 0xe8, 0xf9, 0x6f, 0x90,
+	0x7c, 0x0a, 0x80, 0x17, 0xb1, 0x9f, 0x50, 0xee, 0x47, 0x61, 0xb5, 0x20, 0x3d, 0x9b, 0xb6, 0x6a,
+	0xa0, 0x9d, 0x36, 0xd0, 0x7e, 0x91, 0x36, 0xd0, 0xc9, 0xa0, 0xad, 0x5f, 0x0d, 0xd8, 0xb9, 0x5c,
+	0x13, 0x16, 0x47, 0x21, 0x43, 0xf2, 0x0c, 0xb6, 0x68, 0xda, 0x33, 0x57, 0x36, 0x81, 0x55, 0x8d,
+	0x7a, 0xfe, 0xa0, 0x7c, 0xb8, 0x6f, 0x4f, 0x27, 0xe8, 0x9a, 0xae, 0x3a, 0x9b, 0x53, 0x35, 0x49,
+	0x33, 0xf2, 0x08, 0xd6, 0x93, 0x28, 0xe2, 0x6e, 0xec, 0xe3, 0x00, 0x5d, 0xdf, 0x53, 0xf5, 0x3c,
+	0xda, 0xfc, 0xfd, 0x5d, 0xed, 0xd6, 0x5f, 0xef, 0x6a, 0xc5, 0x53, 0xc1, 0xef, 0xb4, 0x9c, 0xb2,
+	0x40, 0x29, 0xc2, 0xb3, 0x7e, 0xc9, 0x4d, 0xe3, 0x6a, 0x46, 0x81, 0xb0, 0xbb, 0xd4, 0x66, 0x7d,
+	0x02, 0x45, 0xdd, 0x19, 0xdd, 0x29, 0x92, 0xe9, 0xd4, 0xa9, 0xfa, 0x72, 0x52, 0x08, 0xf9, 0x0c,
+	0x36, 0xa3, 0xc4, 0x1f, 0xf9, 0x21, 0x1d, 0xa7, 0xa5, 0x58, 0xad, 0xe7, 0x17, 0x8d, 0xec, 0x46,
+	0x8a, 0xd5, 0xf9, 0x7f, 0x0e, 0x95, 0x49, 0x3c, 0x8e, 0xa8, 0xe7, 0x32, 0x4e, 0x39, 0xab, 0x16,
+	0xa4, 0xaa, 0x39, 0xab, 0xa2, 0xcc, 0xf9, 0x4c, 0x42, 0x7a, 0x02, 0xe1, 0x94, 0x27, 0x33, 0xc2,
+	0xfa, 0x29, 0x07, 0x5b, 0x57, 0x11, 0xe4, 0x01, 0x14, 0xe5, 0x1e, 0xf8, 0x9e, 0x2a, 0xc3, 0xd1,
+	0x86, 0xae, 0x66, 0x41, 0x0c, 0x7c, 0xa7, 0xe5, 0x14, 0x84, 0xb8, 0xe3, 0x91, 0xc7, 0x50, 0x10,
+	0x5e, 0x27, 0x6a, 0x55, 0x36, 0x0e, 0xef, 0x2f, 0x76, 0x6b, 0xf7, 0x24, 0xd0, 0xd1, 0x0a, 0x64,
+	0x1f, 0x40, 0xb5, 0x4c, 0xce, 0xa0, 0x2a, 0x60, 0x49, 0x72, 0xe4, 0xd4, 0xd5, 0xa0, 0xec, 0x4d,
+	0xd4, 0x14, 0xb9, 0x01, 0x93, 0x65, 0xcc, 0x3b, 0x90, 0xb2, 0x8e, 0x99, 0xf5, 0x0c, 0x0a, 0xca,
+	0x22, 0x29, 0x43, 0xb1, 0x73, 0xf2, 0xb2, 0xd1, 0xed, 0xb4, 0xb6, 0x6e, 0x91, 0x75, 0x28, 0xf5,
+	0xce, 0x9a, 0xcd, 0x76, 0xbb, 0xd5, 0x6e, 0x6d, 0x19, 0x04, 0xa0, 0xf0, 0xb4, 0xd1, 0xe9, 0xb6,
+	0x5b, 0x5b, 0x39, 0xb2, 0x0b, 0xa4, 0xfb, 0xfc, 0xe4, 0x4b, 0xf7, 0x45, 0xa3, 0xd3, 0x75, 0x9b,
+	0x8d, 0x93, 0x66, 0x5b, 0xf0, 0xf3, 0x56, 0x1b, 0x6e, 0x5f, 0x99, 0x05, 0x3d, 0xa4, 0x99, 0x36,
+	0x1a, 0x37, 0xb6, 0xd1, 0xfa, 0x16, 0x76, 0xb5, 0x99, 0x56, 0xf4, 0x63, 0x28, 0xf2, 0x5e, 0xea,
+	0x50, 0x59, 0x6f, 0x0d, 0xb8, 0x33, 0xe7, 0x60, 0xe9, 0xeb, 0x94, 0xc9, 0x39, 0x77, 0x73, 0xce,
+	0xaf, 0x80, 0xe8, 0x90, 0x3a, 0xe1, 0x30, 0x5a, 0x6e, 0xbe, 0x4d, 0xd8, 0xbe, 0x64, 0x7b, 0xbe,
+	0x29, 0xff, 0x22, 0xc0, 0x6f, 0xa6, 0x7b, 0xde, 0xc2, 0x31, 0x2e, 0xf9, 0x28, 0x5b, 0x14, 0x6e,
+	0x5f, 0xb1, 0xbe, 0xec, 0x7e, 0x58, 0xbf, 0x19, 0xb0, 0xa7, 0x7d, 0x1c, 0x23, 0xa7, 0x1e, 0xe5,
+	0xf4, 0x2c, 0xf6, 0xe8, 0xb2, 0x9f, 0x17, 0x13, 0xd6, 0x02, 0x6d, 0x5e, 0xee, 0x5a, 0xc5, 0x99,
+	0xd2, 0xe4, 0x0b, 0x58, 0x1f, 0x24, 0xa8, 0x56, 0x51, 0x78, 0x96, 0x0f, 0xc6, 0xfb, 0xdf, 0x80,
+	0x4a, 0xaa, 0xd0, 0xa2, 0x1c, 0xad, 0x63, 0xd8, 0x5f, 0x90, 0xc2, 0xff, 0x5a, 0xb4, 0x3f, 0x0d,
+	0xd8, 0xee, 0xfa, 0x8c, 0x6b, 0x9b, 0xec, 0xa6, 0x4a, 0xec, 0x42, 0x21, 0x4e, 0x70, 0xe8, 0x5f,
+	0xe8, 0x5a, 0x68, 0x4a, 0x9c, 0x18, 0xc6, 0x69, 0xc2, 0x5d, 0x3a, 0x14, 0x9e, 0xf3, 0x52, 0x08,
+	0x92, 0xd5, 0x10, 0x1c, 0x71, 0xa2, 0x30, 0xf4, 0xdc, 0x3e, 0x0e, 0xa3, 0x04, 0x75, 0x59, 0x4a,
+	0x18, 0x7a, 0x47, 0x92, 0x41, 0xf6, 0xa0, 0x94, 0xe0, 0x60, 0x92, 0x30, 0xff, 0x07, 0x55, 0x93,
+	0x35, 0x67, 0xc6, 0x20, 0x3b, 0xe9, 0xef, 0x87, 0x78, 0x31, 0x57, 0xd3, 0x3f, 0x8d, 0x7d, 0x00,
+	0x51, 0x57, 0x77, 0x38, 0xa6, 0x23, 0x56, 0x2d, 0xd6, 0x8d, 0x83, 0xa2, 0x53, 0x12, 0x9c, 0xa7,
+	0x82, 0x61, 0xfd, 0x61, 0xc0, 0xce, 0xe5, 0xd4, 0x74, 0x85, 0x1e, 0xc3, 0xaa, 0xcf, 0x31, 0x48,
+	0xa7, 0xe8, 0xc3, 0xd9, 0x14, 0x5d, 0x07, 0xb7, 0x3b, 0x1c, 0x03, 0x47, 0x69, 0x88, 0x41, 0x08,
+	0x44, 0xfc, 0x39, 0x19, 0xa1, 0xfc, 0x36, 0x11, 0x56, 0x04, 0x64, 0x3a, 0x24, 0x46, 0x66, 0x48,
+	0xfe, 0xd3, 0x82, 0x91, 0xbb, 0x50, 0xf2, 0x99, 0xab, 0xeb, 0x9b, 0x97, 0x2e, 0xd6, 0x7c, 0x76,
+	0x2a, 0xe9, 0xc3, 0xbf, 0x57, 0x60, 0xed, 0x58, 0x07, 0x4a, 0x4e, 0x60, 0xbd, 0x29, 0xa6, 0x02,
+	0x75, 0xb4, 0x24, 0xb3, 0x0a, 0xd7, 0xfc, 0x37, 0x99, 0xf7, 0x16, 0x89, 0x75, 0x49, 0x4e, 0x61,
+	0x5d, 0xdd, 0xeb, 0xd4, 0xde, 0xbc, 0xc2, 0xa5, 0xb7, 0xdd, 0xac, 0x2d, 0x94, 0x6b, 0x8b, 0x5f,
+	0x41, 0x39, 0x73, 0x71, 0xc8, 0xde, 0x1c, 0x3e, 0x73, 0xe4, 0xcc, 0xfd, 0x05, 0x52, 0x6d, 0xeb,
+	0x25, 0x6c, 0xa6, 0x57, 0x3a, 0x8d, 0xaf, 0x3e, 0xa7, 0x71, 0xe5, 0xa1, 0x30, 0xef, 0xbf, 0x07,
+	0x31, 0xcb, 0x5a, 0xdd, 0x9a, 0xc5, 0x59, 0x5f, 0xba, 0x74, 0x66, 0x6d, 0xa1, 0x5c, 0x5b, 0x3c,
+	0x87, 0xdb, 0x6a, 0x1d, 0xaf, 0xec, 0x28, 0xf9, 0x68, 0x4e, 0xf3, 0xda, 0x0b, 0x64, 0x3e, 0xb8,
+	0x11, 0xa7, 0x3d, 0x1d, 0x43, 0x25, 0x3b, 0xad, 0xd9, 0x01, 0xb8, 0x66, 0x9f, 0xcd, 0x7b, 0x8b,
+	0xc4, 0xca, 0xdc, 0xd1, 0xca, 0xab, 0x5c, 0xdc, 0xef, 0x17, 0xe4, 0xf9, 0x79, 0xf4, 0xcf, 0x00,
+	0x37, 0xb2, 0xba, 0xb5, 0x79, 0x0c, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -859,6 +1083,7 @@ type MetainfoClient interface {
 	SegmentInfo(ctx context.Context, in *SegmentInfoRequest, opts ...grpc.CallOption) (*SegmentInfoResponse, error)
 	DownloadSegment(ctx context.Context, in *SegmentDownloadRequest, opts ...grpc.CallOption) (*SegmentDownloadResponse, error)
 	DeleteSegment(ctx context.Context, in *SegmentDeleteRequest, opts ...grpc.CallOption) (*SegmentDeleteResponse, error)
+	UpdateSegmentMetadata(ctx context.Context, in *SegmentMetadataUpdateRequest, opts ...grpc.CallOption) (*SegmentMetadataUpdateResponse, error)
 	ListSegments(ctx context.Context, in *ListSegmentsRequest, opts ...grpc.CallOption) (*ListSegmentsResponse, error)
 }
 
@@ -915,6 +1140,15 @@ func (c *metainfoClient) DeleteSegment(ctx context.Context, in *SegmentDeleteReq
 	return out, nil
 }
 
+func (c *metainfoClient) UpdateSegmentMetadata(ctx context.Context, in *SegmentMetadataUpdateRequest, opts ...grpc.CallOption) (*SegmentMetadataUpdateResponse, error) {
+	out := new(SegmentMetadataUpdateResponse)
+	err := c.cc.Invoke(ctx, "/metainfo.Metainfo/UpdateSegmentMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *metainfoClient) ListSegments(ctx context.Context, in *ListSegmentsRequest, opts ...grpc.CallOption) (*ListSegmentsResponse, error) {
 	out := new(ListSegmentsResponse)
 	err := c.cc.Invoke(ctx, "/metainfo.Metainfo/ListSegments", in, out, opts...)
@@ -931,6 +1165,7 @@ type MetainfoServer interface {
 	SegmentInfo(context.Context, *SegmentInfoRequest) (*SegmentInfoResponse, error)
 	DownloadSegment(context.Context, *SegmentDownloadRequest) (*SegmentDownloadResponse, error)
 	DeleteSegment(context.Context, *SegmentDeleteRequest) (*SegmentDeleteResponse, error)
+	UpdateSegmentMetadata(context.Context, *SegmentMetadataUpdateRequest) (*SegmentMetadataUpdateResponse, error)
 	ListSegments(context.Context, *ListSegmentsRequest) (*ListSegmentsResponse, error)
 }
 
@@ -1028,6 +1263,24 @@ func _Metainfo_DeleteSegment_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Metainfo_UpdateSegmentMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SegmentMetadataUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MetainfoServer).UpdateSegmentMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/metainfo.Metainfo/UpdateSegmentMetadata",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MetainfoServer).UpdateSegmentMetadata(ctx, req.(*SegmentMetadataUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Metainfo_ListSegments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListSegmentsRequest)
 	if err := dec(in); err != nil {
@@ -1070,6 +1323,10 @@ var _Metainfo_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSegment",
 			Handler:    _Metainfo_DeleteSegment_Handler,
 		},
+		{
+			MethodName: "UpdateSegmentMetadata",
+			Handler:    _Metainfo_UpdateSegmentMetadata_Handler,
+		},
 		{
 			MethodName: "ListSegments",
 			Handler:    _Metainfo_ListSegments_Handler,
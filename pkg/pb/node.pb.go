@@ -62,14 +62,22 @@ type NodeTransport int32
 
 const (
 	NodeTransport_TCP_TLS_GRPC NodeTransport = 0
+	// TCP_TLS_GRPC_RELAY nodes accept connections through the relay at the advertised address
+	NodeTransport_TCP_TLS_GRPC_RELAY NodeTransport = 1
+	// QUIC_TLS_GRPC nodes also accept QUIC connections on the UDP port of the advertised address
+	NodeTransport_QUIC_TLS_GRPC NodeTransport = 2
 )
 
 var NodeTransport_name = map[int32]string{
 	0: "TCP_TLS_GRPC",
+	1: "TCP_TLS_GRPC_RELAY",
+	2: "QUIC_TLS_GRPC",
 }
 
 var NodeTransport_value = map[string]int32{
-	"TCP_TLS_GRPC": 0,
+	"TCP_TLS_GRPC":       0,
+	"TCP_TLS_GRPC_RELAY": 1,
+	"QUIC_TLS_GRPC":      2,
 }
 
 func (x NodeTransport) String() string {
@@ -548,52 +556,53 @@ func init() {
 func init() { proto.RegisterFile("node.proto", fileDescriptor_0c843d59d2d938e7) }
 
 var fileDescriptor_0c843d59d2d938e7 = []byte{
-	// 739 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xcd, 0x6e, 0xea, 0x46,
-	0x14, 0x80, 0x63, 0x20, 0x80, 0x0f, 0x3f, 0xf2, 0x9d, 0x8b, 0x5a, 0x2b, 0x55, 0x0b, 0x17, 0xa9,
-	0x2a, 0xba, 0x95, 0xb8, 0xe9, 0xed, 0xa6, 0x91, 0xba, 0x01, 0x92, 0xa6, 0xb4, 0x14, 0xd0, 0xe0,
-	0x66, 0x91, 0x8d, 0x35, 0xd8, 0x13, 0x18, 0xc5, 0xd8, 0x96, 0x67, 0xdc, 0x88, 0x77, 0xe9, 0x03,
-	0xf5, 0x19, 0xba, 0xc8, 0xba, 0x4f, 0x51, 0x55, 0xf3, 0xe3, 0x10, 0x14, 0xb5, 0x52, 0xa4, 0xee,
-	0x7c, 0xce, 0xf9, 0xce, 0xef, 0x9c, 0x63, 0x80, 0x38, 0x09, 0xe9, 0x30, 0xcd, 0x12, 0x91, 0xa0,
-	0x8a, 0xfc, 0x3e, 0x83, 0x4d, 0xb2, 0x49, 0xb4, 0xe6, 0xac, 0xbb, 0x49, 0x92, 0x4d, 0x44, 0x3f,
-	0x28, 0x69, 0x9d, 0xdf, 0x7d, 0x10, 0x6c, 0x47, 0xb9, 0x20, 0xbb, 0x54, 0x03, 0xfd, 0xbf, 0x2d,
-	0xa8, 0xcc, 0x93, 0x90, 0xa2, 0x2f, 0xa0, 0xc4, 0x42, 0xd7, 0xea, 0x59, 0x83, 0xe6, 0xb8, 0xfd,
-	0xc7, 0x63, 0xf7, 0xe4, 0xcf, 0xc7, 0x6e, 0x55, 0x5a, 0xa6, 0x97, 0xb8, 0xc4, 0x42, 0xf4, 0x35,
-	0xd4, 0x48, 0x18, 0x66, 0x94, 0x73, 0xb7, 0xd4, 0xb3, 0x06, 0x8d, 0x8f, 0x6f, 0x86, 0x2a, 0xb3,
-	0x44, 0x46, 0xda, 0x80, 0x0b, 0x02, 0x7d, 0x0a, 0xb5, 0x88, 0x70, 0xe1, 0xb3, 0xd4, 0x6d, 0xf7,
-	0xac, 0x81, 0x8d, 0xab, 0x52, 0x9c, 0xa6, 0x3f, 0x55, 0xea, 0x65, 0xa7, 0x8d, 0x2b, 0x62, 0x9f,
-	0x52, 0xdc, 0xcc, 0x28, 0x17, 0x19, 0x0b, 0x04, 0x4b, 0x62, 0x8e, 0x21, 0xa3, 0x69, 0x2e, 0x88,
-	0x14, 0x70, 0x7d, 0x47, 0x05, 0x09, 0x89, 0x20, 0xb8, 0x19, 0x11, 0x41, 0xe3, 0x60, 0xef, 0x47,
-	0x8c, 0x0b, 0xdc, 0x22, 0x79, 0xc8, 0x84, 0xcf, 0xf3, 0x20, 0x90, 0xe9, 0x4e, 0x19, 0xf7, 0xf3,
-	0x14, 0xb7, 0xf3, 0x34, 0x24, 0x82, 0xfa, 0x06, 0xc5, 0x1d, 0x23, 0x1f, 0xc3, 0x2d, 0xa3, 0xcd,
-	0x53, 0x39, 0x02, 0x5c, 0xfb, 0x8d, 0x66, 0x9c, 0x25, 0x71, 0xff, 0x16, 0x1a, 0xcf, 0x5a, 0x40,
-	0xdf, 0x80, 0x2d, 0x32, 0x12, 0xf3, 0x34, 0xc9, 0x84, 0x9a, 0x46, 0xfb, 0xe3, 0xdb, 0x43, 0xa3,
-	0x5e, 0x61, 0xc2, 0x07, 0x0a, 0xb9, 0xc7, 0x93, 0xb1, 0x9f, 0xc6, 0xd0, 0xff, 0xab, 0x0c, 0xb6,
-	0x74, 0x5b, 0x09, 0x22, 0x38, 0xfa, 0x0a, 0x6a, 0x32, 0x90, 0xff, 0xaf, 0x63, 0xae, 0x4a, 0xf3,
-	0x34, 0x44, 0x9f, 0x03, 0x14, 0x6d, 0x5f, 0x9c, 0xab, 0x98, 0x65, 0x6c, 0x1b, 0xcd, 0xc5, 0x39,
-	0x1a, 0xc2, 0xdb, 0xa3, 0xd6, 0xfc, 0x4c, 0x4e, 0xcd, 0x2d, 0xf7, 0xac, 0x81, 0x85, 0xdf, 0x28,
-	0xd3, 0xca, 0x34, 0x2d, 0x0d, 0xe8, 0x1d, 0x34, 0x75, 0xd3, 0x06, 0xac, 0x28, 0xb0, 0x61, 0x06,
-	0xa1, 0x90, 0x2e, 0x34, 0x74, 0xc8, 0x20, 0xc9, 0x63, 0xe1, 0x9e, 0xaa, 0x94, 0xa0, 0x54, 0x13,
-	0xa9, 0x79, 0x99, 0x53, 0x83, 0x55, 0x05, 0x1e, 0xe5, 0xd4, 0xfc, 0x21, 0xa7, 0x06, 0x6b, 0x0a,
-	0x34, 0x39, 0x35, 0x72, 0x0e, 0x1d, 0x83, 0x1c, 0xc7, 0xac, 0x2b, 0x14, 0x69, 0xdb, 0x51, 0xd0,
-	0x19, 0x74, 0xd4, 0x56, 0x05, 0x49, 0x2c, 0x48, 0xf0, 0x54, 0x8b, 0x6b, 0xab, 0x7d, 0x3c, 0x1b,
-	0xea, 0x5d, 0x1f, 0x16, 0xbb, 0x3e, 0xf4, 0x8a, 0x5d, 0xc7, 0x48, 0xfa, 0x4d, 0xb4, 0x9b, 0x09,
-	0xf9, 0x22, 0xda, 0x1d, 0x61, 0x51, 0x9e, 0x51, 0x17, 0x5e, 0x15, 0xed, 0x07, 0xed, 0xd5, 0xff,
-	0x1e, 0x9a, 0xf2, 0x15, 0x17, 0x29, 0xcd, 0x88, 0x48, 0x32, 0xd4, 0x81, 0x53, 0xba, 0x23, 0x2c,
-	0x52, 0x4f, 0x6d, 0x63, 0x2d, 0xa0, 0x4f, 0xa0, 0xfa, 0x40, 0xa2, 0x88, 0x0a, 0xb3, 0x29, 0x46,
-	0xea, 0x63, 0xed, 0x3d, 0x21, 0x29, 0x09, 0x98, 0xd8, 0xa3, 0x2f, 0xa1, 0x7d, 0x97, 0x51, 0xea,
-	0xaf, 0x49, 0x1c, 0x3e, 0xb0, 0x50, 0x6c, 0x55, 0x98, 0x32, 0x6e, 0x49, 0xed, 0xb8, 0x50, 0xa2,
-	0xcf, 0xc0, 0x56, 0x58, 0xc8, 0xf8, 0xbd, 0xd9, 0x93, 0xba, 0x54, 0x5c, 0x32, 0x7e, 0x5f, 0x54,
-	0xf4, 0x8b, 0x39, 0xa5, 0x57, 0x56, 0x74, 0x03, 0x8e, 0xf4, 0xc6, 0xcf, 0x4e, 0xf4, 0x7f, 0xa9,
-	0xea, 0x77, 0x4b, 0xdf, 0xdb, 0x8d, 0x3e, 0x3f, 0x79, 0x3c, 0xe6, 0x12, 0x4d, 0x5d, 0x85, 0x28,
-	0x77, 0x32, 0x48, 0x76, 0x3b, 0x26, 0xfc, 0x2d, 0xe1, 0x5b, 0x53, 0x1e, 0x68, 0xd5, 0x8f, 0x84,
-	0x6f, 0xd1, 0x77, 0x60, 0x3f, 0xfd, 0xcd, 0xd4, 0xf6, 0xff, 0xf7, 0xab, 0x1d, 0x60, 0x99, 0x34,
-	0xa3, 0x11, 0x25, 0x9c, 0xaa, 0x63, 0xa8, 0xe3, 0x42, 0x7c, 0x3f, 0x87, 0xba, 0xba, 0xf3, 0x7d,
-	0x4a, 0x51, 0x03, 0x6a, 0xd3, 0xf9, 0xcd, 0x68, 0x36, 0xbd, 0x74, 0x4e, 0x50, 0x0b, 0xec, 0xd5,
-	0xc8, 0xbb, 0x9a, 0xcd, 0xa6, 0xde, 0x95, 0x63, 0x49, 0xdb, 0xca, 0x5b, 0xe0, 0xd1, 0xf5, 0x95,
-	0x53, 0x42, 0x00, 0xd5, 0x5f, 0x97, 0xb3, 0xe9, 0xfc, 0x67, 0xa7, 0x2c, 0xb9, 0xf1, 0x62, 0xe1,
-	0xad, 0x3c, 0x3c, 0x5a, 0x3a, 0x95, 0xf7, 0xef, 0xa0, 0x75, 0xf4, 0xdf, 0x40, 0x0e, 0x34, 0xbd,
-	0xc9, 0xd2, 0xf7, 0x66, 0x2b, 0xff, 0x1a, 0x2f, 0x27, 0xce, 0xc9, 0xb8, 0x72, 0x5b, 0x4a, 0xd7,
-	0xeb, 0xaa, 0xaa, 0xf8, 0xdb, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x9d, 0x3d, 0x28, 0xd5, 0xcf,
-	0x05, 0x00, 0x00,
+	// 758 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x94, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0x80, 0xd7, 0x49, 0x36, 0x89, 0x4f, 0x7e, 0xe4, 0x9d, 0xae, 0x8a, 0xb5, 0x08, 0x76, 0x89,
+	0x84, 0x88, 0x8a, 0x94, 0x2e, 0xe5, 0x86, 0x4a, 0xdc, 0x24, 0xd9, 0x50, 0x0c, 0x26, 0x09, 0x13,
+	0x77, 0x25, 0x7a, 0x63, 0x4d, 0xec, 0xd9, 0x64, 0x54, 0xc7, 0xb6, 0x3c, 0x63, 0xaa, 0xbc, 0x0b,
+	0x0f, 0xc4, 0x33, 0x70, 0xd1, 0x6b, 0x9e, 0x02, 0xa1, 0xf9, 0xf1, 0x66, 0xa3, 0x0a, 0xa1, 0x95,
+	0x7a, 0xe7, 0x73, 0xce, 0x77, 0x7e, 0xe7, 0x1c, 0x03, 0xa4, 0x59, 0x4c, 0x47, 0x79, 0x91, 0x89,
+	0x0c, 0x35, 0xe4, 0xf7, 0x05, 0x6c, 0xb2, 0x4d, 0xa6, 0x35, 0x17, 0x97, 0x9b, 0x2c, 0xdb, 0x24,
+	0xf4, 0xb9, 0x92, 0xd6, 0xe5, 0xdd, 0x73, 0xc1, 0x76, 0x94, 0x0b, 0xb2, 0xcb, 0x35, 0x30, 0xf8,
+	0xc7, 0x82, 0xc6, 0x3c, 0x8b, 0x29, 0xfa, 0x1c, 0x6a, 0x2c, 0x76, 0xad, 0x2b, 0x6b, 0xd8, 0x9d,
+	0xf4, 0xff, 0x7c, 0x7f, 0x79, 0xf2, 0xd7, 0xfb, 0xcb, 0xa6, 0xb4, 0x78, 0x37, 0xb8, 0xc6, 0x62,
+	0xf4, 0x35, 0xb4, 0x48, 0x1c, 0x17, 0x94, 0x73, 0xb7, 0x76, 0x65, 0x0d, 0x3b, 0x2f, 0xce, 0x46,
+	0x2a, 0xb3, 0x44, 0xc6, 0xda, 0x80, 0x2b, 0x02, 0x7d, 0x02, 0xad, 0x84, 0x70, 0x11, 0xb2, 0xdc,
+	0xed, 0x5f, 0x59, 0x43, 0x1b, 0x37, 0xa5, 0xe8, 0xe5, 0x3f, 0x35, 0xda, 0x75, 0xa7, 0x8f, 0x1b,
+	0x62, 0x9f, 0x53, 0xdc, 0x2d, 0x28, 0x17, 0x05, 0x8b, 0x04, 0xcb, 0x52, 0x8e, 0xa1, 0xa0, 0x79,
+	0x29, 0x88, 0x14, 0x70, 0x7b, 0x47, 0x05, 0x89, 0x89, 0x20, 0xb8, 0x9b, 0x10, 0x41, 0xd3, 0x68,
+	0x1f, 0x26, 0x8c, 0x0b, 0xdc, 0x23, 0x65, 0xcc, 0x44, 0xc8, 0xcb, 0x28, 0x92, 0xe9, 0x4e, 0x19,
+	0x0f, 0xcb, 0x1c, 0xf7, 0xcb, 0x3c, 0x26, 0x82, 0x86, 0x06, 0xc5, 0xe7, 0x46, 0x3e, 0x86, 0x7b,
+	0x46, 0x5b, 0xe6, 0x72, 0x04, 0xb8, 0xf5, 0x3b, 0x2d, 0x38, 0xcb, 0xd2, 0xc1, 0x1b, 0xe8, 0x3c,
+	0x68, 0x01, 0x7d, 0x03, 0xb6, 0x28, 0x48, 0xca, 0xf3, 0xac, 0x10, 0x6a, 0x1a, 0xfd, 0x17, 0x4f,
+	0x0e, 0x8d, 0x06, 0x95, 0x09, 0x1f, 0x28, 0xe4, 0x1e, 0x4f, 0xc6, 0xbe, 0x1f, 0xc3, 0xe0, 0xef,
+	0x3a, 0xd8, 0xd2, 0x6d, 0x25, 0x88, 0xe0, 0xe8, 0x2b, 0x68, 0xc9, 0x40, 0xe1, 0x7f, 0x8e, 0xb9,
+	0x29, 0xcd, 0x5e, 0x8c, 0x3e, 0x03, 0xa8, 0xda, 0x7e, 0x79, 0xad, 0x62, 0xd6, 0xb1, 0x6d, 0x34,
+	0x2f, 0xaf, 0xd1, 0x08, 0x9e, 0x1c, 0xb5, 0x16, 0x16, 0x72, 0x6a, 0x6e, 0xfd, 0xca, 0x1a, 0x5a,
+	0xf8, 0x4c, 0x99, 0x56, 0xa6, 0x69, 0x69, 0x40, 0x5f, 0x40, 0x57, 0x37, 0x6d, 0xc0, 0x86, 0x02,
+	0x3b, 0x66, 0x10, 0x0a, 0xb9, 0x84, 0x8e, 0x0e, 0x19, 0x65, 0x65, 0x2a, 0xdc, 0x53, 0x95, 0x12,
+	0x94, 0x6a, 0x2a, 0x35, 0x1f, 0xe6, 0xd4, 0x60, 0x53, 0x81, 0x47, 0x39, 0x35, 0x7f, 0xc8, 0xa9,
+	0xc1, 0x96, 0x02, 0x4d, 0x4e, 0x8d, 0x5c, 0xc3, 0xb9, 0x41, 0x8e, 0x63, 0xb6, 0x15, 0x8a, 0xb4,
+	0xed, 0x28, 0xa8, 0x0f, 0xe7, 0x6a, 0xab, 0xa2, 0x2c, 0x15, 0x24, 0xba, 0xaf, 0xc5, 0xb5, 0xd5,
+	0x3e, 0x5e, 0x8c, 0xf4, 0xae, 0x8f, 0xaa, 0x5d, 0x1f, 0x05, 0xd5, 0xae, 0x63, 0x24, 0xfd, 0xa6,
+	0xda, 0xcd, 0x84, 0xfc, 0x20, 0xda, 0x1d, 0x61, 0x49, 0x59, 0x50, 0x17, 0x1e, 0x15, 0xed, 0x07,
+	0xed, 0x35, 0xf8, 0x1e, 0xba, 0xf2, 0x15, 0x17, 0x39, 0x2d, 0x88, 0xc8, 0x0a, 0x74, 0x0e, 0xa7,
+	0x74, 0x47, 0x58, 0xa2, 0x9e, 0xda, 0xc6, 0x5a, 0x40, 0x4f, 0xa1, 0xf9, 0x8e, 0x24, 0x09, 0x15,
+	0x66, 0x53, 0x8c, 0x34, 0xc0, 0xda, 0x7b, 0x4a, 0x72, 0x12, 0x31, 0xb1, 0x47, 0x5f, 0x42, 0xff,
+	0xae, 0xa0, 0x34, 0x5c, 0x93, 0x34, 0x7e, 0xc7, 0x62, 0xb1, 0x55, 0x61, 0xea, 0xb8, 0x27, 0xb5,
+	0x93, 0x4a, 0x89, 0x3e, 0x05, 0x5b, 0x61, 0x31, 0xe3, 0x6f, 0xcd, 0x9e, 0xb4, 0xa5, 0xe2, 0x86,
+	0xf1, 0xb7, 0x55, 0x45, 0xbf, 0x98, 0x53, 0x7a, 0x64, 0x45, 0xb7, 0xe0, 0x48, 0x6f, 0xfc, 0xe0,
+	0x44, 0x3f, 0x4a, 0x55, 0x7f, 0x58, 0xfa, 0xde, 0x6e, 0xf5, 0xf9, 0xc9, 0xe3, 0x31, 0x97, 0x68,
+	0xea, 0xaa, 0x44, 0xb9, 0x93, 0x51, 0xb6, 0xdb, 0x31, 0x11, 0x6e, 0x09, 0xdf, 0x9a, 0xf2, 0x40,
+	0xab, 0x7e, 0x24, 0x7c, 0x8b, 0xbe, 0x03, 0xfb, 0xfe, 0x6f, 0xe6, 0xd6, 0xff, 0xf7, 0xd5, 0x0e,
+	0xb0, 0x4c, 0x5a, 0xd0, 0x84, 0x12, 0x4e, 0xd5, 0x31, 0xb4, 0x71, 0x25, 0x3e, 0x9b, 0x43, 0x5b,
+	0xdd, 0xf9, 0x3e, 0xa7, 0xa8, 0x03, 0x2d, 0x6f, 0x7e, 0x3b, 0xf6, 0xbd, 0x1b, 0xe7, 0x04, 0xf5,
+	0xc0, 0x5e, 0x8d, 0x83, 0x99, 0xef, 0x7b, 0xc1, 0xcc, 0xb1, 0xa4, 0x6d, 0x15, 0x2c, 0xf0, 0xf8,
+	0xd5, 0xcc, 0xa9, 0x21, 0x80, 0xe6, 0xeb, 0xa5, 0xef, 0xcd, 0x7f, 0x76, 0xea, 0x92, 0x9b, 0x2c,
+	0x16, 0xc1, 0x2a, 0xc0, 0xe3, 0xa5, 0xd3, 0x78, 0xe6, 0x43, 0xef, 0xe8, 0xbf, 0x81, 0x1c, 0xe8,
+	0x06, 0xd3, 0x65, 0x18, 0xf8, 0xab, 0xf0, 0x15, 0x5e, 0x4e, 0x9d, 0x13, 0xf4, 0x14, 0xd0, 0x43,
+	0x4d, 0x88, 0x67, 0xfe, 0xf8, 0x37, 0xc7, 0x42, 0x67, 0xd0, 0xfb, 0xf5, 0xb5, 0x37, 0x3d, 0xa0,
+	0xb5, 0x49, 0xe3, 0x4d, 0x2d, 0x5f, 0xaf, 0x9b, 0xaa, 0xb9, 0x6f, 0xff, 0x1d, 0x00, 0xa2, 0xf2,
+	0x34, 0x6e, 0xfa, 0x05, 0x00, 0x00,
 }
@@ -93,6 +93,50 @@ func (SettlementResponse_Status) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_e0f5d4cf0fc9e41b, []int{4, 0}
 }
 
+// RejectReason explains why an order was rejected.
+type SettlementResponse_RejectReason int32
+
+const (
+	SettlementResponse_NONE                 SettlementResponse_RejectReason = 0
+	SettlementResponse_INVALID_SIGNATURE    SettlementResponse_RejectReason = 1
+	SettlementResponse_SERIAL_MISMATCH      SettlementResponse_RejectReason = 2
+	SettlementResponse_EXPIRED              SettlementResponse_RejectReason = 3
+	SettlementResponse_AMOUNT_EXCEEDS_LIMIT SettlementResponse_RejectReason = 4
+	SettlementResponse_DUPLICATE_SERIAL     SettlementResponse_RejectReason = 5
+	SettlementResponse_UNKNOWN_SERIAL       SettlementResponse_RejectReason = 6
+	SettlementResponse_UNAUTHORIZED         SettlementResponse_RejectReason = 7
+)
+
+var SettlementResponse_RejectReason_name = map[int32]string{
+	0: "NONE",
+	1: "INVALID_SIGNATURE",
+	2: "SERIAL_MISMATCH",
+	3: "EXPIRED",
+	4: "AMOUNT_EXCEEDS_LIMIT",
+	5: "DUPLICATE_SERIAL",
+	6: "UNKNOWN_SERIAL",
+	7: "UNAUTHORIZED",
+}
+
+var SettlementResponse_RejectReason_value = map[string]int32{
+	"NONE":                 0,
+	"INVALID_SIGNATURE":    1,
+	"SERIAL_MISMATCH":      2,
+	"EXPIRED":              3,
+	"AMOUNT_EXCEEDS_LIMIT": 4,
+	"DUPLICATE_SERIAL":     5,
+	"UNKNOWN_SERIAL":       6,
+	"UNAUTHORIZED":         7,
+}
+
+func (x SettlementResponse_RejectReason) String() string {
+	return proto.EnumName(SettlementResponse_RejectReason_name, int32(x))
+}
+
+func (SettlementResponse_RejectReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{4, 1}
+}
+
 // OrderLimit2 is provided by satellite to execute specific action on storage node within some limits
 type OrderLimit2 struct {
 	// unique serial to avoid replay attacks
@@ -323,11 +367,12 @@ func (m *SettlementRequest) GetOrder() *Order2 {
 }
 
 type SettlementResponse struct {
-	SerialNumber         SerialNumber              `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3,customtype=SerialNumber" json:"serial_number"`
-	Status               SettlementResponse_Status `protobuf:"varint,2,opt,name=status,proto3,enum=orders.SettlementResponse_Status" json:"status,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	SerialNumber         SerialNumber                    `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3,customtype=SerialNumber" json:"serial_number"`
+	Status               SettlementResponse_Status       `protobuf:"varint,2,opt,name=status,proto3,enum=orders.SettlementResponse_Status" json:"status,omitempty"`
+	Reason               SettlementResponse_RejectReason `protobuf:"varint,3,opt,name=reason,proto3,enum=orders.SettlementResponse_RejectReason" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
 }
 
 func (m *SettlementResponse) Reset()         { *m = SettlementResponse{} }
@@ -361,60 +406,145 @@ func (m *SettlementResponse) GetStatus() SettlementResponse_Status {
 	return SettlementResponse_INVALID
 }
 
+func (m *SettlementResponse) GetReason() SettlementResponse_RejectReason {
+	if m != nil {
+		return m.Reason
+	}
+	return SettlementResponse_NONE
+}
+
+// CorruptedPiecesReport lists pieces that failed an integrity check on the reporting storage node
+type CorruptedPiecesReport struct {
+	PieceIds             []PieceID `protobuf:"bytes,1,rep,name=piece_ids,json=pieceIds,proto3,customtype=PieceID" json:"piece_ids"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *CorruptedPiecesReport) Reset()         { *m = CorruptedPiecesReport{} }
+func (m *CorruptedPiecesReport) String() string { return proto.CompactTextString(m) }
+func (*CorruptedPiecesReport) ProtoMessage()    {}
+func (*CorruptedPiecesReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{5}
+}
+func (m *CorruptedPiecesReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptedPiecesReport.Unmarshal(m, b)
+}
+func (m *CorruptedPiecesReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptedPiecesReport.Marshal(b, m, deterministic)
+}
+func (m *CorruptedPiecesReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptedPiecesReport.Merge(m, src)
+}
+func (m *CorruptedPiecesReport) XXX_Size() int {
+	return xxx_messageInfo_CorruptedPiecesReport.Size(m)
+}
+func (m *CorruptedPiecesReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptedPiecesReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptedPiecesReport proto.InternalMessageInfo
+
+type CorruptedPiecesReportResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CorruptedPiecesReportResponse) Reset()         { *m = CorruptedPiecesReportResponse{} }
+func (m *CorruptedPiecesReportResponse) String() string { return proto.CompactTextString(m) }
+func (*CorruptedPiecesReportResponse) ProtoMessage()    {}
+func (*CorruptedPiecesReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e0f5d4cf0fc9e41b, []int{6}
+}
+func (m *CorruptedPiecesReportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CorruptedPiecesReportResponse.Unmarshal(m, b)
+}
+func (m *CorruptedPiecesReportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CorruptedPiecesReportResponse.Marshal(b, m, deterministic)
+}
+func (m *CorruptedPiecesReportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CorruptedPiecesReportResponse.Merge(m, src)
+}
+func (m *CorruptedPiecesReportResponse) XXX_Size() int {
+	return xxx_messageInfo_CorruptedPiecesReportResponse.Size(m)
+}
+func (m *CorruptedPiecesReportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CorruptedPiecesReportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CorruptedPiecesReportResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterEnum("orders.PieceAction", PieceAction_name, PieceAction_value)
 	proto.RegisterEnum("orders.SettlementResponse_Status", SettlementResponse_Status_name, SettlementResponse_Status_value)
+	proto.RegisterEnum("orders.SettlementResponse_RejectReason", SettlementResponse_RejectReason_name, SettlementResponse_RejectReason_value)
 	proto.RegisterType((*OrderLimit2)(nil), "orders.OrderLimit2")
 	proto.RegisterType((*Order2)(nil), "orders.Order2")
 	proto.RegisterType((*PieceHash)(nil), "orders.PieceHash")
 	proto.RegisterType((*SettlementRequest)(nil), "orders.SettlementRequest")
 	proto.RegisterType((*SettlementResponse)(nil), "orders.SettlementResponse")
+	proto.RegisterType((*CorruptedPiecesReport)(nil), "orders.CorruptedPiecesReport")
+	proto.RegisterType((*CorruptedPiecesReportResponse)(nil), "orders.CorruptedPiecesReportResponse")
 }
 
 func init() { proto.RegisterFile("orders.proto", fileDescriptor_e0f5d4cf0fc9e41b) }
 
 var fileDescriptor_e0f5d4cf0fc9e41b = []byte{
-	// 634 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0xed, 0xe6, 0xc3, 0x49, 0x26, 0x69, 0x62, 0xb6, 0x15, 0x0a, 0x11, 0x52, 0x43, 0xc4, 0x21,
-	0xb4, 0x52, 0x4a, 0x8d, 0x84, 0xd4, 0x63, 0xda, 0x58, 0xc5, 0xa8, 0x2a, 0xd1, 0xc6, 0xe5, 0xc0,
-	0x25, 0x72, 0xea, 0xc5, 0xb5, 0x70, 0xbc, 0xc6, 0xbb, 0x96, 0xf8, 0x05, 0x1c, 0xf9, 0x47, 0xdc,
-	0xf9, 0x0d, 0x1c, 0xfa, 0x5b, 0x90, 0xc7, 0x4e, 0xe2, 0x42, 0x50, 0x0f, 0xbd, 0xf9, 0xcd, 0xbc,
-	0xb7, 0x6f, 0x77, 0xde, 0x18, 0x5a, 0x22, 0x76, 0x79, 0x2c, 0x47, 0x51, 0x2c, 0x94, 0xa0, 0x5a,
-	0x86, 0x7a, 0xe0, 0x09, 0x4f, 0x64, 0xb5, 0xde, 0x81, 0x27, 0x84, 0x17, 0xf0, 0x63, 0x44, 0x8b,
-	0xe4, 0xf3, 0xb1, 0xf2, 0x97, 0x5c, 0x2a, 0x67, 0x19, 0x65, 0x84, 0xc1, 0x8f, 0x0a, 0x34, 0x3f,
-	0xa4, 0xba, 0x4b, 0x7f, 0xe9, 0x2b, 0x83, 0x9e, 0xc2, 0xae, 0xe4, 0xb1, 0xef, 0x04, 0xf3, 0x30,
-	0x59, 0x2e, 0x78, 0xdc, 0x25, 0x7d, 0x32, 0x6c, 0x9d, 0xed, 0xff, 0xba, 0x3b, 0xd8, 0xf9, 0x7d,
-	0x77, 0xd0, 0x9a, 0x61, 0xf3, 0x0a, 0x7b, 0xac, 0x25, 0x0b, 0x88, 0x9e, 0x40, 0x4b, 0x3a, 0x8a,
-	0x07, 0x81, 0xaf, 0xf8, 0xdc, 0x77, 0xbb, 0x25, 0x54, 0xb6, 0x73, 0xa5, 0x76, 0x25, 0x5c, 0x6e,
-	0x4d, 0x58, 0x73, 0xcd, 0xb1, 0x5c, 0x7a, 0x04, 0x8d, 0x24, 0x0a, 0xfc, 0xf0, 0x4b, 0xca, 0x2f,
-	0x6f, 0xe5, 0xd7, 0x33, 0x82, 0xe5, 0xd2, 0xb7, 0xd0, 0x91, 0x4a, 0xc4, 0x8e, 0xc7, 0xe7, 0xa1,
-	0x70, 0xd1, 0xa2, 0xb2, 0x55, 0xb2, 0x9b, 0xd3, 0x10, 0xba, 0xf4, 0x10, 0xea, 0x91, 0xcf, 0x6f,
-	0x50, 0x50, 0x45, 0x41, 0x27, 0x17, 0xd4, 0xa6, 0x69, 0xdd, 0x9a, 0xb0, 0x1a, 0x12, 0x2c, 0x97,
-	0xee, 0x43, 0x35, 0x48, 0x07, 0xd1, 0xd5, 0xfa, 0x64, 0x58, 0x66, 0x19, 0xa0, 0x47, 0xa0, 0x39,
-	0x37, 0xca, 0x17, 0x61, 0xb7, 0xd6, 0x27, 0xc3, 0xb6, 0xb1, 0x37, 0xca, 0x07, 0x8f, 0xfa, 0x31,
-	0xb6, 0x58, 0x4e, 0xa1, 0x26, 0xe8, 0x99, 0x1d, 0xff, 0x16, 0xf9, 0xb1, 0x83, 0xb2, 0x7a, 0x9f,
-	0x0c, 0x9b, 0x46, 0x6f, 0x94, 0xa5, 0x31, 0x5a, 0xa5, 0x31, 0xb2, 0x57, 0x69, 0xb0, 0x0e, 0x6a,
-	0xcc, 0xb5, 0x24, 0x3d, 0x06, 0x4d, 0x8a, 0xc7, 0x34, 0x1e, 0x3e, 0x06, 0x35, 0x85, 0x63, 0x8e,
-	0x61, 0x6f, 0x13, 0x8a, 0xf4, 0xbd, 0xd0, 0x51, 0x49, 0xcc, 0xbb, 0x90, 0xce, 0x81, 0xd1, 0x75,
-	0x6b, 0xb6, 0xea, 0x0c, 0xbe, 0x13, 0xd0, 0x70, 0x21, 0x1e, 0xb5, 0x0b, 0x4f, 0x41, 0x73, 0x96,
-	0x22, 0x09, 0x15, 0x6e, 0x41, 0x99, 0xe5, 0x88, 0xbe, 0x02, 0x3d, 0x0f, 0x7c, 0x73, 0x17, 0xcc,
-	0x9d, 0x75, 0xb2, 0xfa, 0xe6, 0x22, 0x3e, 0x34, 0x70, 0xbc, 0xef, 0x1c, 0x79, 0x7b, 0x2f, 0x43,
-	0xf2, 0x40, 0x86, 0x14, 0x2a, 0xb7, 0x8e, 0xbc, 0xcd, 0xf6, 0x8f, 0xe1, 0x37, 0x7d, 0x0e, 0x8d,
-	0xbf, 0x0d, 0x37, 0x85, 0x81, 0x0b, 0x4f, 0x66, 0x5c, 0xa9, 0x80, 0x2f, 0x79, 0xa8, 0x18, 0xff,
-	0x9a, 0x70, 0x99, 0x5e, 0x35, 0x5f, 0x05, 0x82, 0x53, 0x5f, 0x67, 0x5e, 0xf8, 0x5b, 0x56, 0xfb,
-	0xf1, 0x12, 0xaa, 0xd8, 0x44, 0xcb, 0xa6, 0xd1, 0xbe, 0x47, 0x35, 0x58, 0xd6, 0x1c, 0xfc, 0x24,
-	0x40, 0x8b, 0x36, 0x32, 0x12, 0xa1, 0xe4, 0x8f, 0x99, 0xf2, 0x29, 0x68, 0x52, 0x39, 0x2a, 0x91,
-	0x68, 0xdc, 0x36, 0x5e, 0xac, 0x8c, 0xff, 0xb5, 0x19, 0xcd, 0x90, 0xc8, 0x72, 0xc1, 0xe0, 0x04,
-	0xb4, 0xac, 0x42, 0x9b, 0x50, 0xb3, 0xae, 0x3e, 0x8e, 0x2f, 0xad, 0x89, 0xbe, 0x43, 0x5b, 0x50,
-	0x1f, 0x9f, 0x9f, 0x9b, 0x53, 0xdb, 0x9c, 0xe8, 0x24, 0x45, 0xcc, 0x7c, 0x6f, 0x9e, 0xa7, 0xa8,
-	0x74, 0xe8, 0x41, 0xb3, 0xb0, 0xef, 0xf7, 0x75, 0x35, 0x28, 0x4f, 0xaf, 0x6d, 0x9d, 0xa4, 0x1f,
-	0x17, 0xa6, 0xad, 0x97, 0xe8, 0x2e, 0x34, 0x2e, 0x4c, 0x7b, 0x3e, 0xbe, 0x9e, 0x58, 0xb6, 0x5e,
-	0xa6, 0x6d, 0x80, 0x14, 0x32, 0x73, 0x3a, 0xb6, 0x98, 0x5e, 0x49, 0xf1, 0xf4, 0x7a, 0x8d, 0xab,
-	0x14, 0x40, 0x9b, 0x98, 0x97, 0xa6, 0x6d, 0xea, 0x9a, 0x31, 0xcb, 0x37, 0x50, 0x52, 0x0b, 0x60,
-	0xf3, 0x14, 0xfa, 0x6c, 0xdb, 0xf3, 0x30, 0xac, 0x5e, 0xef, 0xff, 0x2f, 0x1f, 0xec, 0x0c, 0xc9,
-	0x6b, 0x72, 0x56, 0xf9, 0x54, 0x8a, 0x16, 0x0b, 0x0d, 0xff, 0x99, 0x37, 0x7f, 0x02, 0x00, 0x00,
-	0xff, 0xff, 0xbc, 0xfe, 0x4a, 0x7c, 0x3a, 0x05, 0x00, 0x00,
+	// 842 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0xdd, 0x8e, 0xdb, 0x44,
+	0x14, 0x5e, 0xe7, 0xc7, 0x49, 0x4e, 0xd2, 0x64, 0x3a, 0xbb, 0x45, 0x21, 0xa2, 0xda, 0x60, 0x81,
+	0x08, 0x2d, 0xca, 0xd2, 0x20, 0x21, 0xf5, 0x0a, 0xb9, 0xf1, 0x68, 0x77, 0x20, 0xeb, 0x44, 0x13,
+	0x1b, 0x4a, 0x6f, 0x2c, 0xef, 0x7a, 0xc8, 0x1a, 0x12, 0xdb, 0x78, 0x26, 0x12, 0x4f, 0xc0, 0x25,
+	0xaf, 0x01, 0x57, 0x3c, 0x07, 0xcf, 0xc0, 0x45, 0x9f, 0x05, 0x79, 0x6c, 0x27, 0x59, 0x08, 0xed,
+	0x45, 0xef, 0xfc, 0xcd, 0xf9, 0xbe, 0x73, 0xec, 0xf3, 0x7d, 0x63, 0xe8, 0xc4, 0x69, 0xc0, 0x53,
+	0x31, 0x4e, 0xd2, 0x58, 0xc6, 0x58, 0xcf, 0xd1, 0x00, 0x56, 0xf1, 0x2a, 0xce, 0xcf, 0x06, 0xe7,
+	0xab, 0x38, 0x5e, 0xad, 0xf9, 0x85, 0x42, 0x37, 0xdb, 0x1f, 0x2e, 0x64, 0xb8, 0xe1, 0x42, 0xfa,
+	0x9b, 0x24, 0x27, 0x18, 0xbf, 0xd5, 0xa0, 0x3d, 0xcf, 0x74, 0xb3, 0x70, 0x13, 0xca, 0x09, 0x7e,
+	0x0e, 0x0f, 0x04, 0x4f, 0x43, 0x7f, 0xed, 0x45, 0xdb, 0xcd, 0x0d, 0x4f, 0xfb, 0xda, 0x50, 0x1b,
+	0x75, 0x5e, 0x9c, 0xfd, 0xf5, 0xfa, 0xfc, 0xe4, 0xef, 0xd7, 0xe7, 0x9d, 0xa5, 0x2a, 0xda, 0xaa,
+	0xc6, 0x3a, 0xe2, 0x00, 0xe1, 0x67, 0xd0, 0x11, 0xbe, 0xe4, 0xeb, 0x75, 0x28, 0xb9, 0x17, 0x06,
+	0xfd, 0x8a, 0x52, 0x76, 0x0b, 0xa5, 0x6e, 0xc7, 0x01, 0xa7, 0x16, 0x6b, 0xef, 0x38, 0x34, 0xc0,
+	0x4f, 0xa1, 0xb5, 0x4d, 0xd6, 0x61, 0xf4, 0x53, 0xc6, 0xaf, 0x1e, 0xe5, 0x37, 0x73, 0x02, 0x0d,
+	0xf0, 0x97, 0xd0, 0x13, 0x32, 0x4e, 0xfd, 0x15, 0xf7, 0xa2, 0x38, 0x50, 0x23, 0x6a, 0x47, 0x25,
+	0x0f, 0x0a, 0x9a, 0x82, 0x01, 0x7e, 0x02, 0xcd, 0x24, 0xe4, 0xb7, 0x4a, 0x50, 0x57, 0x82, 0x5e,
+	0x21, 0x68, 0x2c, 0xb2, 0x73, 0x6a, 0xb1, 0x86, 0x22, 0xd0, 0x00, 0x9f, 0x41, 0x7d, 0x9d, 0x2d,
+	0xa2, 0xaf, 0x0f, 0xb5, 0x51, 0x95, 0xe5, 0x00, 0x3f, 0x05, 0xdd, 0xbf, 0x95, 0x61, 0x1c, 0xf5,
+	0x1b, 0x43, 0x6d, 0xd4, 0x9d, 0x9c, 0x8e, 0x8b, 0xc5, 0x2b, 0xbd, 0xa9, 0x4a, 0xac, 0xa0, 0x60,
+	0x02, 0x28, 0x1f, 0xc7, 0x7f, 0x49, 0xc2, 0xd4, 0x57, 0xb2, 0xe6, 0x50, 0x1b, 0xb5, 0x27, 0x83,
+	0x71, 0xee, 0xc6, 0xb8, 0x74, 0x63, 0xec, 0x94, 0x6e, 0xb0, 0x9e, 0xd2, 0x90, 0x9d, 0x24, 0x6b,
+	0xa3, 0x86, 0x1c, 0xb6, 0x69, 0xbd, 0xbd, 0x8d, 0xd2, 0x1c, 0xb4, 0xb9, 0x80, 0xd3, 0xbd, 0x29,
+	0x22, 0x5c, 0x45, 0xbe, 0xdc, 0xa6, 0xbc, 0x0f, 0xd9, 0x1e, 0x18, 0xde, 0x95, 0x96, 0x65, 0xc5,
+	0xf8, 0x55, 0x03, 0x5d, 0x05, 0xe2, 0x9d, 0xb2, 0xf0, 0x1e, 0xe8, 0xfe, 0x26, 0xde, 0x46, 0x52,
+	0xa5, 0xa0, 0xca, 0x0a, 0x84, 0x3f, 0x05, 0x54, 0x18, 0xbe, 0x7f, 0x17, 0xe5, 0x3b, 0xeb, 0xe5,
+	0xe7, 0xfb, 0x17, 0x09, 0xa1, 0xa5, 0xd6, 0x7b, 0xe5, 0x8b, 0xbb, 0x7b, 0x1e, 0x6a, 0x6f, 0xf1,
+	0x10, 0x43, 0xed, 0xce, 0x17, 0x77, 0x79, 0xfe, 0x98, 0x7a, 0xc6, 0x1f, 0x40, 0xeb, 0xdf, 0x03,
+	0xf7, 0x07, 0x46, 0x00, 0x0f, 0x97, 0x5c, 0xca, 0x35, 0xdf, 0xf0, 0x48, 0x32, 0xfe, 0xf3, 0x96,
+	0x8b, 0xec, 0x55, 0x8b, 0x28, 0x68, 0x6a, 0xeb, 0x3b, 0xcf, 0x0f, 0x6e, 0x4b, 0x99, 0x8f, 0x8f,
+	0xa0, 0xae, 0x8a, 0x6a, 0x64, 0x7b, 0xd2, 0xbd, 0x47, 0x9d, 0xb0, 0xbc, 0x68, 0xfc, 0x51, 0x05,
+	0x7c, 0x38, 0x46, 0x24, 0x71, 0x24, 0xf8, 0xbb, 0x6c, 0xf9, 0x39, 0xe8, 0x42, 0xfa, 0x72, 0x2b,
+	0xd4, 0xe0, 0xee, 0xe4, 0xc3, 0x72, 0xf0, 0x7f, 0xc7, 0x8c, 0x97, 0x8a, 0xc8, 0x0a, 0x01, 0xfe,
+	0x0a, 0xf4, 0x94, 0xfb, 0x22, 0x8e, 0xd4, 0x36, 0xba, 0x93, 0x4f, 0xde, 0x20, 0x65, 0xfc, 0x47,
+	0x7e, 0x2b, 0x99, 0xa2, 0xb3, 0x42, 0x66, 0x3c, 0x03, 0x3d, 0x6f, 0x89, 0xdb, 0xd0, 0xa0, 0xf6,
+	0xb7, 0xe6, 0x8c, 0x5a, 0xe8, 0x04, 0x77, 0xa0, 0x69, 0x4e, 0xa7, 0x64, 0xe1, 0x10, 0x0b, 0x69,
+	0x19, 0x62, 0xe4, 0x6b, 0x32, 0xcd, 0x50, 0xc5, 0xf8, 0x5d, 0x83, 0xce, 0x61, 0x2f, 0xdc, 0x84,
+	0x9a, 0x3d, 0xb7, 0x09, 0x3a, 0xc1, 0x8f, 0xe0, 0x61, 0xd1, 0xc3, 0x5b, 0xd2, 0x4b, 0xdb, 0x74,
+	0x5c, 0x46, 0x90, 0x86, 0x4f, 0xa1, 0xb7, 0x24, 0x8c, 0x9a, 0x33, 0xef, 0x9a, 0x2e, 0xaf, 0x4d,
+	0x67, 0x7a, 0x85, 0x2a, 0xd9, 0x3c, 0xf2, 0x72, 0x41, 0x19, 0xb1, 0x50, 0x15, 0xf7, 0xe1, 0xcc,
+	0xbc, 0x9e, 0xbb, 0xb6, 0xe3, 0x91, 0x97, 0x53, 0x42, 0xac, 0xa5, 0x37, 0xa3, 0xd7, 0xd4, 0x41,
+	0x35, 0x7c, 0x06, 0xc8, 0x72, 0x17, 0x33, 0x3a, 0x35, 0x1d, 0xe2, 0xe5, 0x5d, 0x50, 0x1d, 0x63,
+	0xe8, 0xba, 0xf6, 0x37, 0xf6, 0xfc, 0x3b, 0xbb, 0x3c, 0xd3, 0x31, 0x82, 0x8e, 0x6b, 0x9b, 0xae,
+	0x73, 0x35, 0x67, 0xf4, 0x15, 0xb1, 0x50, 0xc3, 0x20, 0xf0, 0x68, 0x1a, 0xa7, 0xe9, 0x36, 0x91,
+	0x3c, 0x50, 0xf9, 0x12, 0x8c, 0x27, 0x71, 0x2a, 0xf1, 0x67, 0xd0, 0x2a, 0x73, 0x28, 0xfa, 0xda,
+	0xb0, 0x7a, 0x2c, 0x88, 0xcd, 0x22, 0x88, 0xc2, 0x38, 0x87, 0xc7, 0x47, 0xdb, 0x94, 0x9b, 0x7d,
+	0xb2, 0x82, 0xf6, 0xc1, 0x2f, 0xe4, 0xfe, 0x26, 0x1b, 0x50, 0x5d, 0xb8, 0x0e, 0xd2, 0xb2, 0x87,
+	0x4b, 0xe2, 0xa0, 0x0a, 0x7e, 0x00, 0xad, 0x4b, 0xe2, 0x78, 0xa6, 0x6b, 0x51, 0x07, 0x55, 0x71,
+	0x17, 0x20, 0x83, 0x8c, 0x2c, 0x4c, 0xca, 0x50, 0x2d, 0xc3, 0x0b, 0x77, 0x87, 0xeb, 0x18, 0x40,
+	0xb7, 0xc8, 0x8c, 0x38, 0x04, 0xe9, 0x93, 0x3f, 0xcb, 0x5b, 0x2d, 0x30, 0x05, 0xd8, 0x7b, 0x8c,
+	0xdf, 0x3f, 0xe6, 0xbb, 0xba, 0x00, 0x83, 0xc1, 0xff, 0x47, 0xc2, 0x38, 0x19, 0x69, 0x9f, 0x6b,
+	0xf8, 0x7b, 0xe8, 0xe5, 0x1f, 0xb4, 0xfb, 0x4a, 0xfc, 0xb8, 0x14, 0x1d, 0xfd, 0xf0, 0xc1, 0xc7,
+	0x6f, 0x2c, 0xef, 0xdb, 0xbf, 0xa8, 0xbd, 0xaa, 0x24, 0x37, 0x37, 0xba, 0xfa, 0xc5, 0x7d, 0xf1,
+	0xcf, 0x00, 0x56, 0x7d, 0xf5, 0x99, 0xe9, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -430,6 +560,7 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type OrdersClient interface {
 	Settlement(ctx context.Context, opts ...grpc.CallOption) (Orders_SettlementClient, error)
+	ReportCorrupted(ctx context.Context, in *CorruptedPiecesReport, opts ...grpc.CallOption) (*CorruptedPiecesReportResponse, error)
 }
 
 type ordersClient struct {
@@ -471,9 +602,19 @@ func (x *ordersSettlementClient) Recv() (*SettlementResponse, error) {
 	return m, nil
 }
 
+func (c *ordersClient) ReportCorrupted(ctx context.Context, in *CorruptedPiecesReport, opts ...grpc.CallOption) (*CorruptedPiecesReportResponse, error) {
+	out := new(CorruptedPiecesReportResponse)
+	err := c.cc.Invoke(ctx, "/orders.Orders/ReportCorrupted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrdersServer is the server API for Orders service.
 type OrdersServer interface {
 	Settlement(Orders_SettlementServer) error
+	ReportCorrupted(context.Context, *CorruptedPiecesReport) (*CorruptedPiecesReportResponse, error)
 }
 
 func RegisterOrdersServer(s *grpc.Server, srv OrdersServer) {
@@ -506,10 +647,33 @@ func (x *ordersSettlementServer) Recv() (*SettlementRequest, error) {
 	return m, nil
 }
 
+func _Orders_ReportCorrupted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CorruptedPiecesReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersServer).ReportCorrupted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/orders.Orders/ReportCorrupted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersServer).ReportCorrupted(ctx, req.(*CorruptedPiecesReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Orders_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "orders.Orders",
 	HandlerType: (*OrdersServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportCorrupted",
+			Handler:    _Orders_ReportCorrupted_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Settlement",
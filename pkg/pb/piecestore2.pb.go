@@ -8,6 +8,7 @@ import (
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
 	grpc "google.golang.org/grpc"
 	math "math"
 )
@@ -24,13 +25,13 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 // Expected order of messages from uplink:
-//   OrderLimit ->
-//   repeated
-//      Order ->
-//      Chunk ->
-//   PieceHash signed by uplink ->
-//      <- PieceHash signed by storage node
 //
+//	OrderLimit ->
+//	repeated
+//	   Order ->
+//	   Chunk ->
+//	PieceHash signed by uplink ->
+//	   <- PieceHash signed by storage node
 type PieceUploadRequest struct {
 	// first message to show that we are allowed to upload
 	Limit *OrderLimit2 `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
@@ -182,11 +183,12 @@ func (m *PieceUploadResponse) GetDone() *PieceHash {
 }
 
 // Expected order of messages from uplink:
-//   {OrderLimit, Chunk} ->
-//   go repeated
-//      Order -> (async)
-//   go repeated
-//      <- PieceDownloadResponse.Chunk
+//
+//	{OrderLimit, Chunk} ->
+//	go repeated
+//	   Order -> (async)
+//	go repeated
+//	   <- PieceDownloadResponse.Chunk
 type PieceDownloadRequest struct {
 	// first message to show that we are allowed to upload
 	Limit *OrderLimit2 `protobuf:"bytes,1,opt,name=limit,proto3" json:"limit,omitempty"`
@@ -444,6 +446,129 @@ func (m *PieceDeleteResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PieceDeleteResponse proto.InternalMessageInfo
 
+// PieceRepairRequest is sent by a satellite to have the storage node
+// reconstruct a segment and upload its missing pieces to new storage nodes.
+//
+// Limits are indexed by piece number, pieces that shouldn't be downloaded
+// or uploaded have an empty limit.
+type PieceRepairRequest struct {
+	GetLimits            []*AddressedOrderLimit `protobuf:"bytes,1,rep,name=get_limits,json=getLimits,proto3" json:"get_limits,omitempty"`
+	PutLimits            []*AddressedOrderLimit `protobuf:"bytes,2,rep,name=put_limits,json=putLimits,proto3" json:"put_limits,omitempty"`
+	Redundancy           *RedundancyScheme      `protobuf:"bytes,3,opt,name=redundancy,proto3" json:"redundancy,omitempty"`
+	SegmentSize          int64                  `protobuf:"varint,4,opt,name=segment_size,json=segmentSize,proto3" json:"segment_size,omitempty"`
+	Expiration           *timestamp.Timestamp   `protobuf:"bytes,5,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Path                 string                 `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *PieceRepairRequest) Reset()         { *m = PieceRepairRequest{} }
+func (m *PieceRepairRequest) String() string { return proto.CompactTextString(m) }
+func (*PieceRepairRequest) ProtoMessage()    {}
+func (*PieceRepairRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_23ff32dd550c2439, []int{6}
+}
+func (m *PieceRepairRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceRepairRequest.Unmarshal(m, b)
+}
+func (m *PieceRepairRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceRepairRequest.Marshal(b, m, deterministic)
+}
+func (m *PieceRepairRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceRepairRequest.Merge(m, src)
+}
+func (m *PieceRepairRequest) XXX_Size() int {
+	return xxx_messageInfo_PieceRepairRequest.Size(m)
+}
+func (m *PieceRepairRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceRepairRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceRepairRequest proto.InternalMessageInfo
+
+func (m *PieceRepairRequest) GetGetLimits() []*AddressedOrderLimit {
+	if m != nil {
+		return m.GetLimits
+	}
+	return nil
+}
+
+func (m *PieceRepairRequest) GetPutLimits() []*AddressedOrderLimit {
+	if m != nil {
+		return m.PutLimits
+	}
+	return nil
+}
+
+func (m *PieceRepairRequest) GetRedundancy() *RedundancyScheme {
+	if m != nil {
+		return m.Redundancy
+	}
+	return nil
+}
+
+func (m *PieceRepairRequest) GetSegmentSize() int64 {
+	if m != nil {
+		return m.SegmentSize
+	}
+	return 0
+}
+
+func (m *PieceRepairRequest) GetExpiration() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expiration
+	}
+	return nil
+}
+
+func (m *PieceRepairRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type PieceRepairResponse struct {
+	// hashes signed by the new storage nodes, indexed by piece number,
+	// failed uploads have an empty hash
+	Hashes               []*PieceHash `protobuf:"bytes,1,rep,name=hashes,proto3" json:"hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *PieceRepairResponse) Reset()         { *m = PieceRepairResponse{} }
+func (m *PieceRepairResponse) String() string { return proto.CompactTextString(m) }
+func (*PieceRepairResponse) ProtoMessage()    {}
+func (*PieceRepairResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_23ff32dd550c2439, []int{7}
+}
+func (m *PieceRepairResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PieceRepairResponse.Unmarshal(m, b)
+}
+func (m *PieceRepairResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PieceRepairResponse.Marshal(b, m, deterministic)
+}
+func (m *PieceRepairResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PieceRepairResponse.Merge(m, src)
+}
+func (m *PieceRepairResponse) XXX_Size() int {
+	return xxx_messageInfo_PieceRepairResponse.Size(m)
+}
+func (m *PieceRepairResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PieceRepairResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PieceRepairResponse proto.InternalMessageInfo
+
+func (m *PieceRepairResponse) GetHashes() []*PieceHash {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*PieceUploadRequest)(nil), "piecestore.PieceUploadRequest")
 	proto.RegisterType((*PieceUploadRequest_Chunk)(nil), "piecestore.PieceUploadRequest.Chunk")
@@ -454,38 +579,53 @@ func init() {
 	proto.RegisterType((*PieceDownloadResponse_Chunk)(nil), "piecestore.PieceDownloadResponse.Chunk")
 	proto.RegisterType((*PieceDeleteRequest)(nil), "piecestore.PieceDeleteRequest")
 	proto.RegisterType((*PieceDeleteResponse)(nil), "piecestore.PieceDeleteResponse")
+	proto.RegisterType((*PieceRepairRequest)(nil), "piecestore.PieceRepairRequest")
+	proto.RegisterType((*PieceRepairResponse)(nil), "piecestore.PieceRepairResponse")
 }
 
 func init() { proto.RegisterFile("piecestore2.proto", fileDescriptor_23ff32dd550c2439) }
 
 var fileDescriptor_23ff32dd550c2439 = []byte{
-	// 404 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x94, 0x51, 0x4f, 0xea, 0x30,
-	0x14, 0xc7, 0x29, 0x6c, 0xcb, 0xbd, 0xe7, 0x92, 0x9b, 0x50, 0xc4, 0x2c, 0x4b, 0x54, 0x5c, 0x50,
-	0xf1, 0x65, 0x31, 0xe3, 0xcd, 0xa0, 0x26, 0xca, 0x83, 0x89, 0x1a, 0x49, 0x0d, 0x2f, 0xbe, 0x98,
-	0xc1, 0x0a, 0x2c, 0xe2, 0x3a, 0xd7, 0x11, 0x13, 0xbe, 0x82, 0x9f, 0xd3, 0x8f, 0x61, 0x34, 0x6b,
-	0x37, 0xc8, 0x00, 0x21, 0x9a, 0xf8, 0x04, 0x3d, 0xe7, 0x7f, 0xfa, 0xff, 0xf5, 0xdf, 0x66, 0x50,
-	0x0a, 0x3c, 0xda, 0xa3, 0x3c, 0x62, 0x21, 0xb5, 0xad, 0x20, 0x64, 0x11, 0xc3, 0x30, 0x2b, 0x19,
-	0x30, 0x60, 0x03, 0x26, 0xeb, 0x46, 0x91, 0x85, 0x2e, 0x0d, 0xb9, 0x5c, 0x99, 0xef, 0x08, 0x70,
-	0x3b, 0x16, 0x76, 0x82, 0x11, 0x73, 0x5c, 0x42, 0x9f, 0xc7, 0x94, 0x47, 0xf8, 0x10, 0xd4, 0x91,
-	0xf7, 0xe4, 0x45, 0x3a, 0xaa, 0xa2, 0xfa, 0x3f, 0xbb, 0x6c, 0x25, 0x43, 0xb7, 0xf1, 0xcf, 0x75,
-	0xdc, 0xb1, 0x89, 0x54, 0xe0, 0x1a, 0xa8, 0xa2, 0xa9, 0xe7, 0x85, 0xf4, 0x7f, 0x46, 0x6a, 0x13,
-	0xd9, 0xc4, 0xc7, 0xa0, 0xf6, 0x86, 0x63, 0xff, 0x51, 0x2f, 0x08, 0x55, 0xcd, 0x9a, 0xd1, 0x59,
-	0x8b, 0xfe, 0xd6, 0x45, 0xac, 0x25, 0x72, 0x04, 0xef, 0x81, 0xe2, 0x32, 0x9f, 0xea, 0x8a, 0x18,
-	0x2d, 0xa5, 0x06, 0x62, 0xec, 0xd2, 0xe1, 0x43, 0x22, 0xda, 0x46, 0x03, 0x54, 0x31, 0x86, 0x37,
-	0x41, 0x63, 0xfd, 0x3e, 0xa7, 0x92, 0xbe, 0x40, 0x92, 0x15, 0xc6, 0xa0, 0xb8, 0x4e, 0xe4, 0x08,
-	0xd0, 0x22, 0x11, 0xff, 0xcd, 0x26, 0x94, 0x33, 0xf6, 0x3c, 0x60, 0x3e, 0xa7, 0x53, 0x4b, 0xb4,
-	0xd2, 0xd2, 0x7c, 0x43, 0xb0, 0x21, 0x6a, 0x2d, 0xf6, 0xe2, 0xff, 0x6a, 0x7e, 0xcd, 0x6c, 0x7e,
-	0xfb, 0x0b, 0xf9, 0xcd, 0x11, 0x64, 0x12, 0x34, 0x4e, 0xd7, 0x45, 0xb3, 0x05, 0x20, 0x94, 0x0f,
-	0xdc, 0x9b, 0x50, 0x41, 0x52, 0x20, 0x7f, 0x45, 0xe5, 0xce, 0x9b, 0x50, 0xf3, 0x15, 0x41, 0x65,
-	0xce, 0x25, 0x09, 0xea, 0x24, 0xe5, 0x92, 0x07, 0x3d, 0x58, 0xc1, 0x25, 0x27, 0xb2, 0x60, 0x3f,
-	0xba, 0xb3, 0xb3, 0xe4, 0xc9, 0xb6, 0xe8, 0x88, 0x46, 0xf4, 0xfb, 0x91, 0x9b, 0x95, 0xe4, 0xd2,
-	0xd3, 0x0d, 0x24, 0x99, 0xfd, 0x81, 0x00, 0xda, 0x53, 0x7c, 0x7c, 0x03, 0x9a, 0x7c, 0x15, 0x78,
-	0x7b, 0xf5, 0x6b, 0x35, 0x76, 0xbe, 0xec, 0xcb, 0x9d, 0xcd, 0x5c, 0x1d, 0xe1, 0x0e, 0xfc, 0x49,
-	0xb3, 0xc0, 0xd5, 0x75, 0xd7, 0x67, 0xec, 0xae, 0x0d, 0x32, 0xde, 0xf4, 0x08, 0xe1, 0x2b, 0xd0,
-	0xe4, 0x31, 0x96, 0x50, 0x66, 0x02, 0x5a, 0x42, 0x99, 0x3d, 0xbf, 0x99, 0x3b, 0x57, 0xee, 0xf3,
-	0x41, 0xb7, 0xab, 0x89, 0x4f, 0x43, 0xe3, 0x33, 0x00, 0x00, 0xff, 0xff, 0xf5, 0xfa, 0xf7, 0xbe,
-	0x55, 0x04, 0x00, 0x00,
+	// 616 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x54, 0xdb, 0x6e, 0xd3, 0x4c,
+	0x10, 0xfe, 0x9d, 0x83, 0xf5, 0x77, 0x5a, 0x15, 0x75, 0x43, 0x91, 0x65, 0x54, 0x92, 0x5a, 0x05,
+	0xd2, 0x1b, 0x17, 0xb9, 0x77, 0xa5, 0x9c, 0x7b, 0x81, 0x04, 0x88, 0x6a, 0x4b, 0x6f, 0xb8, 0xa9,
+	0x9c, 0x78, 0x62, 0x5b, 0xc4, 0xde, 0xc5, 0xbb, 0x16, 0xd0, 0x57, 0xe0, 0xa5, 0x78, 0x00, 0x5e,
+	0x83, 0xc7, 0x40, 0x42, 0xde, 0x5d, 0x27, 0x75, 0x4f, 0x01, 0x24, 0xae, 0xbc, 0x3b, 0x33, 0xdf,
+	0x37, 0xe3, 0x6f, 0x66, 0x16, 0xd6, 0x78, 0x8a, 0x63, 0x14, 0x92, 0x15, 0x18, 0xf8, 0xbc, 0x60,
+	0x92, 0x11, 0x98, 0x9b, 0x5c, 0x88, 0x59, 0xcc, 0xb4, 0xdd, 0xed, 0xc7, 0x8c, 0xc5, 0x53, 0xdc,
+	0x51, 0xb7, 0x51, 0x39, 0xd9, 0x91, 0x69, 0x86, 0x42, 0x86, 0x19, 0x37, 0x01, 0xab, 0x19, 0xca,
+	0x30, 0xcd, 0x27, 0x35, 0x60, 0x85, 0x15, 0x11, 0x16, 0xc2, 0xdc, 0x6e, 0x70, 0x96, 0xe6, 0x12,
+	0x8b, 0x68, 0xa4, 0x0d, 0xde, 0x4f, 0x0b, 0xc8, 0x61, 0x95, 0xea, 0x98, 0x4f, 0x59, 0x18, 0x51,
+	0xfc, 0x58, 0xa2, 0x90, 0x64, 0x1b, 0xba, 0xd3, 0x34, 0x4b, 0xa5, 0x63, 0x0d, 0xac, 0xe1, 0x72,
+	0xd0, 0xf3, 0x0d, 0xcb, 0xdb, 0xea, 0xf3, 0xba, 0xf2, 0x04, 0x54, 0x47, 0x90, 0x2d, 0xe8, 0x2a,
+	0xa7, 0xd3, 0x52, 0xa1, 0xab, 0x8d, 0xd0, 0x80, 0x6a, 0x27, 0xd9, 0x83, 0xee, 0x38, 0x29, 0xf3,
+	0x0f, 0x4e, 0x5b, 0x45, 0x6d, 0xf9, 0xf3, 0xff, 0xf3, 0x2f, 0xe6, 0xf7, 0x5f, 0x54, 0xb1, 0x54,
+	0x43, 0xc8, 0x5d, 0xe8, 0x44, 0x2c, 0x47, 0xa7, 0xa3, 0xa0, 0x6b, 0x75, 0x02, 0x05, 0x7b, 0x19,
+	0x8a, 0x84, 0x2a, 0xb7, 0xbb, 0x0b, 0x5d, 0x05, 0x23, 0xb7, 0xc0, 0x66, 0x93, 0x89, 0x40, 0x5d,
+	0x7d, 0x9b, 0x9a, 0x1b, 0x21, 0xd0, 0x89, 0x42, 0x19, 0xaa, 0x42, 0x57, 0xa8, 0x3a, 0x7b, 0xfb,
+	0xd0, 0x6b, 0xa4, 0x17, 0x9c, 0xe5, 0x02, 0x67, 0x29, 0xad, 0x6b, 0x53, 0x7a, 0x3f, 0x2c, 0xb8,
+	0xa9, 0x6c, 0x07, 0xec, 0x53, 0xfe, 0x4f, 0xf5, 0xdb, 0x6f, 0xea, 0x77, 0xef, 0x82, 0x7e, 0xe7,
+	0x2a, 0x68, 0x28, 0xe8, 0x3e, 0x5e, 0x24, 0xcd, 0x06, 0x80, 0x8a, 0x3c, 0x11, 0xe9, 0x29, 0xaa,
+	0x4a, 0xda, 0x74, 0x49, 0x59, 0x8e, 0xd2, 0x53, 0xf4, 0xbe, 0x5a, 0xb0, 0x7e, 0x2e, 0x8b, 0x11,
+	0xea, 0x51, 0x5d, 0x97, 0xfe, 0xd1, 0xfb, 0xd7, 0xd4, 0xa5, 0x11, 0xcd, 0xc2, 0xfe, 0xaa, 0x67,
+	0x4f, 0xcc, 0xc8, 0x1e, 0xe0, 0x14, 0x25, 0xfe, 0xb9, 0xe4, 0xde, 0x3a, 0xf4, 0x1a, 0x04, 0xba,
+	0x32, 0xef, 0x5b, 0xcb, 0x10, 0x53, 0xe4, 0x61, 0x5a, 0xd4, 0xc4, 0xfb, 0x00, 0x31, 0xca, 0x13,
+	0x05, 0x15, 0x8e, 0x35, 0x68, 0x0f, 0x97, 0x83, 0x0d, 0x7f, 0xb6, 0x66, 0xcf, 0xa2, 0xa8, 0x40,
+	0x21, 0x30, 0x9a, 0x27, 0xa2, 0x4b, 0x31, 0x4a, 0x75, 0x12, 0x15, 0x9a, 0x97, 0x33, 0x74, 0xeb,
+	0xb7, 0xd0, 0xbc, 0xac, 0xd1, 0x0f, 0x01, 0x0a, 0x8c, 0xca, 0x3c, 0x0a, 0xf3, 0xf1, 0x17, 0xd3,
+	0xfb, 0xdb, 0xfe, 0x7c, 0x89, 0xe9, 0xcc, 0x79, 0x34, 0x4e, 0x30, 0x43, 0x7a, 0x26, 0x9c, 0x6c,
+	0xc2, 0x8a, 0xc0, 0x38, 0xc3, 0x5c, 0xea, 0xb6, 0x76, 0x94, 0xb2, 0xcb, 0xc6, 0x56, 0x35, 0x96,
+	0xec, 0x01, 0xe0, 0x67, 0x9e, 0x16, 0xa1, 0x4c, 0x59, 0xee, 0x74, 0x15, 0xbf, 0xeb, 0xeb, 0x37,
+	0xc6, 0xaf, 0xdf, 0x18, 0xff, 0x5d, 0xfd, 0xc6, 0xd0, 0x33, 0xd1, 0x55, 0x6b, 0x78, 0x28, 0x13,
+	0xc7, 0x1e, 0x58, 0xc3, 0x25, 0xaa, 0xce, 0xde, 0x53, 0xe8, 0x35, 0x14, 0x34, 0x53, 0xb2, 0x0d,
+	0x76, 0x12, 0x8a, 0x04, 0x6b, 0xf9, 0x2e, 0x59, 0x28, 0x13, 0x10, 0x7c, 0x6f, 0x01, 0x1c, 0xce,
+	0x66, 0x88, 0xbc, 0x01, 0x5b, 0xaf, 0x26, 0xb9, 0x73, 0xfd, 0x93, 0xe1, 0xf6, 0xaf, 0xf4, 0x9b,
+	0xf6, 0xfe, 0x37, 0xb4, 0xc8, 0x31, 0xfc, 0x5f, 0x0f, 0x24, 0x19, 0x2c, 0xda, 0x21, 0x77, 0x73,
+	0xe1, 0x34, 0x57, 0xa4, 0x0f, 0x2c, 0xf2, 0x0a, 0x6c, 0x3d, 0x4b, 0x97, 0x54, 0xd9, 0x98, 0x52,
+	0xb7, 0x7f, 0xa5, 0xbf, 0x26, 0xac, 0xc8, 0xb4, 0x7c, 0x97, 0x90, 0x35, 0x26, 0xd3, 0xed, 0x5f,
+	0xe9, 0xaf, 0xc9, 0x9e, 0x77, 0xde, 0xb7, 0xf8, 0x68, 0x64, 0xab, 0x56, 0xee, 0xfe, 0x1a, 0x00,
+	0x44, 0xa7, 0xed, 0x38, 0x69, 0x06, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -503,6 +643,7 @@ type PiecestoreClient interface {
 	Upload(ctx context.Context, opts ...grpc.CallOption) (Piecestore_UploadClient, error)
 	Download(ctx context.Context, opts ...grpc.CallOption) (Piecestore_DownloadClient, error)
 	Delete(ctx context.Context, in *PieceDeleteRequest, opts ...grpc.CallOption) (*PieceDeleteResponse, error)
+	Repair(ctx context.Context, in *PieceRepairRequest, opts ...grpc.CallOption) (*PieceRepairResponse, error)
 }
 
 type piecestoreClient struct {
@@ -587,11 +728,21 @@ func (c *piecestoreClient) Delete(ctx context.Context, in *PieceDeleteRequest, o
 	return out, nil
 }
 
+func (c *piecestoreClient) Repair(ctx context.Context, in *PieceRepairRequest, opts ...grpc.CallOption) (*PieceRepairResponse, error) {
+	out := new(PieceRepairResponse)
+	err := c.cc.Invoke(ctx, "/piecestore.Piecestore/Repair", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PiecestoreServer is the server API for Piecestore service.
 type PiecestoreServer interface {
 	Upload(Piecestore_UploadServer) error
 	Download(Piecestore_DownloadServer) error
 	Delete(context.Context, *PieceDeleteRequest) (*PieceDeleteResponse, error)
+	Repair(context.Context, *PieceRepairRequest) (*PieceRepairResponse, error)
 }
 
 func RegisterPiecestoreServer(s *grpc.Server, srv PiecestoreServer) {
@@ -668,6 +819,24 @@ func _Piecestore_Delete_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Piecestore_Repair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PieceRepairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PiecestoreServer).Repair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/piecestore.Piecestore/Repair",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PiecestoreServer).Repair(ctx, req.(*PieceRepairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Piecestore_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "piecestore.Piecestore",
 	HandlerType: (*PiecestoreServer)(nil),
@@ -676,6 +845,10 @@ var _Piecestore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Piecestore_Delete_Handler,
 		},
+		{
+			MethodName: "Repair",
+			Handler:    _Piecestore_Repair_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -25,15 +25,18 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 type RedundancyScheme_SchemeType int32
 
 const (
-	RedundancyScheme_RS RedundancyScheme_SchemeType = 0
+	RedundancyScheme_RS        RedundancyScheme_SchemeType = 0
+	RedundancyScheme_RS_CAUCHY RedundancyScheme_SchemeType = 1
 )
 
 var RedundancyScheme_SchemeType_name = map[int32]string{
 	0: "RS",
+	1: "RS_CAUCHY",
 }
 
 var RedundancyScheme_SchemeType_value = map[string]int32{
-	"RS": 0,
+	"RS":        0,
+	"RS_CAUCHY": 1,
 }
 
 func (x RedundancyScheme_SchemeType) String() string {
@@ -71,7 +74,7 @@ func (Pointer_DataType) EnumDescriptor() ([]byte, []int) {
 
 type RedundancyScheme struct {
 	Type RedundancyScheme_SchemeType `protobuf:"varint,1,opt,name=type,proto3,enum=pointerdb.RedundancyScheme_SchemeType" json:"type,omitempty"`
-	// these values apply to RS encoding
+	// these values apply to RS and RS_CAUCHY encoding
 	MinReq               int32    `protobuf:"varint,2,opt,name=min_req,json=minReq,proto3" json:"min_req,omitempty"`
 	Total                int32    `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
 	RepairThreshold      int32    `protobuf:"varint,4,opt,name=repair_threshold,json=repairThreshold,proto3" json:"repair_threshold,omitempty"`
@@ -451,50 +454,51 @@ func init() {
 func init() { proto.RegisterFile("pointerdb.proto", fileDescriptor_75fef806d28fc810) }
 
 var fileDescriptor_75fef806d28fc810 = []byte{
-	// 720 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0xcd, 0x6e, 0xeb, 0x44,
-	0x14, 0x6e, 0xfe, 0x9c, 0xf4, 0xd8, 0xf9, 0xb9, 0xa3, 0x2b, 0xb0, 0x72, 0x91, 0x52, 0x2c, 0x5d,
-	0x28, 0xe2, 0xca, 0x45, 0xbe, 0x3b, 0xba, 0x40, 0x2a, 0xa9, 0x44, 0xa4, 0x12, 0xaa, 0x49, 0x56,
-	0x6c, 0xac, 0x49, 0x7c, 0x1a, 0x8f, 0x88, 0x3d, 0xee, 0xcc, 0x44, 0x6a, 0xfb, 0x26, 0x3c, 0x0c,
-	0x7b, 0x9e, 0x81, 0x45, 0x79, 0x11, 0x16, 0xc8, 0x33, 0x76, 0x92, 0x52, 0x89, 0xbb, 0xb1, 0xcf,
-	0xcf, 0x77, 0xfe, 0xbe, 0x73, 0x06, 0x86, 0x85, 0xe0, 0xb9, 0x46, 0x99, 0xac, 0xc2, 0x42, 0x0a,
-	0x2d, 0xc8, 0xe9, 0xde, 0x30, 0x9e, 0x6c, 0x84, 0xd8, 0x6c, 0xf1, 0xc2, 0x38, 0x56, 0xbb, 0xbb,
-	0x0b, 0xcd, 0x33, 0x54, 0x9a, 0x65, 0x85, 0xc5, 0x8e, 0x61, 0x23, 0x36, 0xa2, 0x96, 0x73, 0x91,
-	0x60, 0x25, 0x8f, 0x0a, 0x8e, 0x6b, 0x54, 0x5a, 0xc8, 0xda, 0xe2, 0x09, 0x99, 0xa0, 0x54, 0x56,
-	0x0b, 0x7e, 0x6f, 0xc2, 0x88, 0x62, 0xb2, 0xcb, 0x13, 0x96, 0xaf, 0x1f, 0x17, 0xeb, 0x14, 0x33,
-	0x24, 0xdf, 0x43, 0x5b, 0x3f, 0x16, 0xe8, 0x37, 0xce, 0x1a, 0xe7, 0x83, 0xe8, 0xab, 0xf0, 0xd0,
-	0xd8, 0x7f, 0xa1, 0xa1, 0xfd, 0x2d, 0x1f, 0x0b, 0xa4, 0x26, 0x86, 0x7c, 0x0e, 0xdd, 0x8c, 0xe7,
-	0xb1, 0xc4, 0x7b, 0xbf, 0x79, 0xd6, 0x38, 0xef, 0x50, 0x27, 0xe3, 0x39, 0xc5, 0x7b, 0xf2, 0x16,
-	0x3a, 0x5a, 0x68, 0xb6, 0xf5, 0x5b, 0xc6, 0x6c, 0x15, 0xf2, 0x0d, 0x8c, 0x24, 0x16, 0x8c, 0xcb,
-	0x58, 0xa7, 0x12, 0x55, 0x2a, 0xb6, 0x89, 0xdf, 0x36, 0x80, 0xa1, 0xb5, 0x2f, 0x6b, 0x33, 0xf9,
-	0x16, 0xde, 0xa8, 0xdd, 0x7a, 0x8d, 0x4a, 0x1d, 0x61, 0x3b, 0x06, 0x3b, 0xaa, 0x1c, 0x07, 0xf0,
-	0x07, 0x20, 0x28, 0x99, 0xda, 0x49, 0x8c, 0x55, 0xca, 0xca, 0x2f, 0x7f, 0x42, 0xdf, 0xb1, 0xe8,
-	0xca, 0xb3, 0x28, 0x1d, 0x0b, 0xfe, 0x84, 0xc1, 0x5b, 0x80, 0xc3, 0x20, 0xc4, 0x81, 0x26, 0x5d,
-	0x8c, 0x4e, 0x82, 0x27, 0x70, 0x29, 0x66, 0x42, 0xe3, 0x6d, 0xc9, 0x21, 0x79, 0x07, 0xa7, 0x86,
-	0xcc, 0x38, 0xdf, 0x65, 0x86, 0x9a, 0x0e, 0xed, 0x19, 0xc3, 0x7c, 0x97, 0x91, 0xaf, 0xa1, 0x5b,
-	0xb2, 0x1e, 0xf3, 0xc4, 0x8c, 0xed, 0x5d, 0x0d, 0xfe, 0x7c, 0x9e, 0x9c, 0xfc, 0xf5, 0x3c, 0x71,
-	0xe6, 0x22, 0xc1, 0xd9, 0x94, 0x3a, 0xa5, 0x7b, 0x96, 0x90, 0xf7, 0xd0, 0x4e, 0x99, 0x4a, 0x0d,
-	0x0b, 0x6e, 0xf4, 0x26, 0xac, 0xb6, 0x61, 0x4a, 0xfc, 0xc4, 0x54, 0x4a, 0x8d, 0x3b, 0xf8, 0xbb,
-	0x01, 0x7d, 0x5b, 0x7c, 0x81, 0x9b, 0x0c, 0x73, 0x4d, 0x2e, 0x01, 0xe4, 0x9e, 0x7d, 0x53, 0xdf,
-	0x8d, 0xde, 0xfd, 0xcf, 0x6a, 0xe8, 0x11, 0x9c, 0x7c, 0x84, 0xbe, 0x14, 0x42, 0xc7, 0x76, 0x80,
-	0x7d, 0x93, 0xc3, 0xaa, 0xc9, 0xae, 0x29, 0x3f, 0x9b, 0x52, 0xb7, 0x44, 0x59, 0x25, 0x21, 0x97,
-	0xd0, 0x97, 0xa6, 0x05, 0x1b, 0xa6, 0xfc, 0xd6, 0x59, 0xeb, 0xdc, 0x8d, 0x3e, 0x7b, 0x51, 0x74,
-	0xcf, 0x0f, 0xf5, 0xe4, 0x41, 0x51, 0x64, 0x02, 0x6e, 0x86, 0xf2, 0xb7, 0x2d, 0xc6, 0x65, 0x4a,
-	0xb3, 0x53, 0x8f, 0x82, 0x35, 0x51, 0x21, 0x74, 0xf0, 0x4f, 0x13, 0xba, 0xb7, 0x36, 0x11, 0xb9,
-	0x78, 0x71, 0x70, 0xc7, 0x53, 0x55, 0x88, 0x70, 0xca, 0x34, 0x3b, 0xba, 0xb2, 0xf7, 0x30, 0xe0,
-	0xf9, 0x96, 0xe7, 0x18, 0x2b, 0x4b, 0x8f, 0xe1, 0xd3, 0xa3, 0x7d, 0x6b, 0xad, 0x39, 0xfb, 0x0e,
-	0x1c, 0xdb, 0x94, 0xa9, 0xef, 0x46, 0xfe, 0xab, 0xd6, 0x2b, 0x24, 0xad, 0x70, 0xe4, 0x4b, 0xf0,
-	0xaa, 0x8c, 0xf6, 0x62, 0xca, 0xfb, 0x6a, 0x51, 0xb7, 0xb2, 0x95, 0xc7, 0x42, 0x7e, 0x80, 0xfe,
-	0x5a, 0x22, 0xd3, 0x5c, 0xe4, 0x71, 0xc2, 0xb4, 0xbd, 0x2a, 0x37, 0x1a, 0x87, 0xf6, 0x8d, 0x86,
-	0xf5, 0x1b, 0x0d, 0x97, 0xf5, 0x1b, 0xa5, 0x5e, 0x1d, 0x30, 0x65, 0x1a, 0xc9, 0x8f, 0x30, 0xc4,
-	0x87, 0x82, 0xcb, 0xa3, 0x14, 0xdd, 0x4f, 0xa6, 0x18, 0x1c, 0x42, 0x4c, 0x92, 0x31, 0xf4, 0x32,
-	0xd4, 0x2c, 0x61, 0x9a, 0xf9, 0x3d, 0x33, 0xfb, 0x5e, 0x0f, 0x02, 0xe8, 0xd5, 0x7c, 0x11, 0x00,
-	0x67, 0x36, 0xbf, 0x99, 0xcd, 0xaf, 0x47, 0x27, 0xa5, 0x4c, 0xaf, 0x7f, 0xfe, 0x65, 0x79, 0x3d,
-	0x6a, 0x04, 0x7f, 0x34, 0xc0, 0xbb, 0xe1, 0x4a, 0x53, 0x54, 0x85, 0xc8, 0x15, 0x92, 0x08, 0x3a,
-	0x5c, 0x63, 0xa6, 0xfc, 0x86, 0xd9, 0xf2, 0x17, 0x47, 0x54, 0x1d, 0xe3, 0xc2, 0x99, 0xc6, 0x8c,
-	0x5a, 0x28, 0x21, 0xd0, 0xce, 0x84, 0x44, 0x73, 0x4d, 0x3d, 0x6a, 0xe4, 0x31, 0x42, 0xbb, 0x84,
-	0x94, 0xbe, 0x82, 0xe9, 0xd4, 0xec, 0xf4, 0x94, 0x1a, 0x99, 0x7c, 0x80, 0x6e, 0x95, 0xd5, 0x84,
-	0xb8, 0x11, 0x79, 0xbd, 0x6a, 0x5a, 0x43, 0xca, 0x07, 0xc7, 0x55, 0x5c, 0x48, 0xbc, 0xe3, 0x0f,
-	0x66, 0xbf, 0x3d, 0xda, 0xe3, 0xea, 0xd6, 0xe8, 0x57, 0xed, 0x5f, 0x9b, 0xc5, 0x6a, 0xe5, 0x18,
-	0xa6, 0x3e, 0xfe, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x0d, 0x8e, 0xe4, 0xf2, 0x3c, 0x05, 0x00, 0x00,
+	// 735 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x4f, 0x8f, 0xdb, 0x44,
+	0x14, 0x5f, 0x67, 0x13, 0x27, 0xfb, 0xec, 0x24, 0xee, 0x08, 0x81, 0x95, 0x22, 0x65, 0x31, 0x2a,
+	0x2c, 0xa2, 0xf2, 0x22, 0xf7, 0x46, 0x0f, 0x88, 0x6e, 0x56, 0x6a, 0xa4, 0x12, 0x56, 0x93, 0x70,
+	0x80, 0x8b, 0x35, 0x89, 0x5f, 0xe3, 0x11, 0xb1, 0xc7, 0x9d, 0x99, 0x48, 0xdd, 0xfd, 0x44, 0x7c,
+	0x09, 0xee, 0x7c, 0x06, 0x0e, 0xe5, 0x8b, 0x70, 0x40, 0x9e, 0xb1, 0x93, 0x94, 0x4a, 0x70, 0xb1,
+	0xe7, 0xbd, 0xf7, 0x7b, 0x7f, 0xe6, 0xf7, 0x7b, 0x03, 0xe3, 0x4a, 0xf0, 0x52, 0xa3, 0xcc, 0xd6,
+	0x71, 0x25, 0x85, 0x16, 0xe4, 0xe2, 0xe0, 0x98, 0x4c, 0xb7, 0x42, 0x6c, 0x77, 0x78, 0x6d, 0x02,
+	0xeb, 0xfd, 0xeb, 0x6b, 0xcd, 0x0b, 0x54, 0x9a, 0x15, 0x95, 0xc5, 0x4e, 0x60, 0x2b, 0xb6, 0xa2,
+	0x3d, 0x97, 0x22, 0xc3, 0xe6, 0x1c, 0x54, 0x1c, 0x37, 0xa8, 0xb4, 0x90, 0xad, 0xc7, 0x17, 0x32,
+	0x43, 0xa9, 0xac, 0x15, 0xfd, 0xd6, 0x81, 0x80, 0x62, 0xb6, 0x2f, 0x33, 0x56, 0x6e, 0xee, 0x97,
+	0x9b, 0x1c, 0x0b, 0x24, 0xdf, 0x42, 0x57, 0xdf, 0x57, 0x18, 0x3a, 0x97, 0xce, 0xd5, 0x28, 0xf9,
+	0x22, 0x3e, 0x0e, 0xf6, 0x6f, 0x68, 0x6c, 0x7f, 0xab, 0xfb, 0x0a, 0xa9, 0xc9, 0x21, 0x9f, 0x40,
+	0xbf, 0xe0, 0x65, 0x2a, 0xf1, 0x4d, 0xd8, 0xb9, 0x74, 0xae, 0x7a, 0xd4, 0x2d, 0x78, 0x49, 0xf1,
+	0x0d, 0xf9, 0x08, 0x7a, 0x5a, 0x68, 0xb6, 0x0b, 0xcf, 0x8d, 0xdb, 0x1a, 0xe4, 0x2b, 0x08, 0x24,
+	0x56, 0x8c, 0xcb, 0x54, 0xe7, 0x12, 0x55, 0x2e, 0x76, 0x59, 0xd8, 0x35, 0x80, 0xb1, 0xf5, 0xaf,
+	0x5a, 0x37, 0xf9, 0x1a, 0x1e, 0xa9, 0xfd, 0x66, 0x83, 0x4a, 0x9d, 0x60, 0x7b, 0x06, 0x1b, 0x34,
+	0x81, 0x23, 0xf8, 0x29, 0x10, 0x94, 0x4c, 0xed, 0x25, 0xa6, 0x2a, 0x67, 0xf5, 0x97, 0x3f, 0x60,
+	0xe8, 0x5a, 0x74, 0x13, 0x59, 0xd6, 0x81, 0x25, 0x7f, 0xc0, 0xe8, 0x73, 0x80, 0xe3, 0x45, 0x88,
+	0x0b, 0x1d, 0xba, 0x0c, 0xce, 0xc8, 0x10, 0x2e, 0xe8, 0x32, 0xbd, 0xf9, 0xfe, 0xa7, 0x9b, 0x97,
+	0x3f, 0x07, 0x4e, 0xf4, 0x00, 0x1e, 0xc5, 0x42, 0x68, 0xbc, 0xab, 0x29, 0x25, 0x8f, 0xe1, 0xc2,
+	0x70, 0x9b, 0x96, 0xfb, 0xc2, 0x30, 0xd5, 0xa3, 0x03, 0xe3, 0x58, 0xec, 0x0b, 0xf2, 0x25, 0xf4,
+	0x6b, 0x11, 0x52, 0x9e, 0x19, 0x16, 0xfc, 0x17, 0xa3, 0x3f, 0xde, 0x4d, 0xcf, 0xfe, 0x7c, 0x37,
+	0x75, 0x17, 0x22, 0xc3, 0xf9, 0x8c, 0xba, 0x75, 0x78, 0x9e, 0x91, 0x27, 0xd0, 0xcd, 0x99, 0xca,
+	0x0d, 0x29, 0x5e, 0xf2, 0x28, 0x6e, 0xc4, 0x31, 0x2d, 0x5e, 0x32, 0x95, 0x53, 0x13, 0x8e, 0xfe,
+	0x72, 0x60, 0x68, 0x9b, 0x2f, 0x71, 0x5b, 0x60, 0xa9, 0xc9, 0x73, 0x00, 0x79, 0x10, 0xc3, 0xf4,
+	0xf7, 0x92, 0xc7, 0xff, 0xa1, 0x14, 0x3d, 0x81, 0x93, 0x67, 0x30, 0x94, 0x42, 0xe8, 0xd4, 0x5e,
+	0xe0, 0x30, 0xe4, 0xb8, 0x19, 0xb2, 0x6f, 0xda, 0xcf, 0x67, 0xd4, 0xab, 0x51, 0xd6, 0xc8, 0xc8,
+	0x73, 0x18, 0x4a, 0x33, 0x82, 0x4d, 0x53, 0xe1, 0xf9, 0xe5, 0xf9, 0x95, 0x97, 0x7c, 0xfc, 0x5e,
+	0xd3, 0x03, 0x3f, 0xd4, 0x97, 0x47, 0x43, 0x91, 0x29, 0x78, 0x05, 0xca, 0x5f, 0x77, 0x98, 0xd6,
+	0x25, 0x8d, 0xc4, 0x3e, 0x05, 0xeb, 0xa2, 0x42, 0xe8, 0xe8, 0xef, 0x0e, 0xf4, 0xef, 0x6c, 0x21,
+	0x72, 0xfd, 0xde, 0xfe, 0x9d, 0xde, 0xaa, 0x41, 0xc4, 0x33, 0xa6, 0xd9, 0xc9, 0xd2, 0x3d, 0x81,
+	0x11, 0x2f, 0x77, 0xbc, 0xc4, 0x54, 0x59, 0x7a, 0x0c, 0x9f, 0x3e, 0x1d, 0x5a, 0x6f, 0xcb, 0xd9,
+	0x37, 0xe0, 0xda, 0xa1, 0x4c, 0x7f, 0x2f, 0x09, 0x3f, 0x18, 0xbd, 0x41, 0xd2, 0x06, 0x47, 0x3e,
+	0x03, 0xbf, 0xa9, 0x68, 0x17, 0xa8, 0x5e, 0xb7, 0x73, 0xea, 0x35, 0xbe, 0x7a, 0x77, 0xc8, 0x77,
+	0x30, 0xdc, 0x48, 0x64, 0x9a, 0x8b, 0x32, 0xcd, 0x98, 0xb6, 0x4b, 0xe6, 0x25, 0x93, 0xd8, 0x3e,
+	0xd9, 0xb8, 0x7d, 0xb2, 0xf1, 0xaa, 0x7d, 0xb2, 0xd4, 0x6f, 0x13, 0x66, 0x4c, 0x23, 0xb9, 0x81,
+	0x31, 0xbe, 0xad, 0xb8, 0x3c, 0x29, 0xd1, 0xff, 0xdf, 0x12, 0xa3, 0x63, 0x8a, 0x29, 0x32, 0x81,
+	0x41, 0x81, 0x9a, 0x65, 0x4c, 0xb3, 0x70, 0x60, 0xee, 0x7e, 0xb0, 0xa3, 0x08, 0x06, 0x2d, 0x5f,
+	0x04, 0xc0, 0x9d, 0x2f, 0x5e, 0xcd, 0x17, 0xb7, 0xc1, 0x59, 0x7d, 0xa6, 0xb7, 0x3f, 0xfc, 0xb8,
+	0xba, 0x0d, 0x9c, 0xe8, 0x77, 0x07, 0xfc, 0x57, 0x5c, 0x69, 0x8a, 0xaa, 0x12, 0xa5, 0x42, 0x92,
+	0x40, 0x8f, 0x6b, 0x2c, 0x54, 0xe8, 0x18, 0x95, 0x3f, 0x3d, 0xa1, 0xea, 0x14, 0x17, 0xcf, 0x35,
+	0x16, 0xd4, 0x42, 0x09, 0x81, 0x6e, 0x21, 0x24, 0x9a, 0x6d, 0x1a, 0x50, 0x73, 0x9e, 0x20, 0x74,
+	0x6b, 0x48, 0x1d, 0xab, 0x98, 0xce, 0x8d, 0xa6, 0x17, 0xd4, 0x9c, 0xc9, 0x53, 0xe8, 0x37, 0x55,
+	0x4d, 0x8a, 0x97, 0x90, 0x0f, 0xa5, 0xa6, 0x2d, 0xa4, 0x7e, 0x70, 0x5c, 0xa5, 0x95, 0xc4, 0xd7,
+	0xfc, 0xad, 0xd1, 0x77, 0x40, 0x07, 0x5c, 0xdd, 0x19, 0xfb, 0x45, 0xf7, 0x97, 0x4e, 0xb5, 0x5e,
+	0xbb, 0x86, 0xa9, 0x67, 0xff, 0x0c, 0x00, 0x8f, 0xf8, 0xc2, 0xe9, 0x4b, 0x05, 0x00, 0x00,
 }
@@ -67,10 +67,13 @@ func (m *SegmentMeta) GetKeyNonce() []byte {
 }
 
 type StreamInfo struct {
-	NumberOfSegments     int64    `protobuf:"varint,1,opt,name=number_of_segments,json=numberOfSegments,proto3" json:"number_of_segments,omitempty"`
-	SegmentsSize         int64    `protobuf:"varint,2,opt,name=segments_size,json=segmentsSize,proto3" json:"segments_size,omitempty"`
-	LastSegmentSize      int64    `protobuf:"varint,3,opt,name=last_segment_size,json=lastSegmentSize,proto3" json:"last_segment_size,omitempty"`
-	Metadata             []byte   `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	NumberOfSegments int64  `protobuf:"varint,1,opt,name=number_of_segments,json=numberOfSegments,proto3" json:"number_of_segments,omitempty"`
+	SegmentsSize     int64  `protobuf:"varint,2,opt,name=segments_size,json=segmentsSize,proto3" json:"segments_size,omitempty"`
+	LastSegmentSize  int64  `protobuf:"varint,3,opt,name=last_segment_size,json=lastSegmentSize,proto3" json:"last_segment_size,omitempty"`
+	Metadata         []byte `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// md5 and sha256 are the checksums of the plaintext of the stream
+	Md5                  []byte   `protobuf:"bytes,5,opt,name=md5,proto3" json:"md5,omitempty"`
+	Sha256               []byte   `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -128,14 +131,31 @@ func (m *StreamInfo) GetMetadata() []byte {
 	return nil
 }
 
+func (m *StreamInfo) GetMd5() []byte {
+	if m != nil {
+		return m.Md5
+	}
+	return nil
+}
+
+func (m *StreamInfo) GetSha256() []byte {
+	if m != nil {
+		return m.Sha256
+	}
+	return nil
+}
+
 type StreamMeta struct {
-	EncryptedStreamInfo  []byte       `protobuf:"bytes,1,opt,name=encrypted_stream_info,json=encryptedStreamInfo,proto3" json:"encrypted_stream_info,omitempty"`
-	EncryptionType       int32        `protobuf:"varint,2,opt,name=encryption_type,json=encryptionType,proto3" json:"encryption_type,omitempty"`
-	EncryptionBlockSize  int32        `protobuf:"varint,3,opt,name=encryption_block_size,json=encryptionBlockSize,proto3" json:"encryption_block_size,omitempty"`
-	LastSegmentMeta      *SegmentMeta `protobuf:"bytes,4,opt,name=last_segment_meta,json=lastSegmentMeta,proto3" json:"last_segment_meta,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	EncryptedStreamInfo []byte       `protobuf:"bytes,1,opt,name=encrypted_stream_info,json=encryptedStreamInfo,proto3" json:"encrypted_stream_info,omitempty"`
+	EncryptionType      int32        `protobuf:"varint,2,opt,name=encryption_type,json=encryptionType,proto3" json:"encryption_type,omitempty"`
+	EncryptionBlockSize int32        `protobuf:"varint,3,opt,name=encryption_block_size,json=encryptionBlockSize,proto3" json:"encryption_block_size,omitempty"`
+	LastSegmentMeta     *SegmentMeta `protobuf:"bytes,4,opt,name=last_segment_meta,json=lastSegmentMeta,proto3" json:"last_segment_meta,omitempty"`
+	// nonce the stream info is encrypted with when it was updated after the
+	// upload, the zero nonce otherwise
+	StreamInfoNonce      []byte   `protobuf:"bytes,5,opt,name=stream_info_nonce,json=streamInfoNonce,proto3" json:"stream_info_nonce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *StreamMeta) Reset()         { *m = StreamMeta{} }
@@ -190,6 +210,13 @@ func (m *StreamMeta) GetLastSegmentMeta() *SegmentMeta {
 	return nil
 }
 
+func (m *StreamMeta) GetStreamInfoNonce() []byte {
+	if m != nil {
+		return m.StreamInfoNonce
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*SegmentMeta)(nil), "streams.SegmentMeta")
 	proto.RegisterType((*StreamInfo)(nil), "streams.StreamInfo")
@@ -199,24 +226,27 @@ func init() {
 func init() { proto.RegisterFile("streams.proto", fileDescriptor_c6bbf8af0ec331d6) }
 
 var fileDescriptor_c6bbf8af0ec331d6 = []byte{
-	// 304 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x51, 0xcb, 0x4e, 0xc3, 0x30,
-	0x10, 0x54, 0x5f, 0x50, 0xb6, 0x29, 0x05, 0x03, 0x52, 0x04, 0x17, 0x14, 0x0e, 0x20, 0x84, 0x7a,
-	0x28, 0x3f, 0x80, 0x7a, 0x43, 0x08, 0x2a, 0x25, 0x9c, 0xb8, 0x58, 0x4e, 0xba, 0x41, 0x51, 0x1a,
-	0x3b, 0x8a, 0xcd, 0xc1, 0xfd, 0x21, 0x3e, 0x8b, 0x5f, 0x41, 0xb6, 0xf3, 0x82, 0xe3, 0xce, 0x8c,
-	0x66, 0x67, 0x76, 0x61, 0x2e, 0x55, 0x85, 0xac, 0x90, 0xcb, 0xb2, 0x12, 0x4a, 0x90, 0xc3, 0x7a,
-	0x0c, 0x36, 0x30, 0x8b, 0xf0, 0xb3, 0x40, 0xae, 0x5e, 0x51, 0x31, 0x72, 0x03, 0x73, 0xe4, 0x49,
-	0xa5, 0x4b, 0x85, 0x5b, 0x9a, 0xa3, 0xf6, 0x07, 0xd7, 0x83, 0x3b, 0x2f, 0xf4, 0x5a, 0xf0, 0x05,
-	0x35, 0xb9, 0x82, 0xa3, 0x1c, 0x35, 0xe5, 0x82, 0x27, 0xe8, 0x0f, 0xad, 0x60, 0x9a, 0xa3, 0x7e,
-	0x33, 0x73, 0xf0, 0x3d, 0x00, 0x88, 0xac, 0xf9, 0x33, 0x4f, 0x05, 0x79, 0x00, 0xc2, 0xbf, 0x8a,
-	0x18, 0x2b, 0x2a, 0x52, 0x2a, 0xdd, 0x26, 0x69, 0x5d, 0x47, 0xe1, 0x89, 0x63, 0x36, 0x69, 0x9d,
-	0x40, 0x9a, 0xf5, 0x8d, 0x86, 0xca, 0x6c, 0xef, 0xdc, 0x47, 0xa1, 0xd7, 0x80, 0x51, 0xb6, 0x47,
-	0x72, 0x0f, 0xa7, 0x3b, 0x26, 0x55, 0xe3, 0xe6, 0x84, 0x23, 0x2b, 0x5c, 0x18, 0xa2, 0x76, 0xb3,
-	0xda, 0x4b, 0x98, 0x16, 0xa8, 0xd8, 0x96, 0x29, 0xe6, 0x8f, 0x5d, 0xd2, 0x66, 0x0e, 0x7e, 0xda,
-	0xa4, 0xb6, 0xfa, 0x0a, 0x2e, 0xba, 0xea, 0xee, 0x3c, 0x34, 0xe3, 0xa9, 0xa8, 0x4f, 0x70, 0xd6,
-	0x92, 0xbd, 0x76, 0xb7, 0xb0, 0xa8, 0xe1, 0x4c, 0x70, 0xaa, 0x74, 0xe9, 0x12, 0x4f, 0xc2, 0xe3,
-	0x0e, 0x7e, 0xd7, 0x25, 0xf6, 0xcc, 0x8d, 0x30, 0xde, 0x89, 0x24, 0xef, 0x72, 0x4f, 0x5a, 0xf3,
-	0x4c, 0xf0, 0xb5, 0xe1, 0x6c, 0xf6, 0xa7, 0x7f, 0x3d, 0x4d, 0x70, 0x5b, 0x62, 0xb6, 0x3a, 0x5f,
-	0x36, 0xef, 0xec, 0x3d, 0xef, 0x4f, 0x7b, 0x03, 0xac, 0xc7, 0x1f, 0xc3, 0x32, 0x8e, 0x0f, 0xec,
-	0xcb, 0x1f, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0xdf, 0x5e, 0x32, 0x31, 0x03, 0x02, 0x00, 0x00,
+	// 343 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x52, 0x4f, 0x4f, 0xfa, 0x40,
+	0x14, 0x0c, 0x14, 0xf8, 0xf1, 0x7b, 0x80, 0xc0, 0xfa, 0x27, 0x8d, 0x5e, 0x0c, 0x1e, 0x34, 0xc4,
+	0x70, 0xc0, 0xe0, 0xd9, 0x70, 0x33, 0x46, 0x49, 0x8a, 0x27, 0x2f, 0x9b, 0x2d, 0xbc, 0x6a, 0x53,
+	0xba, 0xdb, 0x74, 0xd7, 0xc3, 0xf2, 0x15, 0xfc, 0x74, 0x7e, 0x23, 0xd3, 0xdd, 0x6d, 0xa9, 0xde,
+	0xfa, 0xe6, 0x4d, 0x66, 0xdf, 0xcc, 0x14, 0x06, 0x52, 0xe5, 0xc8, 0x52, 0x39, 0xcb, 0x72, 0xa1,
+	0x04, 0xf9, 0xe7, 0xc6, 0xc9, 0x0a, 0x7a, 0x6b, 0x7c, 0x4f, 0x91, 0xab, 0x67, 0x54, 0x8c, 0x5c,
+	0xc1, 0x00, 0xf9, 0x26, 0xd7, 0x99, 0xc2, 0x2d, 0x4d, 0x50, 0xfb, 0x8d, 0xcb, 0xc6, 0x4d, 0x3f,
+	0xe8, 0x57, 0xe0, 0x13, 0x6a, 0x72, 0x01, 0xff, 0x13, 0xd4, 0x94, 0x0b, 0xbe, 0x41, 0xbf, 0x69,
+	0x08, 0xdd, 0x04, 0xf5, 0x4b, 0x31, 0x4f, 0xbe, 0x1b, 0x00, 0x6b, 0x23, 0xfe, 0xc8, 0x23, 0x41,
+	0x6e, 0x81, 0xf0, 0xcf, 0x34, 0xc4, 0x9c, 0x8a, 0x88, 0x4a, 0xfb, 0x92, 0x34, 0xaa, 0x5e, 0x30,
+	0xb2, 0x9b, 0x55, 0xe4, 0x2e, 0x90, 0xc5, 0xf3, 0x25, 0x87, 0xca, 0x78, 0x6f, 0xd5, 0xbd, 0xa0,
+	0x5f, 0x82, 0xeb, 0x78, 0x8f, 0x64, 0x0a, 0xe3, 0x1d, 0x93, 0xaa, 0x54, 0xb3, 0x44, 0xcf, 0x10,
+	0x87, 0xc5, 0xc2, 0xa9, 0x19, 0xee, 0x39, 0x74, 0x53, 0x54, 0x6c, 0xcb, 0x14, 0xf3, 0x5b, 0xf6,
+	0xd2, 0x72, 0x26, 0x23, 0xf0, 0xd2, 0xed, 0xc2, 0x6f, 0x1b, 0xb8, 0xf8, 0x24, 0x67, 0xd0, 0x91,
+	0x1f, 0x6c, 0xbe, 0xb8, 0xf7, 0x3b, 0x06, 0x74, 0xd3, 0xe4, 0xab, 0x59, 0x7a, 0x32, 0x21, 0xcd,
+	0xe1, 0xf4, 0x10, 0x92, 0x0d, 0x92, 0xc6, 0x3c, 0x12, 0x2e, 0xac, 0xe3, 0x6a, 0x59, 0xcb, 0xe1,
+	0x1a, 0x86, 0x0e, 0x8e, 0x05, 0xa7, 0x4a, 0x67, 0xd6, 0x5b, 0x3b, 0x38, 0x3a, 0xc0, 0xaf, 0x3a,
+	0xc3, 0x9a, 0x78, 0x41, 0x0c, 0x77, 0x62, 0x93, 0x1c, 0x1c, 0xb6, 0x2b, 0xf1, 0x58, 0xf0, 0x65,
+	0xb1, 0x33, 0x2e, 0x1f, 0xfe, 0x24, 0x92, 0xa2, 0xb3, 0xdb, 0x9b, 0x9f, 0xcc, 0xca, 0xe2, 0x6b,
+	0x35, 0xff, 0xca, 0xc9, 0x58, 0x9a, 0xc2, 0xb8, 0x66, 0xc4, 0x55, 0x6b, 0x93, 0x19, 0xca, 0xca,
+	0x85, 0x69, 0x78, 0xd9, 0x7a, 0x6b, 0x66, 0x61, 0xd8, 0x31, 0x3f, 0xd2, 0xdd, 0xcf, 0x00, 0x90,
+	0x00, 0x23, 0x05, 0x59, 0x02, 0x00, 0x00,
 }
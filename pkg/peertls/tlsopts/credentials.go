@@ -0,0 +1,59 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package tlsopts
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+
+	"storj.io/storj/pkg/quic"
+)
+
+// secureCredentials are tls credentials that skip the handshake for QUIC
+// connections, which verified the peer with the same tls configuration
+// during the QUIC handshake
+type secureCredentials struct {
+	credentials.TransportCredentials
+}
+
+// newCredentials returns tls credentials for tlsConfig that also accept QUIC connections
+func newCredentials(tlsConfig *tls.Config) credentials.TransportCredentials {
+	return secureCredentials{credentials.NewTLS(tlsConfig)}
+}
+
+// ClientHandshake does the tls handshake unless conn is a QUIC connection
+func (creds secureCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if quicConn, ok := asQUIC(conn); ok {
+		return conn, credentials.TLSInfo{State: quicConn.ConnectionState()}, nil
+	}
+	return creds.TransportCredentials.ClientHandshake(ctx, authority, conn)
+}
+
+// ServerHandshake does the tls handshake unless conn is a QUIC connection
+func (creds secureCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if quicConn, ok := asQUIC(conn); ok {
+		return conn, credentials.TLSInfo{State: quicConn.ConnectionState()}, nil
+	}
+	return creds.TransportCredentials.ServerHandshake(conn)
+}
+
+// Clone makes a copy of the credentials
+func (creds secureCredentials) Clone() credentials.TransportCredentials {
+	return secureCredentials{creds.TransportCredentials.Clone()}
+}
+
+// asQUIC returns the QUIC connection conn is, or wraps when it has a QUICConn method
+func asQUIC(conn net.Conn) (*quic.Conn, bool) {
+	switch conn := conn.(type) {
+	case *quic.Conn:
+		return conn, true
+	case interface{ QUICConn() *quic.Conn }:
+		quicConn := conn.QUICConn()
+		return quicConn, quicConn != nil
+	}
+	return nil, false
+}
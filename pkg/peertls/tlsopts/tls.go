@@ -8,7 +8,6 @@ import (
 	"crypto/x509"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials"
 
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/peertls"
@@ -19,7 +18,7 @@ import (
 // to the node with this full identity.
 func (opts *Options) ServerOption() grpc.ServerOption {
 	tlsConfig := opts.ServerTLSConfig()
-	return grpc.Creds(credentials.NewTLS(tlsConfig))
+	return grpc.Creds(newCredentials(tlsConfig))
 }
 
 // DialOption returns a grpc `DialOption` for making outgoing connections
@@ -29,13 +28,13 @@ func (opts *Options) DialOption(id storj.NodeID) (grpc.DialOption, error) {
 		return nil, Error.New("no ID specified for DialOption")
 	}
 	tlsConfig := opts.ClientTLSConfig(id)
-	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
+	return grpc.WithTransportCredentials(newCredentials(tlsConfig)), nil
 }
 
 // DialUnverifiedIDOption returns a grpc `DialUnverifiedIDOption`
 func (opts *Options) DialUnverifiedIDOption() grpc.DialOption {
-	tlsConfig := opts.tlsConfig(false)
-	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	tlsConfig := opts.UnverifiedClientTLSConfig()
+	return grpc.WithTransportCredentials(newCredentials(tlsConfig))
 }
 
 // ServerTLSConfig returns a TSLConfig for use as a server in handshaking with a peer.
@@ -48,6 +47,12 @@ func (opts *Options) ClientTLSConfig(id storj.NodeID) *tls.Config {
 	return opts.tlsConfig(false, verifyIdentity(id))
 }
 
+// UnverifiedClientTLSConfig returns a TLSConfig for use as a client in handshaking with a peer
+// whose node ID is not known in advance.
+func (opts *Options) UnverifiedClientTLSConfig() *tls.Config {
+	return opts.tlsConfig(false)
+}
+
 func (opts *Options) tlsConfig(isServer bool, verificationFuncs ...peertls.PeerCertVerificationFunc) *tls.Config {
 	verificationFuncs = append(
 		[]peertls.PeerCertVerificationFunc{
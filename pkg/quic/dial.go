@@ -0,0 +1,63 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+
+	"golang.org/x/net/quic"
+)
+
+// dialer holds the udp endpoint all dialed connections share
+var dialer struct {
+	mu       sync.Mutex
+	endpoint *quic.Endpoint
+}
+
+// Dial connects over QUIC to address and opens the stream gRPC runs on.
+//
+// The peer is verified during the handshake with tlsConfig, which should be
+// the tlsopts client configuration for the node being dialed. All dialed
+// connections share a single udp endpoint.
+func Dial(ctx context.Context, tlsConfig *tls.Config, address string) (_ *Conn, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	endpoint, err := dialEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := endpoint.Dial(ctx, "udp", address, &quic.Config{
+		TLSConfig:        withNextProto(tlsConfig),
+		HandshakeTimeout: defaultHandshakeTimeout,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	stream, err := conn.NewStream(ctx)
+	if err != nil {
+		conn.Abort(nil)
+		return nil, Error.Wrap(err)
+	}
+
+	return newConn(conn, stream), nil
+}
+
+// dialEndpoint returns the shared endpoint, creating it on first use
+func dialEndpoint() (*quic.Endpoint, error) {
+	dialer.mu.Lock()
+	defer dialer.mu.Unlock()
+
+	if dialer.endpoint == nil {
+		endpoint, err := quic.Listen("udp", ":0", nil)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		dialer.endpoint = endpoint
+	}
+	return dialer.endpoint, nil
+}
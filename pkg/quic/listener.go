@@ -0,0 +1,111 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/quic"
+)
+
+// Listener is a net.Listener accepting gRPC connections over QUIC
+type Listener struct {
+	log      *zap.Logger
+	endpoint *quic.Endpoint
+
+	conns chan *Conn
+
+	// streamTimeout bounds how long a connection may take to open its stream
+	streamTimeout time.Duration
+
+	ctx       context.Context
+	cancel    func()
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// Listen accepts QUIC connections on the udp address, verifying peers with
+// tlsConfig, which should be the tlsopts server configuration.
+func Listen(log *zap.Logger, tlsConfig *tls.Config, address string) (*Listener, error) {
+	endpoint, err := quic.Listen("udp", address, &quic.Config{
+		TLSConfig:        withNextProto(tlsConfig),
+		HandshakeTimeout: defaultHandshakeTimeout,
+	})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	listener := &Listener{
+		log:           log,
+		endpoint:      endpoint,
+		conns:         make(chan *Conn),
+		streamTimeout: defaultStreamTimeout,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+	go listener.acceptConns()
+	return listener, nil
+}
+
+// Accept waits for a QUIC connection to open its stream
+func (listener *Listener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-listener.conns:
+		return conn, nil
+	case <-listener.ctx.Done():
+		return nil, Error.New("listener closed")
+	}
+}
+
+// Close stops accepting and closes all QUIC connections
+func (listener *Listener) Close() error {
+	listener.closeOnce.Do(func() {
+		listener.cancel()
+		listener.closeErr = closeEndpoint(listener.endpoint)
+	})
+	return listener.closeErr
+}
+
+// Addr returns the udp address the listener is bound to
+func (listener *Listener) Addr() net.Addr {
+	return net.UDPAddrFromAddrPort(listener.endpoint.LocalAddr())
+}
+
+// acceptConns accepts QUIC connections until the listener is closed
+func (listener *Listener) acceptConns() {
+	for {
+		conn, err := listener.endpoint.Accept(listener.ctx)
+		if err != nil {
+			return
+		}
+		// waiting for the stream must not hold up accepting other connections
+		go listener.acceptStream(conn)
+	}
+}
+
+// acceptStream waits for the dialer to open the gRPC stream on conn, closing
+// conn when no stream is opened within the stream timeout
+func (listener *Listener) acceptStream(conn *quic.Conn) {
+	ctx, cancel := context.WithTimeout(listener.ctx, listener.streamTimeout)
+	defer cancel()
+
+	stream, err := conn.AcceptStream(ctx)
+	if err != nil {
+		listener.log.Debug("failed to accept stream", zap.Stringer("remote", conn.RemoteAddr()), zap.Error(err))
+		conn.Abort(err)
+		return
+	}
+
+	select {
+	case listener.conns <- newConn(conn, stream):
+	case <-listener.ctx.Done():
+		conn.Abort(nil)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/net/quic"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestStreamTimeout(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	ident, err := testidentity.PregeneratedIdentity(0, storj.LatestIDVersion())
+	require.NoError(t, err)
+	serverConfig := &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{ident.Leaf.Raw, ident.CA.Raw},
+			PrivateKey:  ident.Key,
+		}},
+	}
+
+	listener, err := Listen(zaptest.NewLogger(t), serverConfig, "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ctx.Check(listener.Close)
+	listener.streamTimeout = 100 * time.Millisecond
+
+	endpoint, err := quic.Listen("udp", "127.0.0.1:0", nil)
+	require.NoError(t, err)
+	defer ctx.Check(func() error { return closeEndpoint(endpoint) })
+
+	// the connection is handshaked, but never opens its stream
+	conn, err := endpoint.Dial(ctx, "udp", listener.Addr().String(), &quic.Config{
+		TLSConfig:        withNextProto(&tls.Config{InsecureSkipVerify: true}),
+		HandshakeTimeout: defaultHandshakeTimeout,
+	})
+	require.NoError(t, err)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// the listener closes it after the stream timeout
+	err = conn.Wait(waitCtx)
+	assert.NotEqual(t, context.DeadlineExceeded, err)
+	assert.NoError(t, waitCtx.Err())
+}
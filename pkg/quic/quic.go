@@ -0,0 +1,170 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package quic lets nodes serve and dial gRPC connections over QUIC.
+//
+// Every QUIC connection carries a single bidirectional stream, which is
+// exposed as a net.Conn so it can be served by the same gRPC server as TCP
+// connections. The peer identity is verified during the QUIC handshake with
+// the tlsopts configuration. The stream is already secured, so gRPC skips its
+// own TLS handshake for it and takes the peer certificates from the QUIC
+// connection instead.
+package quic
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"golang.org/x/net/quic"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default quic errs class
+	Error = errs.Class("quic error")
+)
+
+const (
+	// nextProto is the ALPN protocol negotiated for gRPC over QUIC
+	nextProto = "storj-grpc"
+
+	// defaultHandshakeTimeout is how long to wait for a QUIC handshake
+	defaultHandshakeTimeout = 10 * time.Second
+	// defaultStreamTimeout is how long to wait for a handshaked connection to open its stream
+	defaultStreamTimeout = 10 * time.Second
+	// defaultCloseTimeout is how long to wait for peers to acknowledge closing an endpoint
+	defaultCloseTimeout = time.Second
+)
+
+// Config configures accepting connections over QUIC
+type Config struct {
+	Enabled bool `user:"true" help:"accept connections over QUIC on the udp port of the public address, advertised to clients preferring QUIC for piece transfers" default:"false"`
+}
+
+// withNextProto returns a copy of tlsConfig negotiating gRPC over QUIC
+func withNextProto(tlsConfig *tls.Config) *tls.Config {
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.MinVersion = tls.VersionTLS13
+	tlsConfig.NextProtos = []string{nextProto}
+	return tlsConfig
+}
+
+// Conn is a net.Conn over the single stream of a QUIC connection
+type Conn struct {
+	conn   *quic.Conn
+	stream *quic.Stream
+
+	mu          sync.Mutex
+	cancelRead  func()
+	cancelWrite func()
+
+	closeOnce sync.Once
+}
+
+func newConn(conn *quic.Conn, stream *quic.Stream) *Conn {
+	return &Conn{
+		conn:   conn,
+		stream: stream,
+
+		cancelRead:  func() {},
+		cancelWrite: func() {},
+	}
+}
+
+// ConnectionState returns the state of the TLS handshake done by QUIC
+func (conn *Conn) ConnectionState() tls.ConnectionState {
+	return conn.conn.ConnectionState()
+}
+
+// Read reads from the stream
+func (conn *Conn) Read(p []byte) (int, error) {
+	return conn.stream.Read(p)
+}
+
+// Write writes to the stream and sends the data immediately
+func (conn *Conn) Write(p []byte) (int, error) {
+	n, err := conn.stream.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, conn.stream.Flush()
+}
+
+// Close closes the QUIC connection
+func (conn *Conn) Close() error {
+	conn.closeOnce.Do(func() {
+		conn.mu.Lock()
+		conn.cancelRead()
+		conn.cancelWrite()
+		conn.mu.Unlock()
+
+		conn.conn.Abort(nil)
+	})
+	return nil
+}
+
+// LocalAddr returns the local udp address
+func (conn *Conn) LocalAddr() net.Addr {
+	return net.UDPAddrFromAddrPort(conn.conn.LocalAddr())
+}
+
+// RemoteAddr returns the remote udp address
+func (conn *Conn) RemoteAddr() net.Addr {
+	return net.UDPAddrFromAddrPort(conn.conn.RemoteAddr())
+}
+
+// SetDeadline sets the read and write deadlines
+func (conn *Conn) SetDeadline(t time.Time) error {
+	return errs.Combine(conn.SetReadDeadline(t), conn.SetWriteDeadline(t))
+}
+
+// SetReadDeadline sets the deadline for reads, a zero value disables it
+func (conn *Conn) SetReadDeadline(t time.Time) error {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	conn.cancelRead()
+	ctx, cancel := deadlineContext(t)
+	conn.cancelRead = cancel
+	conn.stream.SetReadContext(ctx)
+	return nil
+}
+
+// SetWriteDeadline sets the deadline for writes, a zero value disables it
+func (conn *Conn) SetWriteDeadline(t time.Time) error {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	conn.cancelWrite()
+	ctx, cancel := deadlineContext(t)
+	conn.cancelWrite = cancel
+	conn.stream.SetWriteContext(ctx)
+	return nil
+}
+
+// closeEndpoint closes endpoint and its connections, peers that don't
+// acknowledge closing in time are dropped
+func closeEndpoint(endpoint *quic.Endpoint) error {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultCloseTimeout)
+	defer cancel()
+
+	err := endpoint.Close(ctx)
+	if err == context.DeadlineExceeded {
+		return nil
+	}
+	return Error.Wrap(err)
+}
+
+// deadlineContext returns a context expiring at t, or one that never expires when t is zero
+func deadlineContext(t time.Time) (context.Context, func()) {
+	if t.IsZero() {
+		return context.Background(), func() {}
+	}
+	return context.WithDeadline(context.Background(), t)
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package quic_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/peertls/tlsopts"
+	"storj.io/storj/pkg/quic"
+	"storj.io/storj/pkg/server"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+)
+
+func newOptions(t *testing.T, index int) (storj.NodeID, *tlsopts.Options) {
+	ident, err := testidentity.PregeneratedIdentity(index, storj.LatestIDVersion())
+	require.NoError(t, err)
+
+	opts, err := tlsopts.NewOptions(ident, tlsopts.Config{PeerIDVersions: "*"})
+	require.NoError(t, err)
+	return ident.ID, opts
+}
+
+func TestQUIC(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodeID, nodeOpts := newOptions(t, 0)
+	dialerID, dialerOpts := newOptions(t, 1)
+
+	listener, err := quic.Listen(zaptest.NewLogger(t), nodeOpts.ServerTLSConfig(), "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ctx.Check(listener.Close)
+
+	// the node echoes everything
+	ctx.Go(func() error {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return nil
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	})
+
+	{ // both peers are verified during the handshake
+		conn, err := quic.Dial(ctx, dialerOpts.ClientTLSConfig(nodeID), listener.Addr().String())
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+
+		_, err = conn.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		data := make([]byte, 5)
+		_, err = io.ReadFull(conn, data)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+
+		peer, err := identity.PeerIdentityFromChain(conn.ConnectionState().PeerCertificates)
+		require.NoError(t, err)
+		assert.Equal(t, nodeID, peer.ID)
+		assert.NotEqual(t, dialerID, peer.ID)
+	}
+
+	{ // dialing with the wrong node id fails the handshake
+		wrongID, _ := newOptions(t, 2)
+
+		_, err := quic.Dial(ctx, dialerOpts.ClientTLSConfig(wrongID), listener.Addr().String())
+		assert.Error(t, err)
+	}
+}
+
+func TestGRPC(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	nodeID, nodeOpts := newOptions(t, 0)
+	dialerID, dialerOpts := newOptions(t, 1)
+
+	// the interceptor records who called the node
+	callers := make(chan storj.NodeID, 1)
+	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		peer, err := identity.PeerIdentityFromContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		callers <- peer.ID
+		return handler(ctx, req)
+	}
+
+	srv, err := server.New(nodeOpts, "127.0.0.1:0", "127.0.0.1:0", interceptor)
+	require.NoError(t, err)
+
+	listener, err := quic.Listen(zaptest.NewLogger(t), nodeOpts.ServerTLSConfig(), srv.Addr().String())
+	require.NoError(t, err)
+	srv.AddListener(listener)
+
+	grpc_health_v1.RegisterHealthServer(srv.GRPC(), health.NewServer())
+
+	ctx.Go(func() error { return srv.Run(ctx) })
+	defer ctx.Check(srv.Close)
+
+	node := &pb.Node{
+		Id: nodeID,
+		Address: &pb.NodeAddress{
+			Transport: pb.NodeTransport_QUIC_TLS_GRPC,
+			Address:   srv.Addr().String(),
+		},
+	}
+
+	conn, err := transport.NewClient(dialerOpts).DialNodeQUIC(ctx, node)
+	require.NoError(t, err)
+	defer ctx.Check(conn.Close)
+
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, dialerID, <-callers)
+}
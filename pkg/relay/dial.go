@@ -0,0 +1,60 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package relay
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// Dial connects to the node with the given id through the relay at address.
+//
+// The returned connection is forwarded as is, callers are expected to do a TLS
+// handshake verifying the node id, the same as for a direct connection.
+func Dial(ctx context.Context, address string, id storj.NodeID) (_ net.Conn, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, conn.Close())
+		}
+	}()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultHeaderTimeout + defaultPairTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if err := writeLine(conn, cmdConnect, id.String()); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	reader := bufio.NewReaderSize(conn, maxLineLength)
+	response, err := readLine(reader)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if response != respOK {
+		return nil, Error.New("relay refused connection to %s: %s", id, strings.TrimPrefix(response, respError+" "))
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
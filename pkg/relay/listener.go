@@ -0,0 +1,238 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package relay
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/peertls/tlsopts"
+)
+
+// Addr is the address of a relay a Listener accepts connections through
+type Addr string
+
+// Network implements net.Addr
+func (addr Addr) Network() string { return "relay" }
+
+// String implements net.Addr
+func (addr Addr) String() string { return string(addr) }
+
+// Listener is a net.Listener accepting connections forwarded by a relay
+type Listener struct {
+	log       *zap.Logger
+	tlsConfig *tls.Config
+	address   string
+
+	dialTimeout   time.Duration
+	retryInterval time.Duration
+
+	mu         sync.Mutex
+	control    net.Conn
+	reader     *bufio.Reader
+	onObserved func(ip string)
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// NewListener returns a listener accepting connections through the relay at address.
+// It connects to the relay when Accept is called and reconnects whenever the
+// control connection is lost.
+func NewListener(log *zap.Logger, opts *tlsopts.Options, address string) *Listener {
+	return &Listener{
+		log:       log,
+		tlsConfig: opts.UnverifiedClientTLSConfig(),
+		address:   address,
+
+		dialTimeout:   defaultHeaderTimeout,
+		retryInterval: defaultRetryInterval,
+
+		closed: make(chan struct{}),
+	}
+}
+
+// Accept waits for the relay to forward a connection
+func (listener *Listener) Accept() (net.Conn, error) {
+	for {
+		reader, err := listener.connect()
+		if err != nil {
+			return nil, err
+		}
+
+		token, err := readLine(reader)
+		if err != nil {
+			if listener.isClosed() {
+				return nil, Error.New("listener closed")
+			}
+			listener.log.Debug("lost connection to relay", zap.String("address", listener.address), zap.Error(err))
+			listener.disconnect()
+			continue
+		}
+
+		conn, err := listener.claim(token)
+		if err != nil {
+			listener.log.Debug("failed to accept relayed connection", zap.String("address", listener.address), zap.Error(err))
+			continue
+		}
+		return conn, nil
+	}
+}
+
+// Close closes the control connection, Accept returns an error afterwards
+func (listener *Listener) Close() error {
+	listener.closeOnce.Do(func() { close(listener.closed) })
+	listener.disconnect()
+	return nil
+}
+
+// Addr returns the address of the relay
+func (listener *Listener) Addr() net.Addr { return Addr(listener.address) }
+
+// OnObservedIP sets fn to be called with the ip address the relay sees the node
+// connecting from, each time the listener connects to the relay.
+func (listener *Listener) OnObservedIP(fn func(ip string)) {
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+
+	listener.onObserved = fn
+}
+
+// connect returns the reader of the control connection, connecting to the relay when necessary
+func (listener *Listener) connect() (*bufio.Reader, error) {
+	for {
+		listener.mu.Lock()
+		reader := listener.reader
+		listener.mu.Unlock()
+		if reader != nil {
+			return reader, nil
+		}
+
+		if listener.isClosed() {
+			return nil, Error.New("listener closed")
+		}
+
+		err := listener.dialControl()
+		if err == nil {
+			continue
+		}
+
+		listener.log.Debug("failed to connect to relay", zap.String("address", listener.address), zap.Error(err))
+		select {
+		case <-listener.closed:
+			return nil, Error.New("listener closed")
+		case <-time.After(listener.retryInterval):
+		}
+	}
+}
+
+// dialControl opens the control connection to the relay
+func (listener *Listener) dialControl() error {
+	conn, err := net.DialTimeout("tcp", listener.address, listener.dialTimeout)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	tlsConn := tls.Client(conn, listener.tlsConfig)
+	if err := conn.SetDeadline(time.Now().Add(listener.dialTimeout)); err != nil {
+		return Error.Wrap(errs.Combine(err, conn.Close()))
+	}
+	if err := tlsConn.Handshake(); err != nil {
+		_ = tlsConn.Close()
+		return Error.Wrap(err)
+	}
+
+	reader := bufio.NewReaderSize(tlsConn, maxLineLength)
+	ip, err := readObservedIP(reader)
+	if err != nil {
+		_ = tlsConn.Close()
+		return Error.Wrap(err)
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		_ = tlsConn.Close()
+		return Error.Wrap(err)
+	}
+
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+
+	// Close may have been called while connecting
+	if listener.isClosed() {
+		return Error.Wrap(tlsConn.Close())
+	}
+
+	listener.control = tlsConn
+	listener.reader = reader
+	listener.log.Debug("connected to relay", zap.String("address", listener.address), zap.String("observed ip", ip))
+	if listener.onObserved != nil {
+		listener.onObserved(ip)
+	}
+	return nil
+}
+
+// readObservedIP reads the address the relay sees the control connection from
+func readObservedIP(reader *bufio.Reader) (string, error) {
+	line, err := readLine(reader)
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) != 2 || fields[0] != cmdObserved {
+		return "", Error.New("unexpected line from relay: %q", line)
+	}
+
+	host, _, err := net.SplitHostPort(fields[1])
+	if err != nil {
+		return "", err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return "", Error.New("invalid observed address %q", fields[1])
+	}
+	return ip.String(), nil
+}
+
+// disconnect closes the control connection
+func (listener *Listener) disconnect() {
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+
+	if listener.control != nil {
+		_ = listener.control.Close()
+	}
+	listener.control = nil
+	listener.reader = nil
+}
+
+// claim opens the connection the relay forwards for token
+func (listener *Listener) claim(token string) (net.Conn, error) {
+	conn, err := net.DialTimeout("tcp", listener.address, listener.dialTimeout)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if err := writeLine(conn, cmdAccept, token); err != nil {
+		return nil, Error.Wrap(errs.Combine(err, conn.Close()))
+	}
+	return conn, nil
+}
+
+// isClosed returns whether Close was called
+func (listener *Listener) isClosed() bool {
+	select {
+	case <-listener.closed:
+		return true
+	default:
+		return false
+	}
+}
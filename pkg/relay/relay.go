@@ -0,0 +1,90 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package relay lets nodes without a publicly reachable address accept
+// connections through a relay they keep an outbound connection to.
+//
+// A node opens a TLS control connection to the relay, which identifies the
+// node by its peer certificate and tells it the address it connected from, so
+// the node can report its own address next to the relay address it advertises
+// for dialing. A dialer connects to the relay and asks for
+// the node by id, the relay then asks the node over the control connection
+// to open a new connection and splices both streams together. The relay only
+// forwards bytes, TLS is negotiated end-to-end between the dialer and the
+// node, so the node identity is verified the same way as for direct dials.
+package relay
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default relay errs class
+	Error = errs.Class("relay error")
+)
+
+const (
+	// cmdConnect asks the relay to forward the connection to a node
+	cmdConnect = "CONNECT"
+	// cmdAccept claims a connection the relay asked the node to accept
+	cmdAccept = "ACCEPT"
+	// cmdObserved tells the node the address the relay sees its control connection from
+	cmdObserved = "OBSERVED"
+	// respOK tells the dialer the connection is forwarded to the node
+	respOK = "OK"
+	// respError tells the dialer the connection can't be forwarded
+	respError = "ERR"
+
+	// tlsHandshakeRecord is the first byte of a TLS connection, used to tell
+	// control connections apart from plain protocol commands
+	tlsHandshakeRecord = 0x16
+
+	// maxLineLength bounds the length of a protocol line
+	maxLineLength = 256
+
+	// defaultHeaderTimeout is how long to wait for a protocol line
+	defaultHeaderTimeout = 10 * time.Second
+	// defaultPairTimeout is how long a dialer waits for the node to accept the connection
+	defaultPairTimeout = 10 * time.Second
+	// defaultRetryInterval is how long a listener waits before reconnecting to the relay
+	defaultRetryInterval = 5 * time.Second
+)
+
+// Config configures hosting a relay for nodes behind NAT
+type Config struct {
+	Address string `user:"true" help:"public address to accept relay connections on, empty disables the relay" default:""`
+}
+
+// readLine reads a single protocol line without the line ending
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadSlice('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(line), "\r\n"), nil
+}
+
+// writeLine writes a single protocol line
+func writeLine(conn net.Conn, fields ...string) error {
+	_, err := conn.Write([]byte(strings.Join(fields, " ") + "\n"))
+	return err
+}
+
+// bufferedConn is a net.Conn that first returns the data already read into reader
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// Read reads from the buffered reader
+func (conn *bufferedConn) Read(p []byte) (int, error) {
+	return conn.reader.Read(p)
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package relay_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/peertls/tlsopts"
+	"storj.io/storj/pkg/relay"
+	"storj.io/storj/storagenode"
+)
+
+func TestRelayedNode(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	planet, err := testplanet.NewCustom(zaptest.NewLogger(t), testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 0,
+		Reconfigure: testplanet.Reconfigure{
+			StorageNode: func(index int, config *storagenode.Config) {
+				config.Kademlia.RelayAddress = tcp.Addr().String()
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	// the relay needs an identity signed by the planet's ca to be trusted by the node
+	relayIdent, err := planet.NewIdentity()
+	require.NoError(t, err)
+	relayOpts, err := tlsopts.NewOptions(relayIdent, tlsopts.Config{PeerIDVersions: "*"})
+	require.NoError(t, err)
+
+	server := relay.NewServer(zaptest.NewLogger(t), relayOpts, tcp)
+	ctx.Go(func() error { return server.Run(ctx) })
+	defer ctx.Check(server.Close)
+
+	planet.Start(ctx)
+
+	{
+		satellite := planet.Satellites[0]
+		node := planet.StorageNodes[0]
+
+		// the node advertises the relay as its address
+		local := node.Local().Node
+		assert.Equal(t, pb.NodeTransport_TCP_TLS_GRPC_RELAY, local.Address.Transport)
+		assert.Equal(t, server.Addr().String(), local.Address.Address)
+
+		// the listener connects to the relay lazily, wait until the relay reported our address
+		for i := 0; node.Local().LastIp == ""; i++ {
+			require.True(t, i < 500, "observed ip not reported")
+			time.Sleep(10 * time.Millisecond)
+		}
+		local = node.Local().Node
+		assert.Equal(t, "127.0.0.1", local.LastIp)
+
+		{ // the satellite reaches the node through the relay
+			conn, err := satellite.Transport.DialNode(ctx, &local)
+			require.NoError(t, err)
+			defer ctx.Check(conn.Close)
+
+			_, err = pb.NewNodesClient(conn).Ping(ctx, &pb.PingRequest{})
+			require.NoError(t, err)
+		}
+
+		{ // the overlay keeps the relay as address and the reported ip as last ip
+			reported := local
+			reported.LastIp = "192.0.2.1"
+			require.NoError(t, satellite.Overlay.Service.Put(ctx, node.ID(), reported))
+
+			dossier, err := satellite.Overlay.Service.Get(ctx, node.ID())
+			require.NoError(t, err)
+			assert.Equal(t, server.Addr().String(), dossier.Address.Address)
+			assert.Equal(t, "192.0.2.1", dossier.LastIp)
+		}
+	}
+}
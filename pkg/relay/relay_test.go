@@ -0,0 +1,123 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package relay_test
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/pkg/peertls/tlsopts"
+	"storj.io/storj/pkg/relay"
+	"storj.io/storj/pkg/storj"
+)
+
+func newOptions(t *testing.T, index int) (storj.NodeID, *tlsopts.Options) {
+	ident, err := testidentity.PregeneratedIdentity(index, storj.LatestIDVersion())
+	require.NoError(t, err)
+
+	opts, err := tlsopts.NewOptions(ident, tlsopts.Config{PeerIDVersions: "*"})
+	require.NoError(t, err)
+	return ident.ID, opts
+}
+
+func TestRelay(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	_, relayOpts := newOptions(t, 0)
+	nodeID, nodeOpts := newOptions(t, 1)
+	_, dialerOpts := newOptions(t, 2)
+
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := relay.NewServer(zaptest.NewLogger(t), relayOpts, tcp)
+	ctx.Go(func() error { return server.Run(ctx) })
+	defer ctx.Check(server.Close)
+
+	listener := relay.NewListener(zaptest.NewLogger(t), nodeOpts, server.Addr().String())
+	defer ctx.Check(listener.Close)
+
+	observed := make(chan string, 1)
+	listener.OnObservedIP(func(ip string) {
+		select {
+		case observed <- ip:
+		default:
+		}
+	})
+
+	// the node echoes everything over tls
+	ctx.Go(func() error {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return nil
+			}
+			go func() {
+				tlsConn := tls.Server(conn, nodeOpts.ServerTLSConfig())
+				defer func() { _ = tlsConn.Close() }()
+				_, _ = io.Copy(tlsConn, tlsConn)
+			}()
+		}
+	})
+
+	{ // the node is verified end-to-end through the relay
+		var conn net.Conn
+		// the listener connects to the relay lazily, retry until the node is known
+		for i := 0; ; i++ {
+			conn, err = relay.Dial(ctx, server.Addr().String(), nodeID)
+			if err == nil || i > 100 {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		require.NoError(t, err)
+
+		tlsConn := tls.Client(conn, dialerOpts.ClientTLSConfig(nodeID))
+		defer ctx.Check(tlsConn.Close)
+
+		_, err = tlsConn.Write([]byte("hello"))
+		require.NoError(t, err)
+
+		data := make([]byte, 5)
+		_, err = io.ReadFull(tlsConn, data)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+
+		// the relay told the node the address it connected from
+		select {
+		case ip := <-observed:
+			assert.Equal(t, "127.0.0.1", ip)
+		case <-time.After(5 * time.Second):
+			t.Fatal("observed ip not reported")
+		}
+	}
+
+	{ // dialing with the wrong node id fails the handshake
+		wrongID, _ := newOptions(t, 3)
+
+		conn, err := relay.Dial(ctx, server.Addr().String(), nodeID)
+		require.NoError(t, err)
+
+		tlsConn := tls.Client(conn, dialerOpts.ClientTLSConfig(wrongID))
+		defer func() { _ = tlsConn.Close() }()
+		assert.Error(t, tlsConn.Handshake())
+	}
+
+	{ // unknown nodes are refused by the relay
+		unknownID, _ := newOptions(t, 4)
+
+		_, err := relay.Dial(ctx, server.Addr().String(), unknownID)
+		assert.Error(t, err)
+	}
+}
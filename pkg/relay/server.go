@@ -0,0 +1,385 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package relay
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/peertls/tlsopts"
+	"storj.io/storj/pkg/storj"
+)
+
+// Server forwards connections to nodes that keep a control connection to it
+type Server struct {
+	log       *zap.Logger
+	tlsConfig *tls.Config
+	listener  net.Listener
+
+	headerTimeout time.Duration
+	pairTimeout   time.Duration
+
+	mu      sync.Mutex
+	nodes   map[storj.NodeID]*session
+	pending map[string]chan net.Conn
+	conns   map[net.Conn]struct{}
+	closed  bool
+}
+
+// session is the control connection of a node
+type session struct {
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewServer creates a relay accepting connections on listener
+func NewServer(log *zap.Logger, opts *tlsopts.Options, listener net.Listener) *Server {
+	return &Server{
+		log:       log,
+		tlsConfig: opts.ServerTLSConfig(),
+		listener:  listener,
+
+		headerTimeout: defaultHeaderTimeout,
+		pairTimeout:   defaultPairTimeout,
+
+		nodes:   make(map[storj.NodeID]*session),
+		pending: make(map[string]chan net.Conn),
+		conns:   make(map[net.Conn]struct{}),
+	}
+}
+
+// Addr returns the address the relay accepts connections on
+func (server *Server) Addr() net.Addr { return server.listener.Addr() }
+
+// Run accepts connections until the context is canceled or the server is closed
+func (server *Server) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			if server.isClosed() {
+				return ctx.Err()
+			}
+			return Error.Wrap(err)
+		}
+		if !server.track(conn) {
+			_ = conn.Close()
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer server.untrack(conn)
+			server.handle(ctx, conn)
+		}()
+	}
+}
+
+// Close stops accepting connections and closes all forwarded connections
+func (server *Server) Close() error {
+	server.mu.Lock()
+	if server.closed {
+		server.mu.Unlock()
+		return nil
+	}
+	server.closed = true
+	conns := server.conns
+	server.conns = map[net.Conn]struct{}{}
+	server.mu.Unlock()
+
+	err := server.listener.Close()
+	for conn := range conns {
+		_ = conn.Close()
+	}
+	return Error.Wrap(err)
+}
+
+// handle dispatches a new connection by its first line
+func (server *Server) handle(ctx context.Context, conn net.Conn) {
+	reader := bufio.NewReaderSize(conn, maxLineLength)
+	if err := conn.SetReadDeadline(time.Now().Add(server.headerTimeout)); err != nil {
+		_ = conn.Close()
+		return
+	}
+
+	first, err := reader.Peek(1)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	if first[0] == tlsHandshakeRecord {
+		server.control(&bufferedConn{Conn: conn, reader: reader})
+		return
+	}
+
+	line, err := readLine(reader)
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		_ = conn.Close()
+		return
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		_ = conn.Close()
+		return
+	}
+
+	switch fields[0] {
+	case cmdConnect:
+		server.connect(ctx, &bufferedConn{Conn: conn, reader: reader}, fields[1])
+	case cmdAccept:
+		server.accept(&bufferedConn{Conn: conn, reader: reader}, fields[1])
+	default:
+		_ = conn.Close()
+	}
+}
+
+// control registers the control connection of a node until it disconnects
+func (server *Server) control(conn net.Conn) {
+	tlsConn := tls.Server(conn, server.tlsConfig)
+	defer func() { _ = tlsConn.Close() }()
+
+	if err := tlsConn.Handshake(); err != nil {
+		server.log.Debug("control handshake failed", zap.Error(err))
+		return
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return
+	}
+
+	peer, err := identity.PeerIdentityFromChain(tlsConn.ConnectionState().PeerCertificates)
+	if err != nil {
+		server.log.Debug("invalid control identity", zap.Error(err))
+		return
+	}
+
+	if err := writeLine(tlsConn, cmdObserved, conn.RemoteAddr().String()); err != nil {
+		server.log.Debug("failed to send observed address", zap.Error(err))
+		return
+	}
+
+	node := &session{conn: tlsConn}
+	server.register(peer.ID, node)
+	defer server.unregister(peer.ID, node)
+
+	server.log.Debug("node connected", zap.Stringer("Node ID", peer.ID))
+
+	// nodes never write on the control connection, reading only detects disconnects
+	_, _ = io.Copy(ioutil.Discard, tlsConn)
+
+	server.log.Debug("node disconnected", zap.Stringer("Node ID", peer.ID))
+}
+
+// connect forwards a dialed connection to the requested node
+func (server *Server) connect(ctx context.Context, conn net.Conn, nodeID string) {
+	id, err := storj.NodeIDFromString(nodeID)
+	if err != nil {
+		server.refuse(conn, "invalid node id")
+		return
+	}
+
+	node := server.lookup(id)
+	if node == nil {
+		server.refuse(conn, "unknown node")
+		return
+	}
+
+	token, accepted, err := server.expect()
+	if err != nil {
+		server.refuse(conn, "internal error")
+		return
+	}
+	defer server.forget(token, accepted)
+
+	if err := node.send(token); err != nil {
+		server.refuse(conn, "node unavailable")
+		return
+	}
+
+	var nodeConn net.Conn
+	select {
+	case nodeConn = <-accepted:
+	case <-time.After(server.pairTimeout):
+		server.refuse(conn, "node did not accept")
+		return
+	case <-ctx.Done():
+		_ = conn.Close()
+		return
+	}
+
+	if err := writeLine(conn, respOK); err != nil {
+		_ = closeBoth(conn, nodeConn)
+		return
+	}
+
+	splice(conn, nodeConn)
+}
+
+// accept hands a connection opened by a node to the dialer waiting for it
+func (server *Server) accept(conn net.Conn, token string) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	accepted, ok := server.pending[token]
+	if !ok {
+		_ = conn.Close()
+		return
+	}
+	delete(server.pending, token)
+
+	// accepted is buffered and only receives once, so this never blocks
+	accepted <- conn
+}
+
+// refuse tells the dialer why the connection is not forwarded and closes it
+func (server *Server) refuse(conn net.Conn, reason string) {
+	_ = writeLine(conn, respError, reason)
+	_ = conn.Close()
+}
+
+// register makes node the control connection for id, replacing any previous one
+func (server *Server) register(id storj.NodeID, node *session) {
+	server.mu.Lock()
+	previous := server.nodes[id]
+	server.nodes[id] = node
+	server.mu.Unlock()
+
+	if previous != nil {
+		_ = previous.conn.Close()
+	}
+}
+
+// unregister removes node when it is still the control connection for id
+func (server *Server) unregister(id storj.NodeID, node *session) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if server.nodes[id] == node {
+		delete(server.nodes, id)
+	}
+}
+
+// lookup returns the control connection for id
+func (server *Server) lookup(id storj.NodeID) *session {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	return server.nodes[id]
+}
+
+// expect creates a token a node can claim a forwarded connection with
+func (server *Server) expect() (string, chan net.Conn, error) {
+	var token [16]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return "", nil, err
+	}
+
+	accepted := make(chan net.Conn, 1)
+	key := hex.EncodeToString(token[:])
+
+	server.mu.Lock()
+	server.pending[key] = accepted
+	server.mu.Unlock()
+
+	return key, accepted, nil
+}
+
+// forget removes the token, closing a connection that was accepted too late
+func (server *Server) forget(token string, accepted chan net.Conn) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	delete(server.pending, token)
+	select {
+	case conn := <-accepted:
+		_ = conn.Close()
+	default:
+	}
+}
+
+// track registers a connection to close when the server closes
+func (server *Server) track(conn net.Conn) bool {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	if server.closed {
+		return false
+	}
+	server.conns[conn] = struct{}{}
+	return true
+}
+
+// untrack removes a connection that was closed
+func (server *Server) untrack(conn net.Conn) {
+	server.mu.Lock()
+	delete(server.conns, conn)
+	server.mu.Unlock()
+}
+
+// isClosed returns whether Close was called
+func (server *Server) isClosed() bool {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	return server.closed
+}
+
+// send asks the node to accept a connection for token
+func (node *session) send(token string) error {
+	node.mu.Lock()
+	defer node.mu.Unlock()
+
+	return writeLine(node.conn, token)
+}
+
+// splice copies data between a and b until either side is closed
+func splice(a, b net.Conn) {
+	done := make(chan struct{}, 2)
+	forward := func(dst, src net.Conn) {
+		_, _ = io.Copy(dst, src)
+		done <- struct{}{}
+	}
+
+	go forward(a, b)
+	go forward(b, a)
+
+	<-done
+	_ = closeBoth(a, b)
+	<-done
+}
+
+// closeBoth closes both connections
+func closeBoth(a, b net.Conn) error {
+	return errs.Combine(a.Close(), b.Close())
+}
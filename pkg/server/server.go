@@ -25,6 +25,9 @@ type Service interface {
 type public struct {
 	listener net.Listener
 	grpc     *grpc.Server
+
+	// additional listeners also served by grpc, e.g. relays
+	additional []net.Listener
 }
 
 type private struct {
@@ -94,6 +97,12 @@ func (p *Server) GRPC() *grpc.Server { return p.public.grpc }
 // PrivateGRPC returns the server's gRPC handle for registration purposes
 func (p *Server) PrivateGRPC() *grpc.Server { return p.private.grpc }
 
+// AddListener serves the public gRPC services on an additional listener,
+// it must be called before Run.
+func (p *Server) AddListener(listener net.Listener) {
+	p.public.additional = append(p.public.additional, listener)
+}
+
 // Close shuts down the server
 func (p *Server) Close() error {
 	p.public.grpc.GracefulStop()
@@ -127,6 +136,13 @@ func (p *Server) Run(ctx context.Context) (err error) {
 		defer cancel()
 		return p.private.grpc.Serve(p.private.listener)
 	})
+	for _, listener := range p.public.additional {
+		listener := listener
+		group.Go(func() error {
+			defer cancel()
+			return p.public.grpc.Serve(listener)
+		})
+	}
 
 	return group.Wait()
 }
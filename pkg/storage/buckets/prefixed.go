@@ -59,6 +59,16 @@ func (o *prefixedObjStore) Delete(ctx context.Context, path storj.Path) (err err
 	return o.store.Delete(ctx, storj.JoinPaths(o.prefix, path))
 }
 
+func (o *prefixedObjStore) UpdateMeta(ctx context.Context, path storj.Path, metadata pb.SerializableMeta) (meta objects.Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(path) == 0 {
+		return objects.Meta{}, storj.ErrNoPath.New("")
+	}
+
+	return o.store.UpdateMeta(ctx, storj.JoinPaths(o.prefix, path), metadata)
+}
+
 func (o *prefixedObjStore) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []objects.ListItem, more bool, err error) {
 	defer mon.Task()(&ctx)(&err)
 
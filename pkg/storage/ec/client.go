@@ -8,11 +8,13 @@ import (
 	"io"
 	"io/ioutil"
 	"sort"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/internal/sync2"
@@ -21,6 +23,7 @@ import (
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/ranger"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transfer"
 	"storj.io/storj/pkg/transport"
 	"storj.io/storj/uplink/piecestore"
 )
@@ -29,7 +32,7 @@ var mon = monkit.Package()
 
 // Client defines an interface for storing erasure coded data to piece store nodes
 type Client interface {
-	Put(ctx context.Context, limits []*pb.AddressedOrderLimit, rs eestream.RedundancyStrategy, data io.Reader, expiration time.Time) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error)
+	Put(ctx context.Context, limits []*pb.AddressedOrderLimit, rs eestream.RedundancyStrategy, data io.Reader, expiration time.Time) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, stats []*pb.PieceUploadStats, err error)
 	Repair(ctx context.Context, limits []*pb.AddressedOrderLimit, rs eestream.RedundancyStrategy, data io.Reader, expiration time.Time, timeout time.Duration, path storj.Path) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error)
 	Get(ctx context.Context, limits []*pb.AddressedOrderLimit, es eestream.ErasureScheme, size int64) (ranger.Ranger, error)
 	Delete(ctx context.Context, limits []*pb.AddressedOrderLimit) error
@@ -37,21 +40,30 @@ type Client interface {
 
 type psClientHelper func(context.Context, *pb.Node) (*piecestore.Client, error)
 
+// quicRetryInterval is how long nodes are dialed over tcp after dialing them
+// over QUIC failed
+const quicRetryInterval = 10 * time.Minute
+
 type ecClient struct {
 	transport   transport.Client
 	memoryLimit int
+
+	// quicFailures holds when dialing a node over QUIC failed last
+	quicMu       sync.Mutex
+	quicFailures map[storj.NodeID]time.Time
 }
 
 // NewClient from the given identity and max buffer memory
 func NewClient(tc transport.Client, memoryLimit int) Client {
 	return &ecClient{
-		transport:   tc,
-		memoryLimit: memoryLimit,
+		transport:    tc,
+		memoryLimit:  memoryLimit,
+		quicFailures: make(map[storj.NodeID]time.Time),
 	}
 }
 
 func (ec *ecClient) newPSClient(ctx context.Context, n *pb.Node) (*piecestore.Client, error) {
-	conn, err := ec.transport.DialNode(ctx, n)
+	conn, err := ec.dialNode(ctx, n)
 	if err != nil {
 		return nil, err
 	}
@@ -63,31 +75,84 @@ func (ec *ecClient) newPSClient(ctx context.Context, n *pb.Node) (*piecestore.Cl
 	), nil
 }
 
-func (ec *ecClient) Put(ctx context.Context, limits []*pb.AddressedOrderLimit, rs eestream.RedundancyStrategy, data io.Reader, expiration time.Time) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error) {
+// dialNode dials over QUIC when the node supports it, falling back to tcp.
+// Nodes that failed over QUIC are dialed over tcp until quicRetryInterval passed.
+func (ec *ecClient) dialNode(ctx context.Context, n *pb.Node) (*grpc.ClientConn, error) {
+	if n.GetAddress().GetTransport() == pb.NodeTransport_QUIC_TLS_GRPC && !ec.quicFailed(n.Id) {
+		conn, err := ec.transport.DialNodeQUIC(ctx, n)
+		if err == nil {
+			return conn, nil
+		}
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		ec.recordQUICFailure(n.Id)
+		zap.S().Debugf("Dialing storage node %s over QUIC failed, falling back to tcp: %v", n.Id, err)
+	}
+	return ec.transport.DialNode(ctx, n)
+}
+
+// quicFailed returns whether dialing the node over QUIC failed recently
+func (ec *ecClient) quicFailed(id storj.NodeID) bool {
+	ec.quicMu.Lock()
+	defer ec.quicMu.Unlock()
+
+	failed, ok := ec.quicFailures[id]
+	if !ok {
+		return false
+	}
+	if time.Since(failed) >= quicRetryInterval {
+		delete(ec.quicFailures, id)
+		return false
+	}
+	return true
+}
+
+// recordQUICFailure remembers that dialing the node over QUIC failed,
+// dropping failures that expired
+func (ec *ecClient) recordQUICFailure(id storj.NodeID) {
+	ec.quicMu.Lock()
+	defer ec.quicMu.Unlock()
+
+	now := time.Now()
+	for failedID, failed := range ec.quicFailures {
+		if now.Sub(failed) >= quicRetryInterval {
+			delete(ec.quicFailures, failedID)
+		}
+	}
+	ec.quicFailures[id] = now
+}
+
+// Put uploads the erasure shares of data to the nodes of limits. Besides the
+// successful nodes and their hashes it returns how the upload to each node went,
+// which the satellite uses to prefer fast nodes.
+func (ec *ecClient) Put(ctx context.Context, limits []*pb.AddressedOrderLimit, rs eestream.RedundancyStrategy, data io.Reader, expiration time.Time) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, stats []*pb.PieceUploadStats, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if len(limits) != rs.TotalCount() {
-		return nil, nil, Error.New("size of limits slice (%d) does not match total count (%d) of erasure scheme", len(limits), rs.TotalCount())
+		return nil, nil, nil, Error.New("size of limits slice (%d) does not match total count (%d) of erasure scheme", len(limits), rs.TotalCount())
 	}
 
 	if nonNilCount(limits) < rs.RepairThreshold() {
-		return nil, nil, Error.New("number of non-nil limits (%d) is less than repair threshold (%d) of erasure scheme", nonNilCount(limits), rs.RepairThreshold())
+		return nil, nil, nil, Error.New("number of non-nil limits (%d) is less than repair threshold (%d) of erasure scheme", nonNilCount(limits), rs.RepairThreshold())
 	}
 
 	if !unique(limits) {
-		return nil, nil, Error.New("duplicated nodes are not allowed")
+		return nil, nil, nil, Error.New("duplicated nodes are not allowed")
 	}
 
 	padded := eestream.PadReader(ioutil.NopCloser(data), rs.StripeSize())
 	readers, err := eestream.EncodeReader(ctx, padded, rs)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	type info struct {
-		i    int
-		err  error
-		hash *pb.PieceHash
+		i      int
+		err    error
+		hash   *pb.PieceHash
+		stats  *pb.PieceUploadStats
+		result transfer.PieceResult
 	}
 	infos := make(chan info, len(limits))
 
@@ -98,8 +163,16 @@ func (ec *ecClient) Put(ctx context.Context, limits []*pb.AddressedOrderLimit, r
 
 	for i, addressedLimit := range limits {
 		go func(i int, addressedLimit *pb.AddressedOrderLimit) {
-			hash, err := ec.putPiece(psCtx, ctx, addressedLimit, readers[i], expiration)
-			infos <- info{i: i, err: err, hash: hash}
+			pieceStart := time.Now()
+			hash, size, err := ec.putPiece(psCtx, ctx, addressedLimit, readers[i], expiration)
+			duration := time.Since(pieceStart)
+			infos <- info{
+				i:      i,
+				err:    err,
+				hash:   hash,
+				stats:  uploadStats(ctx, psCtx, addressedLimit, size, duration, err),
+				result: pieceResult(psCtx, addressedLimit, size, duration, err),
+			}
 		}(i, addressedLimit)
 	}
 
@@ -115,6 +188,11 @@ func (ec *ecClient) Put(ctx context.Context, limits []*pb.AddressedOrderLimit, r
 			continue
 		}
 
+		if info.stats != nil {
+			stats = append(stats, info.stats)
+		}
+		transfer.PieceFinished(ctx, info.result)
+
 		if info.err != nil {
 			zap.S().Debugf("Upload to storage node %s failed: %v", limits[info.i].GetLimit().StorageNodeId, info.err)
 			continue
@@ -166,10 +244,10 @@ func (ec *ecClient) Put(ctx context.Context, limits []*pb.AddressedOrderLimit, r
 	}()
 
 	if int(atomic.LoadInt32(&successfulCount)) < rs.RepairThreshold() {
-		return nil, nil, Error.New("successful puts (%d) less than repair threshold (%d)", atomic.LoadInt32(&successfulCount), rs.RepairThreshold())
+		return nil, nil, stats, Error.New("successful puts (%d) less than repair threshold (%d)", atomic.LoadInt32(&successfulCount), rs.RepairThreshold())
 	}
 
-	return successfulNodes, successfulHashes, nil
+	return successfulNodes, successfulHashes, stats, nil
 }
 
 func (ec *ecClient) Repair(ctx context.Context, limits []*pb.AddressedOrderLimit, rs eestream.RedundancyStrategy, data io.Reader, expiration time.Time, timeout time.Duration, path storj.Path) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error) {
@@ -201,7 +279,7 @@ func (ec *ecClient) Repair(ctx context.Context, limits []*pb.AddressedOrderLimit
 
 	for i, addressedLimit := range limits {
 		go func(i int, addressedLimit *pb.AddressedOrderLimit) {
-			hash, err := ec.putPiece(psCtx, ctx, addressedLimit, readers[i], expiration)
+			hash, _, err := ec.putPiece(psCtx, ctx, addressedLimit, readers[i], expiration)
 			infos <- info{i: i, err: err, hash: hash}
 		}(i, addressedLimit)
 	}
@@ -270,12 +348,62 @@ func (ec *ecClient) Repair(ctx context.Context, limits []*pb.AddressedOrderLimit
 	return successfulNodes, successfulHashes, nil
 }
 
-func (ec *ecClient) putPiece(ctx, parent context.Context, limit *pb.AddressedOrderLimit, data io.ReadCloser, expiration time.Time) (hash *pb.PieceHash, err error) {
+// uploadStats describes the upload of a piece to the node of limit. Uploads
+// canceled by the user are not the node's fault and are not reported.
+func uploadStats(parent, ctx context.Context, limit *pb.AddressedOrderLimit, size int64, duration time.Duration, err error) *pb.PieceUploadStats {
+	if limit == nil || parent.Err() != nil {
+		return nil
+	}
+
+	status := pb.PieceUploadStats_SUCCEEDED
+	switch {
+	case err == nil:
+	case ctx.Err() == context.Canceled:
+		status = pb.PieceUploadStats_LONG_TAIL_CANCELED
+	default:
+		status = pb.PieceUploadStats_FAILED
+	}
+
+	return &pb.PieceUploadStats{
+		NodeId:     limit.GetLimit().StorageNodeId,
+		Status:     status,
+		PieceSize:  size,
+		DurationMs: int64(duration / time.Millisecond),
+	}
+}
+
+// pieceResult describes the transfer of a piece to the node of limit for
+// observers. Transfers interrupted by ctx are canceled.
+func pieceResult(ctx context.Context, limit *pb.AddressedOrderLimit, size int64, duration time.Duration, err error) transfer.PieceResult {
+	status := transfer.Succeeded
+	switch {
+	case err == nil:
+	case ctx.Err() == context.Canceled:
+		status = transfer.Canceled
+	default:
+		status = transfer.Failed
+	}
+
+	var nodeID storj.NodeID
+	if limit.GetLimit() != nil {
+		nodeID = limit.GetLimit().StorageNodeId
+	}
+
+	return transfer.PieceResult{
+		NodeID:   nodeID,
+		Status:   status,
+		Bytes:    size,
+		Duration: duration,
+		Err:      err,
+	}
+}
+
+func (ec *ecClient) putPiece(ctx, parent context.Context, limit *pb.AddressedOrderLimit, data io.ReadCloser, expiration time.Time) (hash *pb.PieceHash, size int64, err error) {
 	defer func() { err = errs.Combine(err, data.Close()) }()
 
 	if limit == nil {
 		_, _ = io.Copy(ioutil.Discard, data)
-		return nil, nil
+		return nil, 0, nil
 	}
 
 	storageNodeID := limit.GetLimit().StorageNodeId
@@ -286,14 +414,14 @@ func (ec *ecClient) putPiece(ctx, parent context.Context, limit *pb.AddressedOrd
 	})
 	if err != nil {
 		zap.S().Debugf("Failed dialing for putting piece %s to node %s: %v", pieceID, storageNodeID, err)
-		return nil, err
+		return nil, 0, err
 	}
 	defer func() { err = errs.Combine(err, ps.Close()) }()
 
 	upload, err := ps.Upload(ctx, limit.GetLimit())
 	if err != nil {
 		zap.S().Debugf("Failed requesting upload of piece %s to node %s: %v", pieceID, storageNodeID, err)
-		return nil, err
+		return nil, 0, err
 	}
 	defer func() {
 		if ctx.Err() != nil || err != nil {
@@ -306,7 +434,7 @@ func (ec *ecClient) putPiece(ctx, parent context.Context, limit *pb.AddressedOrd
 		err = errs.Combine(err, closeErr)
 	}()
 
-	_, err = sync2.Copy(ctx, upload, data)
+	size, err = sync2.Copy(ctx, upload, data)
 	// Canceled context means the piece upload was interrupted by user or due
 	// to slow connection. No error logging for this case.
 	if ctx.Err() == context.Canceled {
@@ -324,7 +452,7 @@ func (ec *ecClient) putPiece(ctx, parent context.Context, limit *pb.AddressedOrd
 		zap.S().Debugf("Failed uploading piece %s to node %s (%+v): %v", pieceID, storageNodeID, nodeAddress, err)
 	}
 
-	return hash, err
+	return hash, size, err
 }
 
 func (ec *ecClient) Get(ctx context.Context, limits []*pb.AddressedOrderLimit, es eestream.ErasureScheme, size int64) (rr ranger.Ranger, err error) {
@@ -455,27 +583,71 @@ func (lr *lazyPieceRanger) Size() int64 {
 
 // Range implements Ranger.Range to be lazily connected
 func (lr *lazyPieceRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	start := time.Now()
 	ps, err := lr.newPSClientHelper(ctx, &pb.Node{
 		Id:      lr.limit.GetLimit().StorageNodeId,
 		Address: lr.limit.GetStorageNodeAddress(),
 	})
 	if err != nil {
+		transfer.PieceFinished(ctx, pieceResult(ctx, lr.limit, 0, time.Since(start), err))
 		return nil, err
 	}
 
 	download, err := ps.Download(ctx, lr.limit.GetLimit(), offset, length)
 	if err != nil {
+		transfer.PieceFinished(ctx, pieceResult(ctx, lr.limit, 0, time.Since(start), err))
 		return nil, errs.Combine(err, ps.Close())
 	}
-	return &clientCloser{download, ps}, nil
+	return &clientCloser{
+		Downloader: download,
+		client:     ps,
+		ctx:        ctx,
+		limit:      lr.limit,
+		length:     length,
+		start:      start,
+	}, nil
 }
 
+// clientCloser closes the client of a piece download, and reports how the
+// download went to the observer of ctx
 type clientCloser struct {
 	piecestore.Downloader
 	client *piecestore.Client
+
+	ctx    context.Context
+	limit  *pb.AddressedOrderLimit
+	length int64
+	start  time.Time
+
+	// the downloader may be closed while it is read
+	mu   sync.Mutex
+	read int64
+	err  error
+}
+
+func (client *clientCloser) Read(p []byte) (n int, err error) {
+	n, err = client.Downloader.Read(p)
+
+	client.mu.Lock()
+	client.read += int64(n)
+	if err != nil && err != io.EOF && client.err == nil {
+		client.err = err
+	}
+	client.mu.Unlock()
+
+	return n, err
 }
 
 func (client *clientCloser) Close() error {
+	client.mu.Lock()
+	result := pieceResult(client.ctx, client.limit, client.read, time.Since(client.start), client.err)
+	client.mu.Unlock()
+	if result.Status == transfer.Succeeded && client.read < client.length {
+		// the download was not needed anymore before it was finished
+		result.Status = transfer.Canceled
+	}
+	transfer.PieceFinished(client.ctx, result)
+
 	return errs.Combine(
 		client.Downloader.Close(),
 		client.client.Close(),
@@ -83,10 +83,20 @@ func testPut(ctx context.Context, t *testing.T, planet *testplanet.Planet, ec ec
 
 	r := bytes.NewReader(data)
 
-	successfulNodes, successfulHashes, err := ec.Put(ctx, limits, rs, r, ttl)
+	successfulNodes, successfulHashes, stats, err := ec.Put(ctx, limits, rs, r, ttl)
 
 	require.NoError(t, err)
 	assert.Equal(t, len(limits), len(successfulNodes))
+	assert.Equal(t, len(limits), len(stats))
+
+	succeeded := 0
+	for _, stat := range stats {
+		if stat.Status == pb.PieceUploadStats_SUCCEEDED {
+			succeeded++
+			assert.True(t, stat.PieceSize > 0)
+		}
+	}
+	assert.True(t, succeeded >= rs.RepairThreshold())
 
 	slowNodes := 0
 	for i := range limits {
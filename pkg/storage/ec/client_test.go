@@ -4,13 +4,18 @@
 package ecclient
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
 
 	"storj.io/storj/internal/teststorj"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/transport"
 )
 
 func TestUnique(t *testing.T) {
@@ -44,3 +49,50 @@ func TestUnique(t *testing.T) {
 		assert.Equal(t, tt.unique, unique(tt.limits), errTag)
 	}
 }
+
+// failingTransport fails every dial and counts how often it dialed over QUIC
+type failingTransport struct {
+	transport.Client
+	quicDials int
+	tcpDials  int
+}
+
+func (client *failingTransport) DialNode(ctx context.Context, node *pb.Node, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	client.tcpDials++
+	return nil, errors.New("dial failed")
+}
+
+func (client *failingTransport) DialNodeQUIC(ctx context.Context, node *pb.Node, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	client.quicDials++
+	return nil, errors.New("dial failed")
+}
+
+func TestQUICFailures(t *testing.T) {
+	tc := &failingTransport{}
+	ec := NewClient(tc, 0).(*ecClient)
+
+	node := &pb.Node{
+		Id:      teststorj.NodeIDFromString("node"),
+		Address: &pb.NodeAddress{Transport: pb.NodeTransport_QUIC_TLS_GRPC},
+	}
+	other := &pb.Node{
+		Id:      teststorj.NodeIDFromString("other"),
+		Address: &pb.NodeAddress{Transport: pb.NodeTransport_QUIC_TLS_GRPC},
+	}
+
+	// after a QUIC failure the node is only dialed over tcp
+	for i := 0; i < 3; i++ {
+		_, _ = ec.dialNode(context.Background(), node)
+	}
+	assert.Equal(t, 1, tc.quicDials)
+	assert.Equal(t, 3, tc.tcpDials)
+
+	// other nodes are still dialed over QUIC
+	_, _ = ec.dialNode(context.Background(), other)
+	assert.Equal(t, 2, tc.quicDials)
+
+	// QUIC is tried again once the failure expired
+	ec.quicFailures[node.Id] = time.Now().Add(-quicRetryInterval)
+	_, _ = ec.dialNode(context.Background(), node)
+	assert.Equal(t, 3, tc.quicDials)
+}
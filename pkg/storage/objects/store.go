@@ -27,7 +27,8 @@ type Meta struct {
 	Modified   time.Time
 	Expiration time.Time
 	Size       int64
-	Checksum   string
+	Checksum   []byte
+	MD5        []byte
 }
 
 // ListItem is a single item in a listing
@@ -43,6 +44,7 @@ type Store interface {
 	Get(ctx context.Context, path storj.Path) (rr ranger.Ranger, meta Meta, err error)
 	Put(ctx context.Context, path storj.Path, data io.Reader, metadata pb.SerializableMeta, expiration time.Time) (meta Meta, err error)
 	Delete(ctx context.Context, path storj.Path) (err error)
+	UpdateMeta(ctx context.Context, path storj.Path, metadata pb.SerializableMeta) (meta Meta, err error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
 }
 
@@ -103,7 +105,7 @@ func (o *objStore) Put(ctx context.Context, path storj.Path, data io.Reader, met
 	if err != nil {
 		return Meta{}, err
 	}
-	m, err := o.store.Put(ctx, path, o.pathCipher, data, b, expiration)
+	m, err := o.store.Put(ctx, path, o.pathCipher, data, streams.MetadataBytes(b), expiration)
 	return convertMeta(m), err
 }
 
@@ -123,6 +125,27 @@ func (o *objStore) Delete(ctx context.Context, path storj.Path) (err error) {
 	return err
 }
 
+func (o *objStore) UpdateMeta(ctx context.Context, path storj.Path, metadata pb.SerializableMeta) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if len(path) == 0 {
+		return Meta{}, storj.ErrNoPath.New("")
+	}
+
+	b, err := proto.Marshal(&metadata)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	m, err := o.store.UpdateMetadata(ctx, path, o.pathCipher, b)
+
+	if storage.ErrKeyNotFound.Has(err) {
+		err = storj.ErrObjectNotFound.Wrap(err)
+	}
+
+	return convertMeta(m), err
+}
+
 func (o *objStore) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (
 	items []ListItem, more bool, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -155,6 +178,8 @@ func convertMeta(m streams.Meta) Meta {
 		Modified:         m.Modified,
 		Expiration:       m.Expiration,
 		Size:             m.Size,
+		Checksum:         m.SHA256,
+		MD5:              m.MD5,
 		SerializableMeta: ser,
 	}
 }
@@ -91,6 +91,19 @@ func (mr *MockStoreMockRecorder) Put(ctx, data, expiration, segmentInfo interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), ctx, data, expiration, segmentInfo)
 }
 
+// UpdateMeta mocks base method
+func (m *MockStore) UpdateMeta(ctx context.Context, path storj.Path, modified time.Time, data []byte) (Meta, error) {
+	ret := m.ctrl.Call(m, "UpdateMeta", ctx, path, modified, data)
+	ret0, _ := ret[0].(Meta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMeta indicates an expected call of UpdateMeta
+func (mr *MockStoreMockRecorder) UpdateMeta(ctx, path, modified, data interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMeta", reflect.TypeOf((*MockStore)(nil).UpdateMeta), ctx, path, modified, data)
+}
+
 // Delete mocks base method
 func (m *MockStore) Delete(ctx context.Context, path storj.Path) error {
 	ret := m.ctrl.Call(m, "Delete", ctx, path)
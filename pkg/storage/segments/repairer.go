@@ -5,39 +5,60 @@ package segments
 
 import (
 	"context"
+	"math/rand"
 	"time"
 
+	"github.com/skyrings/skyring-common/tools/uuid"
 	"github.com/zeebo/errs"
+	"go.uber.org/zap"
 
+	"storj.io/storj/pkg/auth/signing"
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	ecclient "storj.io/storj/pkg/storage/ec"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
 	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/orders"
 )
 
+// Auditor audits a segment
+type Auditor interface {
+	AuditSegment(ctx context.Context, path storj.Path, pointer *pb.Pointer) error
+}
+
 // Repairer for segments
 type Repairer struct {
-	metainfo *metainfo.Service
-	orders   *orders.Service
-	cache    *overlay.Cache
-	ec       ecclient.Client
-	identity *identity.FullIdentity
-	timeout  time.Duration
+	metainfo   *metainfo.Service
+	orders     *orders.Service
+	cache      *overlay.Cache
+	ec         ecclient.Client
+	transport  transport.Client
+	identity   *identity.FullIdentity
+	timeout    time.Duration
+	nodeRepair bool
+	auditor    Auditor
 }
 
-// NewSegmentRepairer creates a new instance of SegmentRepairer
-func NewSegmentRepairer(metainfo *metainfo.Service, orders *orders.Service, cache *overlay.Cache, ec ecclient.Client, identity *identity.FullIdentity, timeout time.Duration) *Repairer {
+// NewSegmentRepairer creates a new instance of SegmentRepairer.
+//
+// When nodeRepair is set, segments are reconstructed by one of the healthy storage nodes
+// instead of being downloaded to the satellite. The satellite never sees the data the
+// storage node uploads then, so the repaired segments are audited with auditor.
+func NewSegmentRepairer(metainfo *metainfo.Service, orders *orders.Service, cache *overlay.Cache, ec ecclient.Client, transport transport.Client, identity *identity.FullIdentity, timeout time.Duration, nodeRepair bool, auditor Auditor) *Repairer {
 	return &Repairer{
-		metainfo: metainfo,
-		orders:   orders,
-		cache:    cache,
-		ec:       ec,
-		identity: identity,
-		timeout:  timeout,
+		metainfo:   metainfo,
+		orders:     orders,
+		cache:      cache,
+		ec:         ec,
+		transport:  transport,
+		identity:   identity,
+		timeout:    timeout,
+		nodeRepair: nodeRepair,
+		auditor:    auditor,
 	}
 }
 
@@ -46,7 +67,7 @@ func (repairer *Repairer) Repair(ctx context.Context, path storj.Path) (err erro
 	defer mon.Task()(&ctx)(&err)
 
 	// Read the segment pointer from the metainfo
-	pointer, err := repairer.metainfo.Get(path)
+	pointerBytes, pointer, err := repairer.metainfo.GetWithBytes(path)
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -64,7 +85,6 @@ func (repairer *Repairer) Repair(ctx context.Context, path storj.Path) (err erro
 	}
 
 	pieceSize := eestream.CalcPieceSize(pointer.GetSegmentSize(), redundancy)
-	expiration := pointer.GetExpirationDate()
 
 	var excludeNodeIDs storj.NodeIDList
 	var healthyPieces []*pb.RemotePiece
@@ -108,8 +128,29 @@ func (repairer *Repairer) Repair(ctx context.Context, path storj.Path) (err erro
 		return Error.Wrap(err)
 	}
 
+	placement, err := repairer.bucketPlacement(ctx, path)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	repairerIdentity := repairer.identity.PeerIdentity()
+
+	var repairNode *pb.Node
+	if repairer.nodeRepair {
+		repairNode, err = repairer.selectRepairNode(ctx, healthyPieces)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		// the storage node downloads and uploads the pieces, so the limits are issued to it
+		// and the orders it signs are verified with its public key
+		repairerIdentity, err = repairer.fetchIdentity(ctx, repairNode)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
 	// Create the order limits for the GET_REPAIR action
-	getOrderLimits, err := repairer.orders.CreateGetRepairOrderLimits(ctx, repairer.identity.PeerIdentity(), bucketID, pointer, healthyPieces)
+	getOrderLimits, err := repairer.orders.CreateGetRepairOrderLimits(ctx, repairerIdentity, bucketID, pointer, healthyPieces)
 	if err != nil {
 		return Error.Wrap(err)
 	}
@@ -120,6 +161,10 @@ func (repairer *Repairer) Repair(ctx context.Context, path storj.Path) (err erro
 		FreeBandwidth:  pieceSize,
 		FreeDisk:       pieceSize,
 		ExcludedNodes:  excludeNodeIDs,
+		Placement:      placement,
+	}
+	for _, piece := range healthyPieces {
+		request.ExistingNodes = append(request.ExistingNodes, piece.NodeId)
 	}
 	newNodes, err := repairer.cache.FindStorageNodes(ctx, request)
 	if err != nil {
@@ -127,27 +172,25 @@ func (repairer *Repairer) Repair(ctx context.Context, path storj.Path) (err erro
 	}
 
 	// Create the order limits for the PUT_REPAIR action
-	putLimits, err := repairer.orders.CreatePutRepairOrderLimits(ctx, repairer.identity.PeerIdentity(), bucketID, pointer, getOrderLimits, newNodes)
+	putLimits, err := repairer.orders.CreatePutRepairOrderLimits(ctx, repairerIdentity, bucketID, pointer, getOrderLimits, newNodes)
 	if err != nil {
 		return Error.Wrap(err)
 	}
 
-	// Download the segment using just the healthy pieces
-	rr, err := repairer.ec.Get(ctx, getOrderLimits, redundancy, pointer.GetSegmentSize())
-	if err != nil {
-		return Error.Wrap(err)
-	}
-
-	r, err := rr.Range(ctx, 0, rr.Size())
-	if err != nil {
-		return Error.Wrap(err)
-	}
-	defer func() { err = errs.Combine(err, r.Close()) }()
-
-	// Upload the repaired pieces
-	successfulNodes, hashes, err := repairer.ec.Repair(ctx, putLimits, redundancy, r, convertTime(expiration), repairer.timeout, path)
-	if err != nil {
-		return Error.Wrap(err)
+	var successfulNodes []*pb.Node
+	var hashes []*pb.PieceHash
+	if repairNode != nil {
+		// Have the storage node reconstruct the segment and upload the repaired pieces
+		successfulNodes, hashes, err = repairer.repairOnNode(ctx, repairNode, path, pointer, getOrderLimits, putLimits)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+	} else {
+		// Download the segment and upload the repaired pieces from the satellite
+		successfulNodes, hashes, err = repairer.repairOnSatellite(ctx, path, pointer, redundancy, getOrderLimits, putLimits)
+		if err != nil {
+			return Error.Wrap(err)
+		}
 	}
 
 	// Add the successfully uploaded pieces to the healthyPieces
@@ -181,8 +224,151 @@ func (repairer *Repairer) Repair(ctx context.Context, path storj.Path) (err erro
 	}
 	mon.FloatVal("healthy_ratio_after_repair").Observe(healthyRatioAfterRepair)
 
-	// Update the segment pointer in the metainfo
-	return repairer.metainfo.Put(path, pointer)
+	// Update the segment pointer in the metainfo, unless the segment was
+	// uploaded again or deleted during the repair
+	err = repairer.metainfo.CompareAndSwap(path, pointerBytes, pointer)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	// only the piece hashes signed by the new nodes were checked, not the data
+	// the repairing node uploaded to them
+	if repairNode != nil && repairer.auditor != nil {
+		err = repairer.auditor.AuditSegment(ctx, path, pointer)
+		if err != nil {
+			zap.L().Warn("audit after repair failed", zap.String("path", path), zap.Stringer("repairer", repairNode.Id), zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// fetchIdentity connects to node and returns its peer identity.
+func (repairer *Repairer) fetchIdentity(ctx context.Context, node *pb.Node) (_ *identity.PeerIdentity, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	dialer := kademlia.NewDialer(zap.L(), repairer.transport)
+	defer func() { err = errs.Combine(err, dialer.Close()) }()
+
+	return dialer.FetchPeerIdentity(ctx, *node)
+}
+
+// repairOnSatellite downloads the segment using the healthy pieces in getLimits and uploads the repaired pieces to the new nodes in putLimits.
+func (repairer *Repairer) repairOnSatellite(ctx context.Context, path storj.Path, pointer *pb.Pointer, redundancy eestream.RedundancyStrategy, getLimits, putLimits []*pb.AddressedOrderLimit) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rr, err := repairer.ec.Get(ctx, getLimits, redundancy, pointer.GetSegmentSize())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := rr.Range(ctx, 0, rr.Size())
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { err = errs.Combine(err, r.Close()) }()
+
+	return repairer.ec.Repair(ctx, putLimits, redundancy, r, convertTime(pointer.GetExpirationDate()), repairer.timeout, path)
+}
+
+// selectRepairNode picks a random online storage node holding one of the healthy pieces to run the repair.
+func (repairer *Repairer) selectRepairNode(ctx context.Context, healthyPieces []*pb.RemotePiece) (_ *pb.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for _, i := range rand.Perm(len(healthyPieces)) {
+		node, err := repairer.cache.Get(ctx, healthyPieces[i].NodeId)
+		if err != nil {
+			continue
+		}
+		if repairer.cache.IsOnline(node) && !node.Disqualified {
+			return &node.Node, nil
+		}
+	}
+
+	return nil, Error.New("no online storage node to run the repair")
+}
+
+// repairOnNode asks node to reconstruct the segment and upload the missing pieces to the new nodes in putLimits.
+// Only the pieces whose hashes are signed by the new storage nodes are returned as successful.
+func (repairer *Repairer) repairOnNode(ctx context.Context, node *pb.Node, path storj.Path, pointer *pb.Pointer, getLimits, putLimits []*pb.AddressedOrderLimit) (successfulNodes []*pb.Node, successfulHashes []*pb.PieceHash, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := repairer.transport.DialNode(ctx, node)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { err = errs.Combine(err, conn.Close()) }()
+
+	// the storage node both downloads and uploads the pieces within this call
+	nodeCtx, cancel := context.WithTimeout(ctx, 2*repairer.timeout)
+	defer cancel()
+
+	response, err := pb.NewPiecestoreClient(conn).Repair(nodeCtx, &pb.PieceRepairRequest{
+		GetLimits:   toRepairLimits(getLimits),
+		PutLimits:   toRepairLimits(putLimits),
+		Redundancy:  pointer.GetRemote().GetRedundancy(),
+		SegmentSize: pointer.GetSegmentSize(),
+		Expiration:  pointer.GetExpirationDate(),
+		Path:        path,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(response.Hashes) != len(putLimits) {
+		return nil, nil, Error.New("expected %d hashes from %s, got %d", len(putLimits), node.Id, len(response.Hashes))
+	}
+
+	dialer := kademlia.NewDialer(zap.L(), repairer.transport)
+	defer func() { err = errs.Combine(err, dialer.Close()) }()
+
+	successfulNodes = make([]*pb.Node, len(putLimits))
+	successfulHashes = make([]*pb.PieceHash, len(putLimits))
+	for i, limit := range putLimits {
+		hash := response.Hashes[i]
+		if limit == nil || len(hash.GetHash()) == 0 {
+			continue
+		}
+
+		newNode := &pb.Node{
+			Id:      limit.GetLimit().StorageNodeId,
+			Address: limit.GetStorageNodeAddress(),
+		}
+		if err := verifyPieceHash(ctx, dialer, newNode, limit.GetLimit(), hash); err != nil {
+			zap.L().Warn("invalid piece hash from repair", zap.String("path", path), zap.Stringer("repairer", node.Id), zap.Stringer("node", newNode.Id), zap.Error(err))
+			continue
+		}
+
+		successfulNodes[i] = newNode
+		successfulHashes[i] = hash
+	}
+
+	return successfulNodes, successfulHashes, nil
+}
+
+// verifyPieceHash verifies that hash is for the piece in limit and is signed by node.
+func verifyPieceHash(ctx context.Context, dialer *kademlia.Dialer, node *pb.Node, limit *pb.OrderLimit2, hash *pb.PieceHash) error {
+	if hash.PieceId != limit.PieceId {
+		return Error.New("piece id changed")
+	}
+
+	peer, err := dialer.FetchPeerIdentity(ctx, *node)
+	if err != nil {
+		return err
+	}
+
+	return signing.VerifyPieceHashSignature(signing.SigneeFromPeerIdentity(peer), hash)
+}
+
+// toRepairLimits replaces nil limits with empty ones, so they can be sent in a repeated field.
+func toRepairLimits(limits []*pb.AddressedOrderLimit) []*pb.AddressedOrderLimit {
+	converted := make([]*pb.AddressedOrderLimit, len(limits))
+	for i, limit := range limits {
+		if limit == nil {
+			limit = &pb.AddressedOrderLimit{}
+		}
+		converted[i] = limit
+	}
+	return converted
 }
 
 // sliceToSet converts the given slice to a set
@@ -194,6 +380,21 @@ func sliceToSet(slice []int32) map[int32]struct{} {
 	return set
 }
 
+// bucketPlacement returns the placement policy of the bucket the segment at path belongs to
+func (repairer *Repairer) bucketPlacement(ctx context.Context, path storj.Path) (_ string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	comps := storj.SplitPath(path)
+	if len(comps) < 3 {
+		return "", Error.New("no bucket component in path: %s", path)
+	}
+	projectID, err := uuid.Parse(comps[0])
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	return repairer.cache.BucketPlacement(ctx, *projectID, []byte(comps[2]))
+}
+
 func createBucketID(path storj.Path) ([]byte, error) {
 	comps := storj.SplitPath(path)
 	if len(comps) < 3 {
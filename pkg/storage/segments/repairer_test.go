@@ -98,7 +98,7 @@ func TestSegmentStoreRepair(t *testing.T) {
 		os := satellite.Orders.Service
 		oc := satellite.Overlay.Service
 		ec := ecclient.NewClient(satellite.Transport, 0)
-		repairer := segments.NewSegmentRepairer(metainfo, os, oc, ec, satellite.Identity, time.Minute)
+		repairer := segments.NewSegmentRepairer(metainfo, os, oc, ec, satellite.Transport, satellite.Identity, time.Minute, false, nil)
 		assert.NotNil(t, repairer)
 
 		err = repairer.Repair(ctx, path)
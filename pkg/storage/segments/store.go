@@ -49,6 +49,7 @@ type Store interface {
 	Get(ctx context.Context, path storj.Path) (rr ranger.Ranger, meta Meta, err error)
 	Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error)
 	Delete(ctx context.Context, path storj.Path) (err error)
+	UpdateMeta(ctx context.Context, path storj.Path, modified time.Time, data []byte) (meta Meta, err error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
 }
 
@@ -93,7 +94,7 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 	defer mon.Task()(&ctx)(&err)
 
 	redundancy := &pb.RedundancyScheme{
-		Type:             pb.RedundancyScheme_RS,
+		Type:             s.rs.SchemeType(),
 		MinReq:           int32(s.rs.RequiredCount()),
 		Total:            int32(s.rs.TotalCount()),
 		RepairThreshold:  int32(s.rs.RepairThreshold()),
@@ -118,6 +119,7 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 	var path storj.Path
 	var pointer *pb.Pointer
 	var originalLimits []*pb.OrderLimit2
+	var uploadStats []*pb.PieceUploadStats
 	if !remoteSized {
 		p, metadata, err := segmentInfo()
 		if err != nil {
@@ -151,10 +153,11 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 
 		sizedReader := SizeReader(peekReader)
 
-		successfulNodes, successfulHashes, err := s.ec.Put(ctx, limits, s.rs, sizedReader, expiration)
+		successfulNodes, successfulHashes, stats, err := s.ec.Put(ctx, limits, s.rs, sizedReader, expiration)
 		if err != nil {
 			return Meta{}, Error.Wrap(err)
 		}
+		uploadStats = stats
 
 		p, metadata, err := segmentInfo()
 		if err != nil {
@@ -178,7 +181,7 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 		return Meta{}, err
 	}
 
-	savedPointer, err := s.metainfo.CommitSegment(ctx, bucket, objectPath, segmentIndex, pointer, originalLimits)
+	savedPointer, err := s.metainfo.CommitSegment(ctx, bucket, objectPath, segmentIndex, pointer, originalLimits, uploadStats)
 	if err != nil {
 		return Meta{}, Error.Wrap(err)
 	}
@@ -259,7 +262,7 @@ func makeRemotePointer(nodes []*pb.Node, hashes []*pb.PieceHash, rs eestream.Red
 		Type: pb.Pointer_REMOTE,
 		Remote: &pb.RemoteSegment{
 			Redundancy: &pb.RedundancyScheme{
-				Type:             pb.RedundancyScheme_RS,
+				Type:             rs.SchemeType(),
 				MinReq:           int32(rs.RequiredCount()),
 				Total:            int32(rs.TotalCount()),
 				RepairThreshold:  int32(rs.RepairThreshold()),
@@ -305,6 +308,24 @@ func (s *segmentStore) Delete(ctx context.Context, path storj.Path) (err error)
 	return nil
 }
 
+// UpdateMeta replaces the metadata of the segment, if the segment was last
+// modified at modified
+func (s *segmentStore) UpdateMeta(ctx context.Context, path storj.Path, modified time.Time, data []byte) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	bucket, objectPath, segmentIndex, err := splitPathFragments(path)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	pointer, err := s.metainfo.UpdateSegmentMetadata(ctx, bucket, objectPath, segmentIndex, data, modified)
+	if err != nil {
+		return Meta{}, Error.Wrap(err)
+	}
+
+	return convertMeta(pointer), nil
+}
+
 // List retrieves paths to segments and their metadata stored in the metainfo
 func (s *segmentStore) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error) {
 	defer mon.Task()(&ctx)(&err)
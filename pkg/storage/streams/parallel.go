@@ -0,0 +1,268 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/ranger"
+)
+
+// segmentUploads runs the uploads of segments in the background. Every
+// upload takes one of a limited number of slots, which also bounds the
+// memory of the buffered segments.
+type segmentUploads struct {
+	ctx    context.Context
+	cancel func()
+	slots  chan struct{}
+	wg     sync.WaitGroup
+
+	mu  sync.Mutex
+	err error
+}
+
+// newSegmentUploads creates background uploads with limit slots
+func newSegmentUploads(ctx context.Context, limit int) *segmentUploads {
+	ctx, cancel := context.WithCancel(ctx)
+	return &segmentUploads{
+		ctx:    ctx,
+		cancel: cancel,
+		slots:  make(chan struct{}, limit),
+	}
+}
+
+// acquire waits for a free slot, which must be passed to start or release.
+// It fails when an upload has failed.
+func (uploads *segmentUploads) acquire() error {
+	select {
+	case uploads.slots <- struct{}{}:
+	case <-uploads.ctx.Done():
+	}
+
+	if err := uploads.failure(); err != nil {
+		return err
+	}
+	return uploads.ctx.Err()
+}
+
+// release frees a slot without uploading
+func (uploads *segmentUploads) release() {
+	<-uploads.slots
+}
+
+// start runs upload in the background with an acquired slot. When the
+// upload fails, the other uploads are canceled.
+func (uploads *segmentUploads) start(upload func(ctx context.Context) error) {
+	uploads.wg.Add(1)
+	go func() {
+		defer uploads.wg.Done()
+		defer uploads.release()
+
+		if err := upload(uploads.ctx); err != nil {
+			uploads.mu.Lock()
+			if uploads.err == nil {
+				uploads.err = err
+			}
+			uploads.mu.Unlock()
+			uploads.cancel()
+		}
+	}()
+}
+
+// failure returns the error of the first failed upload
+func (uploads *segmentUploads) failure() error {
+	uploads.mu.Lock()
+	defer uploads.mu.Unlock()
+	return uploads.err
+}
+
+// wait waits for the started uploads to finish
+func (uploads *segmentUploads) wait() error {
+	uploads.wg.Wait()
+	return uploads.failure()
+}
+
+// close cancels and waits for the started uploads
+func (uploads *segmentUploads) close() {
+	uploads.cancel()
+	uploads.wg.Wait()
+}
+
+// parallelRanger concatenates the rangers of the segments of a stream. Its
+// readers download up to parallelism segments ahead at the same time.
+type parallelRanger struct {
+	rangers     []ranger.Ranger
+	size        int64
+	parallelism int
+}
+
+// concatParallel concatenates rangers, downloading up to parallelism of them
+// at the same time
+func concatParallel(parallelism int, rangers ...ranger.Ranger) ranger.Ranger {
+	if parallelism <= 1 || len(rangers) <= 1 {
+		return ranger.Concat(rangers...)
+	}
+
+	var size int64
+	for _, rr := range rangers {
+		size += rr.Size()
+	}
+	return &parallelRanger{rangers: rangers, size: size, parallelism: parallelism}
+}
+
+// Size implements ranger.Ranger
+func (rr *parallelRanger) Size() int64 { return rr.size }
+
+// Range implements ranger.Ranger
+func (rr *parallelRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, ranger.Error.New("negative offset")
+	}
+	if length < 0 {
+		return nil, ranger.Error.New("negative length")
+	}
+	if offset+length > rr.size {
+		return nil, ranger.Error.New("range beyond end")
+	}
+
+	// collect the parts of the segments within the range
+	var parts []rangePart
+	for _, segment := range rr.rangers {
+		size := segment.Size()
+		if length > 0 && offset < size {
+			partLength := size - offset
+			if partLength > length {
+				partLength = length
+			}
+			parts = append(parts, rangePart{ranger: segment, offset: offset, length: partLength})
+			length -= partLength
+			offset = 0
+		} else {
+			offset -= size
+		}
+	}
+
+	switch len(parts) {
+	case 0:
+		return ioutil.NopCloser(bytes.NewReader(nil)), nil
+	case 1:
+		return parts[0].ranger.Range(ctx, parts[0].offset, parts[0].length)
+	}
+	return newParallelReader(ctx, parts, rr.parallelism), nil
+}
+
+// rangePart is a range of a segment
+type rangePart struct {
+	ranger         ranger.Ranger
+	offset, length int64
+}
+
+// partResult is a downloaded part
+type partResult struct {
+	data []byte
+	err  error
+}
+
+// parallelReader reads parts in order, while up to parallelism of them are
+// downloaded into memory ahead of the reader
+type parallelReader struct {
+	cancel  func()
+	slots   chan struct{}
+	results []chan partResult
+	wg      sync.WaitGroup
+
+	next    int
+	current *bytes.Reader
+	err     error
+}
+
+// newParallelReader starts downloading parts
+func newParallelReader(ctx context.Context, parts []rangePart, parallelism int) *parallelReader {
+	ctx, cancel := context.WithCancel(ctx)
+	reader := &parallelReader{
+		cancel:  cancel,
+		slots:   make(chan struct{}, parallelism),
+		results: make([]chan partResult, len(parts)),
+	}
+	for i := range reader.results {
+		reader.results[i] = make(chan partResult, 1)
+	}
+
+	reader.wg.Add(1)
+	go func() {
+		defer reader.wg.Done()
+		for i, part := range parts {
+			// a slot is freed when the reader is done with a part
+			select {
+			case reader.slots <- struct{}{}:
+			case <-ctx.Done():
+				reader.results[i] <- partResult{err: ctx.Err()}
+				continue
+			}
+
+			reader.wg.Add(1)
+			go func(i int, part rangePart) {
+				defer reader.wg.Done()
+				data, err := download(ctx, part)
+				reader.results[i] <- partResult{data: data, err: err}
+			}(i, part)
+		}
+	}()
+
+	return reader
+}
+
+// download reads a part into memory
+func download(ctx context.Context, part rangePart) (_ []byte, err error) {
+	rc, err := part.ranger.Range(ctx, part.offset, part.length)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rc.Close()) }()
+
+	data := make([]byte, part.length)
+	_, err = io.ReadFull(rc, data)
+	return data, err
+}
+
+// Read reads the downloaded parts in order
+func (reader *parallelReader) Read(p []byte) (n int, err error) {
+	for reader.err == nil {
+		if reader.current != nil && reader.current.Len() > 0 {
+			return reader.current.Read(p)
+		}
+
+		if reader.current != nil {
+			reader.current = nil
+			<-reader.slots
+		}
+
+		if reader.next >= len(reader.results) {
+			reader.err = io.EOF
+			break
+		}
+
+		result := <-reader.results[reader.next]
+		reader.next++
+		if result.err != nil {
+			reader.err = result.err
+			break
+		}
+		reader.current = bytes.NewReader(result.data)
+	}
+	return 0, reader.err
+}
+
+// Close stops the downloads
+func (reader *parallelReader) Close() error {
+	reader.cancel()
+	reader.wg.Wait()
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// memorySegments is a segment store in memory, which records the order in
+// which segments are committed and how many are uploaded at the same time
+type memorySegments struct {
+	segments.Store
+
+	mu        sync.Mutex
+	data      map[storj.Path][]byte
+	meta      map[storj.Path][]byte
+	committed []storj.Path
+	active    int
+	maxActive int
+	fail      bool
+}
+
+func newMemorySegments() *memorySegments {
+	return &memorySegments{data: map[storj.Path][]byte{}, meta: map[storj.Path][]byte{}}
+}
+
+func (store *memorySegments) Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (segments.Meta, error) {
+	store.mu.Lock()
+	store.active++
+	if store.active > store.maxActive {
+		store.maxActive = store.active
+	}
+	fail := store.fail
+	store.mu.Unlock()
+
+	defer func() {
+		store.mu.Lock()
+		store.active--
+		store.mu.Unlock()
+	}()
+
+	// give other uploads the chance to start
+	time.Sleep(10 * time.Millisecond)
+
+	content, err := ioutil.ReadAll(data)
+	if err != nil {
+		return segments.Meta{}, err
+	}
+	path, meta, err := segmentInfo()
+	if err != nil {
+		return segments.Meta{}, err
+	}
+	if fail && !strings.HasPrefix(path, "l/") {
+		return segments.Meta{}, errs.New("upload failed")
+	}
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.data[path], store.meta[path] = content, meta
+	store.committed = append(store.committed, path)
+	return segments.Meta{Size: int64(len(content)), Data: meta}, nil
+}
+
+func (store *memorySegments) Get(ctx context.Context, path storj.Path) (ranger.Ranger, segments.Meta, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	content, ok := store.data[path]
+	if !ok {
+		return nil, segments.Meta{}, storage.ErrKeyNotFound.New("%s", path)
+	}
+	return ranger.ByteRanger(content), segments.Meta{Size: int64(len(content)), Data: store.meta[path]}, nil
+}
+
+func (store *memorySegments) Meta(ctx context.Context, path storj.Path) (segments.Meta, error) {
+	_, meta, err := store.Get(ctx, path)
+	return meta, err
+}
+
+func (store *memorySegments) Delete(ctx context.Context, path storj.Path) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	if _, ok := store.data[path]; !ok {
+		return storage.ErrKeyNotFound.New("%s", path)
+	}
+	delete(store.data, path)
+	return nil
+}
+
+func TestParallelStreamStore(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	const segmentSize = 1024
+	data := make([]byte, 10*segmentSize+100)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	for _, parallelism := range []int{1, 4} {
+		memory := newMemorySegments()
+		store, err := NewParallelStreamStore(memory, segmentSize, new(storj.Key), 256, storj.AESGCM, parallelism, 0)
+		require.NoError(t, err)
+
+		meta, err := store.Put(ctx, "bucket/object", storj.AESGCM, bytes.NewReader(data), MetadataBytes("metadata"), time.Time{})
+		require.NoError(t, err)
+		assert.EqualValues(t, len(data), meta.Size)
+
+		// the last segment is committed after all other segments
+		require.Len(t, memory.committed, 11)
+		assert.True(t, strings.HasPrefix(memory.committed[10], "l/"))
+		assert.Equal(t, parallelism, memory.maxActive)
+
+		rr, _, err := store.Get(ctx, "bucket/object", storj.AESGCM)
+		require.NoError(t, err)
+
+		for _, r := range []struct{ offset, length int64 }{
+			{0, int64(len(data))},
+			{500, 3000},
+			{2048, 1024},
+			{int64(len(data)) - 10, 10},
+		} {
+			reader, err := rr.Range(ctx, r.offset, r.length)
+			require.NoError(t, err)
+			downloaded, err := ioutil.ReadAll(reader)
+			require.NoError(t, err)
+			require.NoError(t, reader.Close())
+			assert.Equal(t, data[r.offset:r.offset+r.length], downloaded)
+		}
+
+		// a reader can be closed before it is read to the end
+		reader, err := rr.Range(ctx, 0, int64(len(data)))
+		require.NoError(t, err)
+		_, err = io.ReadFull(reader, make([]byte, 100))
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+	}
+
+	{ // buffered segments are limited by memory
+		store, err := NewParallelStreamStore(newMemorySegments(), segmentSize, new(storj.Key), 256, storj.AESGCM, 8, 3*segmentSize)
+		require.NoError(t, err)
+		assert.Equal(t, 3, store.(*streamStore).parallelism)
+	}
+
+	{ // a failed segment keeps the stream from becoming visible
+		memory := newMemorySegments()
+		memory.fail = true
+		store, err := NewParallelStreamStore(memory, segmentSize, new(storj.Key), 256, storj.AESGCM, 4, 0)
+		require.NoError(t, err)
+
+		_, err = store.Put(ctx, "bucket/object", storj.AESGCM, bytes.NewReader(data), MetadataBytes("metadata"), time.Time{})
+		require.Error(t, err)
+
+		for _, path := range memory.committed {
+			assert.False(t, strings.HasPrefix(path, "l/"), path)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/storj"
+)
+
+// pausingReader reads data until limit and then pauses the upload
+type pausingReader struct {
+	reader io.Reader
+}
+
+func newPausingReader(data []byte, limit int) *pausingReader {
+	return &pausingReader{reader: bytes.NewReader(data[:limit])}
+}
+
+func (r *pausingReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	if err == io.EOF {
+		return n, ErrPaused.New("test")
+	}
+	return n, err
+}
+
+func TestResumableStreamStore(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	const segmentSize = 1024
+	data := make([]byte, 10*segmentSize+100)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	for _, parallelism := range []int{1, 4} {
+		memory := newMemorySegments()
+		store, err := NewParallelStreamStore(memory, segmentSize, new(storj.Key), 256, storj.AESGCM, parallelism, 0)
+		require.NoError(t, err)
+
+		// the segments which were read completely are kept
+		var state UploadState
+		_, err = store.PutResumable(ctx, "bucket/object", storj.AESGCM, newPausingReader(data, 3500), MetadataBytes("metadata"), time.Time{}, &state)
+		require.True(t, ErrPaused.Has(err), err)
+		assert.EqualValues(t, 3, state.Segments)
+		assert.EqualValues(t, 3*segmentSize, state.Offset())
+		assert.Len(t, memory.committed, 3)
+
+		_, _, err = store.Get(ctx, "bucket/object", storj.AESGCM)
+		require.Error(t, err)
+
+		// the upload can be paused again after it was resumed
+		_, err = store.PutResumable(ctx, "bucket/object", storj.AESGCM, newPausingReader(data[state.Offset():], 2000), MetadataBytes("metadata"), time.Time{}, &state)
+		require.True(t, ErrPaused.Has(err), err)
+		assert.EqualValues(t, 4, state.Segments)
+
+		meta, err := store.PutResumable(ctx, "bucket/object", storj.AESGCM, bytes.NewReader(data[state.Offset():]), MetadataBytes("metadata"), time.Time{}, &state)
+		require.NoError(t, err)
+		assert.EqualValues(t, len(data), meta.Size)
+		assert.True(t, strings.HasPrefix(memory.committed[len(memory.committed)-1], "l/"))
+
+		// the checksums of the resumed upload cover all of the data
+		rr, _, err := store.Get(ctx, "bucket/object", storj.AESGCM)
+		require.NoError(t, err)
+		reader, err := rr.Range(ctx, 0, rr.Size())
+		require.NoError(t, err)
+		downloaded, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+		assert.Equal(t, data, downloaded)
+	}
+
+	{ // the segments of a paused upload are deleted when it is discarded
+		memory := newMemorySegments()
+		store, err := NewParallelStreamStore(memory, segmentSize, new(storj.Key), 256, storj.AESGCM, 1, 0)
+		require.NoError(t, err)
+
+		var state UploadState
+		_, err = store.PutResumable(ctx, "bucket/object", storj.AESGCM, newPausingReader(data, 3500), MetadataBytes("metadata"), time.Time{}, &state)
+		require.True(t, ErrPaused.Has(err), err)
+		require.Len(t, memory.data, 3)
+
+		require.NoError(t, store.DeletePaused(ctx, "bucket/object", storj.AESGCM, &state))
+		assert.Len(t, memory.data, 0)
+	}
+
+	{ // a resumed upload which fails deletes the segments of the paused upload
+		memory := newMemorySegments()
+		store, err := NewParallelStreamStore(memory, segmentSize, new(storj.Key), 256, storj.AESGCM, 1, 0)
+		require.NoError(t, err)
+
+		var state UploadState
+		_, err = store.PutResumable(ctx, "bucket/object", storj.AESGCM, newPausingReader(data, 3500), MetadataBytes("metadata"), time.Time{}, &state)
+		require.True(t, ErrPaused.Has(err), err)
+
+		failing := io.MultiReader(bytes.NewReader(data[state.Offset():state.Offset()+2000]), iotest.ErrReader(errors.New("canceled")))
+		_, err = store.PutResumable(ctx, "bucket/object", storj.AESGCM, failing, MetadataBytes("metadata"), time.Time{}, &state)
+		require.Error(t, err)
+		assert.Len(t, memory.data, 0)
+	}
+
+	{ // uploads without state are not paused
+		memory := newMemorySegments()
+		store, err := NewParallelStreamStore(memory, segmentSize, new(storj.Key), 256, storj.AESGCM, 1, 0)
+		require.NoError(t, err)
+
+		_, err = store.Put(ctx, "bucket/object", storj.AESGCM, newPausingReader(data, 3500), MetadataBytes("metadata"), time.Time{})
+		require.Error(t, err)
+		assert.Equal(t, 0, len(memory.data))
+	}
+
+	{ // the segment size must not change
+		store, err := NewParallelStreamStore(newMemorySegments(), 2*segmentSize, new(storj.Key), 256, storj.AESGCM, 1, 0)
+		require.NoError(t, err)
+
+		state := UploadState{Segments: 3, SegmentSize: segmentSize}
+		_, err = store.PutResumable(ctx, "bucket/object", storj.AESGCM, bytes.NewReader(data), MetadataBytes("metadata"), time.Time{}, &state)
+		require.Error(t, err)
+	}
+}
@@ -6,8 +6,12 @@ package streams
 import (
 	"bytes"
 	"context"
+	"crypto/md5"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"strings"
@@ -25,10 +29,20 @@ import (
 	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storage/segments"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transfer"
 	"storj.io/storj/storage"
 )
 
-var mon = monkit.Package()
+var (
+	mon = monkit.Package()
+
+	// ErrChecksum is returned when downloaded data does not match the checksums of the stream
+	ErrChecksum = errs.Class("checksum mismatch")
+
+	// ErrPaused is returned by the data reader of a resumable upload to
+	// pause it, and by the upload when it was paused
+	ErrPaused = errs.Class("upload paused")
+)
 
 // Meta info about a stream
 type Meta struct {
@@ -36,8 +50,24 @@ type Meta struct {
 	Expiration time.Time
 	Size       int64
 	Data       []byte
+	// MD5 and SHA256 are the checksums of the content, which are unknown
+	// for streams uploaded without them
+	MD5    []byte
+	SHA256 []byte
 }
 
+// Metadata interface returns the latest metadata for an object. It is
+// serialized after all data of the stream has been read.
+type Metadata interface {
+	Metadata() ([]byte, error)
+}
+
+// MetadataBytes is metadata which is known before the upload starts
+type MetadataBytes []byte
+
+// Metadata implements Metadata
+func (metadata MetadataBytes) Metadata() ([]byte, error) { return metadata, nil }
+
 // convertMeta converts segment metadata to stream metadata
 func convertMeta(lastSegmentMeta segments.Meta, stream pb.StreamInfo, streamMeta pb.StreamMeta) Meta {
 	return Meta{
@@ -45,15 +75,36 @@ func convertMeta(lastSegmentMeta segments.Meta, stream pb.StreamInfo, streamMeta
 		Expiration: lastSegmentMeta.Expiration,
 		Size:       ((stream.NumberOfSegments - 1) * stream.SegmentsSize) + stream.LastSegmentSize,
 		Data:       stream.Metadata,
+		MD5:        stream.Md5,
+		SHA256:     stream.Sha256,
 	}
 }
 
+// UploadState is the progress of a paused upload. The first Segments
+// segments of the stream are uploaded, so that the upload is continued with
+// the data at Offset.
+type UploadState struct {
+	Segments    int64
+	SegmentSize int64
+	// MD5 and SHA256 hold the state of the checksums of the uploaded data
+	MD5    []byte
+	SHA256 []byte
+}
+
+// Offset returns the offset of the data from which the upload is continued
+func (state *UploadState) Offset() int64 {
+	return state.Segments * state.SegmentSize
+}
+
 // Store interface methods for streams to satisfy to be a store
 type Store interface {
 	Meta(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (Meta, error)
 	Get(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (ranger.Ranger, Meta, error)
-	Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (Meta, error)
+	Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata Metadata, expiration time.Time) (Meta, error)
+	PutResumable(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata Metadata, expiration time.Time, state *UploadState) (Meta, error)
+	DeletePaused(ctx context.Context, path storj.Path, pathCipher storj.Cipher, state *UploadState) error
 	Delete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) error
+	UpdateMetadata(ctx context.Context, path storj.Path, pathCipher storj.Cipher, metadata []byte) (Meta, error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
 }
 
@@ -64,10 +115,20 @@ type streamStore struct {
 	rootKey      *storj.Key
 	encBlockSize int
 	cipher       storj.Cipher
+	// parallelism is the number of segments transferred at the same time
+	parallelism int
 }
 
 // NewStreamStore stuff
 func NewStreamStore(segments segments.Store, segmentSize int64, rootKey *storj.Key, encBlockSize int, cipher storj.Cipher) (Store, error) {
+	return NewParallelStreamStore(segments, segmentSize, rootKey, encBlockSize, cipher, 1, 0)
+}
+
+// NewParallelStreamStore creates a stream store which uploads and downloads
+// up to parallelism segments of a stream at the same time. Segments which
+// are transferred in parallel are buffered in memory, so parallelism is
+// reduced until they fit into maxBufferMem, unless it is zero.
+func NewParallelStreamStore(segments segments.Store, segmentSize int64, rootKey *storj.Key, encBlockSize int, cipher storj.Cipher, parallelism int, maxBufferMem int64) (Store, error) {
 	if segmentSize <= 0 {
 		return nil, errs.New("segment size must be larger than 0")
 	}
@@ -84,37 +145,89 @@ func NewStreamStore(segments segments.Store, segmentSize int64, rootKey *storj.K
 		rootKey:      rootKey,
 		encBlockSize: encBlockSize,
 		cipher:       cipher,
+		parallelism:  limitParallelism(parallelism, segmentSize, maxBufferMem),
 	}, nil
 }
 
+// limitParallelism limits parallelism so that the buffered segments fit into
+// maxBufferMem
+func limitParallelism(parallelism int, segmentSize, maxBufferMem int64) int {
+	if maxBufferMem > 0 && int64(parallelism)*segmentSize > maxBufferMem {
+		parallelism = int(maxBufferMem / segmentSize)
+	}
+	if parallelism < 1 {
+		return 1
+	}
+	return parallelism
+}
+
 // Put breaks up data as it comes in into s.segmentSize length pieces, then
 // store the first piece at s0/<path>, second piece at s1/<path>, and the
 // *last* piece at l/<path>. Store the given metadata, along with the number
-// of segments, in a new protobuf, in the metadata of l/<path>.
-func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (m Meta, err error) {
+// of segments and the checksums of the data, in a new protobuf, in the
+// metadata of l/<path>.
+func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata Metadata, expiration time.Time) (m Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
-	// previously file uploaded?
-	err = s.Delete(ctx, path, pathCipher)
-	if err != nil && !storage.ErrKeyNotFound.Has(err) {
-		//something wrong happened checking for an existing
-		//file with the same name
-		return Meta{}, err
+	return s.PutResumable(ctx, path, pathCipher, data, metadata, expiration, nil)
+}
+
+// PutResumable is like Put, but the upload can be paused by failing the
+// data reader with ErrPaused. The segments which were uploaded completely
+// are kept, state is updated with the progress and ErrPaused is returned.
+// When state holds the progress of a paused upload, the upload is resumed
+// with data starting at state.Offset().
+func (s *streamStore) PutResumable(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata Metadata, expiration time.Time, state *UploadState) (m Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if state == nil || state.Segments == 0 {
+		// previously file uploaded?
+		err = s.Delete(ctx, path, pathCipher)
+		if err != nil && !storage.ErrKeyNotFound.Has(err) {
+			//something wrong happened checking for an existing
+			//file with the same name
+			return Meta{}, err
+		}
 	}
 
-	m, lastSegment, err := s.upload(ctx, path, pathCipher, data, metadata, expiration)
-	if err != nil {
+	m, lastSegment, err := s.upload(ctx, path, pathCipher, data, metadata, expiration, state)
+	if err != nil && (state == nil || !ErrPaused.Has(err)) {
 		s.cancelHandler(context.Background(), lastSegment, path, pathCipher)
 	}
 
 	return m, err
 }
 
-func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (m Meta, lastSegment int64, err error) {
+// DeletePaused deletes the segments of a paused upload which is not resumed
+func (s *streamStore) DeletePaused(ctx context.Context, path storj.Path, pathCipher storj.Cipher, state *UploadState) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	s.cancelHandler(ctx, state.Segments, path, pathCipher)
+	return nil
+}
+
+func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata Metadata, expiration time.Time, state *UploadState) (m Meta, lastSegment int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	var currentSegment int64
 	var streamSize int64
 	var putMeta segments.Meta
+	var lastStreamInfo pb.StreamInfo
+
+	md5Hash, sha256Hash := md5.New(), sha256.New()
+	if state != nil && state.Segments > 0 {
+		if state.SegmentSize != s.segmentSize {
+			return Meta{}, 0, errs.New("segment size of paused upload (%d) does not match segment size (%d)", state.SegmentSize, s.segmentSize)
+		}
+		err = errs.Combine(
+			md5Hash.(encoding.BinaryUnmarshaler).UnmarshalBinary(state.MD5),
+			sha256Hash.(encoding.BinaryUnmarshaler).UnmarshalBinary(state.SHA256),
+		)
+		if err != nil {
+			return Meta{}, 0, errs.Wrap(err)
+		}
+		currentSegment = state.Segments
+		streamSize = state.Offset()
+	}
 
 	defer func() {
 		select {
@@ -129,81 +242,70 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 		return Meta{}, currentSegment, err
 	}
 
-	eofReader := NewEOFReader(data)
+	eofReader := NewEOFReader(io.TeeReader(data, io.MultiWriter(md5Hash, sha256Hash)))
 
-	for !eofReader.isEOF() && !eofReader.hasError() {
-		// generate random key for encrypting the segment's content
-		var contentKey storj.Key
-		_, err = rand.Read(contentKey[:])
-		if err != nil {
-			return Meta{}, currentSegment, err
-		}
-
-		// Initialize the content nonce with the segment's index incremented by 1.
-		// The increment by 1 is to avoid nonce reuse with the metadata encryption,
-		// which is encrypted with the zero nonce.
-		var contentNonce storj.Nonce
-		_, err := encryption.Increment(&contentNonce, currentSegment+1)
-		if err != nil {
-			return Meta{}, currentSegment, err
-		}
+	// the segments before the last one are uploaded in the background, when
+	// more than one segment may be uploaded at the same time
+	uploads := newSegmentUploads(ctx, s.parallelism)
+	defer uploads.close()
 
-		encrypter, err := encryption.NewEncrypter(s.cipher, &contentKey, &contentNonce, s.encBlockSize)
-		if err != nil {
-			return Meta{}, currentSegment, err
+	// checkpoint is the state of the upload before the current segment
+	var checkpoint UploadState
+	defer func() {
+		if err == nil || state == nil || !ErrPaused.Has(eofReader.err) {
+			return
 		}
-
-		// generate random nonce for encrypting the content key
-		var keyNonce storj.Nonce
-		_, err = rand.Read(keyNonce[:])
-		if err != nil {
-			return Meta{}, currentSegment, err
+		// the segments uploaded in the background were read completely, so
+		// they are finished before the upload is paused
+		if waitErr := uploads.wait(); waitErr != nil {
+			err = waitErr
+			return
 		}
+		*state = checkpoint
+		err = ErrPaused.New("after %d segments", checkpoint.Segments)
+	}()
 
-		encryptedKey, err := encryption.EncryptKey(&contentKey, s.cipher, derivedKey, &keyNonce)
+	// putSegment uploads a segment, which is the last one of the stream if
+	// isLast returns true after reading the segment data
+	putSegment := func(ctx context.Context, index int64, segment *segmentEncryption, data io.Reader, isLast func() bool, size func() int64) (segments.Meta, error) {
+		ctx = transfer.WithSegment(ctx, index)
+		peekReader := segments.NewPeekThresholdReader(transfer.Reader(ctx, data))
+		largeData, err := peekReader.IsLargerThan(segment.encrypter.InBlockSize())
 		if err != nil {
-			return Meta{}, currentSegment, err
-		}
-
-		sizeReader := NewSizeReader(eofReader)
-		segmentReader := io.LimitReader(sizeReader, s.segmentSize)
-		peekReader := segments.NewPeekThresholdReader(segmentReader)
-		largeData, err := peekReader.IsLargerThan(encrypter.InBlockSize())
-		if err != nil {
-			return Meta{}, currentSegment, err
+			return segments.Meta{}, err
 		}
 		var transformedReader io.Reader
 		if largeData {
-			paddedReader := eestream.PadReader(ioutil.NopCloser(peekReader), encrypter.InBlockSize())
-			transformedReader = encryption.TransformReader(paddedReader, encrypter, 0)
+			paddedReader := eestream.PadReader(ioutil.NopCloser(peekReader), segment.encrypter.InBlockSize())
+			transformedReader = encryption.TransformReader(paddedReader, segment.encrypter, 0)
 		} else {
 			data, err := ioutil.ReadAll(peekReader)
 			if err != nil {
-				return Meta{}, currentSegment, err
+				return segments.Meta{}, err
 			}
-			cipherData, err := encryption.Encrypt(data, s.cipher, &contentKey, &contentNonce)
+			cipherData, err := encryption.Encrypt(data, s.cipher, &segment.contentKey, &segment.contentNonce)
 			if err != nil {
-				return Meta{}, currentSegment, err
+				return segments.Meta{}, err
 			}
 			transformedReader = bytes.NewReader(cipherData)
 		}
 
-		putMeta, err = s.segments.Put(ctx, transformedReader, expiration, func() (storj.Path, []byte, error) {
+		return s.segments.Put(ctx, transformedReader, expiration, func() (storj.Path, []byte, error) {
 			encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
 			if err != nil {
 				return "", nil, err
 			}
 
-			if !eofReader.isEOF() {
-				segmentPath := getSegmentPath(encPath, currentSegment)
+			if !isLast() {
+				segmentPath := getSegmentPath(encPath, index)
 
 				if s.cipher == storj.Unencrypted {
 					return segmentPath, nil, nil
 				}
 
 				segmentMeta, err := proto.Marshal(&pb.SegmentMeta{
-					EncryptedKey: encryptedKey,
-					KeyNonce:     keyNonce[:],
+					EncryptedKey: segment.encryptedKey,
+					KeyNonce:     segment.keyNonce[:],
 				})
 				if err != nil {
 					return "", nil, err
@@ -214,18 +316,27 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 
 			lastSegmentPath := storj.JoinPaths("l", encPath)
 
-			streamInfo, err := proto.Marshal(&pb.StreamInfo{
-				NumberOfSegments: currentSegment + 1,
+			// all data has been read, so the metadata and the checksums are final
+			metadataBytes, err := metadata.Metadata()
+			if err != nil {
+				return "", nil, err
+			}
+
+			lastStreamInfo = pb.StreamInfo{
+				NumberOfSegments: index + 1,
 				SegmentsSize:     s.segmentSize,
-				LastSegmentSize:  sizeReader.Size(),
-				Metadata:         metadata,
-			})
+				LastSegmentSize:  size(),
+				Metadata:         metadataBytes,
+				Md5:              md5Hash.Sum(nil),
+				Sha256:           sha256Hash.Sum(nil),
+			}
+			streamInfo, err := proto.Marshal(&lastStreamInfo)
 			if err != nil {
 				return "", nil, err
 			}
 
 			// encrypt metadata with the content encryption key and zero nonce
-			encryptedStreamInfo, err := encryption.Encrypt(streamInfo, s.cipher, &contentKey, &storj.Nonce{})
+			encryptedStreamInfo, err := encryption.Encrypt(streamInfo, s.cipher, &segment.contentKey, &storj.Nonce{})
 			if err != nil {
 				return "", nil, err
 			}
@@ -238,8 +349,8 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 
 			if s.cipher != storj.Unencrypted {
 				streamMeta.LastSegmentMeta = &pb.SegmentMeta{
-					EncryptedKey: encryptedKey,
-					KeyNonce:     keyNonce[:],
+					EncryptedKey: segment.encryptedKey,
+					KeyNonce:     segment.keyNonce[:],
 				}
 			}
 
@@ -250,6 +361,61 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 
 			return lastSegmentPath, lastSegmentMeta, nil
 		})
+	}
+
+	for !eofReader.isEOF() && !eofReader.hasError() {
+		if state != nil {
+			checkpoint, err = newUploadState(currentSegment, s.segmentSize, md5Hash, sha256Hash)
+			if err != nil {
+				return Meta{}, currentSegment, err
+			}
+		}
+
+		segment, err := s.newSegmentEncryption(derivedKey, currentSegment)
+		if err != nil {
+			return Meta{}, currentSegment, err
+		}
+
+		sizeReader := NewSizeReader(eofReader)
+		var segmentReader io.Reader = io.LimitReader(sizeReader, s.segmentSize)
+		isLast := eofReader.isEOF
+
+		if s.parallelism > 1 {
+			if err := uploads.acquire(); err != nil {
+				return Meta{}, currentSegment, err
+			}
+
+			buffer, err := ioutil.ReadAll(segmentReader)
+			if err != nil {
+				uploads.release()
+				return Meta{}, currentSegment, err
+			}
+			segmentReader = bytes.NewReader(buffer)
+
+			last := eofReader.isEOF()
+			isLast = func() bool { return last }
+
+			if !last {
+				index := currentSegment
+				uploads.start(func(ctx context.Context) error {
+					_, err := putSegment(ctx, index, segment, segmentReader, isLast, sizeReader.Size)
+					return err
+				})
+
+				currentSegment++
+				streamSize += sizeReader.Size()
+				continue
+			}
+
+			// the last segment makes the stream visible, so it is only
+			// committed after all other segments
+			uploads.release()
+			if err := uploads.wait(); err != nil {
+				return Meta{}, currentSegment, err
+			}
+		}
+
+		putMeta, err = putSegment(ctx, currentSegment, segment, segmentReader, isLast, sizeReader.Size)
 		if err != nil {
 			return Meta{}, currentSegment, err
 		}
@@ -258,6 +424,10 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 		streamSize += sizeReader.Size()
 	}
 
+	if err := uploads.wait(); err != nil {
+		return Meta{}, currentSegment, err
+	}
+
 	if eofReader.hasError() {
 		return Meta{}, currentSegment, eofReader.err
 	}
@@ -266,12 +436,77 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 		Modified:   putMeta.Modified,
 		Expiration: expiration,
 		Size:       streamSize,
-		Data:       metadata,
+		Data:       lastStreamInfo.Metadata,
+		MD5:        lastStreamInfo.Md5,
+		SHA256:     lastStreamInfo.Sha256,
 	}
 
 	return resultMeta, currentSegment, nil
 }
 
+// newUploadState returns the state of an upload before segment, with the
+// checksums of the data before it
+func newUploadState(segment, segmentSize int64, md5Hash, sha256Hash hash.Hash) (state UploadState, err error) {
+	state.Segments = segment
+	state.SegmentSize = segmentSize
+	state.MD5, err = md5Hash.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return UploadState{}, errs.Wrap(err)
+	}
+	state.SHA256, err = sha256Hash.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		return UploadState{}, errs.Wrap(err)
+	}
+	return state, nil
+}
+
+// segmentEncryption holds the keys for encrypting a segment
+type segmentEncryption struct {
+	contentKey   storj.Key
+	contentNonce storj.Nonce
+	encrypter    encryption.Transformer
+	encryptedKey storj.EncryptedPrivateKey
+	keyNonce     storj.Nonce
+}
+
+// newSegmentEncryption generates the keys for encrypting the segment at
+// index
+func (s *streamStore) newSegmentEncryption(derivedKey *storj.Key, index int64) (_ *segmentEncryption, err error) {
+	segment := &segmentEncryption{}
+
+	// generate random key for encrypting the segment's content
+	_, err = rand.Read(segment.contentKey[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the content nonce with the segment's index incremented by 1.
+	// The increment by 1 is to avoid nonce reuse with the metadata encryption,
+	// which is encrypted with the zero nonce.
+	_, err = encryption.Increment(&segment.contentNonce, index+1)
+	if err != nil {
+		return nil, err
+	}
+
+	segment.encrypter, err = encryption.NewEncrypter(s.cipher, &segment.contentKey, &segment.contentNonce, s.encBlockSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// generate random nonce for encrypting the content key
+	_, err = rand.Read(segment.keyNonce[:])
+	if err != nil {
+		return nil, err
+	}
+
+	segment.encryptedKey, err = encryption.EncryptKey(&segment.contentKey, s.cipher, derivedKey, &segment.keyNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
 // getSegmentPath returns the unique path for a particular segment
 func getSegmentPath(path storj.Path, segNum int64) storj.Path {
 	return storj.JoinPaths(fmt.Sprintf("s%d", segNum), path)
@@ -327,7 +562,7 @@ func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj
 			encBlockSize:  int(streamMeta.EncryptionBlockSize),
 			cipher:        storj.Cipher(streamMeta.EncryptionType),
 		}
-		rangers = append(rangers, rr)
+		rangers = append(rangers, &observedRanger{Ranger: rr, segment: i})
 	}
 
 	var contentNonce storj.Nonce
@@ -336,8 +571,9 @@ func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj
 		return nil, Meta{}, err
 	}
 	encryptedKey, keyNonce := getEncryptedKeyAndNonce(streamMeta.LastSegmentMeta)
+	lastSegment := stream.NumberOfSegments - 1
 	decryptedLastSegmentRanger, err := decryptRanger(
-		ctx,
+		transfer.WithSegment(ctx, lastSegment),
 		lastSegmentRanger,
 		stream.LastSegmentSize,
 		storj.Cipher(streamMeta.EncryptionType),
@@ -351,9 +587,12 @@ func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj
 		return nil, Meta{}, err
 	}
 
-	rangers = append(rangers, decryptedLastSegmentRanger)
-	catRangers := ranger.Concat(rangers...)
+	rangers = append(rangers, &observedRanger{Ranger: decryptedLastSegmentRanger, segment: lastSegment})
+	catRangers := concatParallel(s.parallelism, rangers...)
 	meta = convertMeta(lastSegmentMeta, stream, streamMeta)
+	if meta.SHA256 != nil {
+		catRangers = &checksumRanger{Ranger: catRangers, md5: meta.MD5, sha256: meta.SHA256}
+	}
 	return catRangers, meta, nil
 }
 
@@ -383,6 +622,67 @@ func (s *streamStore) Meta(ctx context.Context, path storj.Path, pathCipher stor
 	return convertMeta(lastSegmentMeta, stream, streamMeta), nil
 }
 
+// UpdateMetadata replaces the metadata of the stream without uploading its
+// data again
+func (s *streamStore) UpdateMetadata(ctx context.Context, path storj.Path, pathCipher storj.Cipher, metadata []byte) (meta Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+	lastSegmentPath := storj.JoinPaths("l", encPath)
+
+	lastSegmentMeta, err := s.segments.Meta(ctx, lastSegmentPath)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	streamInfo, streamMeta, err := DecryptStreamInfo(ctx, lastSegmentMeta.Data, path, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+	contentKey, err := decryptContentKey(streamMeta, path, s.rootKey)
+	if err != nil {
+		return Meta{}, err
+	}
+	var stream pb.StreamInfo
+	if err := proto.Unmarshal(streamInfo, &stream); err != nil {
+		return Meta{}, err
+	}
+
+	stream.Metadata = metadata
+	streamInfo, err = proto.Marshal(&stream)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	// the zero nonce was already used with the content key for the metadata
+	// of the upload, so the update needs a new one
+	var nonce storj.Nonce
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return Meta{}, err
+	}
+
+	streamMeta.EncryptedStreamInfo, err = encryption.Encrypt(streamInfo, storj.Cipher(streamMeta.EncryptionType), contentKey, &nonce)
+	if err != nil {
+		return Meta{}, err
+	}
+	streamMeta.StreamInfoNonce = nonce[:]
+
+	data, err := proto.Marshal(&streamMeta)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	lastSegmentMeta, err = s.segments.UpdateMeta(ctx, lastSegmentPath, lastSegmentMeta.Modified, data)
+	if err != nil {
+		return Meta{}, err
+	}
+
+	return convertMeta(lastSegmentMeta, stream, streamMeta), nil
+}
+
 // Delete all the segments, with the last one last
 func (s *streamStore) Delete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -540,6 +840,23 @@ func (lr *lazySegmentRanger) Range(ctx context.Context, offset, length int64) (i
 	return lr.ranger.Range(ctx, offset, length)
 }
 
+// observedRanger reports the transfers of the ranges of a segment to the
+// observer of their context
+type observedRanger struct {
+	ranger.Ranger
+	segment int64
+}
+
+// Range implements Ranger.Range
+func (rr *observedRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	ctx = transfer.WithSegment(ctx, rr.segment)
+	reader, err := rr.Ranger.Range(ctx, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	return transfer.ReadCloser(ctx, reader), nil
+}
+
 // decryptRanger returns a decrypted ranger of the given rr ranger
 func decryptRanger(ctx context.Context, rr ranger.Ranger, decryptedSize int64, cipher storj.Cipher, derivedKey *storj.Key, encryptedKey storj.EncryptedPrivateKey, encryptedKeyNonce, startingNonce *storj.Nonce, encBlockSize int) (decrypted ranger.Ranger, err error) {
 	contentKey, err := encryption.DecryptKey(encryptedKey, cipher, derivedKey, encryptedKeyNonce)
@@ -651,19 +968,76 @@ func DecryptStreamInfo(ctx context.Context, streamMetaBytes []byte, path storj.P
 		return nil, pb.StreamMeta{}, err
 	}
 
-	derivedKey, err := encryption.DeriveContentKey(path, rootKey)
+	contentKey, err := decryptContentKey(streamMeta, path, rootKey)
 	if err != nil {
 		return nil, pb.StreamMeta{}, err
 	}
 
-	cipher := storj.Cipher(streamMeta.EncryptionType)
-	encryptedKey, keyNonce := getEncryptedKeyAndNonce(streamMeta.LastSegmentMeta)
-	contentKey, err := encryption.DecryptKey(encryptedKey, cipher, derivedKey, keyNonce)
+	// decrypt metadata with the content encryption key and the zero nonce,
+	// unless the metadata was updated with another nonce
+	var nonce storj.Nonce
+	copy(nonce[:], streamMeta.StreamInfoNonce)
+
+	streamInfo, err = encryption.Decrypt(streamMeta.EncryptedStreamInfo, storj.Cipher(streamMeta.EncryptionType), contentKey, &nonce)
+	return streamInfo, streamMeta, err
+}
+
+// decryptContentKey decrypts the content key of the last segment of a stream
+func decryptContentKey(streamMeta pb.StreamMeta, path storj.Path, rootKey *storj.Key) (*storj.Key, error) {
+	derivedKey, err := encryption.DeriveContentKey(path, rootKey)
 	if err != nil {
-		return nil, pb.StreamMeta{}, err
+		return nil, err
 	}
 
-	// decrypt metadata with the content encryption key and zero nonce
-	streamInfo, err = encryption.Decrypt(streamMeta.EncryptedStreamInfo, cipher, contentKey, &storj.Nonce{})
-	return streamInfo, streamMeta, err
+	encryptedKey, keyNonce := getEncryptedKeyAndNonce(streamMeta.LastSegmentMeta)
+	return encryption.DecryptKey(encryptedKey, storj.Cipher(streamMeta.EncryptionType), derivedKey, keyNonce)
+}
+
+// checksumRanger verifies the checksums of the stream when the full
+// stream is read
+type checksumRanger struct {
+	ranger.Ranger
+	md5    []byte
+	sha256 []byte
+}
+
+// Range implements Ranger.Range
+func (rr *checksumRanger) Range(ctx context.Context, offset, length int64) (_ io.ReadCloser, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	reader, err := rr.Ranger.Range(ctx, offset, length)
+	if err != nil || offset != 0 || length != rr.Size() {
+		return reader, err
+	}
+
+	return &checksumReader{
+		ReadCloser: reader,
+		hashes:     []hash.Hash{md5.New(), sha256.New()},
+		expected:   [][]byte{rr.md5, rr.sha256},
+	}, nil
+}
+
+// checksumReader compares the checksums of the data read with the expected
+// ones at the end of the data
+type checksumReader struct {
+	io.ReadCloser
+	hashes   []hash.Hash
+	expected [][]byte
+}
+
+// Read implements io.Reader
+func (reader *checksumReader) Read(p []byte) (n int, err error) {
+	n, err = reader.ReadCloser.Read(p)
+	for _, hash := range reader.hashes {
+		_, _ = hash.Write(p[:n])
+	}
+
+	if err == io.EOF {
+		for i, hash := range reader.hashes {
+			if reader.expected[i] != nil && !bytes.Equal(hash.Sum(nil), reader.expected[i]) {
+				return n, ErrChecksum.New("downloaded data does not match the uploaded data")
+			}
+		}
+	}
+	return n, err
 }
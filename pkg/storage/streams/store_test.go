@@ -5,8 +5,11 @@ package streams
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
 	"time"
@@ -124,18 +127,21 @@ func TestStreamStorePut(t *testing.T) {
 		Data:       []byte{},
 	}
 
+	md5Sum, sha256Sum := md5.Sum([]byte("data")), sha256.Sum256([]byte("data"))
 	streamMeta := Meta{
 		Modified:   segmentMeta.Modified,
 		Expiration: segmentMeta.Expiration,
 		Size:       4,
 		Data:       []byte("metadata"),
+		MD5:        md5Sum[:],
+		SHA256:     sha256Sum[:],
 	}
 
 	for i, test := range []struct {
 		// input for test function
 		path       string
 		data       io.Reader
-		metadata   []byte
+		metadata   MetadataBytes
 		expiration time.Time
 		// output for mock function
 		segmentMeta  segments.Meta
@@ -159,6 +165,8 @@ func TestStreamStorePut(t *testing.T) {
 						break
 					}
 				}
+				_, _, err := info()
+				assert.NoError(t, err)
 			})
 
 		mockSegmentStore.EXPECT().
@@ -384,3 +392,39 @@ func TestStreamStoreList(t *testing.T) {
 		assert.Equal(t, test.streamMore, more, errTag)
 	}
 }
+
+func TestChecksumRanger(t *testing.T) {
+	data := []byte("data")
+	md5sum := md5.Sum(data)
+	sha256sum := sha256.Sum256(data)
+
+	for i, tt := range []struct {
+		md5, sha256    []byte
+		offset, length int64
+		err            bool
+	}{
+		{md5: md5sum[:], sha256: sha256sum[:], offset: 0, length: 4},
+		{md5: md5sum[:], sha256: []byte("wrong"), offset: 0, length: 4, err: true},
+		{md5: []byte("wrong"), sha256: sha256sum[:], offset: 0, length: 4, err: true},
+		// partial reads can't be verified
+		{md5: md5sum[:], sha256: []byte("wrong"), offset: 1, length: 3},
+		{md5: md5sum[:], sha256: []byte("wrong"), offset: 0, length: 2},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		rr := &checksumRanger{Ranger: ranger.ByteRanger(data), md5: tt.md5, sha256: tt.sha256}
+		reader, err := rr.Range(ctx, tt.offset, tt.length)
+		if !assert.NoError(t, err, errTag) {
+			continue
+		}
+
+		downloaded, err := ioutil.ReadAll(reader)
+		if tt.err {
+			assert.True(t, ErrChecksum.Has(err), errTag)
+		} else {
+			assert.NoError(t, err, errTag)
+			assert.Equal(t, data[tt.offset:tt.offset+tt.length], downloaded, errTag)
+		}
+		assert.NoError(t, reader.Close(), errTag)
+	}
+}
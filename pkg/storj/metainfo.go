@@ -64,6 +64,7 @@ func (create CreateObject) Object(bucket Bucket, path Path) Object {
 		Stream: Stream{
 			Size:             -1,  // unknown
 			Checksum:         nil, // unknown
+			MD5:              nil, // unknown
 			SegmentCount:     -1,  // unknown
 			FixedSegmentSize: -1,  // unknown
 
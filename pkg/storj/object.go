@@ -54,8 +54,10 @@ type Object struct {
 type Stream struct {
 	// Size is the total size of the stream in bytes
 	Size int64
-	// Checksum is the checksum of the segment checksums
+	// Checksum is the SHA-256 checksum of the content
 	Checksum []byte
+	// MD5 is the MD5 checksum of the content
+	MD5 []byte
 
 	// SegmentCount is the number of segments
 	SegmentCount int64
@@ -37,4 +37,7 @@ type RedundancyAlgorithm byte
 const (
 	InvalidRedundancyAlgorithm = RedundancyAlgorithm(iota)
 	ReedSolomon
+	// CauchyReedSolomon corrects corrupted erasure shares only as long as it
+	// locates them within a bounded search, see eestream.NewCauchyRSScheme
+	CauchyReedSolomon
 )
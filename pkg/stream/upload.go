@@ -21,35 +21,38 @@ type Upload struct {
 	ctx      context.Context
 	stream   storj.MutableStream
 	streams  streams.Store
-	writer   io.WriteCloser
+	writer   *io.PipeWriter
 	closed   bool
 	errgroup errgroup.Group
+	state    *streams.UploadState
 }
 
 // NewUpload creates new stream upload.
 func NewUpload(ctx context.Context, stream storj.MutableStream, streams streams.Store) *Upload {
+	return NewResumableUpload(ctx, stream, streams, nil)
+}
+
+// NewResumableUpload creates a new stream upload, which continues the
+// paused upload of state, unless it is nil.
+func NewResumableUpload(ctx context.Context, stream storj.MutableStream, store streams.Store, state *streams.UploadState) *Upload {
 	reader, writer := io.Pipe()
 
+	if state == nil {
+		state = &streams.UploadState{}
+	}
+
 	upload := Upload{
 		ctx:     ctx,
 		stream:  stream,
-		streams: streams,
+		streams: store,
 		writer:  writer,
+		state:   state,
 	}
 
 	upload.errgroup.Go(func() error {
 		obj := stream.Info()
 
-		serMetaInfo := pb.SerializableMeta{
-			ContentType: obj.ContentType,
-			UserDefined: obj.Metadata,
-		}
-		metadata, err := proto.Marshal(&serMetaInfo)
-		if err != nil {
-			return errs.Combine(err, reader.CloseWithError(err))
-		}
-
-		_, err = streams.Put(ctx, storj.JoinPaths(obj.Bucket.Name, obj.Path), obj.Bucket.PathCipher, reader, metadata, obj.Expires)
+		_, err := store.PutResumable(ctx, storj.JoinPaths(obj.Bucket.Name, obj.Path), obj.Bucket.PathCipher, reader, metadata{stream}, obj.Expires, state)
 		if err != nil {
 			return errs.Combine(err, reader.CloseWithError(err))
 		}
@@ -84,3 +87,59 @@ func (upload *Upload) Close() error {
 	// Wait for streams.Put to commit the upload to the PointerDB
 	return errs.Combine(err, upload.errgroup.Wait())
 }
+
+// Cancel stops the upload without completing it and deletes the segments
+// which were uploaded, including the ones of the paused upload it resumes.
+func (upload *Upload) Cancel() error {
+	if upload.closed {
+		return Error.New("already closed")
+	}
+
+	upload.closed = true
+
+	_ = upload.writer.CloseWithError(Error.New("upload canceled"))
+
+	// streams.PutResumable deletes the segments when the upload fails
+	err := upload.errgroup.Wait()
+	if err == nil {
+		return Error.New("upload completed before it was canceled")
+	}
+	return nil
+}
+
+// Pause stops the upload without completing it. The segments which were
+// uploaded completely are kept, and the returned state can be used to resume
+// the upload with the data from its offset.
+func (upload *Upload) Pause() (*streams.UploadState, error) {
+	if upload.closed {
+		return nil, Error.New("already closed")
+	}
+
+	upload.closed = true
+
+	_ = upload.writer.CloseWithError(streams.ErrPaused.New("paused by user"))
+
+	err := upload.errgroup.Wait()
+	if !streams.ErrPaused.Has(err) {
+		if err == nil {
+			err = Error.New("upload completed before it was paused")
+		}
+		return nil, err
+	}
+	return upload.state, nil
+}
+
+// metadata serializes the metadata of a stream when the upload is committed,
+// so that it can still be changed while the data is written.
+type metadata struct {
+	stream storj.MutableStream
+}
+
+// Metadata implements streams.Metadata
+func (metadata metadata) Metadata() ([]byte, error) {
+	obj := metadata.stream.Info()
+	return proto.Marshal(&pb.SerializableMeta{
+		ContentType: obj.ContentType,
+		UserDefined: obj.Metadata,
+	})
+}
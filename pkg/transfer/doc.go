@@ -0,0 +1,10 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*
+Package transfer reports the progress of uploads and downloads of streams to
+an Observer, which is carried by the context of the transfer. The stream
+store reports the bytes transferred per segment and the erasure code client
+reports how the transfer of each piece to or from a storage node ended.
+*/
+package transfer
@@ -0,0 +1,164 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transfer
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// Status is the outcome of the transfer of a piece
+type Status int
+
+const (
+	// Succeeded means that the piece was transferred completely
+	Succeeded Status = iota
+	// Failed means that the transfer of the piece failed with an error
+	Failed
+	// Canceled means that the transfer of the piece was canceled, because
+	// enough other pieces were transferred (long tail) or the transfer was
+	// aborted
+	Canceled
+)
+
+// String returns the name of the status
+func (status Status) String() string {
+	switch status {
+	case Succeeded:
+		return "succeeded"
+	case Failed:
+		return "failed"
+	case Canceled:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+// PieceResult describes the transfer of a piece to or from a storage node
+type PieceResult struct {
+	// Segment is the index of the segment of the piece within its stream,
+	// or -1 if the piece is not transferred as part of a stream
+	Segment int64
+	NodeID  storj.NodeID
+	Status  Status
+	// Bytes is the number of bytes of the piece that were transferred
+	Bytes    int64
+	Duration time.Duration
+	// Err is the error of a failed transfer
+	Err error
+}
+
+// Observer is notified about the progress of uploads and downloads. Its
+// methods are called concurrently when segments or pieces are transferred
+// at the same time, and should return quickly.
+type Observer interface {
+	// Progress reports that bytes of the data of segment were transferred
+	Progress(segment int64, bytes int64)
+	// PieceFinished reports the result of the transfer of a piece
+	PieceFinished(result PieceResult)
+}
+
+type contextKey int
+
+const (
+	observerKey contextKey = iota
+	segmentKey
+)
+
+// WithObserver returns a context which reports the transfers made with it
+// to observer
+func WithObserver(ctx context.Context, observer Observer) context.Context {
+	if observer == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, observerKey, observer)
+}
+
+// WithSegment returns a context for transferring the segment with the given
+// index
+func WithSegment(ctx context.Context, segment int64) context.Context {
+	if observerFrom(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, segmentKey, segment)
+}
+
+// observerFrom returns the observer of ctx, which may be nil
+func observerFrom(ctx context.Context) Observer {
+	observer, _ := ctx.Value(observerKey).(Observer)
+	return observer
+}
+
+// segmentFrom returns the index of the segment transferred with ctx
+func segmentFrom(ctx context.Context) int64 {
+	if segment, ok := ctx.Value(segmentKey).(int64); ok {
+		return segment
+	}
+	return -1
+}
+
+// Observed returns whether the transfers made with ctx are observed
+func Observed(ctx context.Context) bool {
+	return observerFrom(ctx) != nil
+}
+
+// Progress reports bytes of the segment of ctx as transferred
+func Progress(ctx context.Context, bytes int64) {
+	if observer := observerFrom(ctx); observer != nil && bytes > 0 {
+		observer.Progress(segmentFrom(ctx), bytes)
+	}
+}
+
+// PieceFinished reports the result of the transfer of a piece of the
+// segment of ctx
+func PieceFinished(ctx context.Context, result PieceResult) {
+	if observer := observerFrom(ctx); observer != nil {
+		result.Segment = segmentFrom(ctx)
+		observer.PieceFinished(result)
+	}
+}
+
+// Reader returns a reader which reports the data read from r as progress of
+// the segment of ctx
+func Reader(ctx context.Context, r io.Reader) io.Reader {
+	if !Observed(ctx) {
+		return r
+	}
+	return &progressReader{ctx: ctx, reader: r}
+}
+
+// ReadCloser is like Reader for an io.ReadCloser
+func ReadCloser(ctx context.Context, rc io.ReadCloser) io.ReadCloser {
+	if !Observed(ctx) {
+		return rc
+	}
+	return &progressReadCloser{progressReader{ctx: ctx, reader: rc}, rc}
+}
+
+// progressReader reports the data read from reader
+type progressReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+// Read implements io.Reader
+func (r *progressReader) Read(p []byte) (n int, err error) {
+	n, err = r.reader.Read(p)
+	Progress(r.ctx, int64(n))
+	return n, err
+}
+
+type progressReadCloser struct {
+	progressReader
+	closer io.Closer
+}
+
+// Close implements io.Closer
+func (r *progressReadCloser) Close() error {
+	return r.closer.Close()
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package transfer
+
+import (
+	"sync"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// Summary sums up observed transfers
+type Summary struct {
+	// Bytes is the number of bytes of data transferred
+	Bytes int64
+	// SegmentBytes is the number of bytes transferred per segment
+	SegmentBytes map[int64]int64
+
+	// Succeeded, Failed and Canceled count the pieces by their status
+	Succeeded int
+	Failed    int
+	Canceled  int
+	// Nodes is the number of distinct nodes pieces were transferred with
+	Nodes int
+}
+
+// Stats is an Observer which sums up the transfers it observes
+type Stats struct {
+	mu      sync.Mutex
+	summary Summary
+	nodes   map[storj.NodeID]struct{}
+}
+
+// Progress implements Observer
+func (stats *Stats) Progress(segment int64, bytes int64) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	if stats.summary.SegmentBytes == nil {
+		stats.summary.SegmentBytes = map[int64]int64{}
+	}
+	stats.summary.Bytes += bytes
+	stats.summary.SegmentBytes[segment] += bytes
+}
+
+// PieceFinished implements Observer
+func (stats *Stats) PieceFinished(result PieceResult) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	switch result.Status {
+	case Succeeded:
+		stats.summary.Succeeded++
+	case Failed:
+		stats.summary.Failed++
+	case Canceled:
+		stats.summary.Canceled++
+	}
+
+	if stats.nodes == nil {
+		stats.nodes = map[storj.NodeID]struct{}{}
+	}
+	stats.nodes[result.NodeID] = struct{}{}
+	stats.summary.Nodes = len(stats.nodes)
+}
+
+// Summary returns the sum of the transfers observed so far
+func (stats *Stats) Summary() Summary {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+
+	summary := stats.summary
+	summary.SegmentBytes = make(map[int64]int64, len(stats.summary.SegmentBytes))
+	for segment, bytes := range stats.summary.SegmentBytes {
+		summary.SegmentBytes[segment] = bytes
+	}
+	return summary
+}
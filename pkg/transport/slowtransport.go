@@ -40,6 +40,11 @@ func (client *slowTransport) DialNode(ctx context.Context, node *pb.Node, opts .
 	return client.client.DialNode(ctx, node, append(client.network.DialOptions(), opts...)...)
 }
 
+// DialNodeQUIC dials a node with latency, the simulated network only supports tcp
+func (client *slowTransport) DialNodeQUIC(ctx context.Context, node *pb.Node, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return client.DialNode(ctx, node, opts...)
+}
+
 // DialAddress dials an address with latency
 func (client *slowTransport) DialAddress(ctx context.Context, address string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	return client.client.DialAddress(ctx, address, append(client.network.DialOptions(), opts...)...)
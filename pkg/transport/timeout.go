@@ -6,6 +6,8 @@ package transport
 import (
 	"net"
 	"time"
+
+	"storj.io/storj/pkg/quic"
 )
 
 type timeoutConn struct {
@@ -54,3 +56,15 @@ func (tc *timeoutConn) SetReadDeadline(t time.Time) error {
 func (tc *timeoutConn) SetWriteDeadline(t time.Time) error {
 	return tc.conn.SetWriteDeadline(t)
 }
+
+// quicTimeoutConn is a timeoutConn over a QUIC connection
+type quicTimeoutConn struct {
+	timeoutConn
+	quic *quic.Conn
+}
+
+// QUICConn returns the QUIC connection, so the tls credentials can skip
+// the handshake QUIC already did
+func (tc *quicTimeoutConn) QUICConn() *quic.Conn {
+	return tc.quic
+}
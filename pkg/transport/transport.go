@@ -13,6 +13,8 @@ import (
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/peertls/tlsopts"
+	"storj.io/storj/pkg/quic"
+	"storj.io/storj/pkg/relay"
 )
 
 // Observer implements the ConnSuccess and ConnFailure methods
@@ -25,6 +27,7 @@ type Observer interface {
 // Client defines the interface to an transport client.
 type Client interface {
 	DialNode(ctx context.Context, node *pb.Node, opts ...grpc.DialOption) (*grpc.ClientConn, error)
+	DialNodeQUIC(ctx context.Context, node *pb.Node, opts ...grpc.DialOption) (*grpc.ClientConn, error)
 	DialAddress(ctx context.Context, address string, opts ...grpc.DialOption) (*grpc.ClientConn, error)
 	Identity() *identity.FullIdentity
 	WithObservers(obs ...Observer) Client
@@ -80,12 +83,22 @@ func (transport *Transport) DialNode(ctx context.Context, node *pb.Node, opts ..
 		return nil, err
 	}
 
+	dial := func(ctx context.Context, addr string) (net.Conn, error) {
+		return (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if node.Address.Transport == pb.NodeTransport_TCP_TLS_GRPC_RELAY {
+		// the relay forwards the raw connection, tls is still verified against node.Id
+		dial = func(ctx context.Context, addr string) (net.Conn, error) {
+			return relay.Dial(ctx, addr, node.Id)
+		}
+	}
+
 	options := append([]grpc.DialOption{
 		dialOption,
 		grpc.WithBlock(),
 		grpc.FailOnNonTempDialError(true),
 		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
-			conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+			conn, err := dial(ctx, addr)
 			if err != nil {
 				return nil, err
 			}
@@ -110,6 +123,55 @@ func (transport *Transport) DialNode(ctx context.Context, node *pb.Node, opts ..
 	return conn, nil
 }
 
+// DialNodeQUIC returns a grpc connection to a node carried over QUIC.
+//
+// The node identity is verified during the QUIC handshake, the same as for
+// DialNode. Failures are not reported to the observers, callers are expected
+// to fall back to DialNode when the node can't be reached over QUIC.
+func (transport *Transport) DialNodeQUIC(ctx context.Context, node *pb.Node, opts ...grpc.DialOption) (conn *grpc.ClientConn, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if node.Address == nil || node.Address.Address == "" {
+		return nil, Error.New("no address")
+	}
+	dialOption, err := transport.tlsOpts.DialOption(node.Id)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := transport.tlsOpts.ClientTLSConfig(node.Id)
+
+	options := append([]grpc.DialOption{
+		dialOption,
+		grpc.WithBlock(),
+		grpc.FailOnNonTempDialError(true),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			conn, err := quic.Dial(ctx, tlsConfig, addr)
+			if err != nil {
+				return nil, err
+			}
+			return &quicTimeoutConn{
+				timeoutConn: timeoutConn{conn: conn, timeout: transport.timeouts.Request},
+				quic:        conn,
+			}, nil
+		}),
+	}, opts...)
+
+	timedCtx, cancel := context.WithTimeout(ctx, transport.timeouts.Dial)
+	defer cancel()
+
+	conn, err = grpc.DialContext(timedCtx, node.GetAddress().Address, options...)
+	if err != nil {
+		if err == context.Canceled {
+			return nil, err
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	alertSuccess(timedCtx, transport.observers, node)
+
+	return conn, nil
+}
+
 // DialAddress returns a grpc connection with tls to an IP address.
 //
 // Do not use this method unless having a good reason. In most cases DialNode
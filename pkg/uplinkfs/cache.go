@@ -0,0 +1,157 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplinkfs
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/storj"
+)
+
+// Error is the errs class of uplink file system errors
+var Error = errs.Class("uplinkfs error")
+
+// Cache is a local disk cache for blocks of objects, which is bounded in
+// size by evicting the least recently used blocks. It also holds the local
+// copies of files while they are written, which do not count towards the
+// size limit.
+type Cache struct {
+	dir   string
+	limit int64
+
+	mu      sync.Mutex
+	size    int64
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+// cacheEntry is a block stored in the cache
+type cacheEntry struct {
+	key  string
+	size int64
+}
+
+// NewCache creates a cache in dir holding at most limit bytes of blocks.
+// Blocks left in dir by a previous cache are removed.
+func NewCache(dir string, limit memory.Size) (*Cache, error) {
+	if err := os.RemoveAll(filepath.Join(dir, "blocks")); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	for _, subdir := range []string{"blocks", "uploads"} {
+		if err := os.MkdirAll(filepath.Join(dir, subdir), 0700); err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+
+	return &Cache{
+		dir:     dir,
+		limit:   limit.Int64(),
+		lru:     list.New(),
+		entries: map[string]*list.Element{},
+	}, nil
+}
+
+// blockKey returns the cache key of a block of an object. The modification
+// time is part of the key, so that blocks of replaced objects are not used.
+func blockKey(path storj.Path, modified time.Time, index int64) string {
+	return fmt.Sprintf("%s\x00%d\x00%d", path, modified.UnixNano(), index)
+}
+
+// blockPath returns the location of the block with key on disk
+func (cache *Cache) blockPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(cache.dir, "blocks", hex.EncodeToString(sum[:]))
+}
+
+// Get returns the block with key if it is cached
+func (cache *Cache) Get(key string) ([]byte, bool) {
+	cache.mu.Lock()
+	element, ok := cache.entries[key]
+	if ok {
+		cache.lru.MoveToFront(element)
+	}
+	cache.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	// the block may be evicted meanwhile, which is treated as a miss
+	data, err := ioutil.ReadFile(cache.blockPath(key))
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Put stores the block with key, evicting the least recently used blocks
+// when the cache would exceed its limit. Blocks larger than the limit are
+// not cached.
+func (cache *Cache) Put(key string, data []byte) error {
+	size := int64(len(data))
+	if size > cache.limit {
+		return nil
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if element, ok := cache.entries[key]; ok {
+		cache.lru.MoveToFront(element)
+		return nil
+	}
+
+	for cache.size+size > cache.limit {
+		if err := cache.evict(); err != nil {
+			return err
+		}
+	}
+
+	if err := ioutil.WriteFile(cache.blockPath(key), data, 0600); err != nil {
+		return Error.Wrap(err)
+	}
+
+	cache.entries[key] = cache.lru.PushFront(&cacheEntry{key: key, size: size})
+	cache.size += size
+	return nil
+}
+
+// evict removes the least recently used block
+func (cache *Cache) evict() error {
+	element := cache.lru.Back()
+	entry := element.Value.(*cacheEntry)
+
+	cache.lru.Remove(element)
+	delete(cache.entries, entry.key)
+	cache.size -= entry.size
+
+	err := os.Remove(cache.blockPath(entry.key))
+	if err != nil && !os.IsNotExist(err) {
+		return Error.Wrap(err)
+	}
+	return nil
+}
+
+// Size returns the number of bytes of cached blocks
+func (cache *Cache) Size() memory.Size {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return memory.Size(cache.size)
+}
+
+// TempFile creates a file for the local copy of a file being written
+func (cache *Cache) TempFile() (*os.File, error) {
+	file, err := ioutil.TempFile(filepath.Join(cache.dir, "uploads"), "upload")
+	return file, Error.Wrap(err)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uplinkfs
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+)
+
+func TestCache(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	cache, err := NewCache(ctx.Dir("cache"), 3*memory.KiB)
+	require.NoError(t, err)
+
+	modified := time.Now()
+	block := func(index int64) string { return blockKey("a/b", modified, index) }
+	data := func(b byte) []byte {
+		data := make([]byte, memory.KiB)
+		for i := range data {
+			data[i] = b
+		}
+		return data
+	}
+
+	for i := int64(0); i < 3; i++ {
+		require.NoError(t, cache.Put(block(i), data(byte(i))))
+	}
+	assert.Equal(t, 3*memory.KiB, cache.Size())
+
+	// reading block 0 makes block 1 the least recently used one
+	cached, ok := cache.Get(block(0))
+	require.True(t, ok)
+	assert.Equal(t, data(0), cached)
+
+	require.NoError(t, cache.Put(block(3), data(3)))
+	assert.Equal(t, 3*memory.KiB, cache.Size())
+
+	_, ok = cache.Get(block(1))
+	assert.False(t, ok)
+	for _, i := range []int64{0, 2, 3} {
+		cached, ok := cache.Get(block(i))
+		require.True(t, ok)
+		assert.Equal(t, data(byte(i)), cached)
+	}
+
+	// blocks of a replaced object are not used
+	_, ok = cache.Get(blockKey("a/b", modified.Add(time.Second), 0))
+	assert.False(t, ok)
+
+	// blocks larger than the cache are not stored
+	require.NoError(t, cache.Put(block(4), make([]byte, 4*memory.KiB)))
+	_, ok = cache.Get(block(4))
+	assert.False(t, ok)
+
+	files, err := ioutil.ReadDir(filepath.Join(ctx.Dir("cache"), "blocks"))
+	require.NoError(t, err)
+	assert.Len(t, files, 3)
+
+	// a new cache starts empty
+	cache, err = NewCache(ctx.Dir("cache"), 3*memory.KiB)
+	require.NoError(t, err)
+	_, ok = cache.Get(block(0))
+	assert.False(t, ok)
+
+	files, err = ioutil.ReadDir(filepath.Join(ctx.Dir("cache"), "blocks"))
+	require.NoError(t, err)
+	assert.Len(t, files, 0)
+
+	temp, err := cache.TempFile()
+	require.NoError(t, err)
+	require.NoError(t, temp.Close())
+	require.NoError(t, os.Remove(temp.Name()))
+}
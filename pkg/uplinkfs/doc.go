@@ -0,0 +1,8 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+/*Package uplinkfs exposes a bucket as a FUSE file system. Objects are read
+in blocks which are cached on the local disk and files are uploaded when
+they are closed. The file system is only available on Linux.
+*/
+package uplinkfs
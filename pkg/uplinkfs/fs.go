@@ -0,0 +1,671 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// +build linux
+
+package uplinkfs
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/zeebo/errs"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+var mon = monkit.Package()
+
+// Config configures how objects are read
+type Config struct {
+	// BlockSize is the size of the blocks objects are read and cached in
+	BlockSize memory.Size
+	// ReadAhead is the number of blocks downloaded at once when a read
+	// misses the cache
+	ReadAhead int
+}
+
+// FS is a FUSE file system of the objects of a bucket. Directories are the
+// prefixes of the object paths delimited by "/".
+type FS struct {
+	bucket *uplink.Bucket
+	cache  *Cache
+	config Config
+
+	mu sync.Mutex
+	// dirs holds the directories created with mkdir, which do not exist
+	// in the bucket until an object is put into them
+	dirs map[storj.Path]bool
+	// writing holds the files which are open for writing, which may not
+	// exist in the bucket yet
+	writing map[storj.Path]*File
+}
+
+// New creates a file system of bucket, which caches blocks in cache
+func New(bucket *uplink.Bucket, cache *Cache, config Config) *FS {
+	if config.BlockSize <= 0 {
+		config.BlockSize = memory.MiB
+	}
+	if config.ReadAhead <= 0 {
+		config.ReadAhead = 1
+	}
+
+	return &FS{
+		bucket:  bucket,
+		cache:   cache,
+		config:  config,
+		dirs:    map[storj.Path]bool{},
+		writing: map[storj.Path]*File{},
+	}
+}
+
+// Root returns the root directory of the file system
+func (filesystem *FS) Root() (fs.Node, error) {
+	return &Dir{fs: filesystem}, nil
+}
+
+// writingFile returns the file at path if it is open for writing
+func (filesystem *FS) writingFile(path storj.Path) *File {
+	filesystem.mu.Lock()
+	defer filesystem.mu.Unlock()
+	return filesystem.writing[path]
+}
+
+// Dir is a directory of the file system
+type Dir struct {
+	fs *FS
+	// path is the prefix of the directory without the trailing "/"
+	path storj.Path
+}
+
+// child returns the path of the entry name in dir
+func (dir *Dir) child(name string) storj.Path {
+	if dir.path == "" {
+		return name
+	}
+	return dir.path + "/" + name
+}
+
+// prefix returns the prefix for listing the entries of dir
+func (dir *Dir) prefix() storj.Path {
+	if dir.path == "" {
+		return ""
+	}
+	return dir.path + "/"
+}
+
+// Attr returns the attributes of dir
+func (dir *Dir) Attr(ctx context.Context, attr *fuse.Attr) error {
+	attr.Mode = os.ModeDir | 0755
+	return nil
+}
+
+// Lookup returns the entry name of dir
+func (dir *Dir) Lookup(ctx context.Context, name string) (_ fs.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	path := dir.child(name)
+	if file := dir.fs.writingFile(path); file != nil {
+		return file, nil
+	}
+
+	object, err := dir.fs.bucket.OpenObject(ctx, path)
+	if err == nil {
+		file := &File{fs: dir.fs, path: path, size: object.Meta.Size, modified: object.Meta.Modified}
+		return file, object.Close()
+	}
+	if !storj.ErrObjectNotFound.Has(err) {
+		return nil, err
+	}
+
+	empty, err := dir.fs.isEmpty(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if empty {
+		return nil, fuse.ENOENT
+	}
+	return &Dir{fs: dir.fs, path: path}, nil
+}
+
+// isEmpty returns whether there is no directory at path or it has no
+// entries
+func (filesystem *FS) isEmpty(ctx context.Context, path storj.Path) (bool, error) {
+	filesystem.mu.Lock()
+	local := filesystem.dirs[path]
+	for writing := range filesystem.writing {
+		local = local || strings.HasPrefix(writing, path+"/")
+	}
+	filesystem.mu.Unlock()
+	if local {
+		return false, nil
+	}
+
+	list, err := filesystem.bucket.ListObjects(ctx, &storj.ListOptions{
+		Prefix:    path + "/",
+		Direction: storj.After,
+		Limit:     1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(list.Items) == 0, nil
+}
+
+// ReadDirAll returns the entries of dir
+func (dir *Dir) ReadDirAll(ctx context.Context) (entries []fuse.Dirent, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	names := map[string]bool{}
+	add := func(name string, typ fuse.DirentType) {
+		if name != "" && !names[name] {
+			names[name] = true
+			entries = append(entries, fuse.Dirent{Name: name, Type: typ})
+		}
+	}
+
+	cursor := ""
+	for {
+		list, err := dir.fs.bucket.ListObjects(ctx, &storj.ListOptions{
+			Prefix:    dir.prefix(),
+			Cursor:    cursor,
+			Delimiter: '/',
+			Direction: storj.After,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range list.Items {
+			if object.IsPrefix {
+				add(strings.TrimSuffix(object.Path, "/"), fuse.DT_Dir)
+			} else {
+				add(object.Path, fuse.DT_File)
+			}
+		}
+
+		if !list.More || len(list.Items) == 0 {
+			break
+		}
+		cursor = list.Items[len(list.Items)-1].Path
+	}
+
+	// add the entries which exist only locally
+	dir.fs.mu.Lock()
+	defer dir.fs.mu.Unlock()
+	for path := range dir.fs.dirs {
+		if name, ok := childName(dir.prefix(), path); ok {
+			add(name, fuse.DT_Dir)
+		}
+	}
+	for path := range dir.fs.writing {
+		if name, ok := childName(dir.prefix(), path); ok {
+			if strings.Contains(name, "/") {
+				add(name[:strings.Index(name, "/")], fuse.DT_Dir)
+			} else {
+				add(name, fuse.DT_File)
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// childName returns path relative to prefix
+func childName(prefix, path storj.Path) (string, bool) {
+	if !strings.HasPrefix(path, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(path, prefix), true
+}
+
+// Mkdir creates a directory in dir. It only exists locally until a file is
+// put into it.
+func (dir *Dir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, error) {
+	path := dir.child(req.Name)
+
+	dir.fs.mu.Lock()
+	defer dir.fs.mu.Unlock()
+	dir.fs.dirs[path] = true
+
+	return &Dir{fs: dir.fs, path: path}, nil
+}
+
+// Create creates a file in dir, which is uploaded when it is closed
+func (dir *Dir) Create(ctx context.Context, req *fuse.CreateRequest, resp *fuse.CreateResponse) (_ fs.Node, _ fs.Handle, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	file := &File{fs: dir.fs, path: dir.child(req.Name), modified: time.Now()}
+	if existing := dir.fs.writingFile(file.path); existing != nil {
+		file = existing
+	}
+
+	if err := file.openWriter(ctx, true); err != nil {
+		return nil, nil, err
+	}
+	return file, &fileHandle{file: file, write: true}, nil
+}
+
+// Remove deletes the entry of dir
+func (dir *Dir) Remove(ctx context.Context, req *fuse.RemoveRequest) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	path := dir.child(req.Name)
+
+	if !req.Dir {
+		err = dir.fs.bucket.DeleteObject(ctx, path)
+		if storj.ErrObjectNotFound.Has(err) || storage.ErrKeyNotFound.Has(err) {
+			return fuse.ENOENT
+		}
+		return err
+	}
+
+	dir.fs.mu.Lock()
+	local := dir.fs.dirs[path]
+	delete(dir.fs.dirs, path)
+	dir.fs.mu.Unlock()
+
+	empty, err := dir.fs.isEmpty(ctx, path)
+	if local && (err != nil || !empty) {
+		// keep the directory when it cannot be removed
+		dir.fs.mu.Lock()
+		dir.fs.dirs[path] = true
+		dir.fs.mu.Unlock()
+	}
+	switch {
+	case err != nil:
+		return err
+	case !empty:
+		return fuse.Errno(syscall.ENOTEMPTY)
+	case !local:
+		return fuse.ENOENT
+	}
+	return nil
+}
+
+// File is an object of the file system
+type File struct {
+	fs   *FS
+	path storj.Path
+
+	mu       sync.Mutex
+	size     int64
+	modified time.Time
+	// local is the copy of the contents while the file is open for writing
+	local   *os.File
+	dirty   bool
+	writers int
+}
+
+// Attr returns the attributes of file
+func (file *File) Attr(ctx context.Context, attr *fuse.Attr) error {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	attr.Mode = 0644
+	attr.Size = uint64(file.size)
+	if file.local != nil {
+		info, err := file.local.Stat()
+		if err != nil {
+			return err
+		}
+		attr.Size = uint64(info.Size())
+	}
+	attr.Mtime = file.modified
+	attr.Ctime = file.modified
+	return nil
+}
+
+// Open opens file for reading or writing
+func (file *File) Open(ctx context.Context, req *fuse.OpenRequest, resp *fuse.OpenResponse) (_ fs.Handle, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if req.Flags.IsReadOnly() {
+		return &fileHandle{file: file}, nil
+	}
+
+	if err := file.openWriter(ctx, req.Flags&fuse.OpenTruncate != 0); err != nil {
+		return nil, err
+	}
+	return &fileHandle{file: file, write: true}, nil
+}
+
+// Setattr changes the size of file
+func (file *File) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fuse.SetattrResponse) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if req.Valid.Size() {
+		if err := file.truncate(ctx, int64(req.Size)); err != nil {
+			return err
+		}
+	}
+
+	return file.Attr(ctx, &resp.Attr)
+}
+
+// truncate changes the size of file. Unless the file is open for writing,
+// it is uploaded again right away.
+func (file *File) truncate(ctx context.Context, size int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	file.mu.Lock()
+	if file.local != nil {
+		err = file.local.Truncate(size)
+		file.dirty = true
+		file.mu.Unlock()
+		return err
+	}
+	file.mu.Unlock()
+
+	if err := file.openWriter(ctx, size == 0); err != nil {
+		return err
+	}
+
+	file.mu.Lock()
+	err = file.local.Truncate(size)
+	file.dirty = true
+	file.mu.Unlock()
+
+	if err == nil {
+		err = file.flush(ctx)
+	}
+	return errs.Combine(err, file.releaseWriter(ctx))
+}
+
+// openWriter prepares file for writing. Unless truncate is set, the local
+// copy is filled with the contents of the object.
+func (file *File) openWriter(ctx context.Context, truncate bool) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if file.local == nil {
+		local, err := file.fs.cache.TempFile()
+		if err != nil {
+			return err
+		}
+
+		if !truncate && file.size > 0 {
+			err = file.download(ctx, local)
+		}
+		if err != nil {
+			return errs.Combine(err, local.Close(), os.Remove(local.Name()))
+		}
+
+		file.local = local
+		file.dirty = truncate
+	} else if truncate {
+		if err := file.local.Truncate(0); err != nil {
+			return err
+		}
+		file.dirty = true
+	}
+
+	file.writers++
+
+	file.fs.mu.Lock()
+	file.fs.writing[file.path] = file
+	file.fs.mu.Unlock()
+
+	return nil
+}
+
+// download copies the contents of the object to local
+func (file *File) download(ctx context.Context, local *os.File) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	reader, err := file.fs.bucket.NewReader(ctx, file.path)
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	_, err = io.Copy(local, reader)
+	return err
+}
+
+// flush uploads the local copy of file if it has been changed
+func (file *File) flush(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if file.local == nil || !file.dirty {
+		return nil
+	}
+
+	if _, err := file.local.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	writer, err := file.fs.bucket.NewWriter(ctx, file.path, &uplink.UploadOptions{})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(writer, file.local)
+	if err = errs.Combine(err, writer.Close()); err != nil {
+		return err
+	}
+
+	object, err := file.fs.bucket.OpenObject(ctx, file.path)
+	if err != nil {
+		return err
+	}
+	file.size = object.Meta.Size
+	file.modified = object.Meta.Modified
+	file.dirty = false
+
+	return object.Close()
+}
+
+// releaseWriter removes the local copy of file once it is no longer open
+// for writing
+func (file *File) releaseWriter(ctx context.Context) error {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	file.writers--
+	if file.writers > 0 {
+		return nil
+	}
+
+	file.fs.mu.Lock()
+	delete(file.fs.writing, file.path)
+	file.fs.mu.Unlock()
+
+	local := file.local
+	file.local, file.dirty = nil, false
+	return errs.Combine(local.Close(), os.Remove(local.Name()))
+}
+
+// fileHandle is an open file
+type fileHandle struct {
+	file  *File
+	write bool
+
+	mu sync.Mutex
+	// object is opened on the first read which misses the cache
+	object *uplink.Object
+}
+
+// Read reads from the local copy of the file if it is being written, and
+// from the cached blocks of the object otherwise
+func (handle *fileHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fuse.ReadResponse) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	data := make([]byte, req.Size)
+
+	file := handle.file
+	file.mu.Lock()
+	if file.local != nil {
+		n, err := file.local.ReadAt(data, req.Offset)
+		file.mu.Unlock()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		resp.Data = data[:n]
+		return nil
+	}
+	size, modified := file.size, file.modified
+	file.mu.Unlock()
+
+	n, err := handle.readAt(ctx, data, req.Offset, size, modified)
+	if err != nil {
+		return err
+	}
+	resp.Data = data[:n]
+	return nil
+}
+
+// readAt reads the blocks of the object at offset into data
+func (handle *fileHandle) readAt(ctx context.Context, data []byte, offset, size int64, modified time.Time) (n int, err error) {
+	if offset >= size {
+		return 0, nil
+	}
+	if offset+int64(len(data)) > size {
+		data = data[:size-offset]
+	}
+
+	blockSize := handle.file.fs.config.BlockSize.Int64()
+	for n < len(data) {
+		position := offset + int64(n)
+		index := position / blockSize
+
+		block, err := handle.block(ctx, index, size, modified)
+		if err != nil {
+			return n, err
+		}
+
+		copied := 0
+		if start := position - index*blockSize; start < int64(len(block)) {
+			copied = copy(data[n:], block[start:])
+		}
+		if copied == 0 {
+			return n, Error.New("short block %d of %q", index, handle.file.path)
+		}
+		n += copied
+	}
+	return n, nil
+}
+
+// block returns the block at index. When it is not cached, the following
+// blocks are downloaded too, as they are likely to be read next.
+func (handle *fileHandle) block(ctx context.Context, index, size int64, modified time.Time) (_ []byte, err error) {
+	filesystem := handle.file.fs
+	path := handle.file.path
+
+	if data, ok := filesystem.cache.Get(blockKey(path, modified, index)); ok {
+		return data, nil
+	}
+
+	defer mon.Task()(&ctx)(&err)
+
+	handle.mu.Lock()
+	defer handle.mu.Unlock()
+
+	// another read may have downloaded the block meanwhile
+	if data, ok := filesystem.cache.Get(blockKey(path, modified, index)); ok {
+		return data, nil
+	}
+
+	if handle.object == nil {
+		handle.object, err = filesystem.bucket.OpenObject(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !handle.object.Meta.Modified.Equal(modified) {
+		return nil, Error.New("%q was modified while reading", path)
+	}
+
+	blockSize := filesystem.config.BlockSize.Int64()
+	offset := index * blockSize
+	length := blockSize * int64(filesystem.config.ReadAhead)
+	if offset+length > size {
+		length = size - offset
+	}
+
+	reader, err := handle.object.DownloadRange(ctx, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	var first []byte
+	for i := int64(0); i*blockSize < length; i++ {
+		block := make([]byte, blockSize)
+		if remaining := length - i*blockSize; remaining < blockSize {
+			block = block[:remaining]
+		}
+
+		if _, err := io.ReadFull(reader, block); err != nil {
+			return nil, err
+		}
+		if err := filesystem.cache.Put(blockKey(path, modified, index+i), block); err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			first = block
+		}
+	}
+	return first, nil
+}
+
+// Write writes to the local copy of the file
+func (handle *fileHandle) Write(ctx context.Context, req *fuse.WriteRequest, resp *fuse.WriteResponse) error {
+	if !handle.write {
+		return fuse.Errno(syscall.EBADF)
+	}
+
+	file := handle.file
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	n, err := file.local.WriteAt(req.Data, req.Offset)
+	resp.Size = n
+	file.dirty = true
+	return err
+}
+
+// Flush uploads the file if it has been written to
+func (handle *fileHandle) Flush(ctx context.Context, req *fuse.FlushRequest) error {
+	if !handle.write {
+		return nil
+	}
+	return handle.file.flush(ctx)
+}
+
+// Fsync uploads the file if it has been written to
+func (file *File) Fsync(ctx context.Context, req *fuse.FsyncRequest) error {
+	return file.flush(ctx)
+}
+
+// Release closes the handle
+func (handle *fileHandle) Release(ctx context.Context, req *fuse.ReleaseRequest) (err error) {
+	handle.mu.Lock()
+	if handle.object != nil {
+		err = handle.object.Close()
+		handle.object = nil
+	}
+	handle.mu.Unlock()
+
+	if handle.write {
+		err = errs.Combine(err, handle.file.flush(ctx), handle.file.releaseWriter(ctx))
+	}
+	return err
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// +build linux
+
+package uplinkfs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sort"
+	"syscall"
+	"testing"
+
+	"bazil.org/fuse"
+	"bazil.org/fuse/fs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/lib/uplink"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestFS(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 5, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+
+		apiKey, err := uplink.ParseAPIKey(planet.Uplinks[0].APIKey[satellite.ID()])
+		require.NoError(t, err)
+
+		config := &uplink.Config{}
+		config.Volatile.TLS.SkipPeerCAWhitelist = true
+		upl, err := uplink.NewUplink(ctx, config)
+		require.NoError(t, err)
+		defer ctx.Check(upl.Close)
+
+		var key storj.Key
+		copy(key[:], "mountain")
+		var options uplink.ProjectOptions
+		options.Volatile.EncryptionKey = &key
+		project, err := upl.OpenProject(ctx, satellite.Addr(), apiKey, &options)
+		require.NoError(t, err)
+		defer ctx.Check(project.Close)
+
+		var bucketConfig uplink.BucketConfig
+		bucketConfig.Volatile.RedundancyScheme = storj.RedundancyScheme{
+			Algorithm:      storj.ReedSolomon,
+			ShareSize:      memory.KiB.Int32(),
+			RequiredShares: 2,
+			RepairShares:   3,
+			OptimalShares:  4,
+			TotalShares:    5,
+		}
+		_, err = project.CreateBucket(ctx, "bucket", &bucketConfig)
+		require.NoError(t, err)
+		bucket, err := project.OpenBucket(ctx, "bucket", &uplink.EncryptionAccess{Key: key})
+		require.NoError(t, err)
+		defer ctx.Check(bucket.Close)
+
+		data := make([]byte, 10*memory.KiB)
+		for i := range data {
+			data[i] = byte(i)
+		}
+		require.NoError(t, bucket.UploadObject(ctx, "docs/big", bytes.NewReader(data), nil))
+
+		cache, err := NewCache(ctx.Dir("cache"), 8*memory.KiB)
+		require.NoError(t, err)
+		filesystem := New(bucket, cache, Config{BlockSize: memory.KiB, ReadAhead: 3})
+
+		root, err := filesystem.Root()
+		require.NoError(t, err)
+		rootDir := root.(*Dir)
+
+		{ // directories are the prefixes of objects
+			entries, err := rootDir.ReadDirAll(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []fuse.Dirent{{Name: "docs", Type: fuse.DT_Dir}}, entries)
+
+			_, err = rootDir.Lookup(ctx, "missing")
+			assert.Equal(t, fuse.ENOENT, err)
+		}
+
+		node, err := rootDir.Lookup(ctx, "docs")
+		require.NoError(t, err)
+		docs := node.(*Dir)
+
+		{ // reads are served from blocks read ahead
+			node, err := docs.Lookup(ctx, "big")
+			require.NoError(t, err)
+
+			var attr fuse.Attr
+			require.NoError(t, node.Attr(ctx, &attr))
+			assert.EqualValues(t, len(data), attr.Size)
+
+			handle, err := node.(*File).Open(ctx, &fuse.OpenRequest{Flags: fuse.OpenReadOnly}, &fuse.OpenResponse{})
+			require.NoError(t, err)
+
+			for _, offset := range []int64{1000, 0, 5000, 9000} {
+				resp := &fuse.ReadResponse{}
+				err := handle.(fs.HandleReader).Read(ctx, &fuse.ReadRequest{Offset: offset, Size: 3000}, resp)
+				require.NoError(t, err)
+
+				end := offset + 3000
+				if end > int64(len(data)) {
+					end = int64(len(data))
+				}
+				assert.Equal(t, data[offset:end], resp.Data)
+			}
+			assert.True(t, cache.Size() <= 8*memory.KiB)
+
+			_, ok := cache.Get(blockKey("docs/big", attr.Mtime, 2))
+			assert.True(t, ok, "block read ahead is not cached")
+
+			require.NoError(t, handle.(fs.HandleReleaser).Release(ctx, &fuse.ReleaseRequest{}))
+		}
+
+		{ // files are uploaded when they are closed
+			node, handle, err := docs.Create(ctx, &fuse.CreateRequest{Name: "new"}, &fuse.CreateResponse{})
+			require.NoError(t, err)
+
+			entries, err := docs.ReadDirAll(ctx)
+			require.NoError(t, err)
+			assert.Contains(t, entries, fuse.Dirent{Name: "new", Type: fuse.DT_File})
+
+			resp := &fuse.WriteResponse{}
+			require.NoError(t, handle.(fs.HandleWriter).Write(ctx, &fuse.WriteRequest{Data: []byte("hello world")}, resp))
+			assert.Equal(t, 11, resp.Size)
+
+			_, err = bucket.OpenObject(ctx, "docs/new")
+			assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+			require.NoError(t, handle.(fs.HandleFlusher).Flush(ctx, &fuse.FlushRequest{}))
+			require.NoError(t, handle.(fs.HandleReleaser).Release(ctx, &fuse.ReleaseRequest{}))
+			assert.Equal(t, "hello world", string(download(ctx, t, bucket, "docs/new")))
+
+			// appending keeps the contents of the object
+			handle, err = node.(*File).Open(ctx, &fuse.OpenRequest{Flags: fuse.OpenWriteOnly}, &fuse.OpenResponse{})
+			require.NoError(t, err)
+			require.NoError(t, handle.(fs.HandleWriter).Write(ctx, &fuse.WriteRequest{Offset: 11, Data: []byte("!")}, &fuse.WriteResponse{}))
+			require.NoError(t, handle.(fs.HandleReleaser).Release(ctx, &fuse.ReleaseRequest{}))
+			assert.Equal(t, "hello world!", string(download(ctx, t, bucket, "docs/new")))
+
+			// truncating uploads the object again
+			var attr fuse.Attr
+			require.NoError(t, node.(*File).Setattr(ctx, &fuse.SetattrRequest{Valid: fuse.SetattrSize, Size: 5}, &fuse.SetattrResponse{}))
+			require.NoError(t, node.Attr(ctx, &attr))
+			assert.EqualValues(t, 5, attr.Size)
+			assert.Equal(t, "hello", string(download(ctx, t, bucket, "docs/new")))
+		}
+
+		{ // directories only exist locally until they have files
+			_, err := rootDir.Mkdir(ctx, &fuse.MkdirRequest{Name: "empty"})
+			require.NoError(t, err)
+
+			entries, err := rootDir.ReadDirAll(ctx)
+			require.NoError(t, err)
+			sort.Slice(entries, func(i, k int) bool { return entries[i].Name < entries[k].Name })
+			assert.Equal(t, []fuse.Dirent{
+				{Name: "docs", Type: fuse.DT_Dir},
+				{Name: "empty", Type: fuse.DT_Dir},
+			}, entries)
+
+			err = rootDir.Remove(ctx, &fuse.RemoveRequest{Name: "docs", Dir: true})
+			assert.Equal(t, fuse.Errno(syscall.ENOTEMPTY), err)
+			require.NoError(t, rootDir.Remove(ctx, &fuse.RemoveRequest{Name: "empty", Dir: true}))
+
+			_, err = rootDir.Lookup(ctx, "empty")
+			assert.Equal(t, fuse.ENOENT, err)
+		}
+
+		{ // removing a file deletes the object
+			require.NoError(t, docs.Remove(ctx, &fuse.RemoveRequest{Name: "new"}))
+			assert.Equal(t, fuse.ENOENT, docs.Remove(ctx, &fuse.RemoveRequest{Name: "new"}))
+
+			_, err := docs.Lookup(ctx, "new")
+			assert.Equal(t, fuse.ENOENT, err)
+		}
+	})
+}
+
+func download(ctx *testcontext.Context, t *testing.T, bucket *uplink.Bucket, path storj.Path) []byte {
+	reader, err := bucket.NewReader(ctx, path)
+	require.NoError(t, err)
+	defer ctx.Check(reader.Close)
+
+	data, err := ioutil.ReadAll(reader)
+	require.NoError(t, err)
+	return data
+}
@@ -0,0 +1,216 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package billing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+	"github.com/zeebo/errs"
+)
+
+// Error is the default billing errs class
+var Error = errs.Class("billing error")
+
+// hoursPerMonth is the number of hours in a billing month, used to convert usage per hour to usage per month
+const hoursPerMonth = 720
+
+// Config contains the price plan applied to project usage, prices are in cents
+type Config struct {
+	StorageGBMonthPrice float64 `help:"price in cents for storing one gigabyte for a month" default:"1"`
+	EgressGBPrice       float64 `help:"price in cents for one gigabyte of egress" default:"4.5"`
+	ObjectMonthPrice    float64 `help:"price in cents for storing one object for a month" default:"0"`
+}
+
+// InvoiceStatus describes whether an invoice has been paid
+type InvoiceStatus int
+
+const (
+	// InvoiceOpen is the status of an invoice that has not been charged yet
+	InvoiceOpen InvoiceStatus = 0
+	// InvoicePaid is the status of an invoice that was charged successfully
+	InvoicePaid InvoiceStatus = 1
+	// InvoiceFailed is the status of an invoice that the payment provider failed to charge
+	InvoiceFailed InvoiceStatus = 2
+	// InvoiceCharging is the status of an invoice that is handed to the payment provider,
+	// the charge may or may not have gone through when an invoice is left in this status
+	InvoiceCharging InvoiceStatus = 3
+)
+
+// String implements Stringer
+func (status InvoiceStatus) String() string {
+	switch status {
+	case InvoiceOpen:
+		return "open"
+	case InvoicePaid:
+		return "paid"
+	case InvoiceFailed:
+		return "failed"
+	case InvoiceCharging:
+		return "charging"
+	default:
+		return "unknown"
+	}
+}
+
+// LineItemKind describes what a line item is charged for
+type LineItemKind int
+
+const (
+	// LineItemStorage charges for stored data
+	LineItemStorage LineItemKind = 0
+	// LineItemEgress charges for downloaded data
+	LineItemEgress LineItemKind = 1
+	// LineItemObjects charges for the number of stored objects
+	LineItemObjects LineItemKind = 2
+	// LineItemCredit deducts redeemed coupons from the invoice
+	LineItemCredit LineItemKind = 3
+)
+
+// String implements Stringer
+func (kind LineItemKind) String() string {
+	switch kind {
+	case LineItemStorage:
+		return "storage"
+	case LineItemEgress:
+		return "egress"
+	case LineItemObjects:
+		return "objects"
+	case LineItemCredit:
+		return "credit"
+	default:
+		return "unknown"
+	}
+}
+
+// LineItem is a single charge or credit on an invoice, the amount is in cents
+type LineItem struct {
+	Kind        LineItemKind `json:"kind"`
+	Description string       `json:"description"`
+	Quantity    float64      `json:"quantity"`
+	UnitPrice   float64      `json:"unitPrice"`
+	Amount      int64        `json:"amount"`
+}
+
+// Invoice is the bill of a project for a single calendar month, amounts are in cents
+type Invoice struct {
+	ID          uuid.UUID `json:"id"`
+	ProjectID   uuid.UUID `json:"projectId"`
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+
+	LineItems []LineItem `json:"lineItems"`
+
+	Subtotal int64 `json:"subtotal"`
+	Credits  int64 `json:"credits"`
+	Total    int64 `json:"total"`
+
+	Status            InvoiceStatus `json:"status"`
+	ProviderReference string        `json:"providerReference"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CouponCode is the secret a customer enters to redeem a coupon
+type CouponCode [32]byte
+
+// NewCouponCode creates new random coupon code
+func NewCouponCode() (CouponCode, error) {
+	var code CouponCode
+
+	_, err := rand.Read(code[:])
+	if err != nil {
+		return code, Error.New("error creating coupon code")
+	}
+
+	return code, nil
+}
+
+// String implements Stringer
+func (code CouponCode) String() string {
+	return base64.URLEncoding.EncodeToString(code[:])
+}
+
+// CouponCodeFromBase64 parses coupon code from base64 string
+func CouponCodeFromBase64(s string) (CouponCode, error) {
+	var code CouponCode
+
+	b, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return code, Error.Wrap(err)
+	}
+	if len(b) != len(code) {
+		return code, Error.New("invalid coupon code length %d", len(b))
+	}
+
+	copy(code[:], b)
+	return code, nil
+}
+
+// Coupon credits a fixed amount in cents on every invoice of a project
+// for DurationMonths months, starting with the month it was redeemed in.
+// Like a console.RegistrationToken it is a random secret handed out by the
+// satellite operator, but it is redeemed by a project rather than a user.
+type Coupon struct {
+	Code CouponCode
+
+	// ProjectID is the project that redeemed the coupon, nil until redeemed
+	ProjectID *uuid.UUID
+
+	Amount         int64 `json:"amount"`
+	DurationMonths int   `json:"durationMonths"`
+
+	RedeemedAt *time.Time `json:"redeemedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// ActiveIn returns whether the coupon credits the invoice of the month starting at periodStart
+func (coupon *Coupon) ActiveIn(periodStart time.Time) bool {
+	if coupon.ProjectID == nil || coupon.RedeemedAt == nil {
+		return false
+	}
+
+	start := PeriodStart(*coupon.RedeemedAt)
+	end := start.AddDate(0, coupon.DurationMonths, 0)
+	return !periodStart.Before(start) && periodStart.Before(end)
+}
+
+// DB stores invoices and coupons
+type DB interface {
+	// CreateInvoice stores the invoice together with its line items
+	CreateInvoice(ctx context.Context, invoice *Invoice) error
+	// GetInvoice returns the invoice with the given id
+	GetInvoice(ctx context.Context, id uuid.UUID) (*Invoice, error)
+	// GetInvoicesByProject returns the invoices of a project, latest period first
+	GetInvoicesByProject(ctx context.Context, projectID uuid.UUID) ([]*Invoice, error)
+	// GetInvoicesByPeriod returns all invoices for the month starting at periodStart
+	GetInvoicesByPeriod(ctx context.Context, periodStart time.Time) ([]*Invoice, error)
+	// UpdateInvoiceStatus updates the status and payment provider reference of an invoice
+	UpdateInvoiceStatus(ctx context.Context, id uuid.UUID, status InvoiceStatus, providerReference string) error
+
+	// CreateCoupon stores a new unredeemed coupon
+	CreateCoupon(ctx context.Context, coupon *Coupon) error
+	// GetCoupon returns the coupon with the given code
+	GetCoupon(ctx context.Context, code CouponCode) (*Coupon, error)
+	// RedeemCoupon assigns an unredeemed coupon to a project
+	RedeemCoupon(ctx context.Context, code CouponCode, projectID uuid.UUID, redeemedAt time.Time) error
+	// GetCouponsByProject returns the coupons redeemed by a project
+	GetCouponsByProject(ctx context.Context, projectID uuid.UUID) ([]*Coupon, error)
+}
+
+// PaymentProvider charges customers for their invoices
+type PaymentProvider interface {
+	// Charge collects the total of the invoice and returns the provider's reference for the payment,
+	// the invoice id must be used as idempotency key so that charging an invoice again does not collect it twice
+	Charge(ctx context.Context, invoice *Invoice) (reference string, err error)
+}
+
+// PeriodStart returns the start of the billing month containing t
+func PeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,200 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package billing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/billing"
+	"storj.io/storj/satellite/billing/simulate"
+	"storj.io/storj/satellite/console"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestCouponActiveIn(t *testing.T) {
+	projectID := uuid.UUID{1}
+	redeemedAt := time.Date(2019, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+	coupon := &billing.Coupon{Amount: 100, DurationMonths: 2}
+	assert.False(t, coupon.ActiveIn(billing.PeriodStart(redeemedAt)), "unredeemed coupon")
+
+	coupon.ProjectID = &projectID
+	coupon.RedeemedAt = &redeemedAt
+
+	for _, tt := range []struct {
+		period time.Time
+		active bool
+	}{
+		{time.Date(2019, time.February, 1, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2019, time.March, 1, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2019, time.April, 1, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2019, time.May, 1, 0, 0, 0, 0, time.UTC), false},
+	} {
+		assert.Equal(t, tt.active, coupon.ActiveIn(tt.period), tt.period.String())
+	}
+}
+
+func TestCouponCode(t *testing.T) {
+	code, err := billing.NewCouponCode()
+	require.NoError(t, err)
+
+	parsed, err := billing.CouponCodeFromBase64(code.String())
+	require.NoError(t, err)
+	assert.Equal(t, code, parsed)
+
+	_, err = billing.CouponCodeFromBase64("c2hvcnQ=")
+	assert.Error(t, err)
+}
+
+// fixedUsage returns the same usage for every project
+type fixedUsage struct {
+	console.UsageRollups
+	usage console.ProjectUsage
+}
+
+func (rollups *fixedUsage) GetProjectTotal(ctx context.Context, projectID uuid.UUID, since, before time.Time) (*console.ProjectUsage, error) {
+	usage := rollups.usage
+	usage.Since, usage.Before = since, before
+	return &usage, nil
+}
+
+func TestInvoices(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		paying, err := db.Console().Projects().Insert(ctx, &console.Project{Name: "paying"})
+		require.NoError(t, err)
+		declined, err := db.Console().Projects().Insert(ctx, &console.Project{Name: "declined"})
+		require.NoError(t, err)
+
+		usage := &fixedUsage{usage: console.ProjectUsage{
+			Storage: 100 * 720, // 100 GB-month
+			Egress:  10,
+		}}
+		config := billing.Config{StorageGBMonthPrice: 1, EgressGBPrice: 4.5}
+		provider := simulate.NewProvider()
+		provider.FailProject(declined.ID)
+
+		service := billing.NewService(zaptest.NewLogger(t), config, db.Billing(), db.Console().Projects(), usage, provider)
+
+		coupon, err := service.CreateCoupon(ctx, 50, 1)
+		require.NoError(t, err)
+		_, err = service.RedeemCoupon(ctx, coupon.Code, paying.ID)
+		require.NoError(t, err)
+
+		_, err = service.RedeemCoupon(ctx, coupon.Code, declined.ID)
+		assert.Error(t, err, "coupon can only be redeemed once")
+
+		period := time.Now()
+		invoices, err := service.GenerateInvoices(ctx, period)
+		require.NoError(t, err)
+		require.Len(t, invoices, 2)
+
+		again, err := service.GenerateInvoices(ctx, period)
+		require.NoError(t, err)
+		assert.Len(t, again, 0, "invoices are generated once per month")
+
+		require.NoError(t, service.ChargeInvoices(ctx, period))
+
+		{ // the coupon is credited and the invoice is charged
+			invoices, err := service.GetProjectInvoices(ctx, paying.ID)
+			require.NoError(t, err)
+			require.Len(t, invoices, 1)
+
+			invoice := invoices[0]
+			assert.Equal(t, billing.PeriodStart(period), invoice.PeriodStart)
+			assert.Equal(t, int64(145), invoice.Subtotal)
+			assert.Equal(t, int64(50), invoice.Credits)
+			assert.Equal(t, int64(95), invoice.Total)
+			assert.Equal(t, billing.InvoicePaid, invoice.Status)
+			assert.NotEmpty(t, invoice.ProviderReference)
+
+			require.Len(t, invoice.LineItems, 4)
+			assert.Equal(t, billing.LineItemStorage, invoice.LineItems[0].Kind)
+			assert.Equal(t, int64(100), invoice.LineItems[0].Amount)
+			assert.Equal(t, billing.LineItemEgress, invoice.LineItems[1].Kind)
+			assert.Equal(t, int64(45), invoice.LineItems[1].Amount)
+			assert.Equal(t, billing.LineItemCredit, invoice.LineItems[3].Kind)
+			assert.Equal(t, int64(-50), invoice.LineItems[3].Amount)
+
+			assert.Equal(t, int64(95), provider.Charged(paying.ID))
+		}
+
+		{ // a declined payment leaves the invoice failed
+			invoices, err := service.GetProjectInvoices(ctx, declined.ID)
+			require.NoError(t, err)
+			require.Len(t, invoices, 1)
+
+			invoice, err := db.Billing().GetInvoice(ctx, invoices[0].ID)
+			require.NoError(t, err)
+			assert.Equal(t, int64(145), invoice.Total)
+			assert.Equal(t, billing.InvoiceFailed, invoice.Status)
+			assert.Equal(t, int64(0), provider.Charged(declined.ID))
+		}
+	})
+}
+
+// failingStatus fails to record the result of the first charge
+type failingStatus struct {
+	billing.DB
+	failed bool
+}
+
+func (db *failingStatus) UpdateInvoiceStatus(ctx context.Context, id uuid.UUID, status billing.InvoiceStatus, providerReference string) error {
+	if status == billing.InvoicePaid && !db.failed {
+		db.failed = true
+		return errs.New("status update failed")
+	}
+	return db.DB.UpdateInvoiceStatus(ctx, id, status, providerReference)
+}
+
+func TestChargeInvoicesOnce(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		project, err := db.Console().Projects().Insert(ctx, &console.Project{Name: "paying"})
+		require.NoError(t, err)
+
+		usage := &fixedUsage{usage: console.ProjectUsage{Egress: 10}}
+		config := billing.Config{EgressGBPrice: 4.5}
+		provider := simulate.NewProvider()
+		billingDB := &failingStatus{DB: db.Billing()}
+
+		service := billing.NewService(zaptest.NewLogger(t), config, billingDB, db.Console().Projects(), usage, provider)
+
+		period := time.Now()
+		invoices, err := service.GenerateInvoices(ctx, period)
+		require.NoError(t, err)
+		require.Len(t, invoices, 1)
+
+		// the charge goes through, but the invoice is left charging
+		require.Error(t, service.ChargeInvoices(ctx, period))
+
+		invoice, err := db.Billing().GetInvoice(ctx, invoices[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, billing.InvoiceCharging, invoice.Status)
+		assert.Equal(t, int64(45), provider.Charged(project.ID))
+
+		// charging again completes the invoice without collecting it twice
+		require.NoError(t, service.ChargeInvoices(ctx, period))
+
+		invoice, err = db.Billing().GetInvoice(ctx, invoices[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, billing.InvoicePaid, invoice.Status)
+		assert.NotEmpty(t, invoice.ProviderReference)
+		assert.Equal(t, int64(45), provider.Charged(project.ID))
+	})
+}
@@ -0,0 +1,251 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package billing
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+	"go.uber.org/zap"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/satellite/console"
+)
+
+var mon = monkit.Package()
+
+// Service turns project usage into invoices and charges them through a payment provider
+type Service struct {
+	log          *zap.Logger
+	config       Config
+	db           DB
+	projects     console.Projects
+	usageRollups console.UsageRollups
+	provider     PaymentProvider
+}
+
+// NewService creates a new billing service, provider may be nil when invoices are not charged
+func NewService(log *zap.Logger, config Config, db DB, projects console.Projects, usageRollups console.UsageRollups, provider PaymentProvider) *Service {
+	return &Service{
+		log:          log,
+		config:       config,
+		db:           db,
+		projects:     projects,
+		usageRollups: usageRollups,
+		provider:     provider,
+	}
+}
+
+// GenerateInvoices creates the invoices of all projects for the month containing period,
+// projects that already have an invoice for the month are skipped.
+func (service *Service) GenerateInvoices(ctx context.Context, period time.Time) (_ []*Invoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	start := PeriodStart(period)
+	end := start.AddDate(0, 1, 0)
+
+	existing, err := service.db.GetInvoicesByPeriod(ctx, start)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	invoiced := make(map[uuid.UUID]bool, len(existing))
+	for _, invoice := range existing {
+		invoiced[invoice.ProjectID] = true
+	}
+
+	projects, err := service.projects.GetAll(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var invoices []*Invoice
+	for _, project := range projects {
+		if invoiced[project.ID] {
+			continue
+		}
+
+		invoice, err := service.invoice(ctx, project.ID, start, end)
+		if err != nil {
+			return invoices, err
+		}
+
+		if err := service.db.CreateInvoice(ctx, invoice); err != nil {
+			return invoices, Error.Wrap(err)
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	service.log.Info("generated invoices", zap.Time("period", start), zap.Int("count", len(invoices)))
+	return invoices, nil
+}
+
+// invoice calculates the invoice of a single project
+func (service *Service) invoice(ctx context.Context, projectID uuid.UUID, start, end time.Time) (*Invoice, error) {
+	usage, err := service.usageRollups.GetProjectTotal(ctx, projectID, start, end)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	id, err := uuid.New()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	invoice := &Invoice{
+		ID:          *id,
+		ProjectID:   projectID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Status:      InvoiceOpen,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	invoice.addCharge(LineItemStorage, "Storage (GB-month)", usage.Storage/hoursPerMonth, service.config.StorageGBMonthPrice)
+	invoice.addCharge(LineItemEgress, "Egress (GB)", usage.Egress, service.config.EgressGBPrice)
+	invoice.addCharge(LineItemObjects, "Objects (object-month)", usage.ObjectCount/hoursPerMonth, service.config.ObjectMonthPrice)
+
+	coupons, err := service.db.GetCouponsByProject(ctx, projectID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	for _, coupon := range coupons {
+		remaining := invoice.Subtotal - invoice.Credits
+		if remaining <= 0 {
+			break
+		}
+		if !coupon.ActiveIn(start) {
+			continue
+		}
+
+		credit := coupon.Amount
+		if credit > remaining {
+			credit = remaining
+		}
+		invoice.LineItems = append(invoice.LineItems, LineItem{
+			Kind:        LineItemCredit,
+			Description: "Coupon credit",
+			Quantity:    1,
+			UnitPrice:   float64(-credit),
+			Amount:      -credit,
+		})
+		invoice.Credits += credit
+	}
+
+	invoice.Total = invoice.Subtotal - invoice.Credits
+	return invoice, nil
+}
+
+// addCharge adds a line item for quantity at unitPrice to the invoice
+func (invoice *Invoice) addCharge(kind LineItemKind, description string, quantity, unitPrice float64) {
+	amount := int64(math.Round(quantity * unitPrice))
+	invoice.LineItems = append(invoice.LineItems, LineItem{
+		Kind:        kind,
+		Description: description,
+		Quantity:    quantity,
+		UnitPrice:   unitPrice,
+		Amount:      amount,
+	})
+	invoice.Subtotal += amount
+}
+
+// ChargeInvoices charges all unpaid invoices of the month containing period
+func (service *Service) ChargeInvoices(ctx context.Context, period time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.provider == nil {
+		return Error.New("no payment provider configured")
+	}
+
+	invoices, err := service.db.GetInvoicesByPeriod(ctx, PeriodStart(period))
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, invoice := range invoices {
+		if invoice.Status == InvoicePaid {
+			continue
+		}
+
+		status, reference := InvoicePaid, ""
+		if invoice.Total > 0 {
+			// the status is recorded before charging, a failure to record the result
+			// leaves the invoice charging and the next run retries it with the same id
+			if err := service.db.UpdateInvoiceStatus(ctx, invoice.ID, InvoiceCharging, ""); err != nil {
+				return Error.Wrap(err)
+			}
+
+			reference, err = service.provider.Charge(ctx, invoice)
+			if err != nil {
+				service.log.Error("failed to charge invoice", zap.Stringer("invoice", &invoice.ID), zap.Error(err))
+				status = InvoiceFailed
+			}
+		}
+
+		if err := service.db.UpdateInvoiceStatus(ctx, invoice.ID, status, reference); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// GetProjectInvoices returns the invoices of a project, latest period first
+func (service *Service) GetProjectInvoices(ctx context.Context, projectID uuid.UUID) (_ []*Invoice, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	invoices, err := service.db.GetInvoicesByProject(ctx, projectID)
+	return invoices, Error.Wrap(err)
+}
+
+// CreateCoupon creates a new coupon worth amount cents per month for durationMonths months
+func (service *Service) CreateCoupon(ctx context.Context, amount int64, durationMonths int) (_ *Coupon, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if amount <= 0 {
+		return nil, Error.New("coupon amount must be positive")
+	}
+	if durationMonths <= 0 {
+		return nil, Error.New("coupon duration must be positive")
+	}
+
+	code, err := NewCouponCode()
+	if err != nil {
+		return nil, err
+	}
+
+	coupon := &Coupon{
+		Code:           code,
+		Amount:         amount,
+		DurationMonths: durationMonths,
+		CreatedAt:      time.Now().UTC(),
+	}
+	if err := service.db.CreateCoupon(ctx, coupon); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return coupon, nil
+}
+
+// RedeemCoupon assigns the coupon to a project, the project is credited starting with the current month
+func (service *Service) RedeemCoupon(ctx context.Context, code CouponCode, projectID uuid.UUID) (_ *Coupon, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	coupon, err := service.db.GetCoupon(ctx, code)
+	if err != nil {
+		return nil, Error.New("coupon not found")
+	}
+	if coupon.ProjectID != nil {
+		return nil, Error.New("coupon has already been redeemed")
+	}
+
+	now := time.Now().UTC()
+	if err := service.db.RedeemCoupon(ctx, code, projectID, now); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	coupon.ProjectID = &projectID
+	coupon.RedeemedAt = &now
+	return coupon, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package simulate
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/satellite/billing"
+)
+
+// Provider is billing.PaymentProvider that records charges in memory
+type Provider struct {
+	mu       sync.Mutex
+	charges  map[uuid.UUID]int64
+	failures map[uuid.UUID]bool
+	// payments contains the reference of every charged invoice
+	payments map[uuid.UUID]string
+}
+
+// NewProvider creates a new simulated payment provider
+func NewProvider() *Provider {
+	return &Provider{
+		charges:  make(map[uuid.UUID]int64),
+		failures: make(map[uuid.UUID]bool),
+		payments: make(map[uuid.UUID]string),
+	}
+}
+
+// FailProject makes all charges for invoices of the project fail
+func (provider *Provider) FailProject(projectID uuid.UUID) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	provider.failures[projectID] = true
+}
+
+// Charge records the total of the invoice as charged to its project,
+// an invoice that was already charged returns the reference of the earlier payment
+func (provider *Provider) Charge(ctx context.Context, invoice *billing.Invoice) (string, error) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	if reference, ok := provider.payments[invoice.ID]; ok {
+		return reference, nil
+	}
+
+	if provider.failures[invoice.ProjectID] {
+		return "", errs.New("payment declined")
+	}
+
+	reference := fmt.Sprintf("simulated-%s", invoice.ID.String())
+	provider.charges[invoice.ProjectID] += invoice.Total
+	provider.payments[invoice.ID] = reference
+	return reference, nil
+}
+
+// Charged returns the total amount charged to the project
+func (provider *Provider) Charged(projectID uuid.UUID) int64 {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	return provider.charges[projectID]
+}
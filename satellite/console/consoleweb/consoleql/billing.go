@@ -0,0 +1,158 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package consoleql
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+	"github.com/skyrings/skyring-common/tools/uuid"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/satellite/billing"
+	"storj.io/storj/satellite/console"
+)
+
+const (
+	// InvoiceType is a graphql type name for invoice
+	InvoiceType = "invoice"
+	// LineItemType is a graphql type name for invoice line item
+	LineItemType = "lineItem"
+	// CouponType is a graphql type name for coupon
+	CouponType = "coupon"
+	// FieldInvoices is a field name for project invoices
+	FieldInvoices = "invoices"
+	// FieldLineItems is a field name for invoice line items
+	FieldLineItems = "lineItems"
+	// FieldPeriodStart is a field name for start of the billing period
+	FieldPeriodStart = "periodStart"
+	// FieldPeriodEnd is a field name for end of the billing period
+	FieldPeriodEnd = "periodEnd"
+	// FieldSubtotal is a field name for invoice amount before credits
+	FieldSubtotal = "subtotal"
+	// FieldCredits is a field name for invoice credits
+	FieldCredits = "credits"
+	// FieldTotal is a field name for invoice amount due
+	FieldTotal = "total"
+	// FieldStatus is a field name for invoice status
+	FieldStatus = "status"
+	// FieldKind is a field name for line item kind
+	FieldKind = "kind"
+	// FieldQuantity is a field name for line item quantity
+	FieldQuantity = "quantity"
+	// FieldUnitPrice is a field name for line item unit price
+	FieldUnitPrice = "unitPrice"
+	// FieldAmount is a field name for amount in cents
+	FieldAmount = "amount"
+	// FieldDurationMonths is a field name for coupon duration
+	FieldDurationMonths = "durationMonths"
+	// FieldRedeemedAt is a field name for coupon redemption time
+	FieldRedeemedAt = "redeemedAt"
+	// FieldCode is a field name for coupon code
+	FieldCode = "code"
+)
+
+// graphqlLineItem creates *graphql.Object type representation of billing.LineItem
+func graphqlLineItem() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: LineItemType,
+		Fields: graphql.Fields{
+			FieldKind: &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					item, _ := p.Source.(billing.LineItem)
+					return item.Kind.String(), nil
+				},
+			},
+			FieldDescription: &graphql.Field{
+				Type: graphql.String,
+			},
+			FieldQuantity: &graphql.Field{
+				Type: graphql.Float,
+			},
+			FieldUnitPrice: &graphql.Field{
+				Type: graphql.Float,
+			},
+			FieldAmount: &graphql.Field{
+				Type: graphql.Int,
+			},
+		},
+	})
+}
+
+// graphqlInvoice creates *graphql.Object type representation of billing.Invoice
+func graphqlInvoice(types *TypeCreator) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: InvoiceType,
+		Fields: graphql.Fields{
+			FieldID: &graphql.Field{
+				Type: graphql.String,
+			},
+			FieldProjectID: &graphql.Field{
+				Type: graphql.String,
+			},
+			FieldPeriodStart: &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			FieldPeriodEnd: &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			FieldLineItems: &graphql.Field{
+				Type: graphql.NewList(types.lineItem),
+			},
+			FieldSubtotal: &graphql.Field{
+				Type: graphql.Int,
+			},
+			FieldCredits: &graphql.Field{
+				Type: graphql.Int,
+			},
+			FieldTotal: &graphql.Field{
+				Type: graphql.Int,
+			},
+			FieldStatus: &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					invoice, _ := p.Source.(*billing.Invoice)
+					return invoice.Status.String(), nil
+				},
+			},
+			FieldCreatedAt: &graphql.Field{
+				Type: graphql.DateTime,
+			},
+		},
+	})
+}
+
+// graphqlCoupon creates *graphql.Object type representation of billing.Coupon
+func graphqlCoupon() *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: CouponType,
+		Fields: graphql.Fields{
+			FieldAmount: &graphql.Field{
+				Type: graphql.Int,
+			},
+			FieldDurationMonths: &graphql.Field{
+				Type: graphql.Int,
+			},
+			FieldRedeemedAt: &graphql.Field{
+				Type: graphql.DateTime,
+			},
+		},
+	})
+}
+
+// verifyProjectMember returns an error when the authorized user is not a member of the project
+func verifyProjectMember(ctx context.Context, service *console.Service, projectID uuid.UUID) error {
+	projects, err := service.GetUsersProjects(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, project := range projects {
+		if project.ID == projectID {
+			return nil
+		}
+	}
+	return errs.New("user is not a member of the project")
+}
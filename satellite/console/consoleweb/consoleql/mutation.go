@@ -9,6 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/storj/internal/post"
+	"storj.io/storj/satellite/billing"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/mailservice"
 )
@@ -42,6 +43,9 @@ const (
 	// DeleteAPIKeysMutation is a mutation name for api key deleting
 	DeleteAPIKeysMutation = "deleteAPIKeys"
 
+	// RedeemCouponMutation is a mutation name for redeeming a coupon for a project
+	RedeemCouponMutation = "redeemCoupon"
+
 	// InputArg is argument name for all input types
 	InputArg = "input"
 	// FieldProjectID is field name for projectID
@@ -53,7 +57,7 @@ const (
 )
 
 // rootMutation creates mutation for graphql populated by AccountsClient
-func rootMutation(log *zap.Logger, service *console.Service, mailService *mailservice.Service, types *TypeCreator) *graphql.Object {
+func rootMutation(log *zap.Logger, service *console.Service, mailService *mailservice.Service, billingService *billing.Service, types *TypeCreator) *graphql.Object {
 	return graphql.NewObject(graphql.ObjectConfig{
 		Name: Mutation,
 		Fields: graphql.Fields{
@@ -420,6 +424,39 @@ func rootMutation(log *zap.Logger, service *console.Service, mailService *mailse
 					return keys, nil
 				},
 			},
+			// redeems a coupon for a project
+			RedeemCouponMutation: &graphql.Field{
+				Type: types.coupon,
+				Args: graphql.FieldConfigArgument{
+					FieldProjectID: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					FieldCode: &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					projectID, _ := p.Args[FieldProjectID].(string)
+					codeInput, _ := p.Args[FieldCode].(string)
+
+					pID, err := uuid.Parse(projectID)
+					if err != nil {
+						return nil, err
+					}
+
+					code, err := billing.CouponCodeFromBase64(codeInput)
+					if err != nil {
+						return nil, err
+					}
+
+					err = verifyProjectMember(p.Context, service, *pID)
+					if err != nil {
+						return nil, err
+					}
+
+					return billingService.RedeemCoupon(p.Context, code, *pID)
+				},
+			},
 		},
 	})
 }
@@ -19,6 +19,8 @@ import (
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/pkg/auth"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/billing"
+	"storj.io/storj/satellite/billing/simulate"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/console/consoleweb/consoleql"
@@ -58,12 +60,14 @@ func TestGrapqhlMutation(t *testing.T) {
 		require.NoError(t, err)
 		defer ctx.Check(mailService.Close)
 
+		billingService := billing.NewService(log, billing.Config{}, db.Billing(), db.Console().Projects(), db.Console().UsageRollups(), simulate.NewProvider())
+
 		rootObject := make(map[string]interface{})
 		rootObject["origin"] = "http://doesntmatter.com/"
 		rootObject[consoleql.ActivationPath] = "?activationToken="
 		rootObject[consoleql.SignInPath] = "login"
 
-		schema, err := consoleql.CreateSchema(log, service, mailService)
+		schema, err := consoleql.CreateSchema(log, service, mailService, billingService)
 		require.NoError(t, err)
 
 		createUser := console.CreateUser{
@@ -474,6 +478,31 @@ func TestGrapqhlMutation(t *testing.T) {
 			}
 		})
 
+		t.Run("Redeem coupon mutation", func(t *testing.T) {
+			coupon, err := billingService.CreateCoupon(ctx, 500, 3)
+			require.NoError(t, err)
+
+			query := fmt.Sprintf(
+				"mutation {redeemCoupon(projectID:\"%s\",code:\"%s\"){amount,durationMonths,redeemedAt}}",
+				project.ID.String(),
+				coupon.Code.String(),
+			)
+
+			result := testQuery(t, query)
+
+			data := result.(map[string]interface{})
+			redeemed := data[consoleql.RedeemCouponMutation].(map[string]interface{})
+
+			assert.Equal(t, 500, redeemed[consoleql.FieldAmount])
+			assert.Equal(t, 3, redeemed[consoleql.FieldDurationMonths])
+			assert.NotNil(t, redeemed[consoleql.FieldRedeemedAt])
+
+			coupons, err := db.Billing().GetCouponsByProject(ctx, project.ID)
+			require.NoError(t, err)
+			require.Len(t, coupons, 1)
+			assert.Equal(t, coupon.Code, coupons[0].Code)
+		})
+
 		t.Run("Delete project mutation", func(t *testing.T) {
 			query := fmt.Sprintf(
 				"mutation {deleteProject(id:\"%s\"){id,name}}",
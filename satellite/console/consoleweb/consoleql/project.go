@@ -8,6 +8,7 @@ import (
 
 	"github.com/graphql-go/graphql"
 
+	"storj.io/storj/satellite/billing"
 	"storj.io/storj/satellite/console"
 )
 
@@ -70,7 +71,7 @@ const (
 )
 
 // graphqlProject creates *graphql.Object type representation of satellite.ProjectInfo
-func graphqlProject(service *console.Service, types *TypeCreator) *graphql.Object {
+func graphqlProject(service *console.Service, billingService *billing.Service, types *TypeCreator) *graphql.Object {
 	return graphql.NewObject(graphql.ObjectConfig{
 		Name: ProjectType,
 		Fields: graphql.Fields{
@@ -189,6 +190,19 @@ func graphqlProject(service *console.Service, types *TypeCreator) *graphql.Objec
 					return service.GetBucketTotals(p.Context, project.ID, cursor, before)
 				},
 			},
+			FieldInvoices: &graphql.Field{
+				Type: graphql.NewList(types.invoice),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					project, _ := p.Source.(*console.Project)
+
+					err := verifyProjectMember(p.Context, service, project.ID)
+					if err != nil {
+						return nil, err
+					}
+
+					return billingService.GetProjectInvoices(p.Context, project.ID)
+				},
+			},
 		},
 	})
 }
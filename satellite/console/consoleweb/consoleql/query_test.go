@@ -16,6 +16,8 @@ import (
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/pkg/auth"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/billing"
+	"storj.io/storj/satellite/billing/simulate"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/console/consoleweb/consoleql"
@@ -42,12 +44,14 @@ func TestGraphqlQuery(t *testing.T) {
 		require.NoError(t, err)
 		defer ctx.Check(mailService.Close)
 
+		billingService := billing.NewService(log, billing.Config{}, db.Billing(), db.Console().Projects(), db.Console().UsageRollups(), simulate.NewProvider())
+
 		rootObject := make(map[string]interface{})
 		rootObject["origin"] = "http://doesntmatter.com/"
 		rootObject[consoleql.ActivationPath] = "?activationToken="
 
 		creator := consoleql.TypeCreator{}
-		err = creator.Create(log, service, mailService)
+		err = creator.Create(log, service, mailService, billingService)
 		require.NoError(t, err)
 
 		schema, err := graphql.NewSchema(graphql.SchemaConfig{
@@ -7,15 +7,16 @@ import (
 	"github.com/graphql-go/graphql"
 	"go.uber.org/zap"
 
+	"storj.io/storj/satellite/billing"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/mailservice"
 )
 
 // CreateSchema creates a schema for satellites console graphql api
-func CreateSchema(log *zap.Logger, service *console.Service, mailService *mailservice.Service) (schema graphql.Schema, err error) {
+func CreateSchema(log *zap.Logger, service *console.Service, mailService *mailservice.Service, billingService *billing.Service) (schema graphql.Schema, err error) {
 	creator := TypeCreator{}
 
-	err = creator.Create(log, service, mailService)
+	err = creator.Create(log, service, mailService, billingService)
 	if err != nil {
 		return
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/graphql-go/graphql"
 	"go.uber.org/zap"
 
+	"storj.io/storj/satellite/billing"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/mailservice"
 )
@@ -26,6 +27,9 @@ type TypeCreator struct {
 	projectMember   *graphql.Object
 	apiKeyInfo      *graphql.Object
 	createAPIKey    *graphql.Object
+	lineItem        *graphql.Object
+	invoice         *graphql.Object
+	coupon          *graphql.Object
 
 	userInput         *graphql.InputObject
 	projectInput      *graphql.InputObject
@@ -33,7 +37,7 @@ type TypeCreator struct {
 }
 
 // Create create types and check for error
-func (c *TypeCreator) Create(log *zap.Logger, service *console.Service, mailService *mailservice.Service) error {
+func (c *TypeCreator) Create(log *zap.Logger, service *console.Service, mailService *mailservice.Service, billingService *billing.Service) error {
 	// inputs
 	c.userInput = graphqlUserInput()
 	if err := c.userInput.Error(); err != nil {
@@ -81,12 +85,27 @@ func (c *TypeCreator) Create(log *zap.Logger, service *console.Service, mailServ
 		return err
 	}
 
+	c.lineItem = graphqlLineItem()
+	if err := c.lineItem.Error(); err != nil {
+		return err
+	}
+
+	c.invoice = graphqlInvoice(c)
+	if err := c.invoice.Error(); err != nil {
+		return err
+	}
+
+	c.coupon = graphqlCoupon()
+	if err := c.coupon.Error(); err != nil {
+		return err
+	}
+
 	c.projectMember = graphqlProjectMember(service, c)
 	if err := c.projectMember.Error(); err != nil {
 		return err
 	}
 
-	c.project = graphqlProject(service, c)
+	c.project = graphqlProject(service, billingService, c)
 	if err := c.project.Error(); err != nil {
 		return err
 	}
@@ -102,7 +121,7 @@ func (c *TypeCreator) Create(log *zap.Logger, service *console.Service, mailServ
 		return err
 	}
 
-	c.mutation = rootMutation(log, service, mailService, c)
+	c.mutation = rootMutation(log, service, mailService, billingService, c)
 	if err := c.mutation.Error(); err != nil {
 		return err
 	}
@@ -22,6 +22,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"storj.io/storj/pkg/auth"
+	"storj.io/storj/satellite/billing"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleweb/consoleql"
 	"storj.io/storj/satellite/mailservice"
@@ -57,9 +58,10 @@ type Config struct {
 type Server struct {
 	log *zap.Logger
 
-	config      Config
-	service     *console.Service
-	mailService *mailservice.Service
+	config         Config
+	service        *console.Service
+	mailService    *mailservice.Service
+	billingService *billing.Service
 
 	listener net.Listener
 	server   http.Server
@@ -68,13 +70,14 @@ type Server struct {
 }
 
 // NewServer creates new instance of console server
-func NewServer(logger *zap.Logger, config Config, service *console.Service, mailService *mailservice.Service, listener net.Listener) *Server {
+func NewServer(logger *zap.Logger, config Config, service *console.Service, mailService *mailservice.Service, billingService *billing.Service, listener net.Listener) *Server {
 	server := Server{
-		log:         logger,
-		config:      config,
-		listener:    listener,
-		service:     service,
-		mailService: mailService,
+		log:            logger,
+		config:         config,
+		listener:       listener,
+		service:        service,
+		mailService:    mailService,
+		billingService: billingService,
 	}
 
 	logger.Sugar().Debugf("Starting Satellite UI on %s...", server.listener.Addr().String())
@@ -346,7 +349,7 @@ func (s *Server) grapqlHandler(w http.ResponseWriter, req *http.Request) {
 func (s *Server) Run(ctx context.Context) error {
 	var err error
 
-	s.schema, err = consoleql.CreateSchema(s.log, s.service, s.mailService)
+	s.schema, err = consoleql.CreateSchema(s.log, s.service, s.mailService, s.billingService)
 	if err != nil {
 		return Error.Wrap(err)
 	}
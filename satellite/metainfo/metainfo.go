@@ -187,10 +187,16 @@ func (endpoint *Endpoint) CreateSegment(ctx context.Context, req *pb.SegmentWrit
 
 	maxPieceSize := eestream.CalcPieceSize(req.GetMaxEncryptedSegmentSize(), redundancy)
 
+	placement, err := endpoint.cache.BucketPlacement(ctx, keyInfo.ProjectID, req.Bucket)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
 	request := overlay.FindStorageNodesRequest{
 		RequestedCount: int(req.Redundancy.Total),
 		FreeBandwidth:  maxPieceSize,
 		FreeDisk:       maxPieceSize,
+		Placement:      placement,
 	}
 	nodes, err := endpoint.cache.FindStorageNodes(ctx, request)
 	if err != nil {
@@ -273,6 +279,11 @@ func (endpoint *Endpoint) CommitSegment(ctx context.Context, req *pb.SegmentComm
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
+	if err := endpoint.cache.UpdateUploadStats(ctx, endpoint.uploadStats(req)); err != nil {
+		endpoint.log.Sugar().Errorf("Could not record upload stats of %s: %v", path, err)
+		// but continue. the stats only affect which nodes are preferred for future uploads.
+	}
+
 	if req.Pointer.Type == pb.Pointer_INLINE {
 		bucketID := createBucketID(keyInfo.ProjectID, req.Bucket)
 		// TODO or maybe use pointer.SegmentSize ??
@@ -398,6 +409,14 @@ func (endpoint *Endpoint) DeleteSegment(ctx context.Context, req *pb.SegmentDele
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
+	if req.Segment == -1 && len(req.Path) == 0 {
+		// the bucket itself was deleted, a new bucket with the same name must not inherit its placement
+		err = endpoint.cache.DeleteBucketPlacement(ctx, keyInfo.ProjectID, req.Bucket)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, err.Error())
+		}
+	}
+
 	if pointer.Type == pb.Pointer_REMOTE && pointer.Remote != nil {
 		uplinkIdentity, err := identity.PeerIdentityFromContext(ctx)
 		if err != nil {
@@ -423,6 +442,48 @@ func (endpoint *Endpoint) DeleteSegment(ctx context.Context, req *pb.SegmentDele
 	return &pb.SegmentDeleteResponse{}, nil
 }
 
+// UpdateSegmentMetadata replaces the metadata of a segment
+func (endpoint *Endpoint) UpdateSegmentMetadata(ctx context.Context, req *pb.SegmentMetadataUpdateRequest) (resp *pb.SegmentMetadataUpdateResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keyInfo, err := endpoint.validateAuth(ctx, macaroon.Action{
+		Op:            macaroon.ActionWrite,
+		Bucket:        req.Bucket,
+		EncryptedPath: req.Path,
+		Time:          time.Now(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, err.Error())
+	}
+
+	err = endpoint.validateBucket(req.Bucket)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	if req.CreationDate == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "creation date is required")
+	}
+
+	path, err := CreatePath(keyInfo.ProjectID, req.Segment, req.Bucket, req.Path)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, err.Error())
+	}
+
+	pointer, err := endpoint.metainfo.UpdateMetadata(path, req.CreationDate, req.Metadata)
+	if err != nil {
+		switch {
+		case storage.ErrKeyNotFound.Has(err):
+			return nil, status.Errorf(codes.NotFound, err.Error())
+		case storage.ErrValueChanged.Has(err):
+			return nil, status.Errorf(codes.FailedPrecondition, "segment was modified")
+		}
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &pb.SegmentMetadataUpdateResponse{Pointer: pointer}, nil
+}
+
 // ListSegments returns all Path keys in the Pointers bucket
 func (endpoint *Endpoint) ListSegments(ctx context.Context, req *pb.ListSegmentsRequest) (resp *pb.ListSegmentsResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -549,6 +610,39 @@ func (endpoint *Endpoint) validateCommit(req *pb.SegmentCommitRequest) error {
 	return nil
 }
 
+// uploadStats returns the upload stats of req about nodes the satellite issued
+// an order limit for, at most one per node
+func (endpoint *Endpoint) uploadStats(req *pb.SegmentCommitRequest) []*pb.PieceUploadStats {
+	if req.Pointer.Type != pb.Pointer_REMOTE || len(req.UploadStats) == 0 {
+		return nil
+	}
+
+	limited := make(map[storj.NodeID]bool)
+	for _, limit := range req.OriginalLimits {
+		if limit == nil || endpoint.orders.VerifyOrderLimitSignature(limit) != nil {
+			continue
+		}
+		limited[limit.StorageNodeId] = true
+	}
+
+	var stats []*pb.PieceUploadStats
+	for _, stat := range req.UploadStats {
+		if stat == nil || !limited[stat.NodeId] {
+			continue
+		}
+		if _, known := pb.PieceUploadStats_Status_name[int32(stat.Status)]; !known || stat.Status == pb.PieceUploadStats_INVALID {
+			continue
+		}
+		if stat.PieceSize < 0 || stat.DurationMs < 0 {
+			continue
+		}
+		// ignore duplicate reports
+		delete(limited, stat.NodeId)
+		stats = append(stats, stat)
+	}
+	return stats
+}
+
 func (endpoint *Endpoint) validatePointer(pointer *pb.Pointer) error {
 	if pointer == nil {
 		return Error.New("no pointer specified")
@@ -5,23 +5,31 @@ package metainfo_test
 
 import (
 	"context"
+	"crypto/rand"
 	"sort"
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zeebo/errs"
+	"go.uber.org/zap"
 
+	"storj.io/storj/internal/memory"
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testplanet"
 	"storj.io/storj/pkg/macaroon"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/console"
+	"storj.io/storj/storage"
+	uplinkmetainfo "storj.io/storj/uplink/metainfo"
 )
 
 // mockAPIKeys is mock for api keys store of pointerdb
@@ -52,7 +60,7 @@ func TestInvalidAPIKey(t *testing.T) {
 		_, _, err = client.CreateSegment(ctx, "hello", "world", 1, &pb.RedundancyScheme{}, 123, time.Now())
 		assertUnauthenticated(t, err, false)
 
-		_, err = client.CommitSegment(ctx, "testbucket", "testpath", 0, &pb.Pointer{}, nil)
+		_, err = client.CommitSegment(ctx, "testbucket", "testpath", 0, &pb.Pointer{}, nil, nil)
 		assertUnauthenticated(t, err, false)
 
 		_, err = client.SegmentInfo(ctx, "testbucket", "testpath", 0)
@@ -160,7 +168,7 @@ func TestRestrictedAPIKey(t *testing.T) {
 		_, _, err = client.CreateSegment(ctx, "testbucket", "testpath", 1, &pb.RedundancyScheme{}, 123, time.Now())
 		assertUnauthenticated(t, err, test.CreateSegmentAllowed)
 
-		_, err = client.CommitSegment(ctx, "testbucket", "testpath", 0, &pb.Pointer{}, nil)
+		_, err = client.CommitSegment(ctx, "testbucket", "testpath", 0, &pb.Pointer{}, nil, nil)
 		assertUnauthenticated(t, err, test.CommitSegmentAllowed)
 
 		_, err = client.SegmentInfo(ctx, "testbucket", "testpath", 0)
@@ -281,12 +289,12 @@ func TestCommitSegment(t *testing.T) {
 
 		{
 			// error if pointer is nil
-			_, err = metainfo.CommitSegment(ctx, "bucket", "path", -1, nil, []*pb.OrderLimit2{})
+			_, err = metainfo.CommitSegment(ctx, "bucket", "path", -1, nil, []*pb.OrderLimit2{}, nil)
 			require.Error(t, err)
 		}
 		{
 			// error if bucket contains slash
-			_, err = metainfo.CommitSegment(ctx, "bucket/storj", "path", -1, &pb.Pointer{}, []*pb.OrderLimit2{})
+			_, err = metainfo.CommitSegment(ctx, "bucket/storj", "path", -1, &pb.Pointer{}, []*pb.OrderLimit2{}, nil)
 			require.Error(t, err)
 		}
 		{
@@ -323,9 +331,117 @@ func TestCommitSegment(t *testing.T) {
 			for i, addresedLimit := range addresedLimits {
 				limits[i] = addresedLimit.Limit
 			}
-			_, err = metainfo.CommitSegment(ctx, "bucket", "path", -1, pointer, limits)
+			_, err = metainfo.CommitSegment(ctx, "bucket", "path", -1, pointer, limits, nil)
 			require.Error(t, err)
 			require.Contains(t, err.Error(), "Number of valid pieces is less than or equal to the repair threshold")
 		}
 	})
 }
+
+func TestCommitSegmentUploadStats(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 6, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		data := make([]byte, 50*memory.KiB)
+		_, err := rand.Read(data)
+		require.NoError(t, err)
+
+		err = planet.Uplinks[0].Upload(ctx, planet.Satellites[0], "testbucket", "test/path", data)
+		require.NoError(t, err)
+
+		uploads := int64(0)
+		for _, storageNode := range planet.StorageNodes {
+			stats, err := planet.Satellites[0].Overlay.Service.UploadStats(ctx, storageNode.ID())
+			require.NoError(t, err)
+			uploads += stats.Count
+
+			assert.True(t, stats.SuccessRatio+stats.LongTailRatio <= 1)
+		}
+		assert.NotZero(t, uploads)
+	})
+}
+
+func TestUpdateSegmentMetadata(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 6, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		err := planet.Uplinks[0].Upload(ctx, planet.Satellites[0], "testbucket", "test/path", []byte("data"))
+		require.NoError(t, err)
+
+		apiKey := planet.Uplinks[0].APIKey[planet.Satellites[0].ID()]
+		metainfo, err := planet.Uplinks[0].DialMetainfo(ctx, planet.Satellites[0], apiKey)
+		require.NoError(t, err)
+
+		items, _, err := metainfo.ListSegments(ctx, "testbucket", "", "", "", true, 1, meta.All)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+
+		path := items[0].Path
+		pointer, err := metainfo.SegmentInfo(ctx, "testbucket", path, -1)
+		require.NoError(t, err)
+		created, err := ptypes.Timestamp(pointer.GetCreationDate())
+		require.NoError(t, err)
+
+		updated, err := metainfo.UpdateSegmentMetadata(ctx, "testbucket", path, -1, []byte("metadata"), created)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("metadata"), updated.Metadata)
+		assert.Equal(t, pointer.InlineSegment, updated.InlineSegment)
+
+		info, err := metainfo.SegmentInfo(ctx, "testbucket", path, -1)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("metadata"), info.Metadata)
+
+		// the segment was changed since it was created at created
+		_, err = metainfo.UpdateSegmentMetadata(ctx, "testbucket", path, -1, []byte("stale"), created)
+		assert.True(t, uplinkmetainfo.ErrSegmentModified.Has(err))
+
+		_, err = metainfo.UpdateSegmentMetadata(ctx, "testbucket", "missing", -1, []byte("metadata"), created)
+		assert.True(t, storage.ErrKeyNotFound.Has(err))
+
+		// the creation date guards against overwriting a newer segment
+		_, err = metainfo.UpdateSegmentMetadata(ctx, "testbucket", path, -1, []byte("metadata"), time.Time{})
+		require.Error(t, err)
+	})
+}
+
+func TestDeleteBucketPlacement(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 6, UplinkCount: 1,
+		Reconfigure: testplanet.Reconfigure{
+			Satellite: func(log *zap.Logger, index int, config *satellite.Config) {
+				config.Overlay.Placement.Policies = "eu:DE"
+			},
+		},
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		sat := planet.Satellites[0]
+
+		err := planet.Uplinks[0].Upload(ctx, sat, "testbucket", "test/path", []byte("data"))
+		require.NoError(t, err)
+
+		projects, err := sat.DB.Console().Projects().GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, projects, 1)
+		projectID := projects[0].ID
+
+		err = sat.Overlay.Service.SetBucketPlacement(ctx, projectID, []byte("testbucket"), "eu")
+		require.NoError(t, err)
+
+		config := planet.Uplinks[0].GetConfig(sat)
+		metainfo, _, err := config.GetMetainfo(ctx, planet.Uplinks[0].Identity)
+		require.NoError(t, err)
+
+		// deleting an object keeps the placement of its bucket
+		require.NoError(t, metainfo.DeleteObject(ctx, "testbucket", "test/path"))
+
+		placement, err := sat.Overlay.Service.BucketPlacement(ctx, projectID, []byte("testbucket"))
+		require.NoError(t, err)
+		assert.Equal(t, "eu", placement)
+
+		// deleting the bucket deletes its placement
+		require.NoError(t, metainfo.DeleteBucket(ctx, "testbucket"))
+
+		placement, err = sat.Overlay.Service.BucketPlacement(ctx, projectID, []byte("testbucket"))
+		require.NoError(t, err)
+		assert.Equal(t, "", placement)
+	})
+}
@@ -6,6 +6,7 @@ package metainfo
 import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
@@ -45,8 +46,46 @@ func (s *Service) Put(path string, pointer *pb.Pointer) (err error) {
 	return nil
 }
 
+// CompareAndSwap replaces the pointer at path with pointer, if the pointer
+// stored at path is still oldPointerBytes. It fails with
+// storage.ErrValueChanged when the pointer changed and with
+// storage.ErrKeyNotFound when it was deleted.
+func (s *Service) CompareAndSwap(path string, oldPointerBytes []byte, pointer *pb.Pointer) (err error) {
+	pointerBytes, err := proto.Marshal(pointer)
+	if err != nil {
+		return err
+	}
+
+	return s.DB.CompareAndSwap([]byte(path), oldPointerBytes, pointerBytes)
+}
+
 // Get gets pointer from db
 func (s *Service) Get(path string) (pointer *pb.Pointer, err error) {
+	_, pointer, err = s.GetWithBytes(path)
+	return pointer, err
+}
+
+// GetWithBytes gets the pointer from db together with its encoded form, which
+// can be passed to CompareAndSwap
+func (s *Service) GetWithBytes(path string) (pointerBytes []byte, pointer *pb.Pointer, err error) {
+	pointerBytes, err = s.DB.Get([]byte(path))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pointer = &pb.Pointer{}
+	err = proto.Unmarshal(pointerBytes, pointer)
+	if err != nil {
+		return nil, nil, errs.New("error unmarshaling pointer: %v", err)
+	}
+
+	return pointerBytes, pointer, nil
+}
+
+// UpdateMetadata replaces the metadata of the pointer at path, if the pointer
+// was created at creationDate. It fails with storage.ErrValueChanged when the
+// pointer was created at another time or changed during the update.
+func (s *Service) UpdateMetadata(path string, creationDate *timestamp.Timestamp, metadata []byte) (pointer *pb.Pointer, err error) {
 	pointerBytes, err := s.DB.Get([]byte(path))
 	if err != nil {
 		return nil, err
@@ -58,6 +97,26 @@ func (s *Service) Get(path string) (pointer *pb.Pointer, err error) {
 		return nil, errs.New("error unmarshaling pointer: %v", err)
 	}
 
+	// the metadata may describe the content of the segment, so it must not
+	// be applied to a segment that was uploaded in the meantime
+	if !proto.Equal(pointer.GetCreationDate(), creationDate) {
+		return nil, storage.ErrValueChanged.New("%s", path)
+	}
+
+	pointer.Metadata = metadata
+	pointer.CreationDate = ptypes.TimestampNow()
+
+	updatedBytes, err := proto.Marshal(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	// fails when the pointer was replaced since it was read
+	err = s.DB.CompareAndSwap([]byte(path), pointerBytes, updatedBytes)
+	if err != nil {
+		return nil, err
+	}
+
 	return pointer, nil
 }
 
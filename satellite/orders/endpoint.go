@@ -18,6 +18,7 @@ import (
 	"storj.io/storj/pkg/auth/signing"
 	"storj.io/storj/pkg/certdb"
 	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
 )
@@ -47,6 +48,26 @@ type DB interface {
 	GetBucketBandwidth(ctx context.Context, bucketID []byte, from, to time.Time) (int64, error)
 	// GetStorageNodeBandwidth gets total storage node bandwidth from period of time
 	GetStorageNodeBandwidth(ctx context.Context, nodeID storj.NodeID, from, to time.Time) (int64, error)
+
+	// ProcessOrders settles a batch of verified orders from a storage node within a single transaction
+	ProcessOrders(ctx context.Context, storageNodeID storj.NodeID, requests []*ProcessOrderRequest, intervalStart time.Time) ([]*pb.SettlementResponse, error)
+
+	// RecordFraud adds rejected settlements to the fraud counters of a storage node and returns its total fraud count
+	RecordFraud(ctx context.Context, storageNodeID storj.NodeID, reasons map[pb.SettlementResponse_RejectReason]int64) (int64, error)
+	// GetFraud returns the fraud counters of a storage node by reject reason
+	GetFraud(ctx context.Context, storageNodeID storj.NodeID) (map[pb.SettlementResponse_RejectReason]int64, error)
+}
+
+// CorruptedPieces collects pieces that storage nodes reported as corrupted
+type CorruptedPieces interface {
+	// Add records pieces that failed an integrity check on the storage node
+	Add(nodeID storj.NodeID, pieceIDs []storj.PieceID)
+}
+
+// ProcessOrderRequest is an order and its order limit submitted for settlement
+type ProcessOrderRequest struct {
+	Order      *pb.Order2
+	OrderLimit *pb.OrderLimit2
 }
 
 var (
@@ -58,25 +79,41 @@ var (
 	mon = monkit.Package()
 )
 
+// Config is a configuration struct for orders settlement
+type Config struct {
+	SettlementBatchSize int   `help:"how many orders are settled within a single database transaction" default:"250"`
+	FraudThreshold      int64 `help:"number of fraudulent settlements after which every further fraudulent batch counts as a failed audit" default:"10"`
+}
+
 // Endpoint for orders receiving
 type Endpoint struct {
 	log             *zap.Logger
 	satelliteSignee signing.Signee
 	DB              DB
 	certdb          certdb.DB
+	overlay         *overlay.Cache
+	corrupted       CorruptedPieces
+	config          Config
 }
 
 // NewEndpoint new orders receiving endpoint
-func NewEndpoint(log *zap.Logger, satelliteSignee signing.Signee, db DB, certdb certdb.DB) *Endpoint {
+func NewEndpoint(log *zap.Logger, satelliteSignee signing.Signee, db DB, certdb certdb.DB, overlay *overlay.Cache, corrupted CorruptedPieces, config Config) *Endpoint {
 	return &Endpoint{
 		log:             log,
 		satelliteSignee: satelliteSignee,
 		DB:              db,
 		certdb:          certdb,
+		overlay:         overlay,
+		corrupted:       corrupted,
+		config:          config,
 	}
 }
 
 // Settlement receives and handles orders.
+//
+// Orders are settled in batches: the endpoint keeps receiving until it has
+// SettlementBatchSize orders or the storage node closes its side of the stream,
+// so storage nodes must not wait for a response before sending the next order.
 func (endpoint *Endpoint) Settlement(stream pb.Orders_SettlementServer) (err error) {
 	ctx := stream.Context()
 	defer mon.Task()(&ctx)(&err)
@@ -86,132 +123,228 @@ func (endpoint *Endpoint) Settlement(stream pb.Orders_SettlementServer) (err err
 		return status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	formatError := func(err error) error {
-		if err == io.EOF {
-			return nil
-		}
-		return status.Error(codes.Unknown, err.Error())
-	}
-
 	log := endpoint.log.Named(peer.ID.String())
 	log.Debug("Settlement")
-	for {
-		request, err := stream.Recv()
-		if err != nil {
-			return formatError(err)
-		}
 
-		if request == nil {
-			return status.Error(codes.InvalidArgument, "request missing")
-		}
-		if request.Limit == nil {
-			return status.Error(codes.InvalidArgument, "order limit missing")
-		}
-		if request.Order == nil {
-			return status.Error(codes.InvalidArgument, "order missing")
-		}
+	batchSize := endpoint.config.SettlementBatchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
 
-		orderLimit := request.Limit
-		order := request.Order
+	// serials seen within this stream, so duplicates don't depend on database state
+	seen := make(map[storj.SerialNumber]struct{})
 
-		if orderLimit.StorageNodeId != peer.ID {
-			return status.Error(codes.Unauthenticated, "only specified storage node can settle order")
-		}
+	for {
+		var requests []*ProcessOrderRequest
+		var rejected []*pb.SettlementResponse
 
-		orderExpiration, err := ptypes.Timestamp(orderLimit.OrderExpiration)
-		if err != nil {
-			return status.Errorf(codes.InvalidArgument, err.Error())
-		}
+		done := false
+		for len(requests)+len(rejected) < batchSize {
+			request, err := stream.Recv()
+			if err == io.EOF {
+				done = true
+				break
+			}
+			if err != nil {
+				return status.Error(codes.Unknown, err.Error())
+			}
 
-		var uplinkSignee signing.Signee
+			if request == nil {
+				return status.Error(codes.InvalidArgument, "request missing")
+			}
+			if request.Limit == nil {
+				return status.Error(codes.InvalidArgument, "order limit missing")
+			}
+			if request.Order == nil {
+				return status.Error(codes.InvalidArgument, "order missing")
+			}
 
-		// who asked for this order: uplink (get/put/del) or satellite (get_repair/put_repair/audit)
-		if endpoint.satelliteSignee.ID() == orderLimit.UplinkId {
-			uplinkSignee = endpoint.satelliteSignee
-		} else {
-			uplinkPubKey, err := endpoint.certdb.GetPublicKey(ctx, orderLimit.UplinkId)
+			reason, err := endpoint.verifyOrder(ctx, log, peer.ID, request.Limit, request.Order)
 			if err != nil {
-				log.Warn("unable to find uplink public key", zap.Error(err))
-				return status.Errorf(codes.Internal, "unable to find uplink public key")
+				return err
 			}
-			uplinkSignee = &signing.PublicKey{
-				Self: orderLimit.UplinkId,
-				Key:  uplinkPubKey,
+			if reason != pb.SettlementResponse_NONE {
+				rejected = append(rejected, &pb.SettlementResponse{
+					SerialNumber: request.Limit.SerialNumber,
+					Status:       pb.SettlementResponse_REJECTED,
+					Reason:       reason,
+				})
+				continue
 			}
-		}
 
-		rejectErr := func() error {
-			if err := signing.VerifyOrderLimitSignature(endpoint.satelliteSignee, orderLimit); err != nil {
-				return Error.New("unable to verify order limit")
+			if _, ok := seen[request.Limit.SerialNumber]; ok {
+				rejected = append(rejected, &pb.SettlementResponse{
+					SerialNumber: request.Limit.SerialNumber,
+					Status:       pb.SettlementResponse_REJECTED,
+					Reason:       pb.SettlementResponse_DUPLICATE_SERIAL,
+				})
+				continue
 			}
+			seen[request.Limit.SerialNumber] = struct{}{}
 
-			if err := signing.VerifyOrderSignature(uplinkSignee, order); err != nil {
-				return Error.New("unable to verify order")
-			}
+			requests = append(requests, &ProcessOrderRequest{
+				Order:      request.Order,
+				OrderLimit: request.Limit,
+			})
+		}
 
-			// TODO should this reject or just error ??
-			if orderLimit.SerialNumber != order.SerialNumber {
-				return Error.New("invalid serial number")
-			}
+		if err := endpoint.settleBatch(ctx, log, stream, peer.ID, requests, rejected); err != nil {
+			return err
+		}
 
-			if orderExpiration.Before(time.Now()) {
-				return Error.New("order limit expired")
-			}
+		if done {
 			return nil
-		}()
-		if rejectErr != err {
-			log.Debug("order limit/order verification failed", zap.String("serial", orderLimit.SerialNumber.String()), zap.Error(err))
-			err := stream.Send(&pb.SettlementResponse{
-				SerialNumber: orderLimit.SerialNumber,
-				Status:       pb.SettlementResponse_REJECTED,
-			})
-			if err != nil {
-				return formatError(err)
-			}
 		}
+	}
+}
+
+// ReportCorrupted receives pieces that failed an integrity check on the storage node.
+// The checker counts every reported piece it removes from a segment as a failed
+// audit of the node, so reporting pieces doesn't avoid the cost of losing them.
+func (endpoint *Endpoint) ReportCorrupted(ctx context.Context, req *pb.CorruptedPiecesReport) (_ *pb.CorruptedPiecesReportResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	endpoint.log.Info("corrupted pieces reported", zap.Stringer("node id", peer.ID), zap.Int("count", len(req.PieceIds)))
+	endpoint.corrupted.Add(peer.ID, req.PieceIds)
 
-		bucketID, err := endpoint.DB.UseSerialNumber(ctx, orderLimit.SerialNumber, orderLimit.StorageNodeId)
+	return &pb.CorruptedPiecesReportResponse{}, nil
+}
+
+// verifyOrder checks a single order and its order limit, it returns the reason for rejecting it
+// or pb.SettlementResponse_NONE when the order can be settled.
+func (endpoint *Endpoint) verifyOrder(ctx context.Context, log *zap.Logger, storageNodeID storj.NodeID, orderLimit *pb.OrderLimit2, order *pb.Order2) (pb.SettlementResponse_RejectReason, error) {
+	if orderLimit.StorageNodeId != storageNodeID {
+		return pb.SettlementResponse_UNAUTHORIZED, nil
+	}
+
+	if err := signing.VerifyOrderLimitSignature(endpoint.satelliteSignee, orderLimit); err != nil {
+		return pb.SettlementResponse_INVALID_SIGNATURE, nil
+	}
+
+	var uplinkSignee signing.Signee
+
+	// who asked for this order: uplink (get/put/del) or satellite (get_repair/put_repair/audit)
+	if endpoint.satelliteSignee.ID() == orderLimit.UplinkId {
+		uplinkSignee = endpoint.satelliteSignee
+	} else {
+		uplinkPubKey, err := endpoint.certdb.GetPublicKey(ctx, orderLimit.UplinkId)
 		if err != nil {
-			log.Warn("unable to use serial number", zap.Error(err))
-			if ErrUsingSerialNumber.Has(err) {
-				err := stream.Send(&pb.SettlementResponse{
-					SerialNumber: orderLimit.SerialNumber,
-					Status:       pb.SettlementResponse_REJECTED,
-				})
-				if err != nil {
-					return formatError(err)
-				}
-			} else {
-				return err
-			}
-			continue
+			log.Warn("unable to find uplink public key", zap.Error(err))
+			return pb.SettlementResponse_NONE, status.Errorf(codes.Internal, "unable to find uplink public key")
+		}
+		uplinkSignee = &signing.PublicKey{
+			Self: orderLimit.UplinkId,
+			Key:  uplinkPubKey,
 		}
+	}
+
+	if err := signing.VerifyOrderSignature(uplinkSignee, order); err != nil {
+		return pb.SettlementResponse_INVALID_SIGNATURE, nil
+	}
+
+	if orderLimit.SerialNumber != order.SerialNumber {
+		return pb.SettlementResponse_SERIAL_MISMATCH, nil
+	}
+
+	orderExpiration, err := ptypes.Timestamp(orderLimit.OrderExpiration)
+	if err != nil || orderExpiration.Before(time.Now()) {
+		return pb.SettlementResponse_EXPIRED, nil
+	}
+
+	if order.Amount < 0 || order.Amount > orderLimit.Limit {
+		return pb.SettlementResponse_AMOUNT_EXCEEDS_LIMIT, nil
+	}
+
+	return pb.SettlementResponse_NONE, nil
+}
+
+// settleBatch settles the verified orders, records fraudulent submissions and sends all responses.
+func (endpoint *Endpoint) settleBatch(ctx context.Context, log *zap.Logger, stream pb.Orders_SettlementServer, storageNodeID storj.NodeID, requests []*ProcessOrderRequest, rejected []*pb.SettlementResponse) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	responses := rejected
+	if len(requests) > 0 {
 		now := time.Now().UTC()
 		intervalStart := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
 
-		if err := endpoint.DB.UpdateBucketBandwidthSettle(ctx, bucketID, orderLimit.Action, order.Amount, intervalStart); err != nil {
-			if err := endpoint.DB.UnuseSerialNumber(ctx, orderLimit.SerialNumber, orderLimit.StorageNodeId); err != nil {
-				log.Error("unable to unuse serial number", zap.Error(err))
-			}
-			return err
+		settled, err := endpoint.DB.ProcessOrders(ctx, storageNodeID, requests, intervalStart)
+		if err != nil {
+			log.Error("unable to settle orders", zap.Int("count", len(requests)), zap.Error(err))
+			return status.Error(codes.Internal, "unable to settle orders")
 		}
+		responses = append(responses, settled...)
+	}
 
-		if err := endpoint.DB.UpdateStoragenodeBandwidthSettle(ctx, orderLimit.StorageNodeId, orderLimit.Action, order.Amount, intervalStart); err != nil {
-			if err := endpoint.DB.UnuseSerialNumber(ctx, orderLimit.SerialNumber, orderLimit.StorageNodeId); err != nil {
-				log.Error("unable to unuse serial number", zap.Error(err))
-			}
-			if err := endpoint.DB.UpdateBucketBandwidthSettle(ctx, bucketID, orderLimit.Action, -order.Amount, intervalStart); err != nil {
-				log.Error("unable to rollback bucket bandwidth", zap.Error(err))
-			}
-			return err
+	fraud := make(map[pb.SettlementResponse_RejectReason]int64)
+	for _, response := range responses {
+		if response.Status != pb.SettlementResponse_REJECTED {
+			continue
+		}
+		log.Debug("order rejected", zap.Stringer("serial", response.SerialNumber), zap.Stringer("reason", response.Reason))
+		if isFraud(response.Reason) {
+			fraud[response.Reason]++
 		}
+	}
 
-		err = stream.Send(&pb.SettlementResponse{
-			SerialNumber: orderLimit.SerialNumber,
-			Status:       pb.SettlementResponse_ACCEPTED,
-		})
-		if err != nil {
-			return formatError(err)
+	if len(fraud) > 0 {
+		endpoint.recordFraud(ctx, log, storageNodeID, fraud)
+	}
+
+	for _, response := range responses {
+		if err := stream.Send(response); err != nil {
+			return status.Error(codes.Unknown, err.Error())
 		}
 	}
+	return nil
+}
+
+// recordFraud stores fraudulent submissions of a storage node and, once the node keeps
+// submitting them, counts them against its audit reputation.
+func (endpoint *Endpoint) recordFraud(ctx context.Context, log *zap.Logger, storageNodeID storj.NodeID, fraud map[pb.SettlementResponse_RejectReason]int64) {
+	var count int64
+	for _, n := range fraud {
+		count += n
+	}
+	log.Warn("fraudulent orders submitted", zap.Int64("count", count))
+	mon.Meter("settlement_fraud").Mark64(count)
+
+	total, err := endpoint.DB.RecordFraud(ctx, storageNodeID, fraud)
+	if err != nil {
+		log.Error("unable to record fraud", zap.Error(err))
+		return
+	}
+
+	if endpoint.overlay == nil || endpoint.config.FraudThreshold <= 0 || total < endpoint.config.FraudThreshold {
+		return
+	}
+
+	_, err = endpoint.overlay.UpdateStats(ctx, &overlay.UpdateRequest{
+		NodeID:       storageNodeID,
+		AuditSuccess: false,
+		IsUp:         true,
+	})
+	if err != nil {
+		log.Error("unable to update reputation", zap.Error(err))
+	}
+}
+
+// isFraud returns whether the order doesn't match its signatures or its order limit.
+//
+// Expired orders and duplicate serials can be caused by a slow or retrying storage node,
+// so they are rejected without counting against the node.
+func isFraud(reason pb.SettlementResponse_RejectReason) bool {
+	switch reason {
+	case pb.SettlementResponse_INVALID_SIGNATURE,
+		pb.SettlementResponse_SERIAL_MISMATCH,
+		pb.SettlementResponse_AMOUNT_EXCEEDS_LIMIT,
+		pb.SettlementResponse_UNAUTHORIZED:
+		return true
+	default:
+		return false
+	}
 }
@@ -5,6 +5,7 @@ package orders_test
 
 import (
 	"crypto/rand"
+	"io"
 	"testing"
 	"time"
 
@@ -13,7 +14,10 @@ import (
 	"storj.io/storj/internal/memory"
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storagenode"
+	"storj.io/storj/storagenode/orders"
 	"storj.io/storj/uplink"
 )
 
@@ -169,6 +173,86 @@ func TestUploadDownloadBandwidth(t *testing.T) {
 	})
 }
 
+func TestSettlementFraud(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 6, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Audit.Service.Loop.Stop()
+		for _, storageNode := range planet.StorageNodes {
+			storageNode.Storage2.Sender.Loop.Pause()
+		}
+
+		expectedData := make([]byte, 50*memory.KiB)
+		_, err := rand.Read(expectedData)
+		require.NoError(t, err)
+
+		redundancy := noLongTailRedundancy(planet)
+		err = planet.Uplinks[0].UploadWithConfig(ctx, satellite, &redundancy, "testbucket", "test/path", expectedData)
+		require.NoError(t, err)
+
+		var storageNode *storagenode.Peer
+		var info *orders.Info
+		for _, node := range planet.StorageNodes {
+			infos, err := node.DB.Orders().ListUnsent(ctx, 1)
+			require.NoError(t, err)
+			if len(infos) > 0 {
+				storageNode, info = node, infos[0]
+				break
+			}
+		}
+		require.NotNil(t, info)
+
+		tampered := *info.Order
+		tampered.Amount = info.Limit.Limit + 1
+
+		local := satellite.Local().Node
+		conn, err := storageNode.Transport.DialNode(ctx, &local)
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+
+		client, err := pb.NewOrdersClient(conn).Settlement(ctx)
+		require.NoError(t, err)
+
+		for _, order := range []*pb.Order2{
+			info.Order, // accepted
+			info.Order, // resubmitted, e.g. after a lost response
+			&tampered,  // changed by the storage node
+		} {
+			err = client.Send(&pb.SettlementRequest{Limit: info.Limit, Order: order})
+			require.NoError(t, err)
+		}
+		require.NoError(t, client.CloseSend())
+
+		reasons := make(map[pb.SettlementResponse_RejectReason]int)
+		accepted := 0
+		for {
+			response, err := client.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if response.Status == pb.SettlementResponse_ACCEPTED {
+				accepted++
+				continue
+			}
+			reasons[response.Reason]++
+		}
+		require.Equal(t, 1, accepted)
+		require.Equal(t, map[pb.SettlementResponse_RejectReason]int{
+			pb.SettlementResponse_DUPLICATE_SERIAL:  1,
+			pb.SettlementResponse_INVALID_SIGNATURE: 1,
+		}, reasons)
+
+		// only the tampered order counts as fraud
+		fraud, err := satellite.DB.Orders().GetFraud(ctx, storageNode.ID())
+		require.NoError(t, err)
+		require.Equal(t, map[pb.SettlementResponse_RejectReason]int64{
+			pb.SettlementResponse_INVALID_SIGNATURE: 1,
+		}, fraud)
+	})
+}
+
 func noLongTailRedundancy(planet *testplanet.Planet) uplink.RSConfig {
 	redundancy := planet.Uplinks[0].GetConfig(planet.Satellites[0]).RS
 	redundancy.SuccessThreshold = redundancy.MaxThreshold
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/satellite"
 	"storj.io/storj/satellite/orders"
@@ -50,3 +51,102 @@ func TestSerialNumbers(t *testing.T) {
 		require.Empty(t, bucketID)
 	})
 }
+
+func TestProcessOrders(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		ordersDB := db.Orders()
+		storageNodeID := storj.NodeID{1}
+		bucketID := []byte("projectID/bucketName")
+		now := time.Now().UTC()
+
+		for _, serialNumber := range []storj.SerialNumber{{1}, {2}} {
+			err := ordersDB.CreateSerialInfo(ctx, serialNumber, bucketID, now.Add(time.Hour))
+			require.NoError(t, err)
+		}
+
+		request := func(serialNumber storj.SerialNumber, amount int64) *orders.ProcessOrderRequest {
+			return &orders.ProcessOrderRequest{
+				Order: &pb.Order2{SerialNumber: serialNumber, Amount: amount},
+				OrderLimit: &pb.OrderLimit2{
+					SerialNumber: serialNumber,
+					Action:       pb.PieceAction_GET,
+					Limit:        amount,
+				},
+			}
+		}
+
+		responses, err := ordersDB.ProcessOrders(ctx, storageNodeID, []*orders.ProcessOrderRequest{
+			request(storj.SerialNumber{1}, 100),
+			request(storj.SerialNumber{2}, 200),
+			request(storj.SerialNumber{99}, 300),
+		}, now)
+		require.NoError(t, err)
+		require.Len(t, responses, 3)
+
+		require.Equal(t, pb.SettlementResponse_ACCEPTED, responses[0].Status)
+		require.Equal(t, pb.SettlementResponse_ACCEPTED, responses[1].Status)
+		require.Equal(t, pb.SettlementResponse_REJECTED, responses[2].Status)
+		require.Equal(t, pb.SettlementResponse_UNKNOWN_SERIAL, responses[2].Reason)
+
+		// settling the same serial number again is a duplicate
+		responses, err = ordersDB.ProcessOrders(ctx, storageNodeID, []*orders.ProcessOrderRequest{
+			request(storj.SerialNumber{1}, 100),
+		}, now)
+		require.NoError(t, err)
+		require.Len(t, responses, 1)
+		require.Equal(t, pb.SettlementResponse_REJECTED, responses[0].Status)
+		require.Equal(t, pb.SettlementResponse_DUPLICATE_SERIAL, responses[0].Reason)
+
+		// only accepted orders are settled
+		bandwidth, err := ordersDB.GetStorageNodeBandwidth(ctx, storageNodeID, now.Add(-time.Hour), now)
+		require.NoError(t, err)
+		require.Equal(t, int64(300), bandwidth)
+
+		bandwidth, err = ordersDB.GetBucketBandwidth(ctx, bucketID, now.Add(-time.Hour), now)
+		require.NoError(t, err)
+		require.Equal(t, int64(300), bandwidth)
+	})
+}
+
+func TestRecordFraud(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		ordersDB := db.Orders()
+
+		fraud, err := ordersDB.GetFraud(ctx, storj.NodeID{1})
+		require.NoError(t, err)
+		require.Empty(t, fraud)
+
+		total, err := ordersDB.RecordFraud(ctx, storj.NodeID{1}, map[pb.SettlementResponse_RejectReason]int64{
+			pb.SettlementResponse_DUPLICATE_SERIAL: 2,
+			pb.SettlementResponse_EXPIRED:          1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(3), total)
+
+		total, err = ordersDB.RecordFraud(ctx, storj.NodeID{1}, map[pb.SettlementResponse_RejectReason]int64{
+			pb.SettlementResponse_DUPLICATE_SERIAL: 4,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(7), total)
+
+		// other storage nodes are tracked separately
+		total, err = ordersDB.RecordFraud(ctx, storj.NodeID{2}, map[pb.SettlementResponse_RejectReason]int64{
+			pb.SettlementResponse_AMOUNT_EXCEEDS_LIMIT: 1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), total)
+
+		fraud, err = ordersDB.GetFraud(ctx, storj.NodeID{1})
+		require.NoError(t, err)
+		require.Equal(t, map[pb.SettlementResponse_RejectReason]int64{
+			pb.SettlementResponse_DUPLICATE_SERIAL: 6,
+			pb.SettlementResponse_EXPIRED:          1,
+		}, fraud)
+	})
+}
@@ -524,6 +524,12 @@ func (service *Service) CreateGetRepairOrderLimits(ctx context.Context, repairer
 		return nil, errs.Combine(err, combinedErrs)
 	}
 
+	// orders are signed by the repairer, which is a storage node when it repairs on behalf of the satellite
+	err = service.certdb.SavePublicKey(ctx, repairer.ID, repairer.Leaf.PublicKey)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
 	err = service.saveSerial(ctx, serialNumber, bucketID, orderExpirationTime)
 	if err != nil {
 		return nil, Error.Wrap(err)
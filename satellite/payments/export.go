@@ -0,0 +1,73 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payments
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// csvHeaders are the column names of exported CSV statements
+var csvHeaders = []string{
+	"nodeID",
+	"period",
+	"nodeCreationDate",
+	"walletAddress",
+	"byte-hours:AtRest",
+	"bytes:BWGet",
+	"bytes:BWRepair-GET",
+	"bytes:BWAudit",
+	"cents:Storage",
+	"cents:Egress",
+	"cents:Repair",
+	"cents:Audit",
+	"cents:Gross",
+	"percent:Held",
+	"cents:Held",
+	"cents:Payout",
+}
+
+// WriteCSV writes the statements as CSV with a header row
+func WriteCSV(output io.Writer, statements ...*Statement) error {
+	w := csv.NewWriter(output)
+	if err := w.Write(csvHeaders); err != nil {
+		return Error.Wrap(err)
+	}
+
+	for _, statement := range statements {
+		record := []string{
+			statement.NodeID.String(),
+			statement.Period.Format("2006-01"),
+			statement.NodeCreatedAt.Format("2006-01-02"),
+			statement.Wallet,
+			strconv.FormatFloat(statement.AtRestTotal, 'f', 5, 64),
+			strconv.FormatInt(statement.GetTotal, 10),
+			strconv.FormatInt(statement.GetRepairTotal, 10),
+			strconv.FormatInt(statement.GetAuditTotal, 10),
+			strconv.FormatInt(statement.StorageAmount, 10),
+			strconv.FormatInt(statement.EgressAmount, 10),
+			strconv.FormatInt(statement.RepairAmount, 10),
+			strconv.FormatInt(statement.AuditAmount, 10),
+			strconv.FormatInt(statement.GrossAmount(), 10),
+			strconv.Itoa(statement.HeldPercent),
+			strconv.FormatInt(statement.HeldAmount, 10),
+			strconv.FormatInt(statement.PayoutAmount, 10),
+		}
+		if err := w.Write(record); err != nil {
+			return Error.Wrap(err)
+		}
+	}
+
+	w.Flush()
+	return Error.Wrap(w.Error())
+}
+
+// WriteJSON writes a single statement as indented JSON
+func WriteJSON(output io.Writer, statement *Statement) error {
+	encoder := json.NewEncoder(output)
+	encoder.SetIndent("", "  ")
+	return Error.Wrap(encoder.Encode(statement))
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payments
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// Error is the default payments errs class
+var Error = errs.Class("payments error")
+
+// hoursPerMonth is the number of hours in a payout month, used to convert byte-hours to byte-months
+const hoursPerMonth = 720
+
+// terabyte is the number of bytes rates are specified for
+const terabyte = 1e12
+
+// Config contains the rates and held back percentages used for storage node payouts
+type Config struct {
+	StorageTBMonthRate int64  `help:"amount in cents paid for storing one terabyte for a month" default:"150"`
+	EgressTBRate       int64  `help:"amount in cents paid for one terabyte of egress" default:"2000"`
+	RepairTBRate       int64  `help:"amount in cents paid for one terabyte of repair egress" default:"1000"`
+	AuditTBRate        int64  `help:"amount in cents paid for one terabyte of audit egress" default:"1000"`
+	HeldPercentages    string `help:"comma separated percentages held back for each month of node age, the last one applies to all older nodes" default:"75,75,75,50,50,50,25,25,25,0"`
+}
+
+// HeldPercent returns the percentage held back from the payout of a node that is ageMonths old
+func (config Config) HeldPercent(ageMonths int) (int, error) {
+	var percentages []int
+	for _, value := range strings.Split(config.HeldPercentages, ",") {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		percent, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, Error.New("invalid held percentage %q", value)
+		}
+		if percent < 0 || percent > 100 {
+			return 0, Error.New("held percentage %d out of range", percent)
+		}
+		percentages = append(percentages, percent)
+	}
+
+	if len(percentages) == 0 {
+		return 0, nil
+	}
+	if ageMonths < 0 {
+		ageMonths = 0
+	}
+	if ageMonths >= len(percentages) {
+		return percentages[len(percentages)-1], nil
+	}
+	return percentages[ageMonths], nil
+}
+
+// Statement is the payment statement of a storage node for a single month, amounts are in cents
+type Statement struct {
+	NodeID        storj.NodeID `json:"nodeId"`
+	Period        time.Time    `json:"period"`
+	NodeCreatedAt time.Time    `json:"nodeCreatedAt"`
+	Wallet        string       `json:"wallet"`
+
+	AtRestTotal    float64 `json:"atRestByteHours"`
+	GetTotal       int64   `json:"getBytes"`
+	GetRepairTotal int64   `json:"getRepairBytes"`
+	GetAuditTotal  int64   `json:"getAuditBytes"`
+
+	StorageAmount int64 `json:"storageAmount"`
+	EgressAmount  int64 `json:"egressAmount"`
+	RepairAmount  int64 `json:"repairAmount"`
+	AuditAmount   int64 `json:"auditAmount"`
+
+	HeldPercent  int   `json:"heldPercent"`
+	HeldAmount   int64 `json:"heldAmount"`
+	PayoutAmount int64 `json:"payoutAmount"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// GrossAmount returns the total amount earned before anything is held back
+func (statement *Statement) GrossAmount() int64 {
+	return statement.StorageAmount + statement.EgressAmount + statement.RepairAmount + statement.AuditAmount
+}
+
+// DB stores payment statements
+type DB interface {
+	// SaveStatements replaces all statements of the period
+	SaveStatements(ctx context.Context, period time.Time, statements []*Statement) error
+	// GetStatements returns all statements of the period ordered by node id
+	GetStatements(ctx context.Context, period time.Time) ([]*Statement, error)
+	// GetStatement returns the statement of a single storage node for the period
+	GetStatement(ctx context.Context, nodeID storj.NodeID, period time.Time) (*Statement, error)
+}
+
+// PeriodStart returns the start of the payout month containing t
+func PeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// ageInMonths returns the number of whole calendar months between created and period
+func ageInMonths(created, period time.Time) int {
+	created = created.UTC()
+	return (period.Year()-created.Year())*12 + int(period.Month()-created.Month())
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payments_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/payments"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestHeldPercent(t *testing.T) {
+	config := payments.Config{HeldPercentages: "75, 75, 50, 0"}
+
+	for _, tt := range []struct {
+		age     int
+		percent int
+	}{
+		{-1, 75},
+		{0, 75},
+		{1, 75},
+		{2, 50},
+		{3, 0},
+		{24, 0},
+	} {
+		percent, err := config.HeldPercent(tt.age)
+		require.NoError(t, err)
+		assert.Equal(t, tt.percent, percent, "age %d", tt.age)
+	}
+
+	percent, err := payments.Config{}.HeldPercent(5)
+	require.NoError(t, err)
+	assert.Equal(t, 0, percent)
+
+	_, err = payments.Config{HeldPercentages: "75,x"}.HeldPercent(0)
+	assert.Error(t, err)
+
+	_, err = payments.Config{HeldPercentages: "150"}.HeldPercent(0)
+	assert.Error(t, err)
+}
+
+func TestGenerateStatements(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		nodeID := storj.NodeID{1, 2, 3}
+		require.NoError(t, db.OverlayCache().UpdateAddress(ctx, &pb.Node{Id: nodeID}))
+
+		period := payments.PeriodStart(time.Now())
+		err := db.StoragenodeAccounting().SaveRollup(ctx, period, accounting.RollupStats{
+			period: {
+				nodeID: &accounting.Rollup{
+					NodeID:         nodeID,
+					StartTime:      period,
+					AtRestTotal:    720 * 1e12, // one terabyte for a month
+					GetTotal:       1e12,
+					GetRepairTotal: 5e11,
+					PutTotal:       1e12,
+				},
+			},
+		})
+		require.NoError(t, err)
+
+		config := payments.Config{
+			StorageTBMonthRate: 150,
+			EgressTBRate:       2000,
+			RepairTBRate:       1000,
+			AuditTBRate:        1000,
+			HeldPercentages:    "75,50,0",
+		}
+		service := payments.NewService(zaptest.NewLogger(t), config, db.StoragenodeAccounting(), db.Payments())
+
+		statements, err := service.GenerateStatements(ctx, period.Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, statements, 1)
+
+		statement := statements[0]
+		assert.Equal(t, nodeID, statement.NodeID)
+		assert.Equal(t, period, statement.Period)
+		assert.Equal(t, int64(150), statement.StorageAmount)
+		assert.Equal(t, int64(2000), statement.EgressAmount)
+		assert.Equal(t, int64(500), statement.RepairAmount)
+		assert.Equal(t, int64(0), statement.AuditAmount)
+		assert.Equal(t, int64(2650), statement.GrossAmount())
+		assert.Equal(t, 75, statement.HeldPercent)
+		assert.Equal(t, int64(1988), statement.HeldAmount)
+		assert.Equal(t, int64(662), statement.PayoutAmount)
+
+		// generating again replaces the statements
+		_, err = service.GenerateStatements(ctx, period)
+		require.NoError(t, err)
+
+		stored, err := db.Payments().GetStatements(ctx, period)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		assert.Equal(t, statement.PayoutAmount, stored[0].PayoutAmount)
+
+		single, err := db.Payments().GetStatement(ctx, nodeID, period)
+		require.NoError(t, err)
+		assert.Equal(t, stored[0], single)
+
+		_, err = db.Payments().GetStatement(ctx, nodeID, period.AddDate(0, -1, 0))
+		assert.True(t, payments.Error.Has(err))
+
+		var csvOutput bytes.Buffer
+		require.NoError(t, payments.WriteCSV(&csvOutput, single))
+		records, err := csv.NewReader(&csvOutput).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, nodeID.String(), records[1][0])
+		assert.Equal(t, "662", records[1][len(records[1])-1])
+
+		var jsonOutput bytes.Buffer
+		require.NoError(t, payments.WriteJSON(&jsonOutput, single))
+		var decoded payments.Statement
+		require.NoError(t, json.Unmarshal(jsonOutput.Bytes(), &decoded))
+		assert.Equal(t, single.NodeID, decoded.NodeID)
+		assert.Equal(t, single.PayoutAmount, decoded.PayoutAmount)
+	})
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package payments
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/pkg/accounting"
+)
+
+var mon = monkit.Package()
+
+// Service generates storage node payment statements from accounting rollups
+type Service struct {
+	log        *zap.Logger
+	config     Config
+	accounting accounting.StoragenodeAccounting
+	db         DB
+}
+
+// NewService creates a new payment statement service
+func NewService(log *zap.Logger, config Config, accounting accounting.StoragenodeAccounting, db DB) *Service {
+	return &Service{
+		log:        log,
+		config:     config,
+		accounting: accounting,
+		db:         db,
+	}
+}
+
+// GenerateStatements creates and stores the statements for the month containing period,
+// statements that were generated for the same month before are replaced.
+func (service *Service) GenerateStatements(ctx context.Context, period time.Time) (_ []*Statement, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	start := PeriodStart(period)
+	end := start.AddDate(0, 1, 0)
+
+	rows, err := service.accounting.QueryPaymentInfo(ctx, start, end)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	now := time.Now().UTC()
+	statements := make([]*Statement, 0, len(rows))
+	for _, row := range rows {
+		statement, err := service.statement(row, start)
+		if err != nil {
+			return nil, err
+		}
+		statement.CreatedAt = now
+		statements = append(statements, statement)
+	}
+
+	if err := service.db.SaveStatements(ctx, start, statements); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	service.log.Info("generated payment statements", zap.Time("period", start), zap.Int("count", len(statements)))
+	return statements, nil
+}
+
+// statement calculates the statement of a single storage node
+func (service *Service) statement(row *accounting.CSVRow, period time.Time) (*Statement, error) {
+	heldPercent, err := service.config.HeldPercent(ageInMonths(row.NodeCreationDate, period))
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &Statement{
+		NodeID:        row.NodeID,
+		Period:        period,
+		NodeCreatedAt: row.NodeCreationDate.UTC(),
+		Wallet:        row.Wallet,
+
+		AtRestTotal:    row.AtRestTotal,
+		GetTotal:       row.GetTotal,
+		GetRepairTotal: row.GetRepairTotal,
+		GetAuditTotal:  row.GetAuditTotal,
+
+		StorageAmount: amount(row.AtRestTotal/hoursPerMonth, service.config.StorageTBMonthRate),
+		EgressAmount:  amount(float64(row.GetTotal), service.config.EgressTBRate),
+		RepairAmount:  amount(float64(row.GetRepairTotal), service.config.RepairTBRate),
+		AuditAmount:   amount(float64(row.GetAuditTotal), service.config.AuditTBRate),
+
+		HeldPercent: heldPercent,
+	}
+
+	gross := statement.GrossAmount()
+	statement.HeldAmount = int64(math.Round(float64(gross) * float64(heldPercent) / 100))
+	statement.PayoutAmount = gross - statement.HeldAmount
+	return statement, nil
+}
+
+// amount returns the amount in cents for bytes at a rate per terabyte
+func amount(bytes float64, rate int64) int64 {
+	return int64(math.Round(bytes * float64(rate) / terabyte))
+}
@@ -40,9 +40,11 @@ import (
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/peertls/tlsopts"
+	"storj.io/storj/pkg/relay"
 	"storj.io/storj/pkg/server"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
+	"storj.io/storj/satellite/billing"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/console/consoleauth"
 	"storj.io/storj/satellite/console/consoleweb"
@@ -51,6 +53,7 @@ import (
 	"storj.io/storj/satellite/mailservice/simulate"
 	"storj.io/storj/satellite/metainfo"
 	"storj.io/storj/satellite/orders"
+	"storj.io/storj/satellite/payments"
 	"storj.io/storj/satellite/vouchers"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/boltdb"
@@ -88,6 +91,10 @@ type DB interface {
 	Orders() orders.DB
 	// Containment returns database for containment
 	Containment() audit.Containment
+	// Payments returns database for storage node payment statements
+	Payments() payments.DB
+	// Billing returns database for customer invoices and coupons
+	Billing() billing.DB
 }
 
 // Config is the global config satellite
@@ -96,6 +103,7 @@ type Config struct {
 
 	// TODO: switch to using server.Config when Identity has been removed from it
 	Server server.Config
+	Relay  relay.Config
 
 	Kademlia  kademlia.Config
 	Overlay   overlay.Config
@@ -103,6 +111,7 @@ type Config struct {
 
 	Metainfo    metainfo.Config
 	BwAgreement bwagreement.Config // TODO: decide whether to keep empty configs for consistency
+	Orders      orders.Config
 
 	Checker  checker.Config
 	Repairer repairer.Config
@@ -117,6 +126,8 @@ type Config struct {
 
 	Vouchers vouchers.Config
 
+	Billing billing.Config
+
 	Version version.Config
 }
 
@@ -130,6 +141,7 @@ type Peer struct {
 	Transport transport.Client
 
 	Server *server.Server
+	Relay  *relay.Server
 
 	Version *version.Service
 
@@ -172,9 +184,11 @@ type Peer struct {
 	}
 
 	Repair struct {
-		Checker   *checker.Checker
-		Repairer  *repairer.Service
-		Inspector *irreparable.Inspector
+		Checker        *checker.Checker
+		Corrupted      *checker.CorruptedPieces
+		Repairer       *repairer.Service
+		Inspector      *irreparable.Inspector
+		QueueInspector *queue.Inspector
 	}
 	Audit struct {
 		Service *audit.Service
@@ -198,6 +212,10 @@ type Peer struct {
 		Service *vouchers.Service
 	}
 
+	Billing struct {
+		Service *billing.Service
+	}
+
 	Console struct {
 		Listener net.Listener
 		Service  *console.Service
@@ -238,13 +256,26 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config, ve
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
+
+		if config.Relay.Address != "" {
+			listener, err := net.Listen("tcp", config.Relay.Address)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+			peer.Relay = relay.NewServer(peer.Log.Named("relay"), options, listener)
+		}
 	}
 
 	{ // setup overlay
 		log.Debug("Starting overlay")
 		config := config.Overlay
 
-		peer.Overlay.Service = overlay.NewCache(peer.Log.Named("overlay"), peer.DB.OverlayCache(), config.Node)
+		placement, err := overlay.NewPlacement(config.Placement)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		peer.Overlay.Service = overlay.NewCache(peer.Log.Named("overlay"), peer.DB.OverlayCache(), config.Node, placement)
 		peer.Transport = peer.Transport.WithObservers(peer.Overlay.Service)
 
 		peer.Overlay.Inspector = overlay.NewInspector(peer.Overlay.Service)
@@ -362,11 +393,15 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config, ve
 	{ // setup orders
 		log.Debug("Setting up orders")
 		satelliteSignee := signing.SigneeFromPeerIdentity(peer.Identity.PeerIdentity())
+		peer.Repair.Corrupted = checker.NewCorruptedPieces()
 		peer.Orders.Endpoint = orders.NewEndpoint(
 			peer.Log.Named("orders:endpoint"),
 			satelliteSignee,
 			peer.DB.Orders(),
 			peer.DB.CertDB(),
+			peer.Overlay.Service,
+			peer.Repair.Corrupted,
+			config.Orders,
 		)
 		peer.Orders.Service = orders.NewService(
 			peer.Log.Named("orders:service"),
@@ -416,23 +451,39 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config, ve
 			peer.Metainfo.Service,
 			peer.DB.RepairQueue(),
 			peer.Overlay.Service, peer.DB.Irreparable(),
+			peer.Repair.Corrupted,
 			0, peer.Log.Named("checker"),
 			config.Checker.Interval,
 			config.Checker.IrreparableInterval)
 
-		peer.Repair.Repairer = repairer.NewService(
-			peer.DB.RepairQueue(),
-			&config.Repairer,
-			config.Repairer.Interval,
-			config.Repairer.MaxRepair,
-			peer.Transport,
-			peer.Metainfo.Service,
-			peer.Orders.Service,
-			peer.Overlay.Service,
-		)
+		// with standalone workers the checker still fills the repair queue
+		if !config.Repairer.Standalone {
+			peer.Repair.Repairer = repairer.NewService(
+				peer.DB.RepairQueue(),
+				&config.Repairer,
+				config.Repairer.Interval,
+				config.Repairer.MaxRepair,
+				peer.Transport,
+				peer.Metainfo.Service,
+				peer.Orders.Service,
+				peer.Overlay.Service,
+				audit.NewSegmentAuditor(
+					peer.Log.Named("repairer:audit"),
+					config.Audit,
+					peer.Transport,
+					peer.Overlay.Service,
+					peer.DB.Containment(),
+					peer.Orders.Service,
+					peer.Identity,
+				),
+			)
+		}
 
 		peer.Repair.Inspector = irreparable.NewInspector(peer.DB.Irreparable())
 		pb.RegisterIrreparableInspectorServer(peer.Server.PrivateGRPC(), peer.Repair.Inspector)
+
+		peer.Repair.QueueInspector = queue.NewInspector(peer.DB.RepairQueue())
+		pb.RegisterRepairQueueInspectorServer(peer.Server.PrivateGRPC(), peer.Repair.QueueInspector)
 	}
 
 	{ // setup audit
@@ -538,6 +589,19 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config, ve
 		}
 	}
 
+	{ // setup billing
+		log.Debug("Setting up billing")
+		// the satellite only generates and shows invoices, they are charged with `satellite charge-invoices`
+		peer.Billing.Service = billing.NewService(
+			peer.Log.Named("billing:service"),
+			config.Billing,
+			peer.DB.Billing(),
+			peer.DB.Console().Projects(),
+			peer.DB.Console().UsageRollups(),
+			nil,
+		)
+	}
+
 	{ // setup console
 		log.Debug("Setting up console")
 		consoleConfig := config.Console
@@ -567,6 +631,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config *Config, ve
 			consoleConfig,
 			peer.Console.Service,
 			peer.Mail.Service,
+			peer.Billing.Service,
 			peer.Console.Listener,
 		)
 	}
@@ -581,6 +646,11 @@ func (peer *Peer) Run(ctx context.Context) error {
 	group.Go(func() error {
 		return errs2.IgnoreCanceled(peer.Version.Run(ctx))
 	})
+	if peer.Relay != nil {
+		group.Go(func() error {
+			return errs2.IgnoreCanceled(peer.Relay.Run(ctx))
+		})
+	}
 	group.Go(func() error {
 		return errs2.IgnoreCanceled(peer.Kademlia.Service.Bootstrap(ctx))
 	})
@@ -593,9 +663,11 @@ func (peer *Peer) Run(ctx context.Context) error {
 	group.Go(func() error {
 		return errs2.IgnoreCanceled(peer.Repair.Checker.Run(ctx))
 	})
-	group.Go(func() error {
-		return errs2.IgnoreCanceled(peer.Repair.Repairer.Run(ctx))
-	})
+	if peer.Repair.Repairer != nil {
+		group.Go(func() error {
+			return errs2.IgnoreCanceled(peer.Repair.Repairer.Run(ctx))
+		})
+	}
 	group.Go(func() error {
 		return errs2.IgnoreCanceled(peer.Accounting.Tally.Run(ctx))
 	})
@@ -630,6 +702,9 @@ func (peer *Peer) Close() error {
 	if peer.Server != nil {
 		errlist.Add(peer.Server.Close())
 	}
+	if peer.Relay != nil {
+		errlist.Add(peer.Relay.Close())
+	}
 
 	if peer.Console.Endpoint != nil {
 		errlist.Add(peer.Console.Endpoint.Close())
@@ -0,0 +1,203 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellite
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"storj.io/storj/internal/errs2"
+	"storj.io/storj/internal/version"
+	"storj.io/storj/pkg/audit"
+	"storj.io/storj/pkg/auth/signing"
+	"storj.io/storj/pkg/datarepair/repairer"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/peertls/tlsopts"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/satellite/metainfo"
+	"storj.io/storj/satellite/orders"
+	"storj.io/storj/storage"
+)
+
+// RepairerConfig is the configuration for a standalone repair worker
+type RepairerConfig struct {
+	Identity identity.Config
+	// Server only configures the TLS options used for dialing, it uses
+	// the same keys as the satellite so both can share a config file.
+	Server tlsopts.Config
+
+	Overlay  overlay.Config
+	Metainfo metainfo.Config
+	Repairer repairer.Config
+	// Audit configures the audits of segments repaired by storage nodes
+	Audit audit.Config
+
+	Version version.Config
+}
+
+// Repairer is a standalone repair worker, it only processes the shared repair queue.
+//
+// Any number of repair workers can run next to the satellite, segments are leased
+// from the repair queue so they don't repair the same segment twice. Workers must use
+// the satellite identity, since they sign orders on behalf of the satellite.
+type Repairer struct {
+	// core dependencies
+	Log      *zap.Logger
+	Identity *identity.FullIdentity
+	DB       DB
+
+	Transport transport.Client
+	TLS       *tlsopts.Options
+
+	Version *version.Service
+
+	// services
+	Overlay struct {
+		Service *overlay.Cache
+	}
+
+	Metainfo struct {
+		Database storage.KeyValueStore // TODO: move into pointerDB
+		Service  *metainfo.Service
+	}
+
+	Orders struct {
+		Service *orders.Service
+	}
+
+	Repairer struct {
+		Service *repairer.Service
+	}
+}
+
+// NewRepairer creates a new standalone repair worker, pointerDB is shared with the satellite
+// and is not closed by the worker.
+func NewRepairer(log *zap.Logger, full *identity.FullIdentity, db DB, pointerDB storage.KeyValueStore, config *RepairerConfig, versionInfo version.Info) (*Repairer, error) {
+	peer := &Repairer{
+		Log:      log,
+		Identity: full,
+		DB:       db,
+	}
+
+	var err error
+
+	{
+		test := version.Info{}
+		if test != versionInfo {
+			peer.Log.Sugar().Debugf("Binary Version: %s with CommitHash %s, built at %s as Release %v",
+				versionInfo.Version.String(), versionInfo.CommitHash, versionInfo.Timestamp.String(), versionInfo.Release)
+		}
+		peer.Version = version.NewService(config.Version, versionInfo, "Satellite Repairer")
+	}
+
+	{ // setup transport
+		log.Debug("Setting up transport")
+		peer.TLS, err = tlsopts.NewOptions(peer.Identity, config.Server)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		peer.Transport = transport.NewClient(peer.TLS)
+	}
+
+	{ // setup overlay
+		log.Debug("Starting overlay")
+		config := config.Overlay
+
+		placement, err := overlay.NewPlacement(config.Placement)
+		if err != nil {
+			return nil, errs.Combine(err, peer.Close())
+		}
+
+		peer.Overlay.Service = overlay.NewCache(peer.Log.Named("overlay"), peer.DB.OverlayCache(), config.Node, placement)
+		peer.Transport = peer.Transport.WithObservers(peer.Overlay.Service)
+	}
+
+	{ // setup orders
+		log.Debug("Setting up orders")
+		peer.Orders.Service = orders.NewService(
+			peer.Log.Named("orders:service"),
+			signing.SignerFromFullIdentity(peer.Identity),
+			peer.Overlay.Service,
+			peer.DB.CertDB(),
+			peer.DB.Orders(),
+			45*24*time.Hour, // TODO: make it configurable?
+		)
+	}
+
+	{ // setup metainfo
+		log.Debug("Setting up metainfo")
+		peer.Metainfo.Database = pointerDB
+		peer.Metainfo.Service = metainfo.NewService(peer.Log.Named("metainfo:service"), peer.Metainfo.Database)
+	}
+
+	{ // setup repairer
+		log.Debug("Setting up repairer")
+		peer.Repairer.Service = repairer.NewService(
+			peer.DB.RepairQueue(),
+			&config.Repairer,
+			config.Repairer.Interval,
+			config.Repairer.MaxRepair,
+			peer.Transport,
+			peer.Metainfo.Service,
+			peer.Orders.Service,
+			peer.Overlay.Service,
+			audit.NewSegmentAuditor(
+				peer.Log.Named("repairer:audit"),
+				config.Audit,
+				peer.Transport,
+				peer.Overlay.Service,
+				peer.DB.Containment(),
+				peer.Orders.Service,
+				peer.Identity,
+			),
+		)
+	}
+
+	return peer, nil
+}
+
+// Run runs the repair worker until it's either closed or it errors.
+func (peer *Repairer) Run(ctx context.Context) error {
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(func() error {
+		return errs2.IgnoreCanceled(peer.Version.Run(ctx))
+	})
+	group.Go(func() error {
+		peer.Log.Sugar().Infof("Repair worker for %s started", peer.Identity.ID)
+		return errs2.IgnoreCanceled(peer.Repairer.Service.Run(ctx))
+	})
+
+	return group.Wait()
+}
+
+// Close closes all the resources.
+func (peer *Repairer) Close() error {
+	var errlist errs.Group
+
+	// close services in reverse initialization order
+	if peer.Repairer.Service != nil {
+		errlist.Add(peer.Repairer.Service.Close())
+	}
+
+	if peer.Overlay.Service != nil {
+		errlist.Add(peer.Overlay.Service.Close())
+	}
+
+	if peer.TLS != nil && peer.TLS.RevDB != nil {
+		errlist.Add(peer.TLS.RevDB.Close())
+	}
+
+	return errlist.Err()
+}
+
+// ID returns the peer ID.
+func (peer *Repairer) ID() storj.NodeID { return peer.Identity.ID }
@@ -0,0 +1,270 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/skyrings/skyring-common/tools/uuid"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/satellite/billing"
+	dbx "storj.io/storj/satellite/satellitedb/dbx"
+)
+
+type billingDB struct {
+	db *dbx.DB
+}
+
+const invoiceColumns = `id, project_id, period_start, period_end,
+	subtotal, credits, total, status, provider_reference, created_at`
+
+const couponColumns = `code, project_id, amount, duration_months, redeemed_at, created_at`
+
+// CreateInvoice stores the invoice together with its line items
+func (db *billingDB) CreateInvoice(ctx context.Context, invoice *billing.Invoice) error {
+	err := db.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+		_, err := tx.Tx.ExecContext(ctx, db.db.Rebind(`INSERT INTO invoices (`+invoiceColumns+`)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`),
+			invoice.ID[:], invoice.ProjectID[:], invoice.PeriodStart.UTC(), invoice.PeriodEnd.UTC(),
+			invoice.Subtotal, invoice.Credits, invoice.Total,
+			int(invoice.Status), invoice.ProviderReference, invoice.CreatedAt.UTC(),
+		)
+		if err != nil {
+			return err
+		}
+
+		statement := db.db.Rebind(`INSERT INTO invoice_line_items
+			( invoice_id, position, kind, description, quantity, unit_price, amount )
+			VALUES (?, ?, ?, ?, ?, ?, ?)`)
+		for i, item := range invoice.LineItems {
+			_, err := tx.Tx.ExecContext(ctx, statement,
+				invoice.ID[:], i, int(item.Kind), item.Description,
+				item.Quantity, item.UnitPrice, item.Amount,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return Error.Wrap(err)
+}
+
+// GetInvoice returns the invoice with the given id
+func (db *billingDB) GetInvoice(ctx context.Context, id uuid.UUID) (*billing.Invoice, error) {
+	row := db.db.QueryRowContext(ctx, db.db.Rebind(`SELECT `+invoiceColumns+`
+		FROM invoices WHERE id = ?`), id[:])
+
+	invoice, err := scanInvoice(row)
+	if err == sql.ErrNoRows {
+		return nil, billing.Error.New("invoice %s not found", id.String())
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	invoice.LineItems, err = db.getLineItems(ctx, invoice.ID)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return invoice, nil
+}
+
+// GetInvoicesByProject returns the invoices of a project, latest period first
+func (db *billingDB) GetInvoicesByProject(ctx context.Context, projectID uuid.UUID) ([]*billing.Invoice, error) {
+	return db.queryInvoices(ctx, `SELECT `+invoiceColumns+`
+		FROM invoices WHERE project_id = ? ORDER BY period_start DESC`, projectID[:])
+}
+
+// GetInvoicesByPeriod returns all invoices for the month starting at periodStart
+func (db *billingDB) GetInvoicesByPeriod(ctx context.Context, periodStart time.Time) ([]*billing.Invoice, error) {
+	return db.queryInvoices(ctx, `SELECT `+invoiceColumns+`
+		FROM invoices WHERE period_start = ? ORDER BY project_id`, periodStart.UTC())
+}
+
+// queryInvoices returns the invoices selected by query together with their line items
+func (db *billingDB) queryInvoices(ctx context.Context, query string, args ...interface{}) (_ []*billing.Invoice, err error) {
+	rows, err := db.db.QueryContext(ctx, db.db.Rebind(query), args...)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	var invoices []*billing.Invoice
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return nil, Error.Wrap(errs.Combine(err, rows.Close()))
+		}
+		invoices = append(invoices, invoice)
+	}
+	if err := errs.Combine(rows.Err(), rows.Close()); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	// line items are queried after closing rows so the query does not hold two connections
+	for _, invoice := range invoices {
+		invoice.LineItems, err = db.getLineItems(ctx, invoice.ID)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+	return invoices, nil
+}
+
+// getLineItems returns the line items of an invoice in order
+func (db *billingDB) getLineItems(ctx context.Context, invoiceID uuid.UUID) (_ []billing.LineItem, err error) {
+	rows, err := db.db.QueryContext(ctx, db.db.Rebind(`SELECT kind, description, quantity, unit_price, amount
+		FROM invoice_line_items WHERE invoice_id = ? ORDER BY position`), invoiceID[:])
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var items []billing.LineItem
+	for rows.Next() {
+		var item billing.LineItem
+		var kind int
+		err := rows.Scan(&kind, &item.Description, &item.Quantity, &item.UnitPrice, &item.Amount)
+		if err != nil {
+			return nil, err
+		}
+		item.Kind = billing.LineItemKind(kind)
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+// UpdateInvoiceStatus updates the status and payment provider reference of an invoice
+func (db *billingDB) UpdateInvoiceStatus(ctx context.Context, id uuid.UUID, status billing.InvoiceStatus, providerReference string) error {
+	result, err := db.db.ExecContext(ctx, db.db.Rebind(`UPDATE invoices SET status = ?, provider_reference = ? WHERE id = ?`),
+		int(status), providerReference, id[:])
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if count == 0 {
+		return billing.Error.New("invoice %s not found", id.String())
+	}
+	return nil
+}
+
+// CreateCoupon stores a new unredeemed coupon
+func (db *billingDB) CreateCoupon(ctx context.Context, coupon *billing.Coupon) error {
+	_, err := db.db.ExecContext(ctx, db.db.Rebind(`INSERT INTO coupons (`+couponColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?)`),
+		coupon.Code[:], nil, coupon.Amount, coupon.DurationMonths, nil, coupon.CreatedAt.UTC(),
+	)
+	return Error.Wrap(err)
+}
+
+// GetCoupon returns the coupon with the given code
+func (db *billingDB) GetCoupon(ctx context.Context, code billing.CouponCode) (*billing.Coupon, error) {
+	row := db.db.QueryRowContext(ctx, db.db.Rebind(`SELECT `+couponColumns+`
+		FROM coupons WHERE code = ?`), code[:])
+
+	coupon, err := scanCoupon(row)
+	if err == sql.ErrNoRows {
+		return nil, billing.Error.New("coupon not found")
+	}
+	return coupon, Error.Wrap(err)
+}
+
+// RedeemCoupon assigns an unredeemed coupon to a project
+func (db *billingDB) RedeemCoupon(ctx context.Context, code billing.CouponCode, projectID uuid.UUID, redeemedAt time.Time) error {
+	result, err := db.db.ExecContext(ctx, db.db.Rebind(`UPDATE coupons SET project_id = ?, redeemed_at = ?
+		WHERE code = ? AND project_id IS NULL`), projectID[:], redeemedAt.UTC(), code[:])
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if count == 0 {
+		return billing.Error.New("coupon not found or already redeemed")
+	}
+	return nil
+}
+
+// GetCouponsByProject returns the coupons redeemed by a project
+func (db *billingDB) GetCouponsByProject(ctx context.Context, projectID uuid.UUID) (_ []*billing.Coupon, err error) {
+	rows, err := db.db.QueryContext(ctx, db.db.Rebind(`SELECT `+couponColumns+`
+		FROM coupons WHERE project_id = ? ORDER BY redeemed_at`), projectID[:])
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var coupons []*billing.Coupon
+	for rows.Next() {
+		coupon, err := scanCoupon(rows)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		coupons = append(coupons, coupon)
+	}
+	return coupons, Error.Wrap(rows.Err())
+}
+
+// scanInvoice scans a single invoice without line items from a row with invoiceColumns
+func scanInvoice(row rowScanner) (*billing.Invoice, error) {
+	var id, projectID []byte
+	var status int
+	invoice := &billing.Invoice{}
+	err := row.Scan(&id, &projectID, &invoice.PeriodStart, &invoice.PeriodEnd,
+		&invoice.Subtotal, &invoice.Credits, &invoice.Total,
+		&status, &invoice.ProviderReference, &invoice.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice.ID, err = bytesToUUID(id)
+	if err != nil {
+		return nil, err
+	}
+	invoice.ProjectID, err = bytesToUUID(projectID)
+	if err != nil {
+		return nil, err
+	}
+	invoice.Status = billing.InvoiceStatus(status)
+	invoice.PeriodStart = invoice.PeriodStart.UTC()
+	invoice.PeriodEnd = invoice.PeriodEnd.UTC()
+	invoice.CreatedAt = invoice.CreatedAt.UTC()
+	return invoice, nil
+}
+
+// scanCoupon scans a single coupon from a row with couponColumns
+func scanCoupon(row rowScanner) (*billing.Coupon, error) {
+	var code, projectID []byte
+	var redeemedAt *time.Time
+	coupon := &billing.Coupon{}
+	err := row.Scan(&code, &projectID, &coupon.Amount, &coupon.DurationMonths, &redeemedAt, &coupon.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	copy(coupon.Code[:], code)
+	if projectID != nil {
+		id, err := bytesToUUID(projectID)
+		if err != nil {
+			return nil, err
+		}
+		coupon.ProjectID = &id
+	}
+	if redeemedAt != nil {
+		utc := redeemedAt.UTC()
+		coupon.RedeemedAt = &utc
+	}
+	coupon.CreatedAt = coupon.CreatedAt.UTC()
+	return coupon, nil
+}
@@ -17,8 +17,10 @@ import (
 	"storj.io/storj/pkg/datarepair/queue"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/billing"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/orders"
+	"storj.io/storj/satellite/payments"
 	dbx "storj.io/storj/satellite/satellitedb/dbx"
 )
 
@@ -149,3 +151,13 @@ func (db *DB) Orders() orders.DB {
 func (db *DB) Containment() audit.Containment {
 	return &containment{db: db.db}
 }
+
+// Billing returns database for customer invoices and coupons
+func (db *DB) Billing() billing.DB {
+	return &billingDB{db: db.db}
+}
+
+// Payments returns database for storage node payment statements
+func (db *DB) Payments() payments.DB {
+	return &paymentsDB{db: db.db}
+}
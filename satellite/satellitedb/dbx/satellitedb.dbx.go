@@ -300,6 +300,13 @@ CREATE TABLE bucket_bandwidth_rollups (
 	settled bigint NOT NULL,
 	PRIMARY KEY ( bucket_name, project_id, interval_start, action )
 );
+CREATE TABLE bucket_placements (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	placement text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name )
+);
 CREATE TABLE bucket_storage_tallies (
 	bucket_name bytea NOT NULL,
 	project_id bytea NOT NULL,
@@ -343,12 +350,37 @@ CREATE TABLE certRecords (
 	update_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE coupons (
+	code bytea NOT NULL,
+	project_id bytea,
+	amount bigint NOT NULL,
+	duration_months integer NOT NULL,
+	redeemed_at timestamp,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( code )
+);
 CREATE TABLE injuredsegments (
 	path text NOT NULL,
 	data bytea NOT NULL,
+	num_healthy_pieces integer NOT NULL,
+	inserted_at timestamp NOT NULL,
 	attempted timestamp,
 	PRIMARY KEY ( path )
 );
+CREATE TABLE invoices (
+	id bytea NOT NULL,
+	project_id bytea NOT NULL,
+	period_start timestamp NOT NULL,
+	period_end timestamp NOT NULL,
+	subtotal bigint NOT NULL,
+	credits bigint NOT NULL,
+	total bigint NOT NULL,
+	status integer NOT NULL,
+	provider_reference text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id ),
+	UNIQUE ( project_id, period_start )
+);
 CREATE TABLE irreparabledbs (
 	segmentpath bytea NOT NULL,
 	segmentdetail bytea NOT NULL,
@@ -357,6 +389,15 @@ CREATE TABLE irreparabledbs (
 	repair_attempt_count bigint NOT NULL,
 	PRIMARY KEY ( segmentpath )
 );
+CREATE TABLE node_upload_stats (
+	node_id bytea NOT NULL,
+	upload_count bigint NOT NULL,
+	success_ratio double precision NOT NULL,
+	long_tail_ratio double precision NOT NULL,
+	throughput double precision NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE nodes (
 	id bytea NOT NULL,
 	address text NOT NULL,
@@ -386,6 +427,16 @@ CREATE TABLE nodes (
 	last_contact_failure timestamp with time zone NOT NULL,
 	contained boolean NOT NULL,
 	disqualified boolean NOT NULL,
+	disqualified_at timestamp with time zone,
+	disqualification_reason text,
+	suspended_at timestamp with time zone,
+	suspension_reason text,
+	last_net text,
+	country_code text,
+	audit_reputation_alpha double precision NOT NULL,
+	audit_reputation_beta double precision NOT NULL,
+	uptime_reputation_alpha double precision NOT NULL,
+	uptime_reputation_beta double precision NOT NULL,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE offers (
@@ -451,6 +502,32 @@ CREATE TABLE storagenode_bandwidth_rollups (
 	settled bigint NOT NULL,
 	PRIMARY KEY ( storagenode_id, interval_start, action )
 );
+CREATE TABLE storagenode_frauds (
+	storagenode_id bytea NOT NULL,
+	reason integer NOT NULL,
+	count bigint NOT NULL,
+	last_seen_at timestamp NOT NULL,
+	PRIMARY KEY ( storagenode_id, reason )
+);
+CREATE TABLE storagenode_statements (
+	node_id bytea NOT NULL,
+	period timestamp NOT NULL,
+	node_created_at timestamp NOT NULL,
+	wallet text NOT NULL,
+	at_rest_total double precision NOT NULL,
+	get_total bigint NOT NULL,
+	get_repair_total bigint NOT NULL,
+	get_audit_total bigint NOT NULL,
+	storage_amount bigint NOT NULL,
+	egress_amount bigint NOT NULL,
+	repair_amount bigint NOT NULL,
+	audit_amount bigint NOT NULL,
+	held_percent integer NOT NULL,
+	held_amount bigint NOT NULL,
+	payout_amount bigint NOT NULL,
+	created_at timestamp NOT NULL,
+	PRIMARY KEY ( node_id, period )
+);
 CREATE TABLE storagenode_storage_tallies (
 	id bigserial NOT NULL,
 	node_id bytea NOT NULL,
@@ -479,6 +556,16 @@ CREATE TABLE api_keys (
 	UNIQUE ( head ),
 	UNIQUE ( name, project_id )
 );
+CREATE TABLE invoice_line_items (
+	invoice_id bytea NOT NULL REFERENCES invoices( id ) ON DELETE CASCADE,
+	position integer NOT NULL,
+	kind integer NOT NULL,
+	description text NOT NULL,
+	quantity double precision NOT NULL,
+	unit_price double precision NOT NULL,
+	amount bigint NOT NULL,
+	PRIMARY KEY ( invoice_id, position )
+);
 CREATE TABLE project_members (
 	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
 	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
@@ -492,6 +579,7 @@ CREATE TABLE used_serials (
 );
 CREATE INDEX bucket_name_project_id_interval_start_interval_seconds ON bucket_bandwidth_rollups ( bucket_name, project_id, interval_start, interval_seconds );
 CREATE UNIQUE INDEX bucket_id_rollup ON bucket_usages ( bucket_id, rollup_end_time );
+CREATE INDEX injuredsegments_num_healthy_pieces_inserted_at_index ON injuredsegments ( num_healthy_pieces, inserted_at );
 CREATE INDEX node_last_ip ON nodes ( last_ip );
 CREATE UNIQUE INDEX serial_number ON serial_numbers ( serial_number );
 CREATE INDEX serial_numbers_expires_at_index ON serial_numbers ( expires_at );
@@ -587,6 +675,13 @@ CREATE TABLE bucket_bandwidth_rollups (
 	settled INTEGER NOT NULL,
 	PRIMARY KEY ( bucket_name, project_id, interval_start, action )
 );
+CREATE TABLE bucket_placements (
+	project_id BLOB NOT NULL,
+	bucket_name BLOB NOT NULL,
+	placement TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name )
+);
 CREATE TABLE bucket_storage_tallies (
 	bucket_name BLOB NOT NULL,
 	project_id BLOB NOT NULL,
@@ -630,12 +725,37 @@ CREATE TABLE certRecords (
 	update_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE coupons (
+	code BLOB NOT NULL,
+	project_id BLOB,
+	amount INTEGER NOT NULL,
+	duration_months INTEGER NOT NULL,
+	redeemed_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( code )
+);
 CREATE TABLE injuredsegments (
 	path TEXT NOT NULL,
 	data BLOB NOT NULL,
+	num_healthy_pieces INTEGER NOT NULL,
+	inserted_at TIMESTAMP NOT NULL,
 	attempted TIMESTAMP,
 	PRIMARY KEY ( path )
 );
+CREATE TABLE invoices (
+	id BLOB NOT NULL,
+	project_id BLOB NOT NULL,
+	period_start TIMESTAMP NOT NULL,
+	period_end TIMESTAMP NOT NULL,
+	subtotal INTEGER NOT NULL,
+	credits INTEGER NOT NULL,
+	total INTEGER NOT NULL,
+	status INTEGER NOT NULL,
+	provider_reference TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id ),
+	UNIQUE ( project_id, period_start )
+);
 CREATE TABLE irreparabledbs (
 	segmentpath BLOB NOT NULL,
 	segmentdetail BLOB NOT NULL,
@@ -644,6 +764,15 @@ CREATE TABLE irreparabledbs (
 	repair_attempt_count INTEGER NOT NULL,
 	PRIMARY KEY ( segmentpath )
 );
+CREATE TABLE node_upload_stats (
+	node_id BLOB NOT NULL,
+	upload_count INTEGER NOT NULL,
+	success_ratio REAL NOT NULL,
+	long_tail_ratio REAL NOT NULL,
+	throughput REAL NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE nodes (
 	id BLOB NOT NULL,
 	address TEXT NOT NULL,
@@ -673,6 +802,16 @@ CREATE TABLE nodes (
 	last_contact_failure TIMESTAMP NOT NULL,
 	contained INTEGER NOT NULL,
 	disqualified INTEGER NOT NULL,
+	disqualified_at TIMESTAMP,
+	disqualification_reason TEXT,
+	suspended_at TIMESTAMP,
+	suspension_reason TEXT,
+	last_net TEXT,
+	country_code TEXT,
+	audit_reputation_alpha REAL NOT NULL,
+	audit_reputation_beta REAL NOT NULL,
+	uptime_reputation_alpha REAL NOT NULL,
+	uptime_reputation_beta REAL NOT NULL,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE offers (
@@ -738,6 +877,32 @@ CREATE TABLE storagenode_bandwidth_rollups (
 	settled INTEGER NOT NULL,
 	PRIMARY KEY ( storagenode_id, interval_start, action )
 );
+CREATE TABLE storagenode_frauds (
+	storagenode_id BLOB NOT NULL,
+	reason INTEGER NOT NULL,
+	count INTEGER NOT NULL,
+	last_seen_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( storagenode_id, reason )
+);
+CREATE TABLE storagenode_statements (
+	node_id BLOB NOT NULL,
+	period TIMESTAMP NOT NULL,
+	node_created_at TIMESTAMP NOT NULL,
+	wallet TEXT NOT NULL,
+	at_rest_total REAL NOT NULL,
+	get_total INTEGER NOT NULL,
+	get_repair_total INTEGER NOT NULL,
+	get_audit_total INTEGER NOT NULL,
+	storage_amount INTEGER NOT NULL,
+	egress_amount INTEGER NOT NULL,
+	repair_amount INTEGER NOT NULL,
+	audit_amount INTEGER NOT NULL,
+	held_percent INTEGER NOT NULL,
+	held_amount INTEGER NOT NULL,
+	payout_amount INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id, period )
+);
 CREATE TABLE storagenode_storage_tallies (
 	id INTEGER NOT NULL,
 	node_id BLOB NOT NULL,
@@ -766,6 +931,16 @@ CREATE TABLE api_keys (
 	UNIQUE ( head ),
 	UNIQUE ( name, project_id )
 );
+CREATE TABLE invoice_line_items (
+	invoice_id BLOB NOT NULL REFERENCES invoices( id ) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	kind INTEGER NOT NULL,
+	description TEXT NOT NULL,
+	quantity REAL NOT NULL,
+	unit_price REAL NOT NULL,
+	amount INTEGER NOT NULL,
+	PRIMARY KEY ( invoice_id, position )
+);
 CREATE TABLE project_members (
 	member_id BLOB NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
 	project_id BLOB NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
@@ -779,6 +954,7 @@ CREATE TABLE used_serials (
 );
 CREATE INDEX bucket_name_project_id_interval_start_interval_seconds ON bucket_bandwidth_rollups ( bucket_name, project_id, interval_start, interval_seconds );
 CREATE UNIQUE INDEX bucket_id_rollup ON bucket_usages ( bucket_id, rollup_end_time );
+CREATE INDEX injuredsegments_num_healthy_pieces_inserted_at_index ON injuredsegments ( num_healthy_pieces, inserted_at );
 CREATE INDEX node_last_ip ON nodes ( last_ip );
 CREATE UNIQUE INDEX serial_number ON serial_numbers ( serial_number );
 CREATE INDEX serial_numbers_expires_at_index ON serial_numbers ( expires_at );
@@ -1254,6 +1430,95 @@ func (f BucketBandwidthRollup_Settled_Field) value() interface{} {
 
 func (BucketBandwidthRollup_Settled_Field) _Column() string { return "settled" }
 
+type BucketPlacement struct {
+	ProjectId  []byte
+	BucketName []byte
+	Placement  string
+	CreatedAt  time.Time
+}
+
+func (BucketPlacement) _Table() string { return "bucket_placements" }
+
+type BucketPlacement_Update_Fields struct {
+	Placement BucketPlacement_Placement_Field
+}
+
+type BucketPlacement_ProjectId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketPlacement_ProjectId(v []byte) BucketPlacement_ProjectId_Field {
+	return BucketPlacement_ProjectId_Field{_set: true, _value: v}
+}
+
+func (f BucketPlacement_ProjectId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketPlacement_ProjectId_Field) _Column() string { return "project_id" }
+
+type BucketPlacement_BucketName_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func BucketPlacement_BucketName(v []byte) BucketPlacement_BucketName_Field {
+	return BucketPlacement_BucketName_Field{_set: true, _value: v}
+}
+
+func (f BucketPlacement_BucketName_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketPlacement_BucketName_Field) _Column() string { return "bucket_name" }
+
+type BucketPlacement_Placement_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func BucketPlacement_Placement(v string) BucketPlacement_Placement_Field {
+	return BucketPlacement_Placement_Field{_set: true, _value: v}
+}
+
+func (f BucketPlacement_Placement_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketPlacement_Placement_Field) _Column() string { return "placement" }
+
+type BucketPlacement_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func BucketPlacement_CreatedAt(v time.Time) BucketPlacement_CreatedAt_Field {
+	return BucketPlacement_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f BucketPlacement_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (BucketPlacement_CreatedAt_Field) _Column() string { return "created_at" }
+
 type BucketStorageTally struct {
 	BucketName          []byte
 	ProjectId           []byte
@@ -1907,1095 +2172,1937 @@ func (f CertRecord_UpdateAt_Field) value() interface{} {
 
 func (CertRecord_UpdateAt_Field) _Column() string { return "update_at" }
 
-type Injuredsegment struct {
-	Path      string
-	Data      []byte
-	Attempted *time.Time
-}
-
-func (Injuredsegment) _Table() string { return "injuredsegments" }
-
-type Injuredsegment_Create_Fields struct {
-	Attempted Injuredsegment_Attempted_Field
-}
-
-type Injuredsegment_Update_Fields struct {
-	Attempted Injuredsegment_Attempted_Field
-}
-
-type Injuredsegment_Path_Field struct {
-	_set   bool
-	_null  bool
-	_value string
+type Coupon struct {
+	Code           []byte
+	ProjectId      []byte
+	Amount         int64
+	DurationMonths int
+	RedeemedAt     *time.Time
+	CreatedAt      time.Time
 }
 
-func Injuredsegment_Path(v string) Injuredsegment_Path_Field {
-	return Injuredsegment_Path_Field{_set: true, _value: v}
-}
+func (Coupon) _Table() string { return "coupons" }
 
-func (f Injuredsegment_Path_Field) value() interface{} {
-	if !f._set || f._null {
-		return nil
-	}
-	return f._value
+type Coupon_Update_Fields struct {
+	ProjectId  Coupon_ProjectId_Field
+	RedeemedAt Coupon_RedeemedAt_Field
 }
 
-func (Injuredsegment_Path_Field) _Column() string { return "path" }
-
-type Injuredsegment_Data_Field struct {
+type Coupon_Code_Field struct {
 	_set   bool
 	_null  bool
 	_value []byte
 }
 
-func Injuredsegment_Data(v []byte) Injuredsegment_Data_Field {
-	return Injuredsegment_Data_Field{_set: true, _value: v}
+func Coupon_Code(v []byte) Coupon_Code_Field {
+	return Coupon_Code_Field{_set: true, _value: v}
 }
 
-func (f Injuredsegment_Data_Field) value() interface{} {
+func (f Coupon_Code_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Injuredsegment_Data_Field) _Column() string { return "data" }
+func (Coupon_Code_Field) _Column() string { return "code" }
 
-type Injuredsegment_Attempted_Field struct {
+type Coupon_ProjectId_Field struct {
 	_set   bool
 	_null  bool
-	_value *time.Time
+	_value []byte
 }
 
-func Injuredsegment_Attempted(v time.Time) Injuredsegment_Attempted_Field {
-	v = toUTC(v)
-	return Injuredsegment_Attempted_Field{_set: true, _value: &v}
+func Coupon_ProjectId(v []byte) Coupon_ProjectId_Field {
+	return Coupon_ProjectId_Field{_set: true, _value: v}
 }
 
-func Injuredsegment_Attempted_Raw(v *time.Time) Injuredsegment_Attempted_Field {
+func Coupon_ProjectId_Raw(v []byte) Coupon_ProjectId_Field {
 	if v == nil {
-		return Injuredsegment_Attempted_Null()
+		return Coupon_ProjectId_Null()
 	}
-	return Injuredsegment_Attempted(*v)
+	return Coupon_ProjectId(v)
 }
 
-func Injuredsegment_Attempted_Null() Injuredsegment_Attempted_Field {
-	return Injuredsegment_Attempted_Field{_set: true, _null: true}
+func Coupon_ProjectId_Null() Coupon_ProjectId_Field {
+	return Coupon_ProjectId_Field{_set: true, _null: true}
 }
 
-func (f Injuredsegment_Attempted_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+func (f Coupon_ProjectId_Field) isnull() bool { return !f._set || f._null || f._value == nil }
 
-func (f Injuredsegment_Attempted_Field) value() interface{} {
+func (f Coupon_ProjectId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Injuredsegment_Attempted_Field) _Column() string { return "attempted" }
-
-type Irreparabledb struct {
-	Segmentpath        []byte
-	Segmentdetail      []byte
-	PiecesLostCount    int64
-	SegDamagedUnixSec  int64
-	RepairAttemptCount int64
-}
-
-func (Irreparabledb) _Table() string { return "irreparabledbs" }
-
-type Irreparabledb_Update_Fields struct {
-	Segmentdetail      Irreparabledb_Segmentdetail_Field
-	PiecesLostCount    Irreparabledb_PiecesLostCount_Field
-	SegDamagedUnixSec  Irreparabledb_SegDamagedUnixSec_Field
-	RepairAttemptCount Irreparabledb_RepairAttemptCount_Field
-}
+func (Coupon_ProjectId_Field) _Column() string { return "project_id" }
 
-type Irreparabledb_Segmentpath_Field struct {
+type Coupon_Amount_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value int64
 }
 
-func Irreparabledb_Segmentpath(v []byte) Irreparabledb_Segmentpath_Field {
-	return Irreparabledb_Segmentpath_Field{_set: true, _value: v}
+func Coupon_Amount(v int64) Coupon_Amount_Field {
+	return Coupon_Amount_Field{_set: true, _value: v}
 }
 
-func (f Irreparabledb_Segmentpath_Field) value() interface{} {
+func (f Coupon_Amount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Irreparabledb_Segmentpath_Field) _Column() string { return "segmentpath" }
+func (Coupon_Amount_Field) _Column() string { return "amount" }
 
-type Irreparabledb_Segmentdetail_Field struct {
+type Coupon_DurationMonths_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value int
 }
 
-func Irreparabledb_Segmentdetail(v []byte) Irreparabledb_Segmentdetail_Field {
-	return Irreparabledb_Segmentdetail_Field{_set: true, _value: v}
+func Coupon_DurationMonths(v int) Coupon_DurationMonths_Field {
+	return Coupon_DurationMonths_Field{_set: true, _value: v}
 }
 
-func (f Irreparabledb_Segmentdetail_Field) value() interface{} {
+func (f Coupon_DurationMonths_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Irreparabledb_Segmentdetail_Field) _Column() string { return "segmentdetail" }
+func (Coupon_DurationMonths_Field) _Column() string { return "duration_months" }
 
-type Irreparabledb_PiecesLostCount_Field struct {
+type Coupon_RedeemedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value *time.Time
 }
 
-func Irreparabledb_PiecesLostCount(v int64) Irreparabledb_PiecesLostCount_Field {
-	return Irreparabledb_PiecesLostCount_Field{_set: true, _value: v}
+func Coupon_RedeemedAt(v time.Time) Coupon_RedeemedAt_Field {
+	v = toUTC(v)
+	return Coupon_RedeemedAt_Field{_set: true, _value: &v}
 }
 
-func (f Irreparabledb_PiecesLostCount_Field) value() interface{} {
-	if !f._set || f._null {
-		return nil
+func Coupon_RedeemedAt_Raw(v *time.Time) Coupon_RedeemedAt_Field {
+	if v == nil {
+		return Coupon_RedeemedAt_Null()
 	}
-	return f._value
+	return Coupon_RedeemedAt(*v)
 }
 
-func (Irreparabledb_PiecesLostCount_Field) _Column() string { return "pieces_lost_count" }
-
-type Irreparabledb_SegDamagedUnixSec_Field struct {
-	_set   bool
-	_null  bool
-	_value int64
+func Coupon_RedeemedAt_Null() Coupon_RedeemedAt_Field {
+	return Coupon_RedeemedAt_Field{_set: true, _null: true}
 }
 
-func Irreparabledb_SegDamagedUnixSec(v int64) Irreparabledb_SegDamagedUnixSec_Field {
-	return Irreparabledb_SegDamagedUnixSec_Field{_set: true, _value: v}
-}
+func (f Coupon_RedeemedAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
 
-func (f Irreparabledb_SegDamagedUnixSec_Field) value() interface{} {
+func (f Coupon_RedeemedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Irreparabledb_SegDamagedUnixSec_Field) _Column() string { return "seg_damaged_unix_sec" }
+func (Coupon_RedeemedAt_Field) _Column() string { return "redeemed_at" }
 
-type Irreparabledb_RepairAttemptCount_Field struct {
+type Coupon_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value time.Time
 }
 
-func Irreparabledb_RepairAttemptCount(v int64) Irreparabledb_RepairAttemptCount_Field {
-	return Irreparabledb_RepairAttemptCount_Field{_set: true, _value: v}
+func Coupon_CreatedAt(v time.Time) Coupon_CreatedAt_Field {
+	return Coupon_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f Irreparabledb_RepairAttemptCount_Field) value() interface{} {
+func (f Coupon_CreatedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Irreparabledb_RepairAttemptCount_Field) _Column() string { return "repair_attempt_count" }
+func (Coupon_CreatedAt_Field) _Column() string { return "created_at" }
 
-type Node struct {
-	Id                 []byte
-	Address            string
-	LastIp             string
-	Protocol           int
-	Type               int
-	Email              string
-	Wallet             string
-	FreeBandwidth      int64
-	FreeDisk           int64
-	Major              int64
-	Minor              int64
-	Patch              int64
-	Hash               string
-	Timestamp          time.Time
-	Release            bool
-	Latency90          int64
-	AuditSuccessCount  int64
-	TotalAuditCount    int64
-	AuditSuccessRatio  float64
-	UptimeSuccessCount int64
-	TotalUptimeCount   int64
-	UptimeRatio        float64
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
-	LastContactSuccess time.Time
-	LastContactFailure time.Time
-	Contained          bool
-	Disqualified       bool
+type Injuredsegment struct {
+	Path             string
+	Data             []byte
+	NumHealthyPieces int
+	InsertedAt       time.Time
+	Attempted        *time.Time
 }
 
-func (Node) _Table() string { return "nodes" }
+func (Injuredsegment) _Table() string { return "injuredsegments" }
 
-type Node_Update_Fields struct {
-	Address            Node_Address_Field
-	LastIp             Node_LastIp_Field
-	Protocol           Node_Protocol_Field
-	Type               Node_Type_Field
-	Email              Node_Email_Field
-	Wallet             Node_Wallet_Field
-	FreeBandwidth      Node_FreeBandwidth_Field
-	FreeDisk           Node_FreeDisk_Field
-	Major              Node_Major_Field
-	Minor              Node_Minor_Field
-	Patch              Node_Patch_Field
-	Hash               Node_Hash_Field
-	Timestamp          Node_Timestamp_Field
-	Release            Node_Release_Field
-	Latency90          Node_Latency90_Field
-	AuditSuccessCount  Node_AuditSuccessCount_Field
-	TotalAuditCount    Node_TotalAuditCount_Field
-	AuditSuccessRatio  Node_AuditSuccessRatio_Field
-	UptimeSuccessCount Node_UptimeSuccessCount_Field
-	TotalUptimeCount   Node_TotalUptimeCount_Field
-	UptimeRatio        Node_UptimeRatio_Field
-	LastContactSuccess Node_LastContactSuccess_Field
-	LastContactFailure Node_LastContactFailure_Field
-	Contained          Node_Contained_Field
-	Disqualified       Node_Disqualified_Field
+type Injuredsegment_Create_Fields struct {
+	Attempted Injuredsegment_Attempted_Field
 }
 
-type Node_Id_Field struct {
+type Injuredsegment_Update_Fields struct {
+	NumHealthyPieces Injuredsegment_NumHealthyPieces_Field
+	Attempted        Injuredsegment_Attempted_Field
+}
+
+type Injuredsegment_Path_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value string
 }
 
-func Node_Id(v []byte) Node_Id_Field {
-	return Node_Id_Field{_set: true, _value: v}
+func Injuredsegment_Path(v string) Injuredsegment_Path_Field {
+	return Injuredsegment_Path_Field{_set: true, _value: v}
 }
 
-func (f Node_Id_Field) value() interface{} {
+func (f Injuredsegment_Path_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Id_Field) _Column() string { return "id" }
+func (Injuredsegment_Path_Field) _Column() string { return "path" }
 
-type Node_Address_Field struct {
+type Injuredsegment_Data_Field struct {
 	_set   bool
 	_null  bool
-	_value string
+	_value []byte
 }
 
-func Node_Address(v string) Node_Address_Field {
-	return Node_Address_Field{_set: true, _value: v}
+func Injuredsegment_Data(v []byte) Injuredsegment_Data_Field {
+	return Injuredsegment_Data_Field{_set: true, _value: v}
 }
 
-func (f Node_Address_Field) value() interface{} {
+func (f Injuredsegment_Data_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Address_Field) _Column() string { return "address" }
+func (Injuredsegment_Data_Field) _Column() string { return "data" }
 
-type Node_LastIp_Field struct {
+type Injuredsegment_NumHealthyPieces_Field struct {
 	_set   bool
 	_null  bool
-	_value string
+	_value int
 }
 
-func Node_LastIp(v string) Node_LastIp_Field {
-	return Node_LastIp_Field{_set: true, _value: v}
+func Injuredsegment_NumHealthyPieces(v int) Injuredsegment_NumHealthyPieces_Field {
+	return Injuredsegment_NumHealthyPieces_Field{_set: true, _value: v}
 }
 
-func (f Node_LastIp_Field) value() interface{} {
+func (f Injuredsegment_NumHealthyPieces_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_LastIp_Field) _Column() string { return "last_ip" }
+func (Injuredsegment_NumHealthyPieces_Field) _Column() string { return "num_healthy_pieces" }
 
-type Node_Protocol_Field struct {
+type Injuredsegment_InsertedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value time.Time
 }
 
-func Node_Protocol(v int) Node_Protocol_Field {
-	return Node_Protocol_Field{_set: true, _value: v}
+func Injuredsegment_InsertedAt(v time.Time) Injuredsegment_InsertedAt_Field {
+	v = toUTC(v)
+	return Injuredsegment_InsertedAt_Field{_set: true, _value: v}
 }
 
-func (f Node_Protocol_Field) value() interface{} {
+func (f Injuredsegment_InsertedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Protocol_Field) _Column() string { return "protocol" }
+func (Injuredsegment_InsertedAt_Field) _Column() string { return "inserted_at" }
 
-type Node_Type_Field struct {
+type Injuredsegment_Attempted_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value *time.Time
 }
 
-func Node_Type(v int) Node_Type_Field {
-	return Node_Type_Field{_set: true, _value: v}
+func Injuredsegment_Attempted(v time.Time) Injuredsegment_Attempted_Field {
+	v = toUTC(v)
+	return Injuredsegment_Attempted_Field{_set: true, _value: &v}
 }
 
-func (f Node_Type_Field) value() interface{} {
+func Injuredsegment_Attempted_Raw(v *time.Time) Injuredsegment_Attempted_Field {
+	if v == nil {
+		return Injuredsegment_Attempted_Null()
+	}
+	return Injuredsegment_Attempted(*v)
+}
+
+func Injuredsegment_Attempted_Null() Injuredsegment_Attempted_Field {
+	return Injuredsegment_Attempted_Field{_set: true, _null: true}
+}
+
+func (f Injuredsegment_Attempted_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Injuredsegment_Attempted_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Type_Field) _Column() string { return "type" }
+func (Injuredsegment_Attempted_Field) _Column() string { return "attempted" }
 
-type Node_Email_Field struct {
+type Invoice struct {
+	Id                []byte
+	ProjectId         []byte
+	PeriodStart       time.Time
+	PeriodEnd         time.Time
+	Subtotal          int64
+	Credits           int64
+	Total             int64
+	Status            int
+	ProviderReference string
+	CreatedAt         time.Time
+}
+
+func (Invoice) _Table() string { return "invoices" }
+
+type Invoice_Update_Fields struct {
+	Status            Invoice_Status_Field
+	ProviderReference Invoice_ProviderReference_Field
+}
+
+type Invoice_Id_Field struct {
 	_set   bool
 	_null  bool
-	_value string
+	_value []byte
 }
 
-func Node_Email(v string) Node_Email_Field {
-	return Node_Email_Field{_set: true, _value: v}
+func Invoice_Id(v []byte) Invoice_Id_Field {
+	return Invoice_Id_Field{_set: true, _value: v}
 }
 
-func (f Node_Email_Field) value() interface{} {
+func (f Invoice_Id_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Email_Field) _Column() string { return "email" }
+func (Invoice_Id_Field) _Column() string { return "id" }
 
-type Node_Wallet_Field struct {
+type Invoice_ProjectId_Field struct {
 	_set   bool
 	_null  bool
-	_value string
+	_value []byte
 }
 
-func Node_Wallet(v string) Node_Wallet_Field {
-	return Node_Wallet_Field{_set: true, _value: v}
+func Invoice_ProjectId(v []byte) Invoice_ProjectId_Field {
+	return Invoice_ProjectId_Field{_set: true, _value: v}
 }
 
-func (f Node_Wallet_Field) value() interface{} {
+func (f Invoice_ProjectId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Wallet_Field) _Column() string { return "wallet" }
+func (Invoice_ProjectId_Field) _Column() string { return "project_id" }
 
-type Node_FreeBandwidth_Field struct {
+type Invoice_PeriodStart_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value time.Time
 }
 
-func Node_FreeBandwidth(v int64) Node_FreeBandwidth_Field {
-	return Node_FreeBandwidth_Field{_set: true, _value: v}
+func Invoice_PeriodStart(v time.Time) Invoice_PeriodStart_Field {
+	v = toUTC(v)
+	return Invoice_PeriodStart_Field{_set: true, _value: v}
 }
 
-func (f Node_FreeBandwidth_Field) value() interface{} {
+func (f Invoice_PeriodStart_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_FreeBandwidth_Field) _Column() string { return "free_bandwidth" }
+func (Invoice_PeriodStart_Field) _Column() string { return "period_start" }
 
-type Node_FreeDisk_Field struct {
+type Invoice_PeriodEnd_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value time.Time
 }
 
-func Node_FreeDisk(v int64) Node_FreeDisk_Field {
-	return Node_FreeDisk_Field{_set: true, _value: v}
+func Invoice_PeriodEnd(v time.Time) Invoice_PeriodEnd_Field {
+	v = toUTC(v)
+	return Invoice_PeriodEnd_Field{_set: true, _value: v}
 }
 
-func (f Node_FreeDisk_Field) value() interface{} {
+func (f Invoice_PeriodEnd_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_FreeDisk_Field) _Column() string { return "free_disk" }
+func (Invoice_PeriodEnd_Field) _Column() string { return "period_end" }
 
-type Node_Major_Field struct {
+type Invoice_Subtotal_Field struct {
 	_set   bool
 	_null  bool
 	_value int64
 }
 
-func Node_Major(v int64) Node_Major_Field {
-	return Node_Major_Field{_set: true, _value: v}
+func Invoice_Subtotal(v int64) Invoice_Subtotal_Field {
+	return Invoice_Subtotal_Field{_set: true, _value: v}
 }
 
-func (f Node_Major_Field) value() interface{} {
+func (f Invoice_Subtotal_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Major_Field) _Column() string { return "major" }
+func (Invoice_Subtotal_Field) _Column() string { return "subtotal" }
 
-type Node_Minor_Field struct {
+type Invoice_Credits_Field struct {
 	_set   bool
 	_null  bool
 	_value int64
 }
 
-func Node_Minor(v int64) Node_Minor_Field {
-	return Node_Minor_Field{_set: true, _value: v}
+func Invoice_Credits(v int64) Invoice_Credits_Field {
+	return Invoice_Credits_Field{_set: true, _value: v}
 }
 
-func (f Node_Minor_Field) value() interface{} {
+func (f Invoice_Credits_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Minor_Field) _Column() string { return "minor" }
+func (Invoice_Credits_Field) _Column() string { return "credits" }
 
-type Node_Patch_Field struct {
+type Invoice_Total_Field struct {
 	_set   bool
 	_null  bool
 	_value int64
 }
 
-func Node_Patch(v int64) Node_Patch_Field {
-	return Node_Patch_Field{_set: true, _value: v}
+func Invoice_Total(v int64) Invoice_Total_Field {
+	return Invoice_Total_Field{_set: true, _value: v}
 }
 
-func (f Node_Patch_Field) value() interface{} {
+func (f Invoice_Total_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Patch_Field) _Column() string { return "patch" }
+func (Invoice_Total_Field) _Column() string { return "total" }
 
-type Node_Hash_Field struct {
+type Invoice_Status_Field struct {
 	_set   bool
 	_null  bool
-	_value string
+	_value int
 }
 
-func Node_Hash(v string) Node_Hash_Field {
-	return Node_Hash_Field{_set: true, _value: v}
+func Invoice_Status(v int) Invoice_Status_Field {
+	return Invoice_Status_Field{_set: true, _value: v}
 }
 
-func (f Node_Hash_Field) value() interface{} {
+func (f Invoice_Status_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Hash_Field) _Column() string { return "hash" }
+func (Invoice_Status_Field) _Column() string { return "status" }
 
-type Node_Timestamp_Field struct {
+type Invoice_ProviderReference_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value string
 }
 
-func Node_Timestamp(v time.Time) Node_Timestamp_Field {
-	return Node_Timestamp_Field{_set: true, _value: v}
+func Invoice_ProviderReference(v string) Invoice_ProviderReference_Field {
+	return Invoice_ProviderReference_Field{_set: true, _value: v}
 }
 
-func (f Node_Timestamp_Field) value() interface{} {
+func (f Invoice_ProviderReference_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Timestamp_Field) _Column() string { return "timestamp" }
+func (Invoice_ProviderReference_Field) _Column() string { return "provider_reference" }
 
-type Node_Release_Field struct {
+type Invoice_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value bool
+	_value time.Time
 }
 
-func Node_Release(v bool) Node_Release_Field {
-	return Node_Release_Field{_set: true, _value: v}
+func Invoice_CreatedAt(v time.Time) Invoice_CreatedAt_Field {
+	return Invoice_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f Node_Release_Field) value() interface{} {
+func (f Invoice_CreatedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Release_Field) _Column() string { return "release" }
+func (Invoice_CreatedAt_Field) _Column() string { return "created_at" }
 
-type Node_Latency90_Field struct {
+type Irreparabledb struct {
+	Segmentpath        []byte
+	Segmentdetail      []byte
+	PiecesLostCount    int64
+	SegDamagedUnixSec  int64
+	RepairAttemptCount int64
+}
+
+func (Irreparabledb) _Table() string { return "irreparabledbs" }
+
+type Irreparabledb_Update_Fields struct {
+	Segmentdetail      Irreparabledb_Segmentdetail_Field
+	PiecesLostCount    Irreparabledb_PiecesLostCount_Field
+	SegDamagedUnixSec  Irreparabledb_SegDamagedUnixSec_Field
+	RepairAttemptCount Irreparabledb_RepairAttemptCount_Field
+}
+
+type Irreparabledb_Segmentpath_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value []byte
 }
 
-func Node_Latency90(v int64) Node_Latency90_Field {
-	return Node_Latency90_Field{_set: true, _value: v}
+func Irreparabledb_Segmentpath(v []byte) Irreparabledb_Segmentpath_Field {
+	return Irreparabledb_Segmentpath_Field{_set: true, _value: v}
 }
 
-func (f Node_Latency90_Field) value() interface{} {
+func (f Irreparabledb_Segmentpath_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Latency90_Field) _Column() string { return "latency_90" }
+func (Irreparabledb_Segmentpath_Field) _Column() string { return "segmentpath" }
 
-type Node_AuditSuccessCount_Field struct {
+type Irreparabledb_Segmentdetail_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value []byte
 }
 
-func Node_AuditSuccessCount(v int64) Node_AuditSuccessCount_Field {
-	return Node_AuditSuccessCount_Field{_set: true, _value: v}
+func Irreparabledb_Segmentdetail(v []byte) Irreparabledb_Segmentdetail_Field {
+	return Irreparabledb_Segmentdetail_Field{_set: true, _value: v}
 }
 
-func (f Node_AuditSuccessCount_Field) value() interface{} {
+func (f Irreparabledb_Segmentdetail_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_AuditSuccessCount_Field) _Column() string { return "audit_success_count" }
+func (Irreparabledb_Segmentdetail_Field) _Column() string { return "segmentdetail" }
 
-type Node_TotalAuditCount_Field struct {
+type Irreparabledb_PiecesLostCount_Field struct {
 	_set   bool
 	_null  bool
 	_value int64
 }
 
-func Node_TotalAuditCount(v int64) Node_TotalAuditCount_Field {
-	return Node_TotalAuditCount_Field{_set: true, _value: v}
+func Irreparabledb_PiecesLostCount(v int64) Irreparabledb_PiecesLostCount_Field {
+	return Irreparabledb_PiecesLostCount_Field{_set: true, _value: v}
 }
 
-func (f Node_TotalAuditCount_Field) value() interface{} {
+func (f Irreparabledb_PiecesLostCount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_TotalAuditCount_Field) _Column() string { return "total_audit_count" }
+func (Irreparabledb_PiecesLostCount_Field) _Column() string { return "pieces_lost_count" }
 
-type Node_AuditSuccessRatio_Field struct {
+type Irreparabledb_SegDamagedUnixSec_Field struct {
 	_set   bool
 	_null  bool
-	_value float64
+	_value int64
 }
 
-func Node_AuditSuccessRatio(v float64) Node_AuditSuccessRatio_Field {
-	return Node_AuditSuccessRatio_Field{_set: true, _value: v}
+func Irreparabledb_SegDamagedUnixSec(v int64) Irreparabledb_SegDamagedUnixSec_Field {
+	return Irreparabledb_SegDamagedUnixSec_Field{_set: true, _value: v}
 }
 
-func (f Node_AuditSuccessRatio_Field) value() interface{} {
+func (f Irreparabledb_SegDamagedUnixSec_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_AuditSuccessRatio_Field) _Column() string { return "audit_success_ratio" }
+func (Irreparabledb_SegDamagedUnixSec_Field) _Column() string { return "seg_damaged_unix_sec" }
 
-type Node_UptimeSuccessCount_Field struct {
+type Irreparabledb_RepairAttemptCount_Field struct {
 	_set   bool
 	_null  bool
 	_value int64
 }
 
-func Node_UptimeSuccessCount(v int64) Node_UptimeSuccessCount_Field {
-	return Node_UptimeSuccessCount_Field{_set: true, _value: v}
+func Irreparabledb_RepairAttemptCount(v int64) Irreparabledb_RepairAttemptCount_Field {
+	return Irreparabledb_RepairAttemptCount_Field{_set: true, _value: v}
 }
 
-func (f Node_UptimeSuccessCount_Field) value() interface{} {
+func (f Irreparabledb_RepairAttemptCount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_UptimeSuccessCount_Field) _Column() string { return "uptime_success_count" }
+func (Irreparabledb_RepairAttemptCount_Field) _Column() string { return "repair_attempt_count" }
 
-type Node_TotalUptimeCount_Field struct {
+type Node struct {
+	Id                     []byte
+	Address                string
+	LastIp                 string
+	Protocol               int
+	Type                   int
+	Email                  string
+	Wallet                 string
+	FreeBandwidth          int64
+	FreeDisk               int64
+	Major                  int64
+	Minor                  int64
+	Patch                  int64
+	Hash                   string
+	Timestamp              time.Time
+	Release                bool
+	Latency90              int64
+	AuditSuccessCount      int64
+	TotalAuditCount        int64
+	AuditSuccessRatio      float64
+	UptimeSuccessCount     int64
+	TotalUptimeCount       int64
+	UptimeRatio            float64
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+	LastContactSuccess     time.Time
+	LastContactFailure     time.Time
+	Contained              bool
+	Disqualified           bool
+	DisqualifiedAt         *time.Time
+	DisqualificationReason *string
+	SuspendedAt            *time.Time
+	SuspensionReason       *string
+	LastNet                *string
+	CountryCode            *string
+	AuditReputationAlpha   float64
+	AuditReputationBeta    float64
+	UptimeReputationAlpha  float64
+	UptimeReputationBeta   float64
+}
+
+func (Node) _Table() string { return "nodes" }
+
+type Node_Create_Fields struct {
+	DisqualifiedAt         Node_DisqualifiedAt_Field
+	DisqualificationReason Node_DisqualificationReason_Field
+	SuspendedAt            Node_SuspendedAt_Field
+	SuspensionReason       Node_SuspensionReason_Field
+	LastNet                Node_LastNet_Field
+	CountryCode            Node_CountryCode_Field
+}
+
+type Node_Update_Fields struct {
+	Address                Node_Address_Field
+	LastIp                 Node_LastIp_Field
+	Protocol               Node_Protocol_Field
+	Type                   Node_Type_Field
+	Email                  Node_Email_Field
+	Wallet                 Node_Wallet_Field
+	FreeBandwidth          Node_FreeBandwidth_Field
+	FreeDisk               Node_FreeDisk_Field
+	Major                  Node_Major_Field
+	Minor                  Node_Minor_Field
+	Patch                  Node_Patch_Field
+	Hash                   Node_Hash_Field
+	Timestamp              Node_Timestamp_Field
+	Release                Node_Release_Field
+	Latency90              Node_Latency90_Field
+	AuditSuccessCount      Node_AuditSuccessCount_Field
+	TotalAuditCount        Node_TotalAuditCount_Field
+	AuditSuccessRatio      Node_AuditSuccessRatio_Field
+	UptimeSuccessCount     Node_UptimeSuccessCount_Field
+	TotalUptimeCount       Node_TotalUptimeCount_Field
+	UptimeRatio            Node_UptimeRatio_Field
+	LastContactSuccess     Node_LastContactSuccess_Field
+	LastContactFailure     Node_LastContactFailure_Field
+	Contained              Node_Contained_Field
+	Disqualified           Node_Disqualified_Field
+	DisqualifiedAt         Node_DisqualifiedAt_Field
+	DisqualificationReason Node_DisqualificationReason_Field
+	SuspendedAt            Node_SuspendedAt_Field
+	SuspensionReason       Node_SuspensionReason_Field
+	LastNet                Node_LastNet_Field
+	CountryCode            Node_CountryCode_Field
+	AuditReputationAlpha   Node_AuditReputationAlpha_Field
+	AuditReputationBeta    Node_AuditReputationBeta_Field
+	UptimeReputationAlpha  Node_UptimeReputationAlpha_Field
+	UptimeReputationBeta   Node_UptimeReputationBeta_Field
+}
+
+type Node_Id_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value []byte
 }
 
-func Node_TotalUptimeCount(v int64) Node_TotalUptimeCount_Field {
-	return Node_TotalUptimeCount_Field{_set: true, _value: v}
+func Node_Id(v []byte) Node_Id_Field {
+	return Node_Id_Field{_set: true, _value: v}
 }
 
-func (f Node_TotalUptimeCount_Field) value() interface{} {
+func (f Node_Id_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_TotalUptimeCount_Field) _Column() string { return "total_uptime_count" }
+func (Node_Id_Field) _Column() string { return "id" }
 
-type Node_UptimeRatio_Field struct {
+type Node_Address_Field struct {
 	_set   bool
 	_null  bool
-	_value float64
+	_value string
 }
 
-func Node_UptimeRatio(v float64) Node_UptimeRatio_Field {
-	return Node_UptimeRatio_Field{_set: true, _value: v}
+func Node_Address(v string) Node_Address_Field {
+	return Node_Address_Field{_set: true, _value: v}
 }
 
-func (f Node_UptimeRatio_Field) value() interface{} {
+func (f Node_Address_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_UptimeRatio_Field) _Column() string { return "uptime_ratio" }
+func (Node_Address_Field) _Column() string { return "address" }
 
-type Node_CreatedAt_Field struct {
+type Node_LastIp_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value string
 }
 
-func Node_CreatedAt(v time.Time) Node_CreatedAt_Field {
-	return Node_CreatedAt_Field{_set: true, _value: v}
+func Node_LastIp(v string) Node_LastIp_Field {
+	return Node_LastIp_Field{_set: true, _value: v}
 }
 
-func (f Node_CreatedAt_Field) value() interface{} {
+func (f Node_LastIp_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_CreatedAt_Field) _Column() string { return "created_at" }
+func (Node_LastIp_Field) _Column() string { return "last_ip" }
 
-type Node_UpdatedAt_Field struct {
+type Node_Protocol_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value int
 }
 
-func Node_UpdatedAt(v time.Time) Node_UpdatedAt_Field {
-	return Node_UpdatedAt_Field{_set: true, _value: v}
+func Node_Protocol(v int) Node_Protocol_Field {
+	return Node_Protocol_Field{_set: true, _value: v}
 }
 
-func (f Node_UpdatedAt_Field) value() interface{} {
+func (f Node_Protocol_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_UpdatedAt_Field) _Column() string { return "updated_at" }
+func (Node_Protocol_Field) _Column() string { return "protocol" }
 
-type Node_LastContactSuccess_Field struct {
+type Node_Type_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value int
 }
 
-func Node_LastContactSuccess(v time.Time) Node_LastContactSuccess_Field {
-	return Node_LastContactSuccess_Field{_set: true, _value: v}
+func Node_Type(v int) Node_Type_Field {
+	return Node_Type_Field{_set: true, _value: v}
 }
 
-func (f Node_LastContactSuccess_Field) value() interface{} {
+func (f Node_Type_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_LastContactSuccess_Field) _Column() string { return "last_contact_success" }
+func (Node_Type_Field) _Column() string { return "type" }
 
-type Node_LastContactFailure_Field struct {
+type Node_Email_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value string
 }
 
-func Node_LastContactFailure(v time.Time) Node_LastContactFailure_Field {
-	return Node_LastContactFailure_Field{_set: true, _value: v}
+func Node_Email(v string) Node_Email_Field {
+	return Node_Email_Field{_set: true, _value: v}
 }
 
-func (f Node_LastContactFailure_Field) value() interface{} {
+func (f Node_Email_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_LastContactFailure_Field) _Column() string { return "last_contact_failure" }
+func (Node_Email_Field) _Column() string { return "email" }
 
-type Node_Contained_Field struct {
+type Node_Wallet_Field struct {
 	_set   bool
 	_null  bool
-	_value bool
+	_value string
 }
 
-func Node_Contained(v bool) Node_Contained_Field {
-	return Node_Contained_Field{_set: true, _value: v}
+func Node_Wallet(v string) Node_Wallet_Field {
+	return Node_Wallet_Field{_set: true, _value: v}
 }
 
-func (f Node_Contained_Field) value() interface{} {
+func (f Node_Wallet_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Contained_Field) _Column() string { return "contained" }
+func (Node_Wallet_Field) _Column() string { return "wallet" }
 
-type Node_Disqualified_Field struct {
+type Node_FreeBandwidth_Field struct {
 	_set   bool
 	_null  bool
-	_value bool
+	_value int64
 }
 
-func Node_Disqualified(v bool) Node_Disqualified_Field {
-	return Node_Disqualified_Field{_set: true, _value: v}
+func Node_FreeBandwidth(v int64) Node_FreeBandwidth_Field {
+	return Node_FreeBandwidth_Field{_set: true, _value: v}
 }
 
-func (f Node_Disqualified_Field) value() interface{} {
+func (f Node_FreeBandwidth_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Disqualified_Field) _Column() string { return "disqualified" }
-
-type Offer struct {
-	Id                        int
-	Name                      string
-	Description               string
-	Type                      int
-	CreditInCents             int
-	AwardCreditDurationDays   int
-	InviteeCreditDurationDays int
-	RedeemableCap             int
-	NumRedeemed               int
-	ExpiresAt                 *time.Time
-	CreatedAt                 time.Time
-	Status                    int
-}
-
-func (Offer) _Table() string { return "offers" }
-
-type Offer_Update_Fields struct {
-	Name                      Offer_Name_Field
-	Description               Offer_Description_Field
-	Type                      Offer_Type_Field
-	CreditInCents             Offer_CreditInCents_Field
-	AwardCreditDurationDays   Offer_AwardCreditDurationDays_Field
-	InviteeCreditDurationDays Offer_InviteeCreditDurationDays_Field
-	RedeemableCap             Offer_RedeemableCap_Field
-	NumRedeemed               Offer_NumRedeemed_Field
-	ExpiresAt                 Offer_ExpiresAt_Field
-	Status                    Offer_Status_Field
-}
+func (Node_FreeBandwidth_Field) _Column() string { return "free_bandwidth" }
 
-type Offer_Id_Field struct {
+type Node_FreeDisk_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value int64
 }
 
-func Offer_Id(v int) Offer_Id_Field {
-	return Offer_Id_Field{_set: true, _value: v}
+func Node_FreeDisk(v int64) Node_FreeDisk_Field {
+	return Node_FreeDisk_Field{_set: true, _value: v}
 }
 
-func (f Offer_Id_Field) value() interface{} {
+func (f Node_FreeDisk_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_Id_Field) _Column() string { return "id" }
+func (Node_FreeDisk_Field) _Column() string { return "free_disk" }
 
-type Offer_Name_Field struct {
+type Node_Major_Field struct {
 	_set   bool
 	_null  bool
-	_value string
+	_value int64
 }
 
-func Offer_Name(v string) Offer_Name_Field {
-	return Offer_Name_Field{_set: true, _value: v}
+func Node_Major(v int64) Node_Major_Field {
+	return Node_Major_Field{_set: true, _value: v}
 }
 
-func (f Offer_Name_Field) value() interface{} {
+func (f Node_Major_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_Name_Field) _Column() string { return "name" }
+func (Node_Major_Field) _Column() string { return "major" }
 
-type Offer_Description_Field struct {
+type Node_Minor_Field struct {
 	_set   bool
 	_null  bool
-	_value string
+	_value int64
 }
 
-func Offer_Description(v string) Offer_Description_Field {
-	return Offer_Description_Field{_set: true, _value: v}
+func Node_Minor(v int64) Node_Minor_Field {
+	return Node_Minor_Field{_set: true, _value: v}
 }
 
-func (f Offer_Description_Field) value() interface{} {
+func (f Node_Minor_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_Description_Field) _Column() string { return "description" }
+func (Node_Minor_Field) _Column() string { return "minor" }
 
-type Offer_Type_Field struct {
+type Node_Patch_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value int64
 }
 
-func Offer_Type(v int) Offer_Type_Field {
-	return Offer_Type_Field{_set: true, _value: v}
+func Node_Patch(v int64) Node_Patch_Field {
+	return Node_Patch_Field{_set: true, _value: v}
 }
 
-func (f Offer_Type_Field) value() interface{} {
+func (f Node_Patch_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_Type_Field) _Column() string { return "type" }
+func (Node_Patch_Field) _Column() string { return "patch" }
 
-type Offer_CreditInCents_Field struct {
+type Node_Hash_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value string
 }
 
-func Offer_CreditInCents(v int) Offer_CreditInCents_Field {
-	return Offer_CreditInCents_Field{_set: true, _value: v}
+func Node_Hash(v string) Node_Hash_Field {
+	return Node_Hash_Field{_set: true, _value: v}
 }
 
-func (f Offer_CreditInCents_Field) value() interface{} {
+func (f Node_Hash_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_CreditInCents_Field) _Column() string { return "credit_in_cents" }
+func (Node_Hash_Field) _Column() string { return "hash" }
 
-type Offer_AwardCreditDurationDays_Field struct {
+type Node_Timestamp_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value time.Time
 }
 
-func Offer_AwardCreditDurationDays(v int) Offer_AwardCreditDurationDays_Field {
-	return Offer_AwardCreditDurationDays_Field{_set: true, _value: v}
+func Node_Timestamp(v time.Time) Node_Timestamp_Field {
+	return Node_Timestamp_Field{_set: true, _value: v}
 }
 
-func (f Offer_AwardCreditDurationDays_Field) value() interface{} {
+func (f Node_Timestamp_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_AwardCreditDurationDays_Field) _Column() string { return "award_credit_duration_days" }
+func (Node_Timestamp_Field) _Column() string { return "timestamp" }
 
-type Offer_InviteeCreditDurationDays_Field struct {
+type Node_Release_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value bool
 }
 
-func Offer_InviteeCreditDurationDays(v int) Offer_InviteeCreditDurationDays_Field {
-	return Offer_InviteeCreditDurationDays_Field{_set: true, _value: v}
+func Node_Release(v bool) Node_Release_Field {
+	return Node_Release_Field{_set: true, _value: v}
 }
 
-func (f Offer_InviteeCreditDurationDays_Field) value() interface{} {
+func (f Node_Release_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_InviteeCreditDurationDays_Field) _Column() string { return "invitee_credit_duration_days" }
+func (Node_Release_Field) _Column() string { return "release" }
 
-type Offer_RedeemableCap_Field struct {
+type Node_Latency90_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value int64
 }
 
-func Offer_RedeemableCap(v int) Offer_RedeemableCap_Field {
-	return Offer_RedeemableCap_Field{_set: true, _value: v}
+func Node_Latency90(v int64) Node_Latency90_Field {
+	return Node_Latency90_Field{_set: true, _value: v}
 }
 
-func (f Offer_RedeemableCap_Field) value() interface{} {
+func (f Node_Latency90_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_RedeemableCap_Field) _Column() string { return "redeemable_cap" }
+func (Node_Latency90_Field) _Column() string { return "latency_90" }
 
-type Offer_NumRedeemed_Field struct {
+type Node_AuditSuccessCount_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value int64
 }
 
-func Offer_NumRedeemed(v int) Offer_NumRedeemed_Field {
-	return Offer_NumRedeemed_Field{_set: true, _value: v}
+func Node_AuditSuccessCount(v int64) Node_AuditSuccessCount_Field {
+	return Node_AuditSuccessCount_Field{_set: true, _value: v}
 }
 
-func (f Offer_NumRedeemed_Field) value() interface{} {
+func (f Node_AuditSuccessCount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_NumRedeemed_Field) _Column() string { return "num_redeemed" }
+func (Node_AuditSuccessCount_Field) _Column() string { return "audit_success_count" }
 
-type Offer_ExpiresAt_Field struct {
+type Node_TotalAuditCount_Field struct {
 	_set   bool
 	_null  bool
-	_value *time.Time
+	_value int64
 }
 
-func Offer_ExpiresAt(v time.Time) Offer_ExpiresAt_Field {
-	return Offer_ExpiresAt_Field{_set: true, _value: &v}
+func Node_TotalAuditCount(v int64) Node_TotalAuditCount_Field {
+	return Node_TotalAuditCount_Field{_set: true, _value: v}
 }
 
-func Offer_ExpiresAt_Raw(v *time.Time) Offer_ExpiresAt_Field {
-	if v == nil {
-		return Offer_ExpiresAt_Null()
+func (f Node_TotalAuditCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
 	}
-	return Offer_ExpiresAt(*v)
+	return f._value
 }
 
-func Offer_ExpiresAt_Null() Offer_ExpiresAt_Field {
-	return Offer_ExpiresAt_Field{_set: true, _null: true}
+func (Node_TotalAuditCount_Field) _Column() string { return "total_audit_count" }
+
+type Node_AuditSuccessRatio_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
 }
 
-func (f Offer_ExpiresAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+func Node_AuditSuccessRatio(v float64) Node_AuditSuccessRatio_Field {
+	return Node_AuditSuccessRatio_Field{_set: true, _value: v}
+}
 
-func (f Offer_ExpiresAt_Field) value() interface{} {
+func (f Node_AuditSuccessRatio_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_ExpiresAt_Field) _Column() string { return "expires_at" }
+func (Node_AuditSuccessRatio_Field) _Column() string { return "audit_success_ratio" }
 
-type Offer_CreatedAt_Field struct {
+type Node_UptimeSuccessCount_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value int64
 }
 
-func Offer_CreatedAt(v time.Time) Offer_CreatedAt_Field {
-	return Offer_CreatedAt_Field{_set: true, _value: v}
+func Node_UptimeSuccessCount(v int64) Node_UptimeSuccessCount_Field {
+	return Node_UptimeSuccessCount_Field{_set: true, _value: v}
 }
 
-func (f Offer_CreatedAt_Field) value() interface{} {
+func (f Node_UptimeSuccessCount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_CreatedAt_Field) _Column() string { return "created_at" }
+func (Node_UptimeSuccessCount_Field) _Column() string { return "uptime_success_count" }
 
-type Offer_Status_Field struct {
+type Node_TotalUptimeCount_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value int64
 }
 
-func Offer_Status(v int) Offer_Status_Field {
-	return Offer_Status_Field{_set: true, _value: v}
+func Node_TotalUptimeCount(v int64) Node_TotalUptimeCount_Field {
+	return Node_TotalUptimeCount_Field{_set: true, _value: v}
 }
 
-func (f Offer_Status_Field) value() interface{} {
+func (f Node_TotalUptimeCount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Offer_Status_Field) _Column() string { return "status" }
-
-type PendingAudits struct {
-	NodeId            []byte
-	PieceId           []byte
-	StripeIndex       int64
-	ShareSize         int64
-	ExpectedShareHash []byte
-	ReverifyCount     int64
-}
-
-func (PendingAudits) _Table() string { return "pending_audits" }
-
-type PendingAudits_Update_Fields struct {
-	ReverifyCount PendingAudits_ReverifyCount_Field
-}
+func (Node_TotalUptimeCount_Field) _Column() string { return "total_uptime_count" }
 
-type PendingAudits_NodeId_Field struct {
+type Node_UptimeRatio_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value float64
 }
 
-func PendingAudits_NodeId(v []byte) PendingAudits_NodeId_Field {
-	return PendingAudits_NodeId_Field{_set: true, _value: v}
+func Node_UptimeRatio(v float64) Node_UptimeRatio_Field {
+	return Node_UptimeRatio_Field{_set: true, _value: v}
 }
 
-func (f PendingAudits_NodeId_Field) value() interface{} {
+func (f Node_UptimeRatio_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_UptimeRatio_Field) _Column() string { return "uptime_ratio" }
+
+type Node_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Node_CreatedAt(v time.Time) Node_CreatedAt_Field {
+	return Node_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f Node_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_CreatedAt_Field) _Column() string { return "created_at" }
+
+type Node_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Node_UpdatedAt(v time.Time) Node_UpdatedAt_Field {
+	return Node_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f Node_UpdatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_UpdatedAt_Field) _Column() string { return "updated_at" }
+
+type Node_LastContactSuccess_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Node_LastContactSuccess(v time.Time) Node_LastContactSuccess_Field {
+	return Node_LastContactSuccess_Field{_set: true, _value: v}
+}
+
+func (f Node_LastContactSuccess_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_LastContactSuccess_Field) _Column() string { return "last_contact_success" }
+
+type Node_LastContactFailure_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Node_LastContactFailure(v time.Time) Node_LastContactFailure_Field {
+	return Node_LastContactFailure_Field{_set: true, _value: v}
+}
+
+func (f Node_LastContactFailure_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_LastContactFailure_Field) _Column() string { return "last_contact_failure" }
+
+type Node_Contained_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func Node_Contained(v bool) Node_Contained_Field {
+	return Node_Contained_Field{_set: true, _value: v}
+}
+
+func (f Node_Contained_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_Contained_Field) _Column() string { return "contained" }
+
+type Node_Disqualified_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func Node_Disqualified(v bool) Node_Disqualified_Field {
+	return Node_Disqualified_Field{_set: true, _value: v}
+}
+
+func (f Node_Disqualified_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_Disqualified_Field) _Column() string { return "disqualified" }
+
+type Node_DisqualifiedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func Node_DisqualifiedAt(v time.Time) Node_DisqualifiedAt_Field {
+	return Node_DisqualifiedAt_Field{_set: true, _value: &v}
+}
+
+func Node_DisqualifiedAt_Raw(v *time.Time) Node_DisqualifiedAt_Field {
+	if v == nil {
+		return Node_DisqualifiedAt_Null()
+	}
+	return Node_DisqualifiedAt(*v)
+}
+
+func Node_DisqualifiedAt_Null() Node_DisqualifiedAt_Field {
+	return Node_DisqualifiedAt_Field{_set: true, _null: true}
+}
+
+func (f Node_DisqualifiedAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Node_DisqualifiedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_DisqualifiedAt_Field) _Column() string { return "disqualified_at" }
+
+type Node_DisqualificationReason_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func Node_DisqualificationReason(v string) Node_DisqualificationReason_Field {
+	return Node_DisqualificationReason_Field{_set: true, _value: &v}
+}
+
+func Node_DisqualificationReason_Raw(v *string) Node_DisqualificationReason_Field {
+	if v == nil {
+		return Node_DisqualificationReason_Null()
+	}
+	return Node_DisqualificationReason(*v)
+}
+
+func Node_DisqualificationReason_Null() Node_DisqualificationReason_Field {
+	return Node_DisqualificationReason_Field{_set: true, _null: true}
+}
+
+func (f Node_DisqualificationReason_Field) isnull() bool {
+	return !f._set || f._null || f._value == nil
+}
+
+func (f Node_DisqualificationReason_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_DisqualificationReason_Field) _Column() string { return "disqualification_reason" }
+
+type Node_SuspendedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func Node_SuspendedAt(v time.Time) Node_SuspendedAt_Field {
+	return Node_SuspendedAt_Field{_set: true, _value: &v}
+}
+
+func Node_SuspendedAt_Raw(v *time.Time) Node_SuspendedAt_Field {
+	if v == nil {
+		return Node_SuspendedAt_Null()
+	}
+	return Node_SuspendedAt(*v)
+}
+
+func Node_SuspendedAt_Null() Node_SuspendedAt_Field {
+	return Node_SuspendedAt_Field{_set: true, _null: true}
+}
+
+func (f Node_SuspendedAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Node_SuspendedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_SuspendedAt_Field) _Column() string { return "suspended_at" }
+
+type Node_SuspensionReason_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func Node_SuspensionReason(v string) Node_SuspensionReason_Field {
+	return Node_SuspensionReason_Field{_set: true, _value: &v}
+}
+
+func Node_SuspensionReason_Raw(v *string) Node_SuspensionReason_Field {
+	if v == nil {
+		return Node_SuspensionReason_Null()
+	}
+	return Node_SuspensionReason(*v)
+}
+
+func Node_SuspensionReason_Null() Node_SuspensionReason_Field {
+	return Node_SuspensionReason_Field{_set: true, _null: true}
+}
+
+func (f Node_SuspensionReason_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Node_SuspensionReason_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_SuspensionReason_Field) _Column() string { return "suspension_reason" }
+
+type Node_LastNet_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func Node_LastNet(v string) Node_LastNet_Field {
+	return Node_LastNet_Field{_set: true, _value: &v}
+}
+
+func Node_LastNet_Raw(v *string) Node_LastNet_Field {
+	if v == nil {
+		return Node_LastNet_Null()
+	}
+	return Node_LastNet(*v)
+}
+
+func Node_LastNet_Null() Node_LastNet_Field {
+	return Node_LastNet_Field{_set: true, _null: true}
+}
+
+func (f Node_LastNet_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Node_LastNet_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_LastNet_Field) _Column() string { return "last_net" }
+
+type Node_CountryCode_Field struct {
+	_set   bool
+	_null  bool
+	_value *string
+}
+
+func Node_CountryCode(v string) Node_CountryCode_Field {
+	return Node_CountryCode_Field{_set: true, _value: &v}
+}
+
+func Node_CountryCode_Raw(v *string) Node_CountryCode_Field {
+	if v == nil {
+		return Node_CountryCode_Null()
+	}
+	return Node_CountryCode(*v)
+}
+
+func Node_CountryCode_Null() Node_CountryCode_Field {
+	return Node_CountryCode_Field{_set: true, _null: true}
+}
+
+func (f Node_CountryCode_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Node_CountryCode_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_CountryCode_Field) _Column() string { return "country_code" }
+
+type Node_AuditReputationAlpha_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func Node_AuditReputationAlpha(v float64) Node_AuditReputationAlpha_Field {
+	return Node_AuditReputationAlpha_Field{_set: true, _value: v}
+}
+
+func (f Node_AuditReputationAlpha_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_AuditReputationAlpha_Field) _Column() string { return "audit_reputation_alpha" }
+
+type Node_AuditReputationBeta_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func Node_AuditReputationBeta(v float64) Node_AuditReputationBeta_Field {
+	return Node_AuditReputationBeta_Field{_set: true, _value: v}
+}
+
+func (f Node_AuditReputationBeta_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_AuditReputationBeta_Field) _Column() string { return "audit_reputation_beta" }
+
+type Node_UptimeReputationAlpha_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func Node_UptimeReputationAlpha(v float64) Node_UptimeReputationAlpha_Field {
+	return Node_UptimeReputationAlpha_Field{_set: true, _value: v}
+}
+
+func (f Node_UptimeReputationAlpha_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_UptimeReputationAlpha_Field) _Column() string { return "uptime_reputation_alpha" }
+
+type Node_UptimeReputationBeta_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func Node_UptimeReputationBeta(v float64) Node_UptimeReputationBeta_Field {
+	return Node_UptimeReputationBeta_Field{_set: true, _value: v}
+}
+
+func (f Node_UptimeReputationBeta_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_UptimeReputationBeta_Field) _Column() string { return "uptime_reputation_beta" }
+
+type NodeUploadStat struct {
+	NodeId        []byte
+	UploadCount   int64
+	SuccessRatio  float64
+	LongTailRatio float64
+	Throughput    float64
+	UpdatedAt     time.Time
+}
+
+func (NodeUploadStat) _Table() string { return "node_upload_stats" }
+
+type NodeUploadStat_Update_Fields struct {
+	UploadCount   NodeUploadStat_UploadCount_Field
+	SuccessRatio  NodeUploadStat_SuccessRatio_Field
+	LongTailRatio NodeUploadStat_LongTailRatio_Field
+	Throughput    NodeUploadStat_Throughput_Field
+}
+
+type NodeUploadStat_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func NodeUploadStat_NodeId(v []byte) NodeUploadStat_NodeId_Field {
+	return NodeUploadStat_NodeId_Field{_set: true, _value: v}
+}
+
+func (f NodeUploadStat_NodeId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeUploadStat_NodeId_Field) _Column() string { return "node_id" }
+
+type NodeUploadStat_UploadCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func NodeUploadStat_UploadCount(v int64) NodeUploadStat_UploadCount_Field {
+	return NodeUploadStat_UploadCount_Field{_set: true, _value: v}
+}
+
+func (f NodeUploadStat_UploadCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeUploadStat_UploadCount_Field) _Column() string { return "upload_count" }
+
+type NodeUploadStat_SuccessRatio_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func NodeUploadStat_SuccessRatio(v float64) NodeUploadStat_SuccessRatio_Field {
+	return NodeUploadStat_SuccessRatio_Field{_set: true, _value: v}
+}
+
+func (f NodeUploadStat_SuccessRatio_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeUploadStat_SuccessRatio_Field) _Column() string { return "success_ratio" }
+
+type NodeUploadStat_LongTailRatio_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func NodeUploadStat_LongTailRatio(v float64) NodeUploadStat_LongTailRatio_Field {
+	return NodeUploadStat_LongTailRatio_Field{_set: true, _value: v}
+}
+
+func (f NodeUploadStat_LongTailRatio_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeUploadStat_LongTailRatio_Field) _Column() string { return "long_tail_ratio" }
+
+type NodeUploadStat_Throughput_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func NodeUploadStat_Throughput(v float64) NodeUploadStat_Throughput_Field {
+	return NodeUploadStat_Throughput_Field{_set: true, _value: v}
+}
+
+func (f NodeUploadStat_Throughput_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeUploadStat_Throughput_Field) _Column() string { return "throughput" }
+
+type NodeUploadStat_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func NodeUploadStat_UpdatedAt(v time.Time) NodeUploadStat_UpdatedAt_Field {
+	return NodeUploadStat_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f NodeUploadStat_UpdatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (NodeUploadStat_UpdatedAt_Field) _Column() string { return "updated_at" }
+
+type Offer struct {
+	Id                        int
+	Name                      string
+	Description               string
+	Type                      int
+	CreditInCents             int
+	AwardCreditDurationDays   int
+	InviteeCreditDurationDays int
+	RedeemableCap             int
+	NumRedeemed               int
+	ExpiresAt                 *time.Time
+	CreatedAt                 time.Time
+	Status                    int
+}
+
+func (Offer) _Table() string { return "offers" }
+
+type Offer_Update_Fields struct {
+	Name                      Offer_Name_Field
+	Description               Offer_Description_Field
+	Type                      Offer_Type_Field
+	CreditInCents             Offer_CreditInCents_Field
+	AwardCreditDurationDays   Offer_AwardCreditDurationDays_Field
+	InviteeCreditDurationDays Offer_InviteeCreditDurationDays_Field
+	RedeemableCap             Offer_RedeemableCap_Field
+	NumRedeemed               Offer_NumRedeemed_Field
+	ExpiresAt                 Offer_ExpiresAt_Field
+	Status                    Offer_Status_Field
+}
+
+type Offer_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Offer_Id(v int) Offer_Id_Field {
+	return Offer_Id_Field{_set: true, _value: v}
+}
+
+func (f Offer_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_Id_Field) _Column() string { return "id" }
+
+type Offer_Name_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Offer_Name(v string) Offer_Name_Field {
+	return Offer_Name_Field{_set: true, _value: v}
+}
+
+func (f Offer_Name_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_Name_Field) _Column() string { return "name" }
+
+type Offer_Description_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Offer_Description(v string) Offer_Description_Field {
+	return Offer_Description_Field{_set: true, _value: v}
+}
+
+func (f Offer_Description_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_Description_Field) _Column() string { return "description" }
+
+type Offer_Type_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Offer_Type(v int) Offer_Type_Field {
+	return Offer_Type_Field{_set: true, _value: v}
+}
+
+func (f Offer_Type_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_Type_Field) _Column() string { return "type" }
+
+type Offer_CreditInCents_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Offer_CreditInCents(v int) Offer_CreditInCents_Field {
+	return Offer_CreditInCents_Field{_set: true, _value: v}
+}
+
+func (f Offer_CreditInCents_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_CreditInCents_Field) _Column() string { return "credit_in_cents" }
+
+type Offer_AwardCreditDurationDays_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Offer_AwardCreditDurationDays(v int) Offer_AwardCreditDurationDays_Field {
+	return Offer_AwardCreditDurationDays_Field{_set: true, _value: v}
+}
+
+func (f Offer_AwardCreditDurationDays_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_AwardCreditDurationDays_Field) _Column() string { return "award_credit_duration_days" }
+
+type Offer_InviteeCreditDurationDays_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Offer_InviteeCreditDurationDays(v int) Offer_InviteeCreditDurationDays_Field {
+	return Offer_InviteeCreditDurationDays_Field{_set: true, _value: v}
+}
+
+func (f Offer_InviteeCreditDurationDays_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_InviteeCreditDurationDays_Field) _Column() string { return "invitee_credit_duration_days" }
+
+type Offer_RedeemableCap_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Offer_RedeemableCap(v int) Offer_RedeemableCap_Field {
+	return Offer_RedeemableCap_Field{_set: true, _value: v}
+}
+
+func (f Offer_RedeemableCap_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_RedeemableCap_Field) _Column() string { return "redeemable_cap" }
+
+type Offer_NumRedeemed_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Offer_NumRedeemed(v int) Offer_NumRedeemed_Field {
+	return Offer_NumRedeemed_Field{_set: true, _value: v}
+}
+
+func (f Offer_NumRedeemed_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_NumRedeemed_Field) _Column() string { return "num_redeemed" }
+
+type Offer_ExpiresAt_Field struct {
+	_set   bool
+	_null  bool
+	_value *time.Time
+}
+
+func Offer_ExpiresAt(v time.Time) Offer_ExpiresAt_Field {
+	return Offer_ExpiresAt_Field{_set: true, _value: &v}
+}
+
+func Offer_ExpiresAt_Raw(v *time.Time) Offer_ExpiresAt_Field {
+	if v == nil {
+		return Offer_ExpiresAt_Null()
+	}
+	return Offer_ExpiresAt(*v)
+}
+
+func Offer_ExpiresAt_Null() Offer_ExpiresAt_Field {
+	return Offer_ExpiresAt_Field{_set: true, _null: true}
+}
+
+func (f Offer_ExpiresAt_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f Offer_ExpiresAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_ExpiresAt_Field) _Column() string { return "expires_at" }
+
+type Offer_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Offer_CreatedAt(v time.Time) Offer_CreatedAt_Field {
+	return Offer_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f Offer_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_CreatedAt_Field) _Column() string { return "created_at" }
+
+type Offer_Status_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Offer_Status(v int) Offer_Status_Field {
+	return Offer_Status_Field{_set: true, _value: v}
+}
+
+func (f Offer_Status_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Offer_Status_Field) _Column() string { return "status" }
+
+type PendingAudits struct {
+	NodeId            []byte
+	PieceId           []byte
+	StripeIndex       int64
+	ShareSize         int64
+	ExpectedShareHash []byte
+	ReverifyCount     int64
+}
+
+func (PendingAudits) _Table() string { return "pending_audits" }
+
+type PendingAudits_Update_Fields struct {
+	ReverifyCount PendingAudits_ReverifyCount_Field
+}
+
+type PendingAudits_NodeId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func PendingAudits_NodeId(v []byte) PendingAudits_NodeId_Field {
+	return PendingAudits_NodeId_Field{_set: true, _value: v}
+}
+
+func (f PendingAudits_NodeId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
@@ -3004,605 +4111,1027 @@ func (f PendingAudits_NodeId_Field) value() interface{} {
 
 func (PendingAudits_NodeId_Field) _Column() string { return "node_id" }
 
-type PendingAudits_PieceId_Field struct {
+type PendingAudits_PieceId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func PendingAudits_PieceId(v []byte) PendingAudits_PieceId_Field {
+	return PendingAudits_PieceId_Field{_set: true, _value: v}
+}
+
+func (f PendingAudits_PieceId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (PendingAudits_PieceId_Field) _Column() string { return "piece_id" }
+
+type PendingAudits_StripeIndex_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func PendingAudits_StripeIndex(v int64) PendingAudits_StripeIndex_Field {
+	return PendingAudits_StripeIndex_Field{_set: true, _value: v}
+}
+
+func (f PendingAudits_StripeIndex_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (PendingAudits_StripeIndex_Field) _Column() string { return "stripe_index" }
+
+type PendingAudits_ShareSize_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func PendingAudits_ShareSize(v int64) PendingAudits_ShareSize_Field {
+	return PendingAudits_ShareSize_Field{_set: true, _value: v}
+}
+
+func (f PendingAudits_ShareSize_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (PendingAudits_ShareSize_Field) _Column() string { return "share_size" }
+
+type PendingAudits_ExpectedShareHash_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func PendingAudits_ExpectedShareHash(v []byte) PendingAudits_ExpectedShareHash_Field {
+	return PendingAudits_ExpectedShareHash_Field{_set: true, _value: v}
+}
+
+func (f PendingAudits_ExpectedShareHash_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (PendingAudits_ExpectedShareHash_Field) _Column() string { return "expected_share_hash" }
+
+type PendingAudits_ReverifyCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func PendingAudits_ReverifyCount(v int64) PendingAudits_ReverifyCount_Field {
+	return PendingAudits_ReverifyCount_Field{_set: true, _value: v}
+}
+
+func (f PendingAudits_ReverifyCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (PendingAudits_ReverifyCount_Field) _Column() string { return "reverify_count" }
+
+type Project struct {
+	Id          []byte
+	Name        string
+	Description string
+	UsageLimit  int64
+	CreatedAt   time.Time
+}
+
+func (Project) _Table() string { return "projects" }
+
+type Project_Update_Fields struct {
+	Description Project_Description_Field
+	UsageLimit  Project_UsageLimit_Field
+}
+
+type Project_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Project_Id(v []byte) Project_Id_Field {
+	return Project_Id_Field{_set: true, _value: v}
+}
+
+func (f Project_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_Id_Field) _Column() string { return "id" }
+
+type Project_Name_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Project_Name(v string) Project_Name_Field {
+	return Project_Name_Field{_set: true, _value: v}
+}
+
+func (f Project_Name_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_Name_Field) _Column() string { return "name" }
+
+type Project_Description_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func Project_Description(v string) Project_Description_Field {
+	return Project_Description_Field{_set: true, _value: v}
+}
+
+func (f Project_Description_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_Description_Field) _Column() string { return "description" }
+
+type Project_UsageLimit_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func Project_UsageLimit(v int64) Project_UsageLimit_Field {
+	return Project_UsageLimit_Field{_set: true, _value: v}
+}
+
+func (f Project_UsageLimit_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_UsageLimit_Field) _Column() string { return "usage_limit" }
+
+type Project_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Project_CreatedAt(v time.Time) Project_CreatedAt_Field {
+	return Project_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f Project_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Project_CreatedAt_Field) _Column() string { return "created_at" }
+
+type RegistrationToken struct {
+	Secret       []byte
+	OwnerId      []byte
+	ProjectLimit int
+	CreatedAt    time.Time
+}
+
+func (RegistrationToken) _Table() string { return "registration_tokens" }
+
+type RegistrationToken_Create_Fields struct {
+	OwnerId RegistrationToken_OwnerId_Field
+}
+
+type RegistrationToken_Update_Fields struct {
+	OwnerId RegistrationToken_OwnerId_Field
+}
+
+type RegistrationToken_Secret_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func RegistrationToken_Secret(v []byte) RegistrationToken_Secret_Field {
+	return RegistrationToken_Secret_Field{_set: true, _value: v}
+}
+
+func (f RegistrationToken_Secret_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RegistrationToken_Secret_Field) _Column() string { return "secret" }
+
+type RegistrationToken_OwnerId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func RegistrationToken_OwnerId(v []byte) RegistrationToken_OwnerId_Field {
+	return RegistrationToken_OwnerId_Field{_set: true, _value: v}
+}
+
+func RegistrationToken_OwnerId_Raw(v []byte) RegistrationToken_OwnerId_Field {
+	if v == nil {
+		return RegistrationToken_OwnerId_Null()
+	}
+	return RegistrationToken_OwnerId(v)
+}
+
+func RegistrationToken_OwnerId_Null() RegistrationToken_OwnerId_Field {
+	return RegistrationToken_OwnerId_Field{_set: true, _null: true}
+}
+
+func (f RegistrationToken_OwnerId_Field) isnull() bool { return !f._set || f._null || f._value == nil }
+
+func (f RegistrationToken_OwnerId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RegistrationToken_OwnerId_Field) _Column() string { return "owner_id" }
+
+type RegistrationToken_ProjectLimit_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func RegistrationToken_ProjectLimit(v int) RegistrationToken_ProjectLimit_Field {
+	return RegistrationToken_ProjectLimit_Field{_set: true, _value: v}
+}
+
+func (f RegistrationToken_ProjectLimit_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RegistrationToken_ProjectLimit_Field) _Column() string { return "project_limit" }
+
+type RegistrationToken_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func RegistrationToken_CreatedAt(v time.Time) RegistrationToken_CreatedAt_Field {
+	return RegistrationToken_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f RegistrationToken_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (RegistrationToken_CreatedAt_Field) _Column() string { return "created_at" }
+
+type ResetPasswordToken struct {
+	Secret    []byte
+	OwnerId   []byte
+	CreatedAt time.Time
+}
+
+func (ResetPasswordToken) _Table() string { return "reset_password_tokens" }
+
+type ResetPasswordToken_Update_Fields struct {
+	OwnerId ResetPasswordToken_OwnerId_Field
+}
+
+type ResetPasswordToken_Secret_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ResetPasswordToken_Secret(v []byte) ResetPasswordToken_Secret_Field {
+	return ResetPasswordToken_Secret_Field{_set: true, _value: v}
+}
+
+func (f ResetPasswordToken_Secret_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ResetPasswordToken_Secret_Field) _Column() string { return "secret" }
+
+type ResetPasswordToken_OwnerId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func ResetPasswordToken_OwnerId(v []byte) ResetPasswordToken_OwnerId_Field {
+	return ResetPasswordToken_OwnerId_Field{_set: true, _value: v}
+}
+
+func (f ResetPasswordToken_OwnerId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ResetPasswordToken_OwnerId_Field) _Column() string { return "owner_id" }
+
+type ResetPasswordToken_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func ResetPasswordToken_CreatedAt(v time.Time) ResetPasswordToken_CreatedAt_Field {
+	return ResetPasswordToken_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f ResetPasswordToken_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (ResetPasswordToken_CreatedAt_Field) _Column() string { return "created_at" }
+
+type SerialNumber struct {
+	Id           int
+	SerialNumber []byte
+	BucketId     []byte
+	ExpiresAt    time.Time
+}
+
+func (SerialNumber) _Table() string { return "serial_numbers" }
+
+type SerialNumber_Update_Fields struct {
+}
+
+type SerialNumber_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func SerialNumber_Id(v int) SerialNumber_Id_Field {
+	return SerialNumber_Id_Field{_set: true, _value: v}
+}
+
+func (f SerialNumber_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SerialNumber_Id_Field) _Column() string { return "id" }
+
+type SerialNumber_SerialNumber_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func SerialNumber_SerialNumber(v []byte) SerialNumber_SerialNumber_Field {
+	return SerialNumber_SerialNumber_Field{_set: true, _value: v}
+}
+
+func (f SerialNumber_SerialNumber_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SerialNumber_SerialNumber_Field) _Column() string { return "serial_number" }
+
+type SerialNumber_BucketId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func SerialNumber_BucketId(v []byte) SerialNumber_BucketId_Field {
+	return SerialNumber_BucketId_Field{_set: true, _value: v}
+}
+
+func (f SerialNumber_BucketId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SerialNumber_BucketId_Field) _Column() string { return "bucket_id" }
+
+type SerialNumber_ExpiresAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func SerialNumber_ExpiresAt(v time.Time) SerialNumber_ExpiresAt_Field {
+	v = toUTC(v)
+	return SerialNumber_ExpiresAt_Field{_set: true, _value: v}
+}
+
+func (f SerialNumber_ExpiresAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (SerialNumber_ExpiresAt_Field) _Column() string { return "expires_at" }
+
+type StoragenodeBandwidthRollup struct {
+	StoragenodeId   []byte
+	IntervalStart   time.Time
+	IntervalSeconds uint
+	Action          uint
+	Allocated       uint64
+	Settled         uint64
+}
+
+func (StoragenodeBandwidthRollup) _Table() string { return "storagenode_bandwidth_rollups" }
+
+type StoragenodeBandwidthRollup_Update_Fields struct {
+	Allocated StoragenodeBandwidthRollup_Allocated_Field
+	Settled   StoragenodeBandwidthRollup_Settled_Field
+}
+
+type StoragenodeBandwidthRollup_StoragenodeId_Field struct {
 	_set   bool
 	_null  bool
 	_value []byte
 }
 
-func PendingAudits_PieceId(v []byte) PendingAudits_PieceId_Field {
-	return PendingAudits_PieceId_Field{_set: true, _value: v}
+func StoragenodeBandwidthRollup_StoragenodeId(v []byte) StoragenodeBandwidthRollup_StoragenodeId_Field {
+	return StoragenodeBandwidthRollup_StoragenodeId_Field{_set: true, _value: v}
 }
 
-func (f PendingAudits_PieceId_Field) value() interface{} {
+func (f StoragenodeBandwidthRollup_StoragenodeId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (PendingAudits_PieceId_Field) _Column() string { return "piece_id" }
+func (StoragenodeBandwidthRollup_StoragenodeId_Field) _Column() string { return "storagenode_id" }
 
-type PendingAudits_StripeIndex_Field struct {
+type StoragenodeBandwidthRollup_IntervalStart_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value time.Time
 }
 
-func PendingAudits_StripeIndex(v int64) PendingAudits_StripeIndex_Field {
-	return PendingAudits_StripeIndex_Field{_set: true, _value: v}
+func StoragenodeBandwidthRollup_IntervalStart(v time.Time) StoragenodeBandwidthRollup_IntervalStart_Field {
+	v = toUTC(v)
+	return StoragenodeBandwidthRollup_IntervalStart_Field{_set: true, _value: v}
 }
 
-func (f PendingAudits_StripeIndex_Field) value() interface{} {
+func (f StoragenodeBandwidthRollup_IntervalStart_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (PendingAudits_StripeIndex_Field) _Column() string { return "stripe_index" }
+func (StoragenodeBandwidthRollup_IntervalStart_Field) _Column() string { return "interval_start" }
 
-type PendingAudits_ShareSize_Field struct {
+type StoragenodeBandwidthRollup_IntervalSeconds_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value uint
 }
 
-func PendingAudits_ShareSize(v int64) PendingAudits_ShareSize_Field {
-	return PendingAudits_ShareSize_Field{_set: true, _value: v}
+func StoragenodeBandwidthRollup_IntervalSeconds(v uint) StoragenodeBandwidthRollup_IntervalSeconds_Field {
+	return StoragenodeBandwidthRollup_IntervalSeconds_Field{_set: true, _value: v}
 }
 
-func (f PendingAudits_ShareSize_Field) value() interface{} {
+func (f StoragenodeBandwidthRollup_IntervalSeconds_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (PendingAudits_ShareSize_Field) _Column() string { return "share_size" }
+func (StoragenodeBandwidthRollup_IntervalSeconds_Field) _Column() string { return "interval_seconds" }
 
-type PendingAudits_ExpectedShareHash_Field struct {
+type StoragenodeBandwidthRollup_Action_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value uint
 }
 
-func PendingAudits_ExpectedShareHash(v []byte) PendingAudits_ExpectedShareHash_Field {
-	return PendingAudits_ExpectedShareHash_Field{_set: true, _value: v}
+func StoragenodeBandwidthRollup_Action(v uint) StoragenodeBandwidthRollup_Action_Field {
+	return StoragenodeBandwidthRollup_Action_Field{_set: true, _value: v}
 }
 
-func (f PendingAudits_ExpectedShareHash_Field) value() interface{} {
+func (f StoragenodeBandwidthRollup_Action_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (PendingAudits_ExpectedShareHash_Field) _Column() string { return "expected_share_hash" }
+func (StoragenodeBandwidthRollup_Action_Field) _Column() string { return "action" }
 
-type PendingAudits_ReverifyCount_Field struct {
+type StoragenodeBandwidthRollup_Allocated_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value uint64
 }
 
-func PendingAudits_ReverifyCount(v int64) PendingAudits_ReverifyCount_Field {
-	return PendingAudits_ReverifyCount_Field{_set: true, _value: v}
+func StoragenodeBandwidthRollup_Allocated(v uint64) StoragenodeBandwidthRollup_Allocated_Field {
+	return StoragenodeBandwidthRollup_Allocated_Field{_set: true, _value: v}
 }
 
-func (f PendingAudits_ReverifyCount_Field) value() interface{} {
+func (f StoragenodeBandwidthRollup_Allocated_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (PendingAudits_ReverifyCount_Field) _Column() string { return "reverify_count" }
-
-type Project struct {
-	Id          []byte
-	Name        string
-	Description string
-	UsageLimit  int64
-	CreatedAt   time.Time
-}
-
-func (Project) _Table() string { return "projects" }
-
-type Project_Update_Fields struct {
-	Description Project_Description_Field
-	UsageLimit  Project_UsageLimit_Field
-}
+func (StoragenodeBandwidthRollup_Allocated_Field) _Column() string { return "allocated" }
 
-type Project_Id_Field struct {
+type StoragenodeBandwidthRollup_Settled_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value uint64
 }
 
-func Project_Id(v []byte) Project_Id_Field {
-	return Project_Id_Field{_set: true, _value: v}
+func StoragenodeBandwidthRollup_Settled(v uint64) StoragenodeBandwidthRollup_Settled_Field {
+	return StoragenodeBandwidthRollup_Settled_Field{_set: true, _value: v}
 }
 
-func (f Project_Id_Field) value() interface{} {
+func (f StoragenodeBandwidthRollup_Settled_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_Id_Field) _Column() string { return "id" }
+func (StoragenodeBandwidthRollup_Settled_Field) _Column() string { return "settled" }
 
-type Project_Name_Field struct {
+type StoragenodeFraud struct {
+	StoragenodeId []byte
+	Reason        int
+	Count         int64
+	LastSeenAt    time.Time
+}
+
+func (StoragenodeFraud) _Table() string { return "storagenode_frauds" }
+
+type StoragenodeFraud_Update_Fields struct {
+	Count      StoragenodeFraud_Count_Field
+	LastSeenAt StoragenodeFraud_LastSeenAt_Field
+}
+
+type StoragenodeFraud_StoragenodeId_Field struct {
 	_set   bool
 	_null  bool
-	_value string
+	_value []byte
 }
 
-func Project_Name(v string) Project_Name_Field {
-	return Project_Name_Field{_set: true, _value: v}
+func StoragenodeFraud_StoragenodeId(v []byte) StoragenodeFraud_StoragenodeId_Field {
+	return StoragenodeFraud_StoragenodeId_Field{_set: true, _value: v}
 }
 
-func (f Project_Name_Field) value() interface{} {
+func (f StoragenodeFraud_StoragenodeId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_Name_Field) _Column() string { return "name" }
+func (StoragenodeFraud_StoragenodeId_Field) _Column() string { return "storagenode_id" }
 
-type Project_Description_Field struct {
+type StoragenodeFraud_Reason_Field struct {
 	_set   bool
 	_null  bool
-	_value string
+	_value int
 }
 
-func Project_Description(v string) Project_Description_Field {
-	return Project_Description_Field{_set: true, _value: v}
+func StoragenodeFraud_Reason(v int) StoragenodeFraud_Reason_Field {
+	return StoragenodeFraud_Reason_Field{_set: true, _value: v}
 }
 
-func (f Project_Description_Field) value() interface{} {
+func (f StoragenodeFraud_Reason_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_Description_Field) _Column() string { return "description" }
+func (StoragenodeFraud_Reason_Field) _Column() string { return "reason" }
 
-type Project_UsageLimit_Field struct {
+type StoragenodeFraud_Count_Field struct {
 	_set   bool
 	_null  bool
 	_value int64
 }
 
-func Project_UsageLimit(v int64) Project_UsageLimit_Field {
-	return Project_UsageLimit_Field{_set: true, _value: v}
+func StoragenodeFraud_Count(v int64) StoragenodeFraud_Count_Field {
+	return StoragenodeFraud_Count_Field{_set: true, _value: v}
 }
 
-func (f Project_UsageLimit_Field) value() interface{} {
+func (f StoragenodeFraud_Count_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_UsageLimit_Field) _Column() string { return "usage_limit" }
+func (StoragenodeFraud_Count_Field) _Column() string { return "count" }
 
-type Project_CreatedAt_Field struct {
+type StoragenodeFraud_LastSeenAt_Field struct {
 	_set   bool
 	_null  bool
 	_value time.Time
 }
 
-func Project_CreatedAt(v time.Time) Project_CreatedAt_Field {
-	return Project_CreatedAt_Field{_set: true, _value: v}
+func StoragenodeFraud_LastSeenAt(v time.Time) StoragenodeFraud_LastSeenAt_Field {
+	v = toUTC(v)
+	return StoragenodeFraud_LastSeenAt_Field{_set: true, _value: v}
 }
 
-func (f Project_CreatedAt_Field) value() interface{} {
+func (f StoragenodeFraud_LastSeenAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Project_CreatedAt_Field) _Column() string { return "created_at" }
-
-type RegistrationToken struct {
-	Secret       []byte
-	OwnerId      []byte
-	ProjectLimit int
-	CreatedAt    time.Time
-}
-
-func (RegistrationToken) _Table() string { return "registration_tokens" }
-
-type RegistrationToken_Create_Fields struct {
-	OwnerId RegistrationToken_OwnerId_Field
-}
-
-type RegistrationToken_Update_Fields struct {
-	OwnerId RegistrationToken_OwnerId_Field
-}
+func (StoragenodeFraud_LastSeenAt_Field) _Column() string { return "last_seen_at" }
 
-type RegistrationToken_Secret_Field struct {
-	_set   bool
-	_null  bool
-	_value []byte
+type StoragenodeStatement struct {
+	NodeId         []byte
+	Period         time.Time
+	NodeCreatedAt  time.Time
+	Wallet         string
+	AtRestTotal    float64
+	GetTotal       int64
+	GetRepairTotal int64
+	GetAuditTotal  int64
+	StorageAmount  int64
+	EgressAmount   int64
+	RepairAmount   int64
+	AuditAmount    int64
+	HeldPercent    int
+	HeldAmount     int64
+	PayoutAmount   int64
+	CreatedAt      time.Time
 }
 
-func RegistrationToken_Secret(v []byte) RegistrationToken_Secret_Field {
-	return RegistrationToken_Secret_Field{_set: true, _value: v}
-}
+func (StoragenodeStatement) _Table() string { return "storagenode_statements" }
 
-func (f RegistrationToken_Secret_Field) value() interface{} {
-	if !f._set || f._null {
-		return nil
-	}
-	return f._value
+type StoragenodeStatement_Update_Fields struct {
 }
 
-func (RegistrationToken_Secret_Field) _Column() string { return "secret" }
-
-type RegistrationToken_OwnerId_Field struct {
+type StoragenodeStatement_NodeId_Field struct {
 	_set   bool
 	_null  bool
 	_value []byte
 }
 
-func RegistrationToken_OwnerId(v []byte) RegistrationToken_OwnerId_Field {
-	return RegistrationToken_OwnerId_Field{_set: true, _value: v}
-}
-
-func RegistrationToken_OwnerId_Raw(v []byte) RegistrationToken_OwnerId_Field {
-	if v == nil {
-		return RegistrationToken_OwnerId_Null()
-	}
-	return RegistrationToken_OwnerId(v)
-}
-
-func RegistrationToken_OwnerId_Null() RegistrationToken_OwnerId_Field {
-	return RegistrationToken_OwnerId_Field{_set: true, _null: true}
+func StoragenodeStatement_NodeId(v []byte) StoragenodeStatement_NodeId_Field {
+	return StoragenodeStatement_NodeId_Field{_set: true, _value: v}
 }
 
-func (f RegistrationToken_OwnerId_Field) isnull() bool { return !f._set || f._null || f._value == nil }
-
-func (f RegistrationToken_OwnerId_Field) value() interface{} {
+func (f StoragenodeStatement_NodeId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (RegistrationToken_OwnerId_Field) _Column() string { return "owner_id" }
+func (StoragenodeStatement_NodeId_Field) _Column() string { return "node_id" }
 
-type RegistrationToken_ProjectLimit_Field struct {
+type StoragenodeStatement_Period_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value time.Time
 }
 
-func RegistrationToken_ProjectLimit(v int) RegistrationToken_ProjectLimit_Field {
-	return RegistrationToken_ProjectLimit_Field{_set: true, _value: v}
+func StoragenodeStatement_Period(v time.Time) StoragenodeStatement_Period_Field {
+	v = toUTC(v)
+	return StoragenodeStatement_Period_Field{_set: true, _value: v}
 }
 
-func (f RegistrationToken_ProjectLimit_Field) value() interface{} {
+func (f StoragenodeStatement_Period_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (RegistrationToken_ProjectLimit_Field) _Column() string { return "project_limit" }
+func (StoragenodeStatement_Period_Field) _Column() string { return "period" }
 
-type RegistrationToken_CreatedAt_Field struct {
+type StoragenodeStatement_NodeCreatedAt_Field struct {
 	_set   bool
 	_null  bool
 	_value time.Time
 }
 
-func RegistrationToken_CreatedAt(v time.Time) RegistrationToken_CreatedAt_Field {
-	return RegistrationToken_CreatedAt_Field{_set: true, _value: v}
+func StoragenodeStatement_NodeCreatedAt(v time.Time) StoragenodeStatement_NodeCreatedAt_Field {
+	v = toUTC(v)
+	return StoragenodeStatement_NodeCreatedAt_Field{_set: true, _value: v}
 }
 
-func (f RegistrationToken_CreatedAt_Field) value() interface{} {
+func (f StoragenodeStatement_NodeCreatedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (RegistrationToken_CreatedAt_Field) _Column() string { return "created_at" }
-
-type ResetPasswordToken struct {
-	Secret    []byte
-	OwnerId   []byte
-	CreatedAt time.Time
-}
-
-func (ResetPasswordToken) _Table() string { return "reset_password_tokens" }
-
-type ResetPasswordToken_Update_Fields struct {
-	OwnerId ResetPasswordToken_OwnerId_Field
-}
+func (StoragenodeStatement_NodeCreatedAt_Field) _Column() string { return "node_created_at" }
 
-type ResetPasswordToken_Secret_Field struct {
+type StoragenodeStatement_Wallet_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value string
 }
 
-func ResetPasswordToken_Secret(v []byte) ResetPasswordToken_Secret_Field {
-	return ResetPasswordToken_Secret_Field{_set: true, _value: v}
+func StoragenodeStatement_Wallet(v string) StoragenodeStatement_Wallet_Field {
+	return StoragenodeStatement_Wallet_Field{_set: true, _value: v}
 }
 
-func (f ResetPasswordToken_Secret_Field) value() interface{} {
+func (f StoragenodeStatement_Wallet_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (ResetPasswordToken_Secret_Field) _Column() string { return "secret" }
+func (StoragenodeStatement_Wallet_Field) _Column() string { return "wallet" }
 
-type ResetPasswordToken_OwnerId_Field struct {
+type StoragenodeStatement_AtRestTotal_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value float64
 }
 
-func ResetPasswordToken_OwnerId(v []byte) ResetPasswordToken_OwnerId_Field {
-	return ResetPasswordToken_OwnerId_Field{_set: true, _value: v}
+func StoragenodeStatement_AtRestTotal(v float64) StoragenodeStatement_AtRestTotal_Field {
+	return StoragenodeStatement_AtRestTotal_Field{_set: true, _value: v}
 }
 
-func (f ResetPasswordToken_OwnerId_Field) value() interface{} {
+func (f StoragenodeStatement_AtRestTotal_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (ResetPasswordToken_OwnerId_Field) _Column() string { return "owner_id" }
+func (StoragenodeStatement_AtRestTotal_Field) _Column() string { return "at_rest_total" }
 
-type ResetPasswordToken_CreatedAt_Field struct {
+type StoragenodeStatement_GetTotal_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value int64
 }
 
-func ResetPasswordToken_CreatedAt(v time.Time) ResetPasswordToken_CreatedAt_Field {
-	return ResetPasswordToken_CreatedAt_Field{_set: true, _value: v}
+func StoragenodeStatement_GetTotal(v int64) StoragenodeStatement_GetTotal_Field {
+	return StoragenodeStatement_GetTotal_Field{_set: true, _value: v}
 }
 
-func (f ResetPasswordToken_CreatedAt_Field) value() interface{} {
+func (f StoragenodeStatement_GetTotal_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (ResetPasswordToken_CreatedAt_Field) _Column() string { return "created_at" }
-
-type SerialNumber struct {
-	Id           int
-	SerialNumber []byte
-	BucketId     []byte
-	ExpiresAt    time.Time
-}
-
-func (SerialNumber) _Table() string { return "serial_numbers" }
-
-type SerialNumber_Update_Fields struct {
-}
+func (StoragenodeStatement_GetTotal_Field) _Column() string { return "get_total" }
 
-type SerialNumber_Id_Field struct {
+type StoragenodeStatement_GetRepairTotal_Field struct {
 	_set   bool
 	_null  bool
-	_value int
+	_value int64
 }
 
-func SerialNumber_Id(v int) SerialNumber_Id_Field {
-	return SerialNumber_Id_Field{_set: true, _value: v}
+func StoragenodeStatement_GetRepairTotal(v int64) StoragenodeStatement_GetRepairTotal_Field {
+	return StoragenodeStatement_GetRepairTotal_Field{_set: true, _value: v}
 }
 
-func (f SerialNumber_Id_Field) value() interface{} {
+func (f StoragenodeStatement_GetRepairTotal_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (SerialNumber_Id_Field) _Column() string { return "id" }
+func (StoragenodeStatement_GetRepairTotal_Field) _Column() string { return "get_repair_total" }
 
-type SerialNumber_SerialNumber_Field struct {
+type StoragenodeStatement_GetAuditTotal_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value int64
 }
 
-func SerialNumber_SerialNumber(v []byte) SerialNumber_SerialNumber_Field {
-	return SerialNumber_SerialNumber_Field{_set: true, _value: v}
+func StoragenodeStatement_GetAuditTotal(v int64) StoragenodeStatement_GetAuditTotal_Field {
+	return StoragenodeStatement_GetAuditTotal_Field{_set: true, _value: v}
 }
 
-func (f SerialNumber_SerialNumber_Field) value() interface{} {
+func (f StoragenodeStatement_GetAuditTotal_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (SerialNumber_SerialNumber_Field) _Column() string { return "serial_number" }
+func (StoragenodeStatement_GetAuditTotal_Field) _Column() string { return "get_audit_total" }
 
-type SerialNumber_BucketId_Field struct {
+type StoragenodeStatement_StorageAmount_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value int64
 }
 
-func SerialNumber_BucketId(v []byte) SerialNumber_BucketId_Field {
-	return SerialNumber_BucketId_Field{_set: true, _value: v}
+func StoragenodeStatement_StorageAmount(v int64) StoragenodeStatement_StorageAmount_Field {
+	return StoragenodeStatement_StorageAmount_Field{_set: true, _value: v}
 }
 
-func (f SerialNumber_BucketId_Field) value() interface{} {
+func (f StoragenodeStatement_StorageAmount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (SerialNumber_BucketId_Field) _Column() string { return "bucket_id" }
+func (StoragenodeStatement_StorageAmount_Field) _Column() string { return "storage_amount" }
 
-type SerialNumber_ExpiresAt_Field struct {
+type StoragenodeStatement_EgressAmount_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value int64
 }
-
-func SerialNumber_ExpiresAt(v time.Time) SerialNumber_ExpiresAt_Field {
-	v = toUTC(v)
-	return SerialNumber_ExpiresAt_Field{_set: true, _value: v}
+
+func StoragenodeStatement_EgressAmount(v int64) StoragenodeStatement_EgressAmount_Field {
+	return StoragenodeStatement_EgressAmount_Field{_set: true, _value: v}
 }
 
-func (f SerialNumber_ExpiresAt_Field) value() interface{} {
+func (f StoragenodeStatement_EgressAmount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (SerialNumber_ExpiresAt_Field) _Column() string { return "expires_at" }
-
-type StoragenodeBandwidthRollup struct {
-	StoragenodeId   []byte
-	IntervalStart   time.Time
-	IntervalSeconds uint
-	Action          uint
-	Allocated       uint64
-	Settled         uint64
-}
-
-func (StoragenodeBandwidthRollup) _Table() string { return "storagenode_bandwidth_rollups" }
-
-type StoragenodeBandwidthRollup_Update_Fields struct {
-	Allocated StoragenodeBandwidthRollup_Allocated_Field
-	Settled   StoragenodeBandwidthRollup_Settled_Field
-}
+func (StoragenodeStatement_EgressAmount_Field) _Column() string { return "egress_amount" }
 
-type StoragenodeBandwidthRollup_StoragenodeId_Field struct {
+type StoragenodeStatement_RepairAmount_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value int64
 }
 
-func StoragenodeBandwidthRollup_StoragenodeId(v []byte) StoragenodeBandwidthRollup_StoragenodeId_Field {
-	return StoragenodeBandwidthRollup_StoragenodeId_Field{_set: true, _value: v}
+func StoragenodeStatement_RepairAmount(v int64) StoragenodeStatement_RepairAmount_Field {
+	return StoragenodeStatement_RepairAmount_Field{_set: true, _value: v}
 }
 
-func (f StoragenodeBandwidthRollup_StoragenodeId_Field) value() interface{} {
+func (f StoragenodeStatement_RepairAmount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (StoragenodeBandwidthRollup_StoragenodeId_Field) _Column() string { return "storagenode_id" }
+func (StoragenodeStatement_RepairAmount_Field) _Column() string { return "repair_amount" }
 
-type StoragenodeBandwidthRollup_IntervalStart_Field struct {
+type StoragenodeStatement_AuditAmount_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value int64
 }
 
-func StoragenodeBandwidthRollup_IntervalStart(v time.Time) StoragenodeBandwidthRollup_IntervalStart_Field {
-	v = toUTC(v)
-	return StoragenodeBandwidthRollup_IntervalStart_Field{_set: true, _value: v}
+func StoragenodeStatement_AuditAmount(v int64) StoragenodeStatement_AuditAmount_Field {
+	return StoragenodeStatement_AuditAmount_Field{_set: true, _value: v}
 }
 
-func (f StoragenodeBandwidthRollup_IntervalStart_Field) value() interface{} {
+func (f StoragenodeStatement_AuditAmount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (StoragenodeBandwidthRollup_IntervalStart_Field) _Column() string { return "interval_start" }
+func (StoragenodeStatement_AuditAmount_Field) _Column() string { return "audit_amount" }
 
-type StoragenodeBandwidthRollup_IntervalSeconds_Field struct {
+type StoragenodeStatement_HeldPercent_Field struct {
 	_set   bool
 	_null  bool
-	_value uint
+	_value int
 }
 
-func StoragenodeBandwidthRollup_IntervalSeconds(v uint) StoragenodeBandwidthRollup_IntervalSeconds_Field {
-	return StoragenodeBandwidthRollup_IntervalSeconds_Field{_set: true, _value: v}
+func StoragenodeStatement_HeldPercent(v int) StoragenodeStatement_HeldPercent_Field {
+	return StoragenodeStatement_HeldPercent_Field{_set: true, _value: v}
 }
 
-func (f StoragenodeBandwidthRollup_IntervalSeconds_Field) value() interface{} {
+func (f StoragenodeStatement_HeldPercent_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (StoragenodeBandwidthRollup_IntervalSeconds_Field) _Column() string { return "interval_seconds" }
+func (StoragenodeStatement_HeldPercent_Field) _Column() string { return "held_percent" }
 
-type StoragenodeBandwidthRollup_Action_Field struct {
+type StoragenodeStatement_HeldAmount_Field struct {
 	_set   bool
 	_null  bool
-	_value uint
+	_value int64
 }
 
-func StoragenodeBandwidthRollup_Action(v uint) StoragenodeBandwidthRollup_Action_Field {
-	return StoragenodeBandwidthRollup_Action_Field{_set: true, _value: v}
+func StoragenodeStatement_HeldAmount(v int64) StoragenodeStatement_HeldAmount_Field {
+	return StoragenodeStatement_HeldAmount_Field{_set: true, _value: v}
 }
 
-func (f StoragenodeBandwidthRollup_Action_Field) value() interface{} {
+func (f StoragenodeStatement_HeldAmount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (StoragenodeBandwidthRollup_Action_Field) _Column() string { return "action" }
+func (StoragenodeStatement_HeldAmount_Field) _Column() string { return "held_amount" }
 
-type StoragenodeBandwidthRollup_Allocated_Field struct {
+type StoragenodeStatement_PayoutAmount_Field struct {
 	_set   bool
 	_null  bool
-	_value uint64
+	_value int64
 }
 
-func StoragenodeBandwidthRollup_Allocated(v uint64) StoragenodeBandwidthRollup_Allocated_Field {
-	return StoragenodeBandwidthRollup_Allocated_Field{_set: true, _value: v}
+func StoragenodeStatement_PayoutAmount(v int64) StoragenodeStatement_PayoutAmount_Field {
+	return StoragenodeStatement_PayoutAmount_Field{_set: true, _value: v}
 }
 
-func (f StoragenodeBandwidthRollup_Allocated_Field) value() interface{} {
+func (f StoragenodeStatement_PayoutAmount_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (StoragenodeBandwidthRollup_Allocated_Field) _Column() string { return "allocated" }
+func (StoragenodeStatement_PayoutAmount_Field) _Column() string { return "payout_amount" }
 
-type StoragenodeBandwidthRollup_Settled_Field struct {
+type StoragenodeStatement_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value uint64
+	_value time.Time
 }
 
-func StoragenodeBandwidthRollup_Settled(v uint64) StoragenodeBandwidthRollup_Settled_Field {
-	return StoragenodeBandwidthRollup_Settled_Field{_set: true, _value: v}
+func StoragenodeStatement_CreatedAt(v time.Time) StoragenodeStatement_CreatedAt_Field {
+	v = toUTC(v)
+	return StoragenodeStatement_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f StoragenodeBandwidthRollup_Settled_Field) value() interface{} {
+func (f StoragenodeStatement_CreatedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (StoragenodeBandwidthRollup_Settled_Field) _Column() string { return "settled" }
+func (StoragenodeStatement_CreatedAt_Field) _Column() string { return "created_at" }
 
 type StoragenodeStorageTally struct {
 	Id              int64
@@ -3991,6 +5520,154 @@ func (f ApiKey_CreatedAt_Field) value() interface{} {
 
 func (ApiKey_CreatedAt_Field) _Column() string { return "created_at" }
 
+type InvoiceLineItem struct {
+	InvoiceId   []byte
+	Position    int
+	Kind        int
+	Description string
+	Quantity    float64
+	UnitPrice   float64
+	Amount      int64
+}
+
+func (InvoiceLineItem) _Table() string { return "invoice_line_items" }
+
+type InvoiceLineItem_Update_Fields struct {
+}
+
+type InvoiceLineItem_InvoiceId_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func InvoiceLineItem_InvoiceId(v []byte) InvoiceLineItem_InvoiceId_Field {
+	return InvoiceLineItem_InvoiceId_Field{_set: true, _value: v}
+}
+
+func (f InvoiceLineItem_InvoiceId_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (InvoiceLineItem_InvoiceId_Field) _Column() string { return "invoice_id" }
+
+type InvoiceLineItem_Position_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func InvoiceLineItem_Position(v int) InvoiceLineItem_Position_Field {
+	return InvoiceLineItem_Position_Field{_set: true, _value: v}
+}
+
+func (f InvoiceLineItem_Position_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (InvoiceLineItem_Position_Field) _Column() string { return "position" }
+
+type InvoiceLineItem_Kind_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func InvoiceLineItem_Kind(v int) InvoiceLineItem_Kind_Field {
+	return InvoiceLineItem_Kind_Field{_set: true, _value: v}
+}
+
+func (f InvoiceLineItem_Kind_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (InvoiceLineItem_Kind_Field) _Column() string { return "kind" }
+
+type InvoiceLineItem_Description_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func InvoiceLineItem_Description(v string) InvoiceLineItem_Description_Field {
+	return InvoiceLineItem_Description_Field{_set: true, _value: v}
+}
+
+func (f InvoiceLineItem_Description_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (InvoiceLineItem_Description_Field) _Column() string { return "description" }
+
+type InvoiceLineItem_Quantity_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func InvoiceLineItem_Quantity(v float64) InvoiceLineItem_Quantity_Field {
+	return InvoiceLineItem_Quantity_Field{_set: true, _value: v}
+}
+
+func (f InvoiceLineItem_Quantity_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (InvoiceLineItem_Quantity_Field) _Column() string { return "quantity" }
+
+type InvoiceLineItem_UnitPrice_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func InvoiceLineItem_UnitPrice(v float64) InvoiceLineItem_UnitPrice_Field {
+	return InvoiceLineItem_UnitPrice_Field{_set: true, _value: v}
+}
+
+func (f InvoiceLineItem_UnitPrice_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (InvoiceLineItem_UnitPrice_Field) _Column() string { return "unit_price" }
+
+type InvoiceLineItem_Amount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func InvoiceLineItem_Amount(v int64) InvoiceLineItem_Amount_Field {
+	return InvoiceLineItem_Amount_Field{_set: true, _value: v}
+}
+
+func (f InvoiceLineItem_Amount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (InvoiceLineItem_Amount_Field) _Column() string { return "amount" }
+
 type ProjectMember struct {
 	MemberId  []byte
 	ProjectId []byte
@@ -4465,7 +6142,12 @@ func (obj *postgresImpl) Create_Node(ctx context.Context,
 	node_last_contact_success Node_LastContactSuccess_Field,
 	node_last_contact_failure Node_LastContactFailure_Field,
 	node_contained Node_Contained_Field,
-	node_disqualified Node_Disqualified_Field) (
+	node_disqualified Node_Disqualified_Field,
+	node_audit_reputation_alpha Node_AuditReputationAlpha_Field,
+	node_audit_reputation_beta Node_AuditReputationBeta_Field,
+	node_uptime_reputation_alpha Node_UptimeReputationAlpha_Field,
+	node_uptime_reputation_beta Node_UptimeReputationBeta_Field,
+	optional Node_Create_Fields) (
 	node *Node, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
@@ -4497,14 +6179,24 @@ func (obj *postgresImpl) Create_Node(ctx context.Context,
 	__last_contact_failure_val := node_last_contact_failure.value()
 	__contained_val := node_contained.value()
 	__disqualified_val := node_disqualified.value()
+	__disqualified_at_val := optional.DisqualifiedAt.value()
+	__disqualification_reason_val := optional.DisqualificationReason.value()
+	__suspended_at_val := optional.SuspendedAt.value()
+	__suspension_reason_val := optional.SuspensionReason.value()
+	__last_net_val := optional.LastNet.value()
+	__country_code_val := optional.CountryCode.value()
+	__audit_reputation_alpha_val := node_audit_reputation_alpha.value()
+	__audit_reputation_beta_val := node_audit_reputation_beta.value()
+	__uptime_reputation_alpha_val := node_uptime_reputation_alpha.value()
+	__uptime_reputation_beta_val := node_uptime_reputation_beta.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, address, last_ip, protocol, type, email, wallet, free_bandwidth, free_disk, major, minor, patch, hash, timestamp, release, latency_90, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, created_at, updated_at, last_contact_success, last_contact_failure, contained, disqualified ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, address, last_ip, protocol, type, email, wallet, free_bandwidth, free_disk, major, minor, patch, hash, timestamp, release, latency_90, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, created_at, updated_at, last_contact_success, last_contact_failure, contained, disqualified, disqualified_at, disqualification_reason, suspended_at, suspension_reason, last_net, country_code, audit_reputation_alpha, audit_reputation_beta, uptime_reputation_alpha, uptime_reputation_beta ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified, nodes.disqualified_at, nodes.disqualification_reason, nodes.suspended_at, nodes.suspension_reason, nodes.last_net, nodes.country_code, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __id_val, __address_val, __last_ip_val, __protocol_val, __type_val, __email_val, __wallet_val, __free_bandwidth_val, __free_disk_val, __major_val, __minor_val, __patch_val, __hash_val, __timestamp_val, __release_val, __latency_90_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val, __last_contact_success_val, __last_contact_failure_val, __contained_val, __disqualified_val)
+	obj.logStmt(__stmt, __id_val, __address_val, __last_ip_val, __protocol_val, __type_val, __email_val, __wallet_val, __free_bandwidth_val, __free_disk_val, __major_val, __minor_val, __patch_val, __hash_val, __timestamp_val, __release_val, __latency_90_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val, __last_contact_success_val, __last_contact_failure_val, __contained_val, __disqualified_val, __disqualified_at_val, __disqualification_reason_val, __suspended_at_val, __suspension_reason_val, __last_net_val, __country_code_val, __audit_reputation_alpha_val, __audit_reputation_beta_val, __uptime_reputation_alpha_val, __uptime_reputation_beta_val)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __id_val, __address_val, __last_ip_val, __protocol_val, __type_val, __email_val, __wallet_val, __free_bandwidth_val, __free_disk_val, __major_val, __minor_val, __patch_val, __hash_val, __timestamp_val, __release_val, __latency_90_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val, __last_contact_success_val, __last_contact_failure_val, __contained_val, __disqualified_val).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified)
+	err = obj.driver.QueryRow(__stmt, __id_val, __address_val, __last_ip_val, __protocol_val, __type_val, __email_val, __wallet_val, __free_bandwidth_val, __free_disk_val, __major_val, __minor_val, __patch_val, __hash_val, __timestamp_val, __release_val, __latency_90_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val, __last_contact_success_val, __last_contact_failure_val, __contained_val, __disqualified_val, __disqualified_at_val, __disqualification_reason_val, __suspended_at_val, __suspension_reason_val, __last_net_val, __country_code_val, __audit_reputation_alpha_val, __audit_reputation_beta_val, __uptime_reputation_alpha_val, __uptime_reputation_beta_val).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified, &node.DisqualifiedAt, &node.DisqualificationReason, &node.SuspendedAt, &node.SuspensionReason, &node.LastNet, &node.CountryCode, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -4745,6 +6437,31 @@ func (obj *postgresImpl) Create_BucketStorageTally(ctx context.Context,
 
 }
 
+func (obj *postgresImpl) Create_StoragenodeFraud(ctx context.Context,
+	storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field,
+	storagenode_fraud_reason StoragenodeFraud_Reason_Field,
+	storagenode_fraud_count StoragenodeFraud_Count_Field,
+	storagenode_fraud_last_seen_at StoragenodeFraud_LastSeenAt_Field) (
+	storagenode_fraud *StoragenodeFraud, err error) {
+	__storagenode_id_val := storagenode_fraud_storagenode_id.value()
+	__reason_val := storagenode_fraud_reason.value()
+	__count_val := storagenode_fraud_count.value()
+	__last_seen_at_val := storagenode_fraud_last_seen_at.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO storagenode_frauds ( storagenode_id, reason, count, last_seen_at ) VALUES ( ?, ?, ?, ? ) RETURNING storagenode_frauds.storagenode_id, storagenode_frauds.reason, storagenode_frauds.count, storagenode_frauds.last_seen_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __storagenode_id_val, __reason_val, __count_val, __last_seen_at_val)
+
+	storagenode_fraud = &StoragenodeFraud{}
+	err = obj.driver.QueryRow(__stmt, __storagenode_id_val, __reason_val, __count_val, __last_seen_at_val).Scan(&storagenode_fraud.StoragenodeId, &storagenode_fraud.Reason, &storagenode_fraud.Count, &storagenode_fraud.LastSeenAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return storagenode_fraud, nil
+
+}
+
 func (obj *postgresImpl) Create_StoragenodeStorageTally(ctx context.Context,
 	storagenode_storage_tally_node_id StoragenodeStorageTally_NodeId_Field,
 	storagenode_storage_tally_interval_end_time StoragenodeStorageTally_IntervalEndTime_Field,
@@ -5038,7 +6755,7 @@ func (obj *postgresImpl) Get_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	node *Node, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified, nodes.disqualified_at, nodes.disqualification_reason, nodes.suspended_at, nodes.suspension_reason, nodes.last_net, nodes.country_code, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta FROM nodes WHERE nodes.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, node_id.value())
@@ -5047,7 +6764,7 @@ func (obj *postgresImpl) Get_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified, &node.DisqualifiedAt, &node.DisqualificationReason, &node.SuspendedAt, &node.SuspensionReason, &node.LastNet, &node.CountryCode, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -5092,7 +6809,7 @@ func (obj *postgresImpl) Limited_Node_By_Id_GreaterOrEqual_OrderBy_Asc_Id(ctx co
 	limit int, offset int64) (
 	rows []*Node, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified FROM nodes WHERE nodes.id >= ? ORDER BY nodes.id LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified, nodes.disqualified_at, nodes.disqualification_reason, nodes.suspended_at, nodes.suspension_reason, nodes.last_net, nodes.country_code, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta FROM nodes WHERE nodes.id >= ? ORDER BY nodes.id LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, node_id_greater_or_equal.value())
@@ -5110,7 +6827,7 @@ func (obj *postgresImpl) Limited_Node_By_Id_GreaterOrEqual_OrderBy_Asc_Id(ctx co
 
 	for __rows.Next() {
 		node := &Node{}
-		err = __rows.Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified)
+		err = __rows.Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified, &node.DisqualifiedAt, &node.DisqualificationReason, &node.SuspendedAt, &node.SuspensionReason, &node.LastNet, &node.CountryCode, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -5714,6 +7431,39 @@ func (obj *postgresImpl) All_StoragenodeBandwidthRollup_By_IntervalStart_Greater
 
 }
 
+func (obj *postgresImpl) All_StoragenodeFraud_By_StoragenodeId(ctx context.Context,
+	storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field) (
+	rows []*StoragenodeFraud, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT storagenode_frauds.storagenode_id, storagenode_frauds.reason, storagenode_frauds.count, storagenode_frauds.last_seen_at FROM storagenode_frauds WHERE storagenode_frauds.storagenode_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, storagenode_fraud_storagenode_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		storagenode_fraud := &StoragenodeFraud{}
+		err = __rows.Scan(&storagenode_fraud.StoragenodeId, &storagenode_fraud.Reason, &storagenode_fraud.Count, &storagenode_fraud.LastSeenAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, storagenode_fraud)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
 func (obj *postgresImpl) Get_StoragenodeStorageTally_By_Id(ctx context.Context,
 	storagenode_storage_tally_id StoragenodeStorageTally_Id_Field) (
 	storagenode_storage_tally *StoragenodeStorageTally, err error) {
@@ -6151,7 +7901,7 @@ func (obj *postgresImpl) Update_Node_By_Id(ctx context.Context,
 	node *Node, err error) {
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified, nodes.disqualified_at, nodes.disqualification_reason, nodes.suspended_at, nodes.suspension_reason, nodes.last_net, nodes.country_code, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -6282,6 +8032,56 @@ func (obj *postgresImpl) Update_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("disqualified = ?"))
 	}
 
+	if update.DisqualifiedAt._set {
+		__values = append(__values, update.DisqualifiedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("disqualified_at = ?"))
+	}
+
+	if update.DisqualificationReason._set {
+		__values = append(__values, update.DisqualificationReason.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("disqualification_reason = ?"))
+	}
+
+	if update.SuspendedAt._set {
+		__values = append(__values, update.SuspendedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("suspended_at = ?"))
+	}
+
+	if update.SuspensionReason._set {
+		__values = append(__values, update.SuspensionReason.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("suspension_reason = ?"))
+	}
+
+	if update.LastNet._set {
+		__values = append(__values, update.LastNet.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_net = ?"))
+	}
+
+	if update.CountryCode._set {
+		__values = append(__values, update.CountryCode.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("country_code = ?"))
+	}
+
+	if update.AuditReputationAlpha._set {
+		__values = append(__values, update.AuditReputationAlpha.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_reputation_alpha = ?"))
+	}
+
+	if update.AuditReputationBeta._set {
+		__values = append(__values, update.AuditReputationBeta.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_reputation_beta = ?"))
+	}
+
+	if update.UptimeReputationAlpha._set {
+		__values = append(__values, update.UptimeReputationAlpha.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_reputation_alpha = ?"))
+	}
+
+	if update.UptimeReputationBeta._set {
+		__values = append(__values, update.UptimeReputationBeta.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_reputation_beta = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -6296,7 +8096,7 @@ func (obj *postgresImpl) Update_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified, &node.DisqualifiedAt, &node.DisqualificationReason, &node.SuspendedAt, &node.SuspensionReason, &node.LastNet, &node.CountryCode, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -6451,6 +8251,52 @@ func (obj *postgresImpl) Update_ApiKey_By_Id(ctx context.Context,
 	return api_key, nil
 }
 
+func (obj *postgresImpl) Update_StoragenodeFraud_By_StoragenodeId_And_Reason(ctx context.Context,
+	storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field,
+	storagenode_fraud_reason StoragenodeFraud_Reason_Field,
+	update StoragenodeFraud_Update_Fields) (
+	storagenode_fraud *StoragenodeFraud, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE storagenode_frauds SET "), __sets, __sqlbundle_Literal(" WHERE storagenode_frauds.storagenode_id = ? AND storagenode_frauds.reason = ? RETURNING storagenode_frauds.storagenode_id, storagenode_frauds.reason, storagenode_frauds.count, storagenode_frauds.last_seen_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.Count._set {
+		__values = append(__values, update.Count.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("count = ?"))
+	}
+
+	if update.LastSeenAt._set {
+		__values = append(__values, update.LastSeenAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_seen_at = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, storagenode_fraud_storagenode_id.value(), storagenode_fraud_reason.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	storagenode_fraud = &StoragenodeFraud{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&storagenode_fraud.StoragenodeId, &storagenode_fraud.Reason, &storagenode_fraud.Count, &storagenode_fraud.LastSeenAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return storagenode_fraud, nil
+}
+
 func (obj *postgresImpl) Update_CertRecord_By_Id(ctx context.Context,
 	certRecord_id CertRecord_Id_Field,
 	update CertRecord_Update_Fields) (
@@ -7000,7 +8846,17 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 	count += __count
-	__res, err = obj.driver.Exec("DELETE FROM project_members;")
+	__res, err = obj.driver.Exec("DELETE FROM project_members;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM invoice_line_items;")
 	if err != nil {
 		return 0, obj.makeErr(err)
 	}
@@ -7035,6 +8891,26 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM storagenode_statements;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM storagenode_frauds;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -7115,6 +8991,16 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM node_upload_stats;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -7125,6 +9011,16 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM invoices;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -7135,6 +9031,16 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM coupons;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -7175,6 +9081,16 @@ func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM bucket_placements;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -7363,7 +9279,12 @@ func (obj *sqlite3Impl) Create_Node(ctx context.Context,
 	node_last_contact_success Node_LastContactSuccess_Field,
 	node_last_contact_failure Node_LastContactFailure_Field,
 	node_contained Node_Contained_Field,
-	node_disqualified Node_Disqualified_Field) (
+	node_disqualified Node_Disqualified_Field,
+	node_audit_reputation_alpha Node_AuditReputationAlpha_Field,
+	node_audit_reputation_beta Node_AuditReputationBeta_Field,
+	node_uptime_reputation_alpha Node_UptimeReputationAlpha_Field,
+	node_uptime_reputation_beta Node_UptimeReputationBeta_Field,
+	optional Node_Create_Fields) (
 	node *Node, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
@@ -7395,13 +9316,23 @@ func (obj *sqlite3Impl) Create_Node(ctx context.Context,
 	__last_contact_failure_val := node_last_contact_failure.value()
 	__contained_val := node_contained.value()
 	__disqualified_val := node_disqualified.value()
+	__disqualified_at_val := optional.DisqualifiedAt.value()
+	__disqualification_reason_val := optional.DisqualificationReason.value()
+	__suspended_at_val := optional.SuspendedAt.value()
+	__suspension_reason_val := optional.SuspensionReason.value()
+	__last_net_val := optional.LastNet.value()
+	__country_code_val := optional.CountryCode.value()
+	__audit_reputation_alpha_val := node_audit_reputation_alpha.value()
+	__audit_reputation_beta_val := node_audit_reputation_beta.value()
+	__uptime_reputation_alpha_val := node_uptime_reputation_alpha.value()
+	__uptime_reputation_beta_val := node_uptime_reputation_beta.value()
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, address, last_ip, protocol, type, email, wallet, free_bandwidth, free_disk, major, minor, patch, hash, timestamp, release, latency_90, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, created_at, updated_at, last_contact_success, last_contact_failure, contained, disqualified ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, address, last_ip, protocol, type, email, wallet, free_bandwidth, free_disk, major, minor, patch, hash, timestamp, release, latency_90, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, created_at, updated_at, last_contact_success, last_contact_failure, contained, disqualified, disqualified_at, disqualification_reason, suspended_at, suspension_reason, last_net, country_code, audit_reputation_alpha, audit_reputation_beta, uptime_reputation_alpha, uptime_reputation_beta ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __id_val, __address_val, __last_ip_val, __protocol_val, __type_val, __email_val, __wallet_val, __free_bandwidth_val, __free_disk_val, __major_val, __minor_val, __patch_val, __hash_val, __timestamp_val, __release_val, __latency_90_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val, __last_contact_success_val, __last_contact_failure_val, __contained_val, __disqualified_val)
+	obj.logStmt(__stmt, __id_val, __address_val, __last_ip_val, __protocol_val, __type_val, __email_val, __wallet_val, __free_bandwidth_val, __free_disk_val, __major_val, __minor_val, __patch_val, __hash_val, __timestamp_val, __release_val, __latency_90_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val, __last_contact_success_val, __last_contact_failure_val, __contained_val, __disqualified_val, __disqualified_at_val, __disqualification_reason_val, __suspended_at_val, __suspension_reason_val, __last_net_val, __country_code_val, __audit_reputation_alpha_val, __audit_reputation_beta_val, __uptime_reputation_alpha_val, __uptime_reputation_beta_val)
 
-	__res, err := obj.driver.Exec(__stmt, __id_val, __address_val, __last_ip_val, __protocol_val, __type_val, __email_val, __wallet_val, __free_bandwidth_val, __free_disk_val, __major_val, __minor_val, __patch_val, __hash_val, __timestamp_val, __release_val, __latency_90_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val, __last_contact_success_val, __last_contact_failure_val, __contained_val, __disqualified_val)
+	__res, err := obj.driver.Exec(__stmt, __id_val, __address_val, __last_ip_val, __protocol_val, __type_val, __email_val, __wallet_val, __free_bandwidth_val, __free_disk_val, __major_val, __minor_val, __patch_val, __hash_val, __timestamp_val, __release_val, __latency_90_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val, __last_contact_success_val, __last_contact_failure_val, __contained_val, __disqualified_val, __disqualified_at_val, __disqualification_reason_val, __suspended_at_val, __suspension_reason_val, __last_net_val, __country_code_val, __audit_reputation_alpha_val, __audit_reputation_beta_val, __uptime_reputation_alpha_val, __uptime_reputation_beta_val)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -7670,6 +9601,34 @@ func (obj *sqlite3Impl) Create_BucketStorageTally(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Create_StoragenodeFraud(ctx context.Context,
+	storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field,
+	storagenode_fraud_reason StoragenodeFraud_Reason_Field,
+	storagenode_fraud_count StoragenodeFraud_Count_Field,
+	storagenode_fraud_last_seen_at StoragenodeFraud_LastSeenAt_Field) (
+	storagenode_fraud *StoragenodeFraud, err error) {
+	__storagenode_id_val := storagenode_fraud_storagenode_id.value()
+	__reason_val := storagenode_fraud_reason.value()
+	__count_val := storagenode_fraud_count.value()
+	__last_seen_at_val := storagenode_fraud_last_seen_at.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO storagenode_frauds ( storagenode_id, reason, count, last_seen_at ) VALUES ( ?, ?, ?, ? )")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __storagenode_id_val, __reason_val, __count_val, __last_seen_at_val)
+
+	__res, err := obj.driver.Exec(__stmt, __storagenode_id_val, __reason_val, __count_val, __last_seen_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastStoragenodeFraud(ctx, __pk)
+
+}
+
 func (obj *sqlite3Impl) Create_StoragenodeStorageTally(ctx context.Context,
 	storagenode_storage_tally_node_id StoragenodeStorageTally_NodeId_Field,
 	storagenode_storage_tally_interval_end_time StoragenodeStorageTally_IntervalEndTime_Field,
@@ -7978,7 +9937,7 @@ func (obj *sqlite3Impl) Get_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	node *Node, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified, nodes.disqualified_at, nodes.disqualification_reason, nodes.suspended_at, nodes.suspension_reason, nodes.last_net, nodes.country_code, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta FROM nodes WHERE nodes.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, node_id.value())
@@ -7987,7 +9946,7 @@ func (obj *sqlite3Impl) Get_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified, &node.DisqualifiedAt, &node.DisqualificationReason, &node.SuspendedAt, &node.SuspensionReason, &node.LastNet, &node.CountryCode, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -8032,7 +9991,7 @@ func (obj *sqlite3Impl) Limited_Node_By_Id_GreaterOrEqual_OrderBy_Asc_Id(ctx con
 	limit int, offset int64) (
 	rows []*Node, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified FROM nodes WHERE nodes.id >= ? ORDER BY nodes.id LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified, nodes.disqualified_at, nodes.disqualification_reason, nodes.suspended_at, nodes.suspension_reason, nodes.last_net, nodes.country_code, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta FROM nodes WHERE nodes.id >= ? ORDER BY nodes.id LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, node_id_greater_or_equal.value())
@@ -8050,7 +10009,7 @@ func (obj *sqlite3Impl) Limited_Node_By_Id_GreaterOrEqual_OrderBy_Asc_Id(ctx con
 
 	for __rows.Next() {
 		node := &Node{}
-		err = __rows.Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified)
+		err = __rows.Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified, &node.DisqualifiedAt, &node.DisqualificationReason, &node.SuspendedAt, &node.SuspensionReason, &node.LastNet, &node.CountryCode, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -8654,6 +10613,39 @@ func (obj *sqlite3Impl) All_StoragenodeBandwidthRollup_By_IntervalStart_GreaterO
 
 }
 
+func (obj *sqlite3Impl) All_StoragenodeFraud_By_StoragenodeId(ctx context.Context,
+	storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field) (
+	rows []*StoragenodeFraud, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT storagenode_frauds.storagenode_id, storagenode_frauds.reason, storagenode_frauds.count, storagenode_frauds.last_seen_at FROM storagenode_frauds WHERE storagenode_frauds.storagenode_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, storagenode_fraud_storagenode_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		storagenode_fraud := &StoragenodeFraud{}
+		err = __rows.Scan(&storagenode_fraud.StoragenodeId, &storagenode_fraud.Reason, &storagenode_fraud.Count, &storagenode_fraud.LastSeenAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, storagenode_fraud)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
 func (obj *sqlite3Impl) Get_StoragenodeStorageTally_By_Id(ctx context.Context,
 	storagenode_storage_tally_id StoragenodeStorageTally_Id_Field) (
 	storagenode_storage_tally *StoragenodeStorageTally, err error) {
@@ -9252,6 +11244,56 @@ func (obj *sqlite3Impl) Update_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("disqualified = ?"))
 	}
 
+	if update.DisqualifiedAt._set {
+		__values = append(__values, update.DisqualifiedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("disqualified_at = ?"))
+	}
+
+	if update.DisqualificationReason._set {
+		__values = append(__values, update.DisqualificationReason.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("disqualification_reason = ?"))
+	}
+
+	if update.SuspendedAt._set {
+		__values = append(__values, update.SuspendedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("suspended_at = ?"))
+	}
+
+	if update.SuspensionReason._set {
+		__values = append(__values, update.SuspensionReason.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("suspension_reason = ?"))
+	}
+
+	if update.LastNet._set {
+		__values = append(__values, update.LastNet.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_net = ?"))
+	}
+
+	if update.CountryCode._set {
+		__values = append(__values, update.CountryCode.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("country_code = ?"))
+	}
+
+	if update.AuditReputationAlpha._set {
+		__values = append(__values, update.AuditReputationAlpha.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_reputation_alpha = ?"))
+	}
+
+	if update.AuditReputationBeta._set {
+		__values = append(__values, update.AuditReputationBeta.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_reputation_beta = ?"))
+	}
+
+	if update.UptimeReputationAlpha._set {
+		__values = append(__values, update.UptimeReputationAlpha.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_reputation_alpha = ?"))
+	}
+
+	if update.UptimeReputationBeta._set {
+		__values = append(__values, update.UptimeReputationBeta.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_reputation_beta = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -9271,12 +11313,12 @@ func (obj *sqlite3Impl) Update_Node_By_Id(ctx context.Context,
 		return nil, obj.makeErr(err)
 	}
 
-	var __embed_stmt_get = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified, nodes.disqualified_at, nodes.disqualification_reason, nodes.suspended_at, nodes.suspension_reason, nodes.last_net, nodes.country_code, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta FROM nodes WHERE nodes.id = ?")
 
 	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
 	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
 
-	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified)
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified, &node.DisqualifiedAt, &node.DisqualificationReason, &node.SuspendedAt, &node.SuspensionReason, &node.LastNet, &node.CountryCode, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -9461,6 +11503,62 @@ func (obj *sqlite3Impl) Update_ApiKey_By_Id(ctx context.Context,
 	return api_key, nil
 }
 
+func (obj *sqlite3Impl) Update_StoragenodeFraud_By_StoragenodeId_And_Reason(ctx context.Context,
+	storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field,
+	storagenode_fraud_reason StoragenodeFraud_Reason_Field,
+	update StoragenodeFraud_Update_Fields) (
+	storagenode_fraud *StoragenodeFraud, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE storagenode_frauds SET "), __sets, __sqlbundle_Literal(" WHERE storagenode_frauds.storagenode_id = ? AND storagenode_frauds.reason = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.Count._set {
+		__values = append(__values, update.Count.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("count = ?"))
+	}
+
+	if update.LastSeenAt._set {
+		__values = append(__values, update.LastSeenAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_seen_at = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, storagenode_fraud_storagenode_id.value(), storagenode_fraud_reason.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	storagenode_fraud = &StoragenodeFraud{}
+	_, err = obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT storagenode_frauds.storagenode_id, storagenode_frauds.reason, storagenode_frauds.count, storagenode_frauds.last_seen_at FROM storagenode_frauds WHERE storagenode_frauds.storagenode_id = ? AND storagenode_frauds.reason = ?")
+
+	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
+	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
+
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&storagenode_fraud.StoragenodeId, &storagenode_fraud.Reason, &storagenode_fraud.Count, &storagenode_fraud.LastSeenAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return storagenode_fraud, nil
+}
+
 func (obj *sqlite3Impl) Update_CertRecord_By_Id(ctx context.Context,
 	certRecord_id CertRecord_Id_Field,
 	update CertRecord_Update_Fields) (
@@ -10093,13 +12191,13 @@ func (obj *sqlite3Impl) getLastNode(ctx context.Context,
 	pk int64) (
 	node *Node, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified FROM nodes WHERE _rowid_ = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.address, nodes.last_ip, nodes.protocol, nodes.type, nodes.email, nodes.wallet, nodes.free_bandwidth, nodes.free_disk, nodes.major, nodes.minor, nodes.patch, nodes.hash, nodes.timestamp, nodes.release, nodes.latency_90, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at, nodes.last_contact_success, nodes.last_contact_failure, nodes.contained, nodes.disqualified, nodes.disqualified_at, nodes.disqualification_reason, nodes.suspended_at, nodes.suspension_reason, nodes.last_net, nodes.country_code, nodes.audit_reputation_alpha, nodes.audit_reputation_beta, nodes.uptime_reputation_alpha, nodes.uptime_reputation_beta FROM nodes WHERE _rowid_ = ?")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, pk)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, pk).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified)
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&node.Id, &node.Address, &node.LastIp, &node.Protocol, &node.Type, &node.Email, &node.Wallet, &node.FreeBandwidth, &node.FreeDisk, &node.Major, &node.Minor, &node.Patch, &node.Hash, &node.Timestamp, &node.Release, &node.Latency90, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt, &node.LastContactSuccess, &node.LastContactFailure, &node.Contained, &node.Disqualified, &node.DisqualifiedAt, &node.DisqualificationReason, &node.SuspendedAt, &node.SuspensionReason, &node.LastNet, &node.CountryCode, &node.AuditReputationAlpha, &node.AuditReputationBeta, &node.UptimeReputationAlpha, &node.UptimeReputationBeta)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -10251,6 +12349,24 @@ func (obj *sqlite3Impl) getLastBucketStorageTally(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) getLastStoragenodeFraud(ctx context.Context,
+	pk int64) (
+	storagenode_fraud *StoragenodeFraud, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT storagenode_frauds.storagenode_id, storagenode_frauds.reason, storagenode_frauds.count, storagenode_frauds.last_seen_at FROM storagenode_frauds WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	storagenode_fraud = &StoragenodeFraud{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&storagenode_fraud.StoragenodeId, &storagenode_fraud.Reason, &storagenode_fraud.Count, &storagenode_fraud.LastSeenAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return storagenode_fraud, nil
+
+}
+
 func (obj *sqlite3Impl) getLastStoragenodeStorageTally(ctx context.Context,
 	pk int64) (
 	storagenode_storage_tally *StoragenodeStorageTally, err error) {
@@ -10374,6 +12490,16 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM invoice_line_items;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -10404,6 +12530,26 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM storagenode_statements;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM storagenode_frauds;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -10484,6 +12630,16 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM node_upload_stats;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -10494,6 +12650,16 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM invoices;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -10504,6 +12670,16 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM coupons;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -10544,6 +12720,16 @@ func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error)
 		return 0, obj.makeErr(err)
 	}
 
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
+	__res, err = obj.driver.Exec("DELETE FROM bucket_placements;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
 	__count, err = __res.RowsAffected()
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -10716,6 +12902,16 @@ func (rx *Rx) All_StoragenodeBandwidthRollup_By_IntervalStart_GreaterOrEqual(ctx
 	return tx.All_StoragenodeBandwidthRollup_By_IntervalStart_GreaterOrEqual(ctx, storagenode_bandwidth_rollup_interval_start_greater_or_equal)
 }
 
+func (rx *Rx) All_StoragenodeFraud_By_StoragenodeId(ctx context.Context,
+	storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field) (
+	rows []*StoragenodeFraud, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_StoragenodeFraud_By_StoragenodeId(ctx, storagenode_fraud_storagenode_id)
+}
+
 func (rx *Rx) All_StoragenodeStorageTally(ctx context.Context) (
 	rows []*StoragenodeStorageTally, err error) {
 	var tx *Tx
@@ -10874,13 +13070,18 @@ func (rx *Rx) Create_Node(ctx context.Context,
 	node_last_contact_success Node_LastContactSuccess_Field,
 	node_last_contact_failure Node_LastContactFailure_Field,
 	node_contained Node_Contained_Field,
-	node_disqualified Node_Disqualified_Field) (
+	node_disqualified Node_Disqualified_Field,
+	node_audit_reputation_alpha Node_AuditReputationAlpha_Field,
+	node_audit_reputation_beta Node_AuditReputationBeta_Field,
+	node_uptime_reputation_alpha Node_UptimeReputationAlpha_Field,
+	node_uptime_reputation_beta Node_UptimeReputationBeta_Field,
+	optional Node_Create_Fields) (
 	node *Node, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_Node(ctx, node_id, node_address, node_last_ip, node_protocol, node_type, node_email, node_wallet, node_free_bandwidth, node_free_disk, node_major, node_minor, node_patch, node_hash, node_timestamp, node_release, node_latency_90, node_audit_success_count, node_total_audit_count, node_audit_success_ratio, node_uptime_success_count, node_total_uptime_count, node_uptime_ratio, node_last_contact_success, node_last_contact_failure, node_contained, node_disqualified)
+	return tx.Create_Node(ctx, node_id, node_address, node_last_ip, node_protocol, node_type, node_email, node_wallet, node_free_bandwidth, node_free_disk, node_major, node_minor, node_patch, node_hash, node_timestamp, node_release, node_latency_90, node_audit_success_count, node_total_audit_count, node_audit_success_ratio, node_uptime_success_count, node_total_uptime_count, node_uptime_ratio, node_last_contact_success, node_last_contact_failure, node_contained, node_disqualified, node_audit_reputation_alpha, node_audit_reputation_beta, node_uptime_reputation_alpha, node_uptime_reputation_beta, optional)
 
 }
 
@@ -10981,6 +13182,20 @@ func (rx *Rx) Create_SerialNumber(ctx context.Context,
 
 }
 
+func (rx *Rx) Create_StoragenodeFraud(ctx context.Context,
+	storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field,
+	storagenode_fraud_reason StoragenodeFraud_Reason_Field,
+	storagenode_fraud_count StoragenodeFraud_Count_Field,
+	storagenode_fraud_last_seen_at StoragenodeFraud_LastSeenAt_Field) (
+	storagenode_fraud *StoragenodeFraud, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_StoragenodeFraud(ctx, storagenode_fraud_storagenode_id, storagenode_fraud_reason, storagenode_fraud_count, storagenode_fraud_last_seen_at)
+
+}
+
 func (rx *Rx) Create_StoragenodeStorageTally(ctx context.Context,
 	storagenode_storage_tally_node_id StoragenodeStorageTally_NodeId_Field,
 	storagenode_storage_tally_interval_end_time StoragenodeStorageTally_IntervalEndTime_Field,
@@ -11557,6 +13772,18 @@ func (rx *Rx) Update_RegistrationToken_By_Secret(ctx context.Context,
 	return tx.Update_RegistrationToken_By_Secret(ctx, registration_token_secret, update)
 }
 
+func (rx *Rx) Update_StoragenodeFraud_By_StoragenodeId_And_Reason(ctx context.Context,
+	storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field,
+	storagenode_fraud_reason StoragenodeFraud_Reason_Field,
+	update StoragenodeFraud_Update_Fields) (
+	storagenode_fraud *StoragenodeFraud, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Update_StoragenodeFraud_By_StoragenodeId_And_Reason(ctx, storagenode_fraud_storagenode_id, storagenode_fraud_reason, update)
+}
+
 func (rx *Rx) Update_User_By_Id(ctx context.Context,
 	user_id User_Id_Field,
 	update User_Update_Fields) (
@@ -11605,6 +13832,10 @@ type Methods interface {
 		storagenode_bandwidth_rollup_interval_start_greater_or_equal StoragenodeBandwidthRollup_IntervalStart_Field) (
 		rows []*StoragenodeBandwidthRollup, err error)
 
+	All_StoragenodeFraud_By_StoragenodeId(ctx context.Context,
+		storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field) (
+		rows []*StoragenodeFraud, err error)
+
 	All_StoragenodeStorageTally(ctx context.Context) (
 		rows []*StoragenodeStorageTally, err error)
 
@@ -11702,7 +13933,12 @@ type Methods interface {
 		node_last_contact_success Node_LastContactSuccess_Field,
 		node_last_contact_failure Node_LastContactFailure_Field,
 		node_contained Node_Contained_Field,
-		node_disqualified Node_Disqualified_Field) (
+		node_disqualified Node_Disqualified_Field,
+		node_audit_reputation_alpha Node_AuditReputationAlpha_Field,
+		node_audit_reputation_beta Node_AuditReputationBeta_Field,
+		node_uptime_reputation_alpha Node_UptimeReputationAlpha_Field,
+		node_uptime_reputation_beta Node_UptimeReputationBeta_Field,
+		optional Node_Create_Fields) (
 		node *Node, err error)
 
 	Create_Offer(ctx context.Context,
@@ -11753,6 +13989,13 @@ type Methods interface {
 		serial_number_expires_at SerialNumber_ExpiresAt_Field) (
 		serial_number *SerialNumber, err error)
 
+	Create_StoragenodeFraud(ctx context.Context,
+		storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field,
+		storagenode_fraud_reason StoragenodeFraud_Reason_Field,
+		storagenode_fraud_count StoragenodeFraud_Count_Field,
+		storagenode_fraud_last_seen_at StoragenodeFraud_LastSeenAt_Field) (
+		storagenode_fraud *StoragenodeFraud, err error)
+
 	Create_StoragenodeStorageTally(ctx context.Context,
 		storagenode_storage_tally_node_id StoragenodeStorageTally_NodeId_Field,
 		storagenode_storage_tally_interval_end_time StoragenodeStorageTally_IntervalEndTime_Field,
@@ -12001,6 +14244,12 @@ type Methods interface {
 		update RegistrationToken_Update_Fields) (
 		registration_token *RegistrationToken, err error)
 
+	Update_StoragenodeFraud_By_StoragenodeId_And_Reason(ctx context.Context,
+		storagenode_fraud_storagenode_id StoragenodeFraud_StoragenodeId_Field,
+		storagenode_fraud_reason StoragenodeFraud_Reason_Field,
+		update StoragenodeFraud_Update_Fields) (
+		storagenode_fraud *StoragenodeFraud, err error)
+
 	Update_User_By_Id(ctx context.Context,
 		user_id User_Id_Field,
 		update User_Update_Fields) (
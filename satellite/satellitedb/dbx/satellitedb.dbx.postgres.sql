@@ -28,6 +28,13 @@ CREATE TABLE bucket_bandwidth_rollups (
 	settled bigint NOT NULL,
 	PRIMARY KEY ( bucket_name, project_id, interval_start, action )
 );
+CREATE TABLE bucket_placements (
+	project_id bytea NOT NULL,
+	bucket_name bytea NOT NULL,
+	placement text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name )
+);
 CREATE TABLE bucket_storage_tallies (
 	bucket_name bytea NOT NULL,
 	project_id bytea NOT NULL,
@@ -71,12 +78,37 @@ CREATE TABLE certRecords (
 	update_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE coupons (
+	code bytea NOT NULL,
+	project_id bytea,
+	amount bigint NOT NULL,
+	duration_months integer NOT NULL,
+	redeemed_at timestamp,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( code )
+);
 CREATE TABLE injuredsegments (
 	path text NOT NULL,
 	data bytea NOT NULL,
+	num_healthy_pieces integer NOT NULL,
+	inserted_at timestamp NOT NULL,
 	attempted timestamp,
 	PRIMARY KEY ( path )
 );
+CREATE TABLE invoices (
+	id bytea NOT NULL,
+	project_id bytea NOT NULL,
+	period_start timestamp NOT NULL,
+	period_end timestamp NOT NULL,
+	subtotal bigint NOT NULL,
+	credits bigint NOT NULL,
+	total bigint NOT NULL,
+	status integer NOT NULL,
+	provider_reference text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( id ),
+	UNIQUE ( project_id, period_start )
+);
 CREATE TABLE irreparabledbs (
 	segmentpath bytea NOT NULL,
 	segmentdetail bytea NOT NULL,
@@ -85,6 +117,15 @@ CREATE TABLE irreparabledbs (
 	repair_attempt_count bigint NOT NULL,
 	PRIMARY KEY ( segmentpath )
 );
+CREATE TABLE node_upload_stats (
+	node_id bytea NOT NULL,
+	upload_count bigint NOT NULL,
+	success_ratio double precision NOT NULL,
+	long_tail_ratio double precision NOT NULL,
+	throughput double precision NOT NULL,
+	updated_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE nodes (
 	id bytea NOT NULL,
 	address text NOT NULL,
@@ -114,6 +155,16 @@ CREATE TABLE nodes (
 	last_contact_failure timestamp with time zone NOT NULL,
 	contained boolean NOT NULL,
 	disqualified boolean NOT NULL,
+	disqualified_at timestamp with time zone,
+	disqualification_reason text,
+	suspended_at timestamp with time zone,
+	suspension_reason text,
+	last_net text,
+	country_code text,
+	audit_reputation_alpha double precision NOT NULL,
+	audit_reputation_beta double precision NOT NULL,
+	uptime_reputation_alpha double precision NOT NULL,
+	uptime_reputation_beta double precision NOT NULL,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE offers (
@@ -179,6 +230,32 @@ CREATE TABLE storagenode_bandwidth_rollups (
 	settled bigint NOT NULL,
 	PRIMARY KEY ( storagenode_id, interval_start, action )
 );
+CREATE TABLE storagenode_frauds (
+	storagenode_id bytea NOT NULL,
+	reason integer NOT NULL,
+	count bigint NOT NULL,
+	last_seen_at timestamp NOT NULL,
+	PRIMARY KEY ( storagenode_id, reason )
+);
+CREATE TABLE storagenode_statements (
+	node_id bytea NOT NULL,
+	period timestamp NOT NULL,
+	node_created_at timestamp NOT NULL,
+	wallet text NOT NULL,
+	at_rest_total double precision NOT NULL,
+	get_total bigint NOT NULL,
+	get_repair_total bigint NOT NULL,
+	get_audit_total bigint NOT NULL,
+	storage_amount bigint NOT NULL,
+	egress_amount bigint NOT NULL,
+	repair_amount bigint NOT NULL,
+	audit_amount bigint NOT NULL,
+	held_percent integer NOT NULL,
+	held_amount bigint NOT NULL,
+	payout_amount bigint NOT NULL,
+	created_at timestamp NOT NULL,
+	PRIMARY KEY ( node_id, period )
+);
 CREATE TABLE storagenode_storage_tallies (
 	id bigserial NOT NULL,
 	node_id bytea NOT NULL,
@@ -207,6 +284,16 @@ CREATE TABLE api_keys (
 	UNIQUE ( head ),
 	UNIQUE ( name, project_id )
 );
+CREATE TABLE invoice_line_items (
+	invoice_id bytea NOT NULL REFERENCES invoices( id ) ON DELETE CASCADE,
+	position integer NOT NULL,
+	kind integer NOT NULL,
+	description text NOT NULL,
+	quantity double precision NOT NULL,
+	unit_price double precision NOT NULL,
+	amount bigint NOT NULL,
+	PRIMARY KEY ( invoice_id, position )
+);
 CREATE TABLE project_members (
 	member_id bytea NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
 	project_id bytea NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
@@ -220,6 +307,7 @@ CREATE TABLE used_serials (
 );
 CREATE INDEX bucket_name_project_id_interval_start_interval_seconds ON bucket_bandwidth_rollups ( bucket_name, project_id, interval_start, interval_seconds );
 CREATE UNIQUE INDEX bucket_id_rollup ON bucket_usages ( bucket_id, rollup_end_time );
+CREATE INDEX injuredsegments_num_healthy_pieces_inserted_at_index ON injuredsegments ( num_healthy_pieces, inserted_at );
 CREATE INDEX node_last_ip ON nodes ( last_ip );
 CREATE UNIQUE INDEX serial_number ON serial_numbers ( serial_number );
 CREATE INDEX serial_numbers_expires_at_index ON serial_numbers ( expires_at );
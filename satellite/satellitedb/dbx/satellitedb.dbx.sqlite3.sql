@@ -28,6 +28,13 @@ CREATE TABLE bucket_bandwidth_rollups (
 	settled INTEGER NOT NULL,
 	PRIMARY KEY ( bucket_name, project_id, interval_start, action )
 );
+CREATE TABLE bucket_placements (
+	project_id BLOB NOT NULL,
+	bucket_name BLOB NOT NULL,
+	placement TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( project_id, bucket_name )
+);
 CREATE TABLE bucket_storage_tallies (
 	bucket_name BLOB NOT NULL,
 	project_id BLOB NOT NULL,
@@ -71,12 +78,37 @@ CREATE TABLE certRecords (
 	update_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( id )
 );
+CREATE TABLE coupons (
+	code BLOB NOT NULL,
+	project_id BLOB,
+	amount INTEGER NOT NULL,
+	duration_months INTEGER NOT NULL,
+	redeemed_at TIMESTAMP,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( code )
+);
 CREATE TABLE injuredsegments (
 	path TEXT NOT NULL,
 	data BLOB NOT NULL,
+	num_healthy_pieces INTEGER NOT NULL,
+	inserted_at TIMESTAMP NOT NULL,
 	attempted TIMESTAMP,
 	PRIMARY KEY ( path )
 );
+CREATE TABLE invoices (
+	id BLOB NOT NULL,
+	project_id BLOB NOT NULL,
+	period_start TIMESTAMP NOT NULL,
+	period_end TIMESTAMP NOT NULL,
+	subtotal INTEGER NOT NULL,
+	credits INTEGER NOT NULL,
+	total INTEGER NOT NULL,
+	status INTEGER NOT NULL,
+	provider_reference TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( id ),
+	UNIQUE ( project_id, period_start )
+);
 CREATE TABLE irreparabledbs (
 	segmentpath BLOB NOT NULL,
 	segmentdetail BLOB NOT NULL,
@@ -85,6 +117,15 @@ CREATE TABLE irreparabledbs (
 	repair_attempt_count INTEGER NOT NULL,
 	PRIMARY KEY ( segmentpath )
 );
+CREATE TABLE node_upload_stats (
+	node_id BLOB NOT NULL,
+	upload_count INTEGER NOT NULL,
+	success_ratio REAL NOT NULL,
+	long_tail_ratio REAL NOT NULL,
+	throughput REAL NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id )
+);
 CREATE TABLE nodes (
 	id BLOB NOT NULL,
 	address TEXT NOT NULL,
@@ -114,6 +155,16 @@ CREATE TABLE nodes (
 	last_contact_failure TIMESTAMP NOT NULL,
 	contained INTEGER NOT NULL,
 	disqualified INTEGER NOT NULL,
+	disqualified_at TIMESTAMP,
+	disqualification_reason TEXT,
+	suspended_at TIMESTAMP,
+	suspension_reason TEXT,
+	last_net TEXT,
+	country_code TEXT,
+	audit_reputation_alpha REAL NOT NULL,
+	audit_reputation_beta REAL NOT NULL,
+	uptime_reputation_alpha REAL NOT NULL,
+	uptime_reputation_beta REAL NOT NULL,
 	PRIMARY KEY ( id )
 );
 CREATE TABLE offers (
@@ -179,6 +230,32 @@ CREATE TABLE storagenode_bandwidth_rollups (
 	settled INTEGER NOT NULL,
 	PRIMARY KEY ( storagenode_id, interval_start, action )
 );
+CREATE TABLE storagenode_frauds (
+	storagenode_id BLOB NOT NULL,
+	reason INTEGER NOT NULL,
+	count INTEGER NOT NULL,
+	last_seen_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( storagenode_id, reason )
+);
+CREATE TABLE storagenode_statements (
+	node_id BLOB NOT NULL,
+	period TIMESTAMP NOT NULL,
+	node_created_at TIMESTAMP NOT NULL,
+	wallet TEXT NOT NULL,
+	at_rest_total REAL NOT NULL,
+	get_total INTEGER NOT NULL,
+	get_repair_total INTEGER NOT NULL,
+	get_audit_total INTEGER NOT NULL,
+	storage_amount INTEGER NOT NULL,
+	egress_amount INTEGER NOT NULL,
+	repair_amount INTEGER NOT NULL,
+	audit_amount INTEGER NOT NULL,
+	held_percent INTEGER NOT NULL,
+	held_amount INTEGER NOT NULL,
+	payout_amount INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id, period )
+);
 CREATE TABLE storagenode_storage_tallies (
 	id INTEGER NOT NULL,
 	node_id BLOB NOT NULL,
@@ -207,6 +284,16 @@ CREATE TABLE api_keys (
 	UNIQUE ( head ),
 	UNIQUE ( name, project_id )
 );
+CREATE TABLE invoice_line_items (
+	invoice_id BLOB NOT NULL REFERENCES invoices( id ) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	kind INTEGER NOT NULL,
+	description TEXT NOT NULL,
+	quantity REAL NOT NULL,
+	unit_price REAL NOT NULL,
+	amount INTEGER NOT NULL,
+	PRIMARY KEY ( invoice_id, position )
+);
 CREATE TABLE project_members (
 	member_id BLOB NOT NULL REFERENCES users( id ) ON DELETE CASCADE,
 	project_id BLOB NOT NULL REFERENCES projects( id ) ON DELETE CASCADE,
@@ -220,6 +307,7 @@ CREATE TABLE used_serials (
 );
 CREATE INDEX bucket_name_project_id_interval_start_interval_seconds ON bucket_bandwidth_rollups ( bucket_name, project_id, interval_start, interval_seconds );
 CREATE UNIQUE INDEX bucket_id_rollup ON bucket_usages ( bucket_id, rollup_end_time );
+CREATE INDEX injuredsegments_num_healthy_pieces_inserted_at_index ON injuredsegments ( num_healthy_pieces, inserted_at );
 CREATE INDEX node_last_ip ON nodes ( last_ip );
 CREATE UNIQUE INDEX serial_number ON serial_numbers ( serial_number );
 CREATE INDEX serial_numbers_expires_at_index ON serial_numbers ( expires_at );
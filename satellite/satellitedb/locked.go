@@ -24,8 +24,10 @@ import (
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/billing"
 	"storj.io/storj/satellite/console"
 	"storj.io/storj/satellite/orders"
+	"storj.io/storj/satellite/payments"
 )
 
 // locked implements a locking wrapper around satellite.DB.
@@ -87,6 +89,82 @@ func (m *lockedBandwidthAgreement) SaveOrder(ctx context.Context, a1 *pb.RenterB
 	return m.db.SaveOrder(ctx, a1)
 }
 
+// Billing returns database for customer invoices and coupons
+func (m *locked) Billing() billing.DB {
+	m.Lock()
+	defer m.Unlock()
+	return &lockedBilling{m.Locker, m.db.Billing()}
+}
+
+// lockedBilling implements locking wrapper for billing.DB
+type lockedBilling struct {
+	sync.Locker
+	db billing.DB
+}
+
+// CreateCoupon stores a new unredeemed coupon
+func (m *lockedBilling) CreateCoupon(ctx context.Context, a1 *billing.Coupon) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.CreateCoupon(ctx, a1)
+}
+
+// CreateInvoice stores the invoice together with its line items
+func (m *lockedBilling) CreateInvoice(ctx context.Context, a1 *billing.Invoice) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.CreateInvoice(ctx, a1)
+}
+
+// GetCoupon returns the coupon with the given code
+func (m *lockedBilling) GetCoupon(ctx context.Context, a1 billing.CouponCode) (*billing.Coupon, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetCoupon(ctx, a1)
+}
+
+// GetCouponsByProject returns the coupons redeemed by a project
+func (m *lockedBilling) GetCouponsByProject(ctx context.Context, a1 uuid.UUID) ([]*billing.Coupon, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetCouponsByProject(ctx, a1)
+}
+
+// GetInvoice returns the invoice with the given id
+func (m *lockedBilling) GetInvoice(ctx context.Context, a1 uuid.UUID) (*billing.Invoice, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetInvoice(ctx, a1)
+}
+
+// GetInvoicesByPeriod returns all invoices for the month starting at periodStart
+func (m *lockedBilling) GetInvoicesByPeriod(ctx context.Context, a1 time.Time) ([]*billing.Invoice, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetInvoicesByPeriod(ctx, a1)
+}
+
+// GetInvoicesByProject returns the invoices of a project, latest period first
+func (m *lockedBilling) GetInvoicesByProject(ctx context.Context, a1 uuid.UUID) ([]*billing.Invoice, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetInvoicesByProject(ctx, a1)
+}
+
+// RedeemCoupon assigns an unredeemed coupon to a project
+func (m *lockedBilling) RedeemCoupon(ctx context.Context, a1 billing.CouponCode, a2 uuid.UUID, a3 time.Time) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.RedeemCoupon(ctx, a1, a2, a3)
+}
+
+// UpdateInvoiceStatus updates the status and payment provider reference of an invoice
+func (m *lockedBilling) UpdateInvoiceStatus(ctx context.Context, a1 uuid.UUID, a2 billing.InvoiceStatus, a3 string) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.UpdateInvoiceStatus(ctx, a1, a2, a3)
+}
+
 // CertDB returns database for storing uplink's public key & ID
 func (m *locked) CertDB() certdb.DB {
 	m.Lock()
@@ -603,6 +681,13 @@ func (m *lockedOrders) GetBucketBandwidth(ctx context.Context, bucketID []byte,
 	return m.db.GetBucketBandwidth(ctx, bucketID, from, to)
 }
 
+// GetFraud returns the fraud counters of a storage node by reject reason
+func (m *lockedOrders) GetFraud(ctx context.Context, storageNodeID storj.NodeID) (map[pb.SettlementResponse_RejectReason]int64, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetFraud(ctx, storageNodeID)
+}
+
 // GetStorageNodeBandwidth gets total storage node bandwidth from period of time
 func (m *lockedOrders) GetStorageNodeBandwidth(ctx context.Context, nodeID storj.NodeID, from time.Time, to time.Time) (int64, error) {
 	m.Lock()
@@ -610,6 +695,20 @@ func (m *lockedOrders) GetStorageNodeBandwidth(ctx context.Context, nodeID storj
 	return m.db.GetStorageNodeBandwidth(ctx, nodeID, from, to)
 }
 
+// ProcessOrders settles a batch of verified orders from a storage node within a single transaction
+func (m *lockedOrders) ProcessOrders(ctx context.Context, storageNodeID storj.NodeID, requests []*orders.ProcessOrderRequest, intervalStart time.Time) ([]*pb.SettlementResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.ProcessOrders(ctx, storageNodeID, requests, intervalStart)
+}
+
+// RecordFraud adds rejected settlements to the fraud counters of a storage node and returns its total fraud count
+func (m *lockedOrders) RecordFraud(ctx context.Context, storageNodeID storj.NodeID, reasons map[pb.SettlementResponse_RejectReason]int64) (int64, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.RecordFraud(ctx, storageNodeID, reasons)
+}
+
 // UnuseSerialNumber removes pair serial number -> storage node id from database
 func (m *lockedOrders) UnuseSerialNumber(ctx context.Context, serialNumber storj.SerialNumber, storageNodeID storj.NodeID) error {
 	m.Lock()
@@ -679,6 +778,13 @@ func (m *lockedOverlayCache) CreateStats(ctx context.Context, nodeID storj.NodeI
 	return m.db.CreateStats(ctx, nodeID, initial)
 }
 
+// DisqualifyNode disqualifies the node, unless it is already disqualified.
+func (m *lockedOverlayCache) DisqualifyNode(ctx context.Context, nodeID storj.NodeID, reason string) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.DisqualifyNode(ctx, nodeID, reason)
+}
+
 // Get looks up the node by nodeID
 func (m *lockedOverlayCache) Get(ctx context.Context, nodeID storj.NodeID) (*overlay.NodeDossier, error) {
 	m.Lock()
@@ -686,6 +792,20 @@ func (m *lockedOverlayCache) Get(ctx context.Context, nodeID storj.NodeID) (*ove
 	return m.db.Get(ctx, nodeID)
 }
 
+// GetBucketPlacement returns the placement policy of the bucket, empty when it has none.
+func (m *lockedOverlayCache) GetBucketPlacement(ctx context.Context, projectID uuid.UUID, bucketName []byte) (string, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetBucketPlacement(ctx, projectID, bucketName)
+}
+
+// GetUploadStats returns how uploads to the node went.
+func (m *lockedOverlayCache) GetUploadStats(ctx context.Context, nodeID storj.NodeID) (*overlay.UploadStats, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetUploadStats(ctx, nodeID)
+}
+
 // KnownUnreliableOrOffline filters a set of nodes to unhealth or offlines node, independent of new
 func (m *lockedOverlayCache) KnownUnreliableOrOffline(ctx context.Context, a1 *overlay.NodeCriteria, a2 storj.NodeIDList) (storj.NodeIDList, error) {
 	m.Lock()
@@ -700,6 +820,13 @@ func (m *lockedOverlayCache) Paginate(ctx context.Context, offset int64, limit i
 	return m.db.Paginate(ctx, offset, limit)
 }
 
+// ReinstateNode clears both disqualification and suspension of the node.
+func (m *lockedOverlayCache) ReinstateNode(ctx context.Context, nodeID storj.NodeID) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.ReinstateNode(ctx, nodeID)
+}
+
 // VetNode returns whether or not the node reaches reputable thresholds
 func (m *lockedOverlayCache) VetNode(ctx context.Context, id storj.NodeID, criteria *overlay.NodeCriteria) (bool, error) {
 	m.Lock()
@@ -721,6 +848,27 @@ func (m *lockedOverlayCache) SelectStorageNodes(ctx context.Context, count int,
 	return m.db.SelectStorageNodes(ctx, count, criteria)
 }
 
+// SetBucketPlacement sets the placement policy of the bucket, an empty policy removes it.
+func (m *lockedOverlayCache) SetBucketPlacement(ctx context.Context, projectID uuid.UUID, bucketName []byte, placement string) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.SetBucketPlacement(ctx, projectID, bucketName, placement)
+}
+
+// SuspendNode suspends the node, unless it is already suspended or disqualified.
+func (m *lockedOverlayCache) SuspendNode(ctx context.Context, nodeID storj.NodeID, reason string) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.SuspendNode(ctx, nodeID, reason)
+}
+
+// UnsuspendNode lifts the suspension of the node when it was suspended for the given reason.
+func (m *lockedOverlayCache) UnsuspendNode(ctx context.Context, nodeID storj.NodeID, reason string) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.UnsuspendNode(ctx, nodeID, reason)
+}
+
 // Update updates node address
 func (m *lockedOverlayCache) UpdateAddress(ctx context.Context, value *pb.Node) error {
 	m.Lock()
@@ -728,6 +876,13 @@ func (m *lockedOverlayCache) UpdateAddress(ctx context.Context, value *pb.Node)
 	return m.db.UpdateAddress(ctx, value)
 }
 
+// UpdateLocation updates the network and country of the node
+func (m *lockedOverlayCache) UpdateLocation(ctx context.Context, nodeID storj.NodeID, location overlay.NodeLocation) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.UpdateLocation(ctx, nodeID, location)
+}
+
 // UpdateNodeInfo updates node dossier with info requested from the node itself like node type, email, wallet, capacity, and version.
 func (m *lockedOverlayCache) UpdateNodeInfo(ctx context.Context, node storj.NodeID, nodeInfo *pb.InfoResponse) (stats *overlay.NodeDossier, err error) {
 	m.Lock()
@@ -742,11 +897,52 @@ func (m *lockedOverlayCache) UpdateStats(ctx context.Context, request *overlay.U
 	return m.db.UpdateStats(ctx, request)
 }
 
+// UpdateUploadStats records how uploads to storage nodes went, lambda is the forgetting factor.
+func (m *lockedOverlayCache) UpdateUploadStats(ctx context.Context, stats []*pb.PieceUploadStats, lambda float64) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.UpdateUploadStats(ctx, stats, lambda)
+}
+
 // UpdateUptime updates a single storagenode's uptime stats.
-func (m *lockedOverlayCache) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool) (stats *overlay.NodeStats, err error) {
+func (m *lockedOverlayCache) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool, lambda float64, weight float64) (stats *overlay.NodeStats, err error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.UpdateUptime(ctx, nodeID, isUp, lambda, weight)
+}
+
+// Payments returns database for storage node payment statements
+func (m *locked) Payments() payments.DB {
+	m.Lock()
+	defer m.Unlock()
+	return &lockedPayments{m.Locker, m.db.Payments()}
+}
+
+// lockedPayments implements locking wrapper for payments.DB
+type lockedPayments struct {
+	sync.Locker
+	db payments.DB
+}
+
+// GetStatement returns the statement of a single storage node for the period
+func (m *lockedPayments) GetStatement(ctx context.Context, nodeID storj.NodeID, period time.Time) (*payments.Statement, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetStatement(ctx, nodeID, period)
+}
+
+// GetStatements returns all statements of the period ordered by node id
+func (m *lockedPayments) GetStatements(ctx context.Context, period time.Time) ([]*payments.Statement, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.GetStatements(ctx, period)
+}
+
+// SaveStatements replaces all statements of the period
+func (m *lockedPayments) SaveStatements(ctx context.Context, period time.Time, statements []*payments.Statement) error {
 	m.Lock()
 	defer m.Unlock()
-	return m.db.UpdateUptime(ctx, nodeID, isUp)
+	return m.db.SaveStatements(ctx, period, statements)
 }
 
 // ProjectAccounting returns database for storing information about project data use
@@ -817,14 +1013,21 @@ func (m *lockedRepairQueue) Delete(ctx context.Context, s *pb.InjuredSegment) er
 	return m.db.Delete(ctx, s)
 }
 
-// Insert adds an injured segment.
+// Depth returns the number of queued and leased segments for each number of healthy pieces.
+func (m *lockedRepairQueue) Depth(ctx context.Context) ([]*pb.QueueDepthBucket, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Depth(ctx)
+}
+
+// Insert adds an injured segment, or updates the health of an already queued one.
 func (m *lockedRepairQueue) Insert(ctx context.Context, s *pb.InjuredSegment) error {
 	m.Lock()
 	defer m.Unlock()
 	return m.db.Insert(ctx, s)
 }
 
-// Select gets an injured segment.
+// Select leases the injured segment with the fewest healthy pieces, oldest first.
 func (m *lockedRepairQueue) Select(ctx context.Context) (*pb.InjuredSegment, error) {
 	m.Lock()
 	defer m.Unlock()
@@ -726,6 +726,147 @@ func (db *DB) PostgresMigration() *migrate.Migration {
 					`ALTER TABLE nodes ADD disqualified boolean NOT NULL DEFAULT false;`,
 				},
 			},
+			{
+				Description: "Add disqualification and suspension details to nodes table",
+				Version:     26,
+				Action: migrate.SQL{
+					`ALTER TABLE nodes ADD disqualified_at timestamp with time zone;`,
+					`ALTER TABLE nodes ADD disqualification_reason text;`,
+					`ALTER TABLE nodes ADD suspended_at timestamp with time zone;`,
+					`ALTER TABLE nodes ADD suspension_reason text;`,
+				},
+			},
+			{
+				Description: "Add beta reputation parameters to nodes table",
+				Version:     27,
+				Action: migrate.SQL{
+					`ALTER TABLE nodes ADD audit_reputation_alpha double precision NOT NULL DEFAULT 0;`,
+					`ALTER TABLE nodes ADD audit_reputation_beta double precision NOT NULL DEFAULT 0;`,
+					`ALTER TABLE nodes ADD uptime_reputation_alpha double precision NOT NULL DEFAULT 0;`,
+					`ALTER TABLE nodes ADD uptime_reputation_beta double precision NOT NULL DEFAULT 0;`,
+					`UPDATE nodes SET
+						audit_reputation_alpha = audit_success_count,
+						audit_reputation_beta = total_audit_count - audit_success_count,
+						uptime_reputation_alpha = uptime_success_count,
+						uptime_reputation_beta = total_uptime_count - uptime_success_count;`,
+				},
+			},
+			{
+				Description: "Order repair queue by segment health",
+				Version:     28,
+				Action: migrate.SQL{
+					`ALTER TABLE injuredsegments ADD num_healthy_pieces integer NOT NULL DEFAULT 0;`,
+					`ALTER TABLE injuredsegments ADD inserted_at timestamp NOT NULL DEFAULT 'epoch';`,
+					`CREATE INDEX injuredsegments_num_healthy_pieces_inserted_at_index ON injuredsegments ( num_healthy_pieces, inserted_at );`,
+				},
+			},
+			{
+				Description: "Track fraudulent order settlements per storage node",
+				Version:     29,
+				Action: migrate.SQL{
+					`CREATE TABLE storagenode_frauds (
+						storagenode_id bytea NOT NULL,
+						reason integer NOT NULL,
+						count bigint NOT NULL,
+						last_seen_at timestamp NOT NULL,
+						PRIMARY KEY ( storagenode_id, reason )
+					);`,
+				},
+			},
+			{
+				Description: "Add storage node payment statements",
+				Version:     30,
+				Action: migrate.SQL{
+					`CREATE TABLE storagenode_statements (
+						node_id bytea NOT NULL,
+						period timestamp NOT NULL,
+						node_created_at timestamp NOT NULL,
+						wallet text NOT NULL,
+						at_rest_total double precision NOT NULL,
+						get_total bigint NOT NULL,
+						get_repair_total bigint NOT NULL,
+						get_audit_total bigint NOT NULL,
+						storage_amount bigint NOT NULL,
+						egress_amount bigint NOT NULL,
+						repair_amount bigint NOT NULL,
+						audit_amount bigint NOT NULL,
+						held_percent integer NOT NULL,
+						held_amount bigint NOT NULL,
+						payout_amount bigint NOT NULL,
+						created_at timestamp NOT NULL,
+						PRIMARY KEY ( node_id, period )
+					);`,
+				},
+			},
+			{
+				Description: "Add billing invoices, line items and coupons",
+				Version:     31,
+				Action: migrate.SQL{
+					`CREATE TABLE invoices (
+						id bytea NOT NULL,
+						project_id bytea NOT NULL,
+						period_start timestamp NOT NULL,
+						period_end timestamp NOT NULL,
+						subtotal bigint NOT NULL,
+						credits bigint NOT NULL,
+						total bigint NOT NULL,
+						status integer NOT NULL,
+						provider_reference text NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( id ),
+						UNIQUE ( project_id, period_start )
+					);`,
+					`CREATE TABLE invoice_line_items (
+						invoice_id bytea NOT NULL REFERENCES invoices( id ) ON DELETE CASCADE,
+						position integer NOT NULL,
+						kind integer NOT NULL,
+						description text NOT NULL,
+						quantity double precision NOT NULL,
+						unit_price double precision NOT NULL,
+						amount bigint NOT NULL,
+						PRIMARY KEY ( invoice_id, position )
+					);`,
+					`CREATE TABLE coupons (
+						code bytea NOT NULL,
+						project_id bytea,
+						amount bigint NOT NULL,
+						duration_months integer NOT NULL,
+						redeemed_at timestamp,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( code )
+					);`,
+				},
+			},
+			{
+				Description: "Add node location and bucket placement policies",
+				Version:     32,
+				Action: migrate.SQL{
+					`ALTER TABLE nodes ADD last_net text;`,
+					`ALTER TABLE nodes ADD country_code text;`,
+					`CREATE TABLE bucket_placements (
+						project_id bytea NOT NULL,
+						bucket_name bytea NOT NULL,
+						placement text NOT NULL,
+						created_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( project_id, bucket_name )
+					);`,
+				},
+			},
+			{
+				Description: "Add node upload stats",
+				Version:     33,
+				Action: migrate.SQL{
+					`CREATE TABLE node_upload_stats (
+						node_id bytea NOT NULL,
+						upload_count bigint NOT NULL,
+						success_ratio double precision NOT NULL,
+						long_tail_ratio double precision NOT NULL,
+						throughput double precision NOT NULL,
+						updated_at timestamp with time zone NOT NULL,
+						PRIMARY KEY ( node_id )
+					);`,
+				},
+			},
 		},
 	}
 }
@@ -184,3 +184,156 @@ func (db *ordersDB) UnuseSerialNumber(ctx context.Context, serialNumber storj.Se
 	_, err := db.db.ExecContext(ctx, db.db.Rebind(statement), storageNodeID.Bytes(), serialNumber.Bytes())
 	return err
 }
+
+// bucketAction identifies bucket bandwidth rollups updated in a settlement batch
+type bucketAction struct {
+	bucketID string
+	action   pb.PieceAction
+}
+
+// ProcessOrders settles a batch of verified orders from a storage node within a single transaction
+func (db *ordersDB) ProcessOrders(ctx context.Context, storageNodeID storj.NodeID, requests []*orders.ProcessOrderRequest, intervalStart time.Time) (responses []*pb.SettlementResponse, err error) {
+	err = db.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+		responses = make([]*pb.SettlementResponse, 0, len(requests))
+		bucketSettled := make(map[bucketAction]int64)
+		storagenodeSettled := make(map[pb.PieceAction]int64)
+
+		for _, request := range requests {
+			serialNumber := request.OrderLimit.SerialNumber
+			response := &pb.SettlementResponse{
+				SerialNumber: serialNumber,
+				Status:       pb.SettlementResponse_REJECTED,
+			}
+			responses = append(responses, response)
+
+			var serialNumberID int64
+			var bucketID []byte
+			err := tx.Tx.QueryRowContext(ctx, db.db.Rebind(
+				`SELECT id, bucket_id FROM serial_numbers WHERE serial_number = ?`,
+			), serialNumber.Bytes()).Scan(&serialNumberID, &bucketID)
+			if err == sql.ErrNoRows {
+				response.Reason = pb.SettlementResponse_UNKNOWN_SERIAL
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			// a failed insert would abort the whole transaction on postgres,
+			// so duplicates are detected from the affected rows instead
+			result, err := tx.Tx.ExecContext(ctx, db.db.Rebind(
+				`INSERT INTO used_serials (serial_number_id, storage_node_id) VALUES (?, ?)
+				ON CONFLICT DO NOTHING`,
+			), serialNumberID, storageNodeID.Bytes())
+			if err != nil {
+				return err
+			}
+			inserted, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			if inserted == 0 {
+				response.Reason = pb.SettlementResponse_DUPLICATE_SERIAL
+				continue
+			}
+
+			response.Status = pb.SettlementResponse_ACCEPTED
+			bucketSettled[bucketAction{string(bucketID), request.OrderLimit.Action}] += request.Order.Amount
+			storagenodeSettled[request.OrderLimit.Action] += request.Order.Amount
+		}
+
+		for key, amount := range bucketSettled {
+			pathElements := bytes.Split([]byte(key.bucketID), []byte("/"))
+			bucketName, projectID := pathElements[1], pathElements[0]
+			_, err := tx.Tx.ExecContext(ctx, db.db.Rebind(
+				`INSERT INTO bucket_bandwidth_rollups (bucket_name, project_id, interval_start, interval_seconds, action, inline, allocated, settled)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT(bucket_name, project_id, interval_start, action)
+				DO UPDATE SET settled = bucket_bandwidth_rollups.settled + ?`,
+			), bucketName, projectID, intervalStart, defaultIntervalSeconds, key.action, 0, 0, uint64(amount), uint64(amount))
+			if err != nil {
+				return err
+			}
+		}
+
+		for action, amount := range storagenodeSettled {
+			_, err := tx.Tx.ExecContext(ctx, db.db.Rebind(
+				`INSERT INTO storagenode_bandwidth_rollups (storagenode_id, interval_start, interval_seconds, action, allocated, settled)
+				VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT(storagenode_id, interval_start, action)
+				DO UPDATE SET settled = storagenode_bandwidth_rollups.settled + ?`,
+			), storageNodeID.Bytes(), intervalStart, defaultIntervalSeconds, action, 0, uint64(amount), uint64(amount))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
+
+// RecordFraud adds rejected settlements to the fraud counters of a storage node and returns its total fraud count
+func (db *ordersDB) RecordFraud(ctx context.Context, storageNodeID storj.NodeID, reasons map[pb.SettlementResponse_RejectReason]int64) (total int64, err error) {
+	err = db.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+		rows, err := tx.All_StoragenodeFraud_By_StoragenodeId(ctx, dbx.StoragenodeFraud_StoragenodeId(storageNodeID.Bytes()))
+		if err != nil {
+			return err
+		}
+
+		existing := make(map[pb.SettlementResponse_RejectReason]int64)
+		for _, row := range rows {
+			existing[pb.SettlementResponse_RejectReason(row.Reason)] = row.Count
+			total += row.Count
+		}
+
+		now := time.Now().UTC()
+		for reason, count := range reasons {
+			total += count
+
+			previous, ok := existing[reason]
+			if !ok {
+				_, err = tx.Create_StoragenodeFraud(ctx,
+					dbx.StoragenodeFraud_StoragenodeId(storageNodeID.Bytes()),
+					dbx.StoragenodeFraud_Reason(int(reason)),
+					dbx.StoragenodeFraud_Count(count),
+					dbx.StoragenodeFraud_LastSeenAt(now),
+				)
+			} else {
+				_, err = tx.Update_StoragenodeFraud_By_StoragenodeId_And_Reason(ctx,
+					dbx.StoragenodeFraud_StoragenodeId(storageNodeID.Bytes()),
+					dbx.StoragenodeFraud_Reason(int(reason)),
+					dbx.StoragenodeFraud_Update_Fields{
+						Count:      dbx.StoragenodeFraud_Count(previous + count),
+						LastSeenAt: dbx.StoragenodeFraud_LastSeenAt(now),
+					},
+				)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// GetFraud returns the fraud counters of a storage node by reject reason
+func (db *ordersDB) GetFraud(ctx context.Context, storageNodeID storj.NodeID) (_ map[pb.SettlementResponse_RejectReason]int64, err error) {
+	rows, err := db.db.All_StoragenodeFraud_By_StoragenodeId(ctx, dbx.StoragenodeFraud_StoragenodeId(storageNodeID.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make(map[pb.SettlementResponse_RejectReason]int64, len(rows))
+	for _, row := range rows {
+		reasons[pb.SettlementResponse_RejectReason(row.Reason)] = row.Count
+	}
+	return reasons, nil
+}
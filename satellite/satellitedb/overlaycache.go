@@ -6,12 +6,14 @@ package satellitedb
 import (
 	"context"
 	"database/sql"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/golang/protobuf/ptypes"
 	"github.com/lib/pq"
 	sqlite3 "github.com/mattn/go-sqlite3"
+	"github.com/skyrings/skyring-common/tools/uuid"
 	"github.com/zeebo/errs"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
@@ -45,7 +47,8 @@ func (cache *overlaycache) SelectStorageNodes(ctx context.Context, count int, cr
 		  AND total_uptime_count >= ?
 		  AND uptime_ratio >= ?
 		  AND last_contact_success > ?
-		  AND last_contact_success > last_contact_failure`
+		  AND last_contact_success > last_contact_failure
+		  AND NOT disqualified AND suspended_at IS NULL`
 	args := append(make([]interface{}, 0, 13),
 		nodeType, criteria.FreeBandwidth, criteria.FreeDisk,
 		criteria.AuditCount, criteria.AuditSuccessRatio, criteria.UptimeCount, criteria.UptimeSuccessRatio,
@@ -62,17 +65,19 @@ func (cache *overlaycache) SelectStorageNodes(ctx context.Context, count int, cr
 		args = append(args, v.Major, v.Major, v.Minor, v.Minor, v.Patch)
 	}
 
-	if !criteria.DistinctIP {
-		nodes, err = cache.queryNodes(ctx, criteria.ExcludedNodes, count, safeQuery, args...)
+	safeQuery, args = placementQuery(criteria, safeQuery, args)
+
+	if !criteria.DistinctIP && !criteria.DistinctSubnet {
+		nodes, err = cache.queryNodes(ctx, criteria.ExcludedNodes, count, safeQuery, criteria.PreferFast, args...)
 		if err != nil {
 			return nil, err
 		}
 		return nodes, nil
 	}
 
-	// query for distinct IPs
+	// query for distinct IPs or subnets
 	for i := 0; i < 3; i++ {
-		moreNodes, err := cache.queryNodesDistinct(ctx, criteria.ExcludedNodes, criteria.ExcludedIPs, count-len(nodes), safeQuery, criteria.DistinctIP, args...)
+		moreNodes, err := cache.queryNodesDistinct(ctx, criteria.ExcludedNodes, criteria.ExcludedIPs, count-len(nodes), safeQuery, criteria.DistinctSubnet, criteria.PreferFast, args...)
 		if err != nil {
 			return nil, err
 		}
@@ -96,7 +101,8 @@ func (cache *overlaycache) SelectNewStorageNodes(ctx context.Context, count int,
 		WHERE type = ? AND free_bandwidth >= ? AND free_disk >= ?
 		  AND total_audit_count < ? AND audit_success_ratio >= ?
 		  AND last_contact_success > ?
-		  AND last_contact_success > last_contact_failure`
+		  AND last_contact_success > last_contact_failure
+		  AND NOT disqualified AND suspended_at IS NULL`
 	args := append(make([]interface{}, 0, 10),
 		nodeType, criteria.FreeBandwidth, criteria.FreeDisk, criteria.AuditCount, criteria.AuditSuccessRatio, time.Now().Add(-criteria.OnlineWindow))
 
@@ -111,17 +117,19 @@ func (cache *overlaycache) SelectNewStorageNodes(ctx context.Context, count int,
 		args = append(args, v.Major, v.Major, v.Minor, v.Minor, v.Patch)
 	}
 
-	if !criteria.DistinctIP {
-		nodes, err = cache.queryNodes(ctx, criteria.ExcludedNodes, count, safeQuery, args...)
+	safeQuery, args = placementQuery(criteria, safeQuery, args)
+
+	if !criteria.DistinctIP && !criteria.DistinctSubnet {
+		nodes, err = cache.queryNodes(ctx, criteria.ExcludedNodes, count, safeQuery, criteria.PreferFast, args...)
 		if err != nil {
 			return nil, err
 		}
 		return nodes, nil
 	}
 
-	// query for distinct IPs
+	// query for distinct IPs or subnets
 	for i := 0; i < 3; i++ {
-		moreNodes, err := cache.queryNodesDistinct(ctx, criteria.ExcludedNodes, criteria.ExcludedIPs, count-len(nodes), safeQuery, criteria.DistinctIP, args...)
+		moreNodes, err := cache.queryNodesDistinct(ctx, criteria.ExcludedNodes, criteria.ExcludedIPs, count-len(nodes), safeQuery, criteria.DistinctSubnet, criteria.PreferFast, args...)
 		if err != nil {
 			return nil, err
 		}
@@ -138,7 +146,30 @@ func (cache *overlaycache) SelectNewStorageNodes(ctx context.Context, count int,
 	return nodes, nil
 }
 
-func (cache *overlaycache) queryNodes(ctx context.Context, excludedNodes []storj.NodeID, count int, safeQuery string, args ...interface{}) (_ []*pb.Node, err error) {
+// placementQuery appends the location constraints of criteria to safeQuery
+func placementQuery(criteria *overlay.NodeCriteria, safeQuery string, args []interface{}) (string, []interface{}) {
+	if len(criteria.Countries) > 0 {
+		safeQuery += ` AND country_code IN (?` + strings.Repeat(", ?", len(criteria.Countries)-1) + `)`
+		for _, country := range criteria.Countries {
+			args = append(args, country)
+		}
+	}
+	if len(criteria.ExcludedCountries) > 0 {
+		safeQuery += ` AND (country_code IS NULL OR country_code NOT IN (?` + strings.Repeat(", ?", len(criteria.ExcludedCountries)-1) + `))`
+		for _, country := range criteria.ExcludedCountries {
+			args = append(args, country)
+		}
+	}
+	if len(criteria.ExcludedNetworks) > 0 {
+		safeQuery += ` AND (last_net IS NULL OR last_net NOT IN (?` + strings.Repeat(", ?", len(criteria.ExcludedNetworks)-1) + `))`
+		for _, network := range criteria.ExcludedNetworks {
+			args = append(args, network)
+		}
+	}
+	return safeQuery, args
+}
+
+func (cache *overlaycache) queryNodes(ctx context.Context, excludedNodes []storj.NodeID, count int, safeQuery string, preferFast bool, args ...interface{}) (_ []*pb.Node, err error) {
 	if count == 0 {
 		return nil, nil
 	}
@@ -153,14 +184,16 @@ func (cache *overlaycache) queryNodes(ctx context.Context, excludedNodes []storj
 
 	args = append(args, count)
 
+	order := cache.selectionOrder(preferFast)
+
 	var rows *sql.Rows
 	rows, err = cache.db.Query(cache.db.Rebind(`SELECT id,
 	type, address, last_ip, free_bandwidth, free_disk, audit_success_ratio,
 	uptime_ratio, total_audit_count, audit_success_count, total_uptime_count,
 	uptime_success_count
-	FROM nodes
+	FROM `+order.from+`
 	`+safeQuery+safeExcludeNodes+`
-	ORDER BY RANDOM()
+	ORDER BY `+order.by(order.score)+`
 	LIMIT ?`), args...)
 
 	if err != nil {
@@ -189,18 +222,18 @@ func (cache *overlaycache) queryNodes(ctx context.Context, excludedNodes []storj
 	return nodes, rows.Err()
 }
 
-func (cache *overlaycache) queryNodesDistinct(ctx context.Context, excludedNodes []storj.NodeID, excludedIPs []string, count int, safeQuery string, distinctIP bool, args ...interface{}) (_ []*pb.Node, err error) {
+func (cache *overlaycache) queryNodesDistinct(ctx context.Context, excludedNodes []storj.NodeID, excludedIPs []string, count int, safeQuery string, distinctSubnet, preferFast bool, args ...interface{}) (_ []*pb.Node, err error) {
 	switch t := cache.db.DB.Driver().(type) {
 	case *sqlite3.SQLiteDriver:
-		return cache.sqliteQueryNodesDistinct(ctx, excludedNodes, excludedIPs, count, safeQuery, distinctIP, args...)
+		return cache.sqliteQueryNodesDistinct(ctx, excludedNodes, excludedIPs, count, safeQuery, distinctSubnet, preferFast, args...)
 	case *pq.Driver:
-		return cache.postgresQueryNodesDistinct(ctx, excludedNodes, excludedIPs, count, safeQuery, distinctIP, args...)
+		return cache.postgresQueryNodesDistinct(ctx, excludedNodes, excludedIPs, count, safeQuery, distinctSubnet, preferFast, args...)
 	default:
 		return []*pb.Node{}, Error.New("Unsupported database %t", t)
 	}
 }
 
-func (cache *overlaycache) sqliteQueryNodesDistinct(ctx context.Context, excludedNodes []storj.NodeID, excludedIPs []string, count int, safeQuery string, distinctIP bool, args ...interface{}) (_ []*pb.Node, err error) {
+func (cache *overlaycache) sqliteQueryNodesDistinct(ctx context.Context, excludedNodes []storj.NodeID, excludedIPs []string, count int, safeQuery string, distinctSubnet, preferFast bool, args ...interface{}) (_ []*pb.Node, err error) {
 	if count == 0 {
 		return nil, nil
 	}
@@ -223,17 +256,20 @@ func (cache *overlaycache) sqliteQueryNodesDistinct(ctx context.Context, exclude
 
 	args = append(args, count)
 
+	order := cache.selectionOrder(preferFast)
+
 	rows, err := cache.db.Query(cache.db.Rebind(`SELECT id,
 	type, address, last_ip, free_bandwidth, free_disk, audit_success_ratio,
 	uptime_ratio, total_audit_count, audit_success_count, total_uptime_count,
 	uptime_success_count
 	FROM (SELECT id, type, address, last_ip, free_bandwidth, free_disk, audit_success_ratio,
 		uptime_ratio, total_audit_count, audit_success_count, total_uptime_count, uptime_success_count,
-		Row_number() OVER(PARTITION BY last_ip ORDER BY RANDOM()) rn
-		FROM nodes
+		`+order.score+` AS score,
+		Row_number() OVER(PARTITION BY `+distinctColumn(distinctSubnet)+` ORDER BY `+order.by(order.score)+`) rn
+		FROM `+order.from+`
 		`+safeQuery+safeExcludeNodes+safeExcludeIPs+`) n
 	WHERE rn = 1
-	ORDER BY RANDOM()
+	ORDER BY `+order.by("score")+`
 	LIMIT ?`), args...)
 
 	if err != nil {
@@ -262,7 +298,7 @@ func (cache *overlaycache) sqliteQueryNodesDistinct(ctx context.Context, exclude
 	return nodes, rows.Err()
 }
 
-func (cache *overlaycache) postgresQueryNodesDistinct(ctx context.Context, excludedNodes []storj.NodeID, excludedIPs []string, count int, safeQuery string, distinctIP bool, args ...interface{}) (_ []*pb.Node, err error) {
+func (cache *overlaycache) postgresQueryNodesDistinct(ctx context.Context, excludedNodes []storj.NodeID, excludedIPs []string, count int, safeQuery string, distinctSubnet, preferFast bool, args ...interface{}) (_ []*pb.Node, err error) {
 	if count == 0 {
 		return nil, nil
 	}
@@ -284,17 +320,19 @@ func (cache *overlaycache) postgresQueryNodesDistinct(ctx context.Context, exclu
 	}
 	args = append(args, count)
 
-	rows, err := cache.db.Query(cache.db.Rebind(`SELECT DISTINCT ON (last_ip) id,
+	order := cache.selectionOrder(preferFast)
+
+	rows, err := cache.db.Query(cache.db.Rebind(`SELECT DISTINCT ON (`+distinctColumn(distinctSubnet)+`) id,
 	type, address, last_ip, free_bandwidth, free_disk, audit_success_ratio,
 	uptime_ratio, total_audit_count, audit_success_count, total_uptime_count,
 	uptime_success_count
 	FROM (SELECT id,
-		type, address, last_ip, free_bandwidth, free_disk, audit_success_ratio,
+		type, address, last_ip, last_net, free_bandwidth, free_disk, audit_success_ratio,
 		uptime_ratio, total_audit_count, audit_success_count, total_uptime_count,
 		uptime_success_count
-		FROM nodes
+		FROM `+order.from+`
 		`+safeQuery+safeExcludeNodes+safeExcludeIPs+`
-		ORDER BY RANDOM()
+		ORDER BY `+order.by(order.score)+`
 		LIMIT ?) n`), args...)
 
 	if err != nil {
@@ -322,6 +360,56 @@ func (cache *overlaycache) postgresQueryNodesDistinct(ctx context.Context, exclu
 	return nodes, rows.Err()
 }
 
+// distinctColumn returns the column nodes have to differ in, nodes without a
+// known subnet are distinguished by their ip
+func distinctColumn(distinctSubnet bool) string {
+	if distinctSubnet {
+		return "COALESCE(last_net, last_ip)"
+	}
+	return "last_ip"
+}
+
+// nodeOrder describes the order nodes are selected in
+type nodeOrder struct {
+	// from are the tables to select nodes from
+	from string
+	// score is the expression nodes with a higher score are preferred by
+	score string
+	// random is a random fraction between 0 and 1
+	random string
+}
+
+// selectionOrder returns the order to select nodes in. Nodes are selected at
+// random unless preferFast is set, in which case nodes are ordered by a random
+// fraction of their score, the product of their upload success ratio and their
+// upload throughput. Fast nodes are then more likely to be selected while slow
+// nodes are still selected occasionally.
+func (cache *overlaycache) selectionOrder(preferFast bool) nodeOrder {
+	if !preferFast {
+		return nodeOrder{from: "nodes", score: "0"}
+	}
+
+	order := nodeOrder{
+		from:   "nodes LEFT JOIN node_upload_stats ON node_upload_stats.node_id = nodes.id",
+		score:  "COALESCE(success_ratio * throughput, 0)",
+		random: "RANDOM()",
+	}
+	if _, ok := cache.db.DB.Driver().(*sqlite3.SQLiteDriver); ok {
+		// sqlite returns a random 64 bit integer
+		order.random = "(RANDOM() / 18446744073709551616.0 + 0.5)"
+	}
+	return order
+}
+
+// by returns the ORDER BY expression for nodes with score
+func (order nodeOrder) by(score string) string {
+	if order.random == "" {
+		return "RANDOM()"
+	}
+	// nodes without a score are ordered at random
+	return score + " * " + order.random + " DESC, RANDOM()"
+}
+
 // Get looks up the node by nodeID
 func (cache *overlaycache) Get(ctx context.Context, id storj.NodeID) (*overlay.NodeDossier, error) {
 	if id.IsZero() {
@@ -349,6 +437,7 @@ func (cache *overlaycache) VetNode(ctx context.Context, id storj.NodeID, criteri
 		AND audit_success_ratio >= ?
 		AND total_uptime_count >= ?
 		AND uptime_ratio >= ?
+		AND NOT disqualified
 		`), id, pb.NodeType_STORAGE, criteria.AuditCount, criteria.AuditSuccessRatio,
 		criteria.UptimeCount, criteria.UptimeSuccessRatio)
 	var bytes *[]byte
@@ -383,6 +472,7 @@ func (cache *overlaycache) KnownUnreliableOrOffline(ctx context.Context, criteri
 			WHERE id IN (?`+strings.Repeat(", ?", len(nodeIds)-1)+`)
 			AND audit_success_ratio >= ? AND uptime_ratio >= ?
 			AND last_contact_success > ? AND last_contact_success > last_contact_failure
+			AND NOT disqualified
 		`), args...)
 
 	case *pq.Driver:
@@ -391,6 +481,7 @@ func (cache *overlaycache) KnownUnreliableOrOffline(ctx context.Context, criteri
 				WHERE id = any($1::bytea[])
 				AND audit_success_ratio >= $2 AND uptime_ratio >= $3
 				AND last_contact_success > $4 AND last_contact_success > last_contact_failure
+				AND NOT disqualified
 			`, postgresNodeIDList(nodeIds),
 			criteria.AuditSuccessRatio, criteria.UptimeSuccessRatio,
 			time.Now().Add(-criteria.OnlineWindow),
@@ -501,6 +592,11 @@ func (cache *overlaycache) UpdateAddress(ctx context.Context, info *pb.Node) (er
 			dbx.Node_LastContactFailure(time.Time{}),
 			dbx.Node_Contained(false),
 			dbx.Node_Disqualified(false),
+			dbx.Node_AuditReputationAlpha(0),
+			dbx.Node_AuditReputationBeta(0),
+			dbx.Node_UptimeReputationAlpha(0),
+			dbx.Node_UptimeReputationBeta(0),
+			dbx.Node_Create_Fields{},
 		)
 		if err != nil {
 			return Error.Wrap(errs.Combine(err, tx.Rollback()))
@@ -521,6 +617,128 @@ func (cache *overlaycache) UpdateAddress(ctx context.Context, info *pb.Node) (er
 	return Error.Wrap(tx.Commit())
 }
 
+// UpdateLocation updates the network and country of the node
+func (cache *overlaycache) UpdateLocation(ctx context.Context, nodeID storj.NodeID, location overlay.NodeLocation) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	update := dbx.Node_Update_Fields{
+		LastNet:     dbx.Node_LastNet_Null(),
+		CountryCode: dbx.Node_CountryCode_Null(),
+	}
+	if location.Network != "" {
+		update.LastNet = dbx.Node_LastNet(location.Network)
+	}
+	if location.Country != "" {
+		update.CountryCode = dbx.Node_CountryCode(location.Country)
+	}
+	_, err = cache.db.Update_Node_By_Id(ctx, dbx.Node_Id(nodeID.Bytes()), update)
+	return Error.Wrap(err)
+}
+
+// GetBucketPlacement returns the placement policy of the bucket, empty when it has none
+func (cache *overlaycache) GetBucketPlacement(ctx context.Context, projectID uuid.UUID, bucketName []byte) (placement string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = cache.db.QueryRowContext(ctx, cache.db.Rebind(
+		`SELECT placement FROM bucket_placements WHERE project_id = ? AND bucket_name = ?`,
+	), projectID[:], bucketName).Scan(&placement)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return placement, Error.Wrap(err)
+}
+
+// SetBucketPlacement sets the placement policy of the bucket, an empty policy removes it
+func (cache *overlaycache) SetBucketPlacement(ctx context.Context, projectID uuid.UUID, bucketName []byte, placement string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if placement == "" {
+		_, err = cache.db.ExecContext(ctx, cache.db.Rebind(
+			`DELETE FROM bucket_placements WHERE project_id = ? AND bucket_name = ?`,
+		), projectID[:], bucketName)
+		return Error.Wrap(err)
+	}
+
+	_, err = cache.db.ExecContext(ctx, cache.db.Rebind(
+		`INSERT INTO bucket_placements (project_id, bucket_name, placement, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(project_id, bucket_name)
+		DO UPDATE SET placement = ?`,
+	), projectID[:], bucketName, placement, time.Now().UTC(), placement)
+	return Error.Wrap(err)
+}
+
+// GetUploadStats returns how uploads to the node went
+func (cache *overlaycache) GetUploadStats(ctx context.Context, nodeID storj.NodeID) (stats *overlay.UploadStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	stats = &overlay.UploadStats{}
+	err = cache.db.QueryRowContext(ctx, cache.db.Rebind(
+		`SELECT upload_count, success_ratio, long_tail_ratio, throughput FROM node_upload_stats WHERE node_id = ?`,
+	), nodeID.Bytes()).Scan(&stats.Count, &stats.SuccessRatio, &stats.LongTailRatio, &stats.Throughput)
+	if err == sql.ErrNoRows {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return stats, nil
+}
+
+// UpdateUploadStats records how uploads to storage nodes went and updates the
+// latency of the nodes with the duration of successful uploads
+func (cache *overlaycache) UpdateUploadStats(ctx context.Context, uploads []*pb.PieceUploadStats, lambda float64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	err = cache.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+		for _, upload := range uploads {
+			stats := overlay.UploadStats{}
+			err := tx.Tx.QueryRowContext(ctx, cache.db.Rebind(
+				`SELECT upload_count, success_ratio, long_tail_ratio, throughput FROM node_upload_stats WHERE node_id = ?`,
+			), upload.NodeId.Bytes()).Scan(&stats.Count, &stats.SuccessRatio, &stats.LongTailRatio, &stats.Throughput)
+			if err != nil && err != sql.ErrNoRows {
+				return err
+			}
+
+			stats = updateUploadStats(stats, upload, lambda)
+			_, err = tx.Tx.ExecContext(ctx, cache.db.Rebind(
+				`INSERT INTO node_upload_stats (node_id, upload_count, success_ratio, long_tail_ratio, throughput, updated_at)
+				VALUES (?, ?, ?, ?, ?, ?)
+				ON CONFLICT(node_id)
+				DO UPDATE SET upload_count = ?, success_ratio = ?, long_tail_ratio = ?, throughput = ?, updated_at = ?`,
+			), upload.NodeId.Bytes(), stats.Count, stats.SuccessRatio, stats.LongTailRatio, stats.Throughput, time.Now().UTC(),
+				stats.Count, stats.SuccessRatio, stats.LongTailRatio, stats.Throughput, time.Now().UTC())
+			if err != nil {
+				return err
+			}
+
+			if upload.Status != pb.PieceUploadStats_SUCCEEDED {
+				continue
+			}
+
+			var latency int64
+			err = tx.Tx.QueryRowContext(ctx, cache.db.Rebind(
+				`SELECT latency_90 FROM nodes WHERE id = ?`,
+			), upload.NodeId.Bytes()).Scan(&latency)
+			if err == sql.ErrNoRows {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Tx.ExecContext(ctx, cache.db.Rebind(
+				`UPDATE nodes SET latency_90 = ? WHERE id = ?`,
+			), updateLatency90(latency, upload.DurationMs, lambda), upload.NodeId.Bytes())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return Error.Wrap(err)
+}
+
 // CreateStats initializes the stats the provided storagenode
 func (cache *overlaycache) CreateStats(ctx context.Context, nodeID storj.NodeID, startingStats *overlay.NodeStats) (stats *overlay.NodeStats, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -546,12 +764,16 @@ func (cache *overlaycache) CreateStats(ctx context.Context, nodeID storj.NodeID,
 		}
 
 		updateFields := dbx.Node_Update_Fields{
-			AuditSuccessCount:  dbx.Node_AuditSuccessCount(startingStats.AuditSuccessCount),
-			TotalAuditCount:    dbx.Node_TotalAuditCount(startingStats.AuditCount),
-			AuditSuccessRatio:  dbx.Node_AuditSuccessRatio(auditSuccessRatio),
-			UptimeSuccessCount: dbx.Node_UptimeSuccessCount(startingStats.UptimeSuccessCount),
-			TotalUptimeCount:   dbx.Node_TotalUptimeCount(startingStats.UptimeCount),
-			UptimeRatio:        dbx.Node_UptimeRatio(uptimeRatio),
+			AuditSuccessCount:     dbx.Node_AuditSuccessCount(startingStats.AuditSuccessCount),
+			TotalAuditCount:       dbx.Node_TotalAuditCount(startingStats.AuditCount),
+			AuditSuccessRatio:     dbx.Node_AuditSuccessRatio(auditSuccessRatio),
+			AuditReputationAlpha:  dbx.Node_AuditReputationAlpha(float64(startingStats.AuditSuccessCount)),
+			AuditReputationBeta:   dbx.Node_AuditReputationBeta(float64(startingStats.AuditCount - startingStats.AuditSuccessCount)),
+			UptimeSuccessCount:    dbx.Node_UptimeSuccessCount(startingStats.UptimeSuccessCount),
+			TotalUptimeCount:      dbx.Node_TotalUptimeCount(startingStats.UptimeCount),
+			UptimeRatio:           dbx.Node_UptimeRatio(uptimeRatio),
+			UptimeReputationAlpha: dbx.Node_UptimeReputationAlpha(float64(startingStats.UptimeSuccessCount)),
+			UptimeReputationBeta:  dbx.Node_UptimeReputationBeta(float64(startingStats.UptimeCount - startingStats.UptimeSuccessCount)),
 		}
 
 		dbNode, err = tx.Update_Node_By_Id(ctx, dbx.Node_Id(nodeID.Bytes()), updateFields)
@@ -584,32 +806,43 @@ func (cache *overlaycache) UpdateStats(ctx context.Context, updateReq *overlay.U
 		return nil, Error.Wrap(errs.Combine(err, tx.Rollback()))
 	}
 
-	auditSuccessCount := dbNode.AuditSuccessCount
-	totalAuditCount := dbNode.TotalAuditCount
-	var auditSuccessRatio float64
-	uptimeSuccessCount := dbNode.UptimeSuccessCount
-	totalUptimeCount := dbNode.TotalUptimeCount
-	var uptimeRatio float64
-
-	auditSuccessCount, totalAuditCount, auditSuccessRatio = updateRatioVars(
+	auditSuccessCount, totalAuditCount := updateCounts(
 		updateReq.AuditSuccess,
-		auditSuccessCount,
-		totalAuditCount,
+		dbNode.AuditSuccessCount,
+		dbNode.TotalAuditCount,
+	)
+	auditAlpha, auditBeta, auditSuccessRatio := updateReputation(
+		updateReq.AuditSuccess,
+		dbNode.AuditReputationAlpha,
+		dbNode.AuditReputationBeta,
+		updateReq.AuditLambda,
+		updateReq.AuditWeight,
 	)
 
-	uptimeSuccessCount, totalUptimeCount, uptimeRatio = updateRatioVars(
+	uptimeSuccessCount, totalUptimeCount := updateCounts(
+		updateReq.IsUp,
+		dbNode.UptimeSuccessCount,
+		dbNode.TotalUptimeCount,
+	)
+	uptimeAlpha, uptimeBeta, uptimeRatio := updateReputation(
 		updateReq.IsUp,
-		uptimeSuccessCount,
-		totalUptimeCount,
+		dbNode.UptimeReputationAlpha,
+		dbNode.UptimeReputationBeta,
+		updateReq.UptimeLambda,
+		updateReq.UptimeWeight,
 	)
 
 	updateFields := dbx.Node_Update_Fields{
-		AuditSuccessCount:  dbx.Node_AuditSuccessCount(auditSuccessCount),
-		TotalAuditCount:    dbx.Node_TotalAuditCount(totalAuditCount),
-		AuditSuccessRatio:  dbx.Node_AuditSuccessRatio(auditSuccessRatio),
-		UptimeSuccessCount: dbx.Node_UptimeSuccessCount(uptimeSuccessCount),
-		TotalUptimeCount:   dbx.Node_TotalUptimeCount(totalUptimeCount),
-		UptimeRatio:        dbx.Node_UptimeRatio(uptimeRatio),
+		AuditSuccessCount:     dbx.Node_AuditSuccessCount(auditSuccessCount),
+		TotalAuditCount:       dbx.Node_TotalAuditCount(totalAuditCount),
+		AuditSuccessRatio:     dbx.Node_AuditSuccessRatio(auditSuccessRatio),
+		AuditReputationAlpha:  dbx.Node_AuditReputationAlpha(auditAlpha),
+		AuditReputationBeta:   dbx.Node_AuditReputationBeta(auditBeta),
+		UptimeSuccessCount:    dbx.Node_UptimeSuccessCount(uptimeSuccessCount),
+		TotalUptimeCount:      dbx.Node_TotalUptimeCount(totalUptimeCount),
+		UptimeRatio:           dbx.Node_UptimeRatio(uptimeRatio),
+		UptimeReputationAlpha: dbx.Node_UptimeReputationAlpha(uptimeAlpha),
+		UptimeReputationBeta:  dbx.Node_UptimeReputationBeta(uptimeBeta),
 	}
 
 	if updateReq.IsUp {
@@ -677,7 +910,7 @@ func (cache *overlaycache) UpdateNodeInfo(ctx context.Context, nodeID storj.Node
 }
 
 // UpdateUptime updates a single storagenode's uptime stats in the db
-func (cache *overlaycache) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool) (stats *overlay.NodeStats, err error) {
+func (cache *overlaycache) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool, lambda, weight float64) (stats *overlay.NodeStats, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	tx, err := cache.db.Open(ctx)
@@ -689,21 +922,26 @@ func (cache *overlaycache) UpdateUptime(ctx context.Context, nodeID storj.NodeID
 		return nil, Error.Wrap(errs.Combine(err, tx.Rollback()))
 	}
 
-	uptimeSuccessCount := dbNode.UptimeSuccessCount
-	totalUptimeCount := dbNode.TotalUptimeCount
-	var uptimeRatio float64
-
 	updateFields := dbx.Node_Update_Fields{}
 
-	uptimeSuccessCount, totalUptimeCount, uptimeRatio = updateRatioVars(
+	uptimeSuccessCount, totalUptimeCount := updateCounts(
+		isUp,
+		dbNode.UptimeSuccessCount,
+		dbNode.TotalUptimeCount,
+	)
+	uptimeAlpha, uptimeBeta, uptimeRatio := updateReputation(
 		isUp,
-		uptimeSuccessCount,
-		totalUptimeCount,
+		dbNode.UptimeReputationAlpha,
+		dbNode.UptimeReputationBeta,
+		lambda,
+		weight,
 	)
 
 	updateFields.UptimeSuccessCount = dbx.Node_UptimeSuccessCount(uptimeSuccessCount)
 	updateFields.TotalUptimeCount = dbx.Node_TotalUptimeCount(totalUptimeCount)
 	updateFields.UptimeRatio = dbx.Node_UptimeRatio(uptimeRatio)
+	updateFields.UptimeReputationAlpha = dbx.Node_UptimeReputationAlpha(uptimeAlpha)
+	updateFields.UptimeReputationBeta = dbx.Node_UptimeReputationBeta(uptimeBeta)
 
 	if isUp {
 		updateFields.LastContactSuccess = dbx.Node_LastContactSuccess(time.Now())
@@ -725,6 +963,78 @@ func (cache *overlaycache) UpdateUptime(ctx context.Context, nodeID storj.NodeID
 	return getNodeStats(dbNode), Error.Wrap(tx.Commit())
 }
 
+// DisqualifyNode disqualifies the node, unless it is already disqualified
+func (cache *overlaycache) DisqualifyNode(ctx context.Context, nodeID storj.NodeID, reason string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := cache.db.ExecContext(ctx, cache.db.Rebind(`
+		UPDATE nodes
+		SET disqualified = ?, disqualified_at = ?, disqualification_reason = ?
+		WHERE id = ? AND NOT disqualified
+	`), true, time.Now().UTC(), reason, nodeID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return cache.checkNodeUpdated(ctx, nodeID, result)
+}
+
+// SuspendNode suspends the node, unless it is already suspended or disqualified
+func (cache *overlaycache) SuspendNode(ctx context.Context, nodeID storj.NodeID, reason string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := cache.db.ExecContext(ctx, cache.db.Rebind(`
+		UPDATE nodes
+		SET suspended_at = ?, suspension_reason = ?
+		WHERE id = ? AND suspended_at IS NULL AND NOT disqualified
+	`), time.Now().UTC(), reason, nodeID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return cache.checkNodeUpdated(ctx, nodeID, result)
+}
+
+// UnsuspendNode lifts the suspension of the node when it was suspended for reason, empty reason matches any suspension
+func (cache *overlaycache) UnsuspendNode(ctx context.Context, nodeID storj.NodeID, reason string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = cache.db.ExecContext(ctx, cache.db.Rebind(`
+		UPDATE nodes
+		SET suspended_at = NULL, suspension_reason = NULL
+		WHERE id = ? AND suspended_at IS NOT NULL AND (? = '' OR suspension_reason = ?)
+	`), nodeID, reason, reason)
+	return Error.Wrap(err)
+}
+
+// ReinstateNode clears both disqualification and suspension of the node
+func (cache *overlaycache) ReinstateNode(ctx context.Context, nodeID storj.NodeID) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	result, err := cache.db.ExecContext(ctx, cache.db.Rebind(`
+		UPDATE nodes
+		SET disqualified = ?, disqualified_at = NULL, disqualification_reason = NULL,
+			suspended_at = NULL, suspension_reason = NULL
+		WHERE id = ?
+	`), false, nodeID)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	return cache.checkNodeUpdated(ctx, nodeID, result)
+}
+
+// checkNodeUpdated returns ErrNodeNotFound when no row was updated and the node doesn't exist
+func (cache *overlaycache) checkNodeUpdated(ctx context.Context, nodeID storj.NodeID, result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	_, err = cache.Get(ctx, nodeID)
+	return err
+}
+
 func convertDBNode(info *dbx.Node) (*overlay.NodeDossier, error) {
 	if info == nil {
 		return nil, Error.New("missing info")
@@ -764,15 +1074,20 @@ func convertDBNode(info *dbx.Node) (*overlay.NodeDossier, error) {
 			FreeDisk:      info.FreeDisk,
 		},
 		Reputation: overlay.NodeStats{
-			Latency90:          info.Latency90,
-			AuditSuccessRatio:  info.AuditSuccessRatio,
-			UptimeRatio:        info.UptimeRatio,
-			AuditCount:         info.TotalAuditCount,
-			AuditSuccessCount:  info.AuditSuccessCount,
-			UptimeCount:        info.TotalUptimeCount,
-			UptimeSuccessCount: info.UptimeSuccessCount,
-			LastContactSuccess: info.LastContactSuccess,
-			LastContactFailure: info.LastContactFailure,
+			Latency90:             info.Latency90,
+			AuditSuccessRatio:     info.AuditSuccessRatio,
+			UptimeRatio:           info.UptimeRatio,
+			AuditCount:            info.TotalAuditCount,
+			AuditSuccessCount:     info.AuditSuccessCount,
+			AuditReputationAlpha:  info.AuditReputationAlpha,
+			AuditReputationBeta:   info.AuditReputationBeta,
+			UptimeCount:           info.TotalUptimeCount,
+			UptimeSuccessCount:    info.UptimeSuccessCount,
+			UptimeReputationAlpha: info.UptimeReputationAlpha,
+			UptimeReputationBeta:  info.UptimeReputationBeta,
+			LastContactSuccess:    info.LastContactSuccess,
+			LastContactFailure:    info.LastContactFailure,
+			SuspendedAt:           info.SuspendedAt,
 		},
 		Version: pb.NodeVersion{
 			Version:    ver.String(),
@@ -782,6 +1097,17 @@ func convertDBNode(info *dbx.Node) (*overlay.NodeDossier, error) {
 		},
 		Contained:    info.Contained,
 		Disqualified: info.Disqualified,
+		Status: overlay.NodeStatus{
+			DisqualifiedAt: info.DisqualifiedAt,
+			SuspendedAt:    info.SuspendedAt,
+		},
+	}
+	if info.DisqualificationReason != nil {
+		node.Status.DisqualificationReason = *info.DisqualificationReason
+	}
+	if info.SuspensionReason != nil {
+		node.Status.SuspensionReason = *info.SuspensionReason
+		node.Reputation.SuspensionReason = *info.SuspensionReason
 	}
 
 	return node, nil
@@ -789,26 +1115,99 @@ func convertDBNode(info *dbx.Node) (*overlay.NodeDossier, error) {
 
 func getNodeStats(dbNode *dbx.Node) *overlay.NodeStats {
 	nodeStats := &overlay.NodeStats{
-		Latency90:          dbNode.Latency90,
-		AuditSuccessRatio:  dbNode.AuditSuccessRatio,
-		AuditSuccessCount:  dbNode.AuditSuccessCount,
-		AuditCount:         dbNode.TotalAuditCount,
-		UptimeRatio:        dbNode.UptimeRatio,
-		UptimeSuccessCount: dbNode.UptimeSuccessCount,
-		UptimeCount:        dbNode.TotalUptimeCount,
-		LastContactSuccess: dbNode.LastContactSuccess,
-		LastContactFailure: dbNode.LastContactFailure,
+		Latency90:             dbNode.Latency90,
+		AuditSuccessRatio:     dbNode.AuditSuccessRatio,
+		AuditSuccessCount:     dbNode.AuditSuccessCount,
+		AuditCount:            dbNode.TotalAuditCount,
+		AuditReputationAlpha:  dbNode.AuditReputationAlpha,
+		AuditReputationBeta:   dbNode.AuditReputationBeta,
+		UptimeRatio:           dbNode.UptimeRatio,
+		UptimeSuccessCount:    dbNode.UptimeSuccessCount,
+		UptimeCount:           dbNode.TotalUptimeCount,
+		UptimeReputationAlpha: dbNode.UptimeReputationAlpha,
+		UptimeReputationBeta:  dbNode.UptimeReputationBeta,
+		LastContactSuccess:    dbNode.LastContactSuccess,
+		LastContactFailure:    dbNode.LastContactFailure,
+		SuspendedAt:           dbNode.SuspendedAt,
+	}
+	if dbNode.SuspensionReason != nil {
+		nodeStats.SuspensionReason = *dbNode.SuspensionReason
 	}
 	return nodeStats
 }
 
-func updateRatioVars(newStatus bool, successCount, totalCount int64) (int64, int64, float64) {
+func updateCounts(newStatus bool, successCount, totalCount int64) (int64, int64) {
 	totalCount++
 	if newStatus {
 		successCount++
 	}
-	newRatio := float64(successCount) / float64(totalCount)
-	return successCount, totalCount, newRatio
+	return successCount, totalCount
+}
+
+// updateReputation updates the alpha and beta parameters of the beta reputation
+// model with a new result and returns them together with the new reputation score.
+// lambda is the forgetting factor and weight the normalization weight of a single
+// result, zero values are treated as 1.
+func updateReputation(isSuccess bool, alpha, beta, lambda, weight float64) (newAlpha, newBeta, score float64) {
+	if lambda == 0 {
+		lambda = 1
+	}
+	if weight == 0 {
+		weight = 1
+	}
+
+	v := -1.0
+	if isSuccess {
+		v = 1.0
+	}
+	newAlpha = lambda*alpha + weight*(1+v)/2
+	newBeta = lambda*beta + weight*(1-v)/2
+	return newAlpha, newBeta, newAlpha / (newAlpha + newBeta)
+}
+
+// updateUploadStats averages the result of an upload into stats. The first
+// uploads are averaged equally until their weight drops below 1-lambda.
+func updateUploadStats(stats overlay.UploadStats, upload *pb.PieceUploadStats, lambda float64) overlay.UploadStats {
+	weight := math.Max(1-lambda, 1/float64(stats.Count+1))
+
+	var success, longTail float64
+	switch upload.Status {
+	case pb.PieceUploadStats_SUCCEEDED:
+		success = 1
+	case pb.PieceUploadStats_LONG_TAIL_CANCELED:
+		longTail = 1
+	}
+
+	stats.Count++
+	stats.SuccessRatio += weight * (success - stats.SuccessRatio)
+	stats.LongTailRatio += weight * (longTail - stats.LongTailRatio)
+
+	if upload.Status == pb.PieceUploadStats_SUCCEEDED && upload.DurationMs > 0 && upload.PieceSize > 0 {
+		throughput := float64(upload.PieceSize) * 1000 / float64(upload.DurationMs)
+		if stats.Throughput == 0 {
+			stats.Throughput = throughput
+		} else {
+			stats.Throughput += (1 - lambda) * (throughput - stats.Throughput)
+		}
+	}
+	return stats
+}
+
+// updateLatency90 moves the latency estimate towards the duration of an upload,
+// nine times faster upwards than downwards, so it approximates the 90th
+// percentile of the upload durations.
+func updateLatency90(latency, durationMs int64, lambda float64) int64 {
+	if latency <= 0 {
+		return durationMs
+	}
+
+	weight := 1 - lambda
+	if durationMs > latency {
+		weight *= 0.9
+	} else {
+		weight *= 0.1
+	}
+	return latency + int64(math.Round(weight*float64(durationMs-latency)))
 }
 
 func checkRatioVars(successCount, totalCount int64) (ratio float64, err error) {
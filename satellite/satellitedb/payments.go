@@ -0,0 +1,111 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite/payments"
+	dbx "storj.io/storj/satellite/satellitedb/dbx"
+)
+
+type paymentsDB struct {
+	db *dbx.DB
+}
+
+const statementColumns = `node_id, period, node_created_at, wallet,
+	at_rest_total, get_total, get_repair_total, get_audit_total,
+	storage_amount, egress_amount, repair_amount, audit_amount,
+	held_percent, held_amount, payout_amount, created_at`
+
+// SaveStatements replaces all statements of the period
+func (db *paymentsDB) SaveStatements(ctx context.Context, period time.Time, statements []*payments.Statement) error {
+	err := db.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
+		_, err := tx.Tx.ExecContext(ctx, db.db.Rebind(`DELETE FROM storagenode_statements WHERE period = ?`), period.UTC())
+		if err != nil {
+			return err
+		}
+
+		statement := db.db.Rebind(`INSERT INTO storagenode_statements (` + statementColumns + `)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+		for _, s := range statements {
+			_, err := tx.Tx.ExecContext(ctx, statement,
+				s.NodeID.Bytes(), period.UTC(), s.NodeCreatedAt.UTC(), s.Wallet,
+				s.AtRestTotal, s.GetTotal, s.GetRepairTotal, s.GetAuditTotal,
+				s.StorageAmount, s.EgressAmount, s.RepairAmount, s.AuditAmount,
+				s.HeldPercent, s.HeldAmount, s.PayoutAmount, s.CreatedAt.UTC(),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return Error.Wrap(err)
+}
+
+// GetStatements returns all statements of the period ordered by node id
+func (db *paymentsDB) GetStatements(ctx context.Context, period time.Time) (_ []*payments.Statement, err error) {
+	rows, err := db.db.QueryContext(ctx, db.db.Rebind(`SELECT `+statementColumns+`
+		FROM storagenode_statements WHERE period = ? ORDER BY node_id`), period.UTC())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	var statements []*payments.Statement
+	for rows.Next() {
+		statement, err := scanStatement(rows)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		statements = append(statements, statement)
+	}
+	return statements, Error.Wrap(rows.Err())
+}
+
+// GetStatement returns the statement of a single storage node for the period
+func (db *paymentsDB) GetStatement(ctx context.Context, nodeID storj.NodeID, period time.Time) (*payments.Statement, error) {
+	row := db.db.QueryRowContext(ctx, db.db.Rebind(`SELECT `+statementColumns+`
+		FROM storagenode_statements WHERE node_id = ? AND period = ?`), nodeID.Bytes(), period.UTC())
+
+	statement, err := scanStatement(row)
+	if err == sql.ErrNoRows {
+		return nil, payments.Error.New("no statement for node %s in %s", nodeID, period.Format("2006-01"))
+	}
+	return statement, Error.Wrap(err)
+}
+
+// rowScanner is implemented by both sql.Row and sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanStatement scans a single statement from a row with statementColumns
+func scanStatement(row rowScanner) (*payments.Statement, error) {
+	var nodeID []byte
+	s := &payments.Statement{}
+	err := row.Scan(&nodeID, &s.Period, &s.NodeCreatedAt, &s.Wallet,
+		&s.AtRestTotal, &s.GetTotal, &s.GetRepairTotal, &s.GetAuditTotal,
+		&s.StorageAmount, &s.EgressAmount, &s.RepairAmount, &s.AuditAmount,
+		&s.HeldPercent, &s.HeldAmount, &s.PayoutAmount, &s.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	s.NodeID, err = storj.NodeIDFromBytes(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	s.Period = s.Period.UTC()
+	s.NodeCreatedAt = s.NodeCreatedAt.UTC()
+	s.CreatedAt = s.CreatedAt.UTC()
+	return s, nil
+}
@@ -7,9 +7,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/lib/pq"
 	sqlite3 "github.com/mattn/go-sqlite3"
+	"github.com/zeebo/errs"
 
 	"storj.io/storj/internal/dbutil/pgutil"
 	"storj.io/storj/internal/dbutil/sqliteutil"
@@ -18,12 +20,36 @@ import (
 	"storj.io/storj/storage"
 )
 
+// repairLease is how long a selected segment is hidden from other
+// repair workers before it can be selected again.
+const repairLease = time.Hour
+
+// postgresNow is the current time of the database in UTC. Leases on
+// postgres are computed with it, so that repair workers on machines with
+// different clocks agree on them. Sqlite databases are local files, whose
+// workers share the clock of their machine.
+const postgresNow = `timezone('utc', now())`
+
 type repairQueue struct {
 	db *dbx.DB
 }
 
 func (r *repairQueue) Insert(ctx context.Context, seg *pb.InjuredSegment) error {
-	_, err := r.db.ExecContext(ctx, r.db.Rebind(`INSERT INTO injuredsegments ( path, data ) VALUES ( ?, ? )`), seg.Path, seg)
+	// segments that are already queued keep their position in time,
+	// but pick up the latest health reported by the checker
+	res, err := r.db.ExecContext(ctx, r.db.Rebind(`UPDATE injuredsegments SET data = ?, num_healthy_pieces = ? WHERE path = ?`), seg, seg.NumHealthyPieces, seg.Path)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	_, err = r.db.ExecContext(ctx, r.db.Rebind(`INSERT INTO injuredsegments ( path, data, num_healthy_pieces, inserted_at ) VALUES ( ?, ?, ?, ? )`), seg.Path, seg, seg.NumHealthyPieces, time.Now().UTC())
 	if err != nil {
 		if pgutil.IsConstraintError(err) || sqliteutil.IsConstraintError(err) {
 			return nil // quietly fail on reinsert
@@ -35,11 +61,11 @@ func (r *repairQueue) Insert(ctx context.Context, seg *pb.InjuredSegment) error
 
 func (r *repairQueue) postgresSelect(ctx context.Context) (seg *pb.InjuredSegment, err error) {
 	err = r.db.QueryRowContext(ctx, `
-	UPDATE injuredsegments SET attempted = timezone('utc', now()) WHERE path = (
+	UPDATE injuredsegments SET attempted = `+postgresNow+` WHERE path = (
 		SELECT path FROM injuredsegments
-		WHERE attempted IS NULL OR attempted < timezone('utc', now()) - interval '1 hour'
-		ORDER BY path FOR UPDATE SKIP LOCKED LIMIT 1
-	) RETURNING data`).Scan(&seg)
+		WHERE attempted IS NULL OR attempted < `+postgresNow+` - $1::float8 * interval '1 second'
+		ORDER BY num_healthy_pieces, inserted_at FOR UPDATE SKIP LOCKED LIMIT 1
+	) RETURNING data`, repairLease.Seconds()).Scan(&seg)
 	if err == sql.ErrNoRows {
 		err = storage.ErrEmptyQueue.New("")
 	}
@@ -47,17 +73,18 @@ func (r *repairQueue) postgresSelect(ctx context.Context) (seg *pb.InjuredSegmen
 }
 
 func (r *repairQueue) sqliteSelect(ctx context.Context) (seg *pb.InjuredSegment, err error) {
+	now := time.Now().UTC()
 	err = r.db.WithTx(ctx, func(ctx context.Context, tx *dbx.Tx) error {
 		var path string
 		err = tx.Tx.QueryRowContext(ctx, r.db.Rebind(`
-			SELECT path, data FROM injuredsegments 
-			WHERE attempted IS NULL 
-			OR attempted < datetime('now','-1 hours') 
-			ORDER BY path LIMIT 1`)).Scan(&path, &seg)
+			SELECT path, data FROM injuredsegments
+			WHERE attempted IS NULL
+			OR attempted < ?
+			ORDER BY num_healthy_pieces, inserted_at LIMIT 1`), now.Add(-repairLease)).Scan(&path, &seg)
 		if err != nil {
 			return err
 		}
-		res, err := tx.Tx.ExecContext(ctx, r.db.Rebind(`UPDATE injuredsegments SET attempted = datetime('now') WHERE path = ?`), path)
+		res, err := tx.Tx.ExecContext(ctx, r.db.Rebind(`UPDATE injuredsegments SET attempted = ? WHERE path = ?`), now, path)
 		if err != nil {
 			return err
 		}
@@ -108,3 +135,31 @@ func (r *repairQueue) SelectN(ctx context.Context, limit int) (segs []pb.Injured
 	}
 	return segs, rows.Err()
 }
+
+func (r *repairQueue) Depth(ctx context.Context) (buckets []*pb.QueueDepthBucket, err error) {
+	leased, arg := "?", interface{}(time.Now().UTC().Add(-repairLease))
+	if _, ok := r.db.DB.Driver().(*pq.Driver); ok {
+		leased, arg = postgresNow+" - ?::float8 * interval '1 second'", repairLease.Seconds()
+	}
+
+	rows, err := r.db.QueryContext(ctx, r.db.Rebind(`
+		SELECT num_healthy_pieces, COUNT(*),
+			SUM(CASE WHEN attempted >= `+leased+` THEN 1 ELSE 0 END)
+		FROM injuredsegments
+		GROUP BY num_healthy_pieces
+		ORDER BY num_healthy_pieces`), arg)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+
+	for rows.Next() {
+		bucket := &pb.QueueDepthBucket{}
+		err = rows.Scan(&bucket.NumHealthyPieces, &bucket.Count, &bucket.Leased)
+		if err != nil {
+			return nil, err
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets, rows.Err()
+}
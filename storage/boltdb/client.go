@@ -179,6 +179,30 @@ func (client *Client) Delete(key storage.Key) error {
 	})
 }
 
+// CompareAndSwap replaces the value of key with newValue when it is oldValue.
+// A nil oldValue expects the key to not exist and a nil newValue deletes the key.
+func (client *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	var changed error
+	err := client.update(func(bucket *bolt.Bucket) error {
+		data := bucket.Get([]byte(key))
+		if changed = storage.CheckValue(key, storage.Value(data), len(data) > 0, oldValue); changed != nil {
+			return nil
+		}
+		if newValue == nil {
+			return bucket.Delete(key)
+		}
+		return bucket.Put(key, newValue)
+	})
+	if err != nil {
+		return err
+	}
+	return changed
+}
+
 // List returns either a list of keys for which boltdb has values or an error.
 func (client *Client) List(first storage.Key, limit int) (storage.Keys, error) {
 	rv, err := storage.ListKeys(client, first, limit)
@@ -19,6 +19,9 @@ var ErrKeyNotFound = errs.Class("key not found")
 // ErrEmptyKey is returned when an empty key is used in Put
 var ErrEmptyKey = errs.Class("empty key")
 
+// ErrValueChanged is returned when the value of a key is not the expected one in CompareAndSwap
+var ErrValueChanged = errs.Class("value changed")
+
 // ErrEmptyQueue is returned when attempting to Dequeue from an empty queue
 var ErrEmptyQueue = errs.Class("empty queue")
 
@@ -60,6 +63,9 @@ type KeyValueStore interface {
 	GetAll(Keys) (Values, error)
 	// Delete deletes key and the value
 	Delete(Key) error
+	// CompareAndSwap replaces the value of key with newValue when it is oldValue.
+	// A nil oldValue expects the key to not exist and a nil newValue deletes the key.
+	CompareAndSwap(key Key, oldValue, newValue Value) error
 	// List lists all keys starting from start and upto limit items
 	List(start Key, limit int) (Keys, error)
 	// Iterate iterates over items based on opts
@@ -160,3 +166,17 @@ func (key Key) Less(b Key) bool { return bytes.Compare([]byte(key), []byte(b)) <
 
 // Equal returns whether key and b are equal
 func (key Key) Equal(b Key) bool { return bytes.Equal([]byte(key), []byte(b)) }
+
+// CheckValue returns the error of CompareAndSwap when the current value of key,
+// which exists when ok, is not oldValue
+func CheckValue(key Key, current Value, ok bool, oldValue Value) error {
+	switch {
+	case oldValue == nil && ok:
+		return ErrValueChanged.New(key.String())
+	case oldValue != nil && !ok:
+		return ErrKeyNotFound.New(key.String())
+	case oldValue != nil && !bytes.Equal(current, oldValue):
+		return ErrValueChanged.New(key.String())
+	}
+	return nil
+}
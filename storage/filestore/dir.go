@@ -149,6 +149,9 @@ func (dir *Dir) Open(ref storage.BlobRef) (*os.File, error) {
 	}
 	file, err := openFileReadOnly(path, blobPermission)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, Error.Wrap(err)
+		}
 		return nil, Error.New("unable to open %q: %v", path, err)
 	}
 	return file, nil
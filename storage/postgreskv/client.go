@@ -114,6 +114,67 @@ func (client *Client) DeletePath(bucket, key storage.Key) error {
 	return nil
 }
 
+// CompareAndSwap replaces the value of key with newValue when it is oldValue.
+// A nil oldValue expects the key to not exist and a nil newValue deletes the key.
+func (client *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	return client.CompareAndSwapPath(storage.Key(defaultBucket), key, oldValue, newValue)
+}
+
+// CompareAndSwapPath replaces the value of key (in the given bucket) with
+// newValue when it is oldValue.
+func (client *Client) CompareAndSwapPath(bucket, key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	var result sql.Result
+	var err error
+	switch {
+	case oldValue == nil && newValue == nil:
+		_, err := client.GetPath(bucket, key)
+		if storage.ErrKeyNotFound.Has(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return storage.ErrValueChanged.New(key.String())
+	case oldValue == nil:
+		q := `
+			INSERT INTO pathdata (bucket, fullpath, metadata)
+				VALUES ($1::BYTEA, $2::BYTEA, $3::BYTEA)
+				ON CONFLICT DO NOTHING
+		`
+		result, err = client.pgConn.Exec(q, []byte(bucket), []byte(key), []byte(newValue))
+	case newValue == nil:
+		q := "DELETE FROM pathdata WHERE bucket = $1::BYTEA AND fullpath = $2::BYTEA AND metadata = $3::BYTEA"
+		result, err = client.pgConn.Exec(q, []byte(bucket), []byte(key), []byte(oldValue))
+	default:
+		q := "UPDATE pathdata SET metadata = $4::BYTEA WHERE bucket = $1::BYTEA AND fullpath = $2::BYTEA AND metadata = $3::BYTEA"
+		result, err = client.pgConn.Exec(q, []byte(bucket), []byte(key), []byte(oldValue), []byte(newValue))
+	}
+	if err != nil {
+		return err
+	}
+	numRows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if numRows > 0 {
+		return nil
+	}
+
+	if oldValue == nil {
+		return storage.ErrValueChanged.New(key.String())
+	}
+	// tell a missing key apart from a changed value
+	_, err = client.GetPath(bucket, key)
+	if err != nil {
+		return err
+	}
+	return storage.ErrValueChanged.New(key.String())
+}
+
 // List returns either a list of known keys, in order, or an error.
 func (client *Client) List(first storage.Key, limit int) (storage.Keys, error) {
 	return storage.ListKeys(client, first, limit)
@@ -117,6 +117,39 @@ func (client *Client) Delete(key storage.Key) error {
 	return nil
 }
 
+// CompareAndSwap replaces the value of key with newValue when it is oldValue.
+// A nil oldValue expects the key to not exist and a nil newValue deletes the key.
+func (client *Client) CompareAndSwap(key storage.Key, oldValue, newValue storage.Value) error {
+	if key.IsZero() {
+		return storage.ErrEmptyKey.New("")
+	}
+
+	err := client.db.Watch(func(tx *redis.Tx) error {
+		value, err := tx.Get(key.String()).Bytes()
+		if err != nil && err != redis.Nil {
+			return Error.New("get error: %v", err)
+		}
+		if err := storage.CheckValue(key, value, err == nil, oldValue); err != nil {
+			return err
+		}
+
+		// the transaction fails when the key was changed since the watch
+		_, err = tx.Pipelined(func(pipe redis.Pipeliner) error {
+			if newValue == nil {
+				pipe.Del(key.String())
+			} else {
+				pipe.Set(key.String(), []byte(newValue), client.TTL)
+			}
+			return nil
+		})
+		return err
+	}, key.String())
+	if err == redis.TxFailedErr {
+		return storage.ErrValueChanged.New(key.String())
+	}
+	return err
+}
+
 // Close closes a redis client
 func (client *Client) Close() error {
 	return client.db.Close()
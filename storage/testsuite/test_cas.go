@@ -0,0 +1,68 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package testsuite
+
+import (
+	"bytes"
+	"testing"
+
+	"storj.io/storj/storage"
+)
+
+func testCompareAndSwap(t *testing.T, store storage.KeyValueStore) {
+	key := storage.Key("cas/key")
+	defer func() { _ = store.Delete(key) }()
+
+	expect := func(value storage.Value) {
+		t.Helper()
+		got, err := store.Get(key)
+		if value == nil {
+			if !storage.ErrKeyNotFound.Has(err) {
+				t.Fatalf("expected %q to not exist: %v", key, err)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("failed to get %q: %v", key, err)
+		}
+		if !bytes.Equal(got, value) {
+			t.Fatalf("invalid value for %q = %v: got %v", key, value, got)
+		}
+	}
+
+	if err := store.CompareAndSwap(key, storage.Value("old"), storage.Value("new")); !storage.ErrKeyNotFound.Has(err) {
+		t.Fatalf("swapping a missing key should fail with key not found: %v", err)
+	}
+	expect(nil)
+
+	if err := store.CompareAndSwap(key, nil, storage.Value("first")); err != nil {
+		t.Fatalf("failed to create %q: %v", key, err)
+	}
+	expect(storage.Value("first"))
+
+	if err := store.CompareAndSwap(key, nil, storage.Value("again")); !storage.ErrValueChanged.Has(err) {
+		t.Fatalf("creating an existing key should fail with value changed: %v", err)
+	}
+	expect(storage.Value("first"))
+
+	if err := store.CompareAndSwap(key, storage.Value("stale"), storage.Value("second")); !storage.ErrValueChanged.Has(err) {
+		t.Fatalf("swapping a changed value should fail with value changed: %v", err)
+	}
+	expect(storage.Value("first"))
+
+	if err := store.CompareAndSwap(key, storage.Value("first"), storage.Value("second")); err != nil {
+		t.Fatalf("failed to swap %q: %v", key, err)
+	}
+	expect(storage.Value("second"))
+
+	if err := store.CompareAndSwap(key, storage.Value("first"), nil); !storage.ErrValueChanged.Has(err) {
+		t.Fatalf("deleting a changed value should fail with value changed: %v", err)
+	}
+	expect(storage.Value("second"))
+
+	if err := store.CompareAndSwap(key, storage.Value("second"), nil); err != nil {
+		t.Fatalf("failed to delete %q: %v", key, err)
+	}
+	expect(nil)
+}
@@ -146,3 +146,33 @@ func (inspector *Endpoint) Dashboard(ctx context.Context, in *pb.DashboardReques
 	}
 	return data, nil
 }
+
+// CorruptedPieces returns pieces that failed the last integrity check
+func (inspector *Endpoint) CorruptedPieces(ctx context.Context, in *pb.CorruptedPiecesRequest) (out *pb.CorruptedPiecesResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	limit := int64(in.Limit)
+	if limit <= 0 {
+		limit = 1000
+	}
+
+	infos, err := inspector.pieceInfo.GetCorrupted(ctx, limit)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	out = &pb.CorruptedPiecesResponse{}
+	for _, info := range infos {
+		detectedAt, err := ptypes.TimestampProto(info.CorruptedAt)
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		out.Pieces = append(out.Pieces, &pb.CorruptedPiece{
+			SatelliteId: info.SatelliteID,
+			PieceId:     info.PieceID,
+			PieceSize:   info.PieceSize,
+			DetectedAt:  detectedAt,
+		})
+	}
+	return out, nil
+}
@@ -188,6 +188,7 @@ func (sender *Sender) Settle(ctx context.Context, satelliteID storj.NodeID, orde
 				log.Error("failed to archive order as accepted", zap.Stringer("serial", response.SerialNumber), zap.Error(err))
 			}
 		case pb.SettlementResponse_REJECTED:
+			log.Warn("order rejected", zap.Stringer("serial", response.SerialNumber), zap.Stringer("reason", response.Reason))
 			err = sender.orders.Archive(ctx, satelliteID, response.SerialNumber, StatusRejected)
 			if err != nil {
 				log.Error("failed to archive order as rejected", zap.Stringer("serial", response.SerialNumber), zap.Error(err))
@@ -5,6 +5,7 @@ package storagenode
 
 import (
 	"context"
+	"net"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -18,7 +19,10 @@ import (
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/peertls/tlsopts"
+	"storj.io/storj/pkg/quic"
+	"storj.io/storj/pkg/relay"
 	"storj.io/storj/pkg/server"
+	ecclient "storj.io/storj/pkg/storage/ec"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
 	"storj.io/storj/storage"
@@ -29,6 +33,7 @@ import (
 	"storj.io/storj/storagenode/orders"
 	"storj.io/storj/storagenode/pieces"
 	"storj.io/storj/storagenode/piecestore"
+	"storj.io/storj/storagenode/scrubber"
 	"storj.io/storj/storagenode/trust"
 )
 
@@ -57,11 +62,14 @@ type Config struct {
 
 	Server   server.Config
 	Kademlia kademlia.Config
+	Relay    relay.Config
+	QUIC     quic.Config
 
 	// TODO: flatten storage config and only keep the new one
 	Storage   piecestore.OldConfig
 	Storage2  piecestore.Config
 	Collector collector.Config
+	Scrubber  scrubber.Config
 
 	Version version.Config
 }
@@ -80,7 +88,9 @@ type Peer struct {
 
 	Transport transport.Client
 
-	Server *server.Server
+	Server        *server.Server
+	Relay         *relay.Server
+	RelayListener *relay.Listener
 
 	Version *version.Service
 
@@ -104,6 +114,7 @@ type Peer struct {
 	}
 
 	Collector *collector.Service
+	Scrubber  *scrubber.Service
 }
 
 // New creates a new Storage Node.
@@ -138,15 +149,47 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config Config, ver
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
 		}
+
+		if config.Kademlia.RelayAddress != "" {
+			peer.RelayListener = relay.NewListener(peer.Log.Named("relay:listener"), options, config.Kademlia.RelayAddress)
+			peer.Server.AddListener(peer.RelayListener)
+		}
+
+		if config.Relay.Address != "" {
+			listener, err := net.Listen("tcp", config.Relay.Address)
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+			peer.Relay = relay.NewServer(peer.Log.Named("relay"), options, listener)
+		}
+
+		if config.QUIC.Enabled {
+			// quic is served on the udp port matching the public tcp address
+			listener, err := quic.Listen(peer.Log.Named("quic"), options.ServerTLSConfig(), peer.Addr())
+			if err != nil {
+				return nil, errs.Combine(err, peer.Close())
+			}
+			peer.Server.AddListener(listener)
+		}
 	}
 
 	{ // setup kademlia
+		quicConfig := config.QUIC
 		config := config.Kademlia
 		// TODO: move this setup logic into kademlia package
 		if config.ExternalAddress == "" {
 			config.ExternalAddress = peer.Addr()
 		}
 
+		transportType := pb.NodeTransport_TCP_TLS_GRPC
+		if quicConfig.Enabled {
+			transportType = pb.NodeTransport_QUIC_TLS_GRPC
+		}
+		if config.RelayAddress != "" {
+			config.ExternalAddress = config.RelayAddress
+			transportType = pb.NodeTransport_TCP_TLS_GRPC_RELAY
+		}
+
 		pbVersion, err := versionInfo.Proto()
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
@@ -156,7 +199,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config Config, ver
 			Node: pb.Node{
 				Id: peer.ID(),
 				Address: &pb.NodeAddress{
-					Transport: pb.NodeTransport_TCP_TLS_GRPC,
+					Transport: transportType,
 					Address:   config.ExternalAddress,
 				},
 			},
@@ -175,6 +218,11 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config Config, ver
 
 		peer.Transport = peer.Transport.WithObservers(peer.Kademlia.RoutingTable)
 
+		if peer.RelayListener != nil {
+			// the node is dialed through the relay, but reports the ip address the relay observed
+			peer.RelayListener.OnObservedIP(peer.Kademlia.RoutingTable.UpdateLastIP)
+		}
+
 		peer.Kademlia.Service, err = kademlia.NewService(peer.Log.Named("kademlia"), peer.Transport, peer.Kademlia.RoutingTable, config)
 		if err != nil {
 			return nil, errs.Combine(err, peer.Close())
@@ -218,6 +266,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config Config, ver
 			peer.DB.Orders(),
 			peer.DB.Bandwidth(),
 			peer.DB.UsedSerials(),
+			ecclient.NewClient(peer.Transport, config.Storage2.RepairMaxBufferMem.Int()),
 			config.Storage2,
 		)
 		if err != nil {
@@ -244,6 +293,7 @@ func New(log *zap.Logger, full *identity.FullIdentity, db DB, config Config, ver
 	}
 
 	peer.Collector = collector.NewService(peer.Log.Named("collector"), peer.Storage2.Store, peer.DB.PieceInfo(), config.Collector)
+	peer.Scrubber = scrubber.NewService(peer.Log.Named("scrubber"), peer.Storage2.Store, peer.DB.PieceInfo(), peer.Transport, peer.Kademlia.Service, config.Scrubber)
 
 	return peer, nil
 }
@@ -266,6 +316,9 @@ func (peer *Peer) Run(ctx context.Context) error {
 	group.Go(func() error {
 		return errs2.IgnoreCanceled(peer.Collector.Run(ctx))
 	})
+	group.Go(func() error {
+		return errs2.IgnoreCanceled(peer.Scrubber.Run(ctx))
+	})
 	group.Go(func() error {
 		return errs2.IgnoreCanceled(peer.Storage2.Sender.Run(ctx))
 	})
@@ -273,6 +326,12 @@ func (peer *Peer) Run(ctx context.Context) error {
 		return errs2.IgnoreCanceled(peer.Storage2.Monitor.Run(ctx))
 	})
 
+	if peer.Relay != nil {
+		group.Go(func() error {
+			return errs2.IgnoreCanceled(peer.Relay.Run(ctx))
+		})
+	}
+
 	group.Go(func() error {
 		// TODO: move the message into Server instead
 		// Don't change the format of this comment, it is used to figure out the node id.
@@ -295,6 +354,9 @@ func (peer *Peer) Close() error {
 	if peer.Server != nil {
 		errlist.Add(peer.Server.Close())
 	}
+	if peer.Relay != nil {
+		errlist.Add(peer.Relay.Close())
+	}
 
 	// close services in reverse initialization order
 
@@ -304,6 +366,9 @@ func (peer *Peer) Close() error {
 	if peer.Storage2.Sender != nil {
 		errlist.Add(peer.Storage2.Sender.Close())
 	}
+	if peer.Scrubber != nil {
+		errlist.Add(peer.Scrubber.Close())
+	}
 	if peer.Collector != nil {
 		errlist.Add(peer.Collector.Close())
 	}
@@ -122,6 +122,21 @@ func TestPieceInfo(t *testing.T) {
 		require.NoError(t, err)
 		require.Empty(t, cmp.Diff(info1, info1loaded, cmp.Comparer(pb.Equal)))
 
+		// getting pieces to scrub before they expire
+		candidates, err := pieceinfos.GetScrubCandidates(ctx, now, now.Add(-time.Hour), 0, 10)
+		assert.NoError(t, err)
+		assert.Len(t, candidates, 3)
+
+		// skipping pieces to scrub
+		candidates, err = pieceinfos.GetScrubCandidates(ctx, now, now.Add(-time.Hour), 1, 10)
+		assert.NoError(t, err)
+		assert.Len(t, candidates, 2)
+
+		// expired pieces aren't scrubbed
+		candidates, err = pieceinfos.GetScrubCandidates(ctx, now.Add(-time.Hour), now.Add(time.Hour), 0, 10)
+		assert.NoError(t, err)
+		assert.Len(t, candidates, 0)
+
 		// getting no expired pieces
 		expired, err := pieceinfos.GetExpired(ctx, now.Add(-10*time.Hour), 10)
 		assert.NoError(t, err)
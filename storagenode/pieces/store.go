@@ -45,6 +45,23 @@ type ExpiredInfo struct {
 	PieceSize   int64
 }
 
+// ScrubInfo contains the information needed to verify the integrity of a stored piece.
+type ScrubInfo struct {
+	SatelliteID storj.NodeID
+	PieceID     storj.PieceID
+	PieceSize   int64
+
+	UplinkPieceHash *pb.PieceHash
+}
+
+// CorruptedInfo describes a piece that failed an integrity check.
+type CorruptedInfo struct {
+	SatelliteID storj.NodeID
+	PieceID     storj.PieceID
+	PieceSize   int64
+	CorruptedAt time.Time
+}
+
 // DB stores meta information about a piece, the actual piece is stored in storage.Blobs
 type DB interface {
 	// Add inserts Info to the database.
@@ -59,6 +76,12 @@ type DB interface {
 	SpaceUsed(ctx context.Context) (int64, error)
 	// GetExpired gets orders that are expired and were created before some time
 	GetExpired(ctx context.Context, expiredAt time.Time, limit int64) ([]ExpiredInfo, error)
+	// GetScrubCandidates gets unexpired pieces that have not been checked for corruption since scrubbedBefore
+	GetScrubCandidates(ctx context.Context, scrubbedBefore, now time.Time, offset, limit int64) ([]ScrubInfo, error)
+	// Scrubbed records the result of checking a piece for corruption
+	Scrubbed(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, scrubbedAt time.Time, corrupted bool) error
+	// GetCorrupted gets pieces that failed the last corruption check
+	GetCorrupted(ctx context.Context, limit int64) ([]CorruptedInfo, error)
 }
 
 // Store implements storing pieces onto a blob storage implementation.
@@ -391,3 +391,18 @@ func GenerateOrderLimit(t *testing.T, satellite storj.NodeID, uplink storj.NodeI
 	}
 	return orderLimit
 }
+
+func TestRepairUntrusted(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 1, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		node := planet.StorageNodes[0].Local().Node
+		conn, err := planet.Uplinks[0].Transport.DialNode(ctx, &node)
+		require.NoError(t, err)
+		defer ctx.Check(conn.Close)
+
+		_, err = pb.NewPiecestoreClient(conn).Repair(ctx, &pb.PieceRepairRequest{})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "untrusted")
+	})
+}
@@ -0,0 +1,122 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package piecestore
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+)
+
+// Repair handles reconstructing a segment for a satellite and uploading its missing pieces to new storage nodes.
+func (endpoint *Endpoint) Repair(ctx context.Context, request *pb.PieceRepairRequest) (_ *pb.PieceRepairResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	if err := endpoint.trust.VerifySatelliteID(ctx, peer.ID); err != nil {
+		return nil, Error.Wrap(ErrVerifyUntrusted.Wrap(err))
+	}
+
+	getLimits := fromRepairLimits(request.GetLimits)
+	putLimits := fromRepairLimits(request.PutLimits)
+	if err := endpoint.verifyRepairLimits(ctx, peer, getLimits, pb.PieceAction_GET_REPAIR); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if err := endpoint.verifyRepairLimits(ctx, peer, putLimits, pb.PieceAction_PUT_REPAIR); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	redundancy, err := eestream.NewRedundancyStrategyFromProto(request.Redundancy)
+	if err != nil {
+		return nil, ErrProtocol.Wrap(err)
+	}
+	if len(getLimits) != redundancy.TotalCount() || len(putLimits) != redundancy.TotalCount() {
+		return nil, ErrProtocol.New("limits don't match redundancy: get=%d put=%d total=%d", len(getLimits), len(putLimits), redundancy.TotalCount())
+	}
+
+	var expiration time.Time
+	if request.Expiration != nil {
+		expiration, err = ptypes.Timestamp(request.Expiration)
+		if err != nil {
+			return nil, ErrProtocol.Wrap(err)
+		}
+	}
+
+	endpoint.log.Info("repair started", zap.Stringer("Satellite ID", peer.ID), zap.String("Path", request.Path))
+
+	rr, err := endpoint.ec.Get(ctx, getLimits, redundancy, request.SegmentSize)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	r, err := rr.Range(ctx, 0, rr.Size())
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, r.Close()) }()
+
+	_, hashes, err := endpoint.ec.Repair(ctx, putLimits, redundancy, r, expiration, endpoint.config.RepairTimeout, request.Path)
+	if err != nil {
+		endpoint.log.Info("repair failed", zap.Stringer("Satellite ID", peer.ID), zap.String("Path", request.Path), zap.Error(err))
+		return nil, Error.Wrap(err)
+	}
+
+	response := &pb.PieceRepairResponse{Hashes: make([]*pb.PieceHash, len(hashes))}
+	for i, hash := range hashes {
+		if hash == nil {
+			hash = &pb.PieceHash{}
+		}
+		response.Hashes[i] = hash
+	}
+
+	endpoint.log.Info("repaired", zap.Stringer("Satellite ID", peer.ID), zap.String("Path", request.Path))
+	return response, nil
+}
+
+// verifyRepairLimits verifies that the limits were issued by the satellite for this storage node to use in a repair.
+// The storage nodes receiving the limits verify them as well.
+func (endpoint *Endpoint) verifyRepairLimits(ctx context.Context, satellite *identity.PeerIdentity, limits []*pb.AddressedOrderLimit, action pb.PieceAction) error {
+	for _, addressedLimit := range limits {
+		if addressedLimit == nil {
+			continue
+		}
+
+		limit := addressedLimit.Limit
+		switch {
+		case limit.Action != action:
+			return ErrProtocol.New("expected %v action got %v", action, limit.Action)
+		case limit.SatelliteId != satellite.ID:
+			return ErrVerifyNotAuthorized.New("limit from satellite %s sent by %s", limit.SatelliteId, satellite.ID)
+		case limit.UplinkId != endpoint.signer.ID():
+			return ErrVerifyNotAuthorized.New("limit intended for other repairer: %v", limit.UplinkId)
+		}
+
+		if err := endpoint.VerifyOrderLimitSignature(ctx, limit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fromRepairLimits converts empty limits, which mark pieces that aren't part of the repair, back to nil.
+func fromRepairLimits(limits []*pb.AddressedOrderLimit) []*pb.AddressedOrderLimit {
+	converted := make([]*pb.AddressedOrderLimit, len(limits))
+	for i, limit := range limits {
+		if limit.GetLimit() != nil {
+			converted[i] = limit
+		}
+	}
+	return converted
+}
@@ -0,0 +1,233 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package scrubber implements periodic integrity checks of pieces stored on storage node.
+package scrubber
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/internal/errs2"
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/sync2"
+	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pkcrypto"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/storagenode/pieces"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the default error class for scrubber errors
+	Error = errs.Class("piece scrubber")
+)
+
+// Config defines parameters for storage node piece scrubber.
+type Config struct {
+	Interval        time.Duration `help:"how frequently pieces are checked for corruption" default:"1h0m0s"`
+	ScrubEvery      time.Duration `help:"how often each piece should be checked for corruption" default:"720h0m0s"`
+	MaxBytesPerRun  memory.Size   `help:"maximum amount of piece data read during a single run" default:"1GiB"`
+	DeleteCorrupted bool          `help:"delete pieces that fail the integrity check" default:"false"`
+	ReportCorrupted bool          `help:"report pieces that fail the integrity check to the satellite, so that they are repaired" default:"true"`
+}
+
+// Service implements verifying stored pieces against the hash signed by the uplink.
+type Service struct {
+	log        *zap.Logger
+	pieces     *pieces.Store
+	pieceinfos pieces.DB
+	transport  transport.Client
+	kademlia   *kademlia.Kademlia
+	config     Config
+
+	Loop sync2.Cycle
+}
+
+// NewService creates a new piece scrubber service.
+func NewService(log *zap.Logger, pieces *pieces.Store, pieceinfos pieces.DB, transport transport.Client, kademlia *kademlia.Kademlia, config Config) *Service {
+	return &Service{
+		log:        log,
+		pieces:     pieces,
+		pieceinfos: pieceinfos,
+		transport:  transport,
+		kademlia:   kademlia,
+		config:     config,
+		Loop:       *sync2.NewCycle(config.Interval),
+	}
+}
+
+// Run runs the scrubber service
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return service.Loop.Run(ctx, func(ctx context.Context) error {
+		err := service.Scrub(ctx, time.Now())
+		if err != nil {
+			service.log.Error("error during scrubbing pieces: ", zap.Error(err))
+		}
+		return nil
+	})
+}
+
+// Close stops the scrubber service.
+func (service *Service) Close() (err error) {
+	service.Loop.Close()
+	return nil
+}
+
+// Scrub checks pieces that haven't been checked within ScrubEvery of now.
+func (service *Service) Scrub(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	const batchSize = 100
+
+	var count, corrupted, skipped int64
+	var bytesRead int64
+	defer func() {
+		if count > 0 {
+			service.log.Info("scrub", zap.Int64("count", count), zap.Int64("corrupted", corrupted), zap.Stringer("size", memory.Size(bytesRead)))
+		}
+	}()
+
+	reports := make(map[storj.NodeID][]storj.PieceID)
+	defer func() {
+		if service.config.ReportCorrupted {
+			for satelliteID, pieceIDs := range reports {
+				service.report(ctx, satelliteID, pieceIDs)
+			}
+		}
+	}()
+
+	scrubbedBefore := now.Add(-service.config.ScrubEvery)
+	for bytesRead < service.config.MaxBytesPerRun.Int64() {
+		// pieces that couldn't be verified keep their place at the front of
+		// the candidates, so they are skipped over for the rest of this run.
+		infos, err := service.pieceinfos.GetScrubCandidates(ctx, scrubbedBefore, now, skipped, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(infos) == 0 {
+			return nil
+		}
+
+		for _, info := range infos {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			bytesRead += info.PieceSize
+
+			ok, err := service.Verify(ctx, info)
+			if err != nil {
+				// failing to read the piece doesn't mean it is corrupted,
+				// it will be checked again on the next run.
+				service.log.Warn("unable to verify piece", zap.Stringer("satellite id", info.SatelliteID), zap.Stringer("piece id", info.PieceID), zap.Error(err))
+				skipped++
+				continue
+			}
+
+			err = service.pieceinfos.Scrubbed(ctx, info.SatelliteID, info.PieceID, now, !ok)
+			if err != nil {
+				return err
+			}
+
+			count++
+			if ok {
+				continue
+			}
+
+			corrupted++
+			mon.Meter("corrupted_piece").Mark(1)
+			service.log.Warn("piece is corrupted", zap.Stringer("satellite id", info.SatelliteID), zap.Stringer("piece id", info.PieceID))
+
+			reports[info.SatelliteID] = append(reports[info.SatelliteID], info.PieceID)
+			if service.config.DeleteCorrupted {
+				service.deleteCorrupted(ctx, info)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Verify checks whether the stored piece matches the hash signed by the uplink.
+// A piece that is missing from the disk is reported as corrupted.
+func (service *Service) Verify(ctx context.Context, info pieces.ScrubInfo) (ok bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	reader, err := service.pieces.Reader(ctx, info.SatelliteID, info.PieceID)
+	if err != nil {
+		if errs2.IsFunc(err, os.IsNotExist) {
+			return false, nil
+		}
+		return false, Error.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, reader.Close()) }()
+
+	if reader.Size() != info.PieceSize {
+		return false, nil
+	}
+
+	hash := pkcrypto.NewHash()
+	_, err = io.CopyN(hash, reader, reader.Size())
+	if err != nil {
+		return false, Error.Wrap(err)
+	}
+
+	return bytes.Equal(hash.Sum(nil), info.UplinkPieceHash.GetHash()), nil
+}
+
+// deleteCorrupted removes a corrupted piece and its piece info.
+func (service *Service) deleteCorrupted(ctx context.Context, info pieces.ScrubInfo) {
+	err := service.pieces.Delete(ctx, info.SatelliteID, info.PieceID)
+	if err != nil && !errs2.IsFunc(err, os.IsNotExist) {
+		service.log.Error("unable to delete corrupted piece", zap.Stringer("satellite id", info.SatelliteID), zap.Stringer("piece id", info.PieceID), zap.Error(err))
+		return
+	}
+
+	err = service.pieceinfos.Delete(ctx, info.SatelliteID, info.PieceID)
+	if err != nil {
+		service.log.Error("unable to delete corrupted piece info", zap.Stringer("satellite id", info.SatelliteID), zap.Stringer("piece id", info.PieceID), zap.Error(err))
+	}
+}
+
+// report notifies the satellite about corrupted pieces, so that they can be
+// repaired before they fail an audit.
+func (service *Service) report(ctx context.Context, satelliteID storj.NodeID, pieceIDs []storj.PieceID) {
+	log := service.log.Named(satelliteID.String())
+
+	satellite, err := service.kademlia.FindNode(ctx, satelliteID)
+	if err != nil {
+		log.Error("unable to find satellite on the network", zap.Error(err))
+		return
+	}
+
+	conn, err := service.transport.DialNode(ctx, &satellite)
+	if err != nil {
+		log.Error("unable to connect to the satellite", zap.Error(err))
+		return
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			log.Warn("failed to close connection", zap.Error(err))
+		}
+	}()
+
+	_, err = pb.NewOrdersClient(conn).ReportCorrupted(ctx, &pb.CorruptedPiecesReport{
+		PieceIds: pieceIDs,
+	})
+	if err != nil {
+		log.Error("unable to report corrupted pieces", zap.Int("count", len(pieceIDs)), zap.Error(err))
+	}
+}
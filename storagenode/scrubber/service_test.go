@@ -0,0 +1,184 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package scrubber_test
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/storage"
+	"storj.io/storj/storagenode"
+)
+
+func TestScrubber(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		for _, storageNode := range planet.StorageNodes {
+			// stop scrubber, so we can run it manually
+			storageNode.Scrubber.Loop.Pause()
+		}
+
+		expectedData := make([]byte, 100*memory.KiB)
+		_, err := rand.Read(expectedData)
+		require.NoError(t, err)
+
+		err = planet.Uplinks[0].Upload(ctx, planet.Satellites[0], "testbucket", "test/path", expectedData)
+		require.NoError(t, err)
+
+		now := time.Now()
+		scrubbed := 0
+		for _, storageNode := range planet.StorageNodes {
+			pieceinfos := storageNode.DB.PieceInfo()
+
+			candidates, err := pieceinfos.GetScrubCandidates(ctx, now, now, 0, 1000)
+			require.NoError(t, err)
+			if len(candidates) == 0 {
+				// this storage node didn't get picked for storing data
+				continue
+			}
+
+			// untouched pieces must pass the check
+			err = storageNode.Scrubber.Scrub(ctx, now)
+			require.NoError(t, err)
+
+			corrupted, err := pieceinfos.GetCorrupted(ctx, 1000)
+			require.NoError(t, err)
+			require.Empty(t, corrupted)
+
+			// scrubbed pieces aren't checked again until the next round
+			remaining, err := pieceinfos.GetScrubCandidates(ctx, now.Add(-time.Minute), now, 0, 1000)
+			require.NoError(t, err)
+			require.Empty(t, remaining)
+
+			// remove a piece from disk behind the node's back
+			missing := candidates[0]
+			err = storageNode.DB.Pieces().Delete(ctx, storage.BlobRef{
+				Namespace: missing.SatelliteID.Bytes(),
+				Key:       missing.PieceID.Bytes(),
+			})
+			require.NoError(t, err)
+
+			later := now.Add(2 * time.Hour)
+			err = storageNode.Scrubber.Scrub(ctx, later)
+			require.NoError(t, err)
+
+			corrupted, err = pieceinfos.GetCorrupted(ctx, 1000)
+			require.NoError(t, err)
+			require.Len(t, corrupted, 1)
+			require.Equal(t, missing.SatelliteID, corrupted[0].SatelliteID)
+			require.Equal(t, missing.PieceID, corrupted[0].PieceID)
+
+			response, err := storageNode.Storage2.Inspector.CorruptedPieces(ctx, &pb.CorruptedPiecesRequest{})
+			require.NoError(t, err)
+			require.Len(t, response.Pieces, 1)
+			require.Equal(t, missing.PieceID, response.Pieces[0].PieceId)
+
+			scrubbed++
+		}
+
+		require.NotZero(t, scrubbed)
+	})
+}
+
+func TestScrubberHashMismatch(t *testing.T) {
+	testplanet.Run(t, testplanet.Config{
+		SatelliteCount: 1, StorageNodeCount: 4, UplinkCount: 1,
+	}, func(t *testing.T, ctx *testcontext.Context, planet *testplanet.Planet) {
+		satellite := planet.Satellites[0]
+		satellite.Repair.Checker.Loop.Pause()
+		satellite.Repair.Repairer.Loop.Pause()
+		for _, storageNode := range planet.StorageNodes {
+			storageNode.Scrubber.Loop.Pause()
+		}
+
+		expectedData := make([]byte, 100*memory.KiB)
+		_, err := rand.Read(expectedData)
+		require.NoError(t, err)
+
+		err = planet.Uplinks[0].Upload(ctx, satellite, "testbucket", "test/path", expectedData)
+		require.NoError(t, err)
+
+		pointerPath, pointer := getRemotePointer(t, satellite)
+		piece := pointer.GetRemote().GetRemotePieces()[0]
+
+		var storageNode *storagenode.Peer
+		for _, node := range planet.StorageNodes {
+			if node.ID() == piece.NodeId {
+				storageNode = node
+			}
+		}
+		require.NotNil(t, storageNode)
+
+		// flip a byte of the stored piece behind the node's back
+		ref := storage.BlobRef{
+			Namespace: satellite.ID().Bytes(),
+			Key:       pointer.GetRemote().RootPieceId.Derive(piece.NodeId).Bytes(),
+		}
+		reader, err := storageNode.DB.Pieces().Open(ctx, ref)
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+
+		data[len(data)/2]++
+
+		require.NoError(t, storageNode.DB.Pieces().Delete(ctx, ref))
+		writer, err := storageNode.DB.Pieces().Create(ctx, ref, int64(len(data)))
+		require.NoError(t, err)
+		_, err = writer.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, writer.Commit())
+
+		err = storageNode.Scrubber.Scrub(ctx, time.Now())
+		require.NoError(t, err)
+
+		corrupted, err := storageNode.DB.PieceInfo().GetCorrupted(ctx, 1000)
+		require.NoError(t, err)
+		require.Len(t, corrupted, 1)
+		require.Equal(t, storj.PieceID(ref.Key), corrupted[0].PieceID)
+
+		// the satellite drops the reported piece from the segment
+		err = satellite.Repair.Checker.IdentifyInjuredSegments(ctx)
+		require.NoError(t, err)
+
+		updated, err := satellite.Metainfo.Service.Get(pointerPath)
+		require.NoError(t, err)
+		require.Len(t, updated.GetRemote().GetRemotePieces(), len(pointer.GetRemote().GetRemotePieces())-1)
+		for _, remaining := range updated.GetRemote().GetRemotePieces() {
+			require.NotEqual(t, piece.NodeId, remaining.NodeId)
+		}
+	})
+}
+
+func getRemotePointer(t *testing.T, satellite *satellite.Peer) (path string, pointer *pb.Pointer) {
+	err := satellite.Metainfo.Service.Iterate("", "", true, false, func(it storage.Iterator) error {
+		var item storage.ListItem
+		for it.Next(&item) {
+			candidate := &pb.Pointer{}
+			if err := proto.Unmarshal(item.Value, candidate); err != nil {
+				return err
+			}
+			if candidate.GetRemote() != nil {
+				path, pointer = item.Key.String(), candidate
+				return nil
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, pointer)
+	return path, pointer
+}
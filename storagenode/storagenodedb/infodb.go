@@ -182,6 +182,14 @@ func (db *InfoDB) Migration() *migrate.Migration {
 					`ALTER TABLE pieceinfo ADD COLUMN deletion_failed_at TIMESTAMP`,
 				},
 			},
+			{
+				Description: "Add tracking of piece integrity checks.",
+				Version:     3,
+				Action: migrate.SQL{
+					`ALTER TABLE pieceinfo ADD COLUMN scrubbed_at TIMESTAMP`,
+					`ALTER TABLE pieceinfo ADD COLUMN corrupted_at TIMESTAMP`,
+				},
+			},
 		},
 	}
 }
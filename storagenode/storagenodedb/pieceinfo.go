@@ -152,3 +152,84 @@ func (db *pieceinfo) SpaceUsed(ctx context.Context) (int64, error) {
 	}
 	return *sum, err
 }
+
+// GetScrubCandidates gets piece information for pieces that haven't been scrubbed since scrubbedBefore
+// and haven't expired by now, skipping the first offset of them.
+func (db *pieceinfo) GetScrubCandidates(ctx context.Context, scrubbedBefore, now time.Time, offset, limit int64) (infos []pieces.ScrubInfo, err error) {
+	defer db.locked()()
+
+	rows, err := db.db.QueryContext(ctx, db.Rebind(`
+		SELECT satellite_id, piece_id, piece_size, uplink_piece_hash
+		FROM pieceinfo
+		WHERE (scrubbed_at IS NULL OR scrubbed_at < ?)
+		  AND (piece_expiration IS NULL OR piece_expiration > ?)
+		  AND deletion_failed_at IS NULL
+		ORDER BY scrubbed_at, satellite_id, piece_id
+		LIMIT ? OFFSET ?
+	`), scrubbedBefore, now, limit, offset)
+	if err != nil {
+		return nil, ErrInfo.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+	for rows.Next() {
+		info := pieces.ScrubInfo{}
+		var uplinkPieceHash []byte
+		err = rows.Scan(&info.SatelliteID, &info.PieceID, &info.PieceSize, &uplinkPieceHash)
+		if err != nil {
+			return infos, ErrInfo.Wrap(err)
+		}
+
+		info.UplinkPieceHash = &pb.PieceHash{}
+		err = proto.Unmarshal(uplinkPieceHash, info.UplinkPieceHash)
+		if err != nil {
+			return infos, ErrInfo.Wrap(err)
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// Scrubbed marks piece as scrubbed and records whether it was found corrupted.
+func (db *pieceinfo) Scrubbed(ctx context.Context, satelliteID storj.NodeID, pieceID storj.PieceID, scrubbedAt time.Time, corrupted bool) error {
+	defer db.locked()()
+
+	var corruptedAt *time.Time
+	if corrupted {
+		corruptedAt = &scrubbedAt
+	}
+
+	_, err := db.db.ExecContext(ctx, db.Rebind(`
+		UPDATE pieceinfo
+		SET scrubbed_at = ?, corrupted_at = ?
+		WHERE satellite_id = ?
+		  AND piece_id = ?
+	`), scrubbedAt, corruptedAt, satelliteID, pieceID)
+
+	return ErrInfo.Wrap(err)
+}
+
+// GetCorrupted gets piece information for pieces that failed the last scrub.
+func (db *pieceinfo) GetCorrupted(ctx context.Context, limit int64) (infos []pieces.CorruptedInfo, err error) {
+	defer db.locked()()
+
+	rows, err := db.db.QueryContext(ctx, db.Rebind(`
+		SELECT satellite_id, piece_id, piece_size, corrupted_at
+		FROM pieceinfo
+		WHERE corrupted_at IS NOT NULL
+		ORDER BY corrupted_at DESC
+		LIMIT ?
+	`), limit)
+	if err != nil {
+		return nil, ErrInfo.Wrap(err)
+	}
+	defer func() { err = errs.Combine(err, rows.Close()) }()
+	for rows.Next() {
+		info := pieces.CorruptedInfo{}
+		err = rows.Scan(&info.SatelliteID, &info.PieceID, &info.PieceSize, &info.CorruptedAt)
+		if err != nil {
+			return infos, ErrInfo.Wrap(err)
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
@@ -9,7 +9,6 @@ import (
 	"io/ioutil"
 	"time"
 
-	"github.com/vivint/infectious"
 	"github.com/zeebo/errs"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
@@ -37,6 +36,7 @@ type RSConfig struct {
 	RepairThreshold  int         `help:"the minimum safe pieces before a repair is triggered. m." releaseDefault:"35" devDefault:"6"`
 	SuccessThreshold int         `help:"the desired total pieces for a segment. o." releaseDefault:"80" devDefault:"8"`
 	MaxThreshold     int         `help:"the largest amount of pieces to encode to. n." releaseDefault:"130" devDefault:"10"`
+	Algorithm        int         `help:"erasure coding algorithm to use for new uploads (1=Reed-Solomon, 2=Cauchy Reed-Solomon)" default:"1"`
 }
 
 // EncryptionConfig is a configuration struct that keeps details about
@@ -58,6 +58,9 @@ type ClientConfig struct {
 	SegmentSize    memory.Size   `help:"the size of a segment in bytes" default:"64MiB"`
 	RequestTimeout time.Duration `help:"timeout for request" default:"0h0m20s"`
 	DialTimeout    time.Duration `help:"timeout for dials" default:"0h0m20s"`
+
+	SegmentParallelism  int         `help:"the number of segments of an object uploaded or downloaded at the same time" default:"1"`
+	MaxSegmentBufferMem memory.Size `help:"maximum memory (in bytes) to be allocated for buffering segments transferred at the same time" default:"256MiB"`
 }
 
 // Config uplink configuration
@@ -101,11 +104,15 @@ func (c Config) GetMetainfo(ctx context.Context, identity *identity.FullIdentity
 	}
 
 	ec := ecclient.NewClient(tc, c.RS.MaxBufferMem.Int())
-	fc, err := infectious.NewFEC(c.RS.MinThreshold, c.RS.MaxThreshold)
+	schemeType, err := eestream.SchemeTypeForAlgorithm(storj.RedundancyAlgorithm(c.RS.Algorithm))
+	if err != nil {
+		return nil, nil, Error.New("failed to create erasure coding client: %v", err)
+	}
+	es, err := eestream.NewErasureScheme(schemeType, c.RS.MinThreshold, c.RS.MaxThreshold, c.RS.ErasureShareSize.Int())
 	if err != nil {
 		return nil, nil, Error.New("failed to create erasure coding client: %v", err)
 	}
-	rs, err := eestream.NewRedundancyStrategy(eestream.NewRSScheme(fc, c.RS.ErasureShareSize.Int()), c.RS.RepairThreshold, c.RS.SuccessThreshold)
+	rs, err := eestream.NewRedundancyStrategy(es, c.RS.RepairThreshold, c.RS.SuccessThreshold)
 	if err != nil {
 		return nil, nil, Error.New("failed to create redundancy strategy: %v", err)
 	}
@@ -126,7 +133,8 @@ func (c Config) GetMetainfo(ctx context.Context, identity *identity.FullIdentity
 		return nil, nil, Error.Wrap(err)
 	}
 
-	streams, err := streams.NewStreamStore(segments, c.Client.SegmentSize.Int64(), key, c.Enc.BlockSize.Int(), storj.Cipher(c.Enc.DataType))
+	streams, err := streams.NewParallelStreamStore(segments, c.Client.SegmentSize.Int64(), key, c.Enc.BlockSize.Int(), storj.Cipher(c.Enc.DataType),
+		c.Client.SegmentParallelism, c.Client.MaxSegmentBufferMem.Int64())
 	if err != nil {
 		return nil, nil, Error.New("failed to create stream store: %v", err)
 	}
@@ -139,7 +147,7 @@ func (c Config) GetMetainfo(ctx context.Context, identity *identity.FullIdentity
 // GetRedundancyScheme returns the configured redundancy scheme for new uploads
 func (c Config) GetRedundancyScheme() storj.RedundancyScheme {
 	return storj.RedundancyScheme{
-		Algorithm:      storj.ReedSolomon,
+		Algorithm:      storj.RedundancyAlgorithm(c.RS.Algorithm),
 		RequiredShares: int16(c.RS.MinThreshold),
 		RepairShares:   int16(c.RS.RepairThreshold),
 		OptimalShares:  int16(c.RS.SuccessThreshold),
@@ -27,6 +27,9 @@ var (
 
 	// Error is the errs class of standard metainfo errors
 	Error = errs.Class("metainfo error")
+
+	// ErrSegmentModified is returned when a segment was replaced while it was updated
+	ErrSegmentModified = errs.Class("segment modified")
 )
 
 // Metainfo creates a grpcClient
@@ -52,10 +55,11 @@ type ListItem struct {
 // Client interface for the Metainfo service
 type Client interface {
 	CreateSegment(ctx context.Context, bucket string, path storj.Path, segmentIndex int64, redundancy *pb.RedundancyScheme, maxEncryptedSegmentSize int64, expiration time.Time) ([]*pb.AddressedOrderLimit, storj.PieceID, error)
-	CommitSegment(ctx context.Context, bucket string, path storj.Path, segmentIndex int64, pointer *pb.Pointer, originalLimits []*pb.OrderLimit2) (*pb.Pointer, error)
+	CommitSegment(ctx context.Context, bucket string, path storj.Path, segmentIndex int64, pointer *pb.Pointer, originalLimits []*pb.OrderLimit2, uploadStats []*pb.PieceUploadStats) (*pb.Pointer, error)
 	SegmentInfo(ctx context.Context, bucket string, path storj.Path, segmentIndex int64) (*pb.Pointer, error)
 	ReadSegment(ctx context.Context, bucket string, path storj.Path, segmentIndex int64) (*pb.Pointer, []*pb.AddressedOrderLimit, error)
 	DeleteSegment(ctx context.Context, bucket string, path storj.Path, segmentIndex int64) ([]*pb.AddressedOrderLimit, error)
+	UpdateSegmentMetadata(ctx context.Context, bucket string, path storj.Path, segmentIndex int64, metadata []byte, created time.Time) (*pb.Pointer, error)
 	ListSegments(ctx context.Context, bucket string, prefix, startAfter, endBefore storj.Path, recursive bool, limit int32, metaFlags uint32) (items []ListItem, more bool, err error)
 }
 
@@ -101,8 +105,9 @@ func (metainfo *Metainfo) CreateSegment(ctx context.Context, bucket string, path
 	return response.GetAddressedLimits(), response.RootPieceId, nil
 }
 
-// CommitSegment requests to store the pointer for the segment
-func (metainfo *Metainfo) CommitSegment(ctx context.Context, bucket string, path storj.Path, segmentIndex int64, pointer *pb.Pointer, originalLimits []*pb.OrderLimit2) (savedPointer *pb.Pointer, err error) {
+// CommitSegment requests to store the pointer for the segment and reports how
+// the uploads to the storage nodes went
+func (metainfo *Metainfo) CommitSegment(ctx context.Context, bucket string, path storj.Path, segmentIndex int64, pointer *pb.Pointer, originalLimits []*pb.OrderLimit2, uploadStats []*pb.PieceUploadStats) (savedPointer *pb.Pointer, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	response, err := metainfo.client.CommitSegment(ctx, &pb.SegmentCommitRequest{
@@ -111,6 +116,7 @@ func (metainfo *Metainfo) CommitSegment(ctx context.Context, bucket string, path
 		Segment:        segmentIndex,
 		Pointer:        pointer,
 		OriginalLimits: originalLimits,
+		UploadStats:    uploadStats,
 	})
 	if err != nil {
 		return nil, Error.Wrap(err)
@@ -175,6 +181,39 @@ func getLimitByStorageNodeID(limits []*pb.AddressedOrderLimit, storageNodeID sto
 	return nil
 }
 
+// UpdateSegmentMetadata replaces the metadata of a segment. The update fails
+// with ErrSegmentModified when the segment was not created at created.
+func (metainfo *Metainfo) UpdateSegmentMetadata(ctx context.Context, bucket string, path storj.Path, segmentIndex int64, metadata []byte, created time.Time) (pointer *pb.Pointer, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if created.IsZero() {
+		return nil, Error.New("creation date is required")
+	}
+	creationDate, err := ptypes.TimestampProto(created)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	response, err := metainfo.client.UpdateSegmentMetadata(ctx, &pb.SegmentMetadataUpdateRequest{
+		Bucket:       []byte(bucket),
+		Path:         []byte(path),
+		Segment:      segmentIndex,
+		Metadata:     metadata,
+		CreationDate: creationDate,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			return nil, storage.ErrKeyNotFound.Wrap(err)
+		case codes.FailedPrecondition:
+			return nil, ErrSegmentModified.Wrap(err)
+		}
+		return nil, Error.Wrap(err)
+	}
+
+	return response.GetPointer(), nil
+}
+
 // DeleteSegment requests the order limits for deleting a segment
 func (metainfo *Metainfo) DeleteSegment(ctx context.Context, bucket string, path storj.Path, segmentIndex int64) (limits []*pb.AddressedOrderLimit, err error) {
 	defer mon.Task()(&ctx)(&err)